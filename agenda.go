@@ -0,0 +1,230 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Разделы панели "Сегодня" в порядке показа
+const (
+	agendaInterviews = "Собеседования сегодня"
+	agendaReminders  = "Напоминания"
+	agendaDeadlines  = "Сроки на неделе"
+	agendaFollowUps  = "Ждут ответа"
+)
+
+var agendaSections = []string{agendaInterviews, agendaReminders, agendaDeadlines, agendaFollowUps}
+
+// agendaItem - строка панели "Сегодня"
+type agendaItem struct {
+	Section string
+	When    string
+	What    string
+	Title   string
+	Company string
+	at      time.Time // Для сортировки внутри раздела
+}
+
+// startOfDay - полночь того же дня
+func startOfDay(t time.Time) time.Time {
+	return atTime(t, 0)
+}
+
+// buildAgenda собирает дела на сегодня: собеседования, наступившие напоминания,
+// сроки в ближайшие семь дней и отклики, оставшиеся без ответа
+func buildAgenda(vacancies []Vacancy, now time.Time) []agendaItem {
+	today := startOfDay(now)
+	tomorrow := today.AddDate(0, 0, 1)
+	weekEnd := today.AddDate(0, 0, 7)
+
+	var items []agendaItem
+	for _, v := range vacancies {
+		if !v.InterviewAt.IsZero() && !v.InterviewAt.Before(today) && v.InterviewAt.Before(tomorrow) {
+			items = append(items, agendaItem{
+				Section: agendaInterviews, When: v.InterviewAt.Format("15:04"), What: "Собеседование",
+				Title: v.Title, Company: v.Company, at: v.InterviewAt,
+			})
+		}
+		if !v.DeadlineAt.IsZero() && !v.DeadlineAt.Before(today) && v.DeadlineAt.Before(weekEnd) && !isArchived(v) {
+			what := "Срок отклика"
+			if v.Status == "Тестовое задание" {
+				what = "Срок тестового задания"
+			}
+			items = append(items, agendaItem{
+				Section: agendaDeadlines, When: formatDue(v.DeadlineAt, now), What: what,
+				Title: v.Title, Company: v.Company, at: v.DeadlineAt,
+			})
+		}
+		if t := v.TestTask; t != nil && t.SubmittedAt.IsZero() && !t.DueAt.IsZero() && t.DueAt.Before(weekEnd) {
+			items = append(items, agendaItem{
+				Section: agendaDeadlines, When: formatDue(t.DueAt, now), What: "Сдать тестовое задание",
+				Title: v.Title, Company: v.Company, at: t.DueAt,
+			})
+		}
+		if days, due := followUpDue(v, now); due {
+			items = append(items, agendaItem{
+				Section: agendaFollowUps, When: fmt.Sprintf("%d дн. без ответа", days), What: "Отклик без ответа - напомнить о себе?",
+				Title: v.Title, Company: v.Company, at: lastActivity(v),
+			})
+		}
+	}
+	for _, r := range pendingReminders(vacancies, tomorrow) {
+		items = append(items, agendaItem{
+			Section: agendaReminders, When: formatDue(r.DueAt, now), What: r.Text,
+			Title: r.Title, Company: r.Company, at: r.DueAt,
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		si, sj := indexOfString(agendaSections, items[i].Section), indexOfString(agendaSections, items[j].Section)
+		if si != sj {
+			return si < sj
+		}
+		return items[i].at.Before(items[j].at)
+	})
+	return items
+}
+
+// AgendaModel - таблица панели "Сегодня"
+type AgendaModel struct {
+	walk.TableModelBase
+	items []agendaItem
+}
+
+func (m *AgendaModel) RowCount() int {
+	return len(m.items)
+}
+
+func (m *AgendaModel) Value(row, col int) interface{} {
+	item := m.items[row]
+	switch col {
+	case 0:
+		return item.Section
+	case 1:
+		return item.When
+	case 2:
+		return item.What
+	case 3:
+		return relationLabel(RelatedVacancy{Title: item.Title, Company: item.Company})
+	}
+	return ""
+}
+
+// agendaSummary - строка над таблицей: сколько дел в каждом разделе
+func agendaSummary(items []agendaItem) string {
+	if len(items) == 0 {
+		return "На сегодня дел нет."
+	}
+	counts := map[string]int{}
+	for _, item := range items {
+		counts[item.Section]++
+	}
+	text := ""
+	for _, s := range agendaSections {
+		if counts[s] > 0 {
+			if text != "" {
+				text += " · "
+			}
+			text += fmt.Sprintf("%s: %d", s, counts[s])
+		}
+	}
+	return text
+}
+
+// showAgenda показывает панель "Сегодня"; двойной щелчок по строке открывает вакансию
+func (app *AppMainWindow) showAgenda() {
+	var dlg *walk.Dialog
+	var table *walk.TableView
+	var closePB *walk.PushButton
+	var open agendaItem
+	opening := false
+
+	items := buildAgenda(snapshotVacancies(), time.Now())
+	model := &AgendaModel{items: items}
+	followUpSent := func() {
+		i := table.CurrentIndex()
+		if i < 0 || i >= len(model.items) || model.items[i].Section != agendaFollowUps {
+			walk.MsgBox(dlg, "Сегодня", "Выберите отклик в разделе «"+agendaFollowUps+"».", walk.MsgBoxIconInformation)
+			return
+		}
+		item := model.items[i]
+		if markFollowUpSent(item.Title, item.Company) {
+			model.items = append(model.items[:i], model.items[i+1:]...)
+			model.PublishRowsReset()
+		}
+	}
+	jump := func() {
+		if i := table.CurrentIndex(); i >= 0 && i < len(model.items) {
+			open, opening = model.items[i], true
+			dlg.Accept()
+		}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Сегодня, " + time.Now().Format("02.01.2006"),
+		DefaultButton: &closePB,
+		CancelButton:  &closePB,
+		MinSize:       Size{Width: 720, Height: 380},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: agendaSummary(items), TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 10}},
+			TableView{
+				AssignTo: &table,
+				Model:    model,
+				Columns: []TableViewColumn{
+					{Title: "Раздел", Width: 160},
+					{Title: "Когда", Width: 120},
+					{Title: "Что", Width: 220},
+					{Title: "Вакансия", Width: 200},
+				},
+				OnItemActivated: jump,
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 5},
+				Children: []Widget{
+					CheckBox{
+						Text:    "Показывать при запуске",
+						Checked: !appSettings.HideAgendaOnStartup,
+						OnCheckedChanged: func() {
+							appSettings.HideAgendaOnStartup = !appSettings.HideAgendaOnStartup
+							saveSettings()
+						},
+					},
+					HSpacer{},
+					PushButton{Text: "Все напоминания...", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: func() { dlg.Cancel(); app.Synchronize(app.showRemindersDialog) }},
+					PushButton{Text: "Follow-up отправлен", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: followUpSent},
+					PushButton{Text: "Перейти к вакансии", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: jump},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	if opening && !app.selectVacancy(open.Title, open.Company) {
+		walk.MsgBox(app.MainWindow, "Сегодня", "Вакансия '"+open.Title+"' не найдена в списке.", walk.MsgBoxIconWarning)
+	}
+}
+
+// showStartupAgenda при запуске показывает панель "Сегодня", если на сегодня что-то есть
+func (app *AppMainWindow) showStartupAgenda() {
+	app.markDueRemindersNotified()
+	if appSettings.HideAgendaOnStartup || startup.Minimized || len(buildAgenda(snapshotVacancies(), time.Now())) == 0 {
+		return
+	}
+	app.showAgenda()
+}
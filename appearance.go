@@ -0,0 +1,310 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Темы и цвета статусов. Обычная палитра различает статусы зелёным и красным, что при
+// дейтеранопии сливается, поэтому есть вторая палитра на основе цветов Окабэ-Ито:
+// «Оффер» в ней синий, «Отказ» - оранжево-красный, и пары не путаются ни при каком виде
+// цветовой слепоты. Палитра выбирается независимо от темы.
+
+const (
+	statusPaletteStandard   = ""           // Обычные цвета
+	statusPaletteColorblind = "colorblind" // Для дейтеранопии и протанопии
+)
+
+// statusPalettes - палитры статусов в порядке показа в настройках
+var statusPalettes = []struct {
+	ID   string
+	Name string
+}{
+	{statusPaletteStandard, "Обычная"},
+	{statusPaletteColorblind, "Для дальтоников (без красного и зелёного)"},
+}
+
+// Свои темы - файлы themes/*.json рядом с настройками. Цвета записываются как "#RRGGBB";
+// незаданные берутся из светлой темы или, если "dark": true, из тёмной.
+const themesDir = "themes"
+
+// themeFile - тема в файле
+type themeFile struct {
+	Name       string `json:"name"`
+	Dark       bool   `json:"dark"`
+	Background string `json:"background"`
+	Text       string `json:"text"`
+	ButtonBG   string `json:"button_bg"`
+	ButtonText string `json:"button_text"`
+	TableBG    string `json:"table_bg"`
+	TableText  string `json:"table_text"`
+	PanelBG    string `json:"panel_bg"`
+	Border     string `json:"border"`
+}
+
+// Свои темы из папки themesDir; читаются и меняются только в потоке интерфейса
+var userThemes []Theme
+
+// parseHexColor читает цвет "#RRGGBB"
+func parseHexColor(s string) (walk.Color, error) {
+	hex, ok := strings.CutPrefix(strings.TrimSpace(s), "#")
+	n, err := strconv.ParseUint(hex, 16, 32)
+	if !ok || len(hex) != 6 || err != nil {
+		return 0, fmt.Errorf("цвет %q должен быть в виде #RRGGBB", s)
+	}
+	return walk.RGB(byte(n>>16), byte(n>>8), byte(n)), nil
+}
+
+// parseThemeFile собирает тему из файла поверх светлой или тёмной
+func parseThemeFile(data []byte) (Theme, error) {
+	var f themeFile
+	if err := json.Unmarshal(data, &f); err != nil {
+		return Theme{}, err
+	}
+	if strings.TrimSpace(f.Name) == "" {
+		return Theme{}, fmt.Errorf("не указано имя темы (name)")
+	}
+	t := lightTheme
+	if f.Dark {
+		t = darkTheme
+	}
+	t.Name = strings.TrimSpace(f.Name)
+	for _, c := range []struct {
+		value string
+		dst   *walk.Color
+	}{
+		{f.Background, &t.Background}, {f.Text, &t.Text},
+		{f.ButtonBG, &t.ButtonBG}, {f.ButtonText, &t.ButtonText},
+		{f.TableBG, &t.TableBG}, {f.TableText, &t.TableText},
+		{f.PanelBG, &t.PanelBG}, {f.Border, &t.BorderColor},
+	} {
+		if c.value == "" {
+			continue
+		}
+		color, err := parseHexColor(c.value)
+		if err != nil {
+			return Theme{}, err
+		}
+		*c.dst = color
+	}
+	return t, nil
+}
+
+// loadUserThemes читает свои темы; файлы с ошибками пропускаются и попадают в журнал
+func loadUserThemes() []Theme {
+	paths, _ := filepath.Glob(filepath.Join(themesDir, "*.json"))
+	sort.Strings(paths)
+	var loaded []Theme
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			var t Theme
+			if t, err = parseThemeFile(data); err == nil {
+				loaded = append(loaded, t)
+				continue
+			}
+		}
+		log.Printf("Ошибка чтения темы %s: %v", path, err)
+	}
+	return loaded
+}
+
+// themes - темы в порядке показа в настройках и мастере первого запуска; свои - после встроенных
+func themes() []Theme {
+	list := []Theme{lightTheme, darkTheme, highContrastTheme}
+	for _, t := range userThemes {
+		if !slices.ContainsFunc(list, func(b Theme) bool { return b.Name == t.Name }) {
+			list = append(list, t)
+		}
+	}
+	return list
+}
+
+func themeNames() []string {
+	var names []string
+	for _, t := range themes() {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// themeByName - тема по имени из настроек; неизвестное имя даёт светлую тему
+func themeByName(name string) Theme {
+	for _, t := range themes() {
+		if t.Name == name {
+			return t
+		}
+	}
+	return lightTheme
+}
+
+// statusColorsFor подбирает цвета статусов под тему и выбранную палитру
+func statusColorsFor(theme Theme, palette string) map[string]walk.Color {
+	colorblind := palette == statusPaletteColorblind
+	switch {
+	case theme.Name == highContrastTheme.Name && colorblind:
+		return map[string]walk.Color{
+			"Новая": walk.RGB(86, 180, 233), // голубой
+			"Планирую откликнуться": walk.RGB(240, 228, 66),  // жёлтый
+			"Откликнулся":           walk.RGB(0, 158, 115),   // сине-зелёный
+			"Тестовое задание":      walk.RGB(230, 159, 0),   // оранжевый
+			"Собеседование":         walk.RGB(204, 121, 167), // красно-пурпурный
+			"Оффер":                 walk.RGB(0, 114, 178),   // синий
+			"Отказ":                 walk.RGB(213, 94, 0),    // киноварь
+			"В архиве":              walk.RGB(128, 128, 128), // серый
+		}
+	case theme.Name == highContrastTheme.Name:
+		return map[string]walk.Color{
+			"Новая": walk.RGB(0, 255, 0), // зелёный
+			"Планирую откликнуться": walk.RGB(255, 255, 0),   // жёлтый
+			"Откликнулся":           walk.RGB(0, 255, 255),   // бирюзовый
+			"Тестовое задание":      walk.RGB(255, 165, 0),   // оранжевый
+			"Собеседование":         walk.RGB(255, 0, 255),   // пурпурный
+			"Оффер":                 walk.RGB(255, 255, 255), // белый
+			"Отказ":                 walk.RGB(255, 64, 64),   // красный
+			"В архиве":              walk.RGB(128, 128, 128), // серый
+		}
+	case theme.Dark && colorblind:
+		return map[string]walk.Color{
+			"Новая": walk.RGB(20, 70, 100), // тёмно-голубой
+			"Планирую откликнуться": walk.RGB(90, 85, 10), // тёмно-жёлтый
+			"Откликнулся":           walk.RGB(0, 80, 60),  // тёмный сине-зелёный
+			"Тестовое задание":      walk.RGB(110, 70, 0), // тёмно-оранжевый
+			"Собеседование":         walk.RGB(90, 40, 70), // тёмный красно-пурпурный
+			"Оффер":                 walk.RGB(0, 90, 150), // синий
+			"Отказ":                 walk.RGB(150, 60, 0), // тёмная киноварь
+			"В архиве":              walk.RGB(50, 50, 50), // тёмно-серый
+		}
+	case theme.Dark:
+		return map[string]walk.Color{
+			"Новая": walk.RGB(0, 80, 0), // тёмно-зелёный
+			"Планирую откликнуться": walk.RGB(80, 80, 0),  // тёмно-жёлтый
+			"Откликнулся":           walk.RGB(0, 60, 80),  // тёмно-голубой
+			"Тестовое задание":      walk.RGB(80, 60, 0),  // тёмно-оранжевый
+			"Собеседование":         walk.RGB(60, 0, 80),  // тёмно-пурпурный
+			"Оффер":                 walk.RGB(0, 100, 0),  // насыщенный зелёный
+			"Отказ":                 walk.RGB(80, 0, 0),   // тёмно-красный
+			"В архиве":              walk.RGB(50, 50, 50), // тёмно-серый
+		}
+	case colorblind:
+		return map[string]walk.Color{
+			"Новая": walk.RGB(200, 230, 250), // светло-голубой
+			"Планирую откликнуться": walk.RGB(250, 240, 170), // светло-жёлтый
+			"Откликнулся":           walk.RGB(170, 225, 205), // светлый сине-зелёный
+			"Тестовое задание":      walk.RGB(250, 210, 150), // светло-оранжевый
+			"Собеседование":         walk.RGB(235, 200, 220), // светлый красно-пурпурный
+			"Оффер":                 walk.RGB(0, 114, 178),   // синий
+			"Отказ":                 walk.RGB(230, 140, 80),  // киноварь
+			"В архиве":              walk.RGB(220, 220, 220), // серый
+		}
+	default:
+		return map[string]walk.Color{
+			"Новая": walk.RGB(220, 255, 220), // светло-зелёный
+			"Планирую откликнуться": walk.RGB(255, 255, 200), // светло-жёлтый
+			"Откликнулся":           walk.RGB(210, 240, 255), // светло-голубой
+			"Тестовое задание":      walk.RGB(255, 230, 200), // светло-оранжевый
+			"Собеседование":         walk.RGB(240, 220, 255), // светло-пурпурный
+			"Оффер":                 walk.RGB(180, 255, 180), // ярко-зелёный
+			"Отказ":                 walk.RGB(255, 200, 200), // светло-красный
+			"В архиве":              walk.RGB(220, 220, 220), // серый
+		}
+	}
+}
+
+// readableTextColor - чёрный или белый текст, смотря что лучше читается на фоне
+func readableTextColor(bg walk.Color) walk.Color {
+	// Яркость по весам Rec. 601
+	r, g, b := int(bg.R()), int(bg.G()), int(bg.B())
+	if r*299+g*587+b*114 >= 128*1000 {
+		return walk.RGB(0, 0, 0)
+	}
+	return walk.RGB(255, 255, 255)
+}
+
+// showAppearanceSettings - выбор темы и палитры статусов
+func (app *AppMainWindow) showAppearanceSettings() {
+	var dlg *walk.Dialog
+	var themeCB, paletteCB *walk.ComboBox
+	var acceptPB, cancelPB *walk.PushButton
+
+	paletteNames := make([]string, len(statusPalettes))
+	paletteIndex := 0
+	for i, p := range statusPalettes {
+		paletteNames[i] = p.Name
+		if p.ID == appSettings.StatusPalette {
+			paletteIndex = i
+		}
+	}
+	themeIndex := 0
+	for i, name := range themeNames() {
+		if name == currentTheme.Name {
+			themeIndex = i
+		}
+	}
+	label := func(text string) Label {
+		return Label{Text: text, TextColor: currentTheme.Text, Font: Font{PointSize: 9}}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Оформление",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 420, Height: 200},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true},
+				Children: []Widget{
+					label("Тема:"),
+					ComboBox{AssignTo: &themeCB, Model: themeNames(), CurrentIndex: themeIndex},
+					label("Цвета статусов:"),
+					ComboBox{AssignTo: &paletteCB, Model: paletteNames, CurrentIndex: paletteIndex},
+				},
+			},
+			label("Палитра для дальтоников различает «Оффер» и «Отказ» синим и оранжевым."),
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if i := paletteCB.CurrentIndex(); i >= 0 {
+								appSettings.StatusPalette = statusPalettes[i].ID
+							}
+							appSettings.ThemeName = themeByName(themeCB.Text()).Name
+							saveSettings()
+							app.applySavedTheme()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"slices"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+	"github.com/lxn/win"
+	"golang.org/x/sys/windows"
+)
+
+// Блокировка приложения PIN-кодом или паролем: окно скрывается при запуске, по команде
+// и после заданного простоя, пока не введён PIN. Это защита от случайного взгляда
+// в офисе, а не шифрование: файл вакансий остаётся читаемым.
+type AppLockSettings struct {
+	Enabled     bool   `json:"enabled"`
+	Hash        string `json:"hash,omitempty"`         // PBKDF2-SHA256 от PIN в base64
+	Salt        string `json:"salt,omitempty"`         // Соль в base64
+	IdleMinutes int    `json:"idle_minutes,omitempty"` // Блокировать после простоя, 0 - только при запуске и по команде
+}
+
+const (
+	lockHashIterations = 200000
+	lockCheckInterval  = 15 * time.Second
+
+	lockFreeAttempts  = 3                // Ошибок PIN без задержки
+	lockMaxRetryDelay = 30 * time.Second // Предельная пауза после ошибок
+)
+
+// failedUnlocks - неверные PIN подряд за сеанс; после lockFreeAttempts ввод
+// блокируется на удваивающуюся паузу
+var failedUnlocks int
+
+// unlockRetryDelay - пауза перед следующей попыткой после failed ошибок подряд
+func unlockRetryDelay(failed int) time.Duration {
+	if failed < lockFreeAttempts {
+		return 0
+	}
+	delay := time.Second
+	for i := lockFreeAttempts; i < failed && delay < lockMaxRetryDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, lockMaxRetryDelay)
+}
+
+var (
+	procGetLastInputInfo = windows.NewLazySystemDLL("user32.dll").NewProc("GetLastInputInfo")
+	procGetTickCount     = windows.NewLazySystemDLL("kernel32.dll").NewProc("GetTickCount")
+)
+
+// lastInputInfo - LASTINPUTINFO из WinAPI
+type lastInputInfo struct {
+	cbSize uint32
+	dwTime uint32
+}
+
+// idleDuration - сколько времени в системе не трогали клавиатуру и мышь
+func idleDuration() time.Duration {
+	info := lastInputInfo{cbSize: uint32(unsafe.Sizeof(lastInputInfo{}))}
+	if r, _, _ := procGetLastInputInfo.Call(uintptr(unsafe.Pointer(&info))); r == 0 {
+		return 0
+	}
+	now, _, _ := procGetTickCount.Call()
+	return time.Duration(uint32(now)-info.dwTime) * time.Millisecond
+}
+
+// hashLockSecret - хэш PIN с солью
+func hashLockSecret(secret string, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, secret, salt, lockHashIterations, 32)
+}
+
+// setLockSecret запоминает хэш нового PIN
+func (s *AppLockSettings) setLockSecret(secret string) error {
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	hash, err := hashLockSecret(secret, salt)
+	if err != nil {
+		return err // Без хэша блокировку не включаем: пустой хэш открывался бы любым PIN
+	}
+	s.Salt = base64.StdEncoding.EncodeToString(salt)
+	s.Hash = base64.StdEncoding.EncodeToString(hash)
+	return nil
+}
+
+// checkLockSecret проверяет введённый PIN
+func (s AppLockSettings) checkLockSecret(secret string) bool {
+	salt, err1 := base64.StdEncoding.DecodeString(s.Salt)
+	want, err2 := base64.StdEncoding.DecodeString(s.Hash)
+	if err1 != nil || err2 != nil || len(want) == 0 {
+		return false
+	}
+	got, err := hashLockSecret(secret, salt)
+	if err != nil {
+		log.Printf("Ошибка вычисления хэша PIN: %v", err)
+		return false
+	}
+	return subtle.ConstantTimeCompare(got, want) == 1
+}
+
+// unlockDialog - открытый запрос PIN, чтобы повторные попытки открыть окно выводили его вперёд
+var unlockDialog *walk.Dialog
+
+// activateUnlockDialog выводит вперёд уже открытый запрос PIN
+func activateUnlockDialog() {
+	if unlockDialog != nil {
+		unlockDialog.Activate()
+	}
+}
+
+// askUnlock спрашивает PIN; false - пользователь отказался (выход из приложения)
+func askUnlock(owner walk.Form, title string) bool {
+	var dlg *walk.Dialog
+	var secretLE *walk.LineEdit
+	var errorLabel *walk.Label
+	var unlockPB, exitPB *walk.PushButton
+	unlocked := false
+
+	if err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         title,
+		DefaultButton: &unlockPB,
+		CancelButton:  &exitPB,
+		MinSize:       Size{Width: 340, Height: 170},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Поисковик Вакансий заблокирован. Введите PIN или пароль:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			LineEdit{AssignTo: &secretLE, PasswordMode: true, Font: Font{PointSize: 10}},
+			Label{AssignTo: &errorLabel, TextColor: walk.RGB(200, 40, 40), Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &unlockPB,
+						Text:       "Разблокировать",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if !appSettings.AppLock.checkLockSecret(secretLE.Text()) {
+								failedUnlocks++
+								log.Printf("Неверный PIN при разблокировке (%d подряд)", failedUnlocks)
+								secretLE.SetText("")
+								delay := unlockRetryDelay(failedUnlocks)
+								if delay == 0 {
+									errorLabel.SetText("Неверный PIN")
+									secretLE.SetFocus()
+									return
+								}
+								errorLabel.SetText(fmt.Sprintf("Неверный PIN. Следующая попытка через %d с", int(delay.Seconds())))
+								unlockPB.SetEnabled(false)
+								secretLE.SetEnabled(false)
+								time.AfterFunc(delay, func() {
+									dlg.Synchronize(func() {
+										if dlg.IsDisposed() {
+											return
+										}
+										errorLabel.SetText("Неверный PIN")
+										unlockPB.SetEnabled(true)
+										secretLE.SetEnabled(true)
+										secretLE.SetFocus()
+									})
+								})
+								return
+							}
+							failedUnlocks = 0
+							unlocked = true
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &exitPB,
+						Text:       "Выход",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(owner); err != nil {
+		log.Print("Dialog error: ", err)
+		return false // Без диалога PIN не проверить - считаем отказом
+	}
+	unlockDialog = dlg
+	defer func() { unlockDialog = nil }()
+	dlg.Run()
+	return unlocked
+}
+
+// unlockAtStartup спрашивает PIN до загрузки вакансий; при отказе приложение закрывается
+func unlockAtStartup() bool {
+	if !appSettings.AppLock.Enabled || appSettings.AppLock.Hash == "" {
+		return true
+	}
+	return askUnlock(nil, "Поисковик Вакансий")
+}
+
+// visibleWindowsFound собирает окна для visibleProcessWindows; обход идёт в потоке окна
+var visibleWindowsFound []win.HWND
+
+var enumVisibleWindowsCallback = syscall.NewCallback(func(hwnd, _ uintptr) uintptr {
+	var pid uint32
+	windows.GetWindowThreadProcessId(windows.HWND(hwnd), &pid)
+	if pid == windows.GetCurrentProcessId() && win.IsWindowVisible(win.HWND(hwnd)) {
+		visibleWindowsFound = append(visibleWindowsFound, win.HWND(hwnd))
+	}
+	return 1 // Продолжить обход
+})
+
+// visibleProcessWindows - видимые окна верхнего уровня приложения: главное, отдельные
+// окна вакансий и открытые диалоги
+func visibleProcessWindows() []win.HWND {
+	visibleWindowsFound = nil
+	if err := windows.EnumWindows(enumVisibleWindowsCallback, nil); err != nil {
+		log.Printf("Ошибка перечисления окон: %v", err)
+	}
+	found := visibleWindowsFound
+	visibleWindowsFound = nil
+	return found
+}
+
+// lockApp скрывает все окна приложения, включая открытые диалоги, до ввода PIN
+func (app *AppMainWindow) lockApp() {
+	if app.locked || !appSettings.AppLock.Enabled || appSettings.AppLock.Hash == "" {
+		return
+	}
+	app.locked = true
+	log.Print("Приложение заблокировано")
+	active := win.GetForegroundWindow()
+	hidden := visibleProcessWindows()
+	for _, h := range hidden {
+		win.ShowWindow(h, win.SW_HIDE)
+	}
+	if !askUnlock(nil, "Поисковик Вакансий") {
+		app.locked = false
+		app.MainWindow.Close()
+		return
+	}
+	app.locked = false
+	for _, h := range hidden {
+		win.ShowWindow(h, win.SW_SHOWNA) // В прежнем состоянии, свёрнутые остаются свёрнутыми
+	}
+	if slices.Contains(hidden, active) {
+		win.SetForegroundWindow(active) // Например, диалог, который был открыт при блокировке
+	} else {
+		app.MainWindow.Activate()
+	}
+	app.replayLaunchArgs()
+}
+
+// startIdleLock блокирует приложение после простоя из настроек. Настройки читаются
+// в потоке окна: их меняют диалоги, а фоновый таймер только будит проверку.
+func (app *AppMainWindow) startIdleLock() {
+	go func() {
+		for range time.Tick(lockCheckInterval) {
+			app.Synchronize(func() {
+				s := appSettings.AppLock
+				if !s.Enabled || s.IdleMinutes <= 0 || idleDuration() < time.Duration(s.IdleMinutes)*time.Minute {
+					return
+				}
+				app.lockApp()
+			})
+		}
+	}()
+}
+
+// showAppLockSettings включает блокировку и задаёт PIN. Чтобы изменить или снять её, нужен текущий PIN.
+func (app *AppMainWindow) showAppLockSettings() {
+	s := appSettings.AppLock
+	if s.Enabled && s.Hash != "" && !askUnlock(app.MainWindow, "Текущий PIN") {
+		return
+	}
+
+	var dlg *walk.Dialog
+	var enabledCB *walk.CheckBox
+	var secretLE, confirmLE *walk.LineEdit
+	var idleNE *walk.NumberEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	label := func(text string) Label {
+		return Label{Text: text, TextColor: currentTheme.Text, Font: Font{PointSize: 9}}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Блокировка приложения",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 420, Height: 280},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			CheckBox{AssignTo: &enabledCB, Text: "Спрашивать PIN при запуске и после простоя", Checked: s.Enabled},
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true},
+				Children: []Widget{
+					label("Новый PIN или пароль:"),
+					LineEdit{AssignTo: &secretLE, PasswordMode: true, CueBanner: "оставьте пустым, чтобы не менять"},
+					label("Ещё раз:"),
+					LineEdit{AssignTo: &confirmLE, PasswordMode: true},
+					label("Блокировать после простоя, мин (0 - нет):"),
+					NumberEdit{AssignTo: &idleNE, Value: float64(s.IdleMinutes), MinValue: 0, MaxValue: 600, SpinButtonsVisible: true},
+				},
+			},
+			label("Это не шифрование: файл вакансий на диске остаётся читаемым.\nЗаблокировать сразу - Ctrl+Shift+L."),
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if secretLE.Text() != confirmLE.Text() {
+								walk.MsgBox(dlg, "Блокировка", "PIN и повтор не совпадают.", walk.MsgBoxIconWarning)
+								return
+							}
+							if secretLE.Text() != "" {
+								if err := s.setLockSecret(secretLE.Text()); err != nil {
+									log.Printf("Ошибка сохранения PIN: %v", err)
+									showError(dlg, "Ошибка", wrapError("Не удалось сохранить PIN", err))
+									return
+								}
+							}
+							if enabledCB.Checked() && s.Hash == "" {
+								walk.MsgBox(dlg, "Блокировка", "Задайте PIN или пароль.", walk.MsgBoxIconWarning)
+								return
+							}
+							s.Enabled = enabledCB.Checked()
+							s.IdleMinutes = int(idleNE.Value())
+							appSettings.AppLock = s
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+)
+
+// Статус вакансий, убранных в архив вручную
+const archivedStatus = "В архиве"
+
+// isArchived - вакансия в архиве или с отказом: по умолчанию такие не показываются в списке
+func isArchived(v Vacancy) bool {
+	return v.Status == archivedStatus || v.Status == rejectedStatus
+}
+
+// hideArchived убирает из списка архивные вакансии, если архив не показывается.
+// Статусы, выбранные явно - кнопкой над таблицей или поиском "По статусу", - не скрываются.
+func (app *AppMainWindow) hideArchived(items []Vacancy, statusTerm string) []Vacancy {
+	if appSettings.ShowArchived {
+		return items
+	}
+	requested := app.selectedChipStatuses()
+	filtered := make([]Vacancy, 0, len(items))
+	for _, v := range items {
+		if !isArchived(v) || requested[v.Status] || (statusTerm != "" && v.Status == statusTerm) {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// toggleShowArchived включает и выключает показ архива
+func (app *AppMainWindow) toggleShowArchived() {
+	appSettings.ShowArchived = app.showArchiveAction.Checked()
+	saveSettings()
+	app.performSearch()
+}
+
+// updateTotalsLabel показывает в нижней панели, сколько всего вакансий, включая скрытые архивные
+func (app *AppMainWindow) updateTotalsLabel(vacancies []Vacancy) {
+	if app.totalsLabel == nil {
+		return
+	}
+	archived := 0
+	for _, v := range vacancies {
+		if isArchived(v) {
+			archived++
+		}
+	}
+	text := fmt.Sprintf("Всего вакансий: %d", len(vacancies))
+	if archived > 0 {
+		text += fmt.Sprintf(", в архиве и с отказом: %d", archived)
+		if !appSettings.ShowArchived {
+			text += " (скрыты)"
+		}
+	}
+	app.totalsLabel.SetText(text)
+}
@@ -0,0 +1,505 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unsafe"
+
+	"github.com/lxn/walk"
+	"github.com/lxn/win"
+	"golang.org/x/sys/windows"
+)
+
+// Папка с вложениями внутри папки данных
+const attachmentsDir = "attachments"
+
+// Размер миниатюры в галерее панели деталей
+const (
+	thumbWidth  = 120
+	thumbHeight = 90
+)
+
+// Форматы, которые можно прикрепить из файла
+var attachmentExts = []string{".png", ".jpg", ".jpeg", ".gif"}
+
+// Attachment - файл, прикреплённый к вакансии: скриншот оффера, переписки, сгенерированное резюме и т.п.
+type Attachment struct {
+	File    string    `json:"file"`              // Имя файла в папке вложений
+	Caption string    `json:"caption,omitempty"` // Исходное имя файла или "Из буфера обмена"
+	AddedAt time.Time `json:"addedAt,omitzero"`
+	OCRText string    `json:"ocrText,omitempty"` // Распознанный текст, уже добавленный в описание
+}
+
+// attachmentPath - полный путь к файлу вложения
+func attachmentPath(a Attachment) string {
+	return filepath.Join(dataPath(attachmentsDir), a.File)
+}
+
+// isImageAttachment - можно ли показать вложение миниатюрой
+func isImageAttachment(a Attachment) bool {
+	return slices.Contains(attachmentExts, strings.ToLower(filepath.Ext(a.File)))
+}
+
+// newAttachmentName возвращает имя файла, уникальное в папке вложений
+func newAttachmentName(ext string) string {
+	return time.Now().Format("20060102-150405") + "-" + strconv.FormatInt(time.Now().UnixNano()%1e6, 36) + strings.ToLower(ext)
+}
+
+// storeAttachmentFile копирует файл изображения в папку вложений
+func storeAttachmentFile(src string) (Attachment, error) {
+	ext := strings.ToLower(filepath.Ext(src))
+	if !slices.Contains(attachmentExts, ext) {
+		return Attachment{}, fmt.Errorf("формат %s не поддерживается", ext)
+	}
+	in, err := os.Open(src)
+	if err != nil {
+		return Attachment{}, err
+	}
+	defer in.Close()
+	if _, _, err := image.DecodeConfig(in); err != nil {
+		return Attachment{}, fmt.Errorf("файл не похож на изображение: %w", err)
+	}
+	if _, err := in.Seek(0, io.SeekStart); err != nil {
+		return Attachment{}, err
+	}
+
+	if err := os.MkdirAll(dataPath(attachmentsDir), 0o755); err != nil {
+		return Attachment{}, err
+	}
+	a := Attachment{File: newAttachmentName(ext), Caption: filepath.Base(src), AddedAt: time.Now()}
+	out, err := os.Create(attachmentPath(a))
+	if err != nil {
+		return Attachment{}, err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		os.Remove(attachmentPath(a))
+		return Attachment{}, err
+	}
+	return a, out.Close()
+}
+
+// storeAttachmentImage сохраняет изображение (из буфера обмена) в папку вложений как PNG
+func storeAttachmentImage(img image.Image, caption string) (Attachment, error) {
+	if err := os.MkdirAll(dataPath(attachmentsDir), 0o755); err != nil {
+		return Attachment{}, err
+	}
+	a := Attachment{File: newAttachmentName(".png"), Caption: caption, AddedAt: time.Now()}
+	f, err := os.Create(attachmentPath(a))
+	if err != nil {
+		return Attachment{}, err
+	}
+	if err := png.Encode(f, img); err != nil {
+		f.Close()
+		os.Remove(attachmentPath(a))
+		return Attachment{}, err
+	}
+	return a, f.Close()
+}
+
+// storeAttachmentData сохраняет сгенерированный документ в папку вложений
+func storeAttachmentData(data []byte, ext, caption string) (Attachment, error) {
+	if err := os.MkdirAll(dataPath(attachmentsDir), 0o755); err != nil {
+		return Attachment{}, err
+	}
+	a := Attachment{File: newAttachmentName(ext), Caption: caption, AddedAt: time.Now()}
+	if err := os.WriteFile(attachmentPath(a), data, 0o644); err != nil {
+		os.Remove(attachmentPath(a))
+		return Attachment{}, err
+	}
+	return a, nil
+}
+
+// decodeDIB разбирает CF_DIB из буфера обмена: BITMAPINFOHEADER и пиксели 24 или 32 бита без сжатия
+func decodeDIB(data []byte) (image.Image, error) {
+	if len(data) < 40 {
+		return nil, errors.New("слишком короткий заголовок DIB")
+	}
+	headerSize := int(binary.LittleEndian.Uint32(data[0:]))
+	width := int(int32(binary.LittleEndian.Uint32(data[4:])))
+	height := int(int32(binary.LittleEndian.Uint32(data[8:])))
+	bitCount := int(binary.LittleEndian.Uint16(data[14:]))
+	compression := binary.LittleEndian.Uint32(data[16:])
+	colorsUsed := int(binary.LittleEndian.Uint32(data[32:]))
+
+	const biRGB, biBitfields = 0, 3
+	if bitCount != 24 && bitCount != 32 {
+		return nil, fmt.Errorf("глубина цвета %d бит не поддерживается", bitCount)
+	}
+	if compression != biRGB && !(compression == biBitfields && bitCount == 32) {
+		return nil, fmt.Errorf("сжатие DIB %d не поддерживается", compression)
+	}
+	topDown := height < 0
+	if topDown {
+		height = -height
+	}
+	if width <= 0 || height <= 0 || width > 20000 || height > 20000 {
+		return nil, fmt.Errorf("некорректный размер изображения %dx%d", width, height)
+	}
+
+	offset := headerSize + colorsUsed*4
+	if compression == biBitfields && headerSize == 40 {
+		offset += 12 // Маски каналов сразу после заголовка
+	}
+	bytesPerPixel := bitCount / 8
+	stride := (width*bytesPerPixel + 3) &^ 3
+	if offset+stride*height > len(data) {
+		return nil, errors.New("данные DIB обрезаны")
+	}
+
+	// У 32-битных DIB альфа-канал часто пустой: тогда считаем изображение непрозрачным
+	hasAlpha := false
+	if bitCount == 32 {
+		for y := 0; y < height && !hasAlpha; y++ {
+			row := data[offset+y*stride:]
+			for x := 0; x < width; x++ {
+				if row[x*4+3] != 0 {
+					hasAlpha = true
+					break
+				}
+			}
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		srcY := height - 1 - y
+		if topDown {
+			srcY = y
+		}
+		row := data[offset+srcY*stride:]
+		for x := 0; x < width; x++ {
+			p := row[x*bytesPerPixel:]
+			a := uint8(255)
+			if hasAlpha {
+				a = p[3]
+			}
+			img.SetNRGBA(x, y, color.NRGBA{R: p[2], G: p[1], B: p[0], A: a})
+		}
+	}
+	return img, nil
+}
+
+var procGlobalSize = windows.NewLazySystemDLL("kernel32.dll").NewProc("GlobalSize")
+
+// clipboardImage читает изображение из буфера обмена
+func clipboardImage(owner walk.Form) (image.Image, error) {
+	if !win.IsClipboardFormatAvailable(win.CF_DIB) {
+		return nil, errors.New("в буфере обмена нет изображения")
+	}
+	if !win.OpenClipboard(owner.Handle()) {
+		return nil, errors.New("не удалось открыть буфер обмена")
+	}
+	defer win.CloseClipboard()
+
+	h := win.GetClipboardData(win.CF_DIB)
+	if h == 0 {
+		return nil, errors.New("не удалось получить изображение из буфера обмена")
+	}
+	size, _, _ := procGlobalSize.Call(uintptr(h))
+	p := win.GlobalLock(win.HGLOBAL(h))
+	if p == nil || size == 0 {
+		return nil, errors.New("не удалось прочитать изображение из буфера обмена")
+	}
+	defer win.GlobalUnlock(win.HGLOBAL(h))
+	data := make([]byte, size)
+	copy(data, unsafe.Slice((*byte)(p), size))
+	return decodeDIB(data)
+}
+
+// thumbnail уменьшает изображение, чтобы оно помещалось в w x h, усредняя пиксели
+func thumbnail(src image.Image, w, h int) image.Image {
+	b := src.Bounds()
+	scale := min(float64(w)/float64(b.Dx()), float64(h)/float64(b.Dy()), 1)
+	tw, th := max(int(float64(b.Dx())*scale), 1), max(int(float64(b.Dy())*scale), 1)
+	dst := image.NewNRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := b.Min.Y+y*b.Dy()/th, b.Min.Y+(y+1)*b.Dy()/th
+		for x := 0; x < tw; x++ {
+			x0, x1 := b.Min.X+x*b.Dx()/tw, b.Min.X+(x+1)*b.Dx()/tw
+			var r, g, bl, a, n uint64
+			// Для больших картинок берём не больше 4x4 точек из каждой клетки
+			stepY, stepX := max((y1-y0)/4, 1), max((x1-x0)/4, 1)
+			for sy := y0; sy < max(y1, y0+1); sy += stepY {
+				for sx := x0; sx < max(x1, x0+1); sx += stepX {
+					c := color.NRGBAModel.Convert(src.At(sx, sy)).(color.NRGBA)
+					r, g, bl, a, n = r+uint64(c.R), g+uint64(c.G), bl+uint64(c.B), a+uint64(c.A), n+1
+				}
+			}
+			dst.SetNRGBA(x, y, color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(bl / n), A: uint8(a / n)})
+		}
+	}
+	return dst
+}
+
+// attachmentThumbnail возвращает миниатюру вложения, загружая её один раз
+func (app *AppMainWindow) attachmentThumbnail(a Attachment) (*walk.Bitmap, error) {
+	if bmp, ok := app.thumbnails[a.File]; ok {
+		return bmp, nil
+	}
+	f, err := os.Open(attachmentPath(a))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	img, _, err := image.Decode(f)
+	if err != nil {
+		return nil, err
+	}
+	bmp, err := walk.NewBitmapFromImageForDPI(thumbnail(img, thumbWidth, thumbHeight), 96)
+	if err != nil {
+		return nil, err
+	}
+	if app.thumbnails == nil {
+		app.thumbnails = map[string]*walk.Bitmap{}
+	}
+	app.thumbnails[a.File] = bmp
+	return bmp, nil
+}
+
+// changeAttachments меняет вложения вакансии и сохраняет файл
+func changeAttachments(title, company string, change func(list []Attachment) []Attachment) bool {
+	allVacanciesMutex.Lock()
+	found := false
+	for i := range allVacancies {
+		if sameVacancy(allVacancies[i].Title, allVacancies[i].Company, title, company) {
+			allVacancies[i].Attachments = change(allVacancies[i].Attachments)
+			found = true
+			break
+		}
+	}
+	allVacanciesMutex.Unlock()
+	if found {
+		saveVacancies()
+		vacancyEvents.Publish(VacancyEvent{Kind: VacancyUpdated, Title: title, Company: company})
+	}
+	return found
+}
+
+// selectedVacancyForAttachments - выбранная вакансия или сообщение, что её нет
+func (app *AppMainWindow) selectedVacancyForAttachments() (Vacancy, bool) {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		walk.MsgBox(app.MainWindow, "Вложения", "Пожалуйста, выберите вакансию.", walk.MsgBoxIconInformation)
+		return Vacancy{}, false
+	}
+	return app.vacancyAt(idx)
+}
+
+// attachToSelected прикрепляет вложение к выбранной вакансии и обновляет галерею
+func (app *AppMainWindow) attachToSelected(v Vacancy, a Attachment) {
+	if !changeAttachments(v.Title, v.Company, func(list []Attachment) []Attachment { return append(list, a) }) {
+		os.Remove(attachmentPath(a))
+		walk.MsgBox(app.MainWindow, "Ошибка", "Не удалось найти вакансию.", walk.MsgBoxIconError)
+	}
+}
+
+// addAttachmentFiles прикрепляет изображения из файлов
+func (app *AppMainWindow) addAttachmentFiles() {
+	v, ok := app.selectedVacancyForAttachments()
+	if !ok {
+		return
+	}
+	dlg := new(walk.FileDialog)
+	dlg.Title = "Прикрепить изображения"
+	dlg.Filter = "Изображения (*.png;*.jpg;*.jpeg;*.gif)|*.png;*.jpg;*.jpeg;*.gif"
+	if ok, err := dlg.ShowOpenMultiple(app.MainWindow); err != nil || !ok {
+		return
+	}
+	var added []Attachment
+	err := runWithProgress(app.MainWindow, "Прикрепление изображений", func(ctx context.Context) error {
+		progressTotal(ctx, len(dlg.FilePaths))
+		for _, path := range dlg.FilePaths {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			progressStep(ctx, filepath.Base(path))
+			a, err := storeAttachmentFile(path)
+			if err != nil {
+				progressFail(ctx, filepath.Base(path), err)
+				continue
+			}
+			added = append(added, a)
+		}
+		return nil
+	})
+	if err != nil {
+		// Прерванное прикрепление не оставляет скопированных файлов
+		for _, a := range added {
+			os.Remove(attachmentPath(a))
+		}
+		return
+	}
+	if len(added) == 0 {
+		return
+	}
+	if !changeAttachments(v.Title, v.Company, func(list []Attachment) []Attachment { return append(list, added...) }) {
+		for _, a := range added {
+			os.Remove(attachmentPath(a))
+		}
+	}
+}
+
+// pasteAttachment прикрепляет изображение из буфера обмена
+func (app *AppMainWindow) pasteAttachment() {
+	v, ok := app.selectedVacancyForAttachments()
+	if !ok {
+		return
+	}
+	img, err := clipboardImage(app.MainWindow)
+	if err != nil {
+		walk.MsgBox(app.MainWindow, "Вложения", err.Error()+".", walk.MsgBoxIconInformation)
+		return
+	}
+	a, err := storeAttachmentImage(img, "Из буфера обмена")
+	if err != nil {
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось сохранить изображение", err))
+		return
+	}
+	app.attachToSelected(v, a)
+}
+
+// removeAttachment удаляет вложение вместе с файлом
+func (app *AppMainWindow) removeAttachment(v Vacancy, a Attachment) {
+	if walk.MsgBox(app.MainWindow, "Вложения", fmt.Sprintf("Удалить вложение «%s»?", a.Caption), walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+		return
+	}
+	changeAttachments(v.Title, v.Company, func(list []Attachment) []Attachment {
+		for i := range list {
+			if list[i].File == a.File {
+				return append(list[:i], list[i+1:]...)
+			}
+		}
+		return list
+	})
+	if err := os.Remove(attachmentPath(a)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Не удалось удалить файл вложения %s: %v", a.File, err)
+	}
+	if bmp, ok := app.thumbnails[a.File]; ok {
+		bmp.Dispose()
+		delete(app.thumbnails, a.File)
+	}
+}
+
+// updateAttachmentsStrip перестраивает галерею миниатюр в панели деталей.
+// Щелчок открывает изображение, правая кнопка - меню с распознаванием текста и удалением.
+func (app *AppMainWindow) updateAttachmentsStrip(v Vacancy, hasSelection bool) {
+	if app.attachmentsStrip == nil || app.attachmentsLabel == nil {
+		return
+	}
+	app.attachmentsStrip.SetSuspended(true)
+	defer app.attachmentsStrip.SetSuspended(false)
+
+	children := app.attachmentsStrip.Children()
+	for children.Len() > 0 {
+		children.At(0).Dispose()
+	}
+	for _, m := range app.attachmentMenus {
+		m.Dispose()
+	}
+	app.attachmentMenus = nil
+	if !hasSelection {
+		app.attachmentsLabel.SetText("Вложения:")
+		return
+	}
+	app.attachmentsLabel.SetText(fmt.Sprintf("Вложения (%d):", len(v.Attachments)))
+
+	for _, a := range v.Attachments {
+		a := a
+		tile, err := app.attachmentTile(a)
+		if err != nil {
+			log.Printf("Не удалось создать миниатюру: %v", err)
+			continue
+		}
+		tile.SetMinMaxSize(walk.Size{Width: thumbWidth, Height: thumbHeight}, walk.Size{Width: thumbWidth, Height: thumbHeight})
+		open := func() {
+			if err := openURL(attachmentPath(a)); err != nil {
+				showError(app.MainWindow, "Ошибка", wrapError("Не удалось открыть вложение", err))
+			}
+		}
+		tile.MouseDown().Attach(func(x, y int, button walk.MouseButton) {
+			if button == walk.LeftButton {
+				open()
+			}
+		})
+		// Распознавание и удаление пересоздают галерею вместе с этой миниатюрой, поэтому откладываем их
+		items := []attachmentMenuItem{{"Открыть", open}}
+		if isImageAttachment(a) {
+			items = append(items, attachmentMenuItem{"Распознать текст в описание", func() { app.MainWindow.Synchronize(func() { app.recognizeAttachment(v, a) }) }})
+		}
+		items = append(items, attachmentMenuItem{"Удалить", func() { app.MainWindow.Synchronize(func() { app.removeAttachment(v, a) }) }})
+		if menu, err := attachmentMenu(items); err == nil {
+			tile.SetContextMenu(menu)
+			app.attachmentMenus = append(app.attachmentMenus, menu)
+		}
+	}
+}
+
+// attachmentTile создаёт элемент галереи: миниатюру изображения или подпись документа
+func (app *AppMainWindow) attachmentTile(a Attachment) (walk.Widget, error) {
+	tooltip := a.Caption + ", " + a.AddedAt.Format("02.01.2006 15:04") + "\nЩелчок - открыть, правая кнопка - меню"
+	if !isImageAttachment(a) {
+		lbl, err := walk.NewLabelWithStyle(app.attachmentsStrip, win.SS_CENTER|win.SS_NOTIFY)
+		if err != nil {
+			return nil, err
+		}
+		// Вместо миниатюры - тип файла и подпись
+		lbl.SetText(strings.ToUpper(strings.TrimPrefix(filepath.Ext(a.File), ".")) + "\r\n\r\n" + a.Caption)
+		lbl.SetToolTipText(tooltip)
+		if _, err := os.Stat(attachmentPath(a)); err != nil {
+			lbl.SetToolTipText(a.Caption + ": файл не найден")
+		}
+		return lbl, nil
+	}
+	iv, err := walk.NewImageView(app.attachmentsStrip)
+	if err != nil {
+		return nil, err
+	}
+	iv.SetMode(walk.ImageViewModeCenter)
+	iv.SetToolTipText(tooltip)
+	if bmp, err := app.attachmentThumbnail(a); err == nil {
+		iv.SetImage(bmp)
+	} else {
+		log.Printf("Не удалось загрузить вложение %s: %v", a.File, err)
+		iv.SetToolTipText(a.Caption + ": файл не найден или повреждён")
+	}
+	return iv, nil
+}
+
+type attachmentMenuItem struct {
+	Text      string
+	Triggered func()
+}
+
+// attachmentMenu собирает контекстное меню миниатюры
+func attachmentMenu(items []attachmentMenuItem) (*walk.Menu, error) {
+	menu, err := walk.NewMenu()
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range items {
+		action := walk.NewAction()
+		action.SetText(item.Text)
+		action.Triggered().Attach(item.Triggered)
+		if err := menu.Actions().Add(action); err != nil {
+			menu.Dispose()
+			return nil, err
+		}
+	}
+	return menu, nil
+}
@@ -0,0 +1,430 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Журнал изменений: каждое добавление, изменение и удаление вакансии дописывается
+// строкой JSON в audit.jsonl и никогда не переписывается. Удалённая вакансия
+// сохраняется в записи целиком, поэтому её можно вернуть из окна журнала.
+const auditFile = "audit.jsonl"
+
+// Действия в журнале
+const (
+	auditAdded   = "Добавлена"
+	auditChanged = "Изменена"
+	auditDeleted = "Удалена"
+)
+
+// AuditChange - изменение одного поля
+type AuditChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// AuditEntry - запись журнала изменений
+type AuditEntry struct {
+	At      time.Time     `json:"at"`
+	User    string        `json:"user"`
+	Action  string        `json:"action"`
+	Title   string        `json:"title"`
+	Company string        `json:"company"`
+	Changes []AuditChange `json:"changes,omitempty"`
+	Deleted *Vacancy      `json:"deleted,omitempty"` // Удалённая вакансия целиком
+}
+
+// Поля, изменения которых попадают в журнал
+var auditFields = []struct {
+	Name string
+	Get  func(Vacancy) string
+}{
+	{"Название", func(v Vacancy) string { return v.Title }},
+	{"Компания", func(v Vacancy) string { return v.Company }},
+	{"Статус", func(v Vacancy) string { return v.Status }},
+	{"Зарплата", func(v Vacancy) string { return v.Salary }},
+	{"Опыт", func(v Vacancy) string { return v.ExperienceLevel }},
+	{"Формат работы", func(v Vacancy) string { return v.WorkFormat }},
+	{"Город", func(v Vacancy) string { return v.Location }},
+	{"Источник", func(v Vacancy) string { return v.Source }},
+	{"Ссылка", func(v Vacancy) string { return v.SourceURL }},
+	{"Рекрутер", func(v Vacancy) string { return v.Recruiter }},
+	{"Почта рекрутера", func(v Vacancy) string { return v.RecruiterEmail }},
+	{"Ключевые слова", func(v Vacancy) string { return strings.Join(v.Keywords, ", ") }},
+	{"Срок", func(v Vacancy) string { return formatAuditDate(v.DeadlineAt) }},
+	{"Причина отказа", func(v Vacancy) string { return v.RejectionReason }},
+	{"Резюме", func(v Vacancy) string { return v.ResumeFileName }},
+	{"Описание", func(v Vacancy) string { return v.Description }},
+	{"Заметки", func(v Vacancy) string { return v.Notes }},
+}
+
+func formatAuditDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("02.01.2006")
+}
+
+// auditUser - имя пользователя Windows, от которого работает приложение
+func auditUser() string {
+	if u, err := user.Current(); err == nil {
+		return u.Username
+	}
+	return os.Getenv("USERNAME")
+}
+
+// diffVacancies - изменившиеся поля вакансии
+func diffVacancies(old, cur Vacancy) []AuditChange {
+	var changes []AuditChange
+	for _, f := range auditFields {
+		if o, n := f.Get(old), f.Get(cur); o != n {
+			changes = append(changes, AuditChange{Field: f.Name, Old: o, New: n})
+		}
+	}
+	return changes
+}
+
+// appendAuditEntries дописывает записи в конец журнала
+func appendAuditEntries(entries []AuditEntry) {
+	if len(entries) == 0 || viewOnly {
+		return
+	}
+	f, err := os.OpenFile(dataPath(auditFile), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		log.Printf("Ошибка записи журнала изменений: %v", err)
+		return
+	}
+	defer f.Close()
+	enc := json.NewEncoder(f)
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			log.Printf("Ошибка записи журнала изменений: %v", err)
+			return
+		}
+	}
+}
+
+// loadAuditEntries читает журнал, новые записи первыми. Повреждённые строки пропускаются.
+func loadAuditEntries() ([]AuditEntry, error) {
+	f, err := os.Open(dataPath(auditFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var entries []AuditEntry
+	sc := bufio.NewScanner(f)
+	sc.Buffer(make([]byte, 64*1024), 16<<20) // Удалённая вакансия с длинным описанием - одна длинная строка
+	for sc.Scan() {
+		var e AuditEntry
+		if err := json.Unmarshal(sc.Bytes(), &e); err != nil {
+			log.Printf("Пропущена повреждённая строка журнала изменений: %v", err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	return entries, sc.Err()
+}
+
+// auditTracker помнит вакансии на момент прошлого события, чтобы найти, что изменилось
+type auditTracker map[string]Vacancy
+
+func newAuditTracker(vacancies []Vacancy) auditTracker {
+	t := auditTracker{}
+	for _, v := range vacancies {
+		t[statusTrackerKey(v.Title, v.Company)] = v
+	}
+	return t
+}
+
+// update сравнивает список с запомненным и возвращает записи журнала.
+// Переименование приходит одним событием VacancyUpdated и записывается как изменение, а не удаление с добавлением.
+func (t auditTracker) update(e VacancyEvent, vacancies []Vacancy) []AuditEntry {
+	now, who := time.Now(), auditUser()
+	entry := func(action string, v Vacancy) AuditEntry {
+		return AuditEntry{At: now, User: who, Action: action, Title: v.Title, Company: v.Company}
+	}
+	cur := newAuditTracker(vacancies)
+	var added, removed []Vacancy
+	var entries []AuditEntry
+	for _, v := range vacancies {
+		old, ok := t[statusTrackerKey(v.Title, v.Company)]
+		if !ok {
+			added = append(added, v)
+			continue
+		}
+		if changes := diffVacancies(old, v); len(changes) > 0 {
+			ch := entry(auditChanged, v)
+			ch.Changes = changes
+			entries = append(entries, ch)
+		}
+	}
+	for key, v := range t {
+		if _, ok := cur[key]; !ok {
+			removed = append(removed, v)
+		}
+	}
+	if e.Kind == VacancyUpdated && len(added) == 1 && len(removed) == 1 {
+		ch := entry(auditChanged, added[0])
+		ch.Changes = diffVacancies(removed[0], added[0])
+		entries = append(entries, ch)
+		added, removed = nil, nil
+	}
+	for _, v := range removed {
+		del := entry(auditDeleted, v)
+		del.Deleted = &v
+		entries = append(entries, del)
+	}
+	for _, v := range added {
+		entries = append(entries, entry(auditAdded, v))
+	}
+	clear(t)
+	for key, v := range cur {
+		t[key] = v
+	}
+	return entries
+}
+
+// subscribeAuditLog записывает в журнал все изменения списка вакансий
+func (app *AppMainWindow) subscribeAuditLog() {
+	tracker := newAuditTracker(snapshotVacancies())
+	vacancyEvents.Subscribe(func(e VacancyEvent) {
+		appendAuditEntries(tracker.update(e, snapshotVacancies()))
+	})
+}
+
+// auditRow - строка таблицы журнала: одно изменённое поле или одна запись без полей
+type auditRow struct {
+	Entry  *AuditEntry
+	Change AuditChange
+}
+
+// auditRows раскладывает записи по строкам таблицы, оставляя подходящие под фильтр
+func auditRows(entries []AuditEntry, filter string) []auditRow {
+	filter = strings.ToLower(strings.TrimSpace(filter))
+	var rows []auditRow
+	for i := range entries {
+		e := &entries[i]
+		if filter != "" && !strings.Contains(strings.ToLower(e.Title+" "+e.Company+" "+e.Action), filter) {
+			continue
+		}
+		if len(e.Changes) == 0 {
+			rows = append(rows, auditRow{Entry: e})
+		}
+		for _, c := range e.Changes {
+			rows = append(rows, auditRow{Entry: e, Change: c})
+		}
+	}
+	return rows
+}
+
+// shortAuditValue укорачивает длинные значения (описание, заметки) для таблицы
+func shortAuditValue(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	if r := []rune(s); len(r) > 80 {
+		return string(r[:80]) + "…"
+	}
+	return s
+}
+
+// AuditModel - таблица окна "Журнал изменений"
+type AuditModel struct {
+	walk.TableModelBase
+	rows []auditRow
+}
+
+func (m *AuditModel) RowCount() int {
+	return len(m.rows)
+}
+
+func (m *AuditModel) Value(row, col int) interface{} {
+	r := m.rows[row]
+	switch col {
+	case 0:
+		return r.Entry.At.Format("02.01.2006 15:04")
+	case 1:
+		return r.Entry.Action
+	case 2:
+		return relationLabel(RelatedVacancy{Title: r.Entry.Title, Company: r.Entry.Company})
+	case 3:
+		return r.Change.Field
+	case 4:
+		return shortAuditValue(r.Change.Old)
+	case 5:
+		return shortAuditValue(r.Change.New)
+	case 6:
+		return r.Entry.User
+	}
+	return ""
+}
+
+// exportAuditCSV сохраняет журнал в CSV: строка на каждое изменённое поле
+func exportAuditCSV(path string, rows []auditRow) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	f.WriteString("\uFEFF") // Чтобы Excel узнал UTF-8
+	w := csv.NewWriter(f)
+	w.Comma = ';'
+	w.Write([]string{"Время", "Пользователь", "Действие", "Вакансия", "Компания", "Поле", "Было", "Стало"})
+	for _, r := range rows {
+		w.Write([]string{r.Entry.At.Format("2006-01-02 15:04:05"), r.Entry.User, r.Entry.Action,
+			r.Entry.Title, r.Entry.Company, r.Change.Field, r.Change.Old, r.Change.New})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// restoreDeletedVacancy возвращает удалённую вакансию из журнала в список
+func (app *AppMainWindow) restoreDeletedVacancy(owner walk.Form, v Vacancy) {
+	if app.findVacancyIndexInAllExt(v.Title, v.Company) != -1 {
+		app.notify("Вакансия '" + v.Title + "' уже есть в списке.")
+		return
+	}
+	allVacanciesMutex.Lock()
+	allVacancies = append(allVacancies, v)
+	allVacanciesMutex.Unlock()
+	saveVacancies()
+	vacancyEvents.Publish(vacancyEvent(VacancyAdded, v))
+	app.notify("Вакансия '" + v.Title + "' восстановлена.")
+}
+
+// showAuditLog показывает журнал изменений с фильтром, выгрузкой и восстановлением удалённых вакансий
+func (app *AppMainWindow) showAuditLog() {
+	var dlg *walk.Dialog
+	var filterLE *walk.LineEdit
+	var table *walk.TableView
+	var detailTE *walk.TextEdit
+	var restorePB, closePB *walk.PushButton
+
+	entries, err := loadAuditEntries()
+	if err != nil {
+		log.Printf("Ошибка чтения журнала изменений: %v", err)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось прочитать журнал изменений", err))
+		return
+	}
+	model := &AuditModel{rows: auditRows(entries, "")}
+	selected := func() (auditRow, bool) {
+		if i := table.CurrentIndex(); i >= 0 && i < len(model.rows) {
+			return model.rows[i], true
+		}
+		return auditRow{}, false
+	}
+	onSelect := func() {
+		r, ok := selected()
+		restorePB.SetEnabled(ok && r.Entry.Deleted != nil)
+		if !ok {
+			detailTE.SetText("")
+			return
+		}
+		text := r.Entry.Action + ": " + relationLabel(RelatedVacancy{Title: r.Entry.Title, Company: r.Entry.Company})
+		if r.Change.Field != "" {
+			text += "\r\n\r\n" + r.Change.Field + ", было:\r\n" + r.Change.Old + "\r\n\r\nстало:\r\n" + r.Change.New
+		}
+		detailTE.SetText(strings.ReplaceAll(text, "\n", "\r\n"))
+	}
+	button := func(assignTo **walk.PushButton, text string, onClicked walk.EventHandler) PushButton {
+		return PushButton{
+			AssignTo:   assignTo,
+			Text:       text,
+			Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+			Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+			OnClicked:  onClicked,
+		}
+	}
+
+	if err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Журнал изменений",
+		DefaultButton: &closePB,
+		CancelButton:  &closePB,
+		MinSize:       Size{Width: 900, Height: 600},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Вакансия или действие:", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+					LineEdit{
+						AssignTo: &filterLE,
+						OnTextChanged: func() {
+							model.rows = auditRows(entries, filterLE.Text())
+							model.PublishRowsReset()
+							onSelect()
+						},
+					},
+				},
+			},
+			TableView{
+				AssignTo:         &table,
+				Model:            model,
+				AlternatingRowBG: true,
+				Columns: []TableViewColumn{
+					{Title: "Время", Width: 110},
+					{Title: "Действие", Width: 80},
+					{Title: "Вакансия", Width: 200},
+					{Title: "Поле", Width: 110},
+					{Title: "Было", Width: 160},
+					{Title: "Стало", Width: 160},
+					{Title: "Пользователь", Width: 90},
+				},
+				OnCurrentIndexChanged: onSelect,
+			},
+			TextEdit{AssignTo: &detailTE, ReadOnly: true, VScroll: true, MinSize: Size{Height: 100}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					button(&restorePB, "Восстановить вакансию", func() {
+						if r, ok := selected(); ok && r.Entry.Deleted != nil {
+							app.restoreDeletedVacancy(dlg, *r.Entry.Deleted)
+						}
+					}),
+					button(nil, "Экспорт в CSV...", func() {
+						fd := new(walk.FileDialog)
+						fd.Title = "Экспорт журнала изменений"
+						fd.Filter = "CSV (*.csv)|*.csv"
+						fd.FilePath = "Журнал изменений " + time.Now().Format("2006-01-02") + ".csv"
+						if ok, err := fd.ShowSave(dlg); err != nil || !ok {
+							return
+						}
+						path := fd.FilePath
+						if !strings.EqualFold(filepath.Ext(path), ".csv") {
+							path += ".csv"
+						}
+						if err := exportAuditCSV(path, model.rows); err != nil {
+							log.Printf("Ошибка экспорта журнала изменений: %v", err)
+							showError(dlg, "Ошибка", wrapError("Не удалось сохранить файл", err))
+						}
+					}),
+					HSpacer{},
+					button(&closePB, "Закрыть", func() { dlg.Accept() }),
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	restorePB.SetEnabled(false)
+	dlg.Run()
+}
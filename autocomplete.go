@@ -0,0 +1,218 @@
+package main
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Сколько подсказок показывать под полем
+const maxSuggestions = 8
+
+// Встроенный список известных работодателей для подсказок
+var bundledCompanies = []string{
+	"Яндекс", "Сбер", "Т-Банк", "VK", "Ozon", "Wildberries", "Авито", "Лаборатория Касперского",
+	"2ГИС", "Skyeng", "hh.ru", "МТС", "Билайн", "МегаФон", "Ростелеком", "Альфа-Банк", "ВТБ",
+	"Райффайзенбанк", "X5 Group", "Lamoda", "Selectel", "JetBrains", "Positive Technologies",
+	"Газпромбанк", "Самокат", "Контур", "Тензор", "Купер", "Точка", "Совкомбанк",
+}
+
+// rankedValues возвращает уникальные значения (без учёта регистра), самые частые - первыми
+func rankedValues(values []string) []string {
+	counts := map[string]int{}
+	display := map[string]string{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			continue
+		}
+		key := strings.ToLower(v)
+		if _, ok := display[key]; !ok {
+			display[key] = v
+		}
+		counts[key]++
+	}
+	keys := make([]string, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if counts[keys[i]] != counts[keys[j]] {
+			return counts[keys[i]] > counts[keys[j]]
+		}
+		return keys[i] < keys[j]
+	})
+	result := make([]string, len(keys))
+	for i, k := range keys {
+		result[i] = display[k]
+	}
+	return result
+}
+
+// companySuggestions - компании из базы и, если включено, из встроенного списка
+func companySuggestions() []string {
+	allVacanciesMutex.Lock()
+	values := make([]string, 0, len(allVacancies))
+	for _, v := range allVacancies {
+		values = append(values, v.Company)
+	}
+	allVacanciesMutex.Unlock()
+
+	ranked := rankedValues(values)
+	if appSettings.SuggestBundledCompanies {
+		ranked = rankedValues(append(ranked, bundledCompanies...))
+	}
+	return ranked
+}
+
+// keywordSuggestions - ключевые слова из базы
+func keywordSuggestions() []string {
+	allVacanciesMutex.Lock()
+	var values []string
+	for _, v := range allVacancies {
+		values = append(values, v.Keywords...)
+	}
+	allVacanciesMutex.Unlock()
+	return rankedValues(values)
+}
+
+// matchSuggestions отбирает варианты для введённого текста: сначала начинающиеся с него,
+// потом содержащие его. Значения из exclude не предлагаются.
+func matchSuggestions(candidates []string, typed string, exclude map[string]bool, limit int) []string {
+	typed = strings.ToLower(strings.TrimSpace(typed))
+	if typed == "" {
+		return nil
+	}
+	var prefix, contains []string
+	for _, c := range candidates {
+		lc := strings.ToLower(c)
+		if exclude[lc] {
+			continue
+		}
+		switch {
+		case strings.HasPrefix(lc, typed):
+			prefix = append(prefix, c)
+		case strings.Contains(lc, typed):
+			contains = append(contains, c)
+		}
+	}
+	matches := append(prefix, contains...)
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// Autocomplete показывает подсказки под LineEdit по мере ввода
+type Autocomplete struct {
+	edit     *walk.LineEdit
+	list     *walk.ListBox
+	source   func() []string
+	multi    bool // Поле со списком через запятую - дополняется последнее значение
+	items    []string
+	updating bool
+}
+
+// newAutocomplete создаёт подсказки для поля; multi - для списков через запятую
+func newAutocomplete(source func() []string, multi bool) *Autocomplete {
+	return &Autocomplete{source: source, multi: multi}
+}
+
+// Widget возвращает список подсказок; размещается сразу под полем ввода
+func (ac *Autocomplete) Widget() Widget {
+	return ListBox{
+		AssignTo:        &ac.list,
+		Visible:         false,
+		MaxSize:         Size{Height: 110},
+		Font:            Font{PointSize: 9},
+		OnItemActivated: ac.accept,
+	}
+}
+
+// Attach подключает подсказки к полю после создания формы
+func (ac *Autocomplete) Attach(edit *walk.LineEdit) {
+	ac.edit = edit
+	edit.TextChanged().Attach(ac.update)
+	edit.KeyDown().Attach(func(key walk.Key) {
+		switch key {
+		case walk.KeyDown:
+			if ac.list.Visible() {
+				ac.list.SetFocus()
+				ac.list.SetCurrentIndex(0)
+			}
+		case walk.KeyEscape:
+			ac.hide()
+		}
+	})
+	onFocusLost := func() {
+		// Фокус мог перейти в список подсказок - проверяем после обработки сообщения
+		edit.Synchronize(func() {
+			if !edit.Focused() && !ac.list.Focused() {
+				ac.hide()
+			}
+		})
+	}
+	edit.FocusedChanged().Attach(onFocusLost)
+	ac.list.FocusedChanged().Attach(onFocusLost)
+}
+
+// split делит текст на уже введённую часть и дополняемое значение
+func (ac *Autocomplete) split(text string) (head, current string) {
+	if !ac.multi {
+		return "", text
+	}
+	i := strings.LastIndex(text, ",")
+	if i < 0 {
+		return "", text
+	}
+	return strings.TrimRight(text[:i+1], " ") + " ", text[i+1:]
+}
+
+func (ac *Autocomplete) update() {
+	if ac.updating || ac.edit.ReadOnly() || !ac.edit.Focused() {
+		return
+	}
+	head, current := ac.split(ac.edit.Text())
+	exclude := map[string]bool{}
+	if ac.multi {
+		for _, kw := range strings.Split(head, ",") {
+			exclude[strings.ToLower(strings.TrimSpace(kw))] = true
+		}
+	}
+	matches := matchSuggestions(ac.source(), current, exclude, maxSuggestions)
+	if len(matches) == 0 || len(matches) == 1 && strings.EqualFold(matches[0], strings.TrimSpace(current)) {
+		ac.hide()
+		return
+	}
+	ac.items = matches
+	ac.list.SetModel(matches)
+	ac.list.SetVisible(true)
+}
+
+func (ac *Autocomplete) accept() {
+	i := ac.list.CurrentIndex()
+	if i < 0 || i >= len(ac.items) {
+		return
+	}
+	head, _ := ac.split(ac.edit.Text())
+	text := head + ac.items[i]
+	if ac.multi {
+		text += ", "
+	}
+	ac.updating = true
+	ac.edit.SetText(text)
+	ac.updating = false
+	end := len(utf16.Encode([]rune(text)))
+	ac.edit.SetFocus()
+	ac.edit.SetTextSelection(end, end)
+	ac.hide()
+}
+
+func (ac *Autocomplete) hide() {
+	if ac.list != nil && ac.list.Visible() {
+		ac.list.SetVisible(false)
+	}
+}
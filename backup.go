@@ -0,0 +1,513 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+const (
+	defaultBackupDir       = "backups"
+	defaultBackupRetention = 8
+	backupManifestName     = "manifest.json"
+	backupResumesDir       = "resumes"
+	autoBackupInterval     = 7 * 24 * time.Hour
+	restoredResumesDir     = "resumes" // Куда распаковываются резюме при восстановлении
+)
+
+// BackupManifest описывает содержимое архива резервной копии
+type BackupManifest struct {
+	CreatedAt time.Time         `json:"createdAt"`
+	Vacancies int               `json:"vacancies"`
+	Resumes   map[string]string `json:"resumes"` // исходный путь -> имя файла внутри архива
+}
+
+// backupDir возвращает папку для резервных копий из настроек
+func backupDir() string {
+	if appSettings.BackupDir != "" {
+		return appSettings.BackupDir
+	}
+	return dataPath(defaultBackupDir)
+}
+
+const (
+	backupFilePrefix     = "jobsearch-backup-"     // Копии, созданные вручную
+	autoBackupFilePrefix = "jobsearch-autobackup-" // Еженедельные копии, старые из них удаляются
+)
+
+// backupFileName формирует имя архива с отметкой времени
+func backupFileName(prefix string, now time.Time) string {
+	return prefix + now.Format("20060102-150405") + ".zip"
+}
+
+// addFileToZip копирует файл с диска в архив под именем name
+func addFileToZip(zw *zip.Writer, path, name string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(w, f)
+	return err
+}
+
+// createBackup сохраняет вакансии, настройки и файлы резюме в zip-архив dest
+func createBackup(dest string) error {
+	allVacanciesMutex.Lock()
+	vacancies := make([]Vacancy, len(allVacancies))
+	copy(vacancies, allVacancies)
+	allVacanciesMutex.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return fmt.Errorf("ошибка создания папки %s: %w", filepath.Dir(dest), err)
+	}
+	out, err := os.Create(dest)
+	if err != nil {
+		return fmt.Errorf("ошибка создания архива %s: %w", dest, err)
+	}
+	zw := zip.NewWriter(out)
+
+	manifest := BackupManifest{CreatedAt: time.Now(), Vacancies: len(vacancies), Resumes: map[string]string{}}
+	writeErr := func() error {
+		data, err := encodeVacanciesFile(vacancies)
+		if err != nil {
+			return err
+		}
+		w, err := zw.Create(vacanciesFile)
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(data); err != nil {
+			return err
+		}
+		// Настройки без паролей и токенов: архив могут хранить где угодно
+		if settings, err := os.ReadFile(settingsPath()); err == nil {
+			if settings, err = withoutSettingsSecrets(settings); err != nil {
+				return fmt.Errorf("ошибка чтения настроек: %w", err)
+			}
+			w, err := zw.Create(settingsFile)
+			if err != nil {
+				return err
+			}
+			if _, err := w.Write(settings); err != nil {
+				return err
+			}
+		}
+		for _, v := range vacancies {
+			if v.ResumePath == "" {
+				continue
+			}
+			if _, done := manifest.Resumes[v.ResumePath]; done {
+				continue
+			}
+			if _, err := os.Stat(v.ResumePath); err != nil {
+				log.Printf("Резюме %s не найдено и не попадёт в резервную копию: %v", v.ResumePath, err)
+				continue
+			}
+			name := fmt.Sprintf("%s/%d_%s", backupResumesDir, len(manifest.Resumes)+1, filepath.Base(v.ResumePath))
+			if err := addFileToZip(zw, v.ResumePath, name); err != nil {
+				return err
+			}
+			manifest.Resumes[v.ResumePath] = name
+		}
+		data, err = json.MarshalIndent(manifest, "", "  ")
+		if err != nil {
+			return err
+		}
+		w, err = zw.Create(backupManifestName)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}()
+
+	if err := zw.Close(); err != nil && writeErr == nil {
+		writeErr = err
+	}
+	if err := out.Close(); err != nil && writeErr == nil {
+		writeErr = err
+	}
+	if writeErr != nil {
+		os.Remove(dest)
+		return fmt.Errorf("ошибка записи резервной копии: %w", writeErr)
+	}
+	log.Printf("Создана резервная копия %s (%d вакансий, %d резюме)", dest, manifest.Vacancies, len(manifest.Resumes))
+	return nil
+}
+
+// pruneBackups удаляет самые старые автоматические копии, оставляя keep последних
+func pruneBackups(dir string, keep int) {
+	if keep <= 0 {
+		return
+	}
+	// Копии, сделанные вручную, удаляет только сам пользователь
+	matches, err := filepath.Glob(filepath.Join(dir, autoBackupFilePrefix+"*.zip"))
+	if err != nil || len(matches) <= keep {
+		return
+	}
+	sort.Strings(matches) // Имена содержат отметку времени, поэтому сортируются хронологически
+	for _, old := range matches[:len(matches)-keep] {
+		if err := os.Remove(old); err != nil {
+			log.Printf("Не удалось удалить старую резервную копию %s: %v", old, err)
+		}
+	}
+}
+
+// runAutoBackupIfDue создаёт еженедельную резервную копию в фоне, если она включена и пора.
+// Настройки читаются и сохраняются в потоке окна.
+func (app *AppMainWindow) runAutoBackupIfDue() {
+	if !appSettings.AutoBackup {
+		return
+	}
+	if !appSettings.LastBackupAt.IsZero() && time.Since(appSettings.LastBackupAt) < autoBackupInterval {
+		return
+	}
+	dir := backupDir()
+	retention := appSettings.BackupRetention
+	if retention <= 0 {
+		retention = defaultBackupRetention
+	}
+	go func() {
+		now := time.Now()
+		if err := createBackup(filepath.Join(dir, backupFileName(autoBackupFilePrefix, now))); err != nil {
+			log.Printf("Ошибка автоматического резервного копирования: %v", err)
+			return
+		}
+		pruneBackups(dir, retention)
+		app.Synchronize(func() {
+			appSettings.LastBackupAt = now
+			saveSettings()
+		})
+	}()
+}
+
+// readBackupManifest читает описание архива резервной копии
+func readBackupManifest(zr *zip.Reader) (BackupManifest, error) {
+	var manifest BackupManifest
+	for _, f := range zr.File {
+		if f.Name != backupManifestName {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return manifest, err
+		}
+		defer rc.Close()
+		err = json.NewDecoder(rc).Decode(&manifest)
+		return manifest, err
+	}
+	return manifest, errors.New("архив не похож на резервную копию: нет " + backupManifestName)
+}
+
+// extractZipFile распаковывает файл архива в путь dest
+func extractZipFile(f *zip.File, dest string) error {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return err
+	}
+	out, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, rc); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// restoreBackup восстанавливает данные из архива. Файлы резюме распаковываются
+// в папку restoredResumesDir, а пути к ним в вакансиях обновляются.
+func restoreBackup(path string, withSettings, withResumes bool) error {
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return fmt.Errorf("ошибка открытия архива: %w", err)
+	}
+	defer zr.Close()
+
+	manifest, err := readBackupManifest(&zr.Reader)
+	if err != nil {
+		return err
+	}
+
+	files := map[string]*zip.File{}
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+	vf, ok := files[vacanciesFile]
+	if !ok {
+		return errors.New("в архиве нет файла " + vacanciesFile)
+	}
+
+	rc, err := vf.Open()
+	if err != nil {
+		return err
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return fmt.Errorf("ошибка чтения вакансий из архива: %w", err)
+	}
+	restored, _, err := decodeVacanciesFile(data)
+	if err != nil {
+		return fmt.Errorf("ошибка чтения вакансий из архива: %w", err)
+	}
+
+	// Страховочная копия текущего состояния перед перезаписью
+	safety := filepath.Join(backupDir(), "before-restore-"+time.Now().Format("20060102-150405")+".zip")
+	if err := createBackup(safety); err != nil {
+		return fmt.Errorf("не удалось сохранить текущие данные перед восстановлением: %w", err)
+	}
+
+	if withResumes {
+		newPaths := map[string]string{}
+		for original, name := range manifest.Resumes {
+			f, ok := files[name]
+			if !ok {
+				continue
+			}
+			dest, err := filepath.Abs(filepath.Join(dataPath(restoredResumesDir), filepath.Base(name)))
+			if err != nil {
+				return err
+			}
+			if err := extractZipFile(f, dest); err != nil {
+				return fmt.Errorf("ошибка распаковки %s: %w", name, err)
+			}
+			newPaths[original] = dest
+		}
+		for i := range restored {
+			if p, ok := newPaths[restored[i].ResumePath]; ok {
+				restored[i].ResumePath = p
+			}
+		}
+	}
+
+	if withSettings {
+		if sf, ok := files[settingsFile]; ok {
+			prev := appSettings
+			if err := extractZipFile(sf, settingsPath()); err != nil {
+				return fmt.Errorf("ошибка восстановления настроек: %w", err)
+			}
+			loadSettings()
+			keepSettingsSecrets(&appSettings, prev) // В копии секретов нет
+			saveSettings()
+		}
+	}
+
+	allVacanciesMutex.Lock()
+	allVacancies = restored
+	vacanciesReadOnly = false
+	allVacanciesMutex.Unlock()
+	saveVacancies()
+	log.Printf("Восстановлено %d вакансий из резервной копии %s", len(restored), path)
+	return nil
+}
+
+// backupNow создаёт резервную копию в выбранный пользователем файл
+func (app *AppMainWindow) backupNow() {
+	dlg := new(walk.FileDialog)
+	dlg.Title = "Создать резервную копию"
+	dlg.Filter = "Архив ZIP (*.zip)|*.zip"
+	dlg.FilePath = filepath.Join(backupDir(), backupFileName(backupFilePrefix, time.Now()))
+	ok, err := dlg.ShowSave(app.MainWindow)
+	if err != nil {
+		showError(app.MainWindow, "Ошибка", wrapError("Ошибка при открытии диалога", err))
+		return
+	}
+	if !ok {
+		return
+	}
+	path := dlg.FilePath
+	if !strings.EqualFold(filepath.Ext(path), ".zip") {
+		path += ".zip"
+	}
+	if err := createBackup(path); err != nil {
+		log.Printf("Ошибка резервного копирования: %v", err)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось создать резервную копию", err))
+		return
+	}
+	app.notifyFileSaved("Резервная копия сохранена", path)
+}
+
+// showRestoreWizard проводит пользователя через восстановление из резервной копии
+func (app *AppMainWindow) showRestoreWizard() {
+	var dlg *walk.Dialog
+	var pathLE *walk.LineEdit
+	var infoLabel *walk.Label
+	var settingsCB, resumesCB *walk.CheckBox
+	var restorePB *walk.PushButton
+	settingsRestored := false
+
+	inspect := func(path string) {
+		restorePB.SetEnabled(false)
+		zr, err := zip.OpenReader(path)
+		if err != nil {
+			infoLabel.SetText("Не удалось открыть архив: " + err.Error())
+			return
+		}
+		defer zr.Close()
+		manifest, err := readBackupManifest(&zr.Reader)
+		if err != nil {
+			infoLabel.SetText(err.Error())
+			return
+		}
+		infoLabel.SetText(fmt.Sprintf("Копия от %s\nВакансий: %d, файлов резюме: %d\n\nТекущие данные будут заменены (перед этим будет сохранена страховочная копия).",
+			manifest.CreatedAt.Local().Format("02.01.2006 15:04"), manifest.Vacancies, len(manifest.Resumes)))
+		restorePB.SetEnabled(true)
+	}
+
+	if _, err := (Dialog{
+		AssignTo:   &dlg,
+		Title:      "Восстановление из резервной копии",
+		MinSize:    Size{Width: 480, Height: 280},
+		Layout:     VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Шаг 1. Выберите архив резервной копии:", Font: Font{Bold: true, PointSize: 9}, TextColor: currentTheme.Text},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					LineEdit{AssignTo: &pathLE, ReadOnly: true, Font: Font{PointSize: 9}},
+					PushButton{
+						Text: "Обзор...",
+						OnClicked: func() {
+							fd := new(walk.FileDialog)
+							fd.Title = "Выберите резервную копию"
+							fd.Filter = "Архив ZIP (*.zip)|*.zip"
+							fd.InitialDirPath = backupDir()
+							if ok, _ := fd.ShowOpen(dlg); ok {
+								pathLE.SetText(fd.FilePath)
+								inspect(fd.FilePath)
+							}
+						},
+					},
+				},
+			},
+			Label{Text: "Шаг 2. Проверьте содержимое и выберите, что восстановить:", Font: Font{Bold: true, PointSize: 9}, TextColor: currentTheme.Text},
+			Label{AssignTo: &infoLabel, Text: "Архив не выбран.", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			CheckBox{AssignTo: &settingsCB, Text: "Восстановить настройки", Checked: true},
+			CheckBox{AssignTo: &resumesCB, Text: "Восстановить файлы резюме", Checked: true},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &restorePB,
+						Text:       "Восстановить",
+						Enabled:    false,
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if walk.DlgCmdYes != walk.MsgBox(dlg, "Подтверждение", "Заменить текущие данные содержимым резервной копии?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) {
+								return
+							}
+							if err := restoreBackup(pathLE.Text(), settingsCB.Checked(), resumesCB.Checked()); err != nil {
+								log.Printf("Ошибка восстановления: %v", err)
+								showError(dlg, "Ошибка", wrapError("Не удалось восстановить данные", err))
+								return
+							}
+							settingsRestored = settingsCB.Checked()
+							vacancyEvents.Publish(VacancyEvent{Kind: VacanciesImported})
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+
+	if settingsRestored {
+		app.applySavedTheme()
+		app.applyFonts()
+		resetHTTPClient()
+	}
+}
+
+// showBackupSettings настраивает автоматическое резервное копирование
+func (app *AppMainWindow) showBackupSettings() {
+	var dlg *walk.Dialog
+	var autoCB *walk.CheckBox
+	var retentionNE *walk.NumberEdit
+	var dirLE *walk.LineEdit
+
+	retention := appSettings.BackupRetention
+	if retention <= 0 {
+		retention = defaultBackupRetention
+	}
+	last := "ещё не выполнялось"
+	if !appSettings.LastBackupAt.IsZero() {
+		last = appSettings.LastBackupAt.Local().Format("02.01.2006 15:04")
+	}
+
+	if _, err := (Dialog{
+		AssignTo:   &dlg,
+		Title:      "Резервное копирование",
+		MinSize:    Size{Width: 420, Height: 230},
+		Layout:     VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			CheckBox{AssignTo: &autoCB, Text: "Создавать резервную копию раз в неделю", Checked: appSettings.AutoBackup},
+			Label{Text: "Хранить последних автоматических копий:", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			NumberEdit{AssignTo: &retentionNE, Value: float64(retention), MinValue: 1, MaxValue: 100, SpinButtonsVisible: true},
+			Label{Text: "Папка для копий:", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			LineEdit{AssignTo: &dirLE, Text: backupDir(), Font: Font{PointSize: 9}},
+			Label{Text: "Последнее автоматическое копирование: " + last, TextColor: currentTheme.Text, Font: Font{PointSize: 8}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							appSettings.AutoBackup = autoCB.Checked()
+							appSettings.BackupRetention = int(retentionNE.Value())
+							appSettings.BackupDir = strings.TrimSpace(dirLE.Text())
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
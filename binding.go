@@ -0,0 +1,78 @@
+package main
+
+import (
+	"strings"
+	"time"
+)
+
+// vacancyFormData - значения полей формы вакансии, связанные с виджетами через walk.DataBinder.
+// Имена полей совпадают с выражениями Bind(...) в описании диалога.
+type vacancyFormData struct {
+	Title           string
+	Company         string
+	Status          string
+	ExperienceLevel string
+	Keywords        string
+	SourceURL       string
+	Salary          string
+	Description     string
+	Notes           string
+	InterviewAt     time.Time
+	DeadlineAt      time.Time
+}
+
+// newVacancyFormData заполняет форму из вакансии. Пустые статус и уровень опыта
+// заменяются первыми значениями списков, как их показывает выпадающий список.
+func newVacancyFormData(v Vacancy) *vacancyFormData {
+	f := &vacancyFormData{
+		Title:           v.Title,
+		Company:         v.Company,
+		Status:          v.Status,
+		ExperienceLevel: v.ExperienceLevel,
+		Keywords:        strings.Join(v.Keywords, ", "),
+		SourceURL:       v.SourceURL,
+		Salary:          v.Salary,
+		Description:     v.Description,
+		Notes:           v.Notes,
+		InterviewAt:     v.InterviewAt,
+		DeadlineAt:      v.DeadlineAt,
+	}
+	if indexOfString(possibleStatuses, f.Status) == 0 {
+		f.Status = possibleStatuses[0]
+	}
+	if indexOfString(possibleExperienceLevels, f.ExperienceLevel) == 0 {
+		f.ExperienceLevel = possibleExperienceLevels[0]
+	}
+	return f
+}
+
+// applyTo переносит значения формы в вакансию. Статус не трогаем: его меняют через
+// setVacancyStatus, чтобы переход попал в историю.
+func (f *vacancyFormData) applyTo(v *Vacancy) {
+	v.Title = strings.TrimSpace(f.Title)
+	v.Company = strings.TrimSpace(f.Company)
+	v.ExperienceLevel = f.ExperienceLevel
+	v.Keywords = parseKeywords(f.Keywords)
+	v.SourceURL = strings.TrimSpace(f.SourceURL)
+	applySalary(v, f.Salary)
+	v.Description = strings.TrimSpace(f.Description)
+	v.Notes = strings.TrimSpace(f.Notes)
+	v.InterviewAt = f.InterviewAt
+	v.DeadlineAt = f.DeadlineAt
+}
+
+// detailsFormData - форма панели деталей: поля вакансии и признак, что вакансия выбрана
+// и её можно править (от него зависит, доступны ли поля)
+type detailsFormData struct {
+	vacancyFormData
+	Selected bool
+	Editable bool
+}
+
+// newDetailsFormData заполняет панель деталей. Без выбранной вакансии поля пустые и недоступны.
+func newDetailsFormData(v Vacancy, selected bool) *detailsFormData {
+	if !selected {
+		return &detailsFormData{vacancyFormData: vacancyFormData{Title: "-", Company: "-"}}
+	}
+	return &detailsFormData{vacancyFormData: *newVacancyFormData(v), Selected: true, Editable: detailsEditable()}
+}
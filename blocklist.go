@@ -0,0 +1,156 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// normalizeBlockTerm приводит строку к виду для сравнения с чёрным списком
+func normalizeBlockTerm(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// blockedCompany возвращает запись чёрного списка, под которую попадает компания.
+// Запись совпадает, если входит в название компании ("кадровое агентство" скроет
+// "ООО Кадровое агентство Плюс").
+func blockedCompany(company string) (string, bool) {
+	c := normalizeBlockTerm(company)
+	if c == "" {
+		return "", false
+	}
+	for _, blocked := range appSettings.BlockedCompanies {
+		if b := normalizeBlockTerm(blocked); b != "" && strings.Contains(c, b) {
+			return blocked, true
+		}
+	}
+	return "", false
+}
+
+// blockedKeyword возвращает слово из чёрного списка, найденное в названии или описании вакансии
+func blockedKeyword(v Vacancy) (string, bool) {
+	text := normalizeBlockTerm(v.Title + " " + v.Description)
+	for _, blocked := range appSettings.BlockedKeywords {
+		if b := normalizeBlockTerm(blocked); b != "" && strings.Contains(text, b) {
+			return blocked, true
+		}
+	}
+	return "", false
+}
+
+// isBlocked проверяет, что онлайн-результат нужно скрыть
+func isBlocked(v Vacancy) bool {
+	if _, ok := blockedCompany(v.Company); ok {
+		return true
+	}
+	_, ok := blockedKeyword(v)
+	return ok
+}
+
+// splitLines разбивает текст по строкам, пропуская пустые
+func splitLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// blockCompany добавляет компанию в чёрный список, если её там ещё нет
+func blockCompany(company string) {
+	if _, ok := blockedCompany(company); ok {
+		return
+	}
+	appSettings.BlockedCompanies = append(appSettings.BlockedCompanies, strings.TrimSpace(company))
+	saveSettings()
+}
+
+// blockPreviewedCompany скрывает компанию выбранного онлайн-результата
+func (app *AppMainWindow) blockPreviewedCompany() {
+	idx := app.onlineResultsTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.onlineVacancyModel.items) {
+		return
+	}
+	company := strings.TrimSpace(app.onlineVacancyModel.items[idx].Company)
+	if company == "" {
+		walk.MsgBox(app.MainWindow, "Чёрный список", "У этой вакансии не указана компания.", walk.MsgBoxIconInformation)
+		return
+	}
+	if walk.DlgCmdYes != walk.MsgBox(app.MainWindow, "Чёрный список",
+		"Скрывать вакансии компании '"+company+"' в результатах онлайн-поиска?\nСписок можно изменить в меню Инструменты → Чёрный список.",
+		walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) {
+		return
+	}
+	blockCompany(company)
+	app.applyOnlineFilter()
+}
+
+// showBlocklistDialog редактирует чёрный список компаний и ключевых слов
+func (app *AppMainWindow) showBlocklistDialog() {
+	var dlg *walk.Dialog
+	var companiesTE, keywordsTE *walk.TextEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Чёрный список",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 460, Height: 420},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Такие вакансии скрываются из результатов онлайн-поиска.\nПо одной записи на строку, регистр не важен.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			Label{Text: "Компании и агентства:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			TextEdit{
+				AssignTo: &companiesTE,
+				Text:     strings.Join(appSettings.BlockedCompanies, "\r\n"),
+				VScroll:  true,
+				Font:     Font{PointSize: 9},
+			},
+			Label{Text: "Слова в названии или описании (например, «менеджер по продажам»):", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			TextEdit{
+				AssignTo: &keywordsTE,
+				Text:     strings.Join(appSettings.BlockedKeywords, "\r\n"),
+				VScroll:  true,
+				Font:     Font{PointSize: 9},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							appSettings.BlockedCompanies = splitLines(companiesTE.Text())
+							appSettings.BlockedKeywords = splitLines(keywordsTE.Text())
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	app.applyOnlineFilter()
+}
@@ -0,0 +1,531 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Выгрузка вакансий в карточки Trello (список на каждый статус) или задачи Jira
+const (
+	trelloAPIBase      = "https://api.trello.com/1/"
+	boardsSyncFile     = "boards_sync.json"
+	boardExportTimeout = 10 * time.Minute
+	defaultJiraType    = "Task"
+)
+
+// Trello пропускает 100 запросов за 10 секунд на токен
+var (
+	trelloProvider = &searchProvider{Name: "trello", limiter: &rateLimiter{interval: 120 * time.Millisecond}}
+	jiraProvider   = &searchProvider{Name: "jira", limiter: &rateLimiter{interval: 200 * time.Millisecond}}
+)
+
+// Короткая ссылка доски в адресе trello.com/b/<id>/...
+var trelloBoardRe = regexp.MustCompile(`trello\.com/b/([A-Za-z0-9]+)`)
+
+// BoardExportSettings - подключение к Trello и Jira
+type BoardExportSettings struct {
+	TrelloKey     string `json:"trello_key,omitempty"`
+	TrelloToken   string `json:"trello_token,omitempty"`
+	TrelloBoard   string `json:"trello_board,omitempty"` // ID или короткая ссылка доски
+	JiraURL       string `json:"jira_url,omitempty"`     // https://<сайт>.atlassian.net
+	JiraEmail     string `json:"jira_email,omitempty"`
+	JiraToken     string `json:"jira_token,omitempty"`
+	JiraProject   string `json:"jira_project,omitempty"`    // Ключ проекта
+	JiraIssueType string `json:"jira_issue_type,omitempty"` // Тип задачи, по умолчанию Task
+}
+
+// boardsSyncState - созданные карточки и задачи по вакансиям, чтобы обновлять, а не дублировать
+type boardsSyncState struct {
+	TrelloBoard string            `json:"trello_board,omitempty"`
+	TrelloCards map[string]string `json:"trello_cards"` // ID карточки по вакансии
+	JiraProject string            `json:"jira_project,omitempty"`
+	JiraIssues  map[string]string `json:"jira_issues"` // Ключ задачи по вакансии
+}
+
+func loadBoardsSyncState() boardsSyncState {
+	state := boardsSyncState{TrelloCards: map[string]string{}, JiraIssues: map[string]string{}}
+	data, err := os.ReadFile(dataPath(boardsSyncFile))
+	if err != nil {
+		return state
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.Printf("Ошибка чтения %s: %v", boardsSyncFile, err)
+	}
+	if state.TrelloCards == nil {
+		state.TrelloCards = map[string]string{}
+	}
+	if state.JiraIssues == nil {
+		state.JiraIssues = map[string]string{}
+	}
+	return state
+}
+
+func saveBoardsSyncState(state boardsSyncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dataPath(boardsSyncFile), data, 0644)
+}
+
+// boardCardText - описание карточки или задачи по вакансии
+func boardCardText(v Vacancy) string {
+	var lines []string
+	add := func(label, value string) {
+		if strings.TrimSpace(value) != "" {
+			lines = append(lines, label+": "+value)
+		}
+	}
+	add("Компания", v.Company)
+	add("Статус", v.Status)
+	add("Зарплата", v.Salary)
+	add("Опыт", v.ExperienceLevel)
+	add("Город", v.Location)
+	add("Ссылка", v.SourceURL)
+	if len(v.Keywords) > 0 {
+		add("Ключевые слова", strings.Join(v.Keywords, ", "))
+	}
+	if !v.InterviewAt.IsZero() {
+		add("Собеседование", v.InterviewAt.Local().Format("02.01.2006 15:04"))
+	}
+	text := strings.Join(lines, "\n")
+	if notes := strings.TrimSpace(v.Notes); notes != "" {
+		text += "\n\n" + notes
+	}
+	return text
+}
+
+// boardCardTitle - заголовок карточки
+func boardCardTitle(v Vacancy) string {
+	if v.Company == "" {
+		return v.Title
+	}
+	return v.Title + " — " + v.Company
+}
+
+// boardExportResult - итог выгрузки
+type boardExportResult struct {
+	Created, Updated int
+}
+
+// apiError достаёт понятное сообщение из ответа с ошибкой
+func apiError(service string, status int, body []byte) error {
+	var e struct {
+		Message       string   `json:"message"`
+		ErrorMessages []string `json:"errorMessages"`
+		Errors        map[string]string
+	}
+	if json.Unmarshal(body, &e) == nil {
+		msgs := e.ErrorMessages
+		if e.Message != "" {
+			msgs = append(msgs, e.Message)
+		}
+		for field, msg := range e.Errors {
+			msgs = append(msgs, field+": "+msg)
+		}
+		if len(msgs) > 0 {
+			return fmt.Errorf("%s: %s", service, strings.Join(msgs, "; "))
+		}
+	}
+	if text := strings.TrimSpace(string(body)); text != "" && len(text) < 200 {
+		return fmt.Errorf("%s ответил %d: %s", service, status, text)
+	}
+	return fmt.Errorf("%s ответил %d", service, status)
+}
+
+// --- Trello ---
+
+type trelloClient struct {
+	ctx        context.Context
+	key, token string
+}
+
+// errTrelloNotFound - карточка удалена на доске
+var errTrelloNotFound = errors.New("trello: не найдено")
+
+func (c *trelloClient) do(method, path string, params url.Values, out any) error {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("key", c.key)
+	params.Set("token", c.token)
+	data, status, err := fetchWithRetry(c.ctx, trelloProvider, func() (*http.Request, error) {
+		return http.NewRequestWithContext(c.ctx, method, trelloAPIBase+path+"?"+params.Encode(), nil)
+	})
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		return errTrelloNotFound
+	}
+	if status != http.StatusOK {
+		return apiError("Trello", status, data)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// parseTrelloBoard достаёт ID доски из ссылки
+func parseTrelloBoard(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if m := trelloBoardRe.FindStringSubmatch(raw); m != nil {
+		return m[1]
+	}
+	return raw
+}
+
+// statusLists возвращает списки доски по статусам, создавая недостающие
+func (c *trelloClient) statusLists(board string) (map[string]string, error) {
+	var lists []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	}
+	if err := c.do(http.MethodGet, "boards/"+board+"/lists", url.Values{"filter": {"open"}}, &lists); err != nil {
+		if errors.Is(err, errTrelloNotFound) {
+			return nil, errors.New("доска Trello не найдена или нет доступа")
+		}
+		return nil, err
+	}
+	byStatus := map[string]string{}
+	for _, status := range possibleStatuses {
+		for _, l := range lists {
+			if strings.EqualFold(strings.TrimSpace(l.Name), status) {
+				byStatus[status] = l.ID
+				break
+			}
+		}
+		if byStatus[status] != "" {
+			continue
+		}
+		var created struct {
+			ID string `json:"id"`
+		}
+		if err := c.do(http.MethodPost, "boards/"+board+"/lists", url.Values{"name": {status}, "pos": {"bottom"}}, &created); err != nil {
+			return nil, err
+		}
+		byStatus[status] = created.ID
+	}
+	return byStatus, nil
+}
+
+// exportToTrello создаёт или обновляет карточку на каждую вакансию в списке её статуса
+func exportToTrello(ctx context.Context, s BoardExportSettings, vacancies []Vacancy, state *boardsSyncState) (boardExportResult, error) {
+	var res boardExportResult
+	c := &trelloClient{ctx: ctx, key: s.TrelloKey, token: s.TrelloToken}
+	board := parseTrelloBoard(s.TrelloBoard)
+	if state.TrelloBoard != board {
+		state.TrelloBoard = board
+		state.TrelloCards = map[string]string{}
+	}
+	lists, err := c.statusLists(board)
+	if err != nil {
+		return res, err
+	}
+	progressTotal(ctx, len(vacancies))
+	for _, v := range vacancies {
+		progressStep(ctx, v.Title)
+		status := v.Status
+		if status == "" {
+			status = possibleStatuses[0]
+		}
+		params := url.Values{"name": {boardCardTitle(v)}, "desc": {boardCardText(v)}, "idList": {lists[status]}}
+		key := statusTrackerKey(v.Title, v.Company)
+		if id := state.TrelloCards[key]; id != "" {
+			err := c.do(http.MethodPut, "cards/"+id, params, nil)
+			if err == nil {
+				res.Updated++
+				continue
+			}
+			if !errors.Is(err, errTrelloNotFound) {
+				return res, err
+			}
+		}
+		var card struct {
+			ID string `json:"id"`
+		}
+		params.Set("pos", "bottom")
+		if validVacancyURL(v.SourceURL) {
+			params.Set("urlSource", v.SourceURL)
+		}
+		if err := c.do(http.MethodPost, "cards", params, &card); err != nil {
+			return res, err
+		}
+		state.TrelloCards[key] = card.ID
+		res.Created++
+	}
+	return res, nil
+}
+
+// --- Jira ---
+
+type jiraClient struct {
+	ctx  context.Context
+	s    BoardExportSettings
+	base string
+}
+
+// errJiraNotFound - задача удалена в проекте
+var errJiraNotFound = errors.New("jira: не найдено")
+
+func (c *jiraClient) do(method, path string, body any, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	data, status, err := fetchWithRetry(c.ctx, jiraProvider, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(c.ctx, method, c.base+"/rest/api/2/"+path, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.SetBasicAuth(c.s.JiraEmail, c.s.JiraToken)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound {
+		return errJiraNotFound
+	}
+	if status < 200 || status >= 300 {
+		return apiError("Jira", status, data)
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// transition переводит задачу в состояние с таким же названием, как статус вакансии.
+// Если в процессе проекта такого состояния нет, статус остаётся только в описании.
+func (c *jiraClient) transition(issue, status string) error {
+	var resp struct {
+		Transitions []struct {
+			ID string `json:"id"`
+			To struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := c.do(http.MethodGet, "issue/"+issue+"/transitions", nil, &resp); err != nil {
+		return err
+	}
+	for _, t := range resp.Transitions {
+		if strings.EqualFold(t.To.Name, status) {
+			return c.do(http.MethodPost, "issue/"+issue+"/transitions", map[string]any{"transition": map[string]string{"id": t.ID}}, nil)
+		}
+	}
+	return nil
+}
+
+// exportToJira создаёт или обновляет задачу на каждую вакансию
+func exportToJira(ctx context.Context, s BoardExportSettings, vacancies []Vacancy, state *boardsSyncState) (boardExportResult, error) {
+	var res boardExportResult
+	c := &jiraClient{ctx: ctx, s: s, base: strings.TrimRight(strings.TrimSpace(s.JiraURL), "/")}
+	issueType := s.JiraIssueType
+	if issueType == "" {
+		issueType = defaultJiraType
+	}
+	project := strings.ToUpper(strings.TrimSpace(s.JiraProject))
+	if state.JiraProject != project {
+		state.JiraProject = project
+		state.JiraIssues = map[string]string{}
+	}
+	progressTotal(ctx, len(vacancies))
+	for _, v := range vacancies {
+		progressStep(ctx, v.Title)
+		fields := map[string]any{"summary": boardCardTitle(v), "description": boardCardText(v)}
+		key := statusTrackerKey(v.Title, v.Company)
+		issue := state.JiraIssues[key]
+		if issue != "" {
+			err := c.do(http.MethodPut, "issue/"+issue, map[string]any{"fields": fields}, nil)
+			if err != nil && !errors.Is(err, errJiraNotFound) {
+				return res, err
+			}
+			if err != nil {
+				issue = ""
+			} else {
+				res.Updated++
+			}
+		}
+		if issue == "" {
+			fields["project"] = map[string]string{"key": project}
+			fields["issuetype"] = map[string]string{"name": issueType}
+			fields["labels"] = []string{"vacancy"}
+			var created struct {
+				Key string `json:"key"`
+			}
+			if err := c.do(http.MethodPost, "issue", map[string]any{"fields": fields}, &created); err != nil {
+				return res, err
+			}
+			issue = created.Key
+			state.JiraIssues[key] = issue
+			res.Created++
+		}
+		if v.Status != "" {
+			if err := c.transition(issue, v.Status); err != nil {
+				log.Printf("Jira: не удалось сменить статус %s: %v", issue, err)
+			}
+		}
+	}
+	return res, nil
+}
+
+// showBoardExportDialog настраивает и запускает выгрузку в Trello или Jira
+func (app *AppMainWindow) showBoardExportDialog() {
+	var dlg *walk.Dialog
+	var trelloKeyLE, trelloTokenLE, trelloBoardLE *walk.LineEdit
+	var jiraURLLE, jiraEmailLE, jiraTokenLE, jiraProjectLE, jiraTypeLE *walk.LineEdit
+	var closePB *walk.PushButton
+
+	s := appSettings.BoardExport
+	if s.JiraIssueType == "" {
+		s.JiraIssueType = defaultJiraType
+	}
+
+	// store сохраняет введённые настройки
+	store := func() {
+		appSettings.BoardExport = BoardExportSettings{
+			TrelloKey:     strings.TrimSpace(trelloKeyLE.Text()),
+			TrelloToken:   strings.TrimSpace(trelloTokenLE.Text()),
+			TrelloBoard:   strings.TrimSpace(trelloBoardLE.Text()),
+			JiraURL:       strings.TrimSpace(jiraURLLE.Text()),
+			JiraEmail:     strings.TrimSpace(jiraEmailLE.Text()),
+			JiraToken:     strings.TrimSpace(jiraTokenLE.Text()),
+			JiraProject:   strings.TrimSpace(jiraProjectLE.Text()),
+			JiraIssueType: strings.TrimSpace(jiraTypeLE.Text()),
+		}
+		saveSettings()
+	}
+
+	// run выгружает вакансии через export, показывая ход выгрузки
+	run := func(service string, export func(context.Context, BoardExportSettings, []Vacancy, *boardsSyncState) (boardExportResult, error)) {
+		store()
+		settings := appSettings.BoardExport
+		vacancies := snapshotVacancies()
+		var res boardExportResult
+		err := runWithProgress(dlg, "Выгрузка в "+service, func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, boardExportTimeout)
+			defer cancel()
+			state := loadBoardsSyncState()
+			var err error
+			res, err = export(ctx, settings, vacancies, &state)
+			// Созданное до ошибки запоминаем, чтобы при повторе не было дублей
+			if saveErr := saveBoardsSyncState(state); saveErr != nil {
+				log.Printf("Ошибка сохранения %s: %v", boardsSyncFile, saveErr)
+			}
+			return err
+		})
+		if errors.Is(err, context.Canceled) {
+			walk.MsgBox(dlg, service, fmt.Sprintf("Выгрузка прервана.\nСоздано: %d\nОбновлено: %d", res.Created, res.Updated), walk.MsgBoxIconInformation)
+			return
+		}
+		if err != nil {
+			showError(dlg, "Ошибка выгрузки в "+service, err)
+			return
+		}
+		app.notify(fmt.Sprintf("Выгрузка в %s: создано %d, обновлено %d.", service, res.Created, res.Updated))
+	}
+
+	field := func(label string, assign **walk.LineEdit, value string, password bool) []Widget {
+		return []Widget{
+			Label{Text: label, TextColor: currentTheme.Text},
+			LineEdit{AssignTo: assign, Text: value, PasswordMode: password},
+		}
+	}
+	trelloRows := append(append(append([]Widget{},
+		field("Ключ API:", &trelloKeyLE, s.TrelloKey, false)...),
+		field("Токен:", &trelloTokenLE, s.TrelloToken, true)...),
+		field("Доска (ссылка или ID):", &trelloBoardLE, s.TrelloBoard, false)...)
+	jiraRows := append(append(append(append(append([]Widget{},
+		field("Адрес сайта:", &jiraURLLE, s.JiraURL, false)...),
+		field("Email:", &jiraEmailLE, s.JiraEmail, false)...),
+		field("API-токен:", &jiraTokenLE, s.JiraToken, true)...),
+		field("Ключ проекта:", &jiraProjectLE, s.JiraProject, false)...),
+		field("Тип задачи:", &jiraTypeLE, s.JiraIssueType, false)...)
+
+	if _, err := (Dialog{
+		AssignTo:     &dlg,
+		Title:        "Выгрузка в Trello и Jira",
+		CancelButton: &closePB,
+		MinSize:      Size{Width: 520, Height: 480},
+		Layout:       VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:   SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			GroupBox{
+				Title:  "Trello: карточка на вакансию, список на каждый статус",
+				Layout: Grid{Columns: 2},
+				Children: append(trelloRows,
+					PushButton{
+						ColumnSpan: 2,
+						Text:       "Выгрузить в Trello",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if trelloKeyLE.Text() == "" || trelloTokenLE.Text() == "" || trelloBoardLE.Text() == "" {
+								walk.MsgBox(dlg, "Trello", "Укажите ключ, токен и доску. Ключ и токен выдаются на trello.com/power-ups/admin.", walk.MsgBoxIconWarning)
+								return
+							}
+							run("Trello", exportToTrello)
+						},
+					},
+				),
+			},
+			GroupBox{
+				Title:  "Jira: задача на вакансию, статус - переходом с тем же названием",
+				Layout: Grid{Columns: 2},
+				Children: append(jiraRows,
+					PushButton{
+						ColumnSpan: 2,
+						Text:       "Выгрузить в Jira",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if !validVacancyURL(jiraURLLE.Text()) || jiraEmailLE.Text() == "" || jiraTokenLE.Text() == "" || jiraProjectLE.Text() == "" {
+								walk.MsgBox(dlg, "Jira", "Укажите адрес сайта (https://...atlassian.net), email, API-токен и ключ проекта.", walk.MsgBoxIconWarning)
+								return
+							}
+							run("Jira", exportToJira)
+						},
+					},
+				),
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							store()
+							dlg.Cancel()
+						},
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Автоматический выключатель источников онлайн-поиска: после нескольких неудач подряд
+// источник на время пропускается, вместо того чтобы каждый поиск ждал его и показывал ошибку.
+// Когда пауза истекает, следующий поиск пробует его снова; успех возвращает источник в строй,
+// новая неудача выключает его на вдвое больший срок.
+const (
+	breakerThreshold   = 3               // Неудач подряд до выключения
+	breakerCooldown    = 5 * time.Minute // Первая пауза
+	breakerMaxCooldown = time.Hour
+)
+
+// circuitBreaker - состояние выключателя одного источника
+type circuitBreaker struct {
+	failures  int // Неудачи подряд
+	trips     int // Сколько раз подряд выключался
+	openUntil time.Time
+}
+
+var breakers = struct {
+	sync.Mutex
+	m map[string]*circuitBreaker
+}{m: map[string]*circuitBreaker{}}
+
+// circuitOpenError - источник пропущен, потому что выключен
+type circuitOpenError struct {
+	Source string
+	Until  time.Time
+}
+
+func (e *circuitOpenError) Error() string {
+	return fmt.Sprintf("%s временно недоступен, повтор после %s", e.Source, e.Until.Format("15:04"))
+}
+
+// breakerAllow проверяет, можно ли обращаться к источнику; если нет - возвращает, до какого времени
+func breakerAllow(source string) (time.Time, bool) {
+	breakers.Lock()
+	defer breakers.Unlock()
+	b := breakers.m[source]
+	if b == nil || !time.Now().Before(b.openUntil) {
+		return time.Time{}, true
+	}
+	return b.openUntil, false
+}
+
+// breakerRecord учитывает результат обращения к источнику. Отмена и отсутствие сети
+// не говорят о неисправности источника и не учитываются.
+func breakerRecord(source string, err error) {
+	if errors.Is(err, context.Canceled) || (err != nil && isOfflineError(err)) {
+		return
+	}
+	breakers.Lock()
+	defer breakers.Unlock()
+	if err == nil {
+		delete(breakers.m, source)
+		return
+	}
+	b := breakers.m[source]
+	if b == nil {
+		b = &circuitBreaker{}
+		breakers.m[source] = b
+	}
+	b.failures++
+	if b.failures < breakerThreshold {
+		return
+	}
+	b.trips++
+	// Удвоение до предела, а не сдвиг на trips: после десятков выключений сдвиг переполнил бы Duration
+	cooldown := breakerCooldown
+	for i := 1; i < b.trips && cooldown < breakerMaxCooldown; i++ {
+		cooldown *= 2
+	}
+	cooldown = min(cooldown, breakerMaxCooldown)
+	b.openUntil = time.Now().Add(cooldown)
+	log.Printf("Источник %s отключён до %s после %d неудач подряд: %v", source, b.openUntil.Format("15:04"), b.failures, err)
+}
+
+// breakerStates - выключенные сейчас источники для окна диагностики
+func breakerStates() []string {
+	breakers.Lock()
+	defer breakers.Unlock()
+	var states []string
+	for source, b := range breakers.m {
+		state := fmt.Sprintf("%s: неудач подряд %d", source, b.failures)
+		if time.Now().Before(b.openUntil) {
+			state += ", отключён до " + b.openUntil.Format("15:04")
+		}
+		states = append(states, state)
+	}
+	sort.Strings(states)
+	return states
+}
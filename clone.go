@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/lxn/walk"
+)
+
+// duplicateVacancy готовит копию вакансии как новую запись: статус сбрасывается,
+// история, даты, причина отказа, оценка оффера и версии описания не переносятся
+func duplicateVacancy(v Vacancy, withResume bool) Vacancy {
+	c := v
+	c.Title = v.Title + " (копия)"
+	c.Keywords = append([]string{}, v.Keywords...)
+	c.Status = possibleStatuses[0]
+	c.StatusHistory = nil
+	c.CreatedAt, c.AppliedAt = time.Time{}, time.Time{}
+	c.InterviewAt, c.DeadlineAt = time.Time{}, time.Time{}
+	c.RejectionReason, c.RejectionComment = "", ""
+	c.OfferPros, c.OfferCons = "", ""
+	c.Related = nil
+	c.Revisions = nil
+	c.Reminders = nil
+	c.FollowUps = nil
+	c.TestTask = nil
+	c.Questions = nil
+	c.Attachments = nil
+	c.Journal = nil
+	c.OrderIndex = 0
+	if !withResume {
+		c.ResumePath, c.ResumeFileName = "", ""
+	}
+	if v.Extra != nil {
+		c.Extra = make(map[string]json.RawMessage, len(v.Extra))
+		for k, raw := range v.Extra {
+			c.Extra[k] = raw
+		}
+	}
+	return c
+}
+
+// duplicateSelectedVacancy открывает диалог добавления с копией выбранной вакансии
+func (app *AppMainWindow) duplicateSelectedVacancy() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		walk.MsgBox(app.MainWindow, "Ошибка", "Пожалуйста, выберите вакансию для дублирования.", walk.MsgBoxIconWarning)
+		return
+	}
+	original, _ := app.vacancyAt(idx)
+
+	withResume := false
+	if original.ResumeFileName != "" {
+		withResume = walk.DlgCmdYes == walk.MsgBox(app.MainWindow, "Дублировать",
+			"Прикрепить к копии то же резюме ("+original.ResumeFileName+")?",
+			walk.MsgBoxYesNo|walk.MsgBoxIconQuestion)
+	}
+
+	c := duplicateVacancy(original, withResume)
+	showVacancyDialogExt(app, &c, false, false)
+}
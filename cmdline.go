@@ -0,0 +1,140 @@
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Параметры запуска для ярлыков с разными конфигурациями:
+//
+//	--data-dir <папка>  папка с вакансиями, резюме и резервными копиями на этот запуск
+//	--profile <имя>     профиль поиска работы (см. profiles.go)
+//	--lang <код>        язык интерфейса, например ru
+//	--theme <имя>       тема оформления: название или light, dark, contrast
+//	--minimized         запуск свёрнутым
+//	--tray              запуск значком в области уведомлений (из автозагрузки, см. tray.go)
+//	--quick-add         сразу перейти к строке быстрого добавления (для ярлыка с горячей клавишей)
+//
+// Значения из командной строки действуют только на этот запуск и не записываются
+// в settings.json, пока пользователь сам не поменяет их в настройках.
+
+// startupOptions - параметры, переданные при запуске
+type startupOptions struct {
+	DataDir   string
+	Lang      string
+	Theme     string
+	Minimized bool
+	Tray      bool
+
+	// Значения из файла настроек, которые заменены параметрами запуска
+	fileLanguage string
+	fileTheme    string
+}
+
+// startup - параметры текущего запуска; разбираются в main до создания окна
+var startup startupOptions
+
+// Короткие имена встроенных тем для командной строки
+var themeAliases = map[string]string{
+	"light":    lightTheme.Name,
+	"dark":     darkTheme.Name,
+	"contrast": highContrastTheme.Name,
+}
+
+// flagValueFromArgs - значение параметра из --name значение или --name=значение
+func flagValueFromArgs(args []string, name string) string {
+	for i, a := range args {
+		if v, ok := strings.CutPrefix(a, "--"+name+"="); ok {
+			return v
+		}
+		if a == "--"+name && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return ""
+}
+
+// boolFlagFromArgs проверяет, передан ли параметр --name или -name
+func boolFlagFromArgs(args []string, name string) bool {
+	for _, a := range args {
+		if a == "--"+name || a == "-"+name {
+			return true
+		}
+	}
+	return false
+}
+
+// parseStartupOptions разбирает параметры запуска, которые не зависят от настроек
+func parseStartupOptions(args []string) startupOptions {
+	o := startupOptions{
+		DataDir:   flagValueFromArgs(args, "data-dir"),
+		Lang:      flagValueFromArgs(args, "lang"),
+		Theme:     flagValueFromArgs(args, "theme"),
+		Minimized: boolFlagFromArgs(args, "minimized"),
+		Tray:      boolFlagFromArgs(args, "tray"),
+	}
+	if o.DataDir != "" {
+		if abs, err := filepath.Abs(o.DataDir); err == nil {
+			o.DataDir = abs
+		}
+		if err := os.MkdirAll(o.DataDir, 0755); err != nil {
+			log.Printf("Ошибка создания папки данных %s: %v", o.DataDir, err)
+		}
+		log.Printf("Папка данных из командной строки: %s", o.DataDir)
+	}
+	return o
+}
+
+// applyStartupOptions заменяет язык и тему из настроек значениями из командной строки.
+// Вызывается после загрузки настроек и своих тем.
+func applyStartupOptions() {
+	if startup.Lang != "" {
+		found := false
+		for _, l := range interfaceLanguages {
+			if strings.EqualFold(l.Code, startup.Lang) {
+				startup.Lang, found = l.Code, true
+				break
+			}
+		}
+		if found {
+			startup.fileLanguage = appSettings.Language
+			appSettings.Language = startup.Lang
+		} else {
+			log.Printf("Язык %q не поддерживается, параметр --lang пропущен", startup.Lang)
+			startup.Lang = ""
+		}
+	}
+	if startup.Theme != "" {
+		name, found := themeAliases[strings.ToLower(startup.Theme)]
+		for _, t := range themes() {
+			if found {
+				break
+			}
+			if strings.EqualFold(t.Name, startup.Theme) {
+				name, found = t.Name, true
+			}
+		}
+		if found {
+			startup.Theme = name
+			startup.fileTheme = appSettings.ThemeName
+			appSettings.ThemeName = name
+		} else {
+			log.Printf("Тема %q не найдена, параметр --theme пропущен", startup.Theme)
+			startup.Theme = ""
+		}
+	}
+}
+
+// withoutStartupOptions возвращает настройки для записи в файл: значения из командной
+// строки, которые пользователь с тех пор не менял, заменяются прежними из файла
+func withoutStartupOptions(s AppSettings) AppSettings {
+	if startup.Lang != "" && s.Language == startup.Lang {
+		s.Language = startup.fileLanguage
+	}
+	if startup.Theme != "" && s.ThemeName == startup.Theme {
+		s.ThemeName = startup.fileTheme
+	}
+	return s
+}
@@ -0,0 +1,337 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Перенос настроек на другой компьютер: настройки, свои темы, сохранённые вкладки
+// фильтров, шаблоны вакансий и синонимы поиска собираются в один JSON-файл.
+// Ключи API и пароли попадают в файл, только если пользователь это разрешил.
+// Пути, счётчики и PIN относятся к конкретному компьютеру и не переносятся.
+
+// Версия формата файла настроек
+const configBundleVersion = 1
+
+// Отметка, по которой файл узнаётся как выгрузка настроек этого приложения
+const configBundleKind = "jobsearch-settings"
+
+// ConfigBundle - выгрузка настроек для переноса
+type ConfigBundle struct {
+	Kind        string            `json:"kind"`
+	Version     int               `json:"version"`
+	AppVersion  string            `json:"app_version"`
+	CreatedAt   time.Time         `json:"created_at"`
+	WithSecrets bool              `json:"with_secrets"` // Есть ли в файле ключи API и пароли
+	Settings    AppSettings       `json:"settings"`
+	Themes      map[string]string `json:"themes,omitempty"` // Имя файла в папке themes - содержимое
+	Templates   []VacancyTemplate `json:"templates,omitempty"`
+	Synonyms    string            `json:"synonyms,omitempty"`
+}
+
+// settingsSecrets - поля настроек с ключами API, токенами и паролями
+func settingsSecrets(s *AppSettings) []*string {
+	return []*string{
+		&s.JoobleAPIKey,
+		&s.TelegramBotToken,
+		&s.Notion.Token,
+		&s.BoardExport.TrelloKey,
+		&s.BoardExport.TrelloToken,
+		&s.BoardExport.JiraToken,
+		&s.SMTP.Password,
+		&s.GoogleCalendar.ClientSecret,
+		&s.GoogleCalendar.RefreshToken,
+	}
+}
+
+// keepLocalSettings переносит в s из local то, что относится к этому компьютеру
+func keepLocalSettings(s *AppSettings, local AppSettings) {
+	s.DataDir = local.DataDir
+	s.BackupDir = local.BackupDir
+	s.LastBackupAt = local.LastBackupAt
+	s.TesseractPath = local.TesseractPath
+	s.TLSCAFile = local.TLSCAFile
+	s.CoverLetterTemplate = local.CoverLetterTemplate
+	s.SkippedVersion = local.SkippedVersion
+	s.TelegramUpdateOffset = local.TelegramUpdateOffset
+	s.Notion.LastSyncAt = local.Notion.LastSyncAt
+	s.AppLock = local.AppLock
+	s.StartWithWindows = local.StartWithWindows
+	s.Telemetry.InstallID = local.Telemetry.InstallID
+	s.Telemetry.LastSentAt = local.Telemetry.LastSentAt
+}
+
+// cloneSettings - независимая копия настроек: срезы и карты не разделяются
+func cloneSettings(s AppSettings) (AppSettings, error) {
+	var c AppSettings
+	data, err := json.Marshal(s)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	return c, err
+}
+
+// buildConfigBundle собирает текущие настройки в выгрузку
+func buildConfigBundle(withSecrets bool) (ConfigBundle, error) {
+	s, err := cloneSettings(withoutStartupOptions(appSettings))
+	if err != nil {
+		return ConfigBundle{}, err
+	}
+	keepLocalSettings(&s, AppSettings{})
+	if !withSecrets {
+		for _, p := range settingsSecrets(&s) {
+			*p = ""
+		}
+	}
+	b := ConfigBundle{
+		Kind:        configBundleKind,
+		Version:     configBundleVersion,
+		AppVersion:  appVersion,
+		CreatedAt:   time.Now(),
+		WithSecrets: withSecrets,
+		Settings:    s,
+		Themes:      map[string]string{},
+		Templates:   loadTemplates(),
+	}
+	paths, _ := filepath.Glob(filepath.Join(themesDir, "*.json"))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return ConfigBundle{}, err
+		}
+		b.Themes[filepath.Base(path)] = string(data)
+	}
+	if data, err := os.ReadFile(dataPath(synonymsFile)); err == nil {
+		b.Synonyms = string(data)
+	}
+	return b, nil
+}
+
+// readConfigBundle читает и проверяет файл с выгрузкой настроек
+func readConfigBundle(path string) (ConfigBundle, error) {
+	var b ConfigBundle
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return b, err
+	}
+	if err := json.Unmarshal(data, &b); err != nil {
+		return b, err
+	}
+	if b.Kind != configBundleKind {
+		return b, errors.New("это не файл настроек приложения")
+	}
+	if b.Version > configBundleVersion {
+		return b, fmt.Errorf("файл сохранён более новой версией приложения (%s)", b.AppVersion)
+	}
+	for name := range b.Themes {
+		if name != filepath.Base(name) || !strings.EqualFold(filepath.Ext(name), ".json") {
+			return b, fmt.Errorf("недопустимое имя файла темы %q", name)
+		}
+	}
+	return b, nil
+}
+
+// describeConfigBundle - что войдёт при импорте, для подтверждения
+func describeConfigBundle(b ConfigBundle) string {
+	lines := []string{
+		fmt.Sprintf("Файл от %s, версия приложения %s.", b.CreatedAt.Local().Format("02.01.2006 15:04"), b.AppVersion),
+		"",
+		"Будут заменены:",
+		"• настройки и вкладки фильтров (" + fmt.Sprint(len(b.Settings.FilterTabs)) + ")",
+	}
+	if len(b.Themes) > 0 {
+		lines = append(lines, fmt.Sprintf("• свои темы (%d)", len(b.Themes)))
+	}
+	if len(b.Templates) > 0 {
+		lines = append(lines, fmt.Sprintf("• шаблоны вакансий (%d, шаблоны с другими именами останутся)", len(b.Templates)))
+	}
+	if b.Synonyms != "" {
+		lines = append(lines, "• синонимы поиска")
+	}
+	lines = append(lines, "")
+	if b.WithSecrets {
+		lines = append(lines, "Ключи API и пароли будут взяты из файла.")
+	} else {
+		lines = append(lines, "Ключей API и паролей в файле нет - останутся текущие.")
+	}
+	lines = append(lines, "Папки, пути к программам и PIN не переносятся.")
+	return strings.Join(lines, "\n")
+}
+
+// applyConfigBundle записывает выгрузку поверх текущих настроек и файлов
+func (app *AppMainWindow) applyConfigBundle(b ConfigBundle) error {
+	if len(b.Themes) > 0 {
+		if err := os.MkdirAll(themesDir, 0755); err != nil {
+			return err
+		}
+		for name, content := range b.Themes {
+			if err := os.WriteFile(filepath.Join(themesDir, name), []byte(content), 0644); err != nil {
+				return err
+			}
+		}
+	}
+	if len(b.Templates) > 0 {
+		templates := loadTemplates()
+		for _, t := range b.Templates {
+			templates = slices.DeleteFunc(templates, func(old VacancyTemplate) bool { return old.Name == t.Name })
+			templates = append(templates, t)
+		}
+		if err := saveTemplates(templates); err != nil {
+			return err
+		}
+	}
+	if b.Synonyms != "" {
+		if err := os.WriteFile(dataPath(synonymsFile), []byte(b.Synonyms), 0644); err != nil {
+			return err
+		}
+		reloadSynonyms()
+	}
+
+	s := b.Settings
+	keepLocalSettings(&s, appSettings)
+	imported, local := settingsSecrets(&s), settingsSecrets(&appSettings)
+	for i, p := range imported {
+		if *p == "" {
+			*p = *local[i] // Пустой ключ в файле не стирает уже введённый
+		}
+	}
+	if err := validateSettings(s); err != nil {
+		return err
+	}
+	old := appSettings
+	appSettings = s
+	userThemes = loadUserThemes()
+	app.applySettings(old)
+	app.applySavedTheme()
+	if len(appSettings.FilterTabs) > 0 {
+		appSettings.ActiveFilterTab = min(max(appSettings.ActiveFilterTab, 0), len(appSettings.FilterTabs)-1)
+		app.applyFilterTab(appSettings.FilterTabs[appSettings.ActiveFilterTab])
+		app.rebuildFilterTabs()
+	}
+	saveSettings()
+	return nil
+}
+
+// exportConfig сохраняет настройки в файл для переноса на другой компьютер
+func (app *AppMainWindow) exportConfig() {
+	var dlg *walk.Dialog
+	var secretsCB *walk.CheckBox
+	var okPB, cancelPB *walk.PushButton
+	withSecrets := false
+
+	res, err := Dialog{
+		AssignTo:      &dlg,
+		Title:         "Экспорт настроек",
+		DefaultButton: &okPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 420, Height: 170},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "В файл войдут настройки, вкладки фильтров, свои темы,\nшаблоны вакансий и синонимы поиска. Вакансии в него не входят -\nдля них есть резервная копия.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			CheckBox{AssignTo: &secretsCB, Text: "Включить ключи API и пароли"},
+			Label{
+				Text:      "Файл с ключами храните так же бережно, как сами пароли.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 8, Italic: true},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &okPB,
+						Text:       "Сохранить...",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							withSecrets = secretsCB.Checked()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}.Run(app.MainWindow)
+	if err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	if res != walk.DlgCmdOK {
+		return
+	}
+
+	fd := &walk.FileDialog{
+		Title:    "Экспорт настроек",
+		Filter:   "Настройки (*.json)|*.json",
+		FilePath: "jobsearch-settings-" + time.Now().Format("2006-01-02") + ".json",
+	}
+	if ok, err := fd.ShowSave(app.MainWindow); err != nil || !ok {
+		return
+	}
+	path := fd.FilePath
+	if filepath.Ext(path) == "" {
+		path += ".json"
+	}
+
+	b, err := buildConfigBundle(withSecrets)
+	if err == nil {
+		var data []byte
+		if data, err = json.MarshalIndent(b, "", "  "); err == nil {
+			err = os.WriteFile(path, data, 0600)
+		}
+	}
+	if err != nil {
+		showError(app.MainWindow, "Экспорт настроек", wrapError("Не удалось сохранить настройки", err))
+		return
+	}
+	log.Printf("Настройки выгружены в %s (ключи API: %v)", path, withSecrets)
+	app.notifyFileSaved("Настройки сохранены: "+filepath.Base(path), path)
+}
+
+// importConfig загружает настройки из файла, сохранённого на другом компьютере
+func (app *AppMainWindow) importConfig() {
+	fd := &walk.FileDialog{
+		Title:  "Импорт настроек",
+		Filter: "Настройки (*.json)|*.json|Все файлы (*.*)|*.*",
+	}
+	if ok, err := fd.ShowOpen(app.MainWindow); err != nil || !ok {
+		return
+	}
+	b, err := readConfigBundle(fd.FilePath)
+	if err != nil {
+		showError(app.MainWindow, "Импорт настроек", wrapError("Не удалось прочитать файл настроек", err))
+		return
+	}
+	if walk.MsgBox(app.MainWindow, "Импорт настроек", describeConfigBundle(b)+"\n\nПродолжить?",
+		walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+		return
+	}
+	if err := app.applyConfigBundle(b); err != nil {
+		showError(app.MainWindow, "Импорт настроек", wrapError("Не удалось применить настройки", err))
+		return
+	}
+	log.Printf("Настройки загружены из %s", fd.FilePath)
+	app.notify("Настройки перенесены из файла.")
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Период ожидания после отказа по умолчанию, в месяцах
+const defaultRejectionCooldownMonths = 6
+
+// CompanyRejection - недавний отказ в компании
+type CompanyRejection struct {
+	Title string
+	At    time.Time
+}
+
+// rejectedAt возвращает время последнего перехода вакансии в статус "Отказ"
+func rejectedAt(v Vacancy) (time.Time, bool) {
+	for i := len(v.StatusHistory) - 1; i >= 0; i-- {
+		if v.StatusHistory[i].Status == rejectedStatus {
+			return v.StatusHistory[i].At, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// recentCompanyRejection ищет самый свежий отказ в компании за последние months месяцев.
+// Вакансия excludeTitle этой же компании (редактируемая) не учитывается.
+func recentCompanyRejection(vacancies []Vacancy, company, excludeTitle string, months int, now time.Time) (CompanyRejection, bool) {
+	company = strings.TrimSpace(company)
+	if months <= 0 || company == "" {
+		return CompanyRejection{}, false
+	}
+	since := now.AddDate(0, -months, 0)
+	var latest CompanyRejection
+	found := false
+	for _, v := range vacancies {
+		if !strings.EqualFold(strings.TrimSpace(v.Company), company) || strings.EqualFold(v.Title, excludeTitle) {
+			continue
+		}
+		at, ok := rejectedAt(v)
+		if !ok || at.Before(since) {
+			continue
+		}
+		if !found || at.After(latest.At) {
+			latest = CompanyRejection{Title: v.Title, At: at}
+			found = true
+		}
+	}
+	return latest, found
+}
+
+// cooldownMessage кратко описывает недавний отказ для предупреждения
+func cooldownMessage(r CompanyRejection, months int) string {
+	return fmt.Sprintf("Отказ в этой компании %s ('%s') - меньше %d мес. назад.", r.At.Local().Format("02.01.2006"), r.Title, months)
+}
+
+// checkCompanyCooldown ищет недавний отказ в компании по всему списку вакансий
+func checkCompanyCooldown(company, excludeTitle string) (CompanyRejection, bool) {
+	allVacanciesMutex.Lock()
+	defer allVacanciesMutex.Unlock()
+	return recentCompanyRejection(allVacancies, company, excludeTitle, appSettings.RejectionCooldownMonths, time.Now())
+}
+
+// beforeApplying - статусы, в которых вакансия ещё не дошла до отклика
+func beforeApplying(status string) bool {
+	return status == "" || status == possibleStatuses[0] || status == "Планирую откликнуться" || status == appliedStatus
+}
+
+// confirmApplyAfterRejection спрашивает подтверждение, если вакансия переходит в "Откликнулся",
+// а в компании недавно был отказ. Возвращает false, если пользователь передумал.
+func confirmApplyAfterRejection(owner walk.Form, v Vacancy, newStatus string) bool {
+	if newStatus != appliedStatus || v.Status == appliedStatus {
+		return true
+	}
+	r, ok := checkCompanyCooldown(v.Company, v.Title)
+	if !ok {
+		return true
+	}
+	return walk.DlgCmdYes == walk.MsgBox(owner, "Недавний отказ",
+		v.Company+": "+cooldownMessage(r, appSettings.RejectionCooldownMonths)+
+			"\nСлишком частые отклики после отказа могут не понравиться рекрутерам.\n\nВсё равно отметить отклик?",
+		walk.MsgBoxYesNo|walk.MsgBoxIconWarning)
+}
+
+// showCooldownSettings задаёт период ожидания после отказа
+func (app *AppMainWindow) showCooldownSettings() {
+	var dlg *walk.Dialog
+	var monthsNE *walk.NumberEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Период ожидания после отказа",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 380, Height: 170},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Предупреждать об отклике в компанию, где был отказ за последние\n(месяцев, 0 - не предупреждать):",
+				TextColor: currentTheme.Text,
+				Font:      Font{Bold: true, PointSize: 9},
+			},
+			NumberEdit{
+				AssignTo:           &monthsNE,
+				Value:              float64(appSettings.RejectionCooldownMonths),
+				MinValue:           0,
+				MaxValue:           36,
+				SpinButtonsVisible: true,
+				Font:               Font{PointSize: 9},
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							appSettings.RejectionCooldownMonths = int(monthsNE.Value())
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
@@ -0,0 +1,424 @@
+package main
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Поля слияния, доступные в шаблоне письма
+const coverLetterFieldsHelp = "Поля в шаблоне: {{.Company}}, {{.Title}}, {{.Recruiter}}, {{.RecruiterEmail}}, {{.Name}}, {{.Position}}, {{.Location}}, {{.Skills}}, {{.Date}}"
+
+// coverLetterData - значения полей слияния
+type coverLetterData struct {
+	Company        string
+	Title          string
+	Recruiter      string
+	RecruiterEmail string
+	Name           string // Из профиля соискателя
+	Position       string
+	Location       string
+	Skills         string // Навыки профиля, найденные в вакансии
+	Date           string
+}
+
+// newCoverLetterData заполняет поля из вакансии и профиля
+func newCoverLetterData(v Vacancy, p UserProfile) coverLetterData {
+	matched, _ := splitSkills(p.Skills, v)
+	return coverLetterData{
+		Company:        v.Company,
+		Title:          v.Title,
+		Recruiter:      v.Recruiter,
+		RecruiterEmail: v.RecruiterEmail,
+		Name:           p.Name,
+		Position:       p.DesiredPosition,
+		Location:       p.Location,
+		Skills:         strings.Join(matched, ", "),
+		Date:           time.Now().Format("02.01.2006"),
+	}
+}
+
+var (
+	// Абзац документа; <w:pPr> не подходит из-за символа после "<w:p"
+	docxParagraphRe = regexp.MustCompile(`(?s)<w:p[ >].*?</w:p>`)
+	// Фрагмент текста внутри абзаца; <w:tab/> и <w:tbl> не подходят
+	docxTextRe = regexp.MustCompile(`(?s)(<w:t(?: [^>]*)?>)(.*?)</w:t>`)
+)
+
+// docxEscape экранирует текст для <w:t>, переводы строк превращает в <w:br/>
+func docxEscape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(strings.ReplaceAll(s, "\r", "")))
+	return strings.ReplaceAll(b.String(), "&#xA;", `</w:t><w:br/><w:t xml:space="preserve">`)
+}
+
+// mergeDocxXML подставляет поля в абзацы части документа.
+// Word часто разбивает {{.Company}} на несколько фрагментов, поэтому весь текст абзаца с полями
+// собирается в первый фрагмент, а остальные очищаются; оформление внутри такого абзаца берётся из первого фрагмента.
+func mergeDocxXML(part []byte, data coverLetterData) ([]byte, error) {
+	var mergeErr error
+	out := docxParagraphRe.ReplaceAllFunc(part, func(p []byte) []byte {
+		if mergeErr != nil {
+			return p
+		}
+		runs := docxTextRe.FindAllSubmatchIndex(p, -1)
+		if len(runs) == 0 {
+			return p
+		}
+		var text strings.Builder
+		for _, r := range runs {
+			text.Write(p[r[4]:r[5]])
+		}
+		plain := html.UnescapeString(text.String())
+		if !strings.Contains(plain, "{{") {
+			return p
+		}
+		tmpl, err := template.New("field").Parse(plain)
+		if err != nil {
+			mergeErr = fmt.Errorf("ошибка в шаблоне в абзаце «%s»: %w", truncateRunes(plain, 60), err)
+			return p
+		}
+		var merged strings.Builder
+		if err := tmpl.Execute(&merged, data); err != nil {
+			mergeErr = fmt.Errorf("неизвестное поле в абзаце «%s»: %w", truncateRunes(plain, 60), err)
+			return p
+		}
+		var b bytes.Buffer
+		last := 0
+		for i, r := range runs {
+			b.Write(p[last:r[0]])
+			if i == 0 {
+				b.WriteString(`<w:t xml:space="preserve">`)
+				b.WriteString(docxEscape(merged.String()))
+			} else {
+				b.Write(p[r[2]:r[3]])
+			}
+			b.WriteString("</w:t>")
+			last = r[1]
+		}
+		b.Write(p[last:])
+		return b.Bytes()
+	})
+	return out, mergeErr
+}
+
+// Текст, перенос строки и табуляция внутри абзаца - по порядку следования
+var docxTokenRe = regexp.MustCompile(`(?s)<w:t(?: [^>]*)?>(.*?)</w:t>|<w:br/>|<w:tab/>`)
+
+// docxPlainText извлекает текст документа по абзацам, например, для тела письма
+func docxPlainText(docx []byte) (string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(docx), int64(len(docx)))
+	if err != nil {
+		return "", err
+	}
+	for _, f := range zr.File {
+		if f.Name != "word/document.xml" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return "", err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return "", err
+		}
+		var lines []string
+		for _, p := range docxParagraphRe.FindAll(content, -1) {
+			var line strings.Builder
+			for _, m := range docxTokenRe.FindAllSubmatch(p, -1) {
+				switch string(m[0]) {
+				case "<w:br/>":
+					line.WriteString("\n")
+				case "<w:tab/>":
+					line.WriteString("\t")
+				default:
+					line.WriteString(html.UnescapeString(string(m[1])))
+				}
+			}
+			lines = append(lines, line.String())
+		}
+		return strings.Join(lines, "\n"), nil
+	}
+	return "", errors.New("в документе нет word/document.xml")
+}
+
+// isDocxTextPart - части документа, в которых есть текст с полями: тело, колонтитулы
+func isDocxTextPart(name string) bool {
+	if path.Dir(name) != "word" || path.Ext(name) != ".xml" {
+		return false
+	}
+	base := path.Base(name)
+	return base == "document.xml" || strings.HasPrefix(base, "header") || strings.HasPrefix(base, "footer")
+}
+
+// mergeDocx заполняет поля шаблона .docx и возвращает готовый документ
+func mergeDocx(templateData []byte, data coverLetterData) ([]byte, error) {
+	zr, err := zip.NewReader(bytes.NewReader(templateData), int64(len(templateData)))
+	if err != nil {
+		return nil, fmt.Errorf("шаблон не похож на документ .docx: %w", err)
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	hasDocument := false
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, err
+		}
+		if isDocxTextPart(f.Name) {
+			hasDocument = hasDocument || f.Name == "word/document.xml"
+			if content, err = mergeDocxXML(content, data); err != nil {
+				return nil, err
+			}
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: f.Name, Method: zip.Deflate, Modified: f.Modified})
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(content); err != nil {
+			return nil, err
+		}
+	}
+	if !hasDocument {
+		return nil, errors.New("в шаблоне нет word/document.xml - это не документ Word")
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Абзацы встроенного шаблона письма
+var defaultCoverLetterParagraphs = []string{
+	"{{.Name}}",
+	"{{.Location}}",
+	"{{.Date}}",
+	"",
+	"Здравствуйте{{if .Recruiter}}, {{.Recruiter}}{{end}}!",
+	"",
+	"Меня заинтересовала вакансия «{{.Title}}» в компании {{.Company}}, и я хотел(а) бы предложить свою кандидатуру.",
+	"{{if .Skills}}Мой опыт хорошо совпадает с требованиями: {{.Skills}}.{{end}}",
+	"Буду рад(а) рассказать о себе подробнее на собеседовании. Резюме прилагаю.",
+	"",
+	"С уважением,",
+	"{{.Name}}",
+}
+
+// defaultCoverLetterTemplate собирает минимальный .docx со встроенным шаблоном письма
+func defaultCoverLetterTemplate() ([]byte, error) {
+	var body strings.Builder
+	for _, p := range defaultCoverLetterParagraphs {
+		body.WriteString(`<w:p><w:r><w:t xml:space="preserve">`)
+		xml.EscapeText(&body, []byte(p))
+		body.WriteString(`</w:t></w:r></w:p>`)
+	}
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/></Relationships>`},
+		{"word/document.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>` + body.String() + `<w:sectPr><w:pgSz w:w="11906" w:h="16838"/><w:pgMar w:top="1134" w:right="850" w:bottom="1134" w:left="1701" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr></w:body></w:document>`},
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for _, part := range parts {
+		w, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(w, part.content); err != nil {
+			return nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// coverLetterTemplateData читает шаблон из настроек или берёт встроенный
+func coverLetterTemplateData(templatePath string) ([]byte, error) {
+	if templatePath == "" {
+		return defaultCoverLetterTemplate()
+	}
+	data, err := os.ReadFile(templatePath)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать шаблон: %w", err)
+	}
+	return data, nil
+}
+
+// attachCoverLetter сохраняет контакт рекрутера и прикрепляет письмо к вакансии
+func attachCoverLetter(v Vacancy, a Attachment) bool {
+	allVacanciesMutex.Lock()
+	found := false
+	for i := range allVacancies {
+		if sameVacancy(allVacancies[i].Title, allVacancies[i].Company, v.Title, v.Company) {
+			allVacancies[i].Recruiter = v.Recruiter
+			allVacancies[i].RecruiterEmail = v.RecruiterEmail
+			allVacancies[i].Attachments = append(allVacancies[i].Attachments, a)
+			found = true
+			break
+		}
+	}
+	allVacanciesMutex.Unlock()
+	if found {
+		saveVacancies()
+		vacancyEvents.Publish(VacancyEvent{Kind: VacancyUpdated, Title: v.Title, Company: v.Company})
+	}
+	return found
+}
+
+// showCoverLetterForSelected создаёт сопроводительное письмо .docx к выбранной вакансии
+func (app *AppMainWindow) showCoverLetterForSelected() {
+	v, ok := app.selectedVacancyForAttachments()
+	if !ok {
+		return
+	}
+
+	var dlg *walk.Dialog
+	var recruiterLE, emailLE, templateLE *walk.LineEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	chooseTemplate := func() {
+		fd := new(walk.FileDialog)
+		fd.Title = "Шаблон сопроводительного письма"
+		fd.Filter = "Документы Word (*.docx)|*.docx"
+		if ok, err := fd.ShowOpen(dlg); err != nil || !ok {
+			return
+		}
+		templateLE.SetText(fd.FilePath)
+	}
+
+	// Встроенный шаблон сохраняется в файл, чтобы его можно было отредактировать в Word
+	createTemplate := func() {
+		fd := new(walk.FileDialog)
+		fd.Title = "Сохранить шаблон письма"
+		fd.Filter = "Документы Word (*.docx)|*.docx"
+		fd.FilePath = "Шаблон сопроводительного письма.docx"
+		if ok, err := fd.ShowSave(dlg); err != nil || !ok {
+			return
+		}
+		filePath := fd.FilePath
+		if !strings.EqualFold(filepath.Ext(filePath), ".docx") {
+			filePath += ".docx"
+		}
+		data, err := defaultCoverLetterTemplate()
+		if err == nil {
+			err = os.WriteFile(filePath, data, 0o644)
+		}
+		if err != nil {
+			showError(dlg, "Ошибка", wrapError("Не удалось сохранить шаблон", err))
+			return
+		}
+		templateLE.SetText(filePath)
+		if err := openFileExternally(filePath); err != nil {
+			log.Printf("Не удалось открыть шаблон %s: %v", filePath, err)
+		}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Сопроводительное письмо",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 560, Height: 260},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: fmt.Sprintf("«%s» - %s", v.Title, v.Company), TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 10}},
+			Composite{
+				Layout: Grid{Columns: 4, MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Рекрутер:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &recruiterLE, Text: v.Recruiter, ColumnSpan: 3},
+					Label{Text: "Email:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &emailLE, Text: v.RecruiterEmail, ColumnSpan: 3},
+					Label{Text: "Шаблон:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &templateLE, Text: appSettings.CoverLetterTemplate, CueBanner: "встроенный шаблон"},
+					PushButton{Text: "Выбрать...", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: chooseTemplate},
+					PushButton{Text: "Создать шаблон...", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: createTemplate},
+				},
+			},
+			Label{Text: coverLetterFieldsHelp, TextColor: currentTheme.Text, Font: Font{PointSize: 8}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Создать письмо",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							v.Recruiter = strings.TrimSpace(recruiterLE.Text())
+							v.RecruiterEmail = strings.TrimSpace(emailLE.Text())
+							if v.RecruiterEmail != "" && !strings.Contains(v.RecruiterEmail, "@") {
+								walk.MsgBox(dlg, "Сопроводительное письмо", "Адрес рекрутера должен содержать @.", walk.MsgBoxIconWarning)
+								return
+							}
+							templatePath := strings.TrimSpace(templateLE.Text())
+							tmpl, err := coverLetterTemplateData(templatePath)
+							var letter []byte
+							if err == nil {
+								letter, err = mergeDocx(tmpl, newCoverLetterData(v, appSettings.Profile))
+							}
+							var a Attachment
+							if err == nil {
+								a, err = storeAttachmentData(letter, ".docx", "Сопроводительное письмо для "+attachmentTarget(v)+".docx")
+							}
+							if err != nil {
+								log.Printf("Не удалось создать сопроводительное письмо: %v", err)
+								showError(dlg, "Ошибка", wrapError("Не удалось создать письмо", err))
+								return
+							}
+							if !attachCoverLetter(v, a) {
+								os.Remove(attachmentPath(a))
+								walk.MsgBox(dlg, "Ошибка", "Не удалось найти вакансию.", walk.MsgBoxIconError)
+								return
+							}
+							if templatePath != appSettings.CoverLetterTemplate {
+								appSettings.CoverLetterTemplate = templatePath
+								saveSettings()
+							}
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
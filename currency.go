@@ -0,0 +1,343 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+const (
+	exchangeRatesFile = "exchange_rates.json"
+	exchangeRatesURL  = "https://www.cbr-xml-daily.ru/daily_json.js" // Курсы ЦБ РФ к рублю
+)
+
+// Валюты, в которые можно пересчитывать зарплаты, и их знаки в таблице
+var salaryCurrencies = []string{"RUB", "USD", "EUR", "KZT"}
+
+var currencySymbols = map[string]string{"RUB": "₽", "USD": "$", "EUR": "€", "KZT": "₸"}
+
+var ratesProvider = &searchProvider{Name: "cbr", limiter: &rateLimiter{interval: time.Second}}
+
+// ExchangeRates - курсы валют за день: сколько рублей стоит единица валюты
+type ExchangeRates struct {
+	Date      string             `json:"date"`       // Дата курсов по данным ЦБ
+	FetchedAt time.Time          `json:"fetched_at"` // Когда курсы скачаны
+	RubPer    map[string]float64 `json:"rub_per"`
+}
+
+var (
+	exchangeRates      ExchangeRates
+	exchangeRatesMutex sync.Mutex
+	exchangeRatesOnce  sync.Once
+)
+
+// loadExchangeRatesLocked читает сохранённые курсы; вызывается под exchangeRatesMutex
+func loadExchangeRatesLocked() {
+	exchangeRatesOnce.Do(func() {
+		data, err := os.ReadFile(dataPath(exchangeRatesFile))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				log.Printf("Ошибка чтения файла %s: %v", exchangeRatesFile, err)
+			}
+			return
+		}
+		if err := json.Unmarshal(data, &exchangeRates); err != nil {
+			log.Printf("Ошибка декодирования JSON из файла %s: %v", exchangeRatesFile, err)
+		}
+	})
+}
+
+// currentExchangeRates возвращает копию известных курсов
+func currentExchangeRates() ExchangeRates {
+	exchangeRatesMutex.Lock()
+	defer exchangeRatesMutex.Unlock()
+	loadExchangeRatesLocked()
+	return exchangeRates
+}
+
+// exchangeRatesStale - курсы не скачивались сегодня
+func exchangeRatesStale() bool {
+	fetched := currentExchangeRates().FetchedAt
+	y1, m1, d1 := fetched.Date()
+	y2, m2, d2 := time.Now().Date()
+	return y1 != y2 || m1 != m2 || d1 != d2
+}
+
+// refreshExchangeRates скачивает курсы ЦБ и сохраняет их в файл
+func refreshExchangeRates(ctx context.Context) error {
+	body, status, err := fetchWithRetry(ctx, ratesProvider, func() (*http.Request, error) {
+		return http.NewRequestWithContext(ctx, "GET", exchangeRatesURL, nil)
+	})
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("сервер курсов вернул HTTP %d", status)
+	}
+	var resp struct {
+		Date   string `json:"Date"`
+		Valute map[string]struct {
+			Nominal float64 `json:"Nominal"`
+			Value   float64 `json:"Value"`
+		} `json:"Valute"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return fmt.Errorf("не удалось разобрать ответ сервера курсов: %w", err)
+	}
+	rates := ExchangeRates{FetchedAt: time.Now(), RubPer: map[string]float64{"RUB": 1}}
+	if t, err := time.Parse(time.RFC3339, resp.Date); err == nil {
+		rates.Date = t.Format("2006-01-02")
+	}
+	for code, v := range resp.Valute {
+		if v.Nominal > 0 && v.Value > 0 {
+			rates.RubPer[code] = v.Value / v.Nominal
+		}
+	}
+	if len(rates.RubPer) == 1 {
+		return errors.New("в ответе сервера нет курсов валют")
+	}
+
+	exchangeRatesMutex.Lock()
+	loadExchangeRatesLocked()
+	exchangeRates = rates
+	exchangeRatesMutex.Unlock()
+
+	data, err := json.MarshalIndent(rates, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(dataPath(exchangeRatesFile), data, 0644); err != nil {
+		log.Printf("Ошибка записи файла %s: %v", exchangeRatesFile, err)
+	}
+	return nil
+}
+
+// salaryTargetCurrency - валюта колонки "Зарплата"
+func salaryTargetCurrency() string {
+	if appSettings.SalaryTargetCurrency == "" {
+		return "RUB"
+	}
+	return appSettings.SalaryTargetCurrency
+}
+
+// convertAmount пересчитывает сумму из валюты from в to по известным курсам
+func convertAmount(rates ExchangeRates, amount int, from, to string) (int, bool) {
+	if from == to || amount == 0 {
+		return amount, true
+	}
+	fromRub, ok1 := rates.RubPer[from]
+	toRub, ok2 := rates.RubPer[to]
+	if !ok1 || !ok2 {
+		return 0, false
+	}
+	return int(float64(amount)*fromRub/toRub + 0.5), true
+}
+
+// convertedSalary - вилка вакансии в валюте колонки. converted - суммы пересчитаны из другой валюты.
+func convertedSalary(rates ExchangeRates, v Vacancy) (minValue, maxValue int, converted, ok bool) {
+	if v.SalaryMin == 0 && v.SalaryMax == 0 {
+		return 0, 0, false, false
+	}
+	from, to := v.SalaryCurrency, salaryTargetCurrency()
+	if from == "" {
+		from = "RUB"
+	}
+	minValue, ok1 := convertAmount(rates, v.SalaryMin, from, to)
+	maxValue, ok2 := convertAmount(rates, v.SalaryMax, from, to)
+	return minValue, maxValue, from != to, ok1 && ok2
+}
+
+// salaryColumnText - текст ячейки "Зарплата": вилка в валюте колонки, пересчитанная помечается "≈".
+// Если курса нет, показывается исходная строка.
+func salaryColumnText(rates ExchangeRates, v Vacancy) string {
+	minValue, maxValue, converted, ok := convertedSalary(rates, v)
+	if !ok {
+		return v.Salary
+	}
+	var text string
+	switch {
+	case minValue > 0 && maxValue > 0 && minValue != maxValue:
+		text = formatMoney(minValue) + "–" + formatMoney(maxValue)
+	case minValue > 0 && maxValue > 0:
+		text = formatMoney(minValue)
+	case minValue > 0:
+		text = "от " + formatMoney(minValue)
+	default:
+		text = "до " + formatMoney(maxValue)
+	}
+	text += " " + currencySymbols[salaryTargetCurrency()]
+	if converted {
+		text = "≈ " + text
+	}
+	return text
+}
+
+// salarySortValue - середина вилки в валюте колонки для сортировки; без зарплаты - 0
+func salarySortValue(rates ExchangeRates, v Vacancy) int {
+	minValue, maxValue, _, ok := convertedSalary(rates, v)
+	if !ok {
+		return 0
+	}
+	return salaryMidpoint(Vacancy{SalaryMin: minValue, SalaryMax: maxValue})
+}
+
+// salaryColumnTitle - заголовок колонки с валютой пересчёта
+func salaryColumnTitle() string {
+	return "Зарплата, " + currencySymbols[salaryTargetCurrency()]
+}
+
+// needsExchangeRates - есть вакансии с зарплатой не в валюте колонки
+func needsExchangeRates() bool {
+	target := salaryTargetCurrency()
+	allVacanciesMutex.Lock()
+	defer allVacanciesMutex.Unlock()
+	for _, v := range allVacancies {
+		if (v.SalaryMin > 0 || v.SalaryMax > 0) && v.SalaryCurrency != "" && v.SalaryCurrency != target {
+			return true
+		}
+	}
+	return false
+}
+
+// updateExchangeRates в фоне обновляет курсы раз в день, если в списке есть зарплаты в других валютах
+func (app *AppMainWindow) updateExchangeRates() {
+	if app.ratesFetching || app.offline || time.Since(app.ratesAttemptAt) < 30*time.Minute ||
+		!exchangeRatesStale() || !needsExchangeRates() {
+		return
+	}
+	app.ratesFetching = true
+	app.ratesAttemptAt = time.Now()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		err := refreshExchangeRates(ctx)
+		app.MainWindow.Synchronize(func() {
+			app.ratesFetching = false
+			if err != nil {
+				log.Printf("Не удалось обновить курсы валют: %v", err)
+				return
+			}
+			app.refreshSalaryColumn()
+		})
+	}()
+}
+
+// refreshSalaryColumn перерисовывает колонку зарплат после смены курсов или валюты
+func (app *AppMainWindow) refreshSalaryColumn() {
+	if app.vacancyTable == nil {
+		return
+	}
+	app.updateSortHeaders() // Заголовок колонки с валютой
+	app.vacancyModel.rates = currentExchangeRates()
+	if n := len(app.vacancyModel.items); n > 0 {
+		app.vacancyModel.PublishRowsChanged(0, n-1)
+	}
+}
+
+// ratesSummary - строка с датой и курсами для окна настроек
+func ratesSummary(rates ExchangeRates) string {
+	if len(rates.RubPer) == 0 {
+		return "Курсы ещё не загружены."
+	}
+	date := rates.Date
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		date = t.Format("02.01.2006")
+	}
+	var parts []string
+	for _, c := range salaryCurrencies[1:] {
+		if r, ok := rates.RubPer[c]; ok {
+			parts = append(parts, fmt.Sprintf("%s %.2f ₽", c, r))
+		}
+	}
+	return "Курсы ЦБ РФ на " + date + ": " + strings.Join(parts, ", ")
+}
+
+// showCurrencySettings - выбор валюты колонки "Зарплата" и ручное обновление курсов
+func (app *AppMainWindow) showCurrencySettings() {
+	var dlg *walk.Dialog
+	var currencyCB *walk.ComboBox
+	var ratesLabel *walk.Label
+	var refreshPB, acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Курсы валют",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 420, Height: 200},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Пересчитывать зарплаты в:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			ComboBox{
+				AssignTo:     &currencyCB,
+				Model:        salaryCurrencies,
+				CurrentIndex: indexOfString(salaryCurrencies, salaryTargetCurrency()),
+				Font:         Font{PointSize: 9},
+			},
+			Label{AssignTo: &ratesLabel, Text: ratesSummary(currentExchangeRates()), TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			PushButton{
+				AssignTo:   &refreshPB,
+				Text:       "Обновить курсы",
+				Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+				OnClicked: func() {
+					refreshPB.SetEnabled(false)
+					ratesLabel.SetText("Загрузка курсов...")
+					go func() {
+						ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+						defer cancel()
+						err := refreshExchangeRates(ctx)
+						dlg.Synchronize(func() {
+							refreshPB.SetEnabled(true)
+							if err != nil {
+								ratesLabel.SetText("Не удалось загрузить курсы: " + err.Error())
+								return
+							}
+							ratesLabel.SetText(ratesSummary(currentExchangeRates()))
+						})
+					}()
+				},
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							appSettings.SalaryTargetCurrency = salaryCurrencies[currencyCB.CurrentIndex()]
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	app.refreshSalaryColumn()
+	app.updateExchangeRates()
+}
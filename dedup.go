@@ -0,0 +1,291 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// DedupSettings - как распознавать одну и ту же вакансию при импорте и что делать с повтором
+type DedupSettings struct {
+	MatchURL        bool   `json:"match_url"`                  // Одна ссылка (без меток рекламы и слэша в конце) - одна вакансия
+	TitleSimilarity int    `json:"title_similarity,omitempty"` // Похожесть названий в процентах для той же компании, 0 - только точное совпадение
+	OnCollision     string `json:"on_collision,omitempty"`     // dedupSkip, dedupMerge или dedupKeepBoth
+}
+
+// Что делать, если импортируемая вакансия уже есть в списке
+const (
+	dedupSkip     = "skip"  // Не добавлять
+	dedupMerge    = "merge" // Дополнить имеющуюся пустыми у неё полями
+	dedupKeepBoth = "keep"  // Добавить как отдельную вакансию
+)
+
+var dedupCollisionChoices = []struct{ Code, Name string }{
+	{dedupSkip, "Пропускать"},
+	{dedupMerge, "Объединять с имеющейся"},
+	{dedupKeepBoth, "Добавлять обе"},
+}
+
+// Параметры ссылок, которые не меняют вакансию: метки рекламы и переходов
+var trackingURLParams = map[string]bool{
+	"gclid": true, "fbclid": true, "yclid": true, "from": true, "ref": true, "refid": true,
+	"trk": true, "trackingid": true, "hhtmfrom": true, "hhtmfromlabel": true,
+}
+
+// canonicalURL приводит ссылку на вакансию к виду для сравнения: без схемы, www, меток и слэша в конце
+func canonicalURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return ""
+	}
+	q := u.Query()
+	for key := range q {
+		if k := strings.ToLower(key); strings.HasPrefix(k, "utm_") || trackingURLParams[k] {
+			q.Del(key)
+		}
+	}
+	s := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.") + strings.TrimRight(u.EscapedPath(), "/")
+	if query := q.Encode(); query != "" {
+		s += "?" + query
+	}
+	return s
+}
+
+// titleSimilarity - похожесть названий в процентах по расстоянию Левенштейна между словами
+func titleSimilarity(a, b string) int {
+	ra := []rune(strings.Join(searchWords(strings.ToLower(a)), " "))
+	rb := []rune(strings.Join(searchWords(strings.ToLower(b)), " "))
+	longest := max(len(ra), len(rb))
+	if longest == 0 {
+		return 100
+	}
+	return 100 - 100*levenshtein(ra, rb)/longest
+}
+
+// isDuplicateVacancy проверяет, одна ли это вакансия, по правилам s
+func isDuplicateVacancy(a, b Vacancy, s DedupSettings) bool {
+	if s.MatchURL {
+		if ua := canonicalURL(a.SourceURL); ua != "" && ua == canonicalURL(b.SourceURL) {
+			return true
+		}
+	}
+	if !strings.EqualFold(strings.TrimSpace(a.Company), strings.TrimSpace(b.Company)) {
+		return false
+	}
+	if strings.EqualFold(strings.TrimSpace(a.Title), strings.TrimSpace(b.Title)) {
+		return true
+	}
+	return s.TitleSimilarity > 0 && titleSimilarity(a.Title, b.Title) >= s.TitleSimilarity
+}
+
+// findDuplicateLocked ищет в списке ту же вакансию. Вызывается при заблокированном allVacanciesMutex.
+func findDuplicateLocked(v Vacancy) int {
+	return slices.IndexFunc(allVacancies, func(existing Vacancy) bool {
+		return isDuplicateVacancy(existing, v, appSettings.Dedup)
+	})
+}
+
+// findDuplicate возвращает вакансию из списка, дублем которой будет v
+func findDuplicate(v Vacancy) (Vacancy, bool) {
+	allVacanciesMutex.Lock()
+	defer allVacanciesMutex.Unlock()
+	if i := findDuplicateLocked(v); i != -1 {
+		return allVacancies[i], true
+	}
+	return Vacancy{}, false
+}
+
+// uniqueTitleLocked - название для второй вакансии с тем же названием и компанией: «Название (2)».
+// Вызывается при заблокированном allVacanciesMutex.
+func uniqueTitleLocked(v Vacancy) string {
+	taken := func(title string) bool {
+		return slices.ContainsFunc(allVacancies, func(existing Vacancy) bool {
+			return sameVacancy(existing.Title, existing.Company, title, v.Company)
+		})
+	}
+	if !taken(v.Title) {
+		return v.Title
+	}
+	for n := 2; ; n++ {
+		if title := fmt.Sprintf("%s (%d)", v.Title, n); !taken(title) {
+			return title
+		}
+	}
+}
+
+// mergeVacancy дополняет вакансию полями импортированной, которые у неё пусты; ключевые слова объединяются
+func mergeVacancy(dst *Vacancy, src Vacancy) {
+	fill := func(field *string, value string) {
+		if strings.TrimSpace(*field) == "" {
+			*field = value
+		}
+	}
+	fill(&dst.Description, src.Description)
+	fill(&dst.SourceURL, src.SourceURL)
+	fill(&dst.Location, src.Location)
+	fill(&dst.WorkFormat, src.WorkFormat)
+	fill(&dst.Source, src.Source)
+	fill(&dst.Recruiter, src.Recruiter)
+	fill(&dst.RecruiterEmail, src.RecruiterEmail)
+	if dst.Salary == "" && src.Salary != "" {
+		dst.Salary, dst.SalaryMin, dst.SalaryMax, dst.SalaryCurrency = src.Salary, src.SalaryMin, src.SalaryMax, src.SalaryCurrency
+	}
+	if dst.PostedAt.IsZero() {
+		dst.PostedAt = src.PostedAt
+	}
+	if dst.DeadlineAt.IsZero() {
+		dst.DeadlineAt = src.DeadlineAt
+	}
+	if notes := strings.TrimSpace(src.Notes); notes != "" && !strings.Contains(dst.Notes, notes) {
+		dst.Notes = strings.TrimSpace(dst.Notes + "\n\n" + notes)
+	}
+	dst.Keywords = uniqueFold(append(dst.Keywords, src.Keywords...))
+}
+
+// Итог импорта одной вакансии
+type importOutcome int
+
+const (
+	importAdded importOutcome = iota
+	importMerged
+	importSkipped
+)
+
+// importVacancyLocked добавляет вакансию по правилам дедупликации.
+// Вызывается при заблокированном allVacanciesMutex.
+func importVacancyLocked(v Vacancy) importOutcome {
+	if i := findDuplicateLocked(v); i != -1 {
+		switch appSettings.Dedup.OnCollision {
+		case dedupMerge:
+			mergeVacancy(&allVacancies[i], v)
+			return importMerged
+		case dedupKeepBoth:
+			v.Title = uniqueTitleLocked(v)
+		default:
+			return importSkipped
+		}
+	}
+	stampNewVacancy(&v)
+	allVacancies = append(allVacancies, v)
+	return importAdded
+}
+
+// openImportedVacancy открывает вакансию из файла, страницы или Telegram в диалоге добавления,
+// если её ещё нет в списке; иначе поступает по правилам дедупликации.
+// Возвращает true, если вакансия добавлена, объединена с имеющейся или уже была в списке.
+func (app *AppMainWindow) openImportedVacancy(v Vacancy) bool {
+	allVacanciesMutex.Lock()
+	i := findDuplicateLocked(v)
+	var existing Vacancy
+	if i != -1 {
+		existing = allVacancies[i]
+		switch appSettings.Dedup.OnCollision {
+		case dedupMerge:
+			mergeVacancy(&allVacancies[i], v)
+		case dedupKeepBoth:
+			v.Title = uniqueTitleLocked(v)
+		}
+	}
+	allVacanciesMutex.Unlock()
+
+	if i == -1 || appSettings.Dedup.OnCollision == dedupKeepBoth {
+		return showVacancyDialogExt(app, &v, false, false)
+	}
+	if appSettings.Dedup.OnCollision == dedupMerge {
+		saveVacancies()
+		vacancyEvents.Publish(vacancyEvent(VacancyUpdated, existing))
+		app.selectVacancy(existing.Title, existing.Company)
+		app.notify("Вакансия «" + existing.Title + "» уже есть в вашем локальном списке - новые данные добавлены в неё.")
+		return true
+	}
+	app.notify("Вакансия «" + existing.Title + "» уже есть в вашем локальном списке.")
+	return true
+}
+
+// showDedupSettings задаёт правила поиска дубликатов при импорте
+func (app *AppMainWindow) showDedupSettings() {
+	var dlg *walk.Dialog
+	var urlCB *walk.CheckBox
+	var similarityNE *walk.NumberEdit
+	var collisionCB *walk.ComboBox
+	var acceptPB, cancelPB *walk.PushButton
+
+	s := appSettings.Dedup
+	names := make([]string, len(dedupCollisionChoices))
+	current := 0
+	for i, c := range dedupCollisionChoices {
+		names[i] = c.Name
+		if c.Code == s.OnCollision {
+			current = i
+		}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Дубликаты вакансий",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 440, Height: 280},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Правила действуют при импорте из файлов, со страниц сайтов, из Telegram\nи при скрытии уже сохранённых вакансий в онлайн-поиске.\nВакансии с одинаковыми названием и компанией всегда считаются одной.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			CheckBox{AssignTo: &urlCB, Text: "Одна ссылка - одна вакансия (без utm-меток, www и слэша в конце)", Checked: s.MatchURL},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Похожесть названий у той же компании, % (0 - только точное совпадение):", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+					NumberEdit{AssignTo: &similarityNE, Value: float64(s.TitleSimilarity), MinValue: 0, MaxValue: 100, SpinButtonsVisible: true, MaxSize: Size{Width: 60}},
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Если вакансия уже есть:", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+					ComboBox{AssignTo: &collisionCB, Model: names, CurrentIndex: current},
+				},
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							appSettings.Dedup = DedupSettings{
+								MatchURL:        urlCB.Checked(),
+								TitleSimilarity: int(similarityNE.Value()),
+								OnCollision:     dedupCollisionChoices[max(collisionCB.CurrentIndex(), 0)].Code,
+							}
+							saveSettings()
+							log.Printf("Правила дубликатов: %+v", appSettings.Dedup)
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
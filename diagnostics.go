@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	_ "net/http/pprof" // Обработчики /debug/pprof/ для режима отладки
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Самодиагностика для разбора редких зависаний интерфейса: скрытое окно (Ctrl+Shift+F12)
+// с памятью, горутинами, размерами списков, временем чтения и записи файла, задержкой
+// отклика интерфейса и последними ошибками. С флагом --debug открывается ещё и pprof.
+
+// Адрес pprof в режиме отладки - только локальный
+const pprofAddr = "127.0.0.1:6060"
+
+// Сколько последних ошибок из журнала помнить
+const maxDiagErrors = 20
+
+// Файл журнала рядом с настройками; при превышении размера прежний журнал переименовывается в .old
+const (
+	logFile    = "jobsearch.log"
+	maxLogSize = 1 << 20
+)
+
+// Как часто проверять, что поток интерфейса обрабатывает сообщения, и с какой задержки считать его зависшим
+const (
+	uiProbeInterval  = 2 * time.Second
+	uiFreezeLogLimit = time.Second
+)
+
+// storageTiming - время последней и самой долгой операции с файлом вакансий
+type storageTiming struct {
+	Last, Max time.Duration
+	Count     int
+}
+
+var diag = struct {
+	sync.Mutex
+	storage   map[string]*storageTiming
+	errors    []string
+	uiLast    time.Duration
+	uiMax     time.Duration
+	uiFreezes int
+	pprof     string // Адрес pprof, если он запущен
+}{storage: map[string]*storageTiming{}}
+
+// recordStorageLatency запоминает, сколько длилось чтение или запись файла, начатые в start
+func recordStorageLatency(op string, start time.Time) {
+	d := time.Since(start)
+	diag.Lock()
+	defer diag.Unlock()
+	t := diag.storage[op]
+	if t == nil {
+		t = &storageTiming{}
+		diag.storage[op] = t
+	}
+	t.Last, t.Max, t.Count = d, max(t.Max, d), t.Count+1
+}
+
+// errorLogWriter пропускает журнал дальше и запоминает строки с ошибками
+type errorLogWriter struct {
+	next io.Writer
+}
+
+func (w errorLogWriter) Write(p []byte) (int, error) {
+	line := strings.TrimSpace(string(p))
+	lower := strings.ToLower(line)
+	if strings.Contains(lower, "ошибк") || strings.Contains(lower, "error") || strings.Contains(lower, "panic") {
+		diag.Lock()
+		diag.errors = append(diag.errors, line)
+		if len(diag.errors) > maxDiagErrors {
+			diag.errors = diag.errors[len(diag.errors)-maxDiagErrors:]
+		}
+		diag.Unlock()
+	}
+	return w.next.Write(p)
+}
+
+// openLogFile открывает файл журнала на дозапись, убирая в .old слишком большой прежний
+func openLogFile() (*os.File, error) {
+	if info, err := os.Stat(logFile); err == nil && info.Size() > maxLogSize {
+		os.Rename(logFile, logFile+".old")
+	}
+	return os.OpenFile(logFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+}
+
+// startDiagnostics начинает вести журнал в файле и собирать из него ошибки; если debug, запускает pprof
+func startDiagnostics(debug bool) {
+	out := log.Writer()
+	if f, err := openLogFile(); err == nil {
+		out = io.MultiWriter(f, out) // Файл первым: у оконного приложения stderr может не работать
+	}
+	log.SetOutput(errorLogWriter{next: out})
+	if !debug {
+		return
+	}
+	diag.Lock()
+	diag.pprof = pprofAddr
+	diag.Unlock()
+	go func() {
+		log.Printf("pprof доступен на http://%s/debug/pprof/", pprofAddr)
+		if err := http.ListenAndServe(pprofAddr, nil); err != nil {
+			log.Printf("Ошибка запуска pprof: %v", err)
+		}
+	}()
+}
+
+// debugFlagFromArgs проверяет, запущено ли приложение с --debug
+func debugFlagFromArgs(args []string) bool {
+	return boolFlagFromArgs(args, "debug")
+}
+
+// startUIProbe периодически измеряет, через сколько поток интерфейса выполняет Synchronize.
+// Долгая задержка означает, что он был занят, и попадает в журнал.
+func (app *AppMainWindow) startUIProbe() {
+	go func() {
+		for {
+			time.Sleep(uiProbeInterval)
+			start := time.Now()
+			done := make(chan struct{})
+			app.Synchronize(func() { close(done) })
+			<-done
+			d := time.Since(start)
+			diag.Lock()
+			diag.uiLast, diag.uiMax = d, max(diag.uiMax, d)
+			if d >= uiFreezeLogLimit {
+				diag.uiFreezes++
+			}
+			diag.Unlock()
+			if d >= uiFreezeLogLimit {
+				log.Printf("Интерфейс не отвечал %v", d.Round(time.Millisecond))
+			}
+		}
+	}()
+}
+
+// diagnosticsReport - текст окна диагностики
+func (app *AppMainWindow) diagnosticsReport() string {
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	mb := func(n uint64) string { return fmt.Sprintf("%.1f МБ", float64(n)/(1<<20)) }
+
+	allVacanciesMutex.Lock()
+	total := len(allVacancies)
+	allVacanciesMutex.Unlock()
+	normalizedMutex.Lock()
+	cached := len(normalizedCache)
+	normalizedMutex.Unlock()
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "Версия: %s, %s/%s, %s\r\n", appVersion, runtime.GOOS, runtime.GOARCH, runtime.Version())
+	fmt.Fprintf(&b, "Время: %s\r\n\r\n", time.Now().Format("02.01.2006 15:04:05"))
+
+	fmt.Fprintf(&b, "Горутины: %d\r\n", runtime.NumGoroutine())
+	fmt.Fprintf(&b, "Куча: %s используется, %s у системы; всего у процесса %s\r\n", mb(m.HeapAlloc), mb(m.HeapSys), mb(m.Sys))
+	fmt.Fprintf(&b, "Сборок мусора: %d, последняя пауза %v\r\n\r\n", m.NumGC, time.Duration(m.PauseNs[(m.NumGC+255)%256]))
+
+	fmt.Fprintf(&b, "Вакансий в базе: %d\r\n", total)
+	fmt.Fprintf(&b, "Строк в таблице: %d\r\n", len(app.vacancyModel.items))
+	fmt.Fprintf(&b, "Онлайн-результатов: %d\r\n", len(app.onlineVacancyModel.all))
+	fmt.Fprintf(&b, "Нормализованных текстов в кэше поиска: %d\r\n", cached)
+	if info, err := os.Stat(dataPath(vacanciesFile)); err == nil {
+		fmt.Fprintf(&b, "Размер %s: %s\r\n", vacanciesFile, mb(uint64(info.Size())))
+	}
+
+	diag.Lock()
+	defer diag.Unlock()
+	b.WriteString("\r\nФайл вакансий (вместе с ожиданием блокировки):\r\n")
+	for _, op := range []string{"чтение", "запись"} {
+		if t := diag.storage[op]; t != nil {
+			fmt.Fprintf(&b, "  %s: последнее %v, самое долгое %v, всего %d\r\n", op, t.Last.Round(time.Millisecond), t.Max.Round(time.Millisecond), t.Count)
+		}
+	}
+	fmt.Fprintf(&b, "\r\nОтклик интерфейса: последний %v, самый долгий %v, зависаний дольше %v: %d\r\n",
+		diag.uiLast.Round(time.Millisecond), diag.uiMax.Round(time.Millisecond), uiFreezeLogLimit, diag.uiFreezes)
+	if states := breakerStates(); len(states) > 0 {
+		b.WriteString("Источники онлайн-поиска со сбоями:\r\n")
+		for _, s := range states {
+			b.WriteString("  " + s + "\r\n")
+		}
+	}
+	if diag.pprof != "" {
+		fmt.Fprintf(&b, "pprof: http://%s/debug/pprof/\r\n", diag.pprof)
+	} else {
+		b.WriteString("pprof выключен (запустите приложение с --debug)\r\n")
+	}
+
+	b.WriteString("\r\nПоследние ошибки:\r\n")
+	if len(diag.errors) == 0 {
+		b.WriteString("  нет\r\n")
+	}
+	for i := len(diag.errors) - 1; i >= 0; i-- {
+		b.WriteString("  " + diag.errors[i] + "\r\n")
+	}
+	return b.String()
+}
+
+// goroutineDump - стеки всех горутин, чтобы увидеть, чем занят поток при зависании
+func goroutineDump() string {
+	buf := make([]byte, 1<<20)
+	return string(buf[:runtime.Stack(buf, true)])
+}
+
+// showDiagnosticsDialog показывает окно самодиагностики
+func (app *AppMainWindow) showDiagnosticsDialog() {
+	var dlg *walk.Dialog
+	var reportTE *walk.TextEdit
+	var closePB *walk.PushButton
+	refresh := func() { reportTE.SetText(app.diagnosticsReport()) }
+	button := func(text string, onClicked walk.EventHandler) PushButton {
+		return PushButton{
+			Text:       text,
+			Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+			Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+			OnClicked:  onClicked,
+		}
+	}
+
+	if err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Диагностика",
+		DefaultButton: &closePB,
+		CancelButton:  &closePB,
+		MinSize:       Size{Width: 620, Height: 520},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			TextEdit{AssignTo: &reportTE, ReadOnly: true, VScroll: true, Font: Font{Family: "Consolas", PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					button("Обновить", func() { refresh() }),
+					button("Собрать мусор", func() {
+						runtime.GC()
+						refresh()
+					}),
+					button("Стеки горутин", func() { reportTE.SetText(strings.ReplaceAll(goroutineDump(), "\n", "\r\n")) }),
+					button("Копировать", func() {
+						if err := walk.Clipboard().SetText(reportTE.Text()); err != nil {
+							log.Printf("Ошибка копирования в буфер обмена: %v", err)
+						}
+					}),
+					HSpacer{},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Accept() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	refresh()
+	dlg.Run()
+}
+
+// registerDiagnosticsShortcut вешает окно диагностики на Ctrl+Shift+F12, не показывая его в меню
+func (app *AppMainWindow) registerDiagnosticsShortcut() {
+	action := walk.NewAction()
+	if err := action.SetShortcut(walk.Shortcut{Modifiers: walk.ModControl | walk.ModShift, Key: walk.KeyF12}); err != nil {
+		log.Printf("Ошибка назначения сочетания диагностики: %v", err)
+		return
+	}
+	action.Triggered().Attach(app.showDiagnosticsDialog)
+	if err := app.MainWindow.ShortcutActions().Add(action); err != nil {
+		log.Printf("Ошибка назначения сочетания диагностики: %v", err)
+	}
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"log"
+	"syscall"
+	"unsafe"
+
+	"github.com/lxn/win"
+	"golang.org/x/sys/windows"
+)
+
+// OLE-приёмник перетаскивания для таблицы вакансий.
+// Outlook перетаскивает письма не файлами (WM_DROPFILES), а через IDataObject, поэтому таблица
+// регистрирует свой IDropTarget: письма Outlook привязываются к вакансии под курсором,
+// а обычные файлы передаются в handleDroppedFiles, как при сбросе на окно.
+
+var (
+	procRegisterDragDrop         = windows.NewLazySystemDLL("ole32.dll").NewProc("RegisterDragDrop")
+	procReleaseStgMedium         = windows.NewLazySystemDLL("ole32.dll").NewProc("ReleaseStgMedium")
+	procRegisterClipboardFormatW = windows.NewLazySystemDLL("user32.dll").NewProc("RegisterClipboardFormatW")
+)
+
+const (
+	dropEffectNone  = 0
+	dropEffectCopy  = 1
+	tymedHGlobal    = 1
+	tymedIStream    = 4
+	dvaspectContent = 1
+)
+
+var (
+	iidIUnknown    = windows.GUID{Data1: 0x00000000, Data4: [8]byte{0xC0, 0, 0, 0, 0, 0, 0, 0x46}}
+	iidIDropTarget = windows.GUID{Data1: 0x00000122, Data4: [8]byte{0xC0, 0, 0, 0, 0, 0, 0, 0x46}}
+)
+
+// formatEtc - FORMATETC
+type formatEtc struct {
+	Format uint16
+	Ptd    uintptr
+	Aspect uint32
+	Index  int32
+	Tymed  uint32
+}
+
+// stgMedium - STGMEDIUM
+type stgMedium struct {
+	Tymed         uint32
+	Data          uintptr
+	UnkForRelease uintptr
+}
+
+type dropTargetVtbl struct {
+	QueryInterface uintptr
+	AddRef         uintptr
+	Release        uintptr
+	DragEnter      uintptr
+	DragOver       uintptr
+	DragLeave      uintptr
+	Drop           uintptr
+}
+
+// vacancyDropTarget - IDropTarget таблицы вакансий; живёт столько же, сколько окно
+type vacancyDropTarget struct {
+	vtbl    *dropTargetVtbl
+	app     *AppMainWindow
+	effect  uint32
+	outlook bool // Перетаскиваются письма Outlook
+}
+
+var vacancyDropVtbl = &dropTargetVtbl{
+	QueryInterface: syscall.NewCallback(dropTargetQueryInterface),
+	AddRef:         syscall.NewCallback(dropTargetAddRef),
+	Release:        syscall.NewCallback(dropTargetAddRef),
+	DragEnter:      syscall.NewCallback(dropTargetDragEnter),
+	DragOver:       syscall.NewCallback(dropTargetDragOver),
+	DragLeave:      syscall.NewCallback(dropTargetDragLeave),
+	Drop:           syscall.NewCallback(dropTargetDrop),
+}
+
+// vacancyDrop удерживает приёмник от сборщика мусора: на него ссылается только OLE
+var vacancyDrop *vacancyDropTarget
+
+// Формат, в котором Outlook передаёт перетаскиваемые письма
+var outlookMessagesFormat = registerClipboardFormat("RenPrivateMessages")
+
+func registerClipboardFormat(name string) uint16 {
+	r, _, _ := procRegisterClipboardFormatW.Call(uintptr(unsafe.Pointer(windows.StringToUTF16Ptr(name))))
+	return uint16(r)
+}
+
+func dropTargetQueryInterface(this *vacancyDropTarget, riid *windows.GUID, ppv *uintptr) uintptr {
+	if *riid == iidIUnknown || *riid == iidIDropTarget {
+		*ppv = uintptr(unsafe.Pointer(this))
+		return win.S_OK
+	}
+	*ppv = 0
+	return win.E_NOINTERFACE
+}
+
+// dropTargetAddRef - счётчик ссылок не нужен: приёмник не освобождается до выхода
+func dropTargetAddRef(this *vacancyDropTarget) uintptr {
+	return 1
+}
+
+// dataObjectHas проверяет, есть ли в перетаскиваемых данных формат
+func dataObjectHas(data *win.IDataObject, format uint16, tymed uint32) bool {
+	fe := formatEtc{Format: format, Aspect: dvaspectContent, Index: -1, Tymed: tymed}
+	hr, _, _ := syscall.SyscallN(data.LpVtbl.QueryGetData, uintptr(unsafe.Pointer(data)), uintptr(unsafe.Pointer(&fe)))
+	return hr == win.S_OK
+}
+
+// droppedFilePaths - пути файлов из CF_HDROP
+func droppedFilePaths(data *win.IDataObject) []string {
+	fe := formatEtc{Format: win.CF_HDROP, Aspect: dvaspectContent, Index: -1, Tymed: tymedHGlobal}
+	var medium stgMedium
+	if hr, _, _ := syscall.SyscallN(data.LpVtbl.GetData, uintptr(unsafe.Pointer(data)), uintptr(unsafe.Pointer(&fe)), uintptr(unsafe.Pointer(&medium))); hr != win.S_OK {
+		return nil
+	}
+	defer procReleaseStgMedium.Call(uintptr(unsafe.Pointer(&medium)))
+	hdrop := win.HDROP(medium.Data)
+	var files []string
+	for i := uint(0); i < win.DragQueryFile(hdrop, 0xFFFFFFFF, nil, 0); i++ {
+		buf := make([]uint16, win.DragQueryFile(hdrop, i, nil, 0)+1)
+		win.DragQueryFile(hdrop, i, &buf[0], uint(len(buf)))
+		files = append(files, windows.UTF16ToString(buf))
+	}
+	return files
+}
+
+func dropTargetDragEnter(this *vacancyDropTarget, data *win.IDataObject, keyState uint32, pt uintptr, effect *uint32) uintptr {
+	this.outlook = outlookMessagesFormat != 0 && dataObjectHas(data, outlookMessagesFormat, tymedHGlobal|tymedIStream)
+	this.effect = dropEffectNone
+	if (this.outlook || dataObjectHas(data, win.CF_HDROP, tymedHGlobal)) && *effect&dropEffectCopy != 0 {
+		this.effect = dropEffectCopy
+	}
+	*effect = this.effect
+	return win.S_OK
+}
+
+func dropTargetDragLeave(this *vacancyDropTarget) uintptr {
+	return win.S_OK
+}
+
+func dropTargetDragOver(this *vacancyDropTarget, keyState uint32, pt uintptr, effect *uint32) uintptr {
+	*effect = this.effect
+	return win.S_OK
+}
+
+// dropTargetDrop выбирает строку под курсором и откладывает обработку: во время Drop
+// источник (Outlook) ждёт завершения перетаскивания и не ответит на вызовы COM.
+// POINTL передаётся по значению и на 64-битных системах приходит одним словом.
+func dropTargetDrop(this *vacancyDropTarget, data *win.IDataObject, keyState uint32, pt uintptr, effect *uint32) uintptr {
+	*effect = this.effect
+	if this.effect == dropEffectNone {
+		return win.S_OK
+	}
+	app := this.app
+	p := win.POINT{X: int32(uint32(uint64(pt))), Y: int32(uint32(uint64(pt) >> 32))}
+	win.ScreenToClient(app.vacancyTable.Handle(), &p)
+	row := app.vacancyTable.IndexAt(int(p.X), int(p.Y))
+
+	outlook := this.outlook
+	var files []string
+	if !outlook {
+		files = droppedFilePaths(data)
+	}
+	app.Synchronize(func() {
+		if row >= 0 && row < len(app.vacancyModel.items) {
+			app.vacancyTable.SetCurrentIndex(row)
+		}
+		if outlook {
+			app.linkOutlookSelection()
+		} else if len(files) > 0 {
+			app.handleDroppedFiles(files)
+		}
+	})
+	return win.S_OK
+}
+
+// registerVacancyDropTarget регистрирует приёмник на таблице вакансий.
+// Только для 64-битной сборки: на 32-битной POINTL занимает два слова стека и сигнатура обработчиков другая.
+func (app *AppMainWindow) registerVacancyDropTarget() {
+	if unsafe.Sizeof(uintptr(0)) != 8 || app.vacancyTable == nil {
+		return
+	}
+	vacancyDrop = &vacancyDropTarget{vtbl: vacancyDropVtbl, app: app}
+	if hr, _, _ := procRegisterDragDrop.Call(uintptr(app.vacancyTable.Handle()), uintptr(unsafe.Pointer(vacancyDrop))); hr != win.S_OK {
+		log.Printf("RegisterDragDrop: 0x%X", hr)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Окно ошибки: понятное объяснение, раскрываемые технические подробности и кнопки
+// «Скопировать» и «Открыть журнал». Вызывающий код оборачивает причину в opError
+// с описанием действия, а по типу причины подбирается подсказка, что делать.
+
+// opError - ошибка действия пользователя: что не получилось и почему
+type opError struct {
+	Op  string // «Не удалось сохранить файл»
+	Err error
+}
+
+func (e *opError) Error() string { return e.Op + ": " + e.Err.Error() }
+
+func (e *opError) Unwrap() error { return e.Err }
+
+// wrapError добавляет к ошибке описание действия, которое не удалось
+func wrapError(op string, err error) error {
+	return &opError{Op: op, Err: err}
+}
+
+// httpStatusError - сайт ответил кодом, отличным от успешного
+type httpStatusError struct {
+	Code   int
+	Status string
+}
+
+func (e *httpStatusError) Error() string { return "сервер вернул статус " + e.Status }
+
+// errorHint - понятное объяснение причины ошибки; пусто, если причина не распознана
+func errorHint(err error) string {
+	var statusErr *httpStatusError
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var netErr net.Error
+	switch {
+	case isOfflineError(err):
+		return "Нет подключения к интернету или сайт недоступен. Проверьте сеть и попробуйте ещё раз."
+	case errors.Is(err, context.DeadlineExceeded), errors.As(err, &netErr) && netErr.Timeout():
+		return "Сервер не ответил вовремя. Попробуйте ещё раз немного позже."
+	case errors.Is(err, os.ErrNotExist):
+		return "Файл или папка не найдены: возможно, их переместили или удалили."
+	case errors.Is(err, os.ErrPermission):
+		return "Нет доступа к файлу: он открыт в другой программе или защищён от записи."
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr):
+		return "Файл повреждён или сохранён в другом формате."
+	case errors.As(err, &statusErr):
+		switch {
+		case statusErr.Code == http.StatusUnauthorized || statusErr.Code == http.StatusForbidden:
+			return "Сайт отклонил доступ: проверьте ключ или токен в настройках."
+		case statusErr.Code == http.StatusTooManyRequests:
+			return "Слишком много запросов подряд. Подождите несколько минут."
+		case statusErr.Code >= 500:
+			return "На стороне сайта сбой. Попробуйте позже."
+		}
+	}
+	return ""
+}
+
+// errorSummary - заголовок и пояснение для окна ошибки
+func errorSummary(err error) (headline, reason string) {
+	var op *opError
+	cause := err
+	if errors.As(err, &op) {
+		headline, cause = op.Op, op.Err
+	}
+	reason = errorHint(cause)
+	if reason == "" {
+		reason = cause.Error()
+	}
+	if headline == "" {
+		headline, reason = reason, ""
+	}
+	return headline, reason
+}
+
+// errorDetails - технические подробности ошибки для поддержки
+func errorDetails(title string, err error) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Окно: %s\r\n", title)
+	fmt.Fprintf(&b, "Время: %s\r\n", time.Now().Format("02.01.2006 15:04:05"))
+	fmt.Fprintf(&b, "Версия: %s, %s/%s\r\n", appVersion, runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(&b, "Ошибка: %v\r\n", err)
+	b.WriteString("Цепочка:\r\n")
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		fmt.Fprintf(&b, "  %T: %v\r\n", e, e)
+	}
+	return b.String()
+}
+
+// showError записывает ошибку в журнал и показывает окно с её объяснением
+func showError(owner walk.Form, title string, err error) {
+	log.Printf("Ошибка (%s): %v", title, err)
+	headline, reason := errorSummary(err)
+	details := errorDetails(title, err)
+
+	var dlg *walk.Dialog
+	var detailsTE *walk.TextEdit
+	var detailsPB, okPB *walk.PushButton
+	button := func(assign **walk.PushButton, text string, onClicked walk.EventHandler) PushButton {
+		return PushButton{
+			AssignTo:   assign,
+			Text:       text,
+			Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+			Font:       Font{Family: "Segoe UI", PointSize: 10},
+			OnClicked:  onClicked,
+		}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         title,
+		DefaultButton: &okPB,
+		CancelButton:  &okPB,
+		MinSize:       Size{Width: 460, Height: 160},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: headline, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 10}},
+			Label{Text: reason, Visible: reason != "", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			TextEdit{AssignTo: &detailsTE, Text: details, ReadOnly: true, VScroll: true, Visible: false, MinSize: Size{Height: 140}, Font: Font{Family: "Consolas", PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					button(&detailsPB, "Подробности ▾", func() {
+						show := !detailsTE.Visible()
+						detailsTE.SetVisible(show)
+						if show {
+							detailsPB.SetText("Подробности ▴")
+						} else {
+							detailsPB.SetText("Подробности ▾")
+						}
+					}),
+					button(nil, "Скопировать", func() {
+						if err := walk.Clipboard().SetText(headline + "\r\n" + reason + "\r\n\r\n" + details); err != nil {
+							log.Printf("Ошибка копирования в буфер обмена: %v", err)
+						}
+					}),
+					button(nil, "Открыть журнал", func() {
+						path, _ := filepath.Abs(logFile)
+						if err := openFileExternally(path); err != nil {
+							log.Printf("Ошибка открытия журнала: %v", err)
+						}
+					}),
+					HSpacer{},
+					PushButton{
+						AssignTo:   &okPB,
+						Text:       "OK",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Accept() },
+					},
+				},
+			},
+		},
+	}).Run(owner); err != nil {
+		log.Print("Dialog error: ", err)
+		walk.MsgBox(owner, title, headline+"\n"+reason, walk.MsgBoxIconError)
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import "strings"
+
+// VacancyEventKind - что произошло со списком вакансий
+type VacancyEventKind int
+
+const (
+	VacancyAdded VacancyEventKind = iota
+	VacancyUpdated
+	VacancyDeleted
+	VacanciesImported // Список пополнен или заменён целиком: импорт, восстановление из копии
+)
+
+// VacancyEvent - изменение в списке вакансий. Title и Company указывают вакансию
+// (после переименования - новые); пустые, если изменилось сразу несколько вакансий.
+type VacancyEvent struct {
+	Kind    VacancyEventKind
+	Title   string
+	Company string
+}
+
+// vacancyEvent - событие об одной вакансии
+func vacancyEvent(kind VacancyEventKind, v Vacancy) VacancyEvent {
+	return VacancyEvent{Kind: kind, Title: v.Title, Company: v.Company}
+}
+
+// statusChange - смена статуса вакансии, найденная statusTracker
+type statusChange struct {
+	Vacancy   Vacancy
+	OldStatus string
+}
+
+// statusTracker помнит статусы вакансий по названию и компании, чтобы по событию
+// шины узнать, у каких вакансий статус сменился: сами события статус не передают
+type statusTracker map[string]string
+
+func statusTrackerKey(title, company string) string {
+	return strings.ToLower(title) + "\x00" + strings.ToLower(company)
+}
+
+// newStatusTracker запоминает текущие статусы
+func newStatusTracker(vacancies []Vacancy) statusTracker {
+	t := statusTracker{}
+	t.update(vacancies)
+	return t
+}
+
+// update запоминает статусы и возвращает смены с прошлого вызова.
+// Новые и переименованные вакансии сменой статуса не считаются.
+func (t statusTracker) update(vacancies []Vacancy) []statusChange {
+	var changes []statusChange
+	seen := make(map[string]bool, len(vacancies))
+	for _, v := range vacancies {
+		key := statusTrackerKey(v.Title, v.Company)
+		seen[key] = true
+		if old, ok := t[key]; ok && old != v.Status {
+			changes = append(changes, statusChange{Vacancy: v, OldStatus: old})
+		}
+		t[key] = v.Status
+	}
+	for key := range t {
+		if !seen[key] {
+			delete(t, key)
+		}
+	}
+	return changes
+}
+
+type vacancySubscription struct {
+	id     int
+	handle func(VacancyEvent)
+}
+
+// vacancyEventBus рассылает изменения вакансий подписчикам в порядке подписки.
+// События публикуются из потока интерфейса после того, как allVacancies изменён и мьютекс отпущен.
+type vacancyEventBus struct {
+	nextID        int
+	subscriptions []vacancySubscription
+}
+
+var vacancyEvents vacancyEventBus
+
+// Subscribe подключает обработчик и возвращает номер для Unsubscribe
+func (b *vacancyEventBus) Subscribe(handle func(VacancyEvent)) int {
+	b.nextID++
+	b.subscriptions = append(b.subscriptions, vacancySubscription{id: b.nextID, handle: handle})
+	return b.nextID
+}
+
+// Unsubscribe отключает обработчик, например при закрытии окна
+func (b *vacancyEventBus) Unsubscribe(id int) {
+	for i, s := range b.subscriptions {
+		if s.id == id {
+			b.subscriptions = append(b.subscriptions[:i:i], b.subscriptions[i+1:]...)
+			return
+		}
+	}
+}
+
+// Publish вызывает обработчики. Обработчик может отписаться во время рассылки.
+func (b *vacancyEventBus) Publish(e VacancyEvent) {
+	for _, s := range append([]vacancySubscription(nil), b.subscriptions...) {
+		s.handle(e)
+	}
+}
+
+// subscribeToVacancyEvents подключает главное окно: таблицу, панель деталей и строку состояния
+func (app *AppMainWindow) subscribeToVacancyEvents() {
+	vacancyEvents.Subscribe(app.refreshVacancyTable)
+	vacancyEvents.Subscribe(func(VacancyEvent) { app.updateVacancyDetails() })
+	vacancyEvents.Subscribe(func(VacancyEvent) { app.updateGoalProgress() })
+}
+
+// refreshVacancyTable заново применяет фильтры и выделяет изменённую вакансию,
+// а после удаления или импорта - ту, что была выделена
+func (app *AppMainWindow) refreshVacancyTable(e VacancyEvent) {
+	title, company := e.Title, e.Company
+	if e.Kind == VacancyDeleted || title == "" {
+		title, company = "", ""
+		if idx := app.vacancyTable.CurrentIndex(); idx >= 0 && idx < len(app.vacancyModel.items) {
+			title, company = app.vacancyModel.items[idx].Title, app.vacancyModel.items[idx].Company
+		}
+	}
+	app.applyVacancyFilters()
+	for i, v := range app.vacancyModel.items {
+		if title != "" && sameVacancy(v.Title, v.Company, title, company) {
+			app.vacancyTable.SetCurrentIndex(i)
+			app.vacancyTable.EnsureItemVisible(i)
+			return
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Уверенность автоопределения уровня опыта
+const (
+	confidenceLow = iota + 1
+	confidenceMedium
+	confidenceHigh
+)
+
+func confidenceLabel(c int) string {
+	switch c {
+	case confidenceHigh:
+		return "высокая"
+	case confidenceMedium:
+		return "средняя"
+	}
+	return "низкая"
+}
+
+// experienceGuess - уровень опыта, найденный в тексте вакансии, и фраза, по которой он найден
+type experienceGuess struct {
+	Level      string
+	Confidence int
+	Phrase     string
+}
+
+var (
+	noExperienceRe = regexp.MustCompile(`(?i)без опыта|опыт(?: работы)? не (?:требуется|обязателен|нужен|важен)|no (?:prior )?experience (?:required|needed)|entry[- ]level`)
+
+	// "3 года", "1-3 года", "3+ лет", "2.5 years": проверяется вместе с окружением, см. detectExperienceYears
+	yearsRe = regexp.MustCompile(`(?i)(\d{1,2}(?:[.,]\d)?)\s*\+?\s*(?:(?:-|–|—|до)\s*\d{1,2}\s*)?(?:года|год|лет|years?|yrs)`)
+
+	yearsContextRe = regexp.MustCompile(`(?i)опыт|стаж|experience`)
+	yearsMinRe     = regexp.MustCompile(`(?i)(?:от|не менее|более|больше|свыше|минимум|at least|minimum(?: of)?|over)\s*$`)
+)
+
+// Грейды в порядке возрастания; в тексте берётся тот, что встретился первым
+var gradeRules = []struct {
+	re    *regexp.Regexp
+	level string
+}{
+	{regexp.MustCompile(`(?i)\b(?:intern|internship|trainee)\b|стаж[её]р|стажировк`), "Без опыта"},
+	{regexp.MustCompile(`(?i)\b(?:junior|jr)\b|джуниор|джун|младш`), "Менее 1 года"},
+	{regexp.MustCompile(`(?i)\b(?:middle|mid-level)\b|мидл`), "1-3 года"},
+	{regexp.MustCompile(`(?i)\b(?:senior|sr)\b|сеньор|синьор|старш`), "3-6 лет"},
+	{regexp.MustCompile(`(?i)\b(?:lead|principal|architect|head of)\b|тимлид|техлид|архитектор|руководитель`), "Более 6 лет"},
+}
+
+// yearsToExperienceLevel переводит минимальный стаж в годах в уровень из possibleExperienceLevels
+func yearsToExperienceLevel(years float64) string {
+	switch {
+	case years <= 0:
+		return "Без опыта"
+	case years < 1:
+		return "Менее 1 года"
+	case years < 3:
+		return "1-3 года"
+	case years < 6:
+		return "3-6 лет"
+	}
+	return "Более 6 лет"
+}
+
+// detectExperienceYears ищет требование к стажу в годах. Число лет засчитывается, только если рядом
+// говорится об опыте ("опыт от 3 лет") или стоит "от"/"не менее" - иначе это может быть срок договора.
+func detectExperienceYears(text string) (experienceGuess, bool) {
+	for _, m := range yearsRe.FindAllStringSubmatchIndex(text, -1) {
+		before := text[max(0, m[0]-60):m[0]]
+		after := text[m[1]:min(len(text), m[1]+40)]
+		confidence := 0
+		switch {
+		case yearsContextRe.MatchString(before) || yearsContextRe.MatchString(after):
+			confidence = confidenceHigh
+		case yearsMinRe.MatchString(before):
+			confidence = confidenceMedium
+		default:
+			continue
+		}
+		years, err := strconv.ParseFloat(strings.Replace(text[m[2]:m[3]], ",", ".", 1), 64)
+		if err != nil || years > 30 {
+			continue
+		}
+		phrase := text[m[0]:m[1]]
+		if loc := yearsMinRe.FindStringIndex(before); loc != nil {
+			phrase = strings.TrimSpace(before[loc[0]:]) + " " + phrase
+		}
+		return experienceGuess{Level: yearsToExperienceLevel(years), Confidence: confidence, Phrase: phrase}, true
+	}
+	return experienceGuess{}, false
+}
+
+// detectGrade ищет грейд (junior, senior, стажёр) - самый ранний в тексте
+func detectGrade(text string) (experienceGuess, bool) {
+	best, found := -1, experienceGuess{}
+	for _, rule := range gradeRules {
+		if loc := rule.re.FindStringIndex(text); loc != nil && (best == -1 || loc[0] < best) {
+			best = loc[0]
+			found = experienceGuess{Level: rule.level, Phrase: text[loc[0]:loc[1]]}
+		}
+	}
+	return found, best != -1
+}
+
+// detectExperience определяет уровень опыта по названию и описанию вакансии.
+// Явное требование к стажу надёжнее грейда, а грейд в названии - надёжнее грейда в описании.
+func detectExperience(title, description string) (experienceGuess, bool) {
+	if m := noExperienceRe.FindString(title + "\n" + description); m != "" {
+		return experienceGuess{Level: "Без опыта", Confidence: confidenceHigh, Phrase: m}, true
+	}
+	if g, ok := detectExperienceYears(description); ok {
+		return g, true
+	}
+	if g, ok := detectExperienceYears(title); ok {
+		return g, true
+	}
+	if g, ok := detectGrade(title); ok {
+		g.Confidence = confidenceMedium
+		return g, true
+	}
+	if g, ok := detectGrade(description); ok {
+		g.Confidence = confidenceLow
+		return g, true
+	}
+	return experienceGuess{}, false
+}
+
+// autoDetectExperience проставляет уровень опыта вакансии, если он не указан
+func autoDetectExperience(v *Vacancy) {
+	if v.ExperienceLevel != "" && v.ExperienceLevel != possibleExperienceLevels[0] {
+		return
+	}
+	if g, ok := detectExperience(v.Title, v.Description); ok {
+		v.ExperienceLevel = g.Level
+	}
+}
+
+// experienceHintText - подсказка под полем опыта: откуда взят уровень и насколько ему можно верить
+func experienceHintText(g experienceGuess) string {
+	return "Определено по тексту «" + g.Phrase + "», уверенность: " + confidenceLabel(g.Confidence) + ". Можно выбрать другой уровень."
+}
+
+// refreshExperienceGuess заново определяет уровень опыта по названию и описанию в диалоге,
+// пока пользователь не выбрал уровень сам
+func (dlg *AddVacancyDialog) refreshExperienceGuess() {
+	if dlg.experienceCB == nil || dlg.experienceHint == nil || dlg.titleLE == nil || dlg.descriptionTE == nil || dlg.experienceManual {
+		return
+	}
+	g, ok := detectExperience(dlg.titleLE.Text(), dlg.descriptionTE.Text())
+	level := possibleExperienceLevels[0]
+	if ok {
+		level = g.Level
+	}
+	dlg.experienceAutoIdx = indexOfString(possibleExperienceLevels, level)
+	dlg.experienceCB.SetCurrentIndex(dlg.experienceAutoIdx)
+	dlg.experienceHint.SetText(experienceHintText(g))
+	dlg.experienceHint.SetVisible(ok)
+}
+
+// onExperienceChanged - уровень выбран вручную: автоопределение его больше не трогает
+func (dlg *AddVacancyDialog) onExperienceChanged() {
+	if dlg.experienceHint == nil || dlg.experienceCB.CurrentIndex() == dlg.experienceAutoIdx {
+		return
+	}
+	dlg.experienceManual = true
+	dlg.experienceHint.SetVisible(false)
+}
@@ -0,0 +1,496 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Опрос RSS/Atom-лент вакансий
+const (
+	defaultFeedPollMinutes = 60
+	feedPollMaxMinutes     = 24 * 60
+)
+
+// Ленты разных сайтов, поэтому общий ограничитель мягче, чем у Jooble
+var feedProvider = &searchProvider{Name: "feed", limiter: &rateLimiter{interval: 500 * time.Millisecond}}
+
+// rssItem - запись RSS 2.0 или RSS 1.0 (RDF)
+type rssItem struct {
+	Title       string   `xml:"title"`
+	Link        string   `xml:"link"`
+	GUID        string   `xml:"guid"`
+	Description string   `xml:"description"`
+	Content     string   `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+	PubDate     string   `xml:"pubDate"`
+	Date        string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Author      string   `xml:"author"`
+	Creator     string   `xml:"http://purl.org/dc/elements/1.1/ creator"`
+	Categories  []string `xml:"category"`
+}
+
+// atomEntry - запись Atom
+type atomEntry struct {
+	Title string `xml:"title"`
+	ID    string `xml:"id"`
+	Links []struct {
+		Href string `xml:"href,attr"`
+		Rel  string `xml:"rel,attr"`
+	} `xml:"link"`
+	Summary   string `xml:"summary"`
+	Content   string `xml:"content"`
+	Updated   string `xml:"updated"`
+	Published string `xml:"published"`
+	Author    struct {
+		Name string `xml:"name"`
+	} `xml:"author"`
+}
+
+// feedDocument покрывает RSS 2.0 (rss/channel/item), RSS 1.0 (rdf:RDF/item) и Atom (feed/entry)
+type feedDocument struct {
+	XMLName xml.Name
+	Channel struct {
+		Title string    `xml:"title"`
+		Items []rssItem `xml:"item"`
+	} `xml:"channel"`
+	Items   []rssItem   `xml:"item"`
+	Title   string      `xml:"title"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+// Форматы дат в лентах: RFC 822 в RSS, RFC 3339 в Atom и dc:date
+var feedDateLayouts = []string{
+	time.RFC1123Z, time.RFC1123, "Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST",
+	"2 Jan 2006 15:04:05 -0700", time.RFC3339, "2006-01-02T15:04:05", "2006-01-02",
+}
+
+// parseFeedDate разбирает дату записи; пустое значение - дата неизвестна
+func parseFeedDate(raw string) time.Time {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range feedDateLayouts {
+		if t, err := time.Parse(layout, raw); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// Символы windows-1251 с кодами 0x80-0xBF; с 0xC0 идут А-я подряд
+var cp1251High = [64]rune{
+	'Ђ', 'Ѓ', '‚', 'ѓ', '„', '…', '†', '‡', '€', '‰', 'Љ', '‹', 'Њ', 'Ќ', 'Ћ', 'Џ',
+	'ђ', '‘', '’', '“', '”', '•', '–', '—', utf8.RuneError, '™', 'љ', '›', 'њ', 'ќ', 'ћ', 'џ',
+	'\u00a0', 'Ў', 'ў', 'Ј', '¤', 'Ґ', '¦', '§', 'Ё', '©', 'Є', '«', '¬', '\u00ad', '®', 'Ї',
+	'°', '±', 'І', 'і', 'ґ', 'µ', '¶', '·', 'ё', '№', 'є', '»', 'ј', 'Ѕ', 'ѕ', 'ї',
+}
+
+// feedCharsetReader перекодирует в UTF-8 ленты в windows-1251, которые ещё встречаются на русских сайтах
+func feedCharsetReader(charset string, input io.Reader) (io.Reader, error) {
+	switch strings.ToLower(charset) {
+	case "windows-1251", "cp1251":
+		data, err := io.ReadAll(input)
+		if err != nil {
+			return nil, err
+		}
+		var b strings.Builder
+		for _, c := range data {
+			switch {
+			case c < 0x80:
+				b.WriteByte(c)
+			case c < 0xC0:
+				b.WriteRune(cp1251High[c-0x80])
+			default:
+				b.WriteRune(rune(c-0xC0) + 'А')
+			}
+		}
+		return strings.NewReader(b.String()), nil
+	}
+	return nil, fmt.Errorf("неподдерживаемая кодировка ленты: %s", charset)
+}
+
+// feedSourceName - подпись источника для записей ленты: заголовок ленты или имя сайта
+func feedSourceName(title, feedURL string) string {
+	if title = strings.TrimSpace(title); title != "" {
+		return title
+	}
+	if u, err := url.Parse(feedURL); err == nil && u.Host != "" {
+		return strings.TrimPrefix(u.Host, "www.")
+	}
+	return feedURL
+}
+
+// firstNonEmpty возвращает первую непустую строку
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// parseFeed превращает RSS или Atom-ленту в список вакансий
+func parseFeed(data []byte, feedURL string) ([]Vacancy, error) {
+	var doc feedDocument
+	dec := xml.NewDecoder(bytes.NewReader(data))
+	dec.CharsetReader = feedCharsetReader
+	dec.Strict = false
+	dec.Entity = xml.HTMLEntity
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("лента %s не похожа на RSS или Atom: %w", feedURL, err)
+	}
+
+	newVacancy := func(title, link, description, company string, posted time.Time, source string) Vacancy {
+		return Vacancy{
+			Title:           sanitizeLine(title),
+			Company:         sanitizeLine(company),
+			Description:     sanitizeDescription(description),
+			Keywords:        []string{},
+			SourceURL:       strings.TrimSpace(link),
+			Source:          source,
+			PostedAt:        posted,
+			Status:          possibleStatuses[0],
+			ExperienceLevel: possibleExperienceLevels[0],
+		}
+	}
+
+	var vacancies []Vacancy
+	switch strings.ToLower(doc.XMLName.Local) {
+	case "rss", "rdf":
+		source := feedSourceName(doc.Channel.Title, feedURL)
+		for _, item := range append(doc.Channel.Items, doc.Items...) {
+			link := item.Link
+			if link == "" && strings.HasPrefix(item.GUID, "http") {
+				link = item.GUID
+			}
+			v := newVacancy(item.Title, link, firstNonEmpty(item.Content, item.Description),
+				firstNonEmpty(item.Creator, item.Author), parseFeedDate(firstNonEmpty(item.PubDate, item.Date)), source)
+			for _, c := range item.Categories {
+				if c = strings.TrimSpace(c); c != "" {
+					v.Keywords = append(v.Keywords, c)
+				}
+			}
+			vacancies = append(vacancies, v)
+		}
+	case "feed":
+		source := feedSourceName(doc.Title, feedURL)
+		for _, entry := range doc.Entries {
+			link := ""
+			for _, l := range entry.Links {
+				if l.Rel == "" || l.Rel == "alternate" {
+					link = l.Href
+					break
+				}
+			}
+			vacancies = append(vacancies, newVacancy(entry.Title, link, firstNonEmpty(entry.Content, entry.Summary),
+				entry.Author.Name, parseFeedDate(firstNonEmpty(entry.Published, entry.Updated)), source))
+		}
+	default:
+		return nil, fmt.Errorf("лента %s не похожа на RSS или Atom (корневой элемент <%s>)", feedURL, doc.XMLName.Local)
+	}
+
+	// Без названия или ссылки запись не превратить в вакансию
+	result := vacancies[:0]
+	for _, v := range vacancies {
+		if v.Title == "" || !validVacancyURL(v.SourceURL) {
+			continue
+		}
+		result = append(result, v)
+	}
+	return result, nil
+}
+
+// fetchFeed загружает и разбирает одну ленту, используя кэш онлайн-поиска
+func fetchFeed(ctx context.Context, feedURL string) ([]Vacancy, error) {
+	cacheKey := searchCacheKey(feedProvider, feedURL)
+	body, _, fromCache := cachedSearchResponse(cacheKey)
+	if !fromCache {
+		var status int
+		var err error
+		body, status, err = fetchWithRetry(ctx, feedProvider, func() (*http.Request, error) {
+			req, err := http.NewRequestWithContext(ctx, "GET", feedURL, nil)
+			if err != nil {
+				return nil, err
+			}
+			req.Header.Set("Accept", "application/rss+xml, application/atom+xml, application/xml;q=0.9, */*;q=0.5")
+			return req, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("лента %s: HTTP %d", feedURL, status)
+		}
+	}
+	vacancies, err := parseFeed(body, feedURL)
+	if err != nil {
+		return nil, err
+	}
+	if !fromCache {
+		storeSearchResponse(cacheKey, body)
+	}
+	return vacancies, nil
+}
+
+// matchesFeedQuery проверяет, что в названии или описании есть все слова запроса; пустой запрос подходит всем
+func matchesFeedQuery(v Vacancy, query string) bool {
+	text := strings.ToLower(v.Title + " " + v.Company + " " + v.Description + " " + strings.Join(v.Keywords, " "))
+	for _, word := range strings.Fields(strings.ToLower(query)) {
+		if !strings.Contains(text, word) {
+			return false
+		}
+	}
+	return true
+}
+
+// searchFeed ищет вакансии в одной ленте
+func searchFeed(ctx context.Context, feedURL, query string) ([]Vacancy, error) {
+	vacancies, err := fetchFeed(ctx, feedURL)
+	if err != nil {
+		return nil, err
+	}
+	var found []Vacancy
+	for _, v := range vacancies {
+		if !matchesFeedQuery(v, query) {
+			continue
+		}
+		autoDetectExperience(&v)
+		applyFieldMapping(providerFeeds, &v)
+		found = append(found, v)
+	}
+	return found, nil
+}
+
+// searchFeeds ищет вакансии во всех настроенных лентах.
+// Ошибки отдельных лент не прерывают поиск и возвращаются вместе с найденным.
+func searchFeeds(ctx context.Context, query string) ([]Vacancy, []error) {
+	var found []Vacancy
+	var errs []error
+	seen := map[string]bool{}
+	for _, feedURL := range appSettings.JobFeeds {
+		if ctx.Err() != nil {
+			errs = append(errs, ctx.Err())
+			break
+		}
+		vacancies, err := searchFeed(ctx, feedURL, query)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, v := range vacancies {
+			// Одна вакансия нередко есть в нескольких лентах
+			if !seen[v.SourceURL] {
+				seen[v.SourceURL] = true
+				found = append(found, v)
+			}
+		}
+	}
+	return found, errs
+}
+
+// feedSources - настроенные ленты как источники онлайн-поиска
+func feedSources(query string) []onlineSource {
+	var sources []onlineSource
+	for _, feedURL := range appSettings.JobFeeds {
+		sources = append(sources, onlineSource{
+			Name:   feedSourceName("", feedURL),
+			Search: func(ctx context.Context) ([]Vacancy, error) { return searchFeed(ctx, feedURL, query) },
+		})
+	}
+	return sources
+}
+
+// pollFeeds в фоне загружает ленты раз в appSettings.FeedPollMinutes
+// и отмечает в меню число записей, появившихся с прошлого опроса
+func (app *AppMainWindow) pollFeeds() {
+	if app.feedPollRunning || len(appSettings.JobFeeds) == 0 {
+		return
+	}
+	if !app.feedsPolledAt.IsZero() && time.Since(app.feedsPolledAt) < time.Duration(appSettings.FeedPollMinutes)*time.Minute {
+		return
+	}
+	app.feedPollRunning = true
+
+	go func() {
+		vacancies, errs := searchFeeds(context.Background(), "")
+		for _, err := range errs {
+			log.Printf("Опрос ленты вакансий: %v", err)
+		}
+		app.Synchronize(func() {
+			app.feedPollRunning = false
+			app.feedsPolledAt = time.Now()
+			first := app.feedSeen == nil
+			if first {
+				app.feedSeen = map[string]bool{}
+			}
+			for _, v := range vacancies {
+				if !app.feedSeen[v.SourceURL] {
+					app.feedSeen[v.SourceURL] = true
+					if !first {
+						app.feedNew++
+					}
+				}
+			}
+			app.updateFeedsAction()
+		})
+	}()
+}
+
+// updateFeedsAction показывает в меню число новых записей в лентах
+func (app *AppMainWindow) updateFeedsAction() {
+	if app.feedsAction == nil {
+		return
+	}
+	text := "Вакансии из RSS-лент"
+	if app.feedNew > 0 {
+		text += fmt.Sprintf(" (новых: %d)", app.feedNew)
+	}
+	app.feedsAction.SetText(text)
+}
+
+// showFeedVacancies показывает все записи настроенных лент в окне онлайн-результатов
+func (app *AppMainWindow) showFeedVacancies() {
+	if len(appSettings.JobFeeds) == 0 {
+		walk.MsgBox(app.MainWindow, "RSS-ленты", "Ленты вакансий не настроены. Добавьте их в меню Инструменты → RSS-ленты вакансий.", walk.MsgBoxIconInformation)
+		app.showFeedSettings()
+		return
+	}
+	if app.offline {
+		walk.MsgBox(app.MainWindow, "RSS-ленты", "Нет подключения к интернету.", walk.MsgBoxIconInformation)
+		return
+	}
+	app.feedNew = 0
+	app.updateFeedsAction()
+	app.startOnlineSearch("", feedSources(""))
+}
+
+// showFeedSettings редактирует список лент и интервал их опроса
+func (app *AppMainWindow) showFeedSettings() {
+	var dlg *walk.Dialog
+	var feedsTE *walk.TextEdit
+	var pollNE *walk.NumberEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	checkFeeds := func() {
+		urls := splitLines(feedsTE.Text())
+		if len(urls) == 0 {
+			return
+		}
+		dlg.SetEnabled(false)
+		go func() {
+			var lines []string
+			for _, feedURL := range urls {
+				ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+				body, err := httpGet(ctx, feedURL, "application/rss+xml, application/atom+xml, application/xml")
+				cancel()
+				if err == nil {
+					var vacancies []Vacancy
+					if vacancies, err = parseFeed(body, feedURL); err == nil {
+						lines = append(lines, fmt.Sprintf("✓ %s — записей: %d", feedURL, len(vacancies)))
+						continue
+					}
+				}
+				lines = append(lines, fmt.Sprintf("✗ %s — %v", feedURL, err))
+			}
+			dlg.Synchronize(func() {
+				dlg.SetEnabled(true)
+				walk.MsgBox(dlg, "Проверка лент", strings.Join(lines, "\n"), walk.MsgBoxIconInformation)
+			})
+		}()
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "RSS-ленты вакансий",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 520, Height: 380},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Адреса RSS или Atom-лент с вакансиями, по одному на строку.\nЗаписи лент попадают в результаты онлайн-поиска.",
+				TextColor: currentTheme.Text,
+				Font:      Font{Bold: true, PointSize: 9},
+			},
+			TextEdit{
+				AssignTo: &feedsTE,
+				Text:     strings.Join(appSettings.JobFeeds, "\r\n"),
+				VScroll:  true,
+				Font:     Font{PointSize: 9},
+			},
+			Label{
+				Text:      "Проверять ленты на новые записи раз в (минут):",
+				TextColor: currentTheme.Text,
+				Font:      Font{Bold: true, PointSize: 9},
+			},
+			NumberEdit{
+				AssignTo:           &pollNE,
+				Value:              float64(appSettings.FeedPollMinutes),
+				MinValue:           5,
+				MaxValue:           feedPollMaxMinutes,
+				SpinButtonsVisible: true,
+				Font:               Font{PointSize: 9},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Проверить ленты",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked:  checkFeeds,
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							var feeds, invalid []string
+							for _, line := range splitLines(feedsTE.Text()) {
+								if validVacancyURL(line) {
+									feeds = append(feeds, line)
+								} else {
+									invalid = append(invalid, line)
+								}
+							}
+							if len(invalid) > 0 {
+								walk.MsgBox(dlg, "RSS-ленты", "Это не адреса http(s):\n"+strings.Join(invalid, "\n"), walk.MsgBoxIconWarning)
+								return
+							}
+							appSettings.JobFeeds = feeds
+							appSettings.FeedPollMinutes = int(pollNE.Value())
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	// Новые ленты опрашиваем сразу
+	app.feedsPolledAt = time.Time{}
+	if !app.offline {
+		app.pollFeeds()
+	}
+}
@@ -0,0 +1,231 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Провайдеры онлайн-вакансий, для которых настраивается перенос полей
+const (
+	providerJooble   = "Jooble"
+	providerFeeds    = "RSS"
+	providerTelegram = "Telegram"
+)
+
+var mappingProviders = []string{providerJooble, providerFeeds, providerTelegram}
+
+// Куда переносить поле провайдера: пустое значение - в одноимённое поле вакансии
+const (
+	mapToField = ""
+	mapToNotes = "notes"
+	mapToSkip  = "skip"
+)
+
+// FieldMapping - как поля провайдера попадают в вакансию. Нулевое значение ничего не меняет.
+type FieldMapping struct {
+	Salary           string `json:"salary,omitempty"`
+	Location         string `json:"location,omitempty"`
+	Description      string `json:"description,omitempty"`
+	TitlePrefix      bool   `json:"title_prefix,omitempty"`       // "[Источник] Название"
+	SourceAsKeyword  bool   `json:"source_as_keyword,omitempty"`  // Источник - ещё одно ключевое слово
+	CompanyFromTitle bool   `json:"company_from_title,omitempty"` // "Компания: Название" в заголовке, если компания не указана
+}
+
+// fieldMappingFor возвращает настройку провайдера
+func fieldMappingFor(provider string) FieldMapping {
+	return appSettings.FieldMappings[provider]
+}
+
+// appendNote дописывает строку "подпись: значение" в заметки
+func appendNote(v *Vacancy, label, value string) {
+	if value = strings.TrimSpace(value); value == "" {
+		return
+	}
+	if v.Notes != "" {
+		v.Notes += "\r\n"
+	}
+	v.Notes += label + ": " + value
+}
+
+// mapField переносит значение поля по настройке; clear очищает поле вакансии
+func mapField(v *Vacancy, target, label, value string, clear func()) {
+	switch target {
+	case mapToNotes:
+		appendNote(v, label, value)
+		clear()
+	case mapToSkip:
+		clear()
+	}
+}
+
+// applyFieldMapping применяет настройку провайдера к полученной от него вакансии
+func applyFieldMapping(provider string, v *Vacancy) {
+	m := fieldMappingFor(provider)
+	if m == (FieldMapping{}) {
+		return
+	}
+	source := v.Source
+	if source == "" {
+		source = provider
+	}
+
+	if m.CompanyFromTitle && v.Company == "" {
+		if company, title, ok := strings.Cut(v.Title, ": "); ok && company != "" && title != "" {
+			v.Company, v.Title = strings.TrimSpace(company), strings.TrimSpace(title)
+		}
+	}
+	mapField(v, m.Salary, "Зарплата", v.Salary, func() {
+		v.Salary, v.SalaryMin, v.SalaryMax, v.SalaryCurrency = "", 0, 0, ""
+	})
+	mapField(v, m.Location, "Город", v.Location, func() { v.Location = "" })
+	mapField(v, m.Description, "Описание", v.Description, func() { v.Description = "" })
+	if m.TitlePrefix && !strings.HasPrefix(v.Title, "["+source+"]") {
+		v.Title = "[" + source + "] " + v.Title
+	}
+	if m.SourceAsKeyword {
+		v.Keywords = append(v.Keywords, source)
+	}
+}
+
+// Варианты переноса в порядке пунктов выпадающих списков
+var mappingTargets = []string{mapToField, mapToNotes, mapToSkip}
+
+// mappingTargetIndex - позиция варианта переноса в выпадающем списке
+func mappingTargetIndex(target string) int {
+	for i, t := range mappingTargets {
+		if t == target {
+			return i
+		}
+	}
+	return 0
+}
+
+// showFieldMappingDialog настраивает перенос полей для каждого провайдера
+func (app *AppMainWindow) showFieldMappingDialog() {
+	var dlg *walk.Dialog
+	var providerCB, salaryCB, locationCB, descriptionCB *walk.ComboBox
+	var prefixCB, keywordCB, companyCB *walk.CheckBox
+	var acceptPB, cancelPB *walk.PushButton
+
+	// Правим копию, чтобы "Отмена" ничего не меняла
+	mappings := map[string]FieldMapping{}
+	for k, v := range appSettings.FieldMappings {
+		mappings[k] = v
+	}
+	current := mappingProviders[0]
+
+	store := func() {
+		mappings[current] = FieldMapping{
+			Salary:           mappingTargets[salaryCB.CurrentIndex()],
+			Location:         mappingTargets[locationCB.CurrentIndex()],
+			Description:      mappingTargets[descriptionCB.CurrentIndex()],
+			TitlePrefix:      prefixCB.Checked(),
+			SourceAsKeyword:  keywordCB.Checked(),
+			CompanyFromTitle: companyCB.Checked(),
+		}
+	}
+	load := func() {
+		m := mappings[current]
+		salaryCB.SetCurrentIndex(mappingTargetIndex(m.Salary))
+		locationCB.SetCurrentIndex(mappingTargetIndex(m.Location))
+		descriptionCB.SetCurrentIndex(mappingTargetIndex(m.Description))
+		prefixCB.SetChecked(m.TitlePrefix)
+		keywordCB.SetChecked(m.SourceAsKeyword)
+		companyCB.SetChecked(m.CompanyFromTitle)
+	}
+	targetCombo := func(assignTo **walk.ComboBox, field string) Widget {
+		return ComboBox{
+			AssignTo:     assignTo,
+			Model:        []string{"в поле «" + field + "»", "в заметки", "не переносить"},
+			CurrentIndex: 0,
+			Font:         Font{PointSize: 9},
+		}
+	}
+	caption := func(text string) Widget {
+		return Label{Text: text, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}}
+	}
+
+	if err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Перенос полей провайдеров",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 460, Height: 340},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true, Spacing: 6},
+				Children: []Widget{
+					caption("Провайдер:"),
+					ComboBox{
+						AssignTo:     &providerCB,
+						Model:        mappingProviders,
+						CurrentIndex: 0,
+						Font:         Font{PointSize: 9},
+						OnCurrentIndexChanged: func() {
+							if i := providerCB.CurrentIndex(); i >= 0 && mappingProviders[i] != current {
+								store()
+								current = mappingProviders[i]
+								load()
+							}
+						},
+					},
+					caption("Зарплата:"),
+					targetCombo(&salaryCB, "Зарплата"),
+					caption("Город:"),
+					targetCombo(&locationCB, "Город"),
+					caption("Описание:"),
+					targetCombo(&descriptionCB, "Описание"),
+				},
+			},
+			CheckBox{AssignTo: &prefixCB, Text: "Добавлять источник в начало названия: «[hh.ru] Разработчик»"},
+			CheckBox{AssignTo: &keywordCB, Text: "Добавлять источник в ключевые слова"},
+			CheckBox{AssignTo: &companyCB, Text: "Брать компанию из названия вида «Компания: Должность»"},
+			Label{
+				Text:      "Настройка применяется к новым результатам поиска, записям RSS-лент\nи постам Telegram; уже сохранённые вакансии не меняются.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							store()
+							for k, v := range mappings {
+								if v == (FieldMapping{}) {
+									delete(mappings, k)
+								}
+							}
+							appSettings.FieldMappings = mappings
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	load()
+	dlg.Run()
+}
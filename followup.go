@@ -0,0 +1,152 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Через сколько дней без движения отклик требует follow-up, если в настройках не задано иное
+const defaultFollowUpDays = 7
+
+// Подсветка откликов без ответа в таблице
+var followUpColor = walk.RGB(255, 225, 180)
+
+// lastActivity - последнее движение по вакансии: отклик, смена статуса или отправленный follow-up
+func lastActivity(v Vacancy) time.Time {
+	last := v.AppliedAt
+	if n := len(v.StatusHistory); n > 0 && v.StatusHistory[n-1].At.After(last) {
+		last = v.StatusHistory[n-1].At
+	}
+	if n := len(v.FollowUps); n > 0 && v.FollowUps[n-1].After(last) {
+		last = v.FollowUps[n-1]
+	}
+	return last
+}
+
+// followUpDue - вакансия в статусе "Откликнулся" без движения дольше настроенного срока.
+// Возвращает, сколько дней прошло с последнего движения.
+func followUpDue(v Vacancy, now time.Time) (int, bool) {
+	if appSettings.FollowUpDays <= 0 || v.Status != appliedStatus {
+		return 0, false
+	}
+	last := lastActivity(v)
+	if last.IsZero() {
+		return 0, false
+	}
+	days := int(now.Sub(last).Hours() / 24)
+	return days, days >= appSettings.FollowUpDays
+}
+
+// markFollowUpSent записывает в журнал вакансии, что follow-up отправлен: срок отсчитывается заново
+func markFollowUpSent(title, company string) bool {
+	allVacanciesMutex.Lock()
+	found := false
+	for i := range allVacancies {
+		if sameVacancy(allVacancies[i].Title, allVacancies[i].Company, title, company) {
+			allVacancies[i].FollowUps = append(allVacancies[i].FollowUps, time.Now())
+			found = true
+			break
+		}
+	}
+	allVacanciesMutex.Unlock()
+	if found {
+		saveVacancies()
+		vacancyEvents.Publish(VacancyEvent{Kind: VacancyUpdated, Title: title, Company: company})
+	}
+	return found
+}
+
+// markSelectedFollowUpSent - кнопка "Отметить follow-up отправленным" в панели деталей
+func (app *AppMainWindow) markSelectedFollowUpSent() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		return
+	}
+	v := app.vacancyModel.items[idx]
+	if !markFollowUpSent(v.Title, v.Company) {
+		walk.MsgBox(app.MainWindow, "Ошибка", "Не удалось найти вакансию.", walk.MsgBoxIconError)
+	}
+}
+
+// updateFollowUpHint показывает в панели деталей, сколько отклик ждёт ответа и когда был последний follow-up
+func (app *AppMainWindow) updateFollowUpHint(v Vacancy, hasSelection bool) {
+	if app.followUpBar == nil || app.followUpLabel == nil {
+		return
+	}
+	days, due := followUpDue(v, time.Now())
+	if !hasSelection || !due {
+		app.followUpBar.SetVisible(false)
+		return
+	}
+	text := fmt.Sprintf("Нет ответа %d дн. - пора напомнить о себе.", days)
+	if n := len(v.FollowUps); n > 0 {
+		text += fmt.Sprintf(" Follow-up отправлялся %d раз, последний %s.", n, v.FollowUps[n-1].Format("02.01.2006"))
+	}
+	app.followUpLabel.SetText(text)
+	app.followUpBar.SetVisible(true)
+}
+
+// showFollowUpSettings задаёт, через сколько дней без ответа напоминать о follow-up
+func (app *AppMainWindow) showFollowUpSettings() {
+	var dlg *walk.Dialog
+	var daysNE *walk.NumberEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Follow-up по откликам",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 380, Height: 170},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Отмечать отклики, по которым нет движения дольше\n(дней, 0 - не отмечать):",
+				TextColor: currentTheme.Text,
+				Font:      Font{Bold: true, PointSize: 9},
+			},
+			NumberEdit{
+				AssignTo:           &daysNE,
+				Value:              float64(appSettings.FollowUpDays),
+				MinValue:           0,
+				MaxValue:           90,
+				SpinButtonsVisible: true,
+				Font:               Font{PointSize: 9},
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							appSettings.FollowUpDays = int(daysNE.Value())
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	app.performSearch()
+}
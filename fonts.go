@@ -0,0 +1,248 @@
+package main
+
+import (
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Шрифты по областям окна: таблицы вакансий, панель деталей и многострочные редакторы
+// (описание, заметки, предпросмотр онлайн-вакансии). Пустое семейство и нулевой размер -
+// шрифт из разметки окна. Размер задаётся для обычного текста области, а заголовки
+// и другие крупные надписи сохраняют разницу с ним; жирный и курсив не меняются.
+
+// FontChoice - шрифт одной области
+type FontChoice struct {
+	Family string `json:"family,omitempty"`
+	Size   int    `json:"size,omitempty"` // Пункты, 0 - как в разметке
+}
+
+// FontSettings - шрифты по областям окна
+type FontSettings struct {
+	Table   FontChoice `json:"table,omitzero"`
+	Details FontChoice `json:"details,omitzero"`
+	Editors FontChoice `json:"editors,omitzero"`
+}
+
+// layoutFontSize - размер обычного текста в разметке окна, от него считается разница для заголовков
+const layoutFontSize = 9
+
+// fontFamilies - семейства, предлагаемые в настройках; можно вписать и любое другое
+var fontFamilies = []string{
+	"Segoe UI", "Arial", "Calibri", "Cambria", "Consolas", "Georgia",
+	"Tahoma", "Times New Roman", "Trebuchet MS", "Verdana",
+}
+
+// layoutFonts - шрифты виджетов из разметки, запомненные до первой замены
+var layoutFonts = map[walk.Widget]*walk.Font{}
+
+// regionFont - шрифт виджета с учётом выбора для области
+func regionFont(base *walk.Font, c FontChoice) (family string, size int) {
+	family, size = base.Family(), base.PointSize()
+	if c.Family != "" {
+		family = c.Family
+	}
+	if c.Size > 0 {
+		size = max(c.Size+size-layoutFontSize, 1)
+	}
+	return family, size
+}
+
+// applyRegionFont меняет шрифт виджета, отталкиваясь от шрифта из разметки.
+// zoom - масштаб в процентах, 100 - без масштаба.
+func applyRegionFont(w walk.Widget, c FontChoice, zoom int) {
+	if w == nil {
+		return
+	}
+	base, ok := layoutFonts[w]
+	if !ok {
+		if base = w.Font(); base == nil {
+			return
+		}
+		layoutFonts[w] = base
+	}
+	family, size := regionFont(base, c)
+	size = max((size*zoom+50)/100, 1)
+	if re, ok := w.(*RichTextEdit); ok {
+		re.SetFace(family, size)
+		return
+	}
+	font, err := walk.NewFont(family, size, base.Style())
+	if err != nil {
+		log.Printf("Ошибка создания шрифта %s %d: %v", family, size, err)
+		return
+	}
+	w.SetFont(font)
+}
+
+// applyContainerFont меняет шрифт всех виджетов контейнера, кроме редакторов - у них своя область
+func applyContainerFont(c walk.Container, choice FontChoice) {
+	children := c.Children()
+	for i := 0; i < children.Len(); i++ {
+		switch w := children.At(i).(type) {
+		case *RichTextEdit:
+			continue
+		case walk.Container:
+			applyContainerFont(w, choice)
+		default:
+			applyRegionFont(w, choice, 100)
+		}
+	}
+}
+
+// applyFonts применяет шрифты из настроек к главному окну
+func (app *AppMainWindow) applyFonts() {
+	fonts := appSettings.Fonts
+	app.MainWindow.SetSuspended(true)
+	defer app.MainWindow.SetSuspended(false)
+
+	for _, t := range []*walk.TableView{app.vacancyTable, app.onlineResultsTable} {
+		if t != nil {
+			applyRegionFont(t, fonts.Table, zoomPercent(appSettings.Zoom.Table))
+		}
+	}
+	if app.detailsScrollView != nil {
+		applyContainerFont(app.detailsScrollView, fonts.Details)
+	}
+	if app.onlinePreviewTE != nil {
+		applyRegionFont(app.onlinePreviewTE, fonts.Editors, 100)
+	}
+	for _, re := range []*RichTextEdit{app.detailDescriptionTE, app.detailNotesTE} {
+		if re != nil {
+			applyRegionFont(re, fonts.Editors, 100)
+			re.SetZoom(zoomPercent(appSettings.Zoom.Editors))
+		}
+	}
+	// Подсветка найденного держится на формате символов - восстанавливаем её
+	app.highlightSearchMatches()
+}
+
+// showFontSettings - выбор шрифтов по областям; изменения сразу видны в главном окне
+func (app *AppMainWindow) showFontSettings() {
+	var dlg *walk.Dialog
+	var acceptPB, cancelPB *walk.PushButton
+	saved := appSettings.Fonts
+
+	type fontRow struct {
+		title  string
+		choice *FontChoice
+		family *walk.ComboBox
+		size   *walk.NumberEdit
+	}
+	working := saved
+	rows := []*fontRow{
+		{title: "Таблицы вакансий:", choice: &working.Table},
+		{title: "Панель деталей:", choice: &working.Details},
+		{title: "Описание и заметки:", choice: &working.Editors},
+	}
+	// preview применяет выбранное к главному окну, не сохраняя настройки
+	preview := func() {
+		for _, r := range rows {
+			if r.family == nil || r.size == nil {
+				return // Диалог ещё создаётся
+			}
+		}
+		for _, r := range rows {
+			r.choice.Family = strings.TrimSpace(r.family.Text())
+			r.choice.Size = int(r.size.Value())
+		}
+		appSettings.Fonts = working
+		app.applyFonts()
+	}
+
+	grid := []Widget{
+		Label{Text: "", Font: Font{PointSize: 9}},
+		Label{Text: "Шрифт (пусто - по умолчанию)", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+		Label{Text: "Размер (0 - по умолчанию)", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+	}
+	for _, r := range rows {
+		// Вписанное вручную семейство добавляем в список, чтобы его можно было выбрать текущим
+		families := fontFamilies
+		if r.choice.Family != "" && !slices.Contains(families, r.choice.Family) {
+			families = append([]string{r.choice.Family}, families...)
+		}
+		grid = append(grid,
+			Label{Text: r.title, TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			ComboBox{
+				AssignTo:              &r.family,
+				Editable:              true,
+				Model:                 families,
+				CurrentIndex:          slices.Index(families, r.choice.Family),
+				OnTextChanged:         preview,
+				OnCurrentIndexChanged: preview,
+			},
+			NumberEdit{
+				AssignTo:           &r.size,
+				Value:              float64(r.choice.Size),
+				MinValue:           0,
+				MaxValue:           28,
+				SpinButtonsVisible: true,
+				OnValueChanged:     preview,
+			},
+		)
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Шрифты",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 480, Height: 230},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Composite{
+				Layout:   Grid{Columns: 3, MarginsZero: true},
+				Children: grid,
+			},
+			Label{Text: "Изменения сразу видны в главном окне.", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "По умолчанию",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							for _, r := range rows {
+								r.family.SetText("")
+								r.size.SetValue(0)
+							}
+							preview()
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							preview()
+							saveSettings()
+							saved = appSettings.Fonts
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+
+	// При отмене и закрытии крестиком возвращаем сохранённые шрифты
+	appSettings.Fonts = saved
+	app.applyFonts()
+}
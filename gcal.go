@@ -0,0 +1,659 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Синхронизация собеседований с Google Календарём через Calendar API v3
+const (
+	gcalAuthURL       = "https://accounts.google.com/o/oauth2/v2/auth"
+	gcalTokenURL      = "https://oauth2.googleapis.com/token"
+	gcalAPIBase       = "https://www.googleapis.com/calendar/v3/"
+	gcalScope         = "https://www.googleapis.com/auth/calendar.events"
+	gcalSyncFile      = "gcal_sync.json"
+	gcalSyncTimeout   = 5 * time.Minute
+	gcalAuthTimeout   = 5 * time.Minute
+	defaultGcalLength = 60 // Минут
+	defaultGcalRemind = 30 // Минут
+)
+
+var gcalProvider = &searchProvider{Name: "google-calendar", limiter: &rateLimiter{interval: 100 * time.Millisecond}}
+
+// errGcalNotFound - событие удалено в календаре
+var errGcalNotFound = errors.New("google calendar: не найдено")
+
+// GoogleCalendarSettings - OAuth-клиент пользователя и параметры событий
+type GoogleCalendarSettings struct {
+	ClientID        string `json:"client_id,omitempty"`
+	ClientSecret    string `json:"client_secret,omitempty"`
+	RefreshToken    string `json:"refresh_token,omitempty"` // Пустой - календарь не подключён
+	CalendarID      string `json:"calendar_id,omitempty"`   // По умолчанию primary
+	DurationMinutes int    `json:"duration_minutes,omitempty"`
+	ReminderMinutes int    `json:"reminder_minutes,omitempty"`
+}
+
+// calendar - идентификатор календаря с учётом значения по умолчанию
+func (s GoogleCalendarSettings) calendar() string {
+	if s.CalendarID == "" {
+		return "primary"
+	}
+	return s.CalendarID
+}
+
+// gcalEntry - событие, созданное для вакансии
+type gcalEntry struct {
+	EventID string    `json:"event_id"`
+	Start   time.Time `json:"start"`             // Время собеседования на момент последней синхронизации
+	Hash    uint64    `json:"hash"`              // Содержимое события на момент последней синхронизации
+	Deleted bool      `json:"deleted,omitempty"` // Событие удалили в календаре - не создаём заново, пока время не изменится
+}
+
+// gcalSyncState - события по вакансиям
+type gcalSyncState struct {
+	Calendar string               `json:"calendar"`
+	Events   map[string]gcalEntry `json:"events"`
+}
+
+func loadGcalSyncState() gcalSyncState {
+	state := gcalSyncState{Events: map[string]gcalEntry{}}
+	data, err := os.ReadFile(dataPath(gcalSyncFile))
+	if err != nil {
+		return state
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.Printf("Ошибка чтения %s: %v", gcalSyncFile, err)
+	}
+	if state.Events == nil {
+		state.Events = map[string]gcalEntry{}
+	}
+	return state
+}
+
+func saveGcalSyncState(state gcalSyncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dataPath(gcalSyncFile), data, 0644)
+}
+
+// randomURLToken - случайная строка для state и PKCE
+func randomURLToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// gcalTokenResponse - ответ сервера авторизации Google
+type gcalTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	RefreshToken     string `json:"refresh_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// requestGcalToken обменивает код или refresh token на токен доступа
+func requestGcalToken(ctx context.Context, form url.Values) (gcalTokenResponse, error) {
+	var tok gcalTokenResponse
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, gcalTokenURL, strings.NewReader(form.Encode()))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		return req, nil
+	}
+	var data []byte
+	var status int
+	var err error
+	if form.Get("grant_type") == "authorization_code" {
+		// Код входа одноразовый: повтор после ответа, который не дошёл, вернул бы invalid_grant
+		data, status, err = fetchOnce(newRequest)
+	} else {
+		data, status, err = fetchWithRetry(ctx, gcalProvider, newRequest)
+	}
+	if err != nil {
+		return tok, err
+	}
+	if err := json.Unmarshal(data, &tok); err != nil && status == http.StatusOK {
+		return tok, err
+	}
+	if tok.Error == "invalid_grant" {
+		return tok, errors.New("доступ к календарю отозван или истёк - подключите календарь заново")
+	}
+	if status != http.StatusOK || tok.AccessToken == "" {
+		if tok.Error != "" {
+			return tok, fmt.Errorf("Google: %s %s", tok.Error, tok.ErrorDescription)
+		}
+		return tok, apiError("Google", status, data)
+	}
+	return tok, nil
+}
+
+// fetchOnce выполняет запрос без повторов и читает ответ
+func fetchOnce(newRequest func() (*http.Request, error)) ([]byte, int, error) {
+	req, err := newRequest()
+	if err != nil {
+		return nil, 0, err
+	}
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	return data, resp.StatusCode, err
+}
+
+// authorizeGoogleCalendar проводит вход через браузер с возвратом на локальный порт (OAuth для приложений на ПК)
+// и возвращает refresh token
+func authorizeGoogleCalendar(ctx context.Context, clientID, clientSecret string) (string, error) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return "", err
+	}
+	defer ln.Close()
+	redirect := "http://" + ln.Addr().String()
+	verifier, err := randomURLToken()
+	if err != nil {
+		return "", err
+	}
+	state, err := randomURLToken()
+	if err != nil {
+		return "", err
+	}
+	challenge := sha256.Sum256([]byte(verifier))
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+	srv := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("state") != state {
+			http.Error(w, "Неверный запрос", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if e := q.Get("error"); e != "" {
+			fmt.Fprint(w, "<p>Доступ не предоставлен. Окно можно закрыть.</p>")
+			select {
+			case results <- result{err: fmt.Errorf("Google: %s", e)}:
+			default:
+			}
+			return
+		}
+		fmt.Fprint(w, "<p>Календарь подключён. Вернитесь в приложение, окно можно закрыть.</p>")
+		select {
+		case results <- result{code: q.Get("code")}:
+		default:
+		}
+	})}
+	go srv.Serve(ln)
+	defer srv.Close()
+
+	authURL := gcalAuthURL + "?" + url.Values{
+		"client_id":             {clientID},
+		"redirect_uri":          {redirect},
+		"response_type":         {"code"},
+		"scope":                 {gcalScope},
+		"access_type":           {"offline"},
+		"prompt":                {"consent"},
+		"state":                 {state},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(challenge[:])},
+		"code_challenge_method": {"S256"},
+	}.Encode()
+	if err := openURL(authURL); err != nil {
+		return "", err
+	}
+
+	var res result
+	select {
+	case res = <-results:
+	case <-ctx.Done():
+		return "", errors.New("не дождались входа в Google")
+	}
+	if res.err != nil {
+		return "", res.err
+	}
+	tok, err := requestGcalToken(ctx, url.Values{
+		"client_id":     {clientID},
+		"client_secret": {clientSecret},
+		"code":          {res.code},
+		"code_verifier": {verifier},
+		"grant_type":    {"authorization_code"},
+		"redirect_uri":  {redirect},
+	})
+	if err != nil {
+		return "", err
+	}
+	if tok.RefreshToken == "" {
+		return "", errors.New("Google не выдал постоянный доступ - отзовите доступ приложения в аккаунте Google и подключите снова")
+	}
+	return tok.RefreshToken, nil
+}
+
+type gcalClient struct {
+	ctx      context.Context
+	token    string
+	calendar string
+}
+
+// newGcalClient получает токен доступа по сохранённому refresh token
+func newGcalClient(ctx context.Context, s GoogleCalendarSettings) (*gcalClient, error) {
+	tok, err := requestGcalToken(ctx, url.Values{
+		"client_id":     {s.ClientID},
+		"client_secret": {s.ClientSecret},
+		"refresh_token": {s.RefreshToken},
+		"grant_type":    {"refresh_token"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &gcalClient{ctx: ctx, token: tok.AccessToken, calendar: s.calendar()}, nil
+}
+
+func (c *gcalClient) do(method, eventID string, body any, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	path := gcalAPIBase + "calendars/" + url.PathEscape(c.calendar) + "/events"
+	if eventID != "" {
+		path += "/" + url.PathEscape(eventID)
+	}
+	data, status, err := fetchWithRetry(c.ctx, gcalProvider, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(c.ctx, method, path, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	if status == http.StatusNotFound || status == http.StatusGone {
+		return errGcalNotFound
+	}
+	if status < 200 || status >= 300 {
+		var e struct {
+			Error struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if json.Unmarshal(data, &e) == nil && e.Error.Message != "" {
+			return fmt.Errorf("Google Календарь: %s", e.Error.Message)
+		}
+		return apiError("Google Календарь", status, data)
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// gcalEventTime - начало или конец события
+type gcalEventTime struct {
+	DateTime string `json:"dateTime,omitempty"`
+	Date     string `json:"date,omitempty"` // У событий на весь день
+}
+
+// gcalEvent - нужные поля события
+type gcalEvent struct {
+	ID          string        `json:"id,omitempty"`
+	Status      string        `json:"status,omitempty"`
+	Summary     string        `json:"summary"`
+	Description string        `json:"description"`
+	Start       gcalEventTime `json:"start"`
+	End         gcalEventTime `json:"end"`
+	Reminders   struct {
+		UseDefault bool           `json:"useDefault"`
+		Overrides  []gcalReminder `json:"overrides"`
+	} `json:"reminders"`
+	Source *gcalSource `json:"source,omitempty"`
+}
+
+// gcalReminder - напоминание о событии
+type gcalReminder struct {
+	Method  string `json:"method"`
+	Minutes int    `json:"minutes"`
+}
+
+// gcalSource - ссылка на вакансию в событии
+type gcalSource struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+// gcalEventFor - событие собеседования по вакансии
+func gcalEventFor(v Vacancy, s GoogleCalendarSettings) gcalEvent {
+	length := time.Duration(firstPositive(s.DurationMinutes, defaultGcalLength)) * time.Minute
+	e := gcalEvent{
+		Summary:     "Собеседование: " + boardCardTitle(v),
+		Description: boardCardText(v),
+		Start:       gcalEventTime{DateTime: v.InterviewAt.Format(time.RFC3339)},
+		End:         gcalEventTime{DateTime: v.InterviewAt.Add(length).Format(time.RFC3339)},
+	}
+	e.Reminders.Overrides = []gcalReminder{{Method: "popup", Minutes: firstPositive(s.ReminderMinutes, defaultGcalRemind)}}
+	if validVacancyURL(v.SourceURL) {
+		e.Source = &gcalSource{Title: "Вакансия", URL: v.SourceURL}
+	}
+	return e
+}
+
+// firstPositive возвращает первое положительное значение
+func firstPositive(values ...int) int {
+	for _, v := range values {
+		if v > 0 {
+			return v
+		}
+	}
+	return 0
+}
+
+// gcalHash - отпечаток содержимого события, чтобы не обновлять неизменившиеся
+func gcalHash(e gcalEvent) uint64 {
+	data, _ := json.Marshal(e)
+	h := fnv.New64a()
+	h.Write(data)
+	return h.Sum64()
+}
+
+// gcalReschedule - собеседование, перенесённое в календаре
+type gcalReschedule struct {
+	Title, Company string
+	From, To       time.Time
+}
+
+// gcalSyncResult - итог синхронизации
+type gcalSyncResult struct {
+	Created, Updated, Deleted int
+	Rescheduled               []gcalReschedule
+	RemovedInCalendar         int
+}
+
+// syncGoogleCalendar создаёт и обновляет события собеседований и забирает из календаря перенесённое время.
+// Если время изменили и в приложении, и в календаре, остаётся время приложения.
+func syncGoogleCalendar(ctx context.Context, s GoogleCalendarSettings, vacancies []Vacancy, state *gcalSyncState) (gcalSyncResult, error) {
+	var res gcalSyncResult
+	c, err := newGcalClient(ctx, s)
+	if err != nil {
+		return res, err
+	}
+	if state.Calendar != s.calendar() {
+		state.Calendar = s.calendar()
+		state.Events = map[string]gcalEntry{}
+	}
+	today := startOfDay(time.Now())
+	progressTotal(ctx, len(vacancies))
+	for _, v := range vacancies {
+		progressStep(ctx, v.Title)
+		key := statusTrackerKey(v.Title, v.Company)
+		entry, known := state.Events[key]
+		localChanged := !v.InterviewAt.Equal(entry.Start)
+
+		if known && !entry.Deleted {
+			var remote gcalEvent
+			err := c.do(http.MethodGet, entry.EventID, nil, &remote)
+			if err != nil && !errors.Is(err, errGcalNotFound) {
+				return res, err
+			}
+			if errors.Is(err, errGcalNotFound) || remote.Status == "cancelled" {
+				entry.Deleted = true
+				state.Events[key] = entry
+				res.RemovedInCalendar++
+				if !localChanged {
+					continue
+				}
+			} else {
+				remoteStart, parseErr := time.Parse(time.RFC3339, remote.Start.DateTime)
+				remoteChanged := parseErr == nil && !remoteStart.Equal(entry.Start)
+				switch {
+				case v.InterviewAt.IsZero():
+					// Собеседование убрали в приложении - убираем и событие
+					if err := c.do(http.MethodDelete, entry.EventID, nil, nil); err != nil && !errors.Is(err, errGcalNotFound) {
+						return res, err
+					}
+					delete(state.Events, key)
+					res.Deleted++
+				case remoteChanged && !localChanged:
+					res.Rescheduled = append(res.Rescheduled, gcalReschedule{Title: v.Title, Company: v.Company, From: v.InterviewAt, To: remoteStart})
+					entry.Start = remoteStart
+					state.Events[key] = entry
+				default:
+					event := gcalEventFor(v, s)
+					if hash := gcalHash(event); localChanged || hash != entry.Hash {
+						if err := c.do(http.MethodPatch, entry.EventID, event, nil); err != nil {
+							return res, err
+						}
+						state.Events[key] = gcalEntry{EventID: entry.EventID, Start: v.InterviewAt, Hash: hash}
+						res.Updated++
+					}
+				}
+				continue
+			}
+		}
+
+		// Новое событие - только для предстоящих собеседований и для перенесённых после удаления в календаре
+		if v.InterviewAt.IsZero() || (known && !localChanged) || (!known && v.InterviewAt.Before(today)) {
+			continue
+		}
+		event := gcalEventFor(v, s)
+		var created gcalEvent
+		if err := c.do(http.MethodPost, "", event, &created); err != nil {
+			return res, err
+		}
+		state.Events[key] = gcalEntry{EventID: created.ID, Start: v.InterviewAt, Hash: gcalHash(event)}
+		res.Created++
+	}
+	return res, nil
+}
+
+// applyGcalReschedules переносит собеседования, время которых изменили в календаре, и пишет об этом в журнал
+func applyGcalReschedules(changes []gcalReschedule) {
+	for _, ch := range changes {
+		text := "собеседование перенесено в Google Календаре"
+		if !ch.From.IsZero() {
+			text += " с " + ch.From.Local().Format("02.01.2006 15:04")
+		}
+		text += " на " + ch.To.Local().Format("02.01.2006 15:04")
+		changeJournal(ch.Title, ch.Company, func(v *Vacancy) {
+			v.InterviewAt = ch.To.Local()
+			v.Journal = append(v.Journal, JournalEntry{At: time.Now(), Kind: journalCalendar, Text: text})
+		})
+	}
+}
+
+// showGoogleCalendarDialog подключает Google Календарь и синхронизирует собеседования
+func (app *AppMainWindow) showGoogleCalendarDialog() {
+	var dlg *walk.Dialog
+	var clientIDLE, secretLE, calendarLE *walk.LineEdit
+	var lengthNE, remindNE *walk.NumberEdit
+	var statusLabel *walk.Label
+	var disconnectPB, syncPB, closePB *walk.PushButton
+
+	s := appSettings.GoogleCalendar
+	store := func() {
+		g := &appSettings.GoogleCalendar
+		clientID, secret := strings.TrimSpace(clientIDLE.Text()), strings.TrimSpace(secretLE.Text())
+		if clientID != g.ClientID || secret != g.ClientSecret {
+			g.RefreshToken = "" // Токен выдан другому клиенту
+		}
+		g.ClientID, g.ClientSecret = clientID, secret
+		g.CalendarID = strings.TrimSpace(calendarLE.Text())
+		g.DurationMinutes = int(lengthNE.Value())
+		g.ReminderMinutes = int(remindNE.Value())
+		saveSettings()
+	}
+	updateState := func() {
+		connected := appSettings.GoogleCalendar.RefreshToken != ""
+		if connected {
+			statusLabel.SetText("Календарь подключён.")
+		} else {
+			statusLabel.SetText("Календарь не подключён.")
+		}
+		disconnectPB.SetEnabled(connected)
+		syncPB.SetEnabled(connected)
+	}
+
+	connect := func() {
+		store()
+		g := appSettings.GoogleCalendar
+		if g.ClientID == "" || g.ClientSecret == "" {
+			walk.MsgBox(dlg, "Google Календарь", "Укажите Client ID и Client secret OAuth-клиента типа «Приложение для ПК».", walk.MsgBoxIconWarning)
+			return
+		}
+		dlg.SetEnabled(false)
+		statusLabel.SetText("Войдите в Google в открывшемся браузере...")
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), gcalAuthTimeout)
+			defer cancel()
+			token, err := authorizeGoogleCalendar(ctx, g.ClientID, g.ClientSecret)
+			dlg.Synchronize(func() {
+				dlg.SetEnabled(true)
+				if err != nil {
+					log.Printf("Google Календарь: %v", err)
+					showError(dlg, "Google Календарь", wrapError("Не удалось подключить календарь", err))
+				} else {
+					appSettings.GoogleCalendar.RefreshToken = token
+					saveSettings()
+				}
+				updateState()
+			})
+		}()
+	}
+
+	sync := func() {
+		store()
+		g := appSettings.GoogleCalendar
+		vacancies := snapshotVacancies()
+		var res gcalSyncResult
+		err := runWithProgress(dlg, "Синхронизация с Google Календарём", func(ctx context.Context) error {
+			ctx, cancel := context.WithTimeout(ctx, gcalSyncTimeout)
+			defer cancel()
+			state := loadGcalSyncState()
+			var err error
+			res, err = syncGoogleCalendar(ctx, g, vacancies, &state)
+			// Созданные до ошибки события запоминаем, чтобы не было дублей
+			if saveErr := saveGcalSyncState(state); saveErr != nil {
+				log.Printf("Ошибка сохранения %s: %v", gcalSyncFile, saveErr)
+			}
+			return err
+		})
+		applyGcalReschedules(res.Rescheduled)
+		if errors.Is(err, context.Canceled) {
+			return
+		}
+		if err != nil {
+			showError(dlg, "Ошибка синхронизации", err)
+			if appSettings.GoogleCalendar.RefreshToken != "" && strings.Contains(err.Error(), "подключите календарь заново") {
+				appSettings.GoogleCalendar.RefreshToken = ""
+				saveSettings()
+				updateState()
+			}
+			return
+		}
+		summary := fmt.Sprintf("Google Календарь: создано событий %d, обновлено %d, удалено %d, перенесено из календаря %d", res.Created, res.Updated, res.Deleted, len(res.Rescheduled))
+		if res.RemovedInCalendar > 0 {
+			summary += fmt.Sprintf(", удалено в календаре %d (создадутся снова, если изменить время)", res.RemovedInCalendar)
+		}
+		app.notify(summary + ".")
+	}
+
+	if err := (Dialog{
+		AssignTo:     &dlg,
+		Title:        "Google Календарь",
+		CancelButton: &closePB,
+		MinSize:      Size{Width: 500, Height: 360},
+		Layout:       VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:   SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Собеседования попадают в календарь событиями с напоминанием,\nа перенесённые в календаре - обратно в вакансии.",
+				TextColor: currentTheme.Text,
+			},
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Client ID:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &clientIDLE, Text: s.ClientID},
+					Label{Text: "Client secret:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &secretLE, Text: s.ClientSecret, PasswordMode: true},
+					Label{Text: "Календарь:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &calendarLE, Text: s.CalendarID, CueBanner: "primary"},
+					Label{Text: "Длительность, мин:", TextColor: currentTheme.Text},
+					NumberEdit{AssignTo: &lengthNE, Value: float64(firstPositive(s.DurationMinutes, defaultGcalLength)), MinValue: 5, MaxValue: 480},
+					Label{Text: "Напомнить за, мин:", TextColor: currentTheme.Text},
+					NumberEdit{AssignTo: &remindNE, Value: float64(firstPositive(s.ReminderMinutes, defaultGcalRemind)), MinValue: 1, MaxValue: 40320},
+				},
+			},
+			Label{
+				Text:      "Клиент создаётся в Google Cloud Console: включите Google Calendar API\nи добавьте OAuth-клиент типа «Приложение для ПК».",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 8},
+			},
+			Label{AssignTo: &statusLabel, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{Text: "Подключить...", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: connect},
+					PushButton{
+						AssignTo:   &disconnectPB,
+						Text:       "Отключить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							appSettings.GoogleCalendar.RefreshToken = ""
+							saveSettings()
+							updateState()
+						},
+					},
+					PushButton{AssignTo: &syncPB, Text: "Синхронизировать", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: sync},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							store()
+							dlg.Cancel()
+						},
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	updateState()
+	dlg.Run()
+}
@@ -0,0 +1,287 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unicode"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Где найдено совпадение - группы результатов в порядке показа
+const (
+	foundInTitle       = "Название и компания"
+	foundInDescription = "Описание"
+	foundInNotes       = "Заметки"
+	foundInJournal     = "Журнал"
+	foundInQuestions   = "Вопросы с собеседований"
+	foundInAttachments = "Текст вложений"
+)
+
+var searchHitGroups = []string{foundInTitle, foundInDescription, foundInNotes, foundInJournal, foundInQuestions, foundInAttachments}
+
+// Сколько символов контекста показывать вокруг найденного слова
+const snippetContext = 40
+
+// searchHit - одно совпадение глобального поиска
+type searchHit struct {
+	Where   string
+	Title   string
+	Company string
+	Snippet string
+}
+
+// searchSource - текст вакансии, в котором ищем, и группа, к которой относится совпадение
+type searchSource struct {
+	Where string
+	Text  string
+}
+
+// vacancySearchSources перечисляет тексты вакансии по группам. Распознанный со скриншотов текст
+// ищется во вложениях, а не в описании, куда он тоже скопирован.
+func vacancySearchSources(v Vacancy) []searchSource {
+	description := v.Description
+	for _, a := range v.Attachments {
+		if a.OCRText != "" {
+			description = strings.Replace(description, ocrBlock(a.Caption, a.OCRText), "", 1)
+		}
+	}
+	sources := []searchSource{
+		{foundInTitle, strings.Join([]string{v.Title, v.Company, v.Location}, " · ")},
+		{foundInDescription, description},
+		{foundInNotes, v.Notes},
+	}
+	journal := func(label, text string) {
+		if strings.TrimSpace(text) != "" {
+			sources = append(sources, searchSource{foundInJournal, label + ": " + text})
+		}
+	}
+	for _, r := range v.Reminders {
+		journal("Напоминание", r.Text)
+	}
+	journal("Отказ", v.RejectionComment)
+	journal("Плюсы оффера", v.OfferPros)
+	journal("Минусы оффера", v.OfferCons)
+	if t := v.TestTask; t != nil {
+		journal("Тестовое задание", t.Link)
+		journal("Отзыв о тестовом", t.Feedback)
+	}
+	for _, e := range v.Journal {
+		journal(e.Kind, e.Text)
+	}
+	for _, q := range v.Questions {
+		sources = append(sources, searchSource{foundInQuestions, q.Text + " " + q.Notes})
+	}
+	for _, a := range v.Attachments {
+		if a.OCRText != "" {
+			sources = append(sources, searchSource{foundInAttachments, a.Caption + ": " + a.OCRText})
+		}
+	}
+	return sources
+}
+
+// matchSnippet проверяет, что в тексте есть все слова запроса, и возвращает фрагмент вокруг первого из них
+func matchSnippet(text string, words []string) (string, bool) {
+	if len(words) == 0 || text == "" {
+		return "", false
+	}
+	lower := []rune(strings.ToLower(text))
+	first := -1
+	for _, w := range words {
+		i := indexRunes(lower, []rune(w))
+		if i == -1 {
+			return "", false
+		}
+		if first == -1 || i < first {
+			first = i
+		}
+	}
+
+	runes := []rune(text)
+	start, end := max(first-snippetContext, 0), min(first+len([]rune(words[0]))+snippetContext*2, len(runes))
+	// Не режем слова по краям фрагмента
+	for start > 0 && !unicode.IsSpace(runes[start-1]) && first-start < snippetContext+15 {
+		start--
+	}
+	for end < len(runes) && !unicode.IsSpace(runes[end]) && end-first < snippetContext*2+25 {
+		end++
+	}
+	snippet := strings.Join(strings.Fields(string(runes[start:end])), " ")
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet, true
+}
+
+// indexRunes - позиция needle в haystack в рунах, -1 если нет
+func indexRunes(haystack, needle []rune) int {
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j, r := range needle {
+			if haystack[i+j] != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			return i
+		}
+	}
+	return -1
+}
+
+// globalSearch ищет слова запроса во всех текстах всех вакансий, включая архивные.
+// Результаты сгруппированы по месту совпадения.
+func globalSearch(vacancies []Vacancy, query string) []searchHit {
+	words := strings.Fields(strings.ToLower(query))
+	if len(words) == 0 {
+		return nil
+	}
+	grouped := map[string][]searchHit{}
+	for _, v := range vacancies {
+		for _, src := range vacancySearchSources(v) {
+			if snippet, ok := matchSnippet(src.Text, words); ok {
+				grouped[src.Where] = append(grouped[src.Where], searchHit{Where: src.Where, Title: v.Title, Company: v.Company, Snippet: snippet})
+			}
+		}
+	}
+	var hits []searchHit
+	for _, g := range searchHitGroups {
+		hits = append(hits, grouped[g]...)
+	}
+	return hits
+}
+
+// searchHitsSummary - сколько совпадений в каждой группе
+func searchHitsSummary(hits []searchHit) string {
+	if len(hits) == 0 {
+		return "Ничего не найдено."
+	}
+	counts := map[string]int{}
+	for _, h := range hits {
+		counts[h.Where]++
+	}
+	var parts []string
+	for _, g := range searchHitGroups {
+		if counts[g] > 0 {
+			parts = append(parts, fmt.Sprintf("%s: %d", g, counts[g]))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// SearchHitModel - таблица результатов глобального поиска
+type SearchHitModel struct {
+	walk.TableModelBase
+	items []searchHit
+}
+
+func (m *SearchHitModel) RowCount() int {
+	return len(m.items)
+}
+
+func (m *SearchHitModel) Value(row, col int) interface{} {
+	h := m.items[row]
+	switch col {
+	case 0:
+		return h.Where
+	case 1:
+		return relationLabel(RelatedVacancy{Title: h.Title, Company: h.Company})
+	case 2:
+		return h.Snippet
+	}
+	return ""
+}
+
+// showGlobalSearch - поиск по всем текстам: описаниям, заметкам, журналу, вопросам и тексту вложений.
+// Двойной щелчок выбирает вакансию в основном списке.
+func (app *AppMainWindow) showGlobalSearch() {
+	var dlg *walk.Dialog
+	var queryLE *walk.LineEdit
+	var table *walk.TableView
+	var summaryLabel *walk.Label
+	var closePB *walk.PushButton
+	var open searchHit
+	opening := false
+
+	model := &SearchHitModel{}
+	run := func() {
+		if summaryLabel == nil {
+			return
+		}
+		model.items = globalSearch(snapshotVacancies(), queryLE.Text())
+		model.PublishRowsReset()
+		if strings.TrimSpace(queryLE.Text()) == "" {
+			summaryLabel.SetText("Введите слова для поиска.")
+			return
+		}
+		summaryLabel.SetText(searchHitsSummary(model.items))
+	}
+	jump := func() {
+		if i := table.CurrentIndex(); i >= 0 && i < len(model.items) {
+			open, opening = model.items[i], true
+			dlg.Accept()
+		}
+	}
+
+	if err := (Dialog{
+		AssignTo:     &dlg,
+		Title:        "Поиск везде",
+		CancelButton: &closePB,
+		MinSize:      Size{Width: 820, Height: 480},
+		Layout:       VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:   SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			LineEdit{
+				AssignTo:      &queryLE,
+				Text:          app.currentTextSearchTerm(),
+				CueBanner:     "Слова для поиска в описаниях, заметках, журнале, вопросах и тексте скриншотов",
+				Font:          Font{PointSize: 10},
+				OnTextChanged: func() { run() },
+			},
+			Label{AssignTo: &summaryLabel, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			TableView{
+				AssignTo: &table,
+				Model:    model,
+				Columns: []TableViewColumn{
+					{Title: "Где", Width: 160},
+					{Title: "Вакансия", Width: 200},
+					{Title: "Фрагмент", Width: 420},
+				},
+				OnItemActivated: jump,
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 5},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{Text: "Перейти к вакансии", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: jump},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	run()
+	queryLE.SetFocus()
+	dlg.Run()
+
+	if !opening {
+		return
+	}
+	if !app.selectVacancy(open.Title, open.Company) {
+		walk.MsgBox(app.MainWindow, "Поиск везде", "Вакансия '"+open.Title+"' скрыта фильтрами или архивом.", walk.MsgBoxIconWarning)
+	}
+}
@@ -2,10 +2,13 @@ module projectgolang
 
 go 1.24.3
 
-require github.com/lxn/walk v0.0.0-20210112085537-c389da54e794
-
 require (
-	github.com/lxn/win v0.0.0-20210218163916-a377121e959e // indirect
-	golang.org/x/sys v0.30.0 // indirect
-	gopkg.in/Knetic/govaluate.v3 v3.0.0 // indirect
+	github.com/blevesearch/snowballstem v0.9.0
+	github.com/go-ole/go-ole v1.2.1
+	github.com/lxn/walk v0.0.0-20210112085537-c389da54e794
+	github.com/lxn/win v0.0.0-20210218163916-a377121e959e
+	github.com/yuin/gopher-lua v1.1.1
+	golang.org/x/sys v0.30.0
 )
+
+require gopkg.in/Knetic/govaluate.v3 v3.0.0 // indirect
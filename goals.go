@@ -0,0 +1,307 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Сколько недель показывать в окне итогов
+const goalSummaryWeeks = 12
+
+// WeekSummary - итоги одной недели по откликам
+type WeekSummary struct {
+	Start        time.Time
+	Applications int
+	GoalMet      bool
+}
+
+// weekStart возвращает начало (понедельник 00:00) недели, содержащей t
+func weekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
+
+// applicationsByWeek группирует отклики по началу недели (по полю AppliedAt)
+func applicationsByWeek(vacancies []Vacancy) map[string]int {
+	weeks := map[string]int{}
+	for _, v := range vacancies {
+		if v.AppliedAt.IsZero() {
+			continue
+		}
+		weeks[weekStart(v.AppliedAt.Local()).Format("2006-01-02")]++
+	}
+	return weeks
+}
+
+// weeklySummaries возвращает итоги за последние n недель, начиная с текущей
+func weeklySummaries(vacancies []Vacancy, goal, n int, now time.Time) []WeekSummary {
+	byWeek := applicationsByWeek(vacancies)
+	start := weekStart(now)
+	summaries := make([]WeekSummary, 0, n)
+	for i := 0; i < n; i++ {
+		ws := start.AddDate(0, 0, -7*i)
+		count := byWeek[ws.Format("2006-01-02")]
+		summaries = append(summaries, WeekSummary{Start: ws, Applications: count, GoalMet: goal > 0 && count >= goal})
+	}
+	return summaries
+}
+
+// applicationStreaks считает текущую и лучшую серию недель с выполненной целью.
+// Текущая неделя засчитывается в серию только если цель уже достигнута,
+// иначе серия отсчитывается от прошлой недели.
+func applicationStreaks(vacancies []Vacancy, goal int, now time.Time) (current, best int) {
+	if goal <= 0 {
+		return 0, 0
+	}
+	// Недели считаются в местном времени, как в applicationsByWeek
+	now = now.Local()
+	byWeek := applicationsByWeek(vacancies)
+	earliest := now
+	for _, v := range vacancies {
+		if !v.AppliedAt.IsZero() && v.AppliedAt.Before(earliest) {
+			earliest = v.AppliedAt.Local()
+		}
+	}
+	first := weekStart(earliest)
+
+	run := 0
+	currentDone := false
+	for ws := weekStart(now); !ws.Before(first); ws = ws.AddDate(0, 0, -7) {
+		met := byWeek[ws.Format("2006-01-02")] >= goal
+		if met {
+			run++
+			if run > best {
+				best = run
+			}
+			continue
+		}
+		if ws.Equal(weekStart(now)) {
+			continue // Неделя ещё не закончилась - не прерываем серию
+		}
+		if !currentDone {
+			current = run
+			currentDone = true
+		}
+		run = 0
+	}
+	if !currentDone {
+		current = run
+	}
+	return current, best
+}
+
+// goalStatusBarWidget создаёт нижнюю панель с прогрессом недельной цели
+func (app *AppMainWindow) goalStatusBarWidget() Widget {
+	return Composite{
+		AssignTo: &app.goalBar,
+		Layout:   HBox{Margins: Margins{Left: 10, Top: 4, Right: 10, Bottom: 4}, Spacing: 8},
+		Children: []Widget{
+			Label{AssignTo: &app.goalLabel, Text: "Цель на неделю не задана"},
+			ProgressBar{AssignTo: &app.goalProgress, MinSize: Size{Width: 150}, MaxSize: Size{Width: 200, Height: 14}, Visible: false},
+			Label{AssignTo: &app.goalStreakLabel, Text: ""},
+			HSpacer{},
+			Label{AssignTo: &app.totalsLabel, Text: ""},
+			LinkLabel{
+				AssignTo: &app.goalLinks,
+				Text:     `<a id="summary">Итоги по неделям</a>   <a id="goal">Изменить цель</a>   <a id="notifications">Уведомления</a>`,
+				OnLinkActivated: func(link *walk.LinkLabelLink) {
+					switch link.Id() {
+					case "notifications":
+						app.showNotificationHistory()
+					case "summary":
+						app.showWeeklySummary()
+					case "goal":
+						app.showGoalDialog()
+					}
+				},
+			},
+		},
+	}
+}
+
+// updateGoalProgress пересчитывает прогресс недельной цели в нижней панели
+func (app *AppMainWindow) updateGoalProgress() {
+	if app.goalLabel == nil || app.goalProgress == nil || app.goalStreakLabel == nil {
+		return
+	}
+
+	allVacanciesMutex.Lock()
+	vacancies := make([]Vacancy, len(allVacancies))
+	copy(vacancies, allVacancies)
+	allVacanciesMutex.Unlock()
+	app.updateTotalsLabel(vacancies)
+
+	goal := appSettings.WeeklyApplicationGoal
+	now := time.Now()
+	thisWeek := weeklySummaries(vacancies, goal, 1, now)[0]
+
+	if goal <= 0 {
+		app.goalLabel.SetText(fmt.Sprintf("Откликов на этой неделе: %d (цель не задана)", thisWeek.Applications))
+		app.goalProgress.SetVisible(false)
+		app.goalStreakLabel.SetText("")
+		return
+	}
+
+	app.goalLabel.SetText(fmt.Sprintf("Цель недели: %d из %d откликов", thisWeek.Applications, goal))
+	app.goalProgress.SetRange(0, goal)
+	value := thisWeek.Applications
+	if value > goal {
+		value = goal
+	}
+	app.goalProgress.SetValue(value)
+	app.goalProgress.SetVisible(true)
+
+	current, _ := applicationStreaks(vacancies, goal, now)
+	if current > 0 {
+		app.goalStreakLabel.SetText(fmt.Sprintf("🔥 Серия: %d нед.", current))
+	} else {
+		app.goalStreakLabel.SetText("")
+	}
+}
+
+// showGoalDialog позволяет задать недельную цель по откликам
+func (app *AppMainWindow) showGoalDialog() {
+	var dlg *walk.Dialog
+	var goalNE *walk.NumberEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Цель по откликам",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 320, Height: 160},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Откликов в неделю (0 - без цели):", Font: Font{Bold: true, PointSize: 9}, TextColor: currentTheme.Text},
+			NumberEdit{
+				AssignTo:           &goalNE,
+				Value:              float64(appSettings.WeeklyApplicationGoal),
+				MinValue:           0,
+				MaxValue:           500,
+				SpinButtonsVisible: true,
+				Font:               Font{PointSize: 9},
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							appSettings.WeeklyApplicationGoal = int(goalNE.Value())
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						OnClicked:  func() { dlg.Cancel() },
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	app.updateGoalProgress()
+}
+
+// WeekSummaryModel для TableView в окне итогов по неделям
+type WeekSummaryModel struct {
+	walk.TableModelBase
+	items []WeekSummary
+	goal  int
+}
+
+func (m *WeekSummaryModel) RowCount() int {
+	return len(m.items)
+}
+
+func (m *WeekSummaryModel) Value(row, col int) interface{} {
+	item := m.items[row]
+	switch col {
+	case 0:
+		return item.Start.Format("02.01.2006") + " – " + item.Start.AddDate(0, 0, 6).Format("02.01.2006")
+	case 1:
+		return item.Applications
+	case 2:
+		if m.goal <= 0 {
+			return "-"
+		}
+		if item.GoalMet {
+			return "✔ выполнена"
+		}
+		return fmt.Sprintf("не хватило %d", m.goal-item.Applications)
+	}
+	return ""
+}
+
+// showWeeklySummary отображает итоги по неделям и серии выполнения цели
+func (app *AppMainWindow) showWeeklySummary() {
+	allVacanciesMutex.Lock()
+	vacancies := make([]Vacancy, len(allVacancies))
+	copy(vacancies, allVacancies)
+	allVacanciesMutex.Unlock()
+
+	goal := appSettings.WeeklyApplicationGoal
+	now := time.Now()
+	model := &WeekSummaryModel{items: weeklySummaries(vacancies, goal, goalSummaryWeeks, now), goal: goal}
+	current, best := applicationStreaks(vacancies, goal, now)
+
+	total := 0
+	for _, w := range model.items {
+		total += w.Applications
+	}
+	summary := fmt.Sprintf("За %d недель: %d откликов, в среднем %.1f в неделю.", goalSummaryWeeks, total, float64(total)/goalSummaryWeeks)
+	if goal > 0 {
+		summary += fmt.Sprintf("\nЦель: %d в неделю. Текущая серия: %d нед., лучшая: %d нед.", goal, current, best)
+	}
+
+	var dlg *walk.Dialog
+	if _, err := (Dialog{
+		AssignTo:   &dlg,
+		Title:      "Итоги по неделям",
+		MinSize:    Size{Width: 480, Height: 420},
+		Layout:     VBox{},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: summary, TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			TableView{
+				Model:      model,
+				Background: SolidColorBrush{Color: currentTheme.TableBG},
+				Columns: []TableViewColumn{
+					{Title: "Неделя", Width: 180},
+					{Title: "Откликов", Width: 80},
+					{Title: "Цель", Width: 150},
+				},
+			},
+			Composite{
+				Layout: HBox{},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked:  func() { dlg.Accept() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"time"
+)
+
+// Статус, при переходе в который вакансия считается "отправленным откликом"
+const appliedStatus = "Откликнулся"
+
+// StatusChange описывает одну смену статуса вакансии
+type StatusChange struct {
+	Status string    `json:"status"`
+	At     time.Time `json:"at"`
+}
+
+// setVacancyStatus меняет статус вакансии и фиксирует переход в истории.
+// Если статус не изменился, ничего не делает.
+func setVacancyStatus(v *Vacancy, status string) {
+	if v.Status == status {
+		return
+	}
+	now := time.Now()
+	v.Status = status
+	v.StatusHistory = append(v.StatusHistory, StatusChange{Status: status, At: now})
+	if status == appliedStatus && v.AppliedAt.IsZero() {
+		v.AppliedAt = now
+	}
+}
+
+// stampNewVacancy проставляет дату создания и начальную запись истории для новой вакансии
+func stampNewVacancy(v *Vacancy) {
+	now := time.Now()
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = now
+	}
+	if len(v.StatusHistory) == 0 && v.Status != "" {
+		v.StatusHistory = []StatusChange{{Status: v.Status, At: now}}
+		if v.Status == appliedStatus && v.AppliedAt.IsZero() {
+			v.AppliedAt = now
+		}
+	}
+}
+
+// statusReachedBetween сообщает, переходила ли вакансия в один из статусов в интервале [from, to)
+func statusReachedBetween(v Vacancy, from, to time.Time, statuses ...string) bool {
+	for _, ch := range v.StatusHistory {
+		if ch.At.Before(from) || !ch.At.Before(to) {
+			continue
+		}
+		for _, s := range statuses {
+			if ch.Status == s {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// everReached сообщает, находилась ли вакансия когда-либо в одном из статусов
+// (учитывается история и текущий статус)
+func everReached(v Vacancy, statuses ...string) bool {
+	for _, s := range statuses {
+		if v.Status == s {
+			return true
+		}
+		for _, ch := range v.StatusHistory {
+			if ch.Status == s {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/sys/windows"
+)
+
+// Правка настроек и тем вручную без перезапуска: папки с settings.json и темами
+// отслеживаются уведомлениями Windows об изменениях, и изменённые файлы применяются
+// к окну. Запись других файлов в тех же папках отсеивается по времени изменения, а
+// собственную запись настроек приложение узнаёт по содержимому файла.
+
+// Редакторы сохраняют файл в несколько приёмов - перечитываем, когда всё затихнет
+const hotReloadDebounce = 300 * time.Millisecond
+
+// Как часто пробовать снова следить за папкой, которой пока нет (например, themes)
+const hotReloadRetry = 10 * time.Second
+
+// Последнее прочитанное или записанное содержимое файлов настроек по путям
+var (
+	settingsDataMutex sync.Mutex
+	knownSettingsData = map[string][]byte{}
+)
+
+// rememberSettingsData запоминает содержимое файла настроек, чтобы не перечитывать свою же запись
+func rememberSettingsData(path string, data []byte) {
+	settingsDataMutex.Lock()
+	defer settingsDataMutex.Unlock()
+	knownSettingsData[path] = bytes.Clone(data)
+}
+
+// settingsDataChanged - содержимое файла отличается от последнего известного
+func settingsDataChanged(path string, data []byte) bool {
+	settingsDataMutex.Lock()
+	defer settingsDataMutex.Unlock()
+	return !bytes.Equal(knownSettingsData[path], data)
+}
+
+// watchDir ждёт изменений файлов в папке dir и вызывает changed.
+// Возвращается, если следить за папкой не удалось или её удалили.
+func watchDir(dir string, changed func()) error {
+	h, err := windows.FindFirstChangeNotification(dir, false, windows.FILE_NOTIFY_CHANGE_LAST_WRITE|windows.FILE_NOTIFY_CHANGE_FILE_NAME)
+	if err != nil {
+		return err
+	}
+	defer windows.FindCloseChangeNotification(h)
+	for {
+		event, err := windows.WaitForSingleObject(h, windows.INFINITE)
+		if err != nil {
+			return err
+		}
+		if event != windows.WAIT_OBJECT_0 {
+			return os.ErrClosed
+		}
+		changed()
+		if err := windows.FindNextChangeNotification(h); err != nil {
+			return err
+		}
+	}
+}
+
+// configFilesState - время изменения и размер файлов настроек и тем. В папке настроек
+// лежат и вакансии, и файлы восстановления, которые пишутся постоянно - по этому
+// снимку видно, что изменилось что-то другое, и перечитывать настройки незачем.
+func configFilesState() string {
+	paths := []string{settingsFile, settingsPath()}
+	themeFiles, _ := filepath.Glob(filepath.Join(themesDir, "*.json"))
+	paths = append(paths, themeFiles...)
+	var b strings.Builder
+	for _, path := range paths {
+		if info, err := os.Stat(path); err == nil {
+			fmt.Fprintf(&b, "%s|%d|%d\n", path, info.ModTime().UnixNano(), info.Size())
+		}
+	}
+	return b.String()
+}
+
+// startHotReload начинает следить за файлами настроек и тем
+func (app *AppMainWindow) startHotReload() {
+	var mu sync.Mutex
+	var timer *time.Timer
+	state := configFilesState()
+	changed := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		current := configFilesState()
+		if current == state {
+			return // Изменились другие файлы в той же папке
+		}
+		state = current
+		if timer != nil {
+			timer.Stop()
+		}
+		timer = time.AfterFunc(hotReloadDebounce, func() { app.Synchronize(app.reloadChangedConfig) })
+	}
+
+	var dirs []string
+	for _, path := range []string{settingsFile, settingsPath(), filepath.Join(themesDir, "theme.json")} {
+		if dir, err := filepath.Abs(filepath.Dir(path)); err == nil && !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	for _, dir := range dirs {
+		go func() {
+			for {
+				if err := watchDir(dir, changed); err != nil && !os.IsNotExist(err) {
+					log.Printf("Не удалось следить за изменениями в %s: %v", dir, err)
+				}
+				time.Sleep(hotReloadRetry)
+			}
+		}()
+	}
+}
+
+// reloadChangedConfig перечитывает настройки и темы, если файлы изменились не самим приложением
+func (app *AppMainWindow) reloadChangedConfig() {
+	themesBefore := themes()
+	userThemes = loadUserThemes()
+	themesChanged := !slices.Equal(themesBefore, themes())
+
+	paths := []string{settingsFile}
+	if path := settingsPath(); path != settingsFile {
+		paths = append(paths, path) // Свои настройки профиля поверх общих, как при запуске
+	}
+	// Копия через JSON: Unmarshal дописывает в те же срезы и карты, а при ошибке в файле
+	// текущие настройки должны остаться нетронутыми
+	var s AppSettings
+	if current, err := json.Marshal(appSettings); err == nil {
+		json.Unmarshal(current, &s)
+	}
+	settingsChanged := false
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil || !settingsDataChanged(path, data) {
+			continue
+		}
+		if err := json.Unmarshal(data, &s); err != nil {
+			// Файл могут сохранять прямо сейчас или в нём опечатка - ждём следующей правки
+			log.Printf("Ошибка в файле настроек %s: %v", path, err)
+			app.notify("В " + filepath.Base(path) + " ошибка, изменения не применены: " + err.Error())
+			return
+		}
+		rememberSettingsData(path, data)
+		settingsChanged = true
+	}
+
+	if settingsChanged {
+		unprotectSettingsSecrets(&s)
+		old := appSettings
+		appSettings = s
+		app.applySettings(old)
+		log.Printf("Настройки перечитаны после изменения файла")
+		app.notify("Настройки обновлены из файла.")
+	}
+	if themesChanged {
+		app.applySavedTheme()
+		log.Printf("Темы перечитаны из папки %s", themesDir)
+	}
+}
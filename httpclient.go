@@ -0,0 +1,495 @@
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+	"golang.org/x/sys/windows/registry"
+)
+
+// Режимы прокси в настройках
+const (
+	proxySystem = "system" // Системный прокси Windows или переменные окружения HTTP(S)_PROXY
+	proxyNone   = "none"   // Прямое подключение
+	proxyManual = "manual" // Адрес из настроек
+)
+
+// Подписи режимов прокси в том же порядке, что и proxyModes
+var (
+	proxyModes      = []string{proxySystem, proxyNone, proxyManual}
+	proxyModeLabels = []string{"Системный", "Без прокси", "Указать вручную"}
+)
+
+// Тайм-ауты по умолчанию, в секундах
+const (
+	defaultConnectTimeoutSeconds = 10
+	defaultReadTimeoutSeconds    = 30
+)
+
+// Общий HTTP-клиент приложения и клиент обновлений; пересоздаются после изменения сетевых настроек
+var (
+	sharedHTTPClient   *http.Client
+	sharedUpdateClient *http.Client
+	httpClientMutex    sync.Mutex
+)
+
+// httpClient возвращает общий HTTP-клиент, настроенный по appSettings.
+// Если настройки некорректны, используется клиент с настройками по умолчанию.
+func httpClient() *http.Client {
+	httpClientMutex.Lock()
+	defer httpClientMutex.Unlock()
+	if sharedHTTPClient == nil {
+		client, err := newHTTPClient(appSettings)
+		if err != nil {
+			log.Printf("Ошибка сетевых настроек, используются настройки по умолчанию: %v", err)
+			client, _ = newHTTPClient(AppSettings{})
+		}
+		sharedHTTPClient = client
+	}
+	return sharedHTTPClient
+}
+
+// updateHTTPClient возвращает клиент для проверки и скачивания обновлений. Сертификаты
+// он проверяет всегда, даже если проверка отключена в настройках: контрольная сумма
+// установщика приходит по тому же соединению, и без проверки подменить можно обе.
+// Прокси и свой корневой сертификат из настроек учитываются.
+func updateHTTPClient() *http.Client {
+	httpClientMutex.Lock()
+	defer httpClientMutex.Unlock()
+	if sharedUpdateClient == nil {
+		s := appSettings
+		s.TLSSkipVerify = false
+		client, err := newHTTPClient(s)
+		if err != nil {
+			log.Printf("Ошибка сетевых настроек, обновления проверяются с настройками по умолчанию: %v", err)
+			client, _ = newHTTPClient(AppSettings{})
+		}
+		client.Timeout = 0 // Установщик скачивается дольше; скачивание отменяется через контекст
+		sharedUpdateClient = client
+	}
+	return sharedUpdateClient
+}
+
+// resetHTTPClient сбрасывает общие клиенты, чтобы следующий запрос учёл новые настройки
+func resetHTTPClient() {
+	httpClientMutex.Lock()
+	defer httpClientMutex.Unlock()
+	for _, c := range []*http.Client{sharedHTTPClient, sharedUpdateClient} {
+		if c != nil {
+			c.CloseIdleConnections()
+		}
+	}
+	sharedHTTPClient, sharedUpdateClient = nil, nil
+}
+
+// newHTTPClient собирает HTTP-клиент из сетевых настроек: прокси, тайм-ауты и TLS
+func newHTTPClient(s AppSettings) (*http.Client, error) {
+	connectTimeout := time.Duration(s.ConnectTimeoutSeconds) * time.Second
+	if connectTimeout <= 0 {
+		connectTimeout = defaultConnectTimeoutSeconds * time.Second
+	}
+	readTimeout := time.Duration(s.ReadTimeoutSeconds) * time.Second
+	if readTimeout <= 0 {
+		readTimeout = defaultReadTimeoutSeconds * time.Second
+	}
+
+	proxy, err := proxyFunc(s)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig, err := tlsConfigFor(s)
+	if err != nil {
+		return nil, err
+	}
+
+	dialer := &net.Dialer{Timeout: connectTimeout, KeepAlive: 30 * time.Second}
+	transport := &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		TLSClientConfig:       tlsConfig,
+		TLSHandshakeTimeout:   connectTimeout,
+		ResponseHeaderTimeout: readTimeout,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          10,
+		ForceAttemptHTTP2:     true,
+	}
+	// Общий тайм-аут ограничивает запрос вместе с чтением тела, даже если вызывающий
+	// передал контекст без срока (фоновый опрос лент, отложенные поиски)
+	return &http.Client{Transport: transport, Timeout: connectTimeout + 2*readTimeout}, nil
+}
+
+// tlsConfigFor настраивает проверку сертификатов: свой корневой сертификат
+// (например, корпоративного прокси) или отключение проверки
+func tlsConfigFor(s AppSettings) (*tls.Config, error) {
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if s.TLSCAFile != "" {
+		pem, err := os.ReadFile(s.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось прочитать файл сертификата: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, errors.New("в файле нет сертификатов в формате PEM")
+		}
+		cfg.RootCAs = pool
+	}
+	cfg.InsecureSkipVerify = s.TLSSkipVerify
+	return cfg, nil
+}
+
+// parseProxyURL разбирает адрес прокси; без схемы считается http://
+func parseProxyURL(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "http://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("некорректный адрес прокси: %s", raw)
+	}
+	return u, nil
+}
+
+// proxyFunc выбирает прокси по режиму из настроек
+func proxyFunc(s AppSettings) (func(*http.Request) (*url.URL, error), error) {
+	switch s.ProxyMode {
+	case proxyNone:
+		return nil, nil
+	case proxyManual:
+		if strings.TrimSpace(s.ProxyURL) == "" {
+			return nil, errors.New("не указан адрес прокси")
+		}
+		u, err := parseProxyURL(s.ProxyURL)
+		if err != nil {
+			return nil, err
+		}
+		return http.ProxyURL(u), nil
+	default:
+		return systemProxy(), nil
+	}
+}
+
+// WindowsProxy - настройки прокси из "Свойств браузера" Windows
+type WindowsProxy struct {
+	Servers map[string]string // Схема ("http", "https") или "" для всех -> host:port
+	Bypass  []string          // Исключения из ProxyOverride; "<local>" - имена без точки
+	PACURL  string            // Скрипт автонастройки (не поддерживается, только для сведения)
+}
+
+// readWindowsProxy читает настройки прокси текущего пользователя из реестра
+func readWindowsProxy() (WindowsProxy, bool) {
+	k, err := registry.OpenKey(registry.CURRENT_USER, `Software\Microsoft\Windows\CurrentVersion\Internet Settings`, registry.QUERY_VALUE)
+	if err != nil {
+		return WindowsProxy{}, false
+	}
+	defer k.Close()
+
+	var p WindowsProxy
+	p.PACURL, _, _ = k.GetStringValue("AutoConfigURL")
+	if enabled, _, err := k.GetIntegerValue("ProxyEnable"); err != nil || enabled == 0 {
+		return p, false
+	}
+	server, _, err := k.GetStringValue("ProxyServer")
+	if err != nil || strings.TrimSpace(server) == "" {
+		return p, false
+	}
+	p.Servers = parseProxyServer(server)
+	if override, _, err := k.GetStringValue("ProxyOverride"); err == nil {
+		for _, item := range strings.Split(override, ";") {
+			if item = strings.TrimSpace(item); item != "" {
+				p.Bypass = append(p.Bypass, strings.ToLower(item))
+			}
+		}
+	}
+	return p, len(p.Servers) > 0
+}
+
+// parseProxyServer разбирает ProxyServer: "host:port" или "http=host:port;https=host:port"
+func parseProxyServer(value string) map[string]string {
+	servers := map[string]string{}
+	for _, item := range strings.Split(value, ";") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		if scheme, host, ok := strings.Cut(item, "="); ok {
+			servers[strings.ToLower(strings.TrimSpace(scheme))] = strings.TrimSpace(host)
+		} else {
+			servers[""] = item
+		}
+	}
+	return servers
+}
+
+// bypassed проверяет, что адрес попадает в исключения прокси
+func (p WindowsProxy) bypassed(host string) bool {
+	host = strings.ToLower(host)
+	for _, pattern := range p.Bypass {
+		if pattern == "<local>" {
+			if !strings.Contains(host, ".") {
+				return true
+			}
+			continue
+		}
+		if ok, _ := path.Match(pattern, host); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// proxyFor возвращает прокси для запроса или nil для прямого подключения
+func (p WindowsProxy) proxyFor(req *http.Request) (*url.URL, error) {
+	if p.bypassed(req.URL.Hostname()) {
+		return nil, nil
+	}
+	server, ok := p.Servers[req.URL.Scheme]
+	if !ok {
+		if server, ok = p.Servers[""]; !ok {
+			server, ok = p.Servers["http"]
+		}
+	}
+	if !ok {
+		return nil, nil
+	}
+	return parseProxyURL(server)
+}
+
+// systemProxy учитывает переменные окружения HTTP(S)_PROXY, а без них - прокси Windows
+func systemProxy() func(*http.Request) (*url.URL, error) {
+	for _, name := range []string{"HTTPS_PROXY", "https_proxy", "HTTP_PROXY", "http_proxy"} {
+		if os.Getenv(name) != "" {
+			return http.ProxyFromEnvironment
+		}
+	}
+	p, ok := readWindowsProxy()
+	if !ok {
+		if p.PACURL != "" {
+			log.Printf("Скрипт автонастройки прокси %s не поддерживается, используется прямое подключение", p.PACURL)
+		}
+		return nil
+	}
+	return p.proxyFor
+}
+
+// describeSystemProxy - строка о найденном системном прокси для окна настроек
+func describeSystemProxy() string {
+	for _, name := range []string{"HTTPS_PROXY", "HTTP_PROXY"} {
+		if v := os.Getenv(name); v != "" {
+			return "Системный прокси: " + v + " (переменная " + name + ")"
+		}
+	}
+	p, ok := readWindowsProxy()
+	switch {
+	case ok:
+		var servers []string
+		for scheme, host := range p.Servers {
+			if scheme != "" {
+				host = scheme + "=" + host
+			}
+			servers = append(servers, host)
+		}
+		return "Системный прокси: " + strings.Join(servers, "; ")
+	case p.PACURL != "":
+		return "В системе задан скрипт автонастройки - он не поддерживается, подключение будет прямым."
+	}
+	return "Системный прокси не задан, подключение прямое."
+}
+
+// testConnection проверяет, что с заданными настройками открывается сайт Jooble
+func testConnection(s AppSettings) error {
+	client, err := newHTTPClient(s)
+	if err != nil {
+		return err
+	}
+	timeout := time.Duration(max(s.ConnectTimeoutSeconds, 1)+max(s.ReadTimeoutSeconds, 1)) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "HEAD", "https://jooble.org/", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// showNetworkSettings настраивает прокси, тайм-ауты и проверку сертификатов
+func (app *AppMainWindow) showNetworkSettings() {
+	var dlg *walk.Dialog
+	var modeCB *walk.ComboBox
+	var proxyLE, caFileLE *walk.LineEdit
+	var connectNE, readNE *walk.NumberEdit
+	var skipVerifyCB *walk.CheckBox
+	var acceptPB, cancelPB *walk.PushButton
+
+	mode := indexOfString(proxyModes, appSettings.ProxyMode)
+	if mode < 0 {
+		mode = 0
+	}
+	// collect собирает настройки из полей окна, не трогая остальные
+	collect := func() AppSettings {
+		s := appSettings
+		s.ProxyMode = proxyModes[max(modeCB.CurrentIndex(), 0)]
+		s.ProxyURL = strings.TrimSpace(proxyLE.Text())
+		s.ConnectTimeoutSeconds = int(connectNE.Value())
+		s.ReadTimeoutSeconds = int(readNE.Value())
+		s.TLSSkipVerify = skipVerifyCB.Checked()
+		s.TLSCAFile = strings.TrimSpace(caFileLE.Text())
+		return s
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Настройки сети",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 480, Height: 400},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Прокси:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			ComboBox{
+				AssignTo:     &modeCB,
+				Model:        proxyModeLabels,
+				CurrentIndex: mode,
+				Font:         Font{PointSize: 9},
+				OnCurrentIndexChanged: func() {
+					proxyLE.SetEnabled(proxyModes[max(modeCB.CurrentIndex(), 0)] == proxyManual)
+				},
+			},
+			LineEdit{
+				AssignTo:  &proxyLE,
+				Text:      appSettings.ProxyURL,
+				CueBanner: "http://proxy.example.com:8080",
+				Enabled:   proxyModes[mode] == proxyManual,
+				Font:      Font{PointSize: 9},
+			},
+			Label{Text: describeSystemProxy(), TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Тайм-аут подключения, сек:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+					NumberEdit{
+						AssignTo:           &connectNE,
+						Value:              float64(max(appSettings.ConnectTimeoutSeconds, 1)),
+						MinValue:           1,
+						MaxValue:           120,
+						SpinButtonsVisible: true,
+						Font:               Font{PointSize: 9},
+					},
+					Label{Text: "Тайм-аут ожидания ответа, сек:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+					NumberEdit{
+						AssignTo:           &readNE,
+						Value:              float64(max(appSettings.ReadTimeoutSeconds, 1)),
+						MinValue:           1,
+						MaxValue:           600,
+						SpinButtonsVisible: true,
+						Font:               Font{PointSize: 9},
+					},
+				},
+			},
+			Label{Text: "Корневой сертификат (PEM), например корпоративного прокси:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					LineEdit{AssignTo: &caFileLE, Text: appSettings.TLSCAFile, Font: Font{PointSize: 9}},
+					PushButton{
+						Text: "Обзор...",
+						OnClicked: func() {
+							fd := new(walk.FileDialog)
+							fd.Title = "Выберите файл сертификата"
+							fd.Filter = "Сертификаты (*.pem;*.crt;*.cer)|*.pem;*.crt;*.cer|Все файлы (*.*)|*.*"
+							if ok, _ := fd.ShowOpen(dlg); ok {
+								caFileLE.SetText(fd.FilePath)
+							}
+						},
+					},
+				},
+			},
+			CheckBox{
+				AssignTo: &skipVerifyCB,
+				Text:     "Не проверять сертификаты сайтов (небезопасно)",
+				Checked:  appSettings.TLSSkipVerify,
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Проверить соединение",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							s := collect()
+							dlg.SetEnabled(false)
+							go func() {
+								err := testConnection(s)
+								dlg.Synchronize(func() {
+									dlg.SetEnabled(true)
+									if err != nil {
+										showError(dlg, "Проверка соединения", wrapError("Не удалось подключиться", err))
+										return
+									}
+									app.notify("Проверка соединения: соединение установлено.")
+								})
+							}()
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							s := collect()
+							if _, err := newHTTPClient(s); err != nil {
+								showError(dlg, "Ошибка", err)
+								return
+							}
+							if s.TLSSkipVerify && !appSettings.TLSSkipVerify &&
+								walk.DlgCmdYes != walk.MsgBox(dlg, "Проверка сертификатов",
+									"Без проверки сертификатов данные можно перехватить. Отключить проверку?",
+									walk.MsgBoxYesNo|walk.MsgBoxIconWarning) {
+								return
+							}
+							appSettings = s
+							saveSettings()
+							resetHTTPClient()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
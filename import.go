@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/lxn/walk"
+)
+
+// Заголовки столбцов CSV (в нижнем регистре) и поля вакансии, в которые они попадают
+var csvHeaderFields = map[string]string{
+	"title":           "title",
+	"название":        "title",
+	"вакансия":        "title",
+	"должность":       "title",
+	"company":         "company",
+	"компания":        "company",
+	"работодатель":    "company",
+	"description":     "description",
+	"описание":        "description",
+	"keywords":        "keywords",
+	"ключевые слова":  "keywords",
+	"навыки":          "keywords",
+	"url":             "url",
+	"sourceurl":       "url",
+	"ссылка":          "url",
+	"status":          "status",
+	"статус":          "status",
+	"experience":      "experience",
+	"experiencelevel": "experience",
+	"опыт":            "experience",
+	"notes":           "notes",
+	"заметки":         "notes",
+	"salary":          "salary",
+	"зарплата":        "salary",
+	"location":        "location",
+	"город":           "location",
+}
+
+// detectCSVDelimiter выбирает разделитель по первой строке: Excel в русской локали сохраняет через ";"
+func detectCSVDelimiter(data []byte) rune {
+	firstLine, _, _ := bytes.Cut(data, []byte("\n"))
+	best, bestCount := ',', bytes.Count(firstLine, []byte(","))
+	for _, d := range []rune{';', '\t'} {
+		if n := bytes.Count(firstLine, []byte(string(d))); n > bestCount {
+			best, bestCount = d, n
+		}
+	}
+	return best
+}
+
+// parseVacanciesCSV читает вакансии из CSV с заголовком. Обязателен только столбец с названием.
+func parseVacanciesCSV(data []byte) ([]Vacancy, error) {
+	data = bytes.TrimPrefix(data, []byte("\ufeff"))
+	r := csv.NewReader(bytes.NewReader(data))
+	r.Comma = detectCSVDelimiter(data)
+	r.FieldsPerRecord = -1
+	r.LazyQuotes = true
+
+	records, err := r.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("файл пуст")
+	}
+
+	columns := map[string]int{}
+	for i, h := range records[0] {
+		if field, ok := csvHeaderFields[strings.ToLower(strings.TrimSpace(h))]; ok {
+			if _, seen := columns[field]; !seen {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, errors.New("в первой строке нет столбца с названием вакансии (title или «Название»)")
+	}
+
+	var vacancies []Vacancy
+	for _, rec := range records[1:] {
+		get := func(field string) string {
+			if i, ok := columns[field]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+		v := Vacancy{
+			Title:           get("title"),
+			Company:         get("company"),
+			Description:     get("description"),
+			SourceURL:       get("url"),
+			Notes:           get("notes"),
+			Location:        get("location"),
+			Status:          possibleStatuses[0],
+			ExperienceLevel: possibleExperienceLevels[0],
+		}
+		if v.Title == "" {
+			continue
+		}
+		if s := get("status"); slices.Contains(possibleStatuses, s) {
+			v.Status = s
+		}
+		if e := get("experience"); slices.Contains(possibleExperienceLevels, e) {
+			v.ExperienceLevel = e
+		}
+		autoDetectExperience(&v)
+		for _, kw := range strings.FieldsFunc(get("keywords"), func(r rune) bool { return r == ',' || r == ';' }) {
+			if kw = strings.TrimSpace(kw); kw != "" {
+				v.Keywords = append(v.Keywords, kw)
+			}
+		}
+		applySalary(&v, get("salary"))
+		vacancies = append(vacancies, v)
+	}
+	return vacancies, nil
+}
+
+// readVacanciesForImport читает вакансии из CSV или файла вакансий этого приложения
+func readVacanciesForImport(path string) ([]Vacancy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		return parseVacanciesCSV(data)
+	}
+	vacancies, _, err := decodeVacanciesFile(data)
+	if err != nil {
+		return nil, fmt.Errorf("не удалось прочитать файл вакансий: %w", err)
+	}
+	return vacancies, nil
+}
+
+// mergeImportedVacancies добавляет импортированные вакансии; с уже имеющимися поступает по правилам дедупликации
+// (совпадают название и компания), и сохраняет список. При отмене ctx уже добавленное сохраняется.
+func mergeImportedVacancies(ctx context.Context, imported []Vacancy) (added, merged, skipped int) {
+	if len(imported) == 0 {
+		return 0, 0, 0
+	}
+	progressTotal(ctx, len(imported))
+	for _, v := range imported {
+		if ctx.Err() != nil {
+			break
+		}
+		progressStep(ctx, v.Title)
+		allVacanciesMutex.Lock()
+		outcome := importVacancyLocked(v)
+		allVacanciesMutex.Unlock()
+		switch outcome {
+		case importAdded:
+			added++
+		case importMerged:
+			merged++
+		default:
+			skipped++
+		}
+	}
+	if added+merged > 0 {
+		saveVacancies()
+		vacancyEvents.Publish(VacancyEvent{Kind: VacanciesImported})
+	}
+	return added, merged, skipped
+}
+
+// importVacanciesFile загружает вакансии из таблицы CSV (в том числе сохранённой из Excel) или файла вакансий
+func (app *AppMainWindow) importVacanciesFile() {
+	fd := new(walk.FileDialog)
+	fd.Title = "Импорт вакансий из CSV или Excel"
+	fd.Filter = "Вакансии (*.csv;*.json)|*.csv;*.json|Все файлы (*.*)|*.*"
+	if ok, err := fd.ShowOpen(app.MainWindow); err != nil || !ok {
+		return
+	}
+	imported, err := readVacanciesForImport(fd.FilePath)
+	if err != nil {
+		log.Printf("Ошибка импорта вакансий из %s: %v", fd.FilePath, err)
+		showError(app.MainWindow, "Импорт вакансий", wrapError("Не удалось прочитать файл. Из Excel сохраните таблицу как «CSV (разделители - запятые)»", err))
+		return
+	}
+	if len(imported) == 0 {
+		app.notify("В файле " + filepath.Base(fd.FilePath) + " не найдено вакансий.")
+		return
+	}
+	var added, merged, skipped int
+	err = runWithProgress(app.MainWindow, "Импорт вакансий", func(ctx context.Context) error {
+		added, merged, skipped = mergeImportedVacancies(ctx, imported)
+		return ctx.Err()
+	})
+	text := fmt.Sprintf("Импортировано вакансий: %d, объединено с имеющимися: %d, пропущено дубликатов: %d.", added, merged, skipped)
+	if errors.Is(err, context.Canceled) {
+		text = "Импорт прерван. " + text
+	}
+	log.Printf("Импорт из %s: %s", fd.FilePath, text)
+	app.notify(text)
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lxn/win"
+	"golang.org/x/sys/windows"
+)
+
+// Одна копия приложения на профиль: повторный запуск (ярлык быстрого добавления,
+// двойной щелчок по файлу .vacancy) передаёт свои аргументы уже открытому окну через
+// именованный канал и завершается, а окно выходит на передний план. Копии с разными
+// профилями и папками данных, а также окна просмотра чужой базы работают независимо.
+
+// Сколько ждать, пока запущенная копия освободит канал
+const instancePipeTimeout = 3 * time.Second
+
+// Предельный размер сообщения с аргументами
+const instanceMessageLimit = 64 << 10
+
+var procAllowSetForegroundWindow = windows.NewLazySystemDLL("user32.dll").NewProc("AllowSetForegroundWindow")
+
+// instanceMessage - аргументы, переданные запущенной копии
+type instanceMessage struct {
+	Args []string `json:"args"`
+}
+
+// instancePipeName - имя канала для текущего пользователя, профиля и папки данных
+func instancePipeName() string {
+	h := fnv.New64a()
+	dir, _ := filepath.Abs(dataPath("."))
+	fmt.Fprintf(h, "%s|%s|%s", os.Getenv("USERNAME"), activeProfile.Name, strings.ToLower(dir))
+	return fmt.Sprintf(`\\.\pipe\JobSearchApp-%x`, h.Sum64())
+}
+
+// createInstancePipe создаёт канал для приёма аргументов; не удаётся, если канал уже
+// принадлежит другой копии приложения
+func createInstancePipe(name string) (windows.Handle, error) {
+	return windows.CreateNamedPipe(windows.StringToUTF16Ptr(name), windows.PIPE_ACCESS_INBOUND|windows.FILE_FLAG_FIRST_PIPE_INSTANCE,
+		windows.PIPE_TYPE_BYTE|windows.PIPE_READMODE_BYTE|windows.PIPE_WAIT|windows.PIPE_REJECT_REMOTE_CLIENTS,
+		windows.PIPE_UNLIMITED_INSTANCES, 4096, 4096, 0, nil)
+}
+
+// claimSingleInstance занимает канал приложения. Если он уже занят, аргументы передаются
+// запущенной копии и возвращается false - этой копии нужно завершиться.
+func claimSingleInstance(args []string) (windows.Handle, bool) {
+	if viewOnly {
+		return windows.InvalidHandle, true // Окна просмотра открываются рядом с основным
+	}
+	name := instancePipeName()
+	h, err := createInstancePipe(name)
+	if err == nil {
+		return h, true
+	}
+	if !errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		log.Printf("Не удалось создать канал %s: %v", name, err)
+		return windows.InvalidHandle, true // Лучше две копии, чем ни одной
+	}
+	if err := forwardToInstance(name, args); err != nil {
+		log.Printf("Не удалось передать аргументы запущенной копии: %v", err)
+		return windows.InvalidHandle, true
+	}
+	log.Print("Приложение уже запущено, аргументы переданы открытому окну")
+	return windows.InvalidHandle, false
+}
+
+// forwardToInstance отправляет аргументы запуска в канал открытой копии
+func forwardToInstance(name string, args []string) error {
+	msg := instanceMessage{}
+	for _, a := range args {
+		// Файлы из проводника могут прийти относительными путями, а папка у копий разная
+		if strings.EqualFold(filepath.Ext(a), sharedVacancyExt) {
+			if abs, err := filepath.Abs(a); err == nil {
+				a = abs
+			}
+		}
+		msg.Args = append(msg.Args, a)
+	}
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+
+	var h windows.Handle
+	deadline := time.Now().Add(instancePipeTimeout)
+	for {
+		h, err = windows.CreateFile(windows.StringToUTF16Ptr(name), windows.GENERIC_WRITE, 0, nil, windows.OPEN_EXISTING, 0, 0)
+		if !errors.Is(err, windows.ERROR_PIPE_BUSY) || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond) // Копия принимает другое сообщение
+	}
+	if err != nil {
+		return err
+	}
+	f := os.NewFile(uintptr(h), name)
+	defer f.Close()
+
+	// Окно вправе выйти вперёд только с разрешения процесса, который запустил пользователь
+	var pid uint32
+	if windows.GetNamedPipeServerProcessId(h, &pid) == nil {
+		procAllowSetForegroundWindow.Call(uintptr(pid))
+	}
+	_, err = f.Write(data)
+	return err
+}
+
+// pipeReader читает сообщение из канала до его закрытия другой стороной; сам канал не закрывает
+type pipeReader windows.Handle
+
+func (r pipeReader) Read(p []byte) (int, error) {
+	var n uint32
+	err := windows.ReadFile(windows.Handle(r), p, &n, nil)
+	if errors.Is(err, windows.ERROR_BROKEN_PIPE) {
+		return int(n), io.EOF
+	}
+	return int(n), err
+}
+
+// serveInstancePipe принимает аргументы от повторных запусков и передаёт их окну
+func (app *AppMainWindow) serveInstancePipe(h windows.Handle) {
+	if h == windows.InvalidHandle {
+		return
+	}
+	name := instancePipeName()
+	go func() {
+		for {
+			if err := windows.ConnectNamedPipe(h, nil); err != nil && !errors.Is(err, windows.ERROR_PIPE_CONNECTED) {
+				log.Printf("Ошибка канала %s: %v", name, err)
+				windows.CloseHandle(h)
+				return
+			}
+			var msg instanceMessage
+			data, err := io.ReadAll(io.LimitReader(pipeReader(h), instanceMessageLimit))
+			if err == nil {
+				err = json.Unmarshal(data, &msg)
+			}
+			windows.DisconnectNamedPipe(h)
+			if err != nil {
+				log.Printf("Ошибка чтения аргументов повторного запуска: %v", err)
+			} else {
+				app.Synchronize(func() { app.handleForwardedArgs(msg.Args) })
+			}
+		}
+	}()
+}
+
+// handleForwardedArgs выводит окно вперёд и выполняет то, с чем приложение запустили повторно
+func (app *AppMainWindow) handleForwardedArgs(args []string) {
+	log.Printf("Повторный запуск с аргументами %q", args)
+	if boolFlagFromArgs(args, "tray") {
+		return // Автозагрузка при уже открытом приложении
+	}
+	app.showMainWindow()
+	if app.inTray || app.locked {
+		// PIN не введён: файлы и ссылки откроются после разблокировки, а не поверх неё
+		app.pendingLaunchArgs = append(app.pendingLaunchArgs, args)
+		return
+	}
+	if win.IsIconic(app.Handle()) {
+		win.ShowWindow(app.Handle(), win.SW_RESTORE)
+	}
+	win.SetForegroundWindow(app.Handle())
+	app.handleLaunchArgs(args)
+}
+
+// replayLaunchArgs выполняет аргументы повторных запусков, отложенные до ввода PIN
+func (app *AppMainWindow) replayLaunchArgs() {
+	pending := app.pendingLaunchArgs
+	app.pendingLaunchArgs = nil
+	for _, args := range pending {
+		app.handleLaunchArgs(args)
+	}
+}
+
+// handleLaunchArgs выполняет действия из аргументов запуска: открывает файлы .vacancy
+// и ссылки vacancy://, переходит к строке быстрого добавления
+func (app *AppMainWindow) handleLaunchArgs(args []string) {
+	for _, f := range vacancyFilesFromArgs(args) {
+		app.importSharedVacancyFile(f)
+	}
+	for _, link := range vacancyLinksFromArgs(args) {
+		app.openVacancyLink(link)
+	}
+	if boolFlagFromArgs(args, "quick-add") && app.quickTitleLE != nil && !viewOnly {
+		app.quickTitleLE.SetFocus()
+	}
+}
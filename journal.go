@@ -0,0 +1,67 @@
+package main
+
+import (
+	"sort"
+	"time"
+)
+
+// Виды записей журнала
+const (
+	journalMail     = "Письмо"
+	journalCalendar = "Календарь"
+)
+
+// JournalEntry - событие по вакансии: отправленное письмо, звонок и т.п.
+type JournalEntry struct {
+	At   time.Time `json:"at"`
+	Kind string    `json:"kind"`
+	Text string    `json:"text"`
+}
+
+// changeJournal меняет вакансию вместе с журналом (например, контакт рекрутера и запись о письме) и сохраняет файл
+func changeJournal(title, company string, change func(v *Vacancy)) bool {
+	allVacanciesMutex.Lock()
+	found := false
+	for i := range allVacancies {
+		if sameVacancy(allVacancies[i].Title, allVacancies[i].Company, title, company) {
+			change(&allVacancies[i])
+			found = true
+			break
+		}
+	}
+	allVacanciesMutex.Unlock()
+	if found {
+		saveVacancies()
+		vacancyEvents.Publish(VacancyEvent{Kind: VacancyUpdated, Title: title, Company: company})
+	}
+	return found
+}
+
+// addJournalEntry добавляет запись в журнал вакансии
+func addJournalEntry(title, company string, e JournalEntry) bool {
+	return changeJournal(title, company, func(v *Vacancy) { v.Journal = append(v.Journal, e) })
+}
+
+// journalLines - записи журнала для списка, новые сверху
+func journalLines(entries []JournalEntry) []string {
+	sorted := append([]JournalEntry{}, entries...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].At.After(sorted[j].At) })
+	lines := make([]string, len(sorted))
+	for i, e := range sorted {
+		lines[i] = e.At.Format("02.01.2006 15:04") + " — " + e.Kind + ": " + e.Text
+	}
+	return lines
+}
+
+// updateJournalList показывает журнал выбранной вакансии в панели деталей
+func (app *AppMainWindow) updateJournalList(v Vacancy, hasSelection bool) {
+	if app.detailJournalLB == nil {
+		return
+	}
+	var lines []string
+	if hasSelection {
+		lines = journalLines(v.Journal)
+	}
+	app.detailJournalLB.SetModel(lines)
+	app.detailJournalLB.SetEnabled(hasSelection)
+}
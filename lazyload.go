@@ -0,0 +1,35 @@
+package main
+
+// Строки таблицы вакансий - облегчённые копии: описание, заметки и версии описания
+// таблице не нужны. Полная вакансия берётся из allVacancies, когда строку выделяют
+// (updateVacancyDetails) или над ней выполняют действие.
+
+// listEntry - строка таблицы: вакансия без тяжёлых полей
+func listEntry(v Vacancy) Vacancy {
+	v.Description = ""
+	v.Notes = ""
+	v.Revisions = nil
+	return v
+}
+
+// listEntries облегчает вакансии для таблицы; items должен быть копией, а не allVacancies
+func listEntries(items []Vacancy) []Vacancy {
+	for i := range items {
+		items[i] = listEntry(items[i])
+	}
+	return items
+}
+
+// vacancyAt - полная вакансия строки таблицы idx
+func (app *AppMainWindow) vacancyAt(idx int) (Vacancy, bool) {
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		return Vacancy{}, false
+	}
+	row := app.vacancyModel.items[idx]
+	allVacanciesMutex.Lock()
+	defer allVacanciesMutex.Unlock()
+	if i := app.findVacancyIndexInAllExt(row.Title, row.Company); i != -1 {
+		return allVacancies[i], true
+	}
+	return row, true // Вакансию только что удалили - показываем то, что есть в строке
+}
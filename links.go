@@ -0,0 +1,272 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Виды связей между вакансиями
+var relationKinds = []string{
+	"Та же компания, другая команда",
+	"Повторная публикация",
+	"Похожая вакансия",
+}
+
+// RelatedVacancy - ссылка на связанную вакансию (по названию и компании, как и везде в приложении)
+type RelatedVacancy struct {
+	Title    string `json:"title"`
+	Company  string `json:"company"`
+	Relation string `json:"relation,omitempty"`
+}
+
+// sameVacancy сравнивает вакансии так же, как findVacancyIndexInAllExt
+func sameVacancy(title, company, otherTitle, otherCompany string) bool {
+	return strings.EqualFold(title, otherTitle) && strings.EqualFold(company, otherCompany)
+}
+
+// addRelation добавляет связь в вакансию, если её ещё нет
+func addRelation(v *Vacancy, title, company, relation string) {
+	for i, r := range v.Related {
+		if sameVacancy(r.Title, r.Company, title, company) {
+			v.Related[i].Relation = relation
+			return
+		}
+	}
+	v.Related = append(v.Related, RelatedVacancy{Title: title, Company: company, Relation: relation})
+}
+
+// removeRelation убирает связь с указанной вакансией
+func removeRelation(v *Vacancy, title, company string) {
+	var kept []RelatedVacancy
+	for _, r := range v.Related {
+		if !sameVacancy(r.Title, r.Company, title, company) {
+			kept = append(kept, r)
+		}
+	}
+	v.Related = kept
+}
+
+// renameVacancyLinksLocked обновляет ссылки на вакансию после смены названия или компании;
+// allVacanciesMutex держит вызывающий
+func renameVacancyLinksLocked(oldTitle, oldCompany, newTitle, newCompany string) {
+	if oldTitle == newTitle && oldCompany == newCompany {
+		return
+	}
+	for i := range allVacancies {
+		for j, r := range allVacancies[i].Related {
+			if sameVacancy(r.Title, r.Company, oldTitle, oldCompany) {
+				allVacancies[i].Related[j].Title = newTitle
+				allVacancies[i].Related[j].Company = newCompany
+			}
+		}
+	}
+}
+
+// removeVacancyLinksLocked убирает ссылки на удалённую вакансию; allVacanciesMutex держит вызывающий
+func removeVacancyLinksLocked(title, company string) {
+	for i := range allVacancies {
+		removeRelation(&allVacancies[i], title, company)
+	}
+}
+
+// relationLabel - строка связанной вакансии для списка в панели деталей
+func relationLabel(r RelatedVacancy) string {
+	label := r.Title
+	if r.Company != "" {
+		label += " — " + r.Company
+	}
+	if r.Relation != "" {
+		label += " (" + r.Relation + ")"
+	}
+	return label
+}
+
+// updateRelatedList показывает связанные вакансии выбранной вакансии
+func (app *AppMainWindow) updateRelatedList(v Vacancy, hasSelection bool) {
+	if app.detailRelatedLB == nil {
+		return
+	}
+	app.relatedItems = nil
+	if hasSelection {
+		app.relatedItems = append(app.relatedItems, v.Related...)
+	}
+	labels := make([]string, len(app.relatedItems))
+	for i, r := range app.relatedItems {
+		labels[i] = relationLabel(r)
+	}
+	app.detailRelatedLB.SetModel(labels)
+	app.detailRelatedLB.SetEnabled(hasSelection)
+}
+
+// selectVacancy выделяет вакансию в таблице; если она скрыта фильтром, фильтр сбрасывается
+func (app *AppMainWindow) selectVacancy(title, company string) bool {
+	find := func() int {
+		for i, v := range app.vacancyModel.items {
+			if sameVacancy(v.Title, v.Company, title, company) {
+				return i
+			}
+		}
+		return -1
+	}
+	i := find()
+	if i == -1 {
+		app.searchFieldCB.SetCurrentIndex(0)
+		app.searchEdit.SetText("")
+		app.performSearch()
+		i = find()
+	}
+	if i == -1 {
+		return false
+	}
+	app.vacancyTable.SetCurrentIndex(i)
+	app.vacancyTable.EnsureItemVisible(i)
+	return true
+}
+
+// openRelatedVacancy переходит к выбранной в списке связанной вакансии
+func (app *AppMainWindow) openRelatedVacancy() {
+	i := app.detailRelatedLB.CurrentIndex()
+	if i < 0 || i >= len(app.relatedItems) {
+		return
+	}
+	r := app.relatedItems[i]
+	if !app.selectVacancy(r.Title, r.Company) {
+		walk.MsgBox(app.MainWindow, "Связанные вакансии", "Вакансия '"+r.Title+"' не найдена в списке.", walk.MsgBoxIconWarning)
+	}
+}
+
+// linkSelectedVacancy связывает выбранную вакансию с другой
+func (app *AppMainWindow) linkSelectedVacancy() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		walk.MsgBox(app.MainWindow, "Связанные вакансии", "Пожалуйста, выберите вакансию.", walk.MsgBoxIconInformation)
+		return
+	}
+	source := app.vacancyModel.items[idx]
+
+	allVacanciesMutex.Lock()
+	var candidates []Vacancy
+	for _, v := range allVacancies {
+		if sameVacancy(v.Title, v.Company, source.Title, source.Company) {
+			continue
+		}
+		candidates = append(candidates, v)
+	}
+	allVacanciesMutex.Unlock()
+	if len(candidates) == 0 {
+		walk.MsgBox(app.MainWindow, "Связанные вакансии", "В списке нет других вакансий.", walk.MsgBoxIconInformation)
+		return
+	}
+	// Вакансии той же компании - первыми
+	sort.SliceStable(candidates, func(i, j int) bool {
+		si := strings.EqualFold(candidates[i].Company, source.Company)
+		sj := strings.EqualFold(candidates[j].Company, source.Company)
+		if si != sj {
+			return si
+		}
+		return strings.ToLower(candidates[i].Title) < strings.ToLower(candidates[j].Title)
+	})
+	names := make([]string, len(candidates))
+	for i, v := range candidates {
+		names[i] = relationLabel(RelatedVacancy{Title: v.Title, Company: v.Company})
+	}
+
+	var dlg *walk.Dialog
+	var targetCB, relationCB *walk.ComboBox
+	var acceptPB, cancelPB *walk.PushButton
+	var target Vacancy
+	var relation string
+	accepted := false
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Связать вакансии",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 420, Height: 200},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: fmt.Sprintf("Связать '%s' с вакансией:", source.Title), TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			ComboBox{AssignTo: &targetCB, Model: names, CurrentIndex: 0, Font: Font{PointSize: 9}},
+			Label{Text: "Вид связи:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			ComboBox{AssignTo: &relationCB, Model: relationKinds, CurrentIndex: 0, Font: Font{PointSize: 9}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Связать",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							i := targetCB.CurrentIndex()
+							if i < 0 {
+								return
+							}
+							target, relation, accepted = candidates[i], relationCB.Text(), true
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	if !accepted {
+		return
+	}
+
+	allVacanciesMutex.Lock()
+	si := app.findVacancyIndexInAllExt(source.Title, source.Company)
+	ti := app.findVacancyIndexInAllExt(target.Title, target.Company)
+	if si != -1 && ti != -1 {
+		addRelation(&allVacancies[si], target.Title, target.Company, relation)
+		addRelation(&allVacancies[ti], source.Title, source.Company, relation)
+	}
+	allVacanciesMutex.Unlock()
+	if si == -1 || ti == -1 {
+		walk.MsgBox(app.MainWindow, "Ошибка", "Не удалось найти вакансии для связывания.", walk.MsgBoxIconError)
+		return
+	}
+	saveVacancies()
+	vacancyEvents.Publish(vacancyEvent(VacancyUpdated, source))
+}
+
+// unlinkRelatedVacancy убирает выбранную связь у обеих вакансий
+func (app *AppMainWindow) unlinkRelatedVacancy() {
+	idx := app.vacancyTable.CurrentIndex()
+	i := app.detailRelatedLB.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) || i < 0 || i >= len(app.relatedItems) {
+		walk.MsgBox(app.MainWindow, "Связанные вакансии", "Выберите связь в списке.", walk.MsgBoxIconInformation)
+		return
+	}
+	source := app.vacancyModel.items[idx]
+	r := app.relatedItems[i]
+
+	allVacanciesMutex.Lock()
+	if si := app.findVacancyIndexInAllExt(source.Title, source.Company); si != -1 {
+		removeRelation(&allVacancies[si], r.Title, r.Company)
+	}
+	if ti := app.findVacancyIndexInAllExt(r.Title, r.Company); ti != -1 {
+		removeRelation(&allVacancies[ti], source.Title, source.Company)
+	}
+	allVacanciesMutex.Unlock()
+	saveVacancies()
+	vacancyEvents.Publish(vacancyEvent(VacancyUpdated, source))
+}
@@ -0,0 +1,395 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"text/template"
+	"time"
+	"unsafe"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+	"golang.org/x/sys/windows"
+)
+
+// Тема письма, пока пользователь не задал свой шаблон
+const defaultMailSubject = "Отклик на вакансию «{{.Title}}» — {{.Name}}"
+
+// Адреса mailto длиннее этого обрезаются оболочкой Windows
+const maxMailtoLength = 2000
+
+var (
+	errMailCancelled   = errors.New("отправка отменена")
+	errMAPIUnavailable = errors.New("почтовая программа не поддерживает Simple MAPI")
+)
+
+var procMAPISendMailW = windows.NewLazySystemDLL("mapi32.dll").NewProc("MAPISendMailW")
+
+// Константы Simple MAPI
+const (
+	mapiTo         = 1
+	mapiLogonUI    = 0x1
+	mapiDialog     = 0x8
+	mapiUserAbort  = 1
+	mapiNoPosition = 0xFFFFFFFF
+)
+
+// mapiRecipDesc - MapiRecipDescW
+type mapiRecipDesc struct {
+	Reserved   uint32
+	RecipClass uint32
+	Name       *uint16
+	Address    *uint16
+	EIDSize    uint32
+	EntryID    uintptr
+}
+
+// mapiFileDesc - MapiFileDescW
+type mapiFileDesc struct {
+	Reserved uint32
+	Flags    uint32
+	Position uint32
+	PathName *uint16
+	FileName *uint16
+	FileType uintptr
+}
+
+// mapiMessage - MapiMessageW
+type mapiMessage struct {
+	Reserved       uint32
+	Subject        *uint16
+	NoteText       *uint16
+	MessageType    *uint16
+	DateReceived   *uint16
+	ConversationID *uint16
+	Flags          uint32
+	Originator     *mapiRecipDesc
+	RecipCount     uint32
+	Recips         *mapiRecipDesc
+	FileCount      uint32
+	Files          *mapiFileDesc
+}
+
+// mailAttachment - файл, который можно приложить к письму
+type mailAttachment struct {
+	Name string // Имя файла в письме
+	Path string
+}
+
+// recruiterMail - письмо рекрутеру, подготовленное в диалоге
+type recruiterMail struct {
+	To      string
+	Name    string
+	Subject string
+	Body    string
+	Files   []mailAttachment
+}
+
+// sendMailMAPI открывает окно нового письма почтовой программы по умолчанию с вложениями.
+// Вызов блокирует поток до закрытия окна, поэтому выполняется вне потока интерфейса.
+func sendMailMAPI(m recruiterMail) error {
+	if err := procMAPISendMailW.Find(); err != nil {
+		return errMAPIUnavailable
+	}
+	recip := mapiRecipDesc{
+		RecipClass: mapiTo,
+		Name:       windows.StringToUTF16Ptr(firstNonEmpty(m.Name, m.To)),
+		Address:    windows.StringToUTF16Ptr("SMTP:" + m.To),
+	}
+	files := make([]mapiFileDesc, len(m.Files))
+	for i, f := range m.Files {
+		files[i] = mapiFileDesc{
+			Position: mapiNoPosition,
+			PathName: windows.StringToUTF16Ptr(f.Path),
+			FileName: windows.StringToUTF16Ptr(f.Name),
+		}
+	}
+	msg := mapiMessage{
+		Subject:    windows.StringToUTF16Ptr(m.Subject),
+		NoteText:   windows.StringToUTF16Ptr(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n")),
+		RecipCount: 1,
+		Recips:     &recip,
+		FileCount:  uint32(len(files)),
+	}
+	if len(files) > 0 {
+		msg.Files = &files[0]
+	}
+	ret, _, _ := procMAPISendMailW.Call(0, 0, uintptr(unsafe.Pointer(&msg)), mapiLogonUI|mapiDialog, 0)
+	runtime.KeepAlive(files)
+	switch ret {
+	case 0:
+		return nil
+	case mapiUserAbort:
+		return errMailCancelled
+	default:
+		return fmt.Errorf("%w (код %d)", errMAPIUnavailable, ret)
+	}
+}
+
+// mailtoURL собирает ссылку mailto:, при необходимости сокращая текст письма
+func mailtoURL(to, subject, body string) string {
+	escape := func(s string) string { return strings.ReplaceAll(url.QueryEscape(s), "+", "%20") }
+	body = strings.ReplaceAll(strings.ReplaceAll(body, "\r\n", "\n"), "\n", "\r\n")
+	for {
+		link := "mailto:" + url.PathEscape(to) + "?subject=" + escape(subject) + "&body=" + escape(body)
+		if len(link) <= maxMailtoLength || body == "" {
+			return link
+		}
+		runes := []rune(body)
+		body = string(runes[:len(runes)*9/10])
+	}
+}
+
+// mergeText подставляет поля вакансии и профиля в однострочный шаблон
+func mergeText(text string, data coverLetterData) (string, error) {
+	tmpl, err := template.New("text").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// coverLetterBody - текст сопроводительного письма из шаблона .docx для тела письма
+func coverLetterBody(v Vacancy) (string, error) {
+	tmpl, err := coverLetterTemplateData(appSettings.CoverLetterTemplate)
+	if err != nil {
+		return "", err
+	}
+	docx, err := mergeDocx(tmpl, newCoverLetterData(v, appSettings.Profile))
+	if err != nil {
+		return "", err
+	}
+	return docxPlainText(docx)
+}
+
+// mailAttachmentCandidates - резюме вакансии и прикреплённые документы
+func mailAttachmentCandidates(v Vacancy) []mailAttachment {
+	var files []mailAttachment
+	if v.ResumePath != "" {
+		files = append(files, mailAttachment{Name: firstNonEmpty(v.ResumeFileName, filepath.Base(v.ResumePath)), Path: v.ResumePath})
+	}
+	for _, a := range v.Attachments {
+		if !isImageAttachment(a) {
+			files = append(files, mailAttachment{Name: unsafeFileNameChars.ReplaceAllString(a.Caption, "_"), Path: attachmentPath(a)})
+		}
+	}
+	return files
+}
+
+// mailJournalText - запись журнала об отправленном письме
+func mailJournalText(m recruiterMail) string {
+	text := "«" + m.Subject + "» → " + m.To
+	if len(m.Files) > 0 {
+		names := make([]string, len(m.Files))
+		for i, f := range m.Files {
+			names[i] = f.Name
+		}
+		text += "; вложения: " + strings.Join(names, ", ")
+	}
+	return text
+}
+
+// mailRecruiterForSelected - «Написать рекрутеру»: письмо с сопроводительным текстом и резюме
+func (app *AppMainWindow) mailRecruiterForSelected() {
+	v, ok := app.selectedVacancyForAttachments()
+	if !ok {
+		return
+	}
+	body, err := coverLetterBody(v)
+	if err != nil {
+		log.Printf("Не удалось подготовить текст письма: %v", err)
+	}
+	subjectTemplate := firstNonEmpty(appSettings.MailSubjectTemplate, defaultMailSubject)
+	candidates := mailAttachmentCandidates(v)
+
+	var dlg *walk.Dialog
+	var toLE, nameLE, subjectLE *walk.LineEdit
+	var subjectPreview *walk.Label
+	var bodyTE *walk.TextEdit
+	var acceptPB, cancelPB *walk.PushButton
+	checks := make([]*walk.CheckBox, len(candidates))
+	var mail recruiterMail
+	accepted, viaSMTP := false, false
+
+	fileWidgets := []Widget{Label{Text: "Вложения:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}}}
+	if len(candidates) == 0 {
+		fileWidgets = append(fileWidgets, Label{Text: "Нет резюме и документов - создайте их кнопками «Резюме...» и «Письмо...».", TextColor: currentTheme.Text})
+	}
+	for i, f := range candidates {
+		fileWidgets = append(fileWidgets, CheckBox{AssignTo: &checks[i], Text: f.Name, Checked: f.Path == v.ResumePath})
+	}
+
+	data := func() coverLetterData {
+		d := newCoverLetterData(v, appSettings.Profile)
+		d.Recruiter, d.RecruiterEmail = strings.TrimSpace(nameLE.Text()), strings.TrimSpace(toLE.Text())
+		return d
+	}
+	updatePreview := func() {
+		if subjectPreview == nil {
+			return
+		}
+		subject, err := mergeText(subjectLE.Text(), data())
+		if err != nil {
+			subject = "ошибка в шаблоне: " + err.Error()
+		}
+		subjectPreview.SetText("Тема: " + subject)
+	}
+
+	// accept проверяет поля и закрывает окно; smtp - отправить через свой сервер, а не почтовую программу
+	accept := func(smtp bool) {
+		d := data()
+		if !strings.Contains(d.RecruiterEmail, "@") {
+			walk.MsgBox(dlg, "Написать рекрутеру", "Укажите адрес рекрутера.", walk.MsgBoxIconWarning)
+			return
+		}
+		subject, err := mergeText(subjectLE.Text(), d)
+		if err != nil {
+			walk.MsgBox(dlg, "Написать рекрутеру", "Ошибка в шаблоне темы: "+err.Error(), walk.MsgBoxIconWarning)
+			return
+		}
+		mail = recruiterMail{To: d.RecruiterEmail, Name: d.Recruiter, Subject: subject, Body: bodyTE.Text()}
+		for i, f := range candidates {
+			if !checks[i].Checked() {
+				continue
+			}
+			if _, err := os.Stat(f.Path); err != nil {
+				walk.MsgBox(dlg, "Написать рекрутеру", "Файл не найден: "+f.Path, walk.MsgBoxIconWarning)
+				return
+			}
+			mail.Files = append(mail.Files, f)
+		}
+		if t := strings.TrimSpace(subjectLE.Text()); t != subjectTemplate {
+			appSettings.MailSubjectTemplate = t
+			saveSettings()
+		}
+		accepted, viaSMTP = true, smtp
+		dlg.Accept()
+	}
+
+	if err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Написать рекрутеру",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 560, Height: 520},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Кому (email):", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &toLE, Text: v.RecruiterEmail, OnTextChanged: updatePreview},
+					Label{Text: "Рекрутер:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &nameLE, Text: v.Recruiter, OnTextChanged: updatePreview},
+					Label{Text: "Шаблон темы:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &subjectLE, Text: subjectTemplate, OnTextChanged: updatePreview},
+				},
+			},
+			Label{AssignTo: &subjectPreview, TextColor: currentTheme.Text},
+			Label{Text: "Текст письма (из шаблона сопроводительного письма):", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			TextEdit{AssignTo: &bodyTE, Text: strings.ReplaceAll(body, "\n", "\r\n"), VScroll: true, Font: Font{PointSize: 9}},
+			Composite{Layout: VBox{MarginsZero: true, Spacing: 2}, Children: fileWidgets},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						Text:        "Отправить через SMTP",
+						ToolTipText: "Отправить сразу, через сервер из «Настроек SMTP»",
+						Background:  SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if !appSettings.SMTP.configured() {
+								walk.MsgBox(dlg, "Написать рекрутеру", "Сначала укажите почтовый сервер.", walk.MsgBoxIconInformation)
+								app.showSMTPSettings()
+								if !appSettings.SMTP.configured() {
+									return
+								}
+							}
+							accept(true)
+						},
+					},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Открыть в почте",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { accept(false) },
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	updatePreview()
+	dlg.Run()
+	if !accepted {
+		return
+	}
+
+	// Контакт сохраняем сразу, даже если письмо потом не отправят
+	if mail.To != v.RecruiterEmail || mail.Name != v.Recruiter {
+		changeJournal(v.Title, v.Company, func(x *Vacancy) { x.Recruiter, x.RecruiterEmail = mail.Name, mail.To })
+	}
+	if viaSMTP {
+		app.sendRecruiterMailSMTP(v, mail)
+		return
+	}
+	app.sendRecruiterMail(v, mail)
+}
+
+// sendRecruiterMail отправляет письмо через MAPI, а если почтовая программа его не поддерживает - через mailto:
+func (app *AppMainWindow) sendRecruiterMail(v Vacancy, m recruiterMail) {
+	logSent := func() {
+		addJournalEntry(v.Title, v.Company, JournalEntry{At: time.Now(), Kind: journalMail, Text: mailJournalText(m)})
+	}
+	go func() {
+		// Simple MAPI показывает окно письма в вызывающем потоке
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		err := sendMailMAPI(m)
+		app.MainWindow.Synchronize(func() {
+			switch {
+			case err == nil:
+				logSent()
+			case errors.Is(err, errMailCancelled):
+			default:
+				log.Printf("MAPI: %v, открываем mailto:", err)
+				if err := openURL(mailtoURL(m.To, m.Subject, m.Body)); err != nil {
+					showError(app.MainWindow, "Ошибка", wrapError("Не удалось открыть почтовую программу", err))
+					return
+				}
+				question := "Письмо открыто в почтовой программе.\n\nОтметить в журнале, что оно отправлено?"
+				if len(m.Files) > 0 {
+					var names []string
+					for _, f := range m.Files {
+						names = append(names, f.Path)
+					}
+					question = "Почтовая программа не принимает вложения автоматически - приложите их вручную:\n" + strings.Join(names, "\n") + "\n\n" + question
+				}
+				if walk.MsgBox(app.MainWindow, "Написать рекрутеру", question, walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) == walk.DlgCmdYes {
+					logSent()
+				}
+			}
+		})
+	}()
+}
@@ -5,18 +5,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
 	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/lxn/walk"
 	. "github.com/lxn/walk/declarative"
+	"github.com/lxn/win"
 )
 
 const vacanciesFile = "vacancies.json"
@@ -34,6 +36,7 @@ type Theme struct {
 	TableText   walk.Color
 	PanelBG     walk.Color
 	BorderColor walk.Color
+	Dark        bool // Светлый текст на тёмном фоне: под него подбираются цвета статусов и подсветки
 }
 
 // ДОБАВЛЕНО: Глобальные темы
@@ -60,6 +63,21 @@ var (
 		TableText:   walk.RGB(220, 220, 220), // Светло-серый текст таблицы
 		PanelBG:     walk.RGB(40, 40, 40),    // Промежуточный серый для панелей
 		BorderColor: walk.RGB(60, 60, 60),    // Более светлый серый для границ
+		Dark:        true,
+	}
+
+	// Высококонтрастная тема для слабовидящих: чистый чёрный фон, белый текст, жёлтые рамки
+	highContrastTheme = Theme{
+		Name:        "Высококонтрастная",
+		Background:  walk.RGB(0, 0, 0),
+		Text:        walk.RGB(255, 255, 255),
+		ButtonBG:    walk.RGB(0, 0, 0),
+		ButtonText:  walk.RGB(255, 255, 0),
+		TableBG:     walk.RGB(0, 0, 0),
+		TableText:   walk.RGB(255, 255, 255),
+		PanelBG:     walk.RGB(0, 0, 0),
+		BorderColor: walk.RGB(255, 255, 0),
+		Dark:        true,
 	}
 )
 
@@ -78,6 +96,41 @@ type Vacancy struct {
 	Notes           string   `json:"notes,omitempty"`           // ДОБАВЛЕНО: Заметки
 	ResumePath      string   `json:"resumePath,omitempty"`      // ДОБАВЛЕНО: Путь к файлу резюме
 	ResumeFileName  string   `json:"resumeFileName,omitempty"`  // ДОБАВЛЕНО: Имя файла резюме
+	OrderIndex      int      `json:"orderIndex,omitempty"`      // Место в ручном порядке, 0 - не задано
+
+	Salary         string `json:"salary,omitempty"`         // Зарплата в исходном виде, как указана в вакансии
+	SalaryMin      int    `json:"salaryMin,omitempty"`      // Нижняя граница, разобранная из Salary
+	SalaryMax      int    `json:"salaryMax,omitempty"`      // Верхняя граница, разобранная из Salary
+	SalaryCurrency string `json:"salaryCurrency,omitempty"` // Код валюты (RUB, USD, EUR...)
+
+	WorkFormat     string `json:"workFormat,omitempty"`     // Формат работы: офис, гибрид, удалённо
+	Location       string `json:"location,omitempty"`       // Город или регион
+	Source         string `json:"source,omitempty"`         // Сайт, на котором опубликована вакансия
+	Recruiter      string `json:"recruiter,omitempty"`      // Имя рекрутера или контактного лица
+	RecruiterEmail string `json:"recruiterEmail,omitempty"` // Адрес для писем рекрутеру
+	OfferPros      string `json:"offerPros,omitempty"`      // Плюсы оффера (по одному на строку)
+	OfferCons      string `json:"offerCons,omitempty"`      // Минусы оффера (по одному на строку)
+
+	RejectionReason  string `json:"rejectionReason,omitempty"`  // Причина отказа из rejectionReasons
+	RejectionComment string `json:"rejectionComment,omitempty"` // Комментарий к отказу
+
+	Related     []RelatedVacancy    `json:"related,omitempty"`     // Связанные вакансии
+	Revisions   []PostingRevision   `json:"revisions,omitempty"`   // Версии описания и зарплаты, полученные при проверке обновлений
+	Reminders   []Reminder          `json:"reminders,omitempty"`   // Напоминания по вакансии
+	TestTask    *TestTask           `json:"testTask,omitempty"`    // Тестовое задание, если выдавалось
+	Questions   []InterviewQuestion `json:"questions,omitempty"`   // Вопросы, заданные на собеседованиях
+	Attachments []Attachment        `json:"attachments,omitempty"` // Прикреплённые скриншоты и документы
+	Journal     []JournalEntry      `json:"journal,omitempty"`     // Письма и другие события по вакансии
+
+	Extra map[string]json.RawMessage `json:"-"` // Поля из файла, неизвестные этой версии приложения
+
+	PostedAt      time.Time      `json:"postedAt,omitzero"`       // Дата публикации вакансии на сайте
+	CreatedAt     time.Time      `json:"createdAt,omitzero"`      // Дата добавления в локальный список
+	AppliedAt     time.Time      `json:"appliedAt,omitzero"`      // Дата отправки отклика
+	InterviewAt   time.Time      `json:"interviewAt,omitzero"`    // Назначенное собеседование
+	DeadlineAt    time.Time      `json:"deadlineAt,omitzero"`     // Срок отклика или тестового задания
+	StatusHistory []StatusChange `json:"statusHistory,omitempty"` // История смены статусов
+	FollowUps     []time.Time    `json:"followUps,omitempty"`     // Когда отправлялись напоминания работодателю об отклике
 }
 
 // Глобальный срез для хранения вакансий
@@ -104,11 +157,16 @@ type VacancyModel struct {
 	items      []Vacancy
 	sortColumn int
 	sortOrder  walk.SortOrder
+	sortKeys   []SortKey     // Основная колонка сортировки и дополнительные (Shift+щелчок по заголовку)
+	rates      ExchangeRates // Курсы для колонки "Зарплата"
 }
 
+// Колонка с зарплатой, пересчитанной в валюту из настроек
+const salaryColumn = 3
+
 // NewVacancyModel создает новую модель для списка вакансий
 func NewVacancyModel(vacancies []Vacancy) *VacancyModel {
-	m := &VacancyModel{items: vacancies, sortColumn: 0, sortOrder: walk.SortAscending} // Default sort
+	m := &VacancyModel{items: vacancies, sortColumn: 0, sortOrder: walk.SortAscending, sortKeys: defaultSortKeys} // Default sort
 	return m
 }
 
@@ -127,12 +185,23 @@ func (m *VacancyModel) Value(row, col int) interface{} {
 		return item.Company
 	case 2: // Новая колонка для статуса
 		return item.Status
+	case salaryColumn:
+		if presentationMode && item.Salary != "" {
+			return redactedText
+		}
+		return salaryColumnText(m.rates, item)
 	}
 	return ""
 }
 
-// Sort сортирует данные в модели
+// Sort сортирует данные в модели; с нажатым Shift колонка добавляется к ключам сортировки
 func (m *VacancyModel) Sort(col int, order walk.SortOrder) error {
+	m.sortKeys = nextSortKeys(m.sortKeys, col, order, shiftPressed())
+	col = m.sortKeys[0].Column
+	order = walk.SortAscending
+	if m.sortKeys[0].Descending {
+		order = walk.SortDescending
+	}
 	m.sortColumn = col
 	m.sortOrder = order
 	sort.SliceStable(m.items, func(i, j int) bool {
@@ -141,24 +210,20 @@ func (m *VacancyModel) Sort(col int, order walk.SortOrder) error {
 	return m.SorterBase.Sort(col, order)
 }
 
-// Less определяет, является ли элемент i меньше элемента j
+// Less определяет, является ли элемент i меньше элемента j: по первой колонке сортировки, где они различаются
 func (m *VacancyModel) Less(i, j int) bool {
 	a, b := m.items[i], m.items[j]
-	var less bool
-	switch m.sortColumn {
-	case 0:
-		less = strings.ToLower(a.Title) < strings.ToLower(b.Title)
-	case 1:
-		less = strings.ToLower(a.Company) < strings.ToLower(b.Company)
-	case 2:
-		less = strings.ToLower(a.Status) < strings.ToLower(b.Status)
-	default:
-		less = strings.ToLower(a.Title) < strings.ToLower(b.Title) // Default to title sort if col is out of bounds
-	}
-	if m.sortOrder == walk.SortDescending {
-		return !less
+	for _, k := range m.sortKeys {
+		c := compareVacancyColumn(m.rates, k.Column, a, b)
+		if c == 0 {
+			continue
+		}
+		if k.Descending {
+			return c > 0
+		}
+		return c < 0
 	}
-	return less
+	return false
 }
 
 // Swap меняет местами элементы i и j
@@ -168,26 +233,43 @@ func (m *VacancyModel) Swap(i, j int) {
 
 // StyleCell для реализации walk.CellStyler
 func (m *VacancyModel) StyleCell(style *walk.CellStyle) {
-	// Применяем стиль только к колонке "Статус" (индекс 2)
-	if style.Col() != 2 || style.Row() < 0 || style.Row() >= len(m.items) {
+	if style.Row() < 0 || style.Row() >= len(m.items) {
+		return
+	}
+	// Отклики без ответа подсвечиваем в колонке с названием
+	if style.Col() == 0 {
+		if _, due := followUpDue(m.items[style.Row()], time.Now()); due {
+			style.BackgroundColor = followUpColor
+		}
+		return
+	}
+	// Цвет статуса применяем только к колонке "Статус" (индекс 2)
+	if style.Col() != 2 {
 		return
 	}
 
 	vacancyStatus := m.items[style.Row()].Status
 	if color, ok := statusColors[vacancyStatus]; ok {
 		style.BackgroundColor = color
+		style.TextColor = readableTextColor(color)
 	}
 }
 
 // OnlineVacancyModel for the online search results TableView
 type OnlineVacancyModel struct {
 	walk.TableModelBase
-	items []Vacancy
+	walk.SorterBase
+	all        []Vacancy // Все полученные результаты
+	items      []Vacancy // Результаты, подходящие под фильтр, в порядке сортировки
+	filter     string
+	blocked    int // Сколько результатов скрыто чёрным списком
+	sortColumn int
+	sortOrder  walk.SortOrder
 }
 
 // NewOnlineVacancyModel creates a new model for online vacancies
 func NewOnlineVacancyModel() *OnlineVacancyModel {
-	return &OnlineVacancyModel{items: []Vacancy{}}
+	return &OnlineVacancyModel{items: []Vacancy{}, sortColumn: -1}
 }
 
 // RowCount returns the number of rows for online vacancies
@@ -204,72 +286,194 @@ func (m *OnlineVacancyModel) Value(row, col int) interface{} {
 	case 1:
 		return item.Company
 	case 2:
-		return item.SourceURL // Or other relevant field for online results
+		return item.Salary
+	case 3:
+		return item.Location
+	case 4:
+		if item.PostedAt.IsZero() {
+			return ""
+		}
+		return item.PostedAt.Local().Format("02.01.2006")
+	case 5:
+		return item.SourceURL
+	case 6:
+		if score := profileMatchScore(item); score >= 0 {
+			return fmt.Sprintf("%d%%", score)
+		}
 	}
 	return ""
 }
 
+// SetResults заменяет результаты поиска, сохраняя фильтр и сортировку
+func (m *OnlineVacancyModel) SetResults(vacancies []Vacancy) {
+	m.all = vacancies
+	m.refresh()
+}
+
+// SetFilter показывает только результаты, содержащие text
+func (m *OnlineVacancyModel) SetFilter(text string) {
+	m.filter = strings.ToLower(strings.TrimSpace(text))
+	m.refresh()
+}
+
+// Append добавляет результаты, пришедшие от очередного источника
+func (m *OnlineVacancyModel) Append(vacancies []Vacancy) {
+	m.all = append(m.all, vacancies...)
+	m.refresh()
+}
+
+// Remove убирает вакансию из результатов (например, после добавления в локальный список)
+func (m *OnlineVacancyModel) Remove(v Vacancy) {
+	var kept []Vacancy
+	for _, item := range m.all {
+		if !(sameVacancy(item.Title, item.Company, v.Title, v.Company) && item.SourceURL == v.SourceURL) {
+			kept = append(kept, item)
+		}
+	}
+	m.all = kept
+	m.refresh()
+}
+
+// refresh заново применяет фильтр и сортировку
+func (m *OnlineVacancyModel) refresh() {
+	m.items = []Vacancy{}
+	m.blocked = 0
+	for _, v := range m.all {
+		if isBlocked(v) {
+			m.blocked++
+			continue
+		}
+		if m.filter == "" || strings.Contains(strings.ToLower(strings.Join([]string{v.Title, v.Company, v.Salary, v.Location, v.Description}, "\n")), m.filter) {
+			m.items = append(m.items, v)
+		}
+	}
+	if m.sortColumn >= 0 {
+		sort.SliceStable(m.items, func(i, j int) bool { return m.Less(i, j) })
+	}
+	m.PublishRowsReset()
+}
+
+// Sort сортирует результаты по колонке
+func (m *OnlineVacancyModel) Sort(col int, order walk.SortOrder) error {
+	m.sortColumn = col
+	m.sortOrder = order
+	sort.SliceStable(m.items, func(i, j int) bool {
+		return m.Less(i, j)
+	})
+	return m.SorterBase.Sort(col, order)
+}
+
+// Less сравнивает результаты i и j по колонке сортировки
+func (m *OnlineVacancyModel) Less(i, j int) bool {
+	a, b := m.items[i], m.items[j]
+	var less bool
+	switch m.sortColumn {
+	case 1:
+		less = strings.ToLower(a.Company) < strings.ToLower(b.Company)
+	case 2:
+		less = salaryMidpoint(a) < salaryMidpoint(b)
+	case 3:
+		less = strings.ToLower(a.Location) < strings.ToLower(b.Location)
+	case 4:
+		less = a.PostedAt.Before(b.PostedAt)
+	case 5:
+		less = strings.ToLower(a.SourceURL) < strings.ToLower(b.SourceURL)
+	case 6:
+		less = profileMatchScore(a) < profileMatchScore(b)
+	default:
+		less = strings.ToLower(a.Title) < strings.ToLower(b.Title)
+	}
+	if m.sortOrder == walk.SortDescending {
+		return !less
+	}
+	return less
+}
+
 // AppMainWindow главная структура нашего приложения
 type AppMainWindow struct {
 	*walk.MainWindow
-	searchEdit          *walk.LineEdit
-	searchFieldCB       *walk.ComboBox
-	searchLabel         *walk.Label
-	statusFilterCB      *walk.ComboBox
-	experienceFilterCB  *walk.ComboBox
-	vacancyTable        *walk.TableView
-	vacancyModel        *VacancyModel
-	searchButton        *walk.PushButton
-	addVacancyButton    *walk.PushButton
-	editVacancyButton   *walk.PushButton
-	deleteVacancyButton *walk.PushButton
-	onlineSearchButton  *walk.PushButton
-	resumeArchiveButton *walk.PushButton // ДОБАВЛЕНО: Кнопка архива резюме
-	hSplitter           *walk.Splitter
-
-	// Details Panel Fields
-	detailsGroup           *walk.GroupBox
-	detailsScrollView      *walk.ScrollView
-	detailTitleLabel       *walk.Label // For "Название:"
-	detailTitleDisplay     *walk.Label // To display the title (non-editable in panel)
-	detailCompanyLabel     *walk.Label // For "Компания:"
-	detailCompanyDisplay   *walk.Label // To display the company (non-editable in panel)
-	detailStatusLabel      *walk.Label
-	detailStatusCB         *walk.ComboBox // Editable
-	detailExperienceLabel  *walk.Label
-	detailExperienceCB     *walk.ComboBox // Editable
-	detailKeywordsLabel    *walk.Label
-	detailKeywordsLE       *walk.LineEdit // Editable
-	detailSourceURLLabel   *walk.Label
-	detailSourceURLLE      *walk.LineEdit // Editable
-	detailDescriptionLabel *walk.Label
-	detailDescriptionTE    *walk.TextEdit // Editable
-	detailNotesLabel       *walk.Label
-	detailNotesTE          *walk.TextEdit   // Editable
-	saveVacancyChangesPB   *walk.PushButton // Button to save changes from details panel
+	SearchBarVM
+	DetailsVM
+	OnlineSearchVM
+
+	vacancyTable           *walk.TableView
+	vacancyModel           *VacancyModel
+	addVacancyButton       *walk.PushButton
+	editVacancyButton      *walk.PushButton
+	deleteVacancyButton    *walk.PushButton
+	duplicateButton        *walk.PushButton
+	onlineSearchButton     *walk.PushButton
+	offline                bool         // Последняя проверка не нашла подключения к интернету
+	queuedSearches         []string     // Онлайн-поиски, отложенные до появления сети
+	watchRunning           bool         // Идёт фоновое обновление отслеживаемых запросов
+	feedsAction            *walk.Action // Пункт меню с числом новых записей в лентах
+	fuzzySearchAction      *walk.Action // Флажки меню, которые меняются и из окна настроек
+	exactSearchAction      *walk.Action
+	suggestCompaniesAction *walk.Action
+	feedPollRunning        bool             // Идёт фоновый опрос лент
+	feedsPolledAt          time.Time        // Когда ленты опрашивались последний раз
+	feedSeen               map[string]bool  // Ссылки на уже виденные записи лент
+	feedNew                int              // Сколько записей появилось с последнего просмотра
+	ratesFetching          bool             // Идёт фоновая загрузка курсов валют
+	ratesAttemptAt         time.Time        // Когда курсы пытались загрузить последний раз
+	resumeArchiveButton    *walk.PushButton // ДОБАВЛЕНО: Кнопка архива резюме
+	hSplitter              *walk.Splitter
 
 	// Containers for switching views
 	localVacanciesContainer *walk.Composite
-	onlineResultsContainer  *walk.Composite
-
-	// Online search results view components
-	onlineResultsLabel       *walk.Label
-	onlineResultsTable       *walk.TableView
-	onlineVacancyModel       *OnlineVacancyModel
-	backToLocalButton        *walk.PushButton
-	cancelOnlineSearchButton *walk.PushButton
-	addOnlineVacancyButton   *walk.PushButton
-
-	// Канал для отмены онлайн поиска
-	onlineSearchCancelChan chan struct{}
-
-	detailResumeLabel    *walk.Label
-	detailResumeDisplay  *walk.Label
-	detailResumeDropArea *walk.Composite
-	detailResumeOpenBtn  *walk.PushButton
-	detailResumeClearBtn *walk.PushButton
+	filterTabsBar           *walk.ToolBar // Вкладки со своими фильтрами и сортировкой
+	applyingFilterTab       bool          // Фильтры меняются переключением вкладки - не запоминать их в вкладку
+	statusChipsBar          *walk.ToolBar
+	statusChips             []*walk.Action // Кнопки-фильтры по статусам, в порядке possibleStatuses
+	clearChipsAction        *walk.Action
+	showArchiveAction       *walk.Action   // "Показывать архив"
+	manualOrderAction       *walk.Action   // "Ручной порядок"
+	quickTitleLE            *walk.LineEdit // Строка быстрого добавления над таблицей
+	quickCompanyLE          *walk.LineEdit
+	quickURLLE              *walk.LineEdit
+	quickStatusCB           *walk.ComboBox
+	quickAddIssue           *walk.Label
+	moveUpAction            *walk.Action // Переместить выбранную вакансию выше в ручном порядке
+	moveDownAction          *walk.Action
+	dragRows                []int // Строки, которые перетаскивают мышью; первая - та, за которую взяли
+
+	remindersDialogOpen bool            // Окно напоминаний уже открыто
+	remindersNotified   map[string]bool // Сроки напоминаний, о которых уже было уведомление
+
+	notifyIcon        *walk.NotifyIcon // Значок в области уведомлений для всплывающих уведомлений
+	inTray            bool             // Запущено из автозагрузки, окно ещё не открывали
+	toastBar          *walk.Composite  // Полоса сообщений внизу окна
+	toastLabel        *walk.Label
+	toastActionPB     *walk.PushButton
+	toastAction       func() // Действие кнопки на полосе сообщений
+	toastSeq          int    // Номер последнего сообщения - по нему таймер узнаёт, не сменилось ли оно
+	notificationClick func() // Что делать по щелчку на последнем уведомлении
 
 	themeToggleButton *walk.PushButton
+
+	// История просмотра вакансий за сеанс
+	history       navHistory
+	backAction    *walk.Action
+	forwardAction *walk.Action
+	backPB        *walk.PushButton
+	forwardPB     *walk.PushButton
+	recentMenu    *walk.Menu
+	profilesMenu  *walk.Menu
+
+	locked             bool         // Окно скрыто до ввода PIN
+	pendingLaunchArgs  [][]string   // Аргументы повторных запусков, пришедшие до ввода PIN
+	presentationAction *walk.Action // "Режим презентации" в меню "Файл"
+
+	viewOnlyBar    *walk.Composite // Полоса "Только просмотр" над панелью поиска
+	viewOnlyBanner *walk.Label
+
+	// Нижняя панель прогресса недельной цели
+	goalBar         *walk.Composite
+	goalLabel       *walk.Label
+	goalProgress    *walk.ProgressBar
+	goalStreakLabel *walk.Label
+	goalLinks       *walk.LinkLabel
+	totalsLabel     *walk.Label // Всего вакансий, включая скрытые архивные
 }
 
 var possibleStatuses = []string{"Новая", "Планирую откликнуться", "Откликнулся", "Тестовое задание", "Собеседование", "Оффер", "Отказ", "В архиве"}
@@ -284,8 +488,11 @@ type AddVacancyDialog struct {
 	descriptionTE   *walk.TextEdit
 	keywordsLE      *walk.LineEdit
 	sourceURLLE     *walk.LineEdit
+	salaryLE        *walk.LineEdit
 	statusCB        *walk.ComboBox
 	experienceCB    *walk.ComboBox
+	interviewDE     *walk.DateEdit
+	deadlineDE      *walk.DateEdit
 	notesTE         *walk.TextEdit
 	acceptPB        *walk.PushButton
 	cancelPB        *walk.PushButton
@@ -293,24 +500,139 @@ type AddVacancyDialog struct {
 	isEdit          bool
 	originalTitle   string
 	originalCompany string
+	originalStatus  string
+	baseline        Vacancy // С чем сравниваются черновики для восстановления после сбоя
+
+	// Автоопределение уровня опыта по тексту
+	experienceHint    *walk.Label
+	experienceManual  bool // Пользователь выбрал уровень сам
+	experienceAutoIdx int  // Уровень, выставленный автоопределением: его смена не считается ручной
+
+	// Сообщения проверки под полями
+	titleIssue       *walk.Label
+	companyIssue     *walk.Label
+	sourceURLIssue   *walk.Label
+	salaryIssue      *walk.Label
+	descriptionIssue *walk.Label
+	notesIssue       *walk.Label
+
+	companyAC  *Autocomplete
+	keywordsAC *Autocomplete
+
+	templateCB *walk.ComboBox
+
+	// Значения полей связаны с виджетами через binder
+	form      *vacancyFormData
+	binder    *walk.DataBinder
+	resetting bool // binder переносит форму в виджеты: их промежуточные значения не читаем
 }
 
 // ДОБАВЛЕНО: Структура для хранения настроек приложения
 type AppSettings struct {
-	ThemeName string `json:"theme_name"`
+	ThemeName             string       `json:"theme_name"`
+	StatusPalette         string       `json:"status_palette,omitempty"` // Цвета статусов: обычные или для дальтоников
+	Fonts                 FontSettings `json:"fonts,omitzero"`           // Шрифты таблицы, панели деталей и редакторов
+	Zoom                  ZoomSettings `json:"zoom,omitzero"`            // Масштаб описания, заметок и таблицы
+	WeeklyApplicationGoal int          `json:"weekly_application_goal"`  // Цель по откликам в неделю, 0 - не задана
+
+	AutoBackup      bool      `json:"auto_backup"`             // Еженедельное автоматическое резервное копирование
+	BackupRetention int       `json:"backup_retention"`        // Сколько последних автоматических копий хранить
+	BackupDir       string    `json:"backup_dir,omitempty"`    // Папка для резервных копий
+	LastBackupAt    time.Time `json:"last_backup_at,omitzero"` // Время последней автоматической копии
+
+	DataDir      string      `json:"data_dir,omitempty"`       // Папка с файлом вакансий, резервными копиями и резюме
+	Language     string      `json:"language,omitempty"`       // Язык интерфейса
+	JoobleAPIKey string      `json:"jooble_api_key,omitempty"` // Собственный ключ Jooble API
+	Profile      UserProfile `json:"profile"`                  // Профиль соискателя
+
+	SkipUpdateCheck bool   `json:"skip_update_check"`         // Не проверять обновления при запуске
+	UpdateURL       string `json:"update_url,omitempty"`      // Адрес проверки обновлений вместо GitHub
+	SkippedVersion  string `json:"skipped_version,omitempty"` // Версия, о которой пользователь просил не напоминать
+
+	SuggestBundledCompanies bool `json:"suggest_bundled_companies"` // Подсказывать компании из встроенного списка
+	FuzzySearch             bool `json:"fuzzy_search,omitempty"`    // Искать с опечатками
+	ExactSearch             bool `json:"exact_search,omitempty"`    // Искать без словоформ (стемминга)
+
+	SortKeys []SortKey `json:"sort_keys,omitempty"` // Сортировка таблицы вакансий: основная колонка и дополнительные
+
+	FilterTabs      []FilterTab `json:"filter_tabs,omitempty"` // Вкладки над таблицей со своими фильтрами
+	ActiveFilterTab int         `json:"active_filter_tab"`
+
+	RejectionCooldownMonths int `json:"rejection_cooldown_months"` // Предупреждать об отклике в компанию после недавнего отказа, 0 - нет
+
+	SearchCacheTTLMinutes int `json:"search_cache_ttl_minutes"` // Сколько минут хранить результаты онлайн-поиска, 0 - не кэшировать
+
+	ProxyMode             string `json:"proxy_mode,omitempty"`    // system, none или manual
+	ProxyURL              string `json:"proxy_url,omitempty"`     // Адрес прокси для режима manual
+	ConnectTimeoutSeconds int    `json:"connect_timeout_seconds"` // Тайм-аут подключения
+	ReadTimeoutSeconds    int    `json:"read_timeout_seconds"`    // Тайм-аут ожидания ответа сервера
+	TLSSkipVerify         bool   `json:"tls_skip_verify"`         // Не проверять сертификаты сайтов
+	TLSCAFile             string `json:"tls_ca_file,omitempty"`   // Дополнительный корневой сертификат (PEM)
+
+	BlockedCompanies []string `json:"blocked_companies,omitempty"` // Компании и агентства, скрываемые из онлайн-результатов
+	BlockedKeywords  []string `json:"blocked_keywords,omitempty"`  // Слова, по которым онлайн-результаты скрываются
+
+	JobFeeds        []string `json:"job_feeds,omitempty"` // Адреса RSS/Atom-лент вакансий
+	FeedPollMinutes int      `json:"feed_poll_minutes"`   // Как часто проверять ленты на новые записи
+
+	TelegramBotToken     string `json:"telegram_bot_token,omitempty"`     // Бот, через которого читаются каналы с вакансиями
+	TelegramUpdateOffset int64  `json:"telegram_update_offset,omitempty"` // С какого обновления Bot API продолжать
+
+	FieldMappings map[string]FieldMapping `json:"field_mappings,omitempty"` // Перенос полей по провайдерам
+
+	ShowArchived bool `json:"show_archived"` // Показывать в списке вакансии "В архиве" и с отказом
+
+	SalaryTargetCurrency string `json:"salary_target_currency,omitempty"` // В какую валюту пересчитывать зарплаты в таблице
+
+	HideAgendaOnStartup bool `json:"hide_agenda_on_startup"` // Не показывать панель "Сегодня" при запуске
+	FollowUpDays        int  `json:"follow_up_days"`         // Через сколько дней без ответа напоминать о follow-up, 0 - не напоминать
+
+	ToastSeconds          int  `json:"toast_seconds"`                     // Сколько секунд показывать сообщения в окне
+	StartWithWindows      bool `json:"start_with_windows,omitempty"`      // Запускаться вместе с Windows в области уведомлений
+	MuteTrayNotifications bool `json:"mute_tray_notifications,omitempty"` // Не показывать всплывающие уведомления Windows, только историю
+
+	TesseractPath string `json:"tesseract_path,omitempty"` // tesseract.exe для распознавания скриншотов, если не найден сам
+	OCRLanguages  string `json:"ocr_languages"`            // Языки распознавания в формате tesseract, например rus+eng
+
+	Webhooks []Webhook `json:"webhooks,omitempty"` // Запросы, отправляемые при смене статуса вакансии
+
+	Notion              NotionSettings         `json:"notion"`                          // Синхронизация с базой Notion
+	BoardExport         BoardExportSettings    `json:"board_export"`                    // Выгрузка в Trello и Jira
+	CoverLetterTemplate string                 `json:"cover_letter_template,omitempty"` // Шаблон сопроводительного письма .docx
+	MailSubjectTemplate string                 `json:"mail_subject_template,omitempty"` // Шаблон темы письма рекрутеру
+	SMTP                SMTPSettings           `json:"smtp"`                            // Сервер для отправки откликов из приложения
+	GoogleCalendar      GoogleCalendarSettings `json:"google_calendar"`                 // Синхронизация собеседований с Google Календарём
+
+	Dedup     DedupSettings     `json:"dedup"`     // Как распознавать дубликаты при импорте
+	AppLock   AppLockSettings   `json:"app_lock"`  // PIN при запуске и после простоя
+	Telemetry TelemetrySettings `json:"telemetry"` // Адрес обратной связи и согласие на анонимную статистику
 }
 
 // ДОБАВЛЕНО: Глобальные настройки
 var appSettings = AppSettings{
-	ThemeName: "Светлая", // По умолчанию светлая тема
+	ThemeName:               "Светлая", // По умолчанию светлая тема
+	RejectionCooldownMonths: defaultRejectionCooldownMonths,
+	FollowUpDays:            defaultFollowUpDays,
+	ToastSeconds:            defaultToastSeconds,
+	OCRLanguages:            defaultOCRLanguages,
+	SearchCacheTTLMinutes:   defaultSearchCacheTTLMinutes,
+	FeedPollMinutes:         defaultFeedPollMinutes,
+	ProxyMode:               proxySystem,
+	ConnectTimeoutSeconds:   defaultConnectTimeoutSeconds,
+	ReadTimeoutSeconds:      defaultReadTimeoutSeconds,
+	Dedup:                   DedupSettings{MatchURL: true},
 }
 
+// Файла настроек ещё нет - приложение запущено впервые
+var isFirstRun bool
+
 // ДОБАВЛЕНО: Функция загрузки настроек
 func loadSettings() {
 	data, err := os.ReadFile(settingsFile)
 	if err != nil {
 		if os.IsNotExist(err) {
 			log.Printf("Файл настроек %s не найден, используются настройки по умолчанию", settingsFile)
+			isFirstRun = true
 			saveSettings() // Создаем файл с настройками по умолчанию
 			return
 		}
@@ -323,80 +645,309 @@ func loadSettings() {
 		log.Printf("Ошибка декодирования JSON из файла настроек %s: %v", settingsFile, err)
 		return
 	}
+	rememberSettingsData(settingsFile, data)
+
+	// Свои настройки профиля поверх общих: ключи, которых нет в файле профиля, остаются общими
+	if path := settingsPath(); path != settingsFile {
+		data, err := os.ReadFile(path)
+		if err == nil {
+			err = json.Unmarshal(data, &appSettings)
+			rememberSettingsData(path, data)
+		}
+		if err != nil && !os.IsNotExist(err) {
+			log.Printf("Ошибка чтения настроек профиля %s: %v", path, err)
+		}
+	}
+	unprotectSettingsSecrets(&appSettings)
 }
 
 // ДОБАВЛЕНО: Функция сохранения настроек
 func saveSettings() {
-	data, err := json.MarshalIndent(appSettings, "", "  ")
+	if viewOnly {
+		return // Чужие настройки не трогаем, свои в режиме просмотра не меняются
+	}
+	s := withoutStartupOptions(appSettings)
+	protectSettingsSecrets(&s)
+	data, err := json.MarshalIndent(s, "", "  ")
 	if err != nil {
 		log.Printf("Ошибка кодирования настроек в JSON: %v", err)
 		return
 	}
 
-	err = os.WriteFile(settingsFile, data, 0644)
+	err = os.WriteFile(settingsPath(), data, 0644)
 	if err != nil {
-		log.Printf("Ошибка записи файла настроек %s: %v", settingsFile, err)
+		log.Printf("Ошибка записи файла настроек %s: %v", settingsPath(), err)
+		return
 	}
+	rememberSettingsData(settingsPath(), data)
 }
 
-// showWelcomeDialog отображает приветственное диалоговое окно
-func showWelcomeDialog(owner walk.Form) {
-	var dlg *walk.Dialog
-
-	_, err := Dialog{
-		AssignTo: &dlg,
-		Title:    "Добро пожаловать!",
-		MinSize:  Size{Width: 380, Height: 230},
-		Layout:   VBox{Margins: Margins{Top: 25, Left: 20, Right: 20, Bottom: 20}, Spacing: 10},
-		Children: []Widget{
-			Label{
-				Text:          "Добро пожаловать в\nПоисковик Вакансий!",
-				Font:          Font{PointSize: 14, Bold: true},
-				TextAlignment: AlignCenter,
-			},
-			VSpacer{Size: 15},
-			Label{
-				Text:          "Это приложение поможет вам управлять\nличным списком вакансий и искать\nновые возможности онлайн.",
-				TextAlignment: AlignCenter,
-				Font:          Font{PointSize: 10},
-			},
-			VSpacer{Size: 25},
-			PushButton{
-				Text:    "Начать работу",
-				MinSize: Size{Width: 150, Height: 0},
-				OnClicked: func() {
-					dlg.Accept()
-				},
-				Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
-				Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
-			},
-		},
-	}.Run(owner)
+// dataPath возвращает путь к файлу внутри папки данных: из --data-dir, профиля или, у основного профиля, из настроек
+func dataPath(name string) string {
+	dir := appSettings.DataDir
+	if activeProfile.Dir != "" {
+		dir = activeProfile.Dir
+	}
+	if startup.DataDir != "" {
+		dir = startup.DataDir
+	}
+	if dir == "" {
+		return name
+	}
+	return filepath.Join(dir, name)
+}
 
-	if err != nil {
-		log.Printf("Ошибка отображения приветственного диалога: %v", err)
+// joobleKey возвращает ключ Jooble API: собственный из настроек или встроенный
+func joobleKey() string {
+	if appSettings.JoobleAPIKey != "" {
+		return appSettings.JoobleAPIKey
 	}
+	return joobleAPIKey
 }
 
 func main() {
-	showWelcomeDialog(nil)
-	loadVacancies()
-	loadSettings() // Загружаем настройки
+	startDiagnostics(debugFlagFromArgs(os.Args[1:]))
+	startup = parseStartupOptions(os.Args[1:])
+	selectStartupProfile() // Профиль определяет, откуда читать настройки и вакансии
+	loadSettings()         // Загружаем настройки
+	userThemes = loadUserThemes()
+	applyStartupOptions()
+	instancePipe, first := claimSingleInstance(os.Args[1:])
+	if !first {
+		return // Аргументы переданы уже открытому окну
+	}
+	if !startup.Tray && !unlockAtStartup() {
+		return // Из трея PIN спрашивается при открытии окна
+	}
+	if isFirstRun {
+		showFirstRunWizard() // Мастер сам загружает вакансии из выбранной папки
+	} else {
+		loadVacanciesWithSplash()
+	}
+	go loadPlugins()
 
 	app := &AppMainWindow{}
-	app.vacancyModel = NewVacancyModel(allVacancies)
+	app.vacancyModel = NewVacancyModel(listEntries(slices.Clone(allVacancies)))
+	app.vacancyModel.rates = currentExchangeRates()
 	app.onlineVacancyModel = NewOnlineVacancyModel()
+	app.detailKeywordsAC = newAutocomplete(keywordSuggestions, true)
+	app.detailsForm = newDetailsFormData(Vacancy{}, false)
 
 	err := MainWindow{
-		AssignTo: &app.MainWindow,
-		Title:    "Поисковик Вакансий",
-		MinSize:  Size{Width: 900, Height: 650},
-		Size:     Size{Width: 1200, Height: 800},
-		Layout:   VBox{MarginsZero: true, SpacingZero: true},
+		AssignTo:    &app.MainWindow,
+		Title:       "Поисковик Вакансий",
+		Visible:     !startup.Tray,
+		MinSize:     Size{Width: 900, Height: 650},
+		Size:        Size{Width: 1200, Height: 800},
+		Layout:      VBox{MarginsZero: true, SpacingZero: true},
+		OnDropFiles: app.handleDroppedFiles,
+		MenuItems: []MenuItem{
+			Menu{
+				Text: "&Файл",
+				Items: []MenuItem{
+					Menu{AssignTo: &app.profilesMenu, Text: "Профиль"},
+					Action{Text: "Профили...", OnTriggered: app.showProfilesDialog},
+					Action{Text: "Открыть чужую базу для просмотра...", OnTriggered: app.openViewOnlyWindow},
+					Separator{},
+					Action{
+						Text:        "Заблокировать",
+						Shortcut:    Shortcut{Modifiers: walk.ModControl | walk.ModShift, Key: walk.KeyL},
+						OnTriggered: app.lockApp,
+					},
+					Action{Text: "Блокировка приложения...", OnTriggered: app.showAppLockSettings},
+					Action{
+						AssignTo:    &app.presentationAction,
+						Text:        "Режим презентации",
+						Checkable:   true,
+						Shortcut:    Shortcut{Modifiers: walk.ModControl | walk.ModShift, Key: walk.KeyH},
+						OnTriggered: app.togglePresentationMode,
+					},
+					Separator{},
+					Action{Text: "Выход", OnTriggered: func() { app.MainWindow.Close() }},
+				},
+			},
+			Menu{
+				Text: "&Переход",
+				Items: []MenuItem{
+					Action{
+						Text:        "Палитра команд...",
+						Shortcut:    Shortcut{Modifiers: walk.ModControl, Key: walk.KeyP},
+						OnTriggered: app.showCommandPalette,
+					},
+					Separator{},
+					Action{
+						AssignTo:    &app.backAction,
+						Text:        "Назад",
+						Shortcut:    Shortcut{Modifiers: walk.ModAlt, Key: walk.KeyLeft},
+						Enabled:     false,
+						OnTriggered: app.navigateBack,
+					},
+					Action{
+						AssignTo:    &app.forwardAction,
+						Text:        "Вперёд",
+						Shortcut:    Shortcut{Modifiers: walk.ModAlt, Key: walk.KeyRight},
+						Enabled:     false,
+						OnTriggered: app.navigateForward,
+					},
+					Separator{},
+					Action{Text: "Открыть вакансию в отдельном окне", OnTriggered: app.openSelectedVacancyWindow},
+					Action{
+						AssignTo:    &app.moveUpAction,
+						Text:        "Переместить выше",
+						Shortcut:    Shortcut{Modifiers: walk.ModAlt, Key: walk.KeyUp},
+						Enabled:     false,
+						OnTriggered: func() { app.moveSelectedVacancy(-1) },
+					},
+					Action{
+						AssignTo:    &app.moveDownAction,
+						Text:        "Переместить ниже",
+						Shortcut:    Shortcut{Modifiers: walk.ModAlt, Key: walk.KeyDown},
+						Enabled:     false,
+						OnTriggered: func() { app.moveSelectedVacancy(1) },
+					},
+					Menu{AssignTo: &app.recentMenu, Text: "Недавние"},
+				},
+			},
+			Menu{
+				Text: "&Инструменты",
+				Items: []MenuItem{
+					Action{Text: "Статистика...", OnTriggered: app.showStatistics},
+					Action{Text: "Сформировать отчёт...", OnTriggered: app.showReportDialog},
+					Action{Text: "Сравнить офферы...", OnTriggered: app.showOfferComparison},
+					Action{Text: "Курсы валют...", OnTriggered: app.showCurrencySettings},
+					Separator{},
+					Action{Text: "Сохранить вакансию как шаблон...", OnTriggered: app.saveSelectedAsTemplate},
+					Action{Text: "Шаблоны вакансий...", OnTriggered: app.showTemplatesDialog},
+					Separator{},
+					Action{Text: "Поделиться вакансией...", OnTriggered: app.shareSelectedVacancy},
+					Action{Text: "Импортировать вакансию...", OnTriggered: app.importSharedVacancy},
+					Action{Text: "Импорт со страницы LinkedIn/Indeed...", OnTriggered: app.importPostingPage},
+					Action{Text: "Вставить вакансию из буфера обмена", OnTriggered: app.importPostingFromClipboard},
+					Action{Text: "Импорт из CSV/Excel...", OnTriggered: app.importVacanciesFile},
+					Action{Text: "Проверить ссылки вакансий...", OnTriggered: app.checkPostingLinks},
+					Action{Text: "Вакансии из Telegram...", OnTriggered: app.showTelegramQueue},
+					Action{Text: "Открывать файлы .vacancy и ссылки vacancy:// в приложении", OnTriggered: app.registerFileAssociation},
+					Action{Text: "Закладка для браузера...", OnTriggered: app.showBookmarkletDialog},
+					Action{Text: "Дубликаты вакансий...", OnTriggered: app.showDedupSettings},
+					Action{Text: "Экспорт через расширение...", OnTriggered: app.exportWithPlugin},
+					Separator{},
+					Action{Text: "Создать резервную копию...", OnTriggered: app.backupNow},
+					Action{Text: "Восстановить из резервной копии...", OnTriggered: app.showRestoreWizard},
+					Action{Text: "Настройки резервного копирования...", OnTriggered: app.showBackupSettings},
+					Action{Text: "Экспорт настроек...", OnTriggered: app.exportConfig},
+					Action{Text: "Импорт настроек...", OnTriggered: app.importConfig},
+					Action{Text: "Журнал изменений...", OnTriggered: app.showAuditLog},
+					Action{Text: "Уведомления...", OnTriggered: app.showNotificationHistory},
+					Separator{},
+					Action{Text: "Цель по откликам...", OnTriggered: app.showGoalDialog},
+					Action{Text: "Период ожидания после отказа...", OnTriggered: app.showCooldownSettings},
+					Action{Text: "Follow-up по откликам...", OnTriggered: app.showFollowUpSettings},
+					Action{Text: "Итоги по неделям", OnTriggered: app.showWeeklySummary},
+					Action{Text: "Сегодня...", OnTriggered: app.showAgenda},
+					Action{Text: "Напоминания...", OnTriggered: app.showRemindersDialog},
+					Action{Text: "Банк вопросов...", OnTriggered: app.showQuestionBank},
+					Action{
+						Text:        "Поиск везде...",
+						Shortcut:    Shortcut{Modifiers: walk.ModControl | walk.ModShift, Key: walk.KeyF},
+						OnTriggered: app.showGlobalSearch,
+					},
+					Action{
+						AssignTo:  &app.fuzzySearchAction,
+						Text:      "Нечёткий поиск (с опечатками)",
+						Checkable: true,
+						Checked:   appSettings.FuzzySearch,
+						OnTriggered: func() {
+							appSettings.FuzzySearch = !appSettings.FuzzySearch
+							saveSettings()
+							app.performSearch()
+						},
+					},
+					Action{
+						AssignTo:  &app.exactSearchAction,
+						Text:      "Точный поиск (без словоформ)",
+						Checkable: true,
+						Checked:   appSettings.ExactSearch,
+						OnTriggered: func() {
+							appSettings.ExactSearch = !appSettings.ExactSearch
+							saveSettings()
+							app.performSearch()
+						},
+					},
+					Action{Text: "Синонимы поиска...", OnTriggered: app.showSynonymsDialog},
+					Action{
+						Text:        "Вставить изображение из буфера",
+						Shortcut:    Shortcut{Modifiers: walk.ModControl | walk.ModShift, Key: walk.KeyV},
+						OnTriggered: app.pasteAttachment,
+					},
+					Separator{},
+					Action{
+						AssignTo:  &app.suggestCompaniesAction,
+						Text:      "Подсказывать известные компании",
+						Checkable: true,
+						Checked:   appSettings.SuggestBundledCompanies,
+						OnTriggered: func() {
+							appSettings.SuggestBundledCompanies = !appSettings.SuggestBundledCompanies
+							saveSettings()
+						},
+					},
+					Action{Text: "История онлайн-поиска...", OnTriggered: app.showSearchHistory},
+					Action{Text: "Чёрный список...", OnTriggered: app.showBlocklistDialog},
+					Action{AssignTo: &app.feedsAction, Text: "Вакансии из RSS-лент", OnTriggered: app.showFeedVacancies},
+					Action{Text: "RSS-ленты вакансий...", OnTriggered: app.showFeedSettings},
+					Action{Text: "Перенос полей провайдеров...", OnTriggered: app.showFieldMappingDialog},
+					Action{
+						Text:        "Настройки...",
+						Shortcut:    Shortcut{Modifiers: walk.ModControl, Key: walk.KeyOEMComma},
+						OnTriggered: app.showSettingsDialog,
+					},
+					Action{Text: "Оформление...", OnTriggered: app.showAppearanceSettings},
+					Action{Text: "Шрифты...", OnTriggered: app.showFontSettings},
+					Action{Text: "Профиль и навыки...", OnTriggered: app.showProfileDialog},
+					Action{Text: "Настройки онлайн-поиска...", OnTriggered: app.showOnlineSearchSettings},
+					Action{Text: "Настройки сети...", OnTriggered: app.showNetworkSettings},
+					Action{Text: "Настройки SMTP...", OnTriggered: app.showSMTPSettings},
+					Action{Text: "Расширения...", OnTriggered: app.showPluginsDialog},
+					Action{Text: "Сценарии...", OnTriggered: app.showScriptsEditor},
+					Action{Text: "Веб-хуки...", OnTriggered: app.showWebhooksDialog},
+					Action{Text: "Синхронизация с Notion...", OnTriggered: app.showNotionDialog},
+					Action{Text: "Выгрузка в Trello и Jira...", OnTriggered: app.showBoardExportDialog},
+					Action{Text: "Google Календарь...", OnTriggered: app.showGoogleCalendarDialog},
+					Action{Text: "Проверить обновления...", OnTriggered: func() { app.checkForUpdates(true) }},
+					Action{Text: "Обратная связь...", OnTriggered: app.showFeedbackDialog},
+				},
+			},
+		},
 		Children: []Widget{
+			Composite{
+				AssignTo:   &app.viewOnlyBar,
+				Visible:    false,
+				Layout:     HBox{Margins: Margins{Left: 10, Top: 6, Right: 10, Bottom: 6}},
+				Background: SolidColorBrush{Color: walk.RGB(255, 224, 130)},
+				Children: []Widget{
+					Label{AssignTo: &app.viewOnlyBanner, TextColor: walk.RGB(90, 60, 0), Font: Font{Bold: true, PointSize: 10}},
+				},
+			},
 			Composite{
 				Layout: HBox{Margins: Margins{Left: 10, Top: 10, Right: 10, Bottom: 5}, Spacing: 8},
 				Children: []Widget{
+					PushButton{
+						AssignTo:    &app.backPB,
+						Text:        "◀",
+						ToolTipText: "Назад к предыдущей просмотренной вакансии (Alt+←)",
+						MaxSize:     Size{Width: 32},
+						Enabled:     false,
+						OnClicked:   app.navigateBack,
+						Background:  SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+					},
+					PushButton{
+						AssignTo:    &app.forwardPB,
+						Text:        "▶",
+						ToolTipText: "Вперёд (Alt+→)",
+						MaxSize:     Size{Width: 32},
+						Enabled:     false,
+						OnClicked:   app.navigateForward,
+						Background:  SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+					},
 					Label{Text: "Искать в:"},
 					ComboBox{
 						AssignTo:     &app.searchFieldCB,
@@ -465,6 +1016,14 @@ func main() {
 						Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
 						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
 					},
+					ComboBox{
+						AssignTo:              &app.searchHistoryCB,
+						Model:                 []string{searchHistoryPlaceholder},
+						CurrentIndex:          0,
+						ToolTipText:           "Повторить один из прошлых онлайн-поисков",
+						MinSize:               Size{Width: 170},
+						OnCurrentIndexChanged: app.onSearchHistoryPicked,
+					},
 					HSpacer{},
 					PushButton{
 						AssignTo:   &app.addVacancyButton,
@@ -495,6 +1054,13 @@ func main() {
 						Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
 						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
 					},
+					PushButton{
+						AssignTo:   &app.duplicateButton,
+						Text:       "Дублировать",
+						OnClicked:  app.duplicateSelectedVacancy,
+						Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+					},
 					PushButton{
 						AssignTo:   &app.resumeArchiveButton,
 						Text:       "Архив резюме",
@@ -513,257 +1079,182 @@ func main() {
 			VSpacer{Size: 5},
 			Composite{
 				AssignTo:      &app.localVacanciesContainer,
-				Layout:        HBox{MarginsZero: true, SpacingZero: true},
+				Layout:        VBox{MarginsZero: true, SpacingZero: true},
 				Visible:       true,
 				StretchFactor: 1,
 				Children: []Widget{
+					ToolBar{
+						AssignTo:    &app.filterTabsBar,
+						ButtonStyle: ToolBarButtonTextOnly,
+						ToolTipText: "Вкладки: у каждой свои фильтры и сортировка",
+					},
+					ToolBar{
+						AssignTo:    &app.statusChipsBar,
+						ButtonStyle: ToolBarButtonTextOnly,
+						ToolTipText: "Быстрый фильтр по статусам: можно выбрать несколько. Перетащите строки таблицы на кнопку, чтобы сменить им статус",
+						Items:       app.statusChipItems(),
+					},
+					app.quickAddRow(),
 					HSplitter{
 						AssignTo:      &app.hSplitter,
 						StretchFactor: 1,
 						HandleWidth:   5,
 						Children: []Widget{
 							TableView{
-								AssignTo:      &app.vacancyTable,
-								Model:         app.vacancyModel,
-								StretchFactor: 2,
+								AssignTo:       &app.vacancyTable,
+								Model:          app.vacancyModel,
+								MultiSelection: true, // Несколько строк можно перетащить на кнопку статуса
+								StretchFactor:  2,
 								Columns: []TableViewColumn{
 									{Title: "Название", Width: 230},
 									{Title: "Компания", Width: 150},
 									{Title: "Статус", Width: 120},
+									{Title: salaryColumnTitle(), Width: 130, Alignment: AlignFar},
 								},
 								OnCurrentIndexChanged: app.updateVacancyDetails,
+								OnItemActivated:       app.openSelectedVacancyWindow, // Двойной щелчок - отдельное окно для сравнения
 								MinSize:               Size{Width: 300},
 							},
 							GroupBox{
-								AssignTo:      &app.detailsGroup,
+								AssignTo:      &app.detailsGroup, // Содержимое строит ensureDetails при первом выборе вакансии
 								Title:         "Детали вакансии",
 								Layout:        VBox{MarginsZero: true, SpacingZero: true},
 								StretchFactor: 1,
 								MinSize:       Size{Width: 300},
-								Children: []Widget{
-									ScrollView{
-										AssignTo:      &app.detailsScrollView,
-										Layout:        VBox{Margins: Margins{Left: 9, Top: 9, Right: 9, Bottom: 9}, Spacing: 6},
-										StretchFactor: 1,
-										Children: []Widget{
-											Label{AssignTo: &app.detailTitleLabel, Text: "Название:", Font: Font{Bold: true, PointSize: 9}},
-											Label{AssignTo: &app.detailTitleDisplay, Text: "-", Font: Font{PointSize: 10, Bold: true}, TextColor: walk.RGB(0, 0, 100)},
-											Label{AssignTo: &app.detailCompanyLabel, Text: "Компания:", Font: Font{Bold: true, PointSize: 9}},
-											Label{AssignTo: &app.detailCompanyDisplay, Text: "-", Font: Font{PointSize: 9}},
-											Label{AssignTo: &app.detailStatusLabel, Text: "Статус:", Font: Font{Bold: true, PointSize: 9}},
-											ComboBox{AssignTo: &app.detailStatusCB, Model: possibleStatuses, Font: Font{PointSize: 9}},
-											Label{AssignTo: &app.detailExperienceLabel, Text: "Уровень опыта:", Font: Font{Bold: true, PointSize: 9}},
-											ComboBox{AssignTo: &app.detailExperienceCB, Model: possibleExperienceLevels, Font: Font{PointSize: 9}},
-											Label{AssignTo: &app.detailKeywordsLabel, Text: "Ключевые слова (через запятую):", Font: Font{Bold: true, PointSize: 9}},
-											LineEdit{AssignTo: &app.detailKeywordsLE, Font: Font{PointSize: 9}},
-											Label{AssignTo: &app.detailSourceURLLabel, Text: "URL Источника:", Font: Font{Bold: true, PointSize: 9}},
-											LineEdit{AssignTo: &app.detailSourceURLLE, Font: Font{PointSize: 9}},
-											Label{AssignTo: &app.detailDescriptionLabel, Text: "Описание:", Font: Font{Bold: true, PointSize: 9}},
-											TextEdit{
-												AssignTo:      &app.detailDescriptionTE,
-												VScroll:       true,
-												MinSize:       Size{Height: 100},
-												MaxSize:       Size{Height: 300},
-												StretchFactor: 2,
-												Font:          Font{PointSize: 9},
-											},
-											Label{AssignTo: &app.detailNotesLabel, Text: "Заметки:", Font: Font{Bold: true, PointSize: 9}},
-											TextEdit{AssignTo: &app.detailNotesTE, MinSize: Size{0, 80}, VScroll: true, Text: "", ReadOnly: false, Font: Font{PointSize: 9}},
-											Label{AssignTo: &app.detailResumeLabel, Text: "Резюме:", Font: Font{Bold: true, PointSize: 9}},
-											Composite{
-												AssignTo:   &app.detailResumeDropArea,
-												Layout:     HBox{Margins: Margins{Top: 2, Bottom: 2}, Spacing: 5},
-												MinSize:    Size{Height: 40},
-												Background: SolidColorBrush{Color: walk.RGB(240, 240, 240)},
-												Children: []Widget{
-													Label{
-														AssignTo:      &app.detailResumeDisplay,
-														Text:          "Нажмите 'Выбрать' для добавления резюме",
-														TextAlignment: AlignCenter,
-														MinSize:       Size{Width: 200},
-													},
-													HSpacer{},
-													PushButton{
-														AssignTo:  &app.detailResumeOpenBtn,
-														Text:      "Открыть",
-														Enabled:   false,
-														MaxSize:   Size{Width: 70},
-														OnClicked: app.openResume,
-														Font:      Font{Family: "Segoe UI", PointSize: 9},
-													},
-													PushButton{
-														Text:      "Выбрать",
-														MaxSize:   Size{Width: 70},
-														OnClicked: app.selectResume,
-														Font:      Font{Family: "Segoe UI", PointSize: 9},
-													},
-													PushButton{
-														AssignTo:  &app.detailResumeClearBtn,
-														Text:      "×",
-														Enabled:   false,
-														MaxSize:   Size{Width: 25},
-														OnClicked: app.clearResume,
-														Font:      Font{Family: "Segoe UI", PointSize: 9, Bold: true},
-													},
-												},
-											},
-											PushButton{
-												AssignTo:   &app.saveVacancyChangesPB,
-												Text:       "Сохранить изменения вакансии",
-												OnClicked:  app.saveVacancyDetails,
-												Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
-												Background: SolidColorBrush{Color: walk.RGB(220, 255, 220)},
-											},
-										},
-									},
-								},
 							},
 						},
 					},
 				},
 			},
 			Composite{
-				AssignTo:      &app.onlineResultsContainer,
+				AssignTo:      &app.onlineResultsContainer, // Содержимое строит ensureOnlineResults при первом онлайн-поиске
 				Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
 				Visible:       false,
 				StretchFactor: 1,
-				Children: []Widget{
-					Composite{
-						Layout: HBox{MarginsZero: true, Spacing: 8},
-						Children: []Widget{
-							Label{
-								AssignTo: &app.onlineResultsLabel,
-								Text:     "Результаты онлайн-поиска:",
-								Font:     Font{Bold: true, PointSize: 10},
-							},
-							HSpacer{},
-							PushButton{
-								AssignTo:   &app.cancelOnlineSearchButton,
-								Text:       "Отменить поиск",
-								Visible:    false,
-								Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
-								Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
-							},
-							PushButton{
-								AssignTo:   &app.backToLocalButton,
-								Text:       "<< Назад к локальному списку",
-								Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
-								Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
-								OnClicked:  app.switchToLocalMode,
-							},
-						},
-					},
-					TableView{
-						AssignTo: &app.onlineResultsTable,
-						Model:    app.onlineVacancyModel,
-						Columns: []TableViewColumn{
-							{Title: "Название", Width: 220},
-							{Title: "Компания", Width: 160},
-							{Title: "Источник", Width: 180},
-						},
-						StretchFactor: 1,
-						OnItemActivated: func() {
-							idx := app.onlineResultsTable.CurrentIndex()
-							if idx >= 0 && idx < len(app.onlineVacancyModel.items) {
-								selectedOnlineVacancy := app.onlineVacancyModel.items[idx]
-								vacancyCopy := selectedOnlineVacancy
-								if showVacancyDialogExt(app, &vacancyCopy, false, true) {
-									app.onlineVacancyModel.items = append(app.onlineVacancyModel.items[:idx], app.onlineVacancyModel.items[idx+1:]...)
-									app.onlineVacancyModel.PublishRowsReset()
-									app.performSearch()
-								}
-							}
-						},
-					},
-					PushButton{
-						AssignTo:   &app.addOnlineVacancyButton,
-						Text:       "Добавить выбранное в локальный список",
-						Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
-						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
-						OnClicked: func() {
-							idx := app.onlineResultsTable.CurrentIndex()
-							if idx < 0 || idx >= len(app.onlineVacancyModel.items) {
-								walk.MsgBox(app.MainWindow, "Подсказка", "Пожалуйста, сначала выберите вакансию из списка выше.", walk.MsgBoxIconInformation)
-								return
-							}
-							selectedOnlineVacancy := app.onlineVacancyModel.items[idx]
-							vacancyCopy := selectedOnlineVacancy
-							if showVacancyDialogExt(app, &vacancyCopy, false, true) {
-								app.onlineVacancyModel.items = append(app.onlineVacancyModel.items[:idx], app.onlineVacancyModel.items[idx+1:]...)
-								app.onlineVacancyModel.PublishRowsReset()
-								app.performSearch()
-							}
-						},
-					},
-				},
 			},
+			app.toastBarWidget(),
+			app.goalStatusBarWidget(),
 		},
 	}.Create()
 
 	if err != nil {
 		log.Fatal(err)
 	}
+	if startup.Minimized {
+		win.ShowWindow(app.Handle(), win.SW_SHOWMINNOACTIVE)
+	}
 
 	// Сначала инициализируем таблицу
 	if app.vacancyTable != nil {
 		app.vacancyTable.SetAlternatingRowBG(true)
-		app.vacancyModel.Sort(app.vacancyModel.sortColumn, app.vacancyModel.sortOrder)
+		app.vacancyModel.items = app.filterByStatusChips(app.vacancyModel.items) // Счётчики на кнопках статусов
+		app.vacancyModel.items = app.hideArchived(app.vacancyModel.items, "")
+		app.restoreVacancySort()
+		app.restoreFilterTabs()
+		app.attachRowDrag()
 	}
 
+	app.registerVacancyDropTarget()
+	app.refreshSearchHistoryCB(loadSearchHistory())
+
 	// Затем применяем тему
-	initialTheme := lightTheme
-	if appSettings.ThemeName == "Тёмная" {
-		initialTheme = darkTheme
-		if app.themeToggleButton != nil {
-			app.themeToggleButton.SetText("☀ Светлая тема")
-		}
+	app.applySavedTheme()
+	app.applyFonts()
+	app.attachZoomHandlers()
+	if vacanciesReadOnly {
+		walk.MsgBox(app.MainWindow, "Данные только для чтения",
+			"Файл "+vacanciesFile+" не удалось прочитать (возможно, он создан более новой версией приложения).\nЧтобы не повредить данные, изменения не будут сохраняться.",
+			walk.MsgBoxIconWarning)
 	}
-	app.applyTheme(initialTheme)
 
 	app.vacancyModel.PublishRowsReset()
 	app.updateVacancyDetails()
+	app.updateGoalProgress()
+	app.subscribeToVacancyEvents()
+	app.subscribeScriptHooks()
+	app.subscribeWebhooks()
+	app.subscribeAuditLog()
+	app.updateHistoryActions()
+	app.buildProfilesMenu()
+	if activeProfile.Name != defaultProfileName {
+		app.MainWindow.SetTitle("Поисковик Вакансий — " + activeProfile.Name)
+	}
+	app.applyViewOnlyMode()
+	app.startConnectivityMonitor() // Заодно обновляет отслеживаемые поиски и ленты
+	app.startReminderMonitor()
+	app.registerDiagnosticsShortcut()
+	app.serveInstancePipe(instancePipe)
+	syncAutostart()
+	if !viewOnly {
+		app.runAutoBackupIfDue()
+	}
+	if startup.Tray {
+		app.enterTray() // Остальное запустится, когда окно откроют
+	} else {
+		app.startInteractive()
+	}
 
 	app.MainWindow.Run()
+	app.disposeNotifyIcon()
+	saveUsage()
+	if !viewOnly {
+		clearRecoveryFile() // Штатный выход - черновики больше не нужны
+	}
+}
+
+// startInteractive запускает то, что нужно только при открытом окне
+func (app *AppMainWindow) startInteractive() {
+	app.updateExchangeRates()
+	if !appSettings.SkipUpdateCheck {
+		app.checkForUpdates(false)
+	}
+
+	if !viewOnly {
+		app.startCrashRecovery()
+	}
+	app.startUIProbe()
+	app.startHotReload()
+	app.startIdleLock()
+	app.startUsageTracking()
+	app.Synchronize(app.showStartupAgenda)
+
+	// Файлы .vacancy, с которыми приложение запущено из проводника, и быстрое добавление
+	app.handleLaunchArgs(os.Args[1:])
 }
 
 // performSearch обрабатывает нажатие кнопки "Поиск"
 func (app *AppMainWindow) performSearch() {
+	app.applyVacancyFilters()
+	app.rememberFilterTab()
+	app.updateVacancyDetails()
+}
+
+// applyVacancyFilters заново отбирает вакансии для таблицы по поиску, фильтрам и архиву
+func (app *AppMainWindow) applyVacancyFilters() {
 	allVacanciesMutex.Lock()
 	currentSearchVacancies := make([]Vacancy, len(allVacancies))
 	copy(currentSearchVacancies, allVacancies)
 	allVacanciesMutex.Unlock()
 
-	var searchTerm string
-	searchInFieldIndex := app.searchFieldCB.CurrentIndex()
-	searchInField := "Везде"
-	if searchInFieldIndex >= 0 && searchInFieldIndex < len(searchFields) {
-		searchInField = searchFields[searchInFieldIndex]
-	}
-
-	// Получаем searchTerm в зависимости от выбранного поля поиска
-	switch searchInField {
-	case "По статусу":
-		searchTerm = app.statusFilterCB.Text()
-	case "По опыту":
-		searchTerm = app.experienceFilterCB.Text()
-	default:
-		searchTerm = app.searchEdit.Text()
-	}
-	searchTerm = strings.ToLower(searchTerm)
+	searchInField, searchTerm := app.SearchBarVM.query()
+	matchQuality := map[string]float64{} // Качество совпадения при нечётком поиске
 
 	// Логика фильтрации (остается почти такой же, но использует уже подготовленный searchTerm)
 	if searchTerm == "" && searchInField != "По опыту" && searchInField != "По статусу" {
 		app.vacancyModel.items = currentSearchVacancies
 	} else {
 		filtered := []Vacancy{}
+		q := newSearchQuery(searchTerm, appSettings.FuzzySearch, !appSettings.ExactSearch)
 		for _, v := range currentSearchVacancies {
 			found := false
+			quality := 1.0
 			matchField := func(fieldValue string) bool {
 				// Для точного совпадения по статусу и опыту из ComboBox, если они выбраны
 				if searchInField == "По статусу" || searchInField == "По опыту" {
 					return strings.EqualFold(fieldValue, searchTerm) // Точное совпадение (без учета регистра)
 				}
-				return strings.Contains(strings.ToLower(fieldValue), searchTerm) // Для остальных - поиск подстроки
+				quality, found = q.match(fieldValue) // Для остальных - поиск подстроки или нечёткий поиск
+				return found
 			}
 
 			switch searchInField {
@@ -775,51 +1266,46 @@ func (app *AppMainWindow) performSearch() {
 				found = matchField(v.Description)
 			case "По ключевым словам":
 				// searchTerm здесь - это то, что введено в searchEdit
-				for _, kw := range v.Keywords {
-					if strings.Contains(strings.ToLower(kw), searchTerm) { // Всегда поиск подстроки для ключевых слов
-						found = true
-						break
-					}
-				}
+				quality, found = q.matchAny(v.Keywords...)
 			case "По статусу":
 				found = matchField(v.Status) // searchTerm берется из statusFilterCB
 			case "По опыту":
 				found = matchField(v.ExperienceLevel) // searchTerm берется из experienceFilterCB
 			default: // "Везде"
 				// searchTerm здесь - это то, что введено в searchEdit
-				if strings.Contains(strings.ToLower(v.Title), searchTerm) ||
-					strings.Contains(strings.ToLower(v.Company), searchTerm) ||
-					strings.Contains(strings.ToLower(v.Description), searchTerm) ||
-					strings.Contains(strings.ToLower(v.Status), searchTerm) ||
-					strings.Contains(strings.ToLower(v.ExperienceLevel), searchTerm) {
-					found = true
-				} else {
-					for _, kw := range v.Keywords {
-						if strings.Contains(strings.ToLower(kw), searchTerm) {
-							found = true
-							break
-						}
-					}
-				}
+				quality, found = q.matchAny(append([]string{v.Title, v.Company, v.Description, v.Status, v.ExperienceLevel}, v.Keywords...)...)
 			}
 
 			if found {
 				filtered = append(filtered, v)
+				matchQuality[statusTrackerKey(v.Title, v.Company)] = quality
 			}
 		}
 		app.vacancyModel.items = filtered
 	}
+	app.vacancyModel.items = app.filterByStatusChips(app.vacancyModel.items)
+	statusTerm := ""
+	if searchInField == "По статусу" {
+		statusTerm = app.statusFilterCB.Text()
+	}
+	app.vacancyModel.items = listEntries(app.hideArchived(app.vacancyModel.items, statusTerm))
+	app.updateExchangeRates()
 
 	app.vacancyModel.Sort(app.vacancyModel.sortColumn, app.vacancyModel.sortOrder)
+	if appSettings.FuzzySearch && len(matchQuality) > 0 {
+		// Точные совпадения выше найденных с опечатками, внутри - порядок сортировки таблицы
+		sort.SliceStable(app.vacancyModel.items, func(i, j int) bool {
+			a, b := app.vacancyModel.items[i], app.vacancyModel.items[j]
+			return matchQuality[statusTrackerKey(a.Title, a.Company)] > matchQuality[statusTrackerKey(b.Title, b.Company)]
+		})
+	}
 	app.vacancyModel.PublishRowsReset()
-	app.updateVacancyDetails()
 }
 
 // showAddVacancyDialog отображает диалоговое окно для добавления новой вакансии
 func (app *AppMainWindow) showAddVacancyDialog() {
 	v := Vacancy{}
 	showVacancyDialogExt(app, &v, false, false)
-	app.performSearch() // Обновляем после закрытия диалога
 }
 
 // showEditVacancyDialog отображает диалоговое окно для редактирования выбранной вакансии
@@ -837,12 +1323,8 @@ func (app *AppMainWindow) showEditVacancyDialog() {
 	}
 	vacancyToEdit := allVacancies[originalIndex] // Получаем копию для редактирования
 
-	if showVacancyDialogExt(app, &vacancyToEdit, true, false) {
-		// Если пользователь сохранил изменения, вакансия в allVacancies[originalIndex] уже обновлена в showVacancyDialogExt
-		// через savedVacancy и allVacancies[originalIndex] = savedVacancy
-		// saveVacancies() также был вызван в showVacancyDialogExt
-		app.performSearch() // Обновляем TableView
-	}
+	// Сохранённые изменения showVacancyDialogExt записывает в allVacancies и рассылает событием
+	showVacancyDialogExt(app, &vacancyToEdit, true, false)
 }
 
 // findVacancyIndexInAllExt ищет вакансию по Title и Company
@@ -859,14 +1341,25 @@ func (app *AppMainWindow) findVacancyIndexInAllExt(title, company string) int {
 // True если вакансия была сохранена (пользователь нажал "Добавить в локальные" или "Сохранить")
 // False если пользователь нажал "Отмена" или закрыл диалог
 func showVacancyDialogExt(app *AppMainWindow, currentVacancy *Vacancy, isEdit bool, isOnlineSearch bool) bool {
-	dlg := &AddVacancyDialog{vacancy: currentVacancy, isEdit: isEdit}
+	return showVacancyDialogFrom(app, currentVacancy, isEdit, isOnlineSearch, *currentVacancy)
+}
+
+// showVacancyDialogFrom открывает диалог с данными currentVacancy. original содержит исходные
+// название, компанию и статус - при восстановлении черновика они отличаются от currentVacancy.
+func showVacancyDialogFrom(app *AppMainWindow, currentVacancy *Vacancy, isEdit bool, isOnlineSearch bool, original Vacancy) bool {
+	// В режиме презентации окно показывает вакансию без зарплаты и контактов и ничего не сохраняет
+	if isEdit && presentationMode {
+		redacted := redactVacancy(*currentVacancy)
+		currentVacancy = &redacted
+	}
+	dlg := &AddVacancyDialog{vacancy: currentVacancy, isEdit: isEdit, resetting: true}
 	var dialogTitle string
 	buttonText := "Сохранить"
 
 	if isEdit {
 		dialogTitle = "Редактировать вакансию"
-		dlg.originalTitle = currentVacancy.Title
-		dlg.originalCompany = currentVacancy.Company
+		dlg.originalTitle = original.Title
+		dlg.originalCompany = original.Company
 	} else if isOnlineSearch {
 		dialogTitle = "Детали вакансии (онлайн)"
 		buttonText = "Добавить в локальный список"
@@ -877,30 +1370,29 @@ func showVacancyDialogExt(app *AppMainWindow, currentVacancy *Vacancy, isEdit bo
 	fieldsReadOnly := isOnlineSearch
 	sourceURLReadOnly := true
 
-	initialStatusIndex := 0
-	if currentVacancy.Status != "" {
-		for i, s := range possibleStatuses {
-			if s == currentVacancy.Status {
-				initialStatusIndex = i
-				break
-			}
-		}
-	} else if !isEdit {
+	if currentVacancy.Status == "" && !isEdit {
 		currentVacancy.Status = possibleStatuses[0]
 	}
+	dlg.originalStatus = original.Status
+	dlg.baseline = original
+	if dlg.originalStatus == "" {
+		dlg.originalStatus = currentVacancy.Status
+	}
 
-	// ДОБАВЛЕНО: Логика для начального значения ExperienceLevel
-	initialExperienceIndex := 0
-	if currentVacancy.ExperienceLevel != "" {
-		for i, el := range possibleExperienceLevels {
-			if el == currentVacancy.ExperienceLevel {
-				initialExperienceIndex = i
-				break
-			}
-		}
-	} else {
+	if currentVacancy.ExperienceLevel == "" {
 		currentVacancy.ExperienceLevel = possibleExperienceLevels[0] // "Не указан" по умолчанию
 	}
+	dlg.form = newVacancyFormData(*currentVacancy)
+	// Не указанный уровень определяем по тексту; уровень, который отличается от найденного, задан вручную
+	initialExperienceIndex := indexOfString(possibleExperienceLevels, dlg.form.ExperienceLevel)
+	guess, guessed := detectExperience(currentVacancy.Title, currentVacancy.Description)
+	if guessed && (initialExperienceIndex == 0 || currentVacancy.ExperienceLevel == guess.Level) {
+		dlg.form.ExperienceLevel = guess.Level
+	} else {
+		guessed = false
+		dlg.experienceManual = initialExperienceIndex != 0
+	}
+	dlg.experienceAutoIdx = indexOfString(possibleExperienceLevels, dlg.form.ExperienceLevel)
 
 	if !isEdit && !isOnlineSearch {
 		fieldsReadOnly = false
@@ -908,41 +1400,105 @@ func showVacancyDialogExt(app *AppMainWindow, currentVacancy *Vacancy, isEdit bo
 	}
 
 	var accepted bool
-	if _, errDialog := (Dialog{
+	var event VacancyEvent
+	registerDraftSource(draftKindDialog, dlg.draft)
+	defer unregisterDraftSource(draftKindDialog)
+	onFieldChanged := func() { dlg.refreshIssues(app) }
+	onTextChanged := func() {
+		dlg.refreshIssues(app)
+		dlg.refreshExperienceGuess()
+	}
+	dlg.companyAC = newAutocomplete(companySuggestions, false)
+	dlg.keywordsAC = newAutocomplete(keywordSuggestions, true)
+	var templates []VacancyTemplate
+	if !isEdit && !isOnlineSearch {
+		templates = loadTemplates()
+	}
+	issueLabel := func(label **walk.Label) Widget {
+		return Label{AssignTo: label, Visible: false, Font: Font{PointSize: 8}}
+	}
+	if errDialog := (Dialog{
 		AssignTo:      &dlg.Dialog,
 		Title:         dialogTitle,
 		DefaultButton: &dlg.acceptPB,
 		CancelButton:  &dlg.cancelPB,
 		MinSize:       Size{Width: 500, Height: 700}, // Увеличена высота для нового поля заметки
 		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		DataBinder: DataBinder{
+			AssignTo:   &dlg.binder,
+			DataSource: dlg.form,
+			OnReset:    func() { dlg.resetting = false },
+		},
 		Children: []Widget{
+			Composite{
+				Visible: len(templates) > 0,
+				Layout:  HBox{MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Шаблон:", Font: Font{Bold: true, PointSize: 9}},
+					ComboBox{
+						AssignTo:     &dlg.templateCB,
+						Model:        templateNames(templates),
+						CurrentIndex: 0,
+						Font:         Font{PointSize: 9},
+						OnCurrentIndexChanged: func() {
+							if dlg.templateCB == nil {
+								return
+							}
+							if i := dlg.templateCB.CurrentIndex(); i > 0 {
+								dlg.applyTemplate(templates[i-1])
+							}
+						},
+					},
+				},
+			},
 			Label{Text: "Название вакансии:", Font: Font{Bold: true, PointSize: 9}},
-			LineEdit{AssignTo: &dlg.titleLE, Text: dlg.vacancy.Title, ReadOnly: fieldsReadOnly, Font: Font{PointSize: 9}},
+			LineEdit{AssignTo: &dlg.titleLE, Text: Bind("Title"), ReadOnly: fieldsReadOnly, Font: Font{PointSize: 9}, OnTextChanged: onTextChanged},
+			issueLabel(&dlg.titleIssue),
 			Label{Text: "Компания:", Font: Font{Bold: true, PointSize: 9}},
-			LineEdit{AssignTo: &dlg.companyLE, Text: dlg.vacancy.Company, ReadOnly: fieldsReadOnly, Font: Font{PointSize: 9}},
+			LineEdit{AssignTo: &dlg.companyLE, Text: Bind("Company"), ReadOnly: fieldsReadOnly, Font: Font{PointSize: 9}, OnTextChanged: onFieldChanged},
+			dlg.companyAC.Widget(),
+			issueLabel(&dlg.companyIssue),
 			Label{Text: "Статус:", Font: Font{Bold: true, PointSize: 9}},
 			ComboBox{
-				AssignTo:     &dlg.statusCB,
-				Model:        possibleStatuses,
-				CurrentIndex: initialStatusIndex,
-				Font:         Font{PointSize: 9},
+				AssignTo: &dlg.statusCB,
+				Model:    possibleStatuses,
+				Value:    Bind("Status"),
+				Font:     Font{PointSize: 9},
 			},
 			// ДОБАВЛЕНО: ComboBox для Уровня опыта
 			Label{Text: "Уровень опыта:", Font: Font{Bold: true, PointSize: 9}},
 			ComboBox{
-				AssignTo:     &dlg.experienceCB,
-				Model:        possibleExperienceLevels,
-				CurrentIndex: initialExperienceIndex,
-				Font:         Font{PointSize: 9},
+				AssignTo:              &dlg.experienceCB,
+				Model:                 possibleExperienceLevels,
+				Value:                 Bind("ExperienceLevel"),
+				Font:                  Font{PointSize: 9},
+				OnCurrentIndexChanged: dlg.onExperienceChanged,
+			},
+			Label{AssignTo: &dlg.experienceHint, Text: experienceHintText(guess), Visible: guessed, Font: Font{PointSize: 8}, TextColor: walk.RGB(90, 90, 90)},
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true, Spacing: 6},
+				Children: []Widget{
+					Label{Text: "Собеседование:", Font: Font{Bold: true, PointSize: 9}},
+					Label{Text: "Срок отклика / тестового:", Font: Font{Bold: true, PointSize: 9}},
+					DateEdit{AssignTo: &dlg.interviewDE, Optional: true, Format: "dd.MM.yyyy HH:mm", Date: Bind("InterviewAt")},
+					DateEdit{AssignTo: &dlg.deadlineDE, Optional: true, Format: "dd.MM.yyyy", Date: Bind("DeadlineAt")},
+				},
 			},
 			Label{Text: "Ключевые слова (через запятую):", Font: Font{Bold: true, PointSize: 9}},
-			LineEdit{AssignTo: &dlg.keywordsLE, Text: strings.Join(dlg.vacancy.Keywords, ", "), ReadOnly: false, Font: Font{PointSize: 9}},
+			LineEdit{AssignTo: &dlg.keywordsLE, Text: Bind("Keywords"), Font: Font{PointSize: 9}},
+			dlg.keywordsAC.Widget(),
 			Label{Text: "URL Источника:", Font: Font{Bold: true, PointSize: 9}},
-			LineEdit{AssignTo: &dlg.sourceURLLE, Text: dlg.vacancy.SourceURL, ReadOnly: sourceURLReadOnly, Font: Font{PointSize: 9}},
+			LineEdit{AssignTo: &dlg.sourceURLLE, Text: Bind("SourceURL"), ReadOnly: sourceURLReadOnly, Font: Font{PointSize: 9}, OnTextChanged: onFieldChanged},
+			issueLabel(&dlg.sourceURLIssue),
+			Label{Text: "Зарплата:", Font: Font{Bold: true, PointSize: 9}},
+			LineEdit{AssignTo: &dlg.salaryLE, Text: Bind("Salary"), ReadOnly: fieldsReadOnly, Font: Font{PointSize: 9}, OnTextChanged: onFieldChanged},
+			issueLabel(&dlg.salaryIssue),
 			Label{Text: "Описание:", Font: Font{Bold: true, PointSize: 9}},
-			TextEdit{AssignTo: &dlg.descriptionTE, MinSize: Size{0, 100}, VScroll: true, Text: dlg.vacancy.Description, ReadOnly: fieldsReadOnly, Font: Font{PointSize: 9}},
+			TextEdit{AssignTo: &dlg.descriptionTE, MinSize: Size{0, 100}, VScroll: true, Text: Bind("Description"), ReadOnly: fieldsReadOnly, Font: Font{PointSize: 9}, OnTextChanged: onTextChanged},
+			issueLabel(&dlg.descriptionIssue),
 			Label{Text: "Заметки:", Font: Font{Bold: true, PointSize: 9}},
-			TextEdit{AssignTo: &dlg.notesTE, MinSize: Size{0, 80}, VScroll: true, Text: dlg.vacancy.Notes, ReadOnly: false, Font: Font{PointSize: 9}},
+			TextEdit{AssignTo: &dlg.notesTE, MinSize: Size{0, 80}, VScroll: true, Text: Bind("Notes"), Font: Font{PointSize: 9}, OnTextChanged: onFieldChanged},
+			issueLabel(&dlg.notesIssue),
 			Composite{
 				Layout: HBox{Margins: Margins{Top: 15}, SpacingZero: true},
 				Children: []Widget{
@@ -950,48 +1506,48 @@ func showVacancyDialogExt(app *AppMainWindow, currentVacancy *Vacancy, isEdit bo
 					PushButton{
 						AssignTo:   &dlg.acceptPB,
 						Text:       buttonText,
+						Enabled:    !viewOnly && !(isEdit && presentationMode),
 						Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
 						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
 						OnClicked: func() {
-							savedVacancy := Vacancy{}
-							savedVacancy.Title = strings.TrimSpace(dlg.titleLE.Text())
-							savedVacancy.Company = strings.TrimSpace(dlg.companyLE.Text())
-							savedVacancy.Description = strings.TrimSpace(dlg.descriptionTE.Text())
-							keywordsStr := dlg.keywordsLE.Text()
-							savedVacancy.Keywords = []string{}
-							if strings.TrimSpace(keywordsStr) != "" {
-								for _, kw := range strings.Split(keywordsStr, ",") {
-									trimmedKw := strings.TrimSpace(kw)
-									if trimmedKw != "" {
-										savedVacancy.Keywords = append(savedVacancy.Keywords, trimmedKw)
-									}
-								}
+							if err := dlg.binder.Submit(); err != nil {
+								log.Print("Form submit error: ", err)
+								return
 							}
-							savedVacancy.SourceURL = strings.TrimSpace(dlg.sourceURLLE.Text())
-							savedVacancy.Status = dlg.statusCB.Text()
-							savedVacancy.ExperienceLevel = dlg.experienceCB.Text()     // ДОБАВЛЕНО: Сохранение уровня опыта
-							savedVacancy.Notes = strings.TrimSpace(dlg.notesTE.Text()) // ДОБАВЛЕНО: Сохранение заметок
-
-							if savedVacancy.Title == "" {
-								walk.MsgBox(dlg.Dialog, "Ошибка", "Название вакансии не может быть пустым.", walk.MsgBoxIconWarning)
+							savedVacancy := *dlg.vacancy // Сохраняем служебные поля (резюме, даты, история)
+							savedVacancy.Status = dlg.originalStatus
+							dlg.form.applyTo(&savedVacancy)
+							setVacancyStatus(&savedVacancy, dlg.form.Status)
+
+							if !dlg.refreshIssues(app) {
+								if issue, ok := firstIssueError(dlg.validate(app)); ok {
+									dlg.fieldWidget(issue.Field).SetFocus()
+								}
 								return
 							}
+							askRejectionReasonIfNeeded(dlg.Dialog, &savedVacancy, dlg.originalStatus)
 
 							if dlg.isEdit && !isOnlineSearch {
+								allVacanciesMutex.Lock()
 								originalIndex := app.findVacancyIndexInAllExt(dlg.originalTitle, dlg.originalCompany)
 								if originalIndex != -1 {
 									allVacancies[originalIndex] = savedVacancy
+									renameVacancyLinksLocked(dlg.originalTitle, dlg.originalCompany, savedVacancy.Title, savedVacancy.Company)
+								}
+								allVacanciesMutex.Unlock()
+								if originalIndex != -1 {
+									event = vacancyEvent(VacancyUpdated, savedVacancy)
 								} else {
 									walk.MsgBox(app.MainWindow, "Ошибка", "Не удалось найти оригинальную вакансию для обновления.", walk.MsgBoxIconError)
 									dlg.Cancel()
 									return
 								}
 							} else {
-								if app.findVacancyIndexInAllExt(savedVacancy.Title, savedVacancy.Company) != -1 {
-									walk.MsgBox(dlg.Dialog, "Информация", "Эта вакансия уже есть в вашем локальном списке.", walk.MsgBoxIconInformation)
-									return
-								}
+								stampNewVacancy(&savedVacancy)
+								allVacanciesMutex.Lock()
 								allVacancies = append(allVacancies, savedVacancy)
+								allVacanciesMutex.Unlock()
+								event = vacancyEvent(VacancyAdded, savedVacancy)
 							}
 							saveVacancies()
 							accepted = true
@@ -1008,8 +1564,16 @@ func showVacancyDialogExt(app *AppMainWindow, currentVacancy *Vacancy, isEdit bo
 				},
 			},
 		},
-	}).Run(app.MainWindow); errDialog != nil {
+	}).Create(app.MainWindow); errDialog != nil {
 		log.Print("Dialog run error: ", errDialog)
+		return false
+	}
+	dlg.companyAC.Attach(dlg.companyLE)
+	dlg.keywordsAC.Attach(dlg.keywordsLE)
+	dlg.refreshIssues(app)
+	dlg.Run()
+	if accepted {
+		vacancyEvents.Publish(event)
 	}
 	return accepted
 }
@@ -1028,20 +1592,25 @@ func (app *AppMainWindow) confirmDeleteVacancy() {
 		return
 	}
 
+	before := snapshotVacancies()
+	allVacanciesMutex.Lock()
 	originalIndexInAll := app.findVacancyIndexInAllExt(selectedVacancyInModel.Title, selectedVacancyInModel.Company)
+	if originalIndexInAll != -1 {
+		allVacancies = append(allVacancies[:originalIndexInAll], allVacancies[originalIndexInAll+1:]...)
+		removeVacancyLinksLocked(selectedVacancyInModel.Title, selectedVacancyInModel.Company)
+	}
+	allVacanciesMutex.Unlock()
 	if originalIndexInAll == -1 {
 		log.Printf("Ошибка: не удалось найти вакансию '%s' в основном списке для удаления.", selectedVacancyInModel.Title)
 		walk.MsgBox(app.MainWindow, "Ошибка", "Произошла внутренняя ошибка при попытке удалить вакансию.", walk.MsgBoxIconError)
 		return
 	}
-
-	allVacancies = append(allVacancies[:originalIndexInAll], allVacancies[originalIndexInAll+1:]...)
+	undo := recordUndo("Вакансия «"+selectedVacancyInModel.Title+"» удалена", before)
 
 	saveVacancies()
-	app.performSearch()
-	// app.updateVacancyDetails() // performSearch уже это делает
+	vacancyEvents.Publish(vacancyEvent(VacancyDeleted, selectedVacancyInModel))
 
-	walk.MsgBox(app.MainWindow, "Удалено", "Вакансия '"+selectedVacancyInModel.Title+"' была успешно удалена.", walk.MsgBoxIconInformation)
+	app.showUndoToast("Вакансия «"+selectedVacancyInModel.Title+"» удалена", undo)
 }
 
 // updateVacancyDetails обновляет поля с деталями выбранной вакансии
@@ -1051,147 +1620,35 @@ func (app *AppMainWindow) updateVacancyDetails() {
 		idx = app.vacancyTable.CurrentIndex()
 	}
 
-	// Создаем функцию для обновления UI, которую будем вызывать через Synchronize
-	updateUI := func(vacancy Vacancy, hasSelection bool) {
-		if !hasSelection {
-			// Clear details panel and disable save button if nothing is selected
-			if app.detailTitleDisplay != nil {
-				app.detailTitleDisplay.SetText("-")
-			}
-			if app.detailCompanyDisplay != nil {
-				app.detailCompanyDisplay.SetText("-")
-			}
-			if app.detailStatusCB != nil {
-				app.detailStatusCB.SetCurrentIndex(-1)
-				app.detailStatusCB.SetEnabled(false)
-			}
-			if app.detailExperienceCB != nil {
-				app.detailExperienceCB.SetCurrentIndex(-1)
-				app.detailExperienceCB.SetEnabled(false)
-			}
-			if app.detailKeywordsLE != nil {
-				app.detailKeywordsLE.SetText("")
-				app.detailKeywordsLE.SetEnabled(false)
-			}
-			if app.detailSourceURLLE != nil {
-				app.detailSourceURLLE.SetText("")
-				app.detailSourceURLLE.SetEnabled(false)
-			}
-			if app.detailDescriptionTE != nil {
-				app.detailDescriptionTE.SetText("")
-				app.detailDescriptionTE.SetEnabled(false)
-			}
-			if app.detailNotesTE != nil {
-				app.detailNotesTE.SetText("")
-				app.detailNotesTE.SetEnabled(false)
-			}
-			if app.saveVacancyChangesPB != nil {
-				app.saveVacancyChangesPB.SetEnabled(false)
-			}
-			if app.detailResumeDisplay != nil {
-				app.detailResumeDisplay.SetText("Нет прикрепленного резюме")
-			}
-			if app.detailResumeOpenBtn != nil {
-				app.detailResumeOpenBtn.SetEnabled(false)
-			}
-			if app.detailResumeClearBtn != nil {
-				app.detailResumeClearBtn.SetEnabled(false)
-			}
-			return
-		}
-
-		// Update fields with selected vacancy data
-		if app.detailTitleDisplay != nil {
-			app.detailTitleDisplay.SetText(vacancy.Title)
-		}
-		if app.detailCompanyDisplay != nil {
-			app.detailCompanyDisplay.SetText(vacancy.Company)
-		}
-
-		if app.detailStatusCB != nil {
-			app.detailStatusCB.SetEnabled(true)
-			currentStatusIdx := -1
-			for i, s := range possibleStatuses {
-				if s == vacancy.Status {
-					currentStatusIdx = i
-					break
-				}
-			}
-			app.detailStatusCB.SetCurrentIndex(currentStatusIdx)
-			if currentStatusIdx == -1 && vacancy.Status == "" && len(possibleStatuses) > 0 {
-				app.detailStatusCB.SetCurrentIndex(0)
-			}
-		}
-
-		if app.detailExperienceCB != nil {
-			app.detailExperienceCB.SetEnabled(true)
-			currentExpIdx := -1
-			for i, el := range possibleExperienceLevels {
-				if el == vacancy.ExperienceLevel {
-					currentExpIdx = i
-					break
-				}
-			}
-			app.detailExperienceCB.SetCurrentIndex(currentExpIdx)
-			if currentExpIdx == -1 && vacancy.ExperienceLevel == "" && len(possibleExperienceLevels) > 0 {
-				app.detailExperienceCB.SetCurrentIndex(0)
-			}
-		}
-
-		if app.detailKeywordsLE != nil {
-			app.detailKeywordsLE.SetText(strings.Join(vacancy.Keywords, ", "))
-			app.detailKeywordsLE.SetEnabled(true)
-		}
-		if app.detailSourceURLLE != nil {
-			app.detailSourceURLLE.SetText(vacancy.SourceURL)
-			app.detailSourceURLLE.SetEnabled(true)
-		}
-		if app.detailDescriptionTE != nil {
-			app.detailDescriptionTE.SetText(vacancy.Description)
-			app.detailDescriptionTE.SetEnabled(true)
-		}
-		if app.detailNotesTE != nil {
-			app.detailNotesTE.SetText(vacancy.Notes)
-			app.detailNotesTE.SetEnabled(true)
-		}
-		if app.saveVacancyChangesPB != nil {
-			app.saveVacancyChangesPB.SetEnabled(true)
-		}
-
-		// Обновляем информацию о резюме
-		if app.detailResumeDisplay != nil {
-			if vacancy.ResumeFileName != "" {
-				app.detailResumeDisplay.SetText(vacancy.ResumeFileName)
-				if app.detailResumeOpenBtn != nil {
-					app.detailResumeOpenBtn.SetEnabled(true)
-				}
-				if app.detailResumeClearBtn != nil {
-					app.detailResumeClearBtn.SetEnabled(true)
-				}
-			} else {
-				app.detailResumeDisplay.SetText("Перетащите файл резюме сюда")
-				if app.detailResumeOpenBtn != nil {
-					app.detailResumeOpenBtn.SetEnabled(false)
-				}
-				if app.detailResumeClearBtn != nil {
-					app.detailResumeClearBtn.SetEnabled(false)
-				}
-			}
-		}
-	}
-
 	// Определяем, есть ли выделение и какие данные показывать
 	var vacancy Vacancy
 	hasSelection := false
-	if idx >= 0 && idx < len(app.vacancyModel.items) {
-		vacancy = app.vacancyModel.items[idx]
-		hasSelection = true
+	if idx >= 0 {
+		vacancy, hasSelection = app.vacancyAt(idx)
+	}
+
+	if hasSelection {
+		app.recordViewed(vacancy)
+	}
+	if presentationMode {
+		vacancy = redactVacancy(vacancy)
+	}
+
+	if hasSelection && !app.ensureDetails() {
+		return
 	}
 
 	// Вызываем обновление UI через Synchronize
 	if app.MainWindow != nil {
 		app.MainWindow.Synchronize(func() {
-			updateUI(vacancy, hasSelection)
+			app.DetailsVM.bind(vacancy, hasSelection, !app.offline)
+			app.highlightSearchMatches()
+			app.updateRelatedList(vacancy, hasSelection)
+			app.updateRemindersList(vacancy, hasSelection)
+			app.updateFollowUpHint(vacancy, hasSelection)
+			app.updateTestTaskSection(vacancy, hasSelection)
+			app.updateAttachmentsStrip(vacancy, hasSelection)
+			app.updateJournalList(vacancy, hasSelection)
 
 			// Обновляем layout всей панели деталей
 			if app.detailsGroup != nil {
@@ -1207,6 +1664,9 @@ func (app *AppMainWindow) updateVacancyDetails() {
 
 // saveVacancyDetails сохраняет изменения, сделанные в панели деталей
 func (app *AppMainWindow) saveVacancyDetails() {
+	if !detailsEditable() {
+		return // В панели могут быть точки вместо настоящих значений
+	}
 	idx := app.vacancyTable.CurrentIndex()
 	if idx < 0 || idx >= len(app.vacancyModel.items) {
 		app.MainWindow.Synchronize(func() {
@@ -1216,6 +1676,22 @@ func (app *AppMainWindow) saveVacancyDetails() {
 	}
 
 	vacancyInView := app.vacancyModel.items[idx]
+	form, err := app.DetailsVM.submit()
+	if err != nil {
+		log.Print("Form submit error: ", err)
+		return
+	}
+
+	// Причину отказа спрашиваем до блокировки списка, пока открыт модальный диалог
+	if !confirmApplyAfterRejection(app.MainWindow, vacancyInView, form.Status) {
+		return
+	}
+
+	var rejectionReason, rejectionComment string
+	rejectionAnswered := false
+	if form.Status == rejectedStatus && vacancyInView.Status != rejectedStatus {
+		rejectionReason, rejectionComment, rejectionAnswered = promptRejectionReason(app.MainWindow, vacancyInView.Title)
+	}
 
 	allVacanciesMutex.Lock()
 	originalIndexInAll := -1
@@ -1235,59 +1711,17 @@ func (app *AppMainWindow) saveVacancyDetails() {
 	}
 
 	updatedVacancy := allVacancies[originalIndexInAll]
-	changed := false
-
-	if app.detailStatusCB != nil {
-		newStatus := app.detailStatusCB.Text()
-		if updatedVacancy.Status != newStatus {
-			updatedVacancy.Status = newStatus
-			changed = true
-		}
-	}
-	if app.detailExperienceCB != nil {
-		newExperience := app.detailExperienceCB.Text()
-		if updatedVacancy.ExperienceLevel != newExperience {
-			updatedVacancy.ExperienceLevel = newExperience
-			changed = true
-		}
-	}
-	if app.detailKeywordsLE != nil {
-		newKeywordsStr := app.detailKeywordsLE.Text()
-		newKeywords := []string{}
-		if strings.TrimSpace(newKeywordsStr) != "" {
-			for _, kw := range strings.Split(newKeywordsStr, ",") {
-				trimmedKw := strings.TrimSpace(kw)
-				if trimmedKw != "" {
-					newKeywords = append(newKeywords, trimmedKw)
-				}
-			}
-		}
-		if !equalStringSlices(updatedVacancy.Keywords, newKeywords) {
-			updatedVacancy.Keywords = newKeywords
-			changed = true
-		}
-	}
-	if app.detailSourceURLLE != nil {
-		newSourceURL := app.detailSourceURLLE.Text()
-		if updatedVacancy.SourceURL != newSourceURL {
-			updatedVacancy.SourceURL = newSourceURL
-			changed = true
-		}
-	}
-	if app.detailDescriptionTE != nil {
-		newDescription := app.detailDescriptionTE.Text()
-		if updatedVacancy.Description != newDescription {
-			updatedVacancy.Description = newDescription
-			changed = true
-		}
-	}
-	if app.detailNotesTE != nil {
-		newNotes := app.detailNotesTE.Text()
-		if updatedVacancy.Notes != newNotes {
-			updatedVacancy.Notes = newNotes
-			changed = true
+	if updatedVacancy.Status != form.Status {
+		setVacancyStatus(&updatedVacancy, form.Status)
+		if rejectionAnswered {
+			updatedVacancy.RejectionReason = rejectionReason
+			updatedVacancy.RejectionComment = rejectionComment
 		}
 	}
+	// Название и компания в панели не редактируются
+	form.Title, form.Company = updatedVacancy.Title, updatedVacancy.Company
+	form.applyTo(&updatedVacancy)
+	changed := vacancyFieldsDiffer(allVacancies[originalIndexInAll], updatedVacancy)
 
 	if changed {
 		allVacancies[originalIndexInAll] = updatedVacancy
@@ -1295,17 +1729,18 @@ func (app *AppMainWindow) saveVacancyDetails() {
 		go saveVacancies()
 		log.Printf("Вакансия '%s' обновлена через панель деталей.", updatedVacancy.Title)
 		app.MainWindow.Synchronize(func() {
-			walk.MsgBox(app.MainWindow, "Сохранено", "Изменения для вакансии '"+updatedVacancy.Title+"' сохранены.", walk.MsgBoxIconInformation)
+			app.notify("Изменения для вакансии «" + updatedVacancy.Title + "» сохранены.")
 		})
 	} else {
 		app.MainWindow.Synchronize(func() {
-			walk.MsgBox(app.MainWindow, "Информация", "Нет изменений для сохранения.", walk.MsgBoxIconInformation)
+			app.notify("Нет изменений для сохранения.")
 		})
 	}
 	allVacanciesMutex.Unlock()
 
-	// PerformSearch already calls updateVacancyDetails, which is now synchronized.
-	app.performSearch()
+	if changed {
+		vacancyEvents.Publish(vacancyEvent(VacancyUpdated, updatedVacancy))
+	}
 }
 
 // equalStringSlices проверяет, равны ли два строковых слайса (порядок важен)
@@ -1322,7 +1757,8 @@ func equalStringSlices(a, b []string) bool {
 }
 
 func loadVacancies() {
-	data, err := os.ReadFile(vacanciesFile)
+	defer recordStorageLatency("чтение", time.Now())
+	data, err := os.ReadFile(dataPath(vacanciesFile))
 	if err != nil {
 		if os.IsNotExist(err) {
 			log.Printf("Файл %s не найден, создаем с примерами.", vacanciesFile)
@@ -1342,27 +1778,43 @@ func loadVacancies() {
 
 	allVacanciesMutex.Lock()
 	defer allVacanciesMutex.Unlock()
-	err = json.Unmarshal(data, &allVacancies)
+	vacancies, version, err := decodeVacanciesFile(data)
 	if err != nil {
 		log.Printf("Ошибка декодирования JSON из файла %s: %v", vacanciesFile, err)
 		allVacancies = []Vacancy{}
+		// Не перезаписываем файл, который не смогли прочитать
+		vacanciesReadOnly = true
 		return
 	}
+	if version < currentSchemaVersion {
+		backupBeforeMigration(dataPath(vacanciesFile), data, version)
+	}
+	allVacancies = vacancies
 	log.Printf("Загружено %d вакансий из файла %s", len(allVacancies), vacanciesFile)
 }
 
 // saveVacancies сохраняет текущий список вакансий в файл vacancies.json
 func saveVacancies() {
+	defer recordStorageLatency("запись", time.Now())
 	allVacanciesMutex.Lock()
 	defer allVacanciesMutex.Unlock()
 
-	data, err := json.MarshalIndent(allVacancies, "", "  ")
+	if vacanciesReadOnly {
+		log.Printf("Сохранение в %s отключено: файл не удалось прочитать этой версией приложения", vacanciesFile)
+		return
+	}
+	if viewOnly {
+		log.Printf("Сохранение в %s отключено: база открыта только для просмотра", vacanciesFile)
+		return
+	}
+
+	data, err := encodeVacanciesFile(allVacancies)
 	if err != nil {
 		log.Printf("Ошибка кодирования вакансий в JSON: %v", err)
 		return
 	}
 
-	err = os.WriteFile(vacanciesFile, data, 0644)
+	err = os.WriteFile(dataPath(vacanciesFile), data, 0644)
 	if err != nil {
 		log.Printf("Ошибка записи файла %s: %v", vacanciesFile, err)
 	}
@@ -1403,8 +1855,22 @@ type JoobleError struct {
 	Message string `json:"message"`
 }
 
+// Форматы даты в поле updated ответа Jooble
+var joobleDateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05.0000000", "2006-01-02T15:04:05", "2006-01-02"}
+
+// parseJoobleDate разбирает дату публикации вакансии; пустое значение - дата неизвестна
+func parseJoobleDate(raw string) time.Time {
+	raw = strings.TrimSpace(raw)
+	for _, layout := range joobleDateLayouts {
+		if t, err := time.ParseInLocation(layout, raw, time.Local); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
 // ИСПРАВЛЕНО: Восстановление функции searchVacanciesJooble
-func searchVacanciesJooble(keywords, location string, ch chan struct{}) ([]Vacancy, error) {
+func searchVacanciesJooble(ctx context.Context, keywords, location string) ([]Vacancy, error) {
 	apiURL := "https://jooble.org/api/"
 	joobleReq := JoobleRequest{
 		Keywords: keywords,
@@ -1417,63 +1883,35 @@ func searchVacanciesJooble(keywords, location string, ch chan struct{}) ([]Vacan
 		return nil, fmt.Errorf("ошибка кодирования запроса в JSON: %w", err)
 	}
 
-	// Создаем контекст для отмены HTTP-запроса
-	ctx, cancelRequest := context.WithCancel(context.Background())
-	defer cancelRequest() // Убедимся, что cancelRequest вызывается при выходе из функции
-
-	// Goroutine для прослушивания канала отмены от UI и отмены HTTP-контекста
-	go func() {
-		select {
-		case <-ch: // Получен сигнал отмены из UI
-			cancelRequest() // Отменяем HTTP-запрос
-		case <-ctx.Done(): // Контекст HTTP-запроса уже завершен (например, по таймауту или другой причине)
-			// Ничего не делаем, запрос уже завершился или был отменен
-		}
-	}()
-
-	req, err := http.NewRequestWithContext(ctx, "POST", apiURL+joobleAPIKey, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return nil, fmt.Errorf("ошибка создания HTTP запроса: %w", err)
-	}
-	req.Header.Set("Content-Type", "application/json")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		// Проверяем, была ли ошибка вызвана отменой контекста
-		select {
-		case <-ch: // Канал отмены из UI закрыт
-			return nil, fmt.Errorf("поиск отменен пользователем (сигнал из UI)")
-		default:
-			if ctx.Err() == context.Canceled {
-				return nil, fmt.Errorf("поиск отменен пользователем (контекст HTTP)")
+	cacheKey := searchCacheKey(joobleProvider, keywords, location, fmt.Sprint(joobleReq.Page))
+	body, cachedAt, fromCache := cachedSearchResponse(cacheKey)
+	if fromCache {
+		log.Printf("Результаты Jooble по запросу '%s' взяты из кэша от %s", keywords, cachedAt.Format("15:04"))
+	} else {
+		// Запрос с ограничением частоты и повторами при сбоях сети и сервера
+		var status int
+		body, status, err = fetchWithRetry(ctx, joobleProvider, func() (*http.Request, error) {
+			req, err := http.NewRequestWithContext(ctx, "POST", apiURL+joobleKey(), bytes.NewBuffer(jsonData))
+			if err != nil {
+				return nil, fmt.Errorf("ошибка создания HTTP запроса: %w", err)
+			}
+			req.Header.Set("Content-Type", "application/json")
+			return req, nil
+		})
+		if err != nil {
+			// Отмена сеанса поиска прерывает и HTTP-запрос
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("поиск отменен пользователем: %w", ctx.Err())
 			}
 			return nil, fmt.Errorf("ошибка выполнения HTTP запроса: %w", err)
 		}
-	}
-	defer resp.Body.Close()
-
-	// Проверка на отмену перед чтением тела (на всякий случай, если Do() не вернул ошибку сразу)
-	select {
-	case <-ch:
-		return nil, fmt.Errorf("поиск отменен пользователем перед чтением ответа")
-	default:
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("ошибка чтения тела ответа: %w", err)
-	}
-
-	// Еще одна проверка на отмену
-	select {
-	case <-ch:
-		return nil, fmt.Errorf("поиск отменен пользователем перед обработкой ответа")
-	default:
-	}
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("поиск отменен пользователем перед обработкой ответа: %w", ctx.Err())
+		}
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("ошибка API Jooble (HTTP %d): %s", resp.StatusCode, string(body))
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("ошибка API Jooble (HTTP %d): %s", status, string(body))
+		}
 	}
 
 	var joobleResp JoobleResponse
@@ -1489,29 +1927,37 @@ func searchVacanciesJooble(keywords, location string, ch chan struct{}) ([]Vacan
 	if joobleResp.Error != nil {
 		return nil, fmt.Errorf("API Jooble вернуло ошибку: %s (код: %d)", joobleResp.Error.Message, joobleResp.Error.Code)
 	}
+	if !fromCache {
+		storeSearchResponse(cacheKey, body)
+	}
 
 	var vacancies []Vacancy
 	for _, job := range joobleResp.Jobs {
 		// Проверка на отмену в цикле, если вакансий много
-		select {
-		case <-ch:
-			return nil, fmt.Errorf("поиск отменен пользователем во время обработки результатов")
-		default:
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("поиск отменен пользователем во время обработки результатов: %w", ctx.Err())
 		}
 		if job.Title == "" || job.Link == "" {
 			log.Printf("Пропущена вакансия от Jooble из-за отсутствия Title или Link: %+v", job)
 			continue
 		}
-		vacancies = append(vacancies, Vacancy{
-			Title:           job.Title,
-			Company:         job.Company,
-			Description:     job.Snippet,
+		vacancy := Vacancy{
+			Title:           sanitizeLine(job.Title),
+			Company:         sanitizeLine(job.Company),
+			Description:     sanitizeDescription(job.Snippet),
 			Keywords:        []string{},
 			SourceURL:       job.Link,
+			Location:        sanitizeLine(job.Location),
+			Source:          job.Source,
+			PostedAt:        parseJoobleDate(job.Updated),
 			Status:          possibleStatuses[0],         // "Новая"
 			ExperienceLevel: possibleExperienceLevels[0], // ДОБАВЛЕНО: "Не указан" для вакансий Jooble
 			Notes:           "",                          // ДОБАВЛЕНО: Пустые заметки для онлайн вакансий
-		})
+		}
+		applySalary(&vacancy, job.Salary)
+		autoDetectExperience(&vacancy)
+		applyFieldMapping(providerJooble, &vacancy)
+		vacancies = append(vacancies, vacancy)
 	}
 
 	return vacancies, nil
@@ -1532,25 +1978,20 @@ func (app *AppMainWindow) switchToLocalMode() {
 
 	// Включаем кнопки для локальных операций
 	if app.addVacancyButton != nil {
-		app.addVacancyButton.SetEnabled(true)
+		app.addVacancyButton.SetEnabled(!viewOnly)
 	}
 	if app.editVacancyButton != nil {
-		app.editVacancyButton.SetEnabled(true)
+		app.editVacancyButton.SetEnabled(!viewOnly)
 	}
 	if app.deleteVacancyButton != nil {
-		app.deleteVacancyButton.SetEnabled(true)
-	}
-	if app.searchEdit != nil {
-		app.searchEdit.SetEnabled(true)
+		app.deleteVacancyButton.SetEnabled(!viewOnly)
 	}
-	if app.searchFieldCB != nil {
-		app.searchFieldCB.SetEnabled(true)
+	if app.duplicateButton != nil {
+		app.duplicateButton.SetEnabled(!viewOnly)
 	}
-	if app.searchButton != nil {
-		app.searchButton.SetEnabled(true)
-	} // Убедимся, что кнопка поиска тоже включается
+	app.SearchBarVM.setEnabled(true)
 	if app.onlineSearchButton != nil {
-		app.onlineSearchButton.SetEnabled(true)
+		app.onlineSearchButton.SetEnabled(!app.offline)
 	} // И кнопка онлайн-поиска
 
 	app.performSearch()
@@ -1563,40 +2004,43 @@ func (app *AppMainWindow) switchToOnlineSearchMode() {
 		walk.MsgBox(app.MainWindow, "Онлайн поиск", "Пожалуйста, введите текст для поиска.", walk.MsgBoxIconInformation)
 		return
 	}
+	if app.offline {
+		app.queueOnlineSearch(searchTerm)
+		walk.MsgBox(app.MainWindow, "Онлайн поиск", "Нет подключения к интернету. Поиск '"+searchTerm+"' будет выполнен, когда сеть появится.", walk.MsgBoxIconInformation)
+		return
+	}
+
+	app.startOnlineSearch(searchTerm, onlineSources(searchTerm))
+}
 
-	if app.localVacanciesContainer == nil || app.onlineResultsContainer == nil || app.cancelOnlineSearchButton == nil || app.backToLocalButton == nil {
-		log.Println("switchToOnlineSearchMode: один из ключевых компонентов UI не инициализирован")
+// startOnlineSearch переключает окно на онлайн-результаты и опрашивает источники sources в фоне.
+// Пустой term - просмотр RSS-лент без запроса, такой поиск не попадает в историю.
+func (app *AppMainWindow) startOnlineSearch(term string, sources []onlineSource) {
+	if !app.ensureOnlineResults() || app.localVacanciesContainer == nil || app.cancelOnlineSearchButton == nil || app.backToLocalButton == nil {
+		log.Println("startOnlineSearch: один из ключевых компонентов UI не инициализирован")
 		return
 	}
 	app.localVacanciesContainer.SetVisible(false)
 	app.onlineResultsContainer.SetVisible(true)
 
-	app.onlineSearchCancelChan = make(chan struct{})
-	cancelChan := app.onlineSearchCancelChan
+	// Предыдущий поиск, если он ещё идёт, больше не нужен
+	if app.onlineSearch != nil {
+		app.onlineSearch.End()
+	}
+	session := newSearchSession()
+	app.onlineSearch = session
 
 	app.cancelOnlineSearchButton.SetVisible(true)
 	app.cancelOnlineSearchButton.SetEnabled(true)
 	app.cancelOnlineSearchButton.SetText("Отменить поиск")
-
-	app.cancelOnlineSearchButton.Clicked().Attach(func() {
-		select {
-		case <-cancelChan:
-		default:
-			close(cancelChan)
-		}
+	session.attach(app.cancelOnlineSearchButton.Clicked(), func() {
+		session.Cancel()
 		app.cancelOnlineSearchButton.SetEnabled(false)
 		app.cancelOnlineSearchButton.SetText("Отменяется...")
 	})
 
 	app.backToLocalButton.SetEnabled(true)
-	app.backToLocalButton.Clicked().Attach(func() {
-		select {
-		case <-cancelChan:
-		default:
-			close(cancelChan)
-		}
-		app.switchToLocalMode()
-	})
+	session.attach(app.backToLocalButton.Clicked(), session.Cancel) // Возврат к списку - switchToLocalMode
 
 	if app.addVacancyButton != nil {
 		app.addVacancyButton.SetEnabled(false)
@@ -1607,6 +2051,9 @@ func (app *AppMainWindow) switchToOnlineSearchMode() {
 	if app.deleteVacancyButton != nil {
 		app.deleteVacancyButton.SetEnabled(false)
 	}
+	if app.duplicateButton != nil {
+		app.duplicateButton.SetEnabled(false)
+	}
 	if app.searchButton != nil {
 		app.searchButton.SetEnabled(false)
 	}
@@ -1614,93 +2061,147 @@ func (app *AppMainWindow) switchToOnlineSearchMode() {
 		app.onlineSearchButton.SetEnabled(false)
 	}
 
-	app.onlineVacancyModel.items = []Vacancy{}
-	app.onlineVacancyModel.PublishRowsReset()
+	if app.onlineFilterLE != nil {
+		app.onlineFilterLE.SetText("")
+	}
+	app.onlineVacancyModel.SetResults(nil)
+	app.updateOnlinePreview()
 	app.onlineResultsLabel.SetText("Идет поиск онлайн... Пожалуйста, подождите.")
 
-	go func(currentSearchTerm string, ch chan struct{}) {
-		joobleVacancies, err := searchVacanciesJooble(currentSearchTerm, "", ch)
+	what := fmt.Sprintf("по запросу '%s'", term)
+	if term == "" {
+		what = "по RSS-лентам"
+	}
+
+	progress := newSearchProgress(sources)
+	app.onlineProgressPB.SetRange(0, len(sources))
+	app.onlineProgressPB.SetValue(0)
+	app.onlineProgressPB.SetVisible(true)
+	app.onlineSourcesLabel.SetText(progress.text())
 
-		select {
-		case <-ch:
+	// Результаты источников показываются по мере готовности; Synchronize сохраняет их порядок
+	go func() {
+		for batch := range streamSearch(session.Context(), sources) {
 			app.MainWindow.Synchronize(func() {
-				app.onlineResultsLabel.SetText(fmt.Sprintf("Онлайн поиск по запросу '%s' отменен.", currentSearchTerm))
-				if app.cancelOnlineSearchButton != nil {
-					app.cancelOnlineSearchButton.SetVisible(false)
+				if app.onlineSearch != session {
+					return // Уже начат другой поиск
 				}
-				if app.onlineSearchButton != nil {
-					app.onlineSearchButton.SetEnabled(true)
+				if fresh := progress.add(batch); len(fresh) > 0 {
+					app.appendOnlineResults(fresh)
 				}
-				if app.searchButton != nil {
-					app.searchButton.SetEnabled(true)
+				app.onlineProgressPB.SetValue(progress.done)
+				app.onlineSourcesLabel.SetText(progress.text())
+				if !session.Canceled() {
+					app.onlineResultsLabel.SetText(fmt.Sprintf("Идет поиск онлайн (ответили %d из %d источников), новых: %d",
+						progress.done, len(progress.sources), len(app.onlineVacancyModel.all)))
 				}
 			})
-			return
-		default:
 		}
-
 		app.MainWindow.Synchronize(func() {
-			if app.cancelOnlineSearchButton != nil {
-				app.cancelOnlineSearchButton.SetVisible(false)
-			}
-			if app.onlineSearchButton != nil {
-				app.onlineSearchButton.SetEnabled(true)
+			if app.onlineSearch != session {
+				return
 			}
-			if app.searchButton != nil {
-				app.searchButton.SetEnabled(true)
+			app.finishOnlineSearch(session, progress, term, what)
+		})
+	}()
+}
+
+// appendOnlineResults добавляет результаты в таблицу, не сбрасывая выделенную строку
+func (app *AppMainWindow) appendOnlineResults(vacancies []Vacancy) {
+	m := app.onlineVacancyModel
+	idx := app.onlineResultsTable.CurrentIndex()
+	var selected *Vacancy
+	if idx >= 0 && idx < len(m.items) {
+		v := m.items[idx]
+		selected = &v
+	}
+	m.Append(vacancies)
+	if selected != nil {
+		for i, v := range m.items {
+			if sameVacancy(v.Title, v.Company, selected.Title, selected.Company) && v.SourceURL == selected.SourceURL {
+				app.onlineResultsTable.SetCurrentIndex(i)
+				break
 			}
+		}
+	}
+	app.updateOnlinePreview()
+}
 
-			if err != nil {
-				if strings.Contains(err.Error(), "context canceled") {
-					app.onlineResultsLabel.SetText(fmt.Sprintf("Онлайн поиск по запросу '%s' отменен.", currentSearchTerm))
-				} else {
-					log.Printf("Ошибка онлайн поиска Jooble: %v", err)
-					walk.MsgBox(app.MainWindow, "Ошибка поиска", fmt.Sprintf("Не удалось выполнить онлайн поиск: %v", err), walk.MsgBoxIconError)
-					app.onlineResultsLabel.SetText(fmt.Sprintf("Ошибка онлайн поиска: %v", err))
-				}
+// finishOnlineSearch подводит итог онлайн-поиска, когда ответили все источники
+func (app *AppMainWindow) finishOnlineSearch(session *SearchSession, progress *searchProgress, term, what string) {
+	session.End()
+	app.onlineSearch = nil
+	app.onlineProgressPB.SetVisible(false)
+	if app.cancelOnlineSearchButton != nil {
+		app.cancelOnlineSearchButton.SetVisible(false)
+	}
+	if app.onlineSearchButton != nil {
+		app.onlineSearchButton.SetEnabled(!app.offline)
+	}
+	if app.searchButton != nil {
+		app.searchButton.SetEnabled(true)
+	}
+
+	newCount := len(app.onlineVacancyModel.all)
+	if session.Canceled() {
+		text := fmt.Sprintf("Онлайн поиск %s отменен.", what)
+		if newCount > 0 {
+			text += fmt.Sprintf(" Показаны уже найденные: %d", newCount)
+		}
+		app.onlineResultsLabel.SetText(text)
+		return
+	}
+	if err := progress.firstErr; err != nil && progress.found == 0 {
+		if isOfflineError(err) {
+			log.Printf("Онлайн поиск: нет сети: %v", err)
+			app.setOnline(false)
+			if term == "" {
+				app.onlineResultsLabel.SetText("Нет подключения к интернету.")
 				return
 			}
+			app.queueOnlineSearch(term)
+			app.onlineResultsLabel.SetText(fmt.Sprintf("Нет подключения к интернету. Поиск '%s' будет выполнен автоматически, когда сеть появится.", term))
+		} else if progress.unavailable() {
+			// Об источниках, отключённых после череды сбоев, говорит строка состояния, без окна с ошибкой
+			app.onlineResultsLabel.SetText("Источники временно недоступны, поиск повторится в них позже: " + progress.text())
+		} else {
+			showError(app.MainWindow, "Ошибка поиска", wrapError("Не удалось выполнить онлайн поиск", err))
+			app.onlineResultsLabel.SetText(fmt.Sprintf("Ошибка онлайн поиска: %v", err))
+		}
+		return
+	}
 
-			filteredOnlineVacancies := []Vacancy{}
-			allVacanciesMutex.Lock()
-			for _, onlineV := range joobleVacancies {
-				foundLocally := false
-				select {
-				case <-ch:
-					allVacanciesMutex.Unlock()
-					app.onlineResultsLabel.SetText(fmt.Sprintf("Онлайн поиск по запросу '%s' отменен в процессе фильтрации.", currentSearchTerm))
-					return
-				default:
-				}
-				for _, localV := range allVacancies {
-					if strings.EqualFold(onlineV.Title, localV.Title) && strings.EqualFold(onlineV.Company, localV.Company) {
-						foundLocally = true
-						break
-					}
-				}
-				if !foundLocally {
-					filteredOnlineVacancies = append(filteredOnlineVacancies, onlineV)
-				}
-			}
-			allVacanciesMutex.Unlock()
+	app.recordOnlineSearch(term, progress.found, newCount)
+	if newCount == 0 {
+		app.onlineResultsLabel.SetText(fmt.Sprintf("Онлайн поиск %s не дал новых результатов.", what))
+	} else {
+		app.updateOnlineResultsLabel()
+	}
+}
 
-			app.onlineVacancyModel.items = filteredOnlineVacancies
-			app.onlineVacancyModel.PublishRowsReset()
-			if len(filteredOnlineVacancies) == 0 {
-				select {
-				case <-ch:
-					app.onlineResultsLabel.SetText(fmt.Sprintf("Онлайн поиск по запросу '%s' отменен.", currentSearchTerm))
-				default:
-					if err != nil {
-					} else {
-						app.onlineResultsLabel.SetText(fmt.Sprintf("Онлайн поиск по запросу '%s' не дал новых результатов.", currentSearchTerm))
-					}
-				}
-			} else {
-				app.onlineResultsLabel.SetText(fmt.Sprintf("Найдено онлайн (новые): %d", len(filteredOnlineVacancies)))
-			}
-		})
-	}(searchTerm, cancelChan)
+// applyOnlineFilter фильтрует полученные онлайн-результаты по тексту из поля фильтра
+func (app *AppMainWindow) applyOnlineFilter() {
+	if app.onlineFilterLE == nil {
+		return
+	}
+	app.onlineVacancyModel.SetFilter(app.onlineFilterLE.Text())
+	app.updateOnlinePreview()
+	if len(app.onlineVacancyModel.all) > 0 {
+		app.updateOnlineResultsLabel()
+	}
+}
+
+// updateOnlineResultsLabel показывает число найденных и отфильтрованных результатов
+func (app *AppMainWindow) updateOnlineResultsLabel() {
+	m := app.onlineVacancyModel
+	text := fmt.Sprintf("Найдено онлайн (новые): %d", len(m.all))
+	if m.filter != "" {
+		text += fmt.Sprintf(", показано по фильтру: %d", len(m.items))
+	}
+	if m.blocked > 0 {
+		text += fmt.Sprintf(", скрыто чёрным списком: %d", m.blocked)
+	}
+	app.onlineResultsLabel.SetText(text)
 }
 
 // ДОБАВЛЕНО: Функция для открытия файла резюме
@@ -1719,7 +2220,7 @@ func (app *AppMainWindow) openResume() {
 	cmd := exec.Command("cmd", "/c", "start", vacancy.ResumePath)
 	err := cmd.Start()
 	if err != nil {
-		walk.MsgBox(app.MainWindow, "Ошибка", "Не удалось открыть файл резюме: "+err.Error(), walk.MsgBoxIconError)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось открыть файл резюме", err))
 	}
 }
 
@@ -1741,7 +2242,7 @@ func (app *AppMainWindow) clearResume() {
 		allVacancies[originalIndex].ResumePath = ""
 		allVacancies[originalIndex].ResumeFileName = ""
 		saveVacancies()
-		app.updateVacancyDetails()
+		vacancyEvents.Publish(vacancyEvent(VacancyUpdated, allVacancies[originalIndex]))
 	}
 }
 
@@ -1783,7 +2284,7 @@ func (app *AppMainWindow) handleFileDrop(files []string) {
 		allVacancies[originalIndex].ResumePath = filePath
 		allVacancies[originalIndex].ResumeFileName = fileName
 		saveVacancies()
-		app.updateVacancyDetails()
+		vacancyEvents.Publish(vacancyEvent(VacancyUpdated, allVacancies[originalIndex]))
 	}
 }
 
@@ -1800,7 +2301,7 @@ func (app *AppMainWindow) selectResume() {
 	dlg.Filter = "Все поддерживаемые форматы (*.pdf;*.doc;*.docx;*.txt;*.rtf)|*.pdf;*.doc;*.docx;*.txt;*.rtf"
 
 	if ok, err := dlg.ShowOpen(app.MainWindow); err != nil {
-		walk.MsgBox(app.MainWindow, "Ошибка", "Ошибка при открытии диалога: "+err.Error(), walk.MsgBoxIconError)
+		showError(app.MainWindow, "Ошибка", wrapError("Ошибка при открытии диалога", err))
 	} else if ok {
 		filePath := dlg.FilePath
 		fileName := filepath.Base(filePath)
@@ -1826,7 +2327,7 @@ func (app *AppMainWindow) selectResume() {
 			allVacancies[originalIndex].ResumePath = filePath
 			allVacancies[originalIndex].ResumeFileName = fileName
 			saveVacancies()
-			app.updateVacancyDetails()
+			vacancyEvents.Publish(vacancyEvent(VacancyUpdated, allVacancies[originalIndex]))
 		}
 	}
 }
@@ -1840,185 +2341,56 @@ func (app *AppMainWindow) applyTheme(theme Theme) {
 	defer mainBrush.Dispose()
 	app.MainWindow.SetBackground(mainBrush)
 
-	// Применяем тему к контейнерам
-	containers := []*walk.Composite{
-		app.localVacanciesContainer,
-		app.onlineResultsContainer,
-		app.detailResumeDropArea,
-	}
-
-	containerBrush, _ := walk.NewSolidColorBrush(theme.Background)
-	defer containerBrush.Dispose()
-	for _, container := range containers {
-		if container != nil {
-			container.SetBackground(containerBrush)
-		}
-	}
+	// Контейнеры, кнопки, таблица и метки самого окна
+	themeContainers(theme, app.localVacanciesContainer, app.goalBar)
+	themeButtons(theme,
+		app.addVacancyButton, app.editVacancyButton, app.deleteVacancyButton, app.duplicateButton,
+		app.onlineSearchButton, app.themeToggleButton, app.resumeArchiveButton)
+	themeTables(theme, app.vacancyTable)
+	themeLabels(theme, app.goalLabel, app.goalStreakLabel)
 
-	// ScrollView отдельно
-	if app.detailsScrollView != nil {
-		scrollBrush, _ := walk.NewSolidColorBrush(theme.Background)
-		defer scrollBrush.Dispose()
-		app.detailsScrollView.SetBackground(scrollBrush)
-	}
-
-	// Группы (GroupBox)
-	if app.detailsGroup != nil {
-		groupBrush, _ := walk.NewSolidColorBrush(theme.PanelBG)
-		defer groupBrush.Dispose()
-		app.detailsGroup.SetBackground(groupBrush)
-	}
-
-	// Кнопки
-	buttons := []*walk.PushButton{
-		app.searchButton,
-		app.addVacancyButton,
-		app.editVacancyButton,
-		app.deleteVacancyButton,
-		app.onlineSearchButton,
-		app.saveVacancyChangesPB,
-		app.detailResumeOpenBtn,
-		app.detailResumeClearBtn,
-		app.themeToggleButton,
-		app.resumeArchiveButton,
-		app.backToLocalButton,
-		app.cancelOnlineSearchButton,
-	}
-
-	buttonBrush, _ := walk.NewSolidColorBrush(theme.ButtonBG)
-	defer buttonBrush.Dispose()
-	for _, btn := range buttons {
-		if btn != nil {
-			btn.SetBackground(buttonBrush)
-		}
-	}
+	// Панели применяют тему к своим виджетам сами
+	app.SearchBarVM.applyTheme(theme)
+	app.DetailsVM.applyTheme(theme)
+	app.OnlineSearchVM.applyTheme(theme)
+	app.highlightSearchMatches()
 
-	// Таблицы
-	tables := []*walk.TableView{
-		app.vacancyTable,
-		app.onlineResultsTable,
-	}
+	statusColors = statusColorsFor(theme, appSettings.StatusPalette)
 
-	tableBrush, _ := walk.NewSolidColorBrush(theme.TableBG)
-	defer tableBrush.Dispose()
-	for _, table := range tables {
-		if table != nil {
-			table.SetBackground(tableBrush)
-		}
-	}
-
-	// Метки
-	labels := []*walk.Label{
-		app.searchLabel,
-		app.detailTitleLabel,
-		app.detailTitleDisplay,
-		app.detailCompanyLabel,
-		app.detailCompanyDisplay,
-		app.detailStatusLabel,
-		app.detailExperienceLabel,
-		app.detailKeywordsLabel,
-		app.detailSourceURLLabel,
-		app.detailDescriptionLabel,
-		app.detailNotesLabel,
-		app.detailResumeLabel,
-		app.detailResumeDisplay,
-		app.onlineResultsLabel,
-	}
-
-	for _, label := range labels {
-		if label != nil {
-			label.SetTextColor(theme.Text)
-		}
-	}
-
-	// ComboBox'ы
-	comboBoxes := []*walk.ComboBox{
-		app.searchFieldCB,
-		app.statusFilterCB,
-		app.experienceFilterCB,
-		app.detailStatusCB,
-		app.detailExperienceCB,
-	}
-
-	comboBoxBrush, _ := walk.NewSolidColorBrush(theme.ButtonBG)
-	defer comboBoxBrush.Dispose()
-	for _, cb := range comboBoxes {
-		if cb != nil {
-			cb.SetBackground(comboBoxBrush)
-		}
-	}
-
-	// LineEdit'ы
-	lineEdits := []*walk.LineEdit{
-		app.searchEdit,
-		app.detailKeywordsLE,
-		app.detailSourceURLLE,
-	}
-
-	editBrush, _ := walk.NewSolidColorBrush(theme.Background)
-	defer editBrush.Dispose()
-	for _, le := range lineEdits {
-		if le != nil {
-			le.SetBackground(editBrush)
-			le.SetTextColor(theme.Text)
-		}
+	// Обновляем отображение таблицы для применения новых цветов статусов
+	if app.vacancyTable != nil {
+		app.vacancyTable.Invalidate()
 	}
+}
 
-	// TextEdit'ы
-	textEdits := []*walk.TextEdit{
-		app.detailDescriptionTE,
-		app.detailNotesTE,
-	}
+// applySavedTheme применяет тему из настроек и обновляет надпись кнопки переключения
+func (app *AppMainWindow) applySavedTheme() {
+	app.applyTheme(themeByName(appSettings.ThemeName))
+	app.updateThemeToggleText()
+}
 
-	textEditBrush, _ := walk.NewSolidColorBrush(theme.Background)
-	defer textEditBrush.Dispose()
-	for _, te := range textEdits {
-		if te != nil {
-			te.SetBackground(textEditBrush)
-			te.SetTextColor(theme.Text)
-		}
+// updateThemeToggleText подписывает кнопку переключения темой, на которую она переключит
+func (app *AppMainWindow) updateThemeToggleText() {
+	if app.themeToggleButton == nil {
+		return
 	}
-
-	// Обновляем цвета статусов для тёмной темы
-	if theme.Name == "Тёмная" {
-		statusColors = map[string]walk.Color{
-			"Новая": walk.RGB(0, 80, 0), // тёмно-зелёный
-			"Планирую откликнуться": walk.RGB(80, 80, 0),  // тёмно-жёлтый
-			"Откликнулся":           walk.RGB(0, 60, 80),  // тёмно-голубой
-			"Тестовое задание":      walk.RGB(80, 60, 0),  // тёмно-оранжевый
-			"Собеседование":         walk.RGB(60, 0, 80),  // тёмно-пурпурный
-			"Оффер":                 walk.RGB(0, 100, 0),  // насыщенный зелёный
-			"Отказ":                 walk.RGB(80, 0, 0),   // тёмно-красный
-			"В архиве":              walk.RGB(50, 50, 50), // тёмно-серый
-		}
+	if currentTheme.Dark {
+		app.themeToggleButton.SetText("☀ Светлая тема")
 	} else {
-		statusColors = map[string]walk.Color{
-			"Новая": walk.RGB(220, 255, 220), // светло-зелёный
-			"Планирую откликнуться": walk.RGB(255, 255, 200), // светло-жёлтый
-			"Откликнулся":           walk.RGB(210, 240, 255), // светло-голубой
-			"Тестовое задание":      walk.RGB(255, 230, 200), // светло-оранжевый
-			"Собеседование":         walk.RGB(240, 220, 255), // светло-пурпурный
-			"Оффер":                 walk.RGB(180, 255, 180), // ярко-зелёный
-			"Отказ":                 walk.RGB(255, 200, 200), // светло-красный
-			"В архиве":              walk.RGB(220, 220, 220), // серый
-		}
-	}
-
-	// Обновляем отображение таблицы для применения новых цветов статусов
-	if app.vacancyTable != nil {
-		app.vacancyTable.Invalidate()
+		app.themeToggleButton.SetText("🌙 Тёмная тема")
 	}
 }
 
 // ДОБАВЛЕНО: Метод для переключения темы
 func (app *AppMainWindow) toggleTheme() {
-	if currentTheme.Name == "Светлая" {
-		app.applyTheme(darkTheme)
-		app.themeToggleButton.SetText("☀ Светлая тема")
-	} else {
+	if currentTheme.Dark {
 		app.applyTheme(lightTheme)
-		app.themeToggleButton.SetText("🌙 Тёмная тема")
+	} else {
+		app.applyTheme(darkTheme)
 	}
+	app.updateThemeToggleText()
+	appSettings.ThemeName = currentTheme.Name
+	saveSettings()
 }
 
 // ResumeArchiveEntry представляет запись в архиве резюме
@@ -2134,7 +2506,7 @@ func (d *ResumeArchiveDialog) onItemActivated() {
 	entry := d.model.items[idx]
 	cmd := exec.Command("cmd", "/c", "start", entry.FilePath)
 	if err := cmd.Start(); err != nil {
-		walk.MsgBox(d.Dialog, "Ошибка", "Не удалось открыть файл резюме: "+err.Error(), walk.MsgBoxIconError)
+		showError(d.Dialog, "Ошибка", wrapError("Не удалось открыть файл резюме", err))
 	}
 }
 
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"slices"
+
+	"github.com/lxn/walk"
+)
+
+// Ручной порядок вакансий: строки таблицы можно перетаскивать мышью или двигать
+// Alt+стрелками, задавая свой приоритет. Порядок хранится в OrderIndex вакансии и
+// действует, когда включён режим «Ручной порядок» вместо сортировки по колонкам.
+// Вакансии без номера (например, только что добавленные) идут в конце.
+
+// manualSortColumn - ключ сортировки по ручному порядку; колонки в таблице у него нет
+const manualSortColumn = -1
+
+// compareManualOrder сравнивает вакансии по ручному порядку; без номера - в конце
+func compareManualOrder(a, b Vacancy) int {
+	switch {
+	case a.OrderIndex == b.OrderIndex:
+		return 0
+	case a.OrderIndex == 0:
+		return 1
+	case b.OrderIndex == 0:
+		return -1
+	case a.OrderIndex < b.OrderIndex:
+		return -1
+	}
+	return 1
+}
+
+// renumberManualOrder записывает вакансиям номера по порядку order (индексы в vacancies)
+func renumberManualOrder(vacancies []Vacancy, order []int) {
+	for pos, i := range order {
+		vacancies[i].OrderIndex = pos + 1
+	}
+}
+
+// sortedVacancyIndexes - индексы вакансий, упорядоченные по ключам сортировки таблицы
+func sortedVacancyIndexes(vacancies []Vacancy, keys []SortKey, rates ExchangeRates) []int {
+	order := make([]int, len(vacancies))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(i, j int) int {
+		for _, k := range keys {
+			c := compareVacancyColumn(rates, k.Column, vacancies[i], vacancies[j])
+			if k.Descending {
+				c = -c
+			}
+			if c != 0 {
+				return c
+			}
+		}
+		return 0
+	})
+	return order
+}
+
+// manualOrderActive - таблица отсортирована по ручному порядку
+func (app *AppMainWindow) manualOrderActive() bool {
+	keys := app.vacancyModel.sortKeys
+	return len(keys) > 0 && keys[0].Column == manualSortColumn
+}
+
+// updateManualOrderActions отмечает кнопку режима и включает команды перемещения
+func (app *AppMainWindow) updateManualOrderActions() {
+	active := app.manualOrderActive()
+	if app.manualOrderAction != nil {
+		app.manualOrderAction.SetChecked(active)
+	}
+	for _, a := range []*walk.Action{app.moveUpAction, app.moveDownAction} {
+		if a != nil {
+			a.SetEnabled(active)
+		}
+	}
+}
+
+// setManualOrder включает и выключает ручной порядок. При первом включении
+// он заполняется текущей сортировкой таблицы, чтобы строки не перескакивали.
+func (app *AppMainWindow) setManualOrder(on bool) {
+	if on == app.manualOrderActive() {
+		app.updateManualOrderActions()
+		return
+	}
+	keys := defaultSortKeys
+	if on {
+		keys = []SortKey{{Column: manualSortColumn}}
+		allVacanciesMutex.Lock()
+		seed := !slices.ContainsFunc(allVacancies, func(v Vacancy) bool { return v.OrderIndex != 0 })
+		if seed {
+			renumberManualOrder(allVacancies, sortedVacancyIndexes(allVacancies, app.vacancyModel.sortKeys, app.vacancyModel.rates))
+		}
+		allVacanciesMutex.Unlock()
+		if seed {
+			saveVacancies()
+			app.applyVacancyFilters()
+		}
+	}
+	app.vacancyModel.setSortKeys(keys)
+	appSettings.SortKeys = append([]SortKey{}, app.vacancyModel.sortKeys...)
+	app.updateSortHeaders()
+	app.rememberFilterTab()
+	saveSettings()
+}
+
+// toggleManualOrder переключает ручной порядок из палитры команд
+func (app *AppMainWindow) toggleManualOrder() {
+	app.setManualOrder(!app.manualOrderActive())
+}
+
+// moveVacancyTo ставит вакансию moved на место target: ниже неё, если moved стояла выше, и наоборот
+func (app *AppMainWindow) moveVacancyTo(moved, target Vacancy) {
+	allVacanciesMutex.Lock()
+	from := app.findVacancyIndexInAllExt(moved.Title, moved.Company)
+	to := app.findVacancyIndexInAllExt(target.Title, target.Company)
+	if from == -1 || to == -1 || from == to {
+		allVacanciesMutex.Unlock()
+		return
+	}
+	order := sortedVacancyIndexes(allVacancies, []SortKey{{Column: manualSortColumn}}, ExchangeRates{})
+	fromPos, toPos := slices.Index(order, from), slices.Index(order, to)
+	order = slices.Delete(order, fromPos, fromPos+1)
+	order = slices.Insert(order, toPos, from)
+	renumberManualOrder(allVacancies, order)
+	updated := allVacancies[from]
+	allVacanciesMutex.Unlock()
+
+	saveVacancies()
+	vacancyEvents.Publish(vacancyEvent(VacancyUpdated, updated))
+}
+
+// moveSelectedVacancy сдвигает выбранную вакансию на delta строк таблицы
+func (app *AppMainWindow) moveSelectedVacancy(delta int) {
+	if viewOnly || !app.manualOrderActive() {
+		return
+	}
+	idx := app.vacancyTable.CurrentIndex()
+	moved, ok := app.vacancyAt(idx)
+	target, ok2 := app.vacancyAt(idx + delta)
+	if !ok || !ok2 {
+		return
+	}
+	app.moveVacancyTo(moved, target)
+}
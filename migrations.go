@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Текущая версия формата файла вакансий. Увеличивается при каждом
+// несовместимом изменении структуры Vacancy вместе с новой миграцией.
+const currentSchemaVersion = 1
+
+// vacanciesDocument - формат файла вакансий начиная с версии 1
+type vacanciesDocument struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	Vacancies     []json.RawMessage `json:"vacancies"`
+}
+
+// migration переводит записи вакансий с версии N на N+1
+type migration func(items []map[string]json.RawMessage) error
+
+// migrations[i] переводит данные с версии i на i+1
+var migrations = []migration{
+	migrateV0toV1,
+}
+
+// Если файл создан более новой версией приложения или не читается, сохранение запрещается,
+// чтобы не потерять данные, о которых эта версия не знает
+var vacanciesReadOnly bool
+
+// migrateV0toV1: файл был голым массивом; заполняем пустые статус и уровень опыта
+// значениями по умолчанию, как это делает диалог редактирования
+func migrateV0toV1(items []map[string]json.RawMessage) error {
+	defaults := map[string]string{
+		"status":          possibleStatuses[0],
+		"experienceLevel": possibleExperienceLevels[0],
+	}
+	for _, item := range items {
+		for field, value := range defaults {
+			var current string
+			if raw, ok := item[field]; ok {
+				if err := json.Unmarshal(raw, &current); err != nil {
+					return fmt.Errorf("поле %s: %w", field, err)
+				}
+			}
+			if current == "" {
+				encoded, _ := json.Marshal(value)
+				item[field] = encoded
+			}
+		}
+	}
+	return nil
+}
+
+// decodeVacanciesFile разбирает содержимое файла вакансий любой известной версии,
+// применяя миграции до текущей. Возвращает исходную версию файла.
+func decodeVacanciesFile(data []byte) ([]Vacancy, int, error) {
+	version := 0
+	var rawItems []json.RawMessage
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &rawItems); err != nil {
+			return nil, 0, err
+		}
+	} else {
+		var doc vacanciesDocument
+		if err := json.Unmarshal(trimmed, &doc); err != nil {
+			return nil, 0, err
+		}
+		version = doc.SchemaVersion
+		rawItems = doc.Vacancies
+	}
+
+	if version > currentSchemaVersion {
+		return nil, version, fmt.Errorf("файл создан более новой версией приложения (формат %d, поддерживается до %d)", version, currentSchemaVersion)
+	}
+
+	if version < currentSchemaVersion {
+		items := make([]map[string]json.RawMessage, len(rawItems))
+		for i, raw := range rawItems {
+			if err := json.Unmarshal(raw, &items[i]); err != nil {
+				return nil, version, fmt.Errorf("запись %d: %w", i+1, err)
+			}
+		}
+		for v := version; v < currentSchemaVersion; v++ {
+			if err := migrations[v](items); err != nil {
+				return nil, version, fmt.Errorf("миграция с версии %d на %d: %w", v, v+1, err)
+			}
+		}
+		for i, item := range items {
+			encoded, err := json.Marshal(item)
+			if err != nil {
+				return nil, version, err
+			}
+			rawItems[i] = encoded
+		}
+	}
+
+	vacancies := make([]Vacancy, len(rawItems))
+	for i, raw := range rawItems {
+		if err := json.Unmarshal(raw, &vacancies[i]); err != nil {
+			return nil, version, fmt.Errorf("запись %d: %w", i+1, err)
+		}
+		if len(vacancies[i].Extra) > 0 {
+			names := make([]string, 0, len(vacancies[i].Extra))
+			for name := range vacancies[i].Extra {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			log.Printf("Вакансия '%s' содержит неизвестные поля (%s), они будут сохранены без изменений", vacancies[i].Title, strings.Join(names, ", "))
+		}
+	}
+	return vacancies, version, nil
+}
+
+// encodeVacanciesFile сериализует вакансии в формат текущей версии
+func encodeVacanciesFile(vacancies []Vacancy) ([]byte, error) {
+	doc := vacanciesDocument{SchemaVersion: currentSchemaVersion, Vacancies: make([]json.RawMessage, len(vacancies))}
+	for i, v := range vacancies {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		doc.Vacancies[i] = data
+	}
+	return json.MarshalIndent(doc, "", "  ")
+}
+
+// backupBeforeMigration сохраняет копию файла в старом формате перед первой записью в новом
+func backupBeforeMigration(path string, data []byte, version int) {
+	backup := fmt.Sprintf("%s.v%d.bak", path, version)
+	if _, err := os.Stat(backup); err == nil {
+		return
+	}
+	if err := os.WriteFile(backup, data, 0644); err != nil {
+		log.Printf("Не удалось сохранить копию %s перед миграцией: %v", backup, err)
+		return
+	}
+	log.Printf("Файл %s переведён с формата %d на %d, исходная копия: %s", path, version, currentSchemaVersion, backup)
+}
+
+// vacancyJSON - Vacancy без собственных методов сериализации
+type vacancyJSON Vacancy
+
+var (
+	knownVacancyFieldsOnce sync.Once
+	knownVacancyFields     map[string]bool
+)
+
+// vacancyFieldNames возвращает JSON-имена всех полей Vacancy
+func vacancyFieldNames() map[string]bool {
+	knownVacancyFieldsOnce.Do(func() {
+		knownVacancyFields = map[string]bool{}
+		t := reflect.TypeOf(Vacancy{})
+		for i := 0; i < t.NumField(); i++ {
+			name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+			if name != "" && name != "-" {
+				knownVacancyFields[name] = true
+			}
+		}
+	})
+	return knownVacancyFields
+}
+
+// UnmarshalJSON сохраняет незнакомые поля в Extra, чтобы они не терялись при перезаписи файла
+func (v *Vacancy) UnmarshalJSON(data []byte) error {
+	var plain vacancyJSON
+	if err := json.Unmarshal(data, &plain); err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	known := vacancyFieldNames()
+	for name := range raw {
+		if known[name] {
+			delete(raw, name)
+		}
+	}
+	*v = Vacancy(plain)
+	v.Extra = nil
+	if len(raw) > 0 {
+		v.Extra = raw
+	}
+	return nil
+}
+
+// MarshalJSON записывает известные поля и возвращает на место сохранённые незнакомые
+func (v Vacancy) MarshalJSON() ([]byte, error) {
+	data, err := json.Marshal(vacancyJSON(v))
+	if err != nil || len(v.Extra) == 0 {
+		return data, err
+	}
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	for name, value := range v.Extra {
+		if _, ok := merged[name]; !ok {
+			merged[name] = value
+		}
+	}
+	return json.Marshal(merged)
+}
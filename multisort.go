@@ -0,0 +1,133 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/lxn/walk"
+	"github.com/lxn/win"
+)
+
+// SortKey - колонка сортировки таблицы вакансий; первая в списке - основная
+type SortKey struct {
+	Column     int  `json:"column"`
+	Descending bool `json:"descending,omitempty"`
+}
+
+// Сортировка по умолчанию - по названию
+var defaultSortKeys = []SortKey{{Column: 0}}
+
+// vacancyColumnTitles - заголовки колонок таблицы вакансий без пометок сортировки
+func vacancyColumnTitles() []string {
+	return []string{"Название", "Компания", "Статус", salaryColumnTitle()}
+}
+
+// compareVacancyColumn сравнивает вакансии по значению колонки
+func compareVacancyColumn(rates ExchangeRates, col int, a, b Vacancy) int {
+	switch col {
+	case manualSortColumn:
+		return compareManualOrder(a, b)
+	case 1:
+		return strings.Compare(strings.ToLower(a.Company), strings.ToLower(b.Company))
+	case 2:
+		return strings.Compare(strings.ToLower(a.Status), strings.ToLower(b.Status))
+	case salaryColumn:
+		return cmp.Compare(salarySortValue(rates, a), salarySortValue(rates, b))
+	}
+	return strings.Compare(strings.ToLower(a.Title), strings.ToLower(b.Title))
+}
+
+// shiftPressed - нажат ли Shift: щелчок по заголовку с Shift добавляет колонку к сортировке
+func shiftPressed() bool {
+	return win.GetKeyState(win.VK_SHIFT) < 0
+}
+
+// nextSortKeys - ключи сортировки после щелчка по заголовку col с порядком order, который выбрал walk.
+// Без Shift колонка становится единственным ключом; с Shift - добавляется к ключам,
+// а если она уже есть, порядок по ней меняется на обратный. Ручной порядок с колонками не складывается.
+func nextSortKeys(keys []SortKey, col int, order walk.SortOrder, multi bool) []SortKey {
+	key := SortKey{Column: col, Descending: order == walk.SortDescending}
+	if len(keys) > 0 && keys[0] == key {
+		return keys // Пересортировка после изменения данных
+	}
+	if !multi || len(keys) == 0 || keys[0].Column == manualSortColumn {
+		return []SortKey{key}
+	}
+	next := append([]SortKey{}, keys...)
+	for i := range next {
+		if next[i].Column == col {
+			if i == 0 {
+				next[0] = key
+			} else {
+				next[i].Descending = !next[i].Descending
+			}
+			return next
+		}
+	}
+	return append(next, SortKey{Column: col})
+}
+
+// validSortKeys отбрасывает ключи с несуществующими и повторяющимися колонками;
+// ручной порядок допустим только как единственный ключ
+func validSortKeys(keys []SortKey) []SortKey {
+	if len(keys) > 0 && keys[0].Column == manualSortColumn {
+		return []SortKey{{Column: manualSortColumn}}
+	}
+	var valid []SortKey
+	seen := map[int]bool{}
+	for _, k := range keys {
+		if k.Column >= 0 && k.Column < len(vacancyColumnTitles()) && !seen[k.Column] {
+			seen[k.Column] = true
+			valid = append(valid, k)
+		}
+	}
+	if len(valid) == 0 {
+		return defaultSortKeys
+	}
+	return valid
+}
+
+// setSortKeys сортирует таблицу по сохранённым ключам
+func (m *VacancyModel) setSortKeys(keys []SortKey) {
+	m.sortKeys = validSortKeys(keys)
+	order := walk.SortAscending
+	if m.sortKeys[0].Descending {
+		order = walk.SortDescending
+	}
+	m.Sort(m.sortKeys[0].Column, order)
+}
+
+// updateSortHeaders помечает в заголовках порядок колонок, когда сортировка идёт по нескольким
+func (app *AppMainWindow) updateSortHeaders() {
+	if app.vacancyTable == nil {
+		return
+	}
+	app.updateManualOrderActions()
+	titles := vacancyColumnTitles()
+	keys := app.vacancyModel.sortKeys
+	if len(keys) > 1 {
+		for rank, k := range keys {
+			arrow := "▲"
+			if k.Descending {
+				arrow = "▼"
+			}
+			titles[k.Column] += fmt.Sprintf(" %s%d", arrow, rank+1)
+		}
+	}
+	cols := app.vacancyTable.Columns()
+	for i := 0; i < cols.Len() && i < len(titles); i++ {
+		cols.At(i).SetTitle(titles[i])
+	}
+}
+
+// restoreVacancySort восстанавливает сортировку прошлого сеанса и сохраняет её при щелчках по заголовкам
+func (app *AppMainWindow) restoreVacancySort() {
+	app.vacancyModel.setSortKeys(appSettings.SortKeys)
+	app.updateSortHeaders()
+	app.vacancyTable.ColumnClicked().Attach(func(col int) {
+		appSettings.SortKeys = append([]SortKey{}, app.vacancyModel.sortKeys...)
+		saveSettings()
+		app.updateSortHeaders()
+	})
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"github.com/lxn/walk"
+)
+
+const (
+	maxNavHistory   = 100 // Сколько переходов помнить для "Назад"/"Вперёд"
+	maxRecentViewed = 15  // Сколько вакансий показывать в меню "Недавние"
+)
+
+// viewedVacancy - вакансия в истории просмотра; ищется по названию и компании, как связанные вакансии
+type viewedVacancy struct {
+	Title   string
+	Company string
+}
+
+// navHistory - история просмотра вакансий за текущий сеанс
+type navHistory struct {
+	items      []viewedVacancy
+	pos        int  // Текущая позиция в items
+	navigating bool // Выделение меняется кнопками "Назад"/"Вперёд", в историю не пишем
+}
+
+// visit добавляет просмотр; всё, что было "впереди", отбрасывается, как в браузере
+func (h *navHistory) visit(v viewedVacancy) {
+	if len(h.items) > 0 && sameVacancy(h.items[h.pos].Title, h.items[h.pos].Company, v.Title, v.Company) {
+		return
+	}
+	if len(h.items) > 0 {
+		h.items = h.items[:h.pos+1]
+	}
+	h.items = append(h.items, v)
+	if len(h.items) > maxNavHistory {
+		h.items = h.items[len(h.items)-maxNavHistory:]
+	}
+	h.pos = len(h.items) - 1
+}
+
+func (h *navHistory) canBack() bool    { return h.pos > 0 }
+func (h *navHistory) canForward() bool { return h.pos < len(h.items)-1 }
+
+// recent возвращает недавно просмотренные вакансии без повторов, начиная с последней
+func (h *navHistory) recent() []viewedVacancy {
+	var list []viewedVacancy
+	for i := len(h.items) - 1; i >= 0 && len(list) < maxRecentViewed; i-- {
+		seen := false
+		for _, r := range list {
+			if sameVacancy(r.Title, r.Company, h.items[i].Title, h.items[i].Company) {
+				seen = true
+				break
+			}
+		}
+		if !seen {
+			list = append(list, h.items[i])
+		}
+	}
+	return list
+}
+
+// recordViewed запоминает показанную в деталях вакансию
+func (app *AppMainWindow) recordViewed(v Vacancy) {
+	if app.history.navigating {
+		return
+	}
+	app.history.visit(viewedVacancy{Title: v.Title, Company: v.Company})
+	app.updateHistoryActions()
+}
+
+// navigateHistory переходит на step шагов назад (-1) или вперёд (1).
+// Удалённые с тех пор вакансии пропускаются.
+func (app *AppMainWindow) navigateHistory(step int) {
+	h := &app.history
+	for pos := h.pos + step; pos >= 0 && pos < len(h.items); pos += step {
+		target := h.items[pos]
+		h.navigating = true
+		found := app.selectVacancy(target.Title, target.Company)
+		h.navigating = false
+		if found {
+			h.pos = pos
+			break
+		}
+		// Вакансии больше нет - убираем её из истории
+		h.items = append(h.items[:pos], h.items[pos+1:]...)
+		if pos < h.pos {
+			h.pos--
+		}
+		if step > 0 {
+			pos -= step
+		}
+	}
+	app.updateHistoryActions()
+}
+
+func (app *AppMainWindow) navigateBack()    { app.navigateHistory(-1) }
+func (app *AppMainWindow) navigateForward() { app.navigateHistory(1) }
+
+// openRecentVacancy выделяет вакансию из меню "Недавние"
+func (app *AppMainWindow) openRecentVacancy(v viewedVacancy) {
+	if !app.selectVacancy(v.Title, v.Company) {
+		walk.MsgBox(app.MainWindow, "Недавние", "Вакансия '"+v.Title+"' не найдена в списке.", walk.MsgBoxIconWarning)
+	}
+}
+
+// updateHistoryActions включает кнопки "Назад"/"Вперёд" и пересобирает меню "Недавние"
+func (app *AppMainWindow) updateHistoryActions() {
+	if app.backAction != nil {
+		app.backAction.SetEnabled(app.history.canBack())
+	}
+	if app.forwardAction != nil {
+		app.forwardAction.SetEnabled(app.history.canForward())
+	}
+	if app.backPB != nil {
+		app.backPB.SetEnabled(app.history.canBack())
+	}
+	if app.forwardPB != nil {
+		app.forwardPB.SetEnabled(app.history.canForward())
+	}
+
+	if app.recentMenu == nil {
+		return
+	}
+	app.recentMenu.Actions().Clear()
+	recent := app.history.recent()
+	if len(recent) == 0 {
+		empty := walk.NewAction()
+		empty.SetText("(пусто)")
+		empty.SetEnabled(false)
+		app.recentMenu.Actions().Add(empty)
+		return
+	}
+	for _, v := range recent {
+		text := v.Title
+		if v.Company != "" {
+			text += " — " + v.Company
+		}
+		a := walk.NewAction()
+		a.SetText(text)
+		a.Triggered().Attach(func() { app.openRecentVacancy(v) })
+		app.recentMenu.Actions().Add(a)
+	}
+}
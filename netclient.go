@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Повторы запросов к провайдерам вакансий
+const (
+	maxRequestRetries = 3                // Сколько раз повторять запрос после первой неудачи
+	retryBaseDelay    = time.Second      // Пауза перед первым повтором, дальше удваивается
+	retryMaxDelay     = 20 * time.Second // Дольше этого между повторами не ждём
+)
+
+// Кэш ответов онлайн-поиска
+const (
+	searchCacheFile              = "search_cache.json"
+	defaultSearchCacheTTLMinutes = 30
+	maxSearchCacheEntries        = 200
+	searchCacheTTLMaxMinutes     = 7 * 24 * 60
+)
+
+// rateLimiter пропускает запросы не чаще одного за interval
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// Wait ждёт своей очереди на запрос или отмены контекста
+func (l *rateLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	now := time.Now()
+	var wait time.Duration
+	if l.next.After(now) {
+		wait = l.next.Sub(now)
+		l.next = l.next.Add(l.interval)
+	} else {
+		l.next = now.Add(l.interval)
+	}
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// searchProvider - сайт или API с вакансиями и его ограничения
+type searchProvider struct {
+	Name    string
+	limiter *rateLimiter
+}
+
+// Провайдеры онлайн-поиска
+var joobleProvider = &searchProvider{Name: "jooble", limiter: &rateLimiter{interval: 2 * time.Second}}
+
+// transientStatus - ответы сервера, после которых имеет смысл повторить запрос
+func transientStatus(code int) bool {
+	switch code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryDelay - пауза перед повтором номер attempt (с нуля) со случайным разбросом до 20%
+func retryDelay(attempt int) time.Duration {
+	d := retryBaseDelay << attempt
+	if d > retryMaxDelay {
+		d = retryMaxDelay
+	}
+	return d + time.Duration(rand.Int64N(int64(d)/5+1))
+}
+
+// retryAfter разбирает заголовок Retry-After (секунды или дата)
+func retryAfter(value string) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(value); err == nil && secs > 0 {
+		return min(time.Duration(secs)*time.Second, retryMaxDelay)
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return min(max(time.Until(at), 0), retryMaxDelay)
+	}
+	return 0
+}
+
+// sleepContext ждёт d или отмены контекста
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// fetchWithRetry выполняет запрос к провайдеру с учётом его ограничения частоты и повторяет его
+// с нарастающей паузой при сетевых ошибках, 429 и 5xx. newRequest вызывается на каждую попытку,
+// потому что тело запроса нельзя прочитать дважды. Возвращает тело и код последнего ответа.
+func fetchWithRetry(ctx context.Context, p *searchProvider, newRequest func() (*http.Request, error)) ([]byte, int, error) {
+	for attempt := 0; ; attempt++ {
+		if err := p.limiter.Wait(ctx); err != nil {
+			return nil, 0, err
+		}
+		req, err := newRequest()
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var delay time.Duration
+		var failure error
+		resp, err := httpClient().Do(req)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, 0, ctx.Err()
+			}
+			// Без сети повторы только затянут ожидание
+			if attempt >= maxRequestRetries || isOfflineError(err) {
+				return nil, 0, err
+			}
+			failure = err
+		} else {
+			body, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if (readErr == nil && !transientStatus(resp.StatusCode)) || attempt >= maxRequestRetries {
+				return body, resp.StatusCode, readErr
+			}
+			failure = readErr
+			if failure == nil {
+				failure = errors.New(resp.Status)
+				delay = retryAfter(resp.Header.Get("Retry-After"))
+			}
+		}
+
+		if delay == 0 {
+			delay = retryDelay(attempt)
+		}
+		log.Printf("%s: попытка %d не удалась (%v), повтор через %s", p.Name, attempt+1, failure, delay.Round(100*time.Millisecond))
+		if err := sleepContext(ctx, delay); err != nil {
+			return nil, 0, err
+		}
+	}
+}
+
+// cachedResponse - сохранённый ответ провайдера (JSON)
+type cachedResponse struct {
+	At   time.Time       `json:"at"`
+	Body json.RawMessage `json:"body"`
+}
+
+var (
+	searchCache      map[string]cachedResponse // Загружается из файла при первом обращении
+	searchCacheMutex sync.Mutex
+)
+
+// searchCacheTTL - сколько хранить ответы, из настроек
+func searchCacheTTL() time.Duration {
+	return time.Duration(appSettings.SearchCacheTTLMinutes) * time.Minute
+}
+
+// searchCacheKey составляет ключ кэша из провайдера и параметров запроса
+func searchCacheKey(p *searchProvider, params ...string) string {
+	parts := []string{p.Name}
+	for _, param := range params {
+		parts = append(parts, strings.ToLower(strings.Join(strings.Fields(param), " ")))
+	}
+	return strings.Join(parts, "|")
+}
+
+// loadSearchCacheLocked читает кэш из файла. Вызывается при заблокированном searchCacheMutex.
+func loadSearchCacheLocked() {
+	if searchCache != nil {
+		return
+	}
+	searchCache = map[string]cachedResponse{}
+	data, err := os.ReadFile(dataPath(searchCacheFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Ошибка чтения файла %s: %v", searchCacheFile, err)
+		}
+		return
+	}
+	if err := json.Unmarshal(data, &searchCache); err != nil {
+		log.Printf("Ошибка декодирования JSON из файла %s: %v", searchCacheFile, err)
+		searchCache = map[string]cachedResponse{}
+	}
+}
+
+// saveSearchCacheLocked убирает устаревшие ответы и записывает кэш в файл.
+// Вызывается при заблокированном searchCacheMutex.
+func saveSearchCacheLocked() {
+	ttl := searchCacheTTL()
+	for key, entry := range searchCache {
+		if time.Since(entry.At) > ttl {
+			delete(searchCache, key)
+		}
+	}
+	// Если записей слишком много, выбрасываем самые старые
+	for len(searchCache) > maxSearchCacheEntries {
+		oldestKey, oldest := "", time.Now()
+		for key, entry := range searchCache {
+			if entry.At.Before(oldest) {
+				oldestKey, oldest = key, entry.At
+			}
+		}
+		delete(searchCache, oldestKey)
+	}
+
+	data, err := json.Marshal(searchCache)
+	if err != nil {
+		log.Printf("Ошибка кодирования кэша поиска: %v", err)
+		return
+	}
+	if err := os.WriteFile(dataPath(searchCacheFile), data, 0644); err != nil {
+		log.Printf("Ошибка записи файла %s: %v", searchCacheFile, err)
+	}
+}
+
+// cachedSearchResponse возвращает сохранённый ответ, если он ещё не устарел
+func cachedSearchResponse(key string) ([]byte, time.Time, bool) {
+	ttl := searchCacheTTL()
+	if ttl <= 0 {
+		return nil, time.Time{}, false
+	}
+	searchCacheMutex.Lock()
+	defer searchCacheMutex.Unlock()
+	loadSearchCacheLocked()
+	entry, ok := searchCache[key]
+	if !ok || time.Since(entry.At) > ttl {
+		return nil, time.Time{}, false
+	}
+	return entry.Body, entry.At, true
+}
+
+// storeSearchResponse сохраняет успешный ответ провайдера в кэш
+func storeSearchResponse(key string, body []byte) {
+	if searchCacheTTL() <= 0 || !json.Valid(body) {
+		return
+	}
+	searchCacheMutex.Lock()
+	defer searchCacheMutex.Unlock()
+	loadSearchCacheLocked()
+	searchCache[key] = cachedResponse{At: time.Now(), Body: append(json.RawMessage(nil), body...)}
+	saveSearchCacheLocked()
+}
+
+// clearSearchCache удаляет все сохранённые ответы
+func clearSearchCache() error {
+	searchCacheMutex.Lock()
+	defer searchCacheMutex.Unlock()
+	searchCache = map[string]cachedResponse{}
+	if err := os.Remove(dataPath(searchCacheFile)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// showOnlineSearchSettings задаёт срок хранения результатов онлайн-поиска
+func (app *AppMainWindow) showOnlineSearchSettings() {
+	var dlg *walk.Dialog
+	var ttlNE *walk.NumberEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Настройки онлайн-поиска",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 400, Height: 190},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Сколько минут хранить результаты онлайн-поиска\n(0 - не кэшировать):",
+				TextColor: currentTheme.Text,
+				Font:      Font{Bold: true, PointSize: 9},
+			},
+			NumberEdit{
+				AssignTo:           &ttlNE,
+				Value:              float64(appSettings.SearchCacheTTLMinutes),
+				MinValue:           0,
+				MaxValue:           searchCacheTTLMaxMinutes,
+				SpinButtonsVisible: true,
+				Font:               Font{PointSize: 9},
+			},
+			Label{
+				Text:      fmt.Sprintf("Повторные запросы к Jooble отправляются не чаще раза в %s,\nпри сбоях сети запрос повторяется до %d раз.", joobleProvider.limiter.interval, maxRequestRetries),
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Очистить кэш",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if err := clearSearchCache(); err != nil {
+								showError(dlg, "Ошибка", wrapError("Не удалось очистить кэш", err))
+								return
+							}
+							app.notify("Сохранённые результаты онлайн-поиска удалены.")
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							appSettings.SearchCacheTTLMinutes = int(ttlNE.Value())
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
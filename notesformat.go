@@ -0,0 +1,380 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+	"unsafe"
+
+	"github.com/lxn/win"
+)
+
+// Заметки хранятся в упрощённом Markdown: **жирный**, *курсив* и строки-пункты "- ".
+// В файле и в экспорте они остаются читаемым текстом, а в панели деталей показываются с оформлением.
+
+// noteRun - фрагмент строки заметки с одинаковым оформлением
+type noteRun struct {
+	Text   string
+	Bold   bool
+	Italic bool
+}
+
+// noteLine - абзац заметки
+type noteLine struct {
+	Bullet bool
+	Runs   []noteRun
+}
+
+const noteBulletPrefix = "- "
+
+// appendNoteRun добавляет текст к строке, склеивая его с предыдущим фрагментом того же оформления
+func appendNoteRun(runs []noteRun, text string, bold, italic bool) []noteRun {
+	if text == "" {
+		return runs
+	}
+	if n := len(runs); n > 0 && runs[n-1].Bold == bold && runs[n-1].Italic == italic {
+		runs[n-1].Text += text
+		return runs
+	}
+	return append(runs, noteRun{Text: text, Bold: bold, Italic: italic})
+}
+
+// isNoteEscapable - символы, которые экранируются обратным слешем. Остальные слеши
+// (например, в путях к файлам) остаются как есть.
+func isNoteEscapable(r rune) bool {
+	return r == '\\' || r == '*' || r == '-'
+}
+
+// parseNoteMarkdown разбирает заметку на абзацы. Как в Markdown, открывающие звёздочки стоят
+// перед непробельным символом, закрывающие - после него, а звёздочки без пары остаются
+// обычным текстом, поэтому старые заметки без разметки читаются как прежде.
+func parseNoteMarkdown(md string) []noteLine {
+	md = strings.ReplaceAll(md, "\r\n", "\n")
+	var lines []noteLine
+	for _, raw := range strings.Split(md, "\n") {
+		var line noteLine
+		if strings.HasPrefix(raw, noteBulletPrefix) {
+			line.Bullet = true
+			raw = raw[len(noteBulletPrefix):]
+		}
+		rs := []rune(raw)
+		bold, italic := false, false
+		var prev rune // Последний символ текста перед звёздочками
+		var buf strings.Builder
+		flush := func() {
+			line.Runs = appendNoteRun(line.Runs, buf.String(), bold, italic)
+			buf.Reset()
+		}
+		for i := 0; i < len(rs); i++ {
+			if rs[i] == '\\' && i+1 < len(rs) && isNoteEscapable(rs[i+1]) {
+				i++
+				buf.WriteRune(rs[i])
+				prev = rs[i]
+				continue
+			}
+			if rs[i] != '*' {
+				buf.WriteRune(rs[i])
+				prev = rs[i]
+				continue
+			}
+			n := 1
+			for i+n < len(rs) && rs[i+n] == '*' {
+				n++
+			}
+			rest := string(rs[i+n:])
+			canClose := prev != 0 && !unicode.IsSpace(prev)
+			canOpen := rest != "" && !unicode.IsSpace(rs[i+n])
+			i += n - 1
+			flush()
+			// Сначала закрываем: нечётное число звёздочек закрывает курсив, пара - жирный
+			if canClose && n%2 == 1 && italic {
+				italic = false
+				n--
+			}
+			if canClose && n >= 2 && bold {
+				bold = false
+				n -= 2
+			}
+			// Открываем, только если дальше в строке есть закрывающая пара
+			if canOpen && n >= 2 && !bold && strings.Contains(rest, "**") {
+				bold = true
+				n -= 2
+			}
+			if canOpen && n >= 1 && !italic && strings.Contains(rest, "*") {
+				italic = true
+				n--
+			}
+			if n > 0 {
+				buf.WriteString(strings.Repeat("*", n))
+				prev = '*'
+			}
+		}
+		flush()
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// renderNoteMarkdown собирает текст заметки; escape экранирует все служебные символы
+func renderNoteMarkdown(lines []noteLine, escape bool) string {
+	escaper := strings.NewReplacer(`\`, `\\`, `*`, `\*`)
+	out := make([]string, len(lines))
+	for i, line := range lines {
+		var sb strings.Builder
+		if line.Bullet {
+			sb.WriteString(noteBulletPrefix)
+		}
+		bold, italic := false, false
+		for j, run := range line.Runs {
+			if bold && !run.Bold {
+				sb.WriteString("**")
+			}
+			if italic && !run.Italic {
+				sb.WriteString("*")
+			}
+			if !bold && run.Bold {
+				sb.WriteString("**")
+			}
+			if !italic && run.Italic {
+				sb.WriteString("*")
+			}
+			bold, italic = run.Bold, run.Italic
+			text := run.Text
+			if escape {
+				text = escaper.Replace(text)
+				if j == 0 && !line.Bullet && strings.HasPrefix(text, noteBulletPrefix) {
+					text = `\` + text
+				}
+			}
+			sb.WriteString(text)
+		}
+		if bold {
+			sb.WriteString("**")
+		}
+		if italic {
+			sb.WriteString("*")
+		}
+		out[i] = sb.String()
+	}
+	return strings.Join(out, "\r\n")
+}
+
+// trimFormattedSpaces выносит пробелы по краям оформленных фрагментов в обычный текст:
+// "** слово**" в Markdown не читается как жирный
+func trimFormattedSpaces(lines []noteLine) []noteLine {
+	out := make([]noteLine, len(lines))
+	for i, line := range lines {
+		out[i].Bullet = line.Bullet
+		for _, run := range line.Runs {
+			if !run.Bold && !run.Italic {
+				out[i].Runs = appendNoteRun(out[i].Runs, run.Text, false, false)
+				continue
+			}
+			core := strings.TrimLeftFunc(run.Text, unicode.IsSpace)
+			lead := run.Text[:len(run.Text)-len(core)]
+			trimmed := strings.TrimRightFunc(core, unicode.IsSpace)
+			out[i].Runs = appendNoteRun(out[i].Runs, lead, false, false)
+			out[i].Runs = appendNoteRun(out[i].Runs, trimmed, run.Bold, run.Italic)
+			out[i].Runs = appendNoteRun(out[i].Runs, core[len(trimmed):], false, false)
+		}
+	}
+	return out
+}
+
+// formatNoteMarkdown превращает абзацы в Markdown. Служебные символы экранируются,
+// только если без этого текст прочитался бы иначе: обычные заметки не обрастают обратными слешами.
+func formatNoteMarkdown(lines []noteLine) string {
+	lines = trimFormattedSpaces(lines)
+	exact := renderNoteMarkdown(lines, true)
+	if plain := renderNoteMarkdown(lines, false); renderNoteMarkdown(parseNoteMarkdown(plain), true) == exact {
+		return plain
+	}
+	return exact
+}
+
+// Сообщения RichEdit для оформления, которых нет в lxn/win
+const (
+	emGetParaFormat = win.WM_USER + 61
+	emSetParaFormat = win.WM_USER + 71
+	emGetCharFormat = win.WM_USER + 58
+
+	cfmBold   = 0x00000001
+	cfmItalic = 0x00000002
+	cfeBold   = 0x00000001
+	cfeItalic = 0x00000002
+
+	pfmOffset    = 0x00000004
+	pfmNumbering = 0x00000020
+	pfnBullet    = 1
+
+	bulletOffsetTwips = 360
+)
+
+// withSelection выполняет f и возвращает выделение и прокрутку пользователя как было
+func (re *RichTextEdit) withSelection(f func()) {
+	var selection charRange
+	re.SendMessage(emExGetSel, 0, uintptr(unsafe.Pointer(&selection)))
+	re.SendMessage(emHideSelection, 1, 0)
+	re.SetSuspended(true)
+	defer func() {
+		re.SendMessage(emExSetSel, 0, uintptr(unsafe.Pointer(&selection)))
+		re.SendMessage(emHideSelection, 0, 0)
+		re.SetSuspended(false)
+		re.Invalidate()
+	}()
+	f()
+}
+
+func (re *RichTextEdit) selectRange(from, to int32) {
+	r := charRange{from, to}
+	re.SendMessage(emExSetSel, 0, uintptr(unsafe.Pointer(&r)))
+}
+
+// selectionCharFormat - оформление выделения; для смешанного выделения бит в dwMask сброшен
+func (re *RichTextEdit) selectionCharFormat() charFormat2 {
+	cf := charFormat2{dwMask: cfmBold | cfmItalic}
+	cf.cbSize = uint32(unsafe.Sizeof(cf))
+	re.SendMessage(emGetCharFormat, scfSelection, uintptr(unsafe.Pointer(&cf)))
+	return cf
+}
+
+func (re *RichTextEdit) setSelectionEffects(mask, effects uint32) {
+	cf := charFormat2{dwMask: mask, dwEffects: effects}
+	cf.cbSize = uint32(unsafe.Sizeof(cf))
+	re.SendMessage(emSetCharFormat, scfSelection, uintptr(unsafe.Pointer(&cf)))
+}
+
+func (re *RichTextEdit) selectionBullet() bool {
+	pf := win.PARAFORMAT{DwMask: pfmNumbering}
+	pf.CbSize = uint32(unsafe.Sizeof(pf))
+	re.SendMessage(emGetParaFormat, 0, uintptr(unsafe.Pointer(&pf)))
+	return pf.WNumbering == pfnBullet
+}
+
+func (re *RichTextEdit) setSelectionBullet(bullet bool) {
+	pf := win.PARAFORMAT{DwMask: pfmNumbering | pfmOffset}
+	pf.CbSize = uint32(unsafe.Sizeof(pf))
+	if bullet {
+		pf.WNumbering = pfnBullet
+		pf.DxOffset = bulletOffsetTwips
+	}
+	re.SendMessage(emSetParaFormat, 0, uintptr(unsafe.Pointer(&pf)))
+}
+
+// utf16Len - длина строки в позициях RichEdit
+func utf16Len(s string) int32 {
+	n := int32(0)
+	for _, r := range s {
+		n++
+		if r > 0xFFFF {
+			n++
+		}
+	}
+	return n
+}
+
+// SetMarkdown показывает заметку с оформлением
+func (re *RichTextEdit) SetMarkdown(md string) {
+	lines := parseNoteMarkdown(md)
+	plain := make([]string, len(lines))
+	for i, line := range lines {
+		for _, run := range line.Runs {
+			plain[i] += run.Text
+		}
+	}
+	re.SetText(strings.Join(plain, "\r\n"))
+
+	re.withSelection(func() {
+		re.selectRange(0, -1)
+		re.setSelectionEffects(cfmBold|cfmItalic, 0)
+		re.setSelectionBullet(false)
+		pos := int32(0)
+		for _, line := range lines {
+			if line.Bullet {
+				re.selectRange(pos, pos)
+				re.setSelectionBullet(true)
+			}
+			for _, run := range line.Runs {
+				end := pos + utf16Len(run.Text)
+				if run.Bold || run.Italic {
+					var effects uint32
+					if run.Bold {
+						effects |= cfeBold
+					}
+					if run.Italic {
+						effects |= cfeItalic
+					}
+					re.selectRange(pos, end)
+					re.setSelectionEffects(cfmBold|cfmItalic, effects)
+				}
+				pos = end
+			}
+			pos++ // Перевод строки
+		}
+	})
+}
+
+// Markdown возвращает заметку с оформлением в виде Markdown
+func (re *RichTextEdit) Markdown() string {
+	text := re.Text()
+	if text == "" {
+		return ""
+	}
+	rawLines := strings.Split(text, "\r\n")
+	lines := make([]noteLine, len(rawLines))
+	re.withSelection(func() {
+		re.selectRange(0, -1)
+		all := re.selectionCharFormat()
+		// Весь текст без жирного и курсива - посимвольно не проверяем
+		uniform := all.dwMask&(cfmBold|cfmItalic) == cfmBold|cfmItalic && all.dwEffects&(cfeBold|cfeItalic) == 0
+
+		pos := int32(0)
+		for i, raw := range rawLines {
+			re.selectRange(pos, pos)
+			lines[i].Bullet = re.selectionBullet()
+			if uniform {
+				lines[i].Runs = appendNoteRun(nil, raw, false, false)
+				pos += utf16Len(raw) + 1
+				continue
+			}
+			for _, r := range raw {
+				width := utf16Len(string(r))
+				re.selectRange(pos, pos+width)
+				cf := re.selectionCharFormat()
+				lines[i].Runs = appendNoteRun(lines[i].Runs, string(r), cf.dwEffects&cfeBold != 0, cf.dwEffects&cfeItalic != 0)
+				pos += width
+			}
+			pos++
+		}
+	})
+	return formatNoteMarkdown(lines)
+}
+
+// ToggleBold включает или снимает жирный шрифт у выделения
+func (re *RichTextEdit) ToggleBold() {
+	cf := re.selectionCharFormat()
+	on := cf.dwMask&cfmBold == 0 || cf.dwEffects&cfeBold == 0
+	var effects uint32
+	if on {
+		effects = cfeBold
+	}
+	re.setSelectionEffects(cfmBold, effects)
+	re.SetFocus()
+}
+
+// ToggleItalic включает или снимает курсив у выделения
+func (re *RichTextEdit) ToggleItalic() {
+	cf := re.selectionCharFormat()
+	on := cf.dwMask&cfmItalic == 0 || cf.dwEffects&cfeItalic == 0
+	var effects uint32
+	if on {
+		effects = cfeItalic
+	}
+	re.setSelectionEffects(cfmItalic, effects)
+	re.SetFocus()
+}
+
+// ToggleBullets превращает выделенные абзацы в маркированный список или обратно
+func (re *RichTextEdit) ToggleBullets() {
+	re.setSelectionBullet(!re.selectionBullet())
+	re.SetFocus()
+}
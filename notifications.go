@@ -0,0 +1,187 @@
+package main
+
+import (
+	"log"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// notification - запись в истории уведомлений
+type notification struct {
+	At   time.Time
+	Text string
+}
+
+// notificationHistoryLimit - сколько последних уведомлений помнит история
+const notificationHistoryLimit = 200
+
+// notificationHistory - уведомления за сеанс, старые первыми: сообщения полосы внизу окна
+// и всплывающие уведомления Windows, чтобы пропущенное можно было перечитать.
+// Уведомления приходят и из фоновых проверок, поэтому история под мьютексом.
+var (
+	notificationHistory      []notification
+	notificationHistoryMutex sync.Mutex
+)
+
+// recordNotification добавляет уведомление в историю
+func recordNotification(text string) {
+	notificationHistoryMutex.Lock()
+	defer notificationHistoryMutex.Unlock()
+	notificationHistory = append(notificationHistory, notification{At: time.Now(), Text: text})
+	if len(notificationHistory) > notificationHistoryLimit {
+		notificationHistory = slices.Delete(notificationHistory, 0, len(notificationHistory)-notificationHistoryLimit)
+	}
+}
+
+// notificationsSnapshot - копия истории уведомлений
+func notificationsSnapshot() []notification {
+	notificationHistoryMutex.Lock()
+	defer notificationHistoryMutex.Unlock()
+	return slices.Clone(notificationHistory)
+}
+
+// clearNotificationHistory очищает историю уведомлений
+func clearNotificationHistory() {
+	notificationHistoryMutex.Lock()
+	defer notificationHistoryMutex.Unlock()
+	notificationHistory = nil
+}
+
+// notify сообщает о результате действия полосой внизу окна, не требуя нажать OK
+func (app *AppMainWindow) notify(text string) {
+	app.showInAppToast(text, "", nil)
+}
+
+// notifyFileSaved сообщает о сохранённом файле и предлагает открыть папку с ним
+func (app *AppMainWindow) notifyFileSaved(text, path string) {
+	app.showInAppToast(text+": "+filepath.Base(path), "Открыть папку", func() {
+		if err := openFileExternally(filepath.Dir(path)); err != nil {
+			log.Printf("Не удалось открыть папку %s: %v", filepath.Dir(path), err)
+		}
+	})
+}
+
+// showNotificationHistory показывает уведомления за сеанс, новые сверху
+func (app *AppMainWindow) showNotificationHistory() {
+	var dlg *walk.Dialog
+	var list *walk.ListBox
+	var closePB *walk.PushButton
+
+	lines := func() []string {
+		var items []string
+		for _, n := range slices.Backward(notificationsSnapshot()) {
+			items = append(items, n.At.Format("15:04:05")+"   "+n.Text)
+		}
+		if len(items) == 0 {
+			items = []string{"Уведомлений пока не было."}
+		}
+		return items
+	}
+
+	if _, err := (Dialog{
+		AssignTo:     &dlg,
+		Title:        "Уведомления",
+		CancelButton: &closePB,
+		MinSize:      Size{Width: 560, Height: 380},
+		Layout:       VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:   SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Уведомления за этот сеанс:", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			ListBox{AssignTo: &list, Model: lines(), Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Очистить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							clearNotificationHistory()
+							list.SetModel(lines())
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
+
+// ensureNotifyIcon создаёт значок в области уведомлений: без него Windows не показывает всплывающие уведомления
+func (app *AppMainWindow) ensureNotifyIcon() *walk.NotifyIcon {
+	if app.notifyIcon != nil {
+		return app.notifyIcon
+	}
+	ni, err := walk.NewNotifyIcon(app.MainWindow)
+	if err != nil {
+		log.Printf("Не удалось создать значок уведомлений: %v", err)
+		return nil
+	}
+	icon := walk.Image(walk.IconApplication())
+	if app.MainWindow.Icon() != nil {
+		icon = app.MainWindow.Icon()
+	}
+	ni.SetIcon(icon)
+	ni.SetToolTip("Поисковик Вакансий")
+	ni.MessageClicked().Attach(app.onNotificationClicked)
+	ni.MouseDown().Attach(func(x, y int, button walk.MouseButton) {
+		if button == walk.LeftButton {
+			app.showMainWindow()
+		}
+	})
+	if err := ni.SetVisible(true); err != nil {
+		log.Printf("Не удалось показать значок уведомлений: %v", err)
+	}
+	app.notifyIcon = ni
+	return ni
+}
+
+// showToast показывает всплывающее уведомление и дублирует его в каналы расширений; щелчок по нему вызывает onClick
+func (app *AppMainWindow) showToast(title, text string, onClick func()) {
+	notifyPlugins(title, text)
+	recordNotification(title + ": " + text)
+	if appSettings.MuteTrayNotifications {
+		return
+	}
+	ni := app.ensureNotifyIcon()
+	if ni == nil {
+		return
+	}
+	app.notificationClick = onClick
+	if err := ni.ShowInfo(title, text); err != nil {
+		log.Printf("Не удалось показать уведомление: %v", err)
+	}
+}
+
+// onNotificationClicked выводит окно на передний план и выполняет действие последнего уведомления
+func (app *AppMainWindow) onNotificationClicked() {
+	app.showMainWindow()
+	if app.inTray || app.locked {
+		return // PIN не введён
+	}
+	if app.notificationClick != nil {
+		app.notificationClick()
+	}
+}
+
+// disposeNotifyIcon убирает значок из области уведомлений при выходе
+func (app *AppMainWindow) disposeNotifyIcon() {
+	if app.notifyIcon != nil {
+		app.notifyIcon.Dispose()
+		app.notifyIcon = nil
+	}
+}
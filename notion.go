@@ -0,0 +1,729 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Синхронизация вакансий с базой данных Notion
+const (
+	notionAPIBase     = "https://api.notion.com/v1/"
+	notionAPIVersion  = "2022-06-28"
+	notionSyncFile    = "notion_sync.json"
+	notionSyncTimeout = 10 * time.Minute
+)
+
+// Notion пропускает около трёх запросов в секунду
+var notionProvider = &searchProvider{Name: "notion", limiter: &rateLimiter{interval: 350 * time.Millisecond}}
+
+// ID базы - 32 шестнадцатеричных символа, в ссылке может быть без дефисов
+var notionIDRe = regexp.MustCompile(`[0-9a-fA-F]{8}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{4}-?[0-9a-fA-F]{12}`)
+
+// NotionSettings - подключение к базе Notion и соответствие её свойств полям вакансии
+type NotionSettings struct {
+	Token           string    `json:"token,omitempty"`
+	DatabaseID      string    `json:"database_id,omitempty"`
+	TwoWay          bool      `json:"two_way"` // Забирать изменения из Notion, а не только отправлять
+	TitleProperty   string    `json:"title_property,omitempty"`
+	CompanyProperty string    `json:"company_property,omitempty"`
+	StatusProperty  string    `json:"status_property,omitempty"`
+	URLProperty     string    `json:"url_property,omitempty"`
+	SalaryProperty  string    `json:"salary_property,omitempty"`
+	LastSyncAt      time.Time `json:"last_sync_at,omitzero"`
+}
+
+// Свойства базы по умолчанию
+var defaultNotionSettings = NotionSettings{
+	TitleProperty:   "Name",
+	CompanyProperty: "Company",
+	StatusProperty:  "Status",
+	URLProperty:     "URL",
+	SalaryProperty:  "Salary",
+}
+
+// notionValues - поля вакансии, которые синхронизируются с Notion
+type notionValues struct {
+	Title   string `json:"title"`
+	Company string `json:"company"`
+	Status  string `json:"status"`
+	URL     string `json:"url"`
+	Salary  string `json:"salary"`
+}
+
+func localNotionValues(v Vacancy) notionValues {
+	status := v.Status
+	if status == "" {
+		status = possibleStatuses[0]
+	}
+	return notionValues{Title: v.Title, Company: v.Company, Status: status, URL: v.SourceURL, Salary: v.Salary}
+}
+
+// notionLink - страница Notion, связанная с локальной вакансией, и значения на момент последней синхронизации
+type notionLink struct {
+	Title   string       `json:"local_title"`
+	Company string       `json:"local_company"`
+	Synced  notionValues `json:"synced"`
+}
+
+// notionSyncState хранится отдельно от вакансий: по нему видно, где изменения были после синхронизации
+type notionSyncState struct {
+	DatabaseID string                `json:"database_id"`
+	Pages      map[string]notionLink `json:"pages"` // По ID страницы
+}
+
+func loadNotionSyncState(databaseID string) notionSyncState {
+	state := notionSyncState{DatabaseID: databaseID, Pages: map[string]notionLink{}}
+	data, err := os.ReadFile(dataPath(notionSyncFile))
+	if err != nil {
+		return state
+	}
+	var saved notionSyncState
+	if err := json.Unmarshal(data, &saved); err != nil {
+		log.Printf("Ошибка чтения %s: %v", notionSyncFile, err)
+		return state
+	}
+	// Связи с другой базой не подходят
+	if saved.DatabaseID != databaseID || saved.Pages == nil {
+		return state
+	}
+	return saved
+}
+
+func saveNotionSyncState(state notionSyncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dataPath(notionSyncFile), data, 0644)
+}
+
+// parseNotionID достаёт ID базы из ссылки или строки с ID
+func parseNotionID(raw string) (string, error) {
+	id := notionIDRe.FindString(raw)
+	if id == "" {
+		return "", errors.New("не удалось найти ID базы Notion: вставьте ссылку на базу или её ID")
+	}
+	return strings.ToLower(strings.ReplaceAll(id, "-", "")), nil
+}
+
+// notionClient выполняет запросы к API Notion
+type notionClient struct {
+	ctx   context.Context
+	token string
+}
+
+func (c *notionClient) do(method, path string, body any, out any) error {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+	data, status, err := fetchWithRetry(c.ctx, notionProvider, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(c.ctx, method, notionAPIBase+path, bytes.NewReader(payload))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Authorization", "Bearer "+c.token)
+		req.Header.Set("Notion-Version", notionAPIVersion)
+		req.Header.Set("Content-Type", "application/json")
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		if json.Unmarshal(data, &apiErr) == nil && apiErr.Message != "" {
+			return fmt.Errorf("Notion: %s", apiErr.Message)
+		}
+		return fmt.Errorf("Notion ответил %d", status)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
+
+// notionPage - страница базы с нужными свойствами
+type notionPage struct {
+	ID         string                     `json:"id"`
+	Archived   bool                       `json:"archived"`
+	Properties map[string]json.RawMessage `json:"properties"`
+}
+
+// propertyTypes возвращает типы свойств базы по имени
+func (c *notionClient) propertyTypes(databaseID string) (map[string]string, error) {
+	var db struct {
+		Properties map[string]struct {
+			Type string `json:"type"`
+		} `json:"properties"`
+	}
+	if err := c.do(http.MethodGet, "databases/"+databaseID, nil, &db); err != nil {
+		return nil, err
+	}
+	types := make(map[string]string, len(db.Properties))
+	for name, p := range db.Properties {
+		types[name] = p.Type
+	}
+	return types, nil
+}
+
+// queryPages загружает все страницы базы постранично
+func (c *notionClient) queryPages(databaseID string) ([]notionPage, error) {
+	var pages []notionPage
+	cursor := ""
+	for {
+		body := map[string]any{"page_size": 100}
+		if cursor != "" {
+			body["start_cursor"] = cursor
+		}
+		var resp struct {
+			Results    []notionPage `json:"results"`
+			HasMore    bool         `json:"has_more"`
+			NextCursor string       `json:"next_cursor"`
+		}
+		if err := c.do(http.MethodPost, "databases/"+databaseID+"/query", body, &resp); err != nil {
+			return nil, err
+		}
+		pages = append(pages, resp.Results...)
+		if !resp.HasMore || resp.NextCursor == "" {
+			return pages, nil
+		}
+		cursor = resp.NextCursor
+	}
+}
+
+// notionPropertyText читает значение свойства как строку
+func notionPropertyText(raw json.RawMessage) string {
+	var p struct {
+		Type  string `json:"type"`
+		Title []struct {
+			PlainText string `json:"plain_text"`
+		} `json:"title"`
+		RichText []struct {
+			PlainText string `json:"plain_text"`
+		} `json:"rich_text"`
+		Select *struct {
+			Name string `json:"name"`
+		} `json:"select"`
+		Status *struct {
+			Name string `json:"name"`
+		} `json:"status"`
+		URL    *string  `json:"url"`
+		Number *float64 `json:"number"`
+	}
+	if json.Unmarshal(raw, &p) != nil {
+		return ""
+	}
+	var sb strings.Builder
+	switch p.Type {
+	case "title":
+		for _, t := range p.Title {
+			sb.WriteString(t.PlainText)
+		}
+	case "rich_text":
+		for _, t := range p.RichText {
+			sb.WriteString(t.PlainText)
+		}
+	case "select":
+		if p.Select != nil {
+			sb.WriteString(p.Select.Name)
+		}
+	case "status":
+		if p.Status != nil {
+			sb.WriteString(p.Status.Name)
+		}
+	case "url":
+		if p.URL != nil {
+			sb.WriteString(*p.URL)
+		}
+	case "number":
+		if p.Number != nil {
+			sb.WriteString(fmt.Sprint(*p.Number))
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+// notionPropertyValue формирует значение свойства заданного типа
+func notionPropertyValue(kind, text string) (any, bool) {
+	richText := []map[string]any{{"text": map[string]string{"content": text}}}
+	switch kind {
+	case "title":
+		return map[string]any{"title": richText}, true
+	case "rich_text":
+		return map[string]any{"rich_text": richText}, true
+	case "select", "status":
+		if text == "" {
+			return map[string]any{kind: nil}, true
+		}
+		return map[string]any{kind: map[string]string{"name": text}}, true
+	case "url":
+		if text == "" {
+			return map[string]any{"url": nil}, true
+		}
+		return map[string]any{"url": text}, true
+	case "number":
+		var n float64
+		if _, err := fmt.Sscan(text, &n); err != nil {
+			return map[string]any{"number": nil}, true
+		}
+		return map[string]any{"number": n}, true
+	}
+	return nil, false
+}
+
+// notionMapping - соответствие полей свойствам базы с их типами
+type notionMapping struct {
+	s     NotionSettings
+	types map[string]string
+}
+
+func (m notionMapping) fields(v *notionValues) []struct {
+	property string
+	value    *string
+} {
+	return []struct {
+		property string
+		value    *string
+	}{
+		{m.s.TitleProperty, &v.Title},
+		{m.s.CompanyProperty, &v.Company},
+		{m.s.StatusProperty, &v.Status},
+		{m.s.URLProperty, &v.URL},
+		{m.s.SalaryProperty, &v.Salary},
+	}
+}
+
+// properties собирает свойства страницы; поля без свойства в базе пропускаются
+func (m notionMapping) properties(v notionValues) map[string]any {
+	props := map[string]any{}
+	for _, f := range m.fields(&v) {
+		if f.property == "" {
+			continue
+		}
+		if value, ok := notionPropertyValue(m.types[f.property], *f.value); ok {
+			props[f.property] = value
+		}
+	}
+	return props
+}
+
+// values читает поля вакансии из свойств страницы
+func (m notionMapping) values(p notionPage) notionValues {
+	var v notionValues
+	for _, f := range m.fields(&v) {
+		if raw, ok := p.Properties[f.property]; ok && f.property != "" {
+			*f.value = notionPropertyText(raw)
+		}
+	}
+	return v
+}
+
+// mask подставляет локальные значения полей, которых нет в базе, чтобы они не считались изменёнными в Notion
+func (m notionMapping) mask(remote, local notionValues) notionValues {
+	lp, rp := m.fields(&local), m.fields(&remote)
+	for i, f := range rp {
+		if _, ok := m.types[f.property]; !ok || f.property == "" {
+			*f.value = *lp[i].value
+		}
+	}
+	return remote
+}
+
+// notionConflict - вакансия изменена и здесь, и в Notion
+type notionConflict struct {
+	Local, Remote notionValues
+}
+
+// Решение по конфликту
+const (
+	notionKeepLocal = iota
+	notionTakeRemote
+	notionSkip
+)
+
+// notionSyncResult - итог синхронизации для показа пользователю
+type notionSyncResult struct {
+	Created, Updated, Pulled, Imported, Skipped int
+	LocalUpdates                                map[int]notionValues // Правки из Notion по индексу в снимке списка
+	NewVacancies                                []Vacancy
+}
+
+// syncNotion отправляет вакансии в базу и при двусторонней синхронизации забирает изменения.
+// resolve вызывается для конфликтов; выполняется в фоне, локальный список не меняет.
+func syncNotion(ctx context.Context, s NotionSettings, vacancies []Vacancy, resolve func(notionConflict) int) (notionSyncResult, notionSyncState, error) {
+	res := notionSyncResult{LocalUpdates: map[int]notionValues{}}
+	c := &notionClient{ctx: ctx, token: s.Token}
+	state := loadNotionSyncState(s.DatabaseID)
+
+	types, err := c.propertyTypes(s.DatabaseID)
+	if err != nil {
+		return res, state, err
+	}
+	m := notionMapping{s: s, types: types}
+	if types[s.TitleProperty] != "title" {
+		return res, state, fmt.Errorf("в базе нет заголовка «%s»: укажите имя свойства-заголовка в настройках", s.TitleProperty)
+	}
+	pages, err := c.queryPages(s.DatabaseID)
+	if err != nil {
+		return res, state, err
+	}
+	remote := map[string]notionValues{}
+	for _, p := range pages {
+		if !p.Archived {
+			remote[p.ID] = m.values(p)
+		}
+	}
+
+	// Страницы, ещё не связанные с вакансиями: при первой синхронизации связываем по названию и компании
+	linked := map[int]string{}
+	for id, link := range state.Pages {
+		for i, v := range vacancies {
+			if sameVacancy(v.Title, v.Company, link.Title, link.Company) {
+				linked[i] = id
+				break
+			}
+		}
+	}
+	isLinked := func(id string) bool {
+		_, ok := state.Pages[id]
+		return ok
+	}
+	for id, rv := range remote {
+		if isLinked(id) {
+			continue
+		}
+		for i, v := range vacancies {
+			if _, ok := linked[i]; !ok && isDuplicateVacancy(v, Vacancy{Title: rv.Title, Company: rv.Company, SourceURL: rv.URL}, appSettings.Dedup) {
+				linked[i] = id
+				state.Pages[id] = notionLink{Title: v.Title, Company: v.Company, Synced: rv}
+				break
+			}
+		}
+	}
+
+	progressTotal(ctx, len(vacancies))
+	for i, v := range vacancies {
+		if err := ctx.Err(); err != nil {
+			return res, state, err
+		}
+		progressStep(ctx, v.Title)
+		local := localNotionValues(v)
+		id, ok := linked[i]
+		rv, exists := remote[id]
+		if !ok || !exists {
+			// Новая вакансия или страница удалена в Notion - создаём заново
+			var page notionPage
+			body := map[string]any{"parent": map[string]string{"database_id": s.DatabaseID}, "properties": m.properties(local)}
+			if err := c.do(http.MethodPost, "pages", body, &page); err != nil {
+				return res, state, err
+			}
+			if ok {
+				delete(state.Pages, id)
+			}
+			state.Pages[page.ID] = notionLink{Title: v.Title, Company: v.Company, Synced: local}
+			res.Created++
+			continue
+		}
+
+		synced := state.Pages[id].Synced
+		rv = m.mask(rv, local)
+		localChanged, remoteChanged := local != synced, rv != synced
+		action := notionKeepLocal
+		switch {
+		case local == rv:
+			state.Pages[id] = notionLink{Title: v.Title, Company: v.Company, Synced: local}
+			continue
+		case !s.TwoWay || !remoteChanged:
+			action = notionKeepLocal
+		case !localChanged:
+			action = notionTakeRemote
+		default:
+			action = resolve(notionConflict{Local: local, Remote: rv})
+		}
+
+		switch action {
+		case notionKeepLocal:
+			if err := c.do(http.MethodPatch, "pages/"+id, map[string]any{"properties": m.properties(local)}, nil); err != nil {
+				return res, state, err
+			}
+			state.Pages[id] = notionLink{Title: v.Title, Company: v.Company, Synced: local}
+			res.Updated++
+		case notionTakeRemote:
+			res.LocalUpdates[i] = rv
+			state.Pages[id] = notionLink{Title: rv.Title, Company: rv.Company, Synced: rv}
+			res.Pulled++
+		default:
+			res.Skipped++
+		}
+	}
+
+	if s.TwoWay {
+		for id, rv := range remote {
+			if isLinked(id) || strings.TrimSpace(rv.Title) == "" {
+				continue
+			}
+			v := Vacancy{Title: rv.Title, Company: rv.Company, SourceURL: rv.URL, Source: "Notion"}
+			applyNotionValues(&v, rv)
+			res.NewVacancies = append(res.NewVacancies, v)
+			state.Pages[id] = notionLink{Title: v.Title, Company: v.Company, Synced: rv}
+			res.Imported++
+		}
+	}
+	return res, state, nil
+}
+
+// applyNotionValues переносит поля из Notion в вакансию; незнакомый статус не меняется
+func applyNotionValues(v *Vacancy, nv notionValues) {
+	v.Title, v.Company, v.SourceURL = nv.Title, nv.Company, nv.URL
+	if v.Salary != nv.Salary {
+		applySalary(v, nv.Salary)
+	}
+	for _, status := range possibleStatuses {
+		if strings.EqualFold(status, nv.Status) {
+			setVacancyStatus(v, status)
+			break
+		}
+	}
+}
+
+// applyNotionResult переносит изменения из Notion в список вакансий
+func applyNotionResult(snapshot []Vacancy, res notionSyncResult) bool {
+	if len(res.LocalUpdates) == 0 && len(res.NewVacancies) == 0 {
+		return false
+	}
+	allVacanciesMutex.Lock()
+	for i, nv := range res.LocalUpdates {
+		old := snapshot[i]
+		for j := range allVacancies {
+			if sameVacancy(allVacancies[j].Title, allVacancies[j].Company, old.Title, old.Company) {
+				applyNotionValues(&allVacancies[j], nv)
+				break
+			}
+		}
+	}
+	for _, v := range res.NewVacancies {
+		stampNewVacancy(&v)
+		allVacancies = append(allVacancies, v)
+	}
+	allVacanciesMutex.Unlock()
+	saveVacancies()
+	return true
+}
+
+// runNotionSync синхронизирует, показывая ход синхронизации, и по окончании вызывает done.
+// Прерванная пользователем синхронизация ошибкой не считается.
+func (app *AppMainWindow) runNotionSync(owner walk.Form, done func(error)) {
+	s := appSettings.Notion
+	snapshot := snapshotVacancies()
+	resolve := func(c notionConflict) int {
+		answer := make(chan int)
+		owner.Synchronize(func() {
+			text := fmt.Sprintf("«%s» изменена и в приложении, и в Notion.\n\nЗдесь: %s, %s, %s, %s\nВ Notion: %s, %s, %s, %s\n\nДа - оставить версию приложения, Нет - взять из Notion, Отмена - пропустить.",
+				c.Local.Title, c.Local.Title, c.Local.Company, c.Local.Status, c.Local.Salary,
+				c.Remote.Title, c.Remote.Company, c.Remote.Status, c.Remote.Salary)
+			switch walk.MsgBox(owner, "Конфликт синхронизации", text, walk.MsgBoxYesNoCancel|walk.MsgBoxIconQuestion) {
+			case walk.DlgCmdYes:
+				answer <- notionKeepLocal
+			case walk.DlgCmdNo:
+				answer <- notionTakeRemote
+			default:
+				answer <- notionSkip
+			}
+		})
+		return <-answer
+	}
+
+	var res notionSyncResult
+	err := runWithProgress(owner, "Синхронизация с Notion", func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, notionSyncTimeout)
+		defer cancel()
+		var state notionSyncState
+		var err error
+		res, state, err = syncNotion(ctx, s, snapshot, resolve)
+		// Созданные до ошибки страницы сохраняем, чтобы не создать их повторно
+		if saveErr := saveNotionSyncState(state); saveErr != nil {
+			log.Printf("Ошибка сохранения %s: %v", notionSyncFile, saveErr)
+		}
+		return err
+	})
+	if errors.Is(err, context.Canceled) {
+		done(nil)
+		return
+	}
+	if err == nil {
+		if applyNotionResult(snapshot, res) {
+			vacancyEvents.Publish(VacancyEvent{Kind: VacanciesImported})
+		}
+		appSettings.Notion.LastSyncAt = time.Now()
+		saveSettings()
+		summary := fmt.Sprintf("Синхронизация с Notion: создано страниц %d, обновлено в Notion %d", res.Created, res.Updated)
+		if s.TwoWay {
+			summary += fmt.Sprintf(", изменено из Notion %d, добавлено из Notion %d, пропущено конфликтов %d", res.Pulled, res.Imported, res.Skipped)
+		}
+		app.notify(summary + ".")
+	}
+	done(err)
+}
+
+// showNotionDialog настраивает подключение к Notion и запускает синхронизацию
+func (app *AppMainWindow) showNotionDialog() {
+	var dlg *walk.Dialog
+	var tokenLE, databaseLE *walk.LineEdit
+	var titleLE, companyLE, statusLE, urlLE, salaryLE *walk.LineEdit
+	var modeCB *walk.ComboBox
+	var lastSyncLabel *walk.Label
+	var syncPB, acceptPB, cancelPB *walk.PushButton
+
+	s := appSettings.Notion
+	for _, f := range []struct{ value, def *string }{
+		{&s.TitleProperty, &defaultNotionSettings.TitleProperty},
+		{&s.CompanyProperty, &defaultNotionSettings.CompanyProperty},
+		{&s.StatusProperty, &defaultNotionSettings.StatusProperty},
+		{&s.URLProperty, &defaultNotionSettings.URLProperty},
+		{&s.SalaryProperty, &defaultNotionSettings.SalaryProperty},
+	} {
+		if *f.value == "" {
+			*f.value = *f.def
+		}
+	}
+	modes := []string{"Только отправлять в Notion", "Двусторонняя с вопросом при конфликте"}
+	lastSync := "Ещё не синхронизировалось"
+	if !s.LastSyncAt.IsZero() {
+		lastSync = "Последняя синхронизация: " + s.LastSyncAt.Local().Format("02.01.2006 15:04")
+	}
+
+	// apply проверяет поля и сохраняет настройки
+	apply := func() bool {
+		id, err := parseNotionID(databaseLE.Text())
+		if err != nil {
+			walk.MsgBox(dlg, "Notion", err.Error(), walk.MsgBoxIconWarning)
+			return false
+		}
+		token := strings.TrimSpace(tokenLE.Text())
+		if token == "" {
+			walk.MsgBox(dlg, "Notion", "Укажите токен интеграции Notion.", walk.MsgBoxIconWarning)
+			return false
+		}
+		titleProperty := strings.TrimSpace(titleLE.Text())
+		if titleProperty == "" {
+			walk.MsgBox(dlg, "Notion", "Укажите свойство-заголовок базы.", walk.MsgBoxIconWarning)
+			return false
+		}
+		appSettings.Notion.Token = token
+		appSettings.Notion.DatabaseID = id
+		appSettings.Notion.TwoWay = modeCB.CurrentIndex() == 1
+		appSettings.Notion.TitleProperty = titleProperty
+		appSettings.Notion.CompanyProperty = strings.TrimSpace(companyLE.Text())
+		appSettings.Notion.StatusProperty = strings.TrimSpace(statusLE.Text())
+		appSettings.Notion.URLProperty = strings.TrimSpace(urlLE.Text())
+		appSettings.Notion.SalaryProperty = strings.TrimSpace(salaryLE.Text())
+		saveSettings()
+		return true
+	}
+
+	modeIndex := 0
+	if s.TwoWay {
+		modeIndex = 1
+	}
+	propertyRow := func(label string, assign **walk.LineEdit, value string) []Widget {
+		return []Widget{
+			Label{Text: label, TextColor: currentTheme.Text},
+			LineEdit{AssignTo: assign, Text: value},
+		}
+	}
+	rows := []Widget{
+		Label{Text: "Токен интеграции:", TextColor: currentTheme.Text},
+		LineEdit{AssignTo: &tokenLE, Text: s.Token, PasswordMode: true},
+		Label{Text: "База (ссылка или ID):", TextColor: currentTheme.Text},
+		LineEdit{AssignTo: &databaseLE, Text: s.DatabaseID},
+		Label{Text: "Режим:", TextColor: currentTheme.Text},
+		ComboBox{AssignTo: &modeCB, Model: modes, CurrentIndex: modeIndex},
+		Label{Text: "Свойства базы", ColumnSpan: 2, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+	}
+	rows = append(rows, propertyRow("Название (заголовок):", &titleLE, s.TitleProperty)...)
+	rows = append(rows, propertyRow("Компания:", &companyLE, s.CompanyProperty)...)
+	rows = append(rows, propertyRow("Статус (select):", &statusLE, s.StatusProperty)...)
+	rows = append(rows, propertyRow("Ссылка:", &urlLE, s.URLProperty)...)
+	rows = append(rows, propertyRow("Зарплата:", &salaryLE, s.SalaryProperty)...)
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Синхронизация с Notion",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 520, Height: 420},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Создайте интеграцию на notion.so/my-integrations и подключите её к базе (Share → Connections).\nПустое имя свойства - поле не синхронизируется.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			Composite{Layout: Grid{Columns: 2, MarginsZero: true}, Children: rows},
+			Label{AssignTo: &lastSyncLabel, Text: lastSync, TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						AssignTo:   &syncPB,
+						Text:       "Синхронизировать сейчас",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if !apply() {
+								return
+							}
+							app.runNotionSync(dlg, func(err error) {
+								if err != nil {
+									showError(dlg, "Ошибка синхронизации", err)
+									return
+								}
+								lastSyncLabel.SetText("Последняя синхронизация: " + appSettings.Notion.LastSyncAt.Local().Format("02.01.2006 15:04"))
+							})
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if apply() {
+								dlg.Accept()
+							}
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
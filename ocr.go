@@ -0,0 +1,193 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/lxn/walk"
+	"golang.org/x/sys/windows"
+)
+
+// Языки распознавания по умолчанию: вакансии из Telegram обычно на русском с английскими терминами
+const defaultOCRLanguages = "rus+eng"
+
+// Сколько ждать tesseract на одном изображении
+const ocrTimeout = 2 * time.Minute
+
+// Заголовок блока распознанного текста в описании вакансии
+const ocrBlockHeader = "--- Текст со скриншота «%s» ---"
+
+var ocrBlankLinesRe = regexp.MustCompile(`\n{3,}`)
+
+// findTesseract ищет tesseract.exe: путь из настроек, PATH и стандартные папки установки
+func findTesseract() (string, error) {
+	if appSettings.TesseractPath != "" {
+		if _, err := os.Stat(appSettings.TesseractPath); err == nil {
+			return appSettings.TesseractPath, nil
+		}
+	}
+	if path, err := exec.LookPath("tesseract"); err == nil {
+		return path, nil
+	}
+	for _, env := range []string{"ProgramFiles", "ProgramFiles(x86)", "LocalAppData"} {
+		dir := os.Getenv(env)
+		if dir == "" {
+			continue
+		}
+		for _, path := range []string{
+			filepath.Join(dir, "Tesseract-OCR", "tesseract.exe"),
+			filepath.Join(dir, "Programs", "Tesseract-OCR", "tesseract.exe"),
+		} {
+			if _, err := os.Stat(path); err == nil {
+				return path, nil
+			}
+		}
+	}
+	return "", errors.New("не найдена программа распознавания Tesseract OCR")
+}
+
+// cleanOCRText убирает разрывы страниц и лишние пустые строки из вывода tesseract
+func cleanOCRText(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = strings.ReplaceAll(text, "\f", "\n")
+	lines := strings.Split(text, "\n")
+	for i := range lines {
+		lines[i] = strings.TrimRight(lines[i], " \t")
+	}
+	return strings.TrimSpace(ocrBlankLinesRe.ReplaceAllString(strings.Join(lines, "\n"), "\n\n"))
+}
+
+// runOCR распознаёт текст на изображении с помощью tesseract
+func runOCR(ctx context.Context, exe, imagePath, languages string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, ocrTimeout)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, exe, imagePath, "stdout", "-l", languages)
+	// Без этого на время распознавания мелькает окно консоли
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true, CreationFlags: windows.CREATE_NO_WINDOW}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", errors.New("распознавание заняло слишком много времени")
+	}
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if strings.Contains(msg, "Failed loading language") {
+			return "", fmt.Errorf("в Tesseract не установлены языковые данные для %q", languages)
+		}
+		return "", fmt.Errorf("ошибка tesseract: %w (%s)", err, msg)
+	}
+	return cleanOCRText(string(out)), nil
+}
+
+// ocrBlock - распознанный текст в том виде, в каком он добавляется в описание
+func ocrBlock(caption, text string) string {
+	return fmt.Sprintf(ocrBlockHeader, caption) + "\r\n" + strings.ReplaceAll(text, "\n", "\r\n")
+}
+
+// mergeOCRText добавляет распознанный текст в конец описания, а при повторном распознавании
+// заменяет прежний блок того же вложения
+func mergeOCRText(description string, a Attachment, text string) string {
+	block := ocrBlock(a.Caption, text)
+	if a.OCRText != "" {
+		if old := ocrBlock(a.Caption, a.OCRText); strings.Contains(description, old) {
+			return strings.Replace(description, old, block, 1)
+		}
+	}
+	if strings.TrimSpace(description) == "" {
+		return block
+	}
+	return strings.TrimRight(description, "\r\n") + "\r\n\r\n" + block
+}
+
+// chooseTesseract просит указать tesseract.exe, если он не найден автоматически
+func (app *AppMainWindow) chooseTesseract() (string, bool) {
+	if walk.MsgBox(app.MainWindow, "Распознавание текста",
+		"Для распознавания текста нужна бесплатная программа Tesseract OCR\n(github.com/UB-Mannheim/tesseract/wiki) с русским языком.\n\nУказать путь к tesseract.exe?",
+		walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+		return "", false
+	}
+	dlg := new(walk.FileDialog)
+	dlg.Title = "Где установлен tesseract.exe"
+	dlg.Filter = "tesseract.exe|tesseract.exe|Программы (*.exe)|*.exe"
+	if ok, err := dlg.ShowOpen(app.MainWindow); err != nil || !ok {
+		return "", false
+	}
+	appSettings.TesseractPath = dlg.FilePath
+	saveSettings()
+	return dlg.FilePath, true
+}
+
+// recognizeAttachment распознаёт текст на скриншоте и добавляет его в описание вакансии, чтобы он находился поиском
+func (app *AppMainWindow) recognizeAttachment(v Vacancy, a Attachment) {
+	if app.ocrRunning {
+		walk.MsgBox(app.MainWindow, "Распознавание текста", "Распознавание уже выполняется, подождите.", walk.MsgBoxIconInformation)
+		return
+	}
+	if a.OCRText != "" && walk.MsgBox(app.MainWindow, "Распознавание текста",
+		"Текст с этого изображения уже добавлен в описание. Распознать заново?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+		return
+	}
+	exe, err := findTesseract()
+	if err != nil {
+		var ok bool
+		if exe, ok = app.chooseTesseract(); !ok {
+			return
+		}
+	}
+	languages := appSettings.OCRLanguages
+	if languages == "" {
+		languages = defaultOCRLanguages
+	}
+
+	app.ocrRunning = true
+	app.attachmentsLabel.SetText("Вложения: распознаётся текст...")
+	go func() {
+		text, err := runOCR(context.Background(), exe, attachmentPath(a), languages)
+		app.Synchronize(func() {
+			app.ocrRunning = false
+			if err != nil {
+				showError(app.MainWindow, "Распознавание текста", wrapError("Не удалось распознать текст", err))
+				app.selectVacancy(v.Title, v.Company)
+				return
+			}
+			if text == "" {
+				app.notify("На изображении не найден текст.")
+				app.selectVacancy(v.Title, v.Company)
+				return
+			}
+			app.applyOCRText(v, a, text)
+		})
+	}()
+}
+
+// applyOCRText сохраняет распознанный текст во вложении и в описании вакансии
+func (app *AppMainWindow) applyOCRText(v Vacancy, a Attachment, text string) {
+	allVacanciesMutex.Lock()
+	i := app.findVacancyIndexInAllExt(v.Title, v.Company)
+	if i != -1 {
+		for j := range allVacancies[i].Attachments {
+			if allVacancies[i].Attachments[j].File == a.File {
+				allVacancies[i].Description = mergeOCRText(allVacancies[i].Description, allVacancies[i].Attachments[j], text)
+				allVacancies[i].Attachments[j].OCRText = text
+			}
+		}
+	}
+	allVacanciesMutex.Unlock()
+	if i == -1 {
+		walk.MsgBox(app.MainWindow, "Ошибка", "Вакансия была удалена, пока распознавался текст.", walk.MsgBoxIconError)
+		return
+	}
+	saveVacancies()
+	vacancyEvents.Publish(vacancyEvent(VacancyUpdated, v))
+	app.notify(fmt.Sprintf("Распознано %d символов. Текст добавлен в конец описания вакансии.", len([]rune(text))))
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+const offerStatus = "Оффер"
+
+// Возможные форматы работы
+var possibleWorkFormats = []string{"Не указан", "Офис", "Гибрид", "Удалённо"}
+
+// offerColumn - виджеты одной колонки в окне сравнения офферов
+type offerColumn struct {
+	title    string
+	company  string
+	formatCB *walk.ComboBox
+	location *walk.LineEdit
+	pros     *walk.TextEdit
+	cons     *walk.TextEdit
+}
+
+// offerVacancies возвращает копии вакансий в статусе "Оффер"
+func offerVacancies() []Vacancy {
+	allVacanciesMutex.Lock()
+	defer allVacanciesMutex.Unlock()
+	var offers []Vacancy
+	for _, v := range allVacancies {
+		if v.Status == offerStatus {
+			offers = append(offers, v)
+		}
+	}
+	return offers
+}
+
+// indexOfString возвращает индекс значения в списке или 0, если его там нет
+func indexOfString(list []string, value string) int {
+	for i, s := range list {
+		if s == value {
+			return i
+		}
+	}
+	return 0
+}
+
+// showOfferComparison открывает окно сравнения офферов
+func (app *AppMainWindow) showOfferComparison() {
+	offers := offerVacancies()
+	if len(offers) == 0 {
+		walk.MsgBox(app.MainWindow, "Сравнение офферов", "Нет вакансий в статусе \"Оффер\".", walk.MsgBoxIconInformation)
+		return
+	}
+
+	var dlg *walk.Dialog
+	columns := make([]*offerColumn, len(offers))
+	var offerWidgets []Widget
+	for i, v := range offers {
+		col := &offerColumn{title: v.Title, company: v.Company}
+		columns[i] = col
+
+		salary := v.Salary
+		if salary == "" {
+			salary = "не указана"
+		}
+		offerWidgets = append(offerWidgets, GroupBox{
+			Title:   v.Company,
+			Layout:  VBox{Spacing: 4},
+			MinSize: Size{Width: 240},
+			Children: []Widget{
+				Label{Text: v.Title, Font: Font{PointSize: 10, Bold: true}},
+				Label{Text: "Зарплата:", Font: Font{Bold: true, PointSize: 9}},
+				Label{Text: salary, Font: Font{PointSize: 9}},
+				Label{Text: "Формат работы:", Font: Font{Bold: true, PointSize: 9}},
+				ComboBox{AssignTo: &col.formatCB, Model: possibleWorkFormats, CurrentIndex: indexOfString(possibleWorkFormats, v.WorkFormat), Font: Font{PointSize: 9}},
+				Label{Text: "Локация:", Font: Font{Bold: true, PointSize: 9}},
+				LineEdit{AssignTo: &col.location, Text: v.Location, Font: Font{PointSize: 9}},
+				Label{Text: "Плюсы:", Font: Font{Bold: true, PointSize: 9}},
+				TextEdit{AssignTo: &col.pros, Text: v.OfferPros, VScroll: true, MinSize: Size{Height: 70}, Font: Font{PointSize: 9}},
+				Label{Text: "Минусы:", Font: Font{Bold: true, PointSize: 9}},
+				TextEdit{AssignTo: &col.cons, Text: v.OfferCons, VScroll: true, MinSize: Size{Height: 70}, Font: Font{PointSize: 9}},
+				Label{Text: "Заметки:", Font: Font{Bold: true, PointSize: 9}},
+				TextEdit{Text: v.Notes, ReadOnly: true, VScroll: true, MinSize: Size{Height: 70}, Font: Font{PointSize: 9}},
+			},
+		})
+	}
+
+	// applyEdits переносит правки из окна в общий список вакансий
+	applyEdits := func() []Vacancy {
+		allVacanciesMutex.Lock()
+		defer allVacanciesMutex.Unlock()
+		var updated []Vacancy
+		for _, col := range columns {
+			for i := range allVacancies {
+				v := &allVacancies[i]
+				if !strings.EqualFold(v.Title, col.title) || !strings.EqualFold(v.Company, col.company) {
+					continue
+				}
+				v.WorkFormat = col.formatCB.Text()
+				if v.WorkFormat == possibleWorkFormats[0] {
+					v.WorkFormat = ""
+				}
+				v.Location = strings.TrimSpace(col.location.Text())
+				v.OfferPros = strings.TrimSpace(col.pros.Text())
+				v.OfferCons = strings.TrimSpace(col.cons.Text())
+				updated = append(updated, *v)
+				break
+			}
+		}
+		return updated
+	}
+
+	if _, err := (Dialog{
+		AssignTo:   &dlg,
+		Title:      "Сравнение офферов",
+		MinSize:    Size{Width: 800, Height: 600},
+		Layout:     VBox{},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			ScrollView{
+				Layout:        HBox{Spacing: 8},
+				Children:      offerWidgets,
+				StretchFactor: 1,
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Печатная сводка",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							updated := applyEdits()
+							saveVacancies()
+							vacancyEvents.Publish(VacancyEvent{Kind: VacancyUpdated})
+							path, err := writeOfferSummary(updated)
+							if err == nil {
+								err = openFileExternally(path)
+							}
+							if err != nil {
+								log.Printf("Ошибка формирования сводки офферов: %v", err)
+								showError(dlg, "Ошибка", wrapError("Не удалось сформировать сводку", err))
+							}
+						},
+					},
+					HSpacer{},
+					PushButton{
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							applyEdits()
+							saveVacancies()
+							vacancyEvents.Publish(VacancyEvent{Kind: VacancyUpdated})
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
+
+var offerSummaryTemplate = template.Must(template.New("offers").Funcs(template.FuncMap{
+	"lines": func(s string) []string {
+		var out []string
+		for _, line := range strings.Split(s, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				out = append(out, line)
+			}
+		}
+		return out
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>Сравнение офферов</title>
+<style>
+body { font-family: "Segoe UI", Arial, sans-serif; color: #222; margin: 32px; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+th, td { border: 1px solid #ccc; padding: 6px 10px; text-align: left; vertical-align: top; }
+th { background: #f3f3f3; width: 140px; }
+ul { margin: 0; padding-left: 18px; }
+.muted { color: #777; font-size: 12px; }
+</style>
+</head>
+<body>
+<h1>Сравнение офферов</h1>
+<div class="muted">Сформировано {{.Generated}}</div>
+<table>
+<tr><th></th>{{range .Offers}}<th>{{.Company}}<br>{{.Title}}</th>{{end}}</tr>
+<tr><th>Зарплата</th>{{range .Offers}}<td>{{if .Salary}}{{.Salary}}{{else}}—{{end}}</td>{{end}}</tr>
+<tr><th>Формат</th>{{range .Offers}}<td>{{if .WorkFormat}}{{.WorkFormat}}{{else}}—{{end}}</td>{{end}}</tr>
+<tr><th>Локация</th>{{range .Offers}}<td>{{if .Location}}{{.Location}}{{else}}—{{end}}</td>{{end}}</tr>
+<tr><th>Плюсы</th>{{range .Offers}}<td><ul>{{range lines .OfferPros}}<li>{{.}}</li>{{end}}</ul></td>{{end}}</tr>
+<tr><th>Минусы</th>{{range .Offers}}<td><ul>{{range lines .OfferCons}}<li>{{.}}</li>{{end}}</ul></td>{{end}}</tr>
+<tr><th>Заметки</th>{{range .Offers}}<td>{{range lines .Notes}}{{.}}<br>{{end}}</td>{{end}}</tr>
+</table>
+</body>
+</html>
+`))
+
+// writeOfferSummary сохраняет печатную сводку офферов во временный HTML-файл и возвращает путь к нему
+func writeOfferSummary(offers []Vacancy) (string, error) {
+	var buf bytes.Buffer
+	data := struct {
+		Generated string
+		Offers    []Vacancy
+	}{Generated: time.Now().Format("02.01.2006 15:04"), Offers: offers}
+	if err := offerSummaryTemplate.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("ошибка формирования сводки: %w", err)
+	}
+	path := filepath.Join(os.TempDir(), "offers-"+time.Now().Format("20060102-150405")+".html")
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return "", fmt.Errorf("ошибка записи файла %s: %w", path, err)
+	}
+	return path, nil
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+)
+
+// Проверка подключения к интернету
+const (
+	connectivityProbeURL      = "https://jooble.org/"
+	connectivityCheckTimeout  = 5 * time.Second
+	connectivityCheckInterval = 30 * time.Second
+)
+
+// Подсказка на кнопках, которым нужна сеть
+const offlineToolTip = "Нет подключения к интернету. Кнопка станет доступна, когда сеть появится."
+
+// checkConnectivity коротким запросом проверяет, доступен ли сервис вакансий
+// (через тот же клиент и прокси, что и поиск)
+func checkConnectivity() bool {
+	ctx, cancel := context.WithTimeout(context.Background(), connectivityCheckTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "HEAD", connectivityProbeURL, nil)
+	if err != nil {
+		return false
+	}
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return true
+}
+
+// isOfflineError проверяет, что запрос не удался из-за отсутствия сети:
+// не разрешилось имя сервера или не удалось установить соединение
+func isOfflineError(err error) bool {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// startConnectivityMonitor проверяет сеть при запуске и затем периодически
+func (app *AppMainWindow) startConnectivityMonitor() {
+	go func() {
+		for {
+			online := checkConnectivity()
+			app.Synchronize(func() { app.setOnline(online) })
+			time.Sleep(connectivityCheckInterval)
+		}
+	}()
+}
+
+// setOnline обновляет кнопки, которым нужна сеть; при появлении сети выполняет отложенные поиски
+func (app *AppMainWindow) setOnline(online bool) {
+	wasOffline := app.offline
+	app.offline = !online
+	if wasOffline != app.offline {
+		log.Printf("Подключение к интернету: %v", online)
+	}
+
+	toolTip := ""
+	if app.offline {
+		toolTip = offlineToolTip
+	}
+	if app.onlineSearchButton != nil {
+		// Во время поиска кнопка выключена, её включит сам поиск по завершении
+		searching := app.cancelOnlineSearchButton != nil && app.cancelOnlineSearchButton.Visible()
+		if !searching {
+			app.onlineSearchButton.SetEnabled(online)
+		}
+		app.onlineSearchButton.SetToolTipText(toolTip)
+	}
+	if app.checkPostingPB != nil {
+		app.checkPostingPB.SetToolTipText(toolTip)
+		if app.offline {
+			app.checkPostingPB.SetEnabled(false)
+		} else if idx := app.vacancyTable.CurrentIndex(); idx >= 0 && idx < len(app.vacancyModel.items) {
+			app.checkPostingPB.SetEnabled(app.vacancyModel.items[idx].SourceURL != "")
+		}
+	}
+
+	if online {
+		app.refreshWatchedSearches()
+		app.pollFeeds()
+	}
+	// Неудавшиеся отложенные поиски возвращаются в очередь и повторяются при следующей проверке
+	if online && len(app.queuedSearches) > 0 {
+		app.runQueuedSearches()
+	}
+}
+
+// queueOnlineSearch откладывает поиск до появления сети
+func (app *AppMainWindow) queueOnlineSearch(term string) {
+	for _, queued := range app.queuedSearches {
+		if strings.EqualFold(queued, term) {
+			return
+		}
+	}
+	app.queuedSearches = append(app.queuedSearches, term)
+}
+
+// runQueuedSearches выполняет отложенные поиски в фоне (результаты попадают в кэш)
+// и предлагает открыть последний из них
+func (app *AppMainWindow) runQueuedSearches() {
+	terms := app.queuedSearches
+	app.queuedSearches = nil
+
+	go func() {
+		var lines, done, failed []string
+		for _, term := range terms {
+			found, err := searchOnline(context.Background(), term)
+			if err != nil {
+				log.Printf("Отложенный поиск '%s' не удался: %v", term, err)
+				failed = append(failed, term)
+				continue
+			}
+			lines = append(lines, fmt.Sprintf("• %s — найдено %d", term, len(found)))
+			done = append(done, term)
+		}
+
+		app.Synchronize(func() {
+			for _, term := range failed {
+				app.queueOnlineSearch(term)
+			}
+			if len(done) == 0 {
+				return
+			}
+			last := done[len(done)-1]
+			message := "Сеть снова доступна, отложенные поиски выполнены:\n" + strings.Join(lines, "\n")
+			if len(failed) > 0 {
+				message += "\n\nНе удалось выполнить: " + strings.Join(failed, ", ") + " - попробуем ещё раз позже."
+			}
+			message += "\n\nОткрыть результаты поиска '" + last + "'?"
+			if walk.DlgCmdYes == walk.MsgBox(app.MainWindow, "Онлайн поиск", message, walk.MsgBoxYesNo|walk.MsgBoxIconInformation) {
+				app.searchEdit.SetText(last)
+				app.switchToOnlineSearchMode()
+			}
+		})
+	}()
+}
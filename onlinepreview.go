@@ -0,0 +1,66 @@
+package main
+
+import (
+	"log"
+	"os/exec"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// openURL открывает ссылку в браузере по умолчанию
+func openURL(link string) error {
+	// В отличие от "cmd /c start", не ломается на символах & и ^ в адресе
+	return exec.Command("rundll32", "url.dll,FileProtocolHandler", link).Start()
+}
+
+// onlinePreviewPane - панель просмотра выбранного онлайн-результата справа от таблицы
+func (app *AppMainWindow) onlinePreviewPane() Widget {
+	caption := func(text string) Widget {
+		return Label{Text: text, Font: Font{Bold: true, PointSize: 9}}
+	}
+	return Composite{
+		AssignTo: &app.onlinePreviewPanel,
+		Layout:   VBox{Margins: Margins{Left: 8}, Spacing: 4},
+		MinSize:  Size{Width: 280},
+		Children: []Widget{
+			Label{AssignTo: &app.onlinePreviewTitle, Text: "Выберите вакансию в списке", Font: Font{PointSize: 10, Bold: true}},
+			Label{AssignTo: &app.onlinePreviewCompany, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true, Spacing: 4},
+				Children: []Widget{
+					caption("Зарплата:"),
+					Label{AssignTo: &app.onlinePreviewSalary, Font: Font{PointSize: 9}},
+					caption("Город:"),
+					Label{AssignTo: &app.onlinePreviewLocation, Font: Font{PointSize: 9}},
+					caption("Источник:"),
+					Label{AssignTo: &app.onlinePreviewSource, Font: Font{PointSize: 9}},
+					caption("Обновлено:"),
+					Label{AssignTo: &app.onlinePreviewPosted, Font: Font{PointSize: 9}},
+				},
+			},
+			LinkLabel{
+				AssignTo: &app.onlinePreviewLink,
+				Font:     Font{PointSize: 9},
+				OnLinkActivated: func(link *walk.LinkLabelLink) {
+					if err := openURL(link.URL()); err != nil {
+						log.Printf("Не удалось открыть ссылку %s: %v", link.URL(), err)
+					}
+				},
+			},
+			TextEdit{
+				AssignTo: &app.onlinePreviewTE,
+				ReadOnly: true,
+				VScroll:  true,
+				Font:     Font{PointSize: 9},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{Text: "Скрыть эту компанию", OnClicked: app.blockPreviewedCompany, Font: Font{Family: "Segoe UI", PointSize: 9}},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,466 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"mime"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/go-ole/go-ole"
+	"github.com/go-ole/go-ole/oleutil"
+	"github.com/lxn/walk"
+)
+
+// Автоматизация Microsoft Outlook через COM: встречи для собеседований и привязка писем к вакансиям
+const (
+	outlookSyncFile      = "outlook_appointments.json"
+	olAppointmentItem    = 1  // OlItemType
+	olMail               = 43 // OlObjectClass почтового сообщения
+	olDiscard            = 1  // OlInspectorClose
+	olBusy               = 2  // OlBusyStatus
+	outlookNoReceiveYear = 4000
+)
+
+var errOutlookUnavailable = errors.New("Microsoft Outlook не установлен или не отвечает")
+
+// Нулевая дата OLE Automation (VT_DATE) - количество дней от неё
+var oleDateEpoch = time.Date(1899, 12, 30, 0, 0, 0, 0, time.Local)
+
+// linkedMail - письмо, привязываемое к вакансии как запись журнала
+type linkedMail struct {
+	Subject     string
+	From        string
+	FromAddress string
+	At          time.Time
+}
+
+// journalText - текст записи журнала о письме
+func (m linkedMail) journalText() string {
+	text := "«" + firstNonEmpty(m.Subject, "(без темы)") + "»"
+	if m.From != "" {
+		text += " от " + m.From
+	}
+	return text
+}
+
+// outlookState - встречи Outlook, созданные для вакансий (EntryID по ключу вакансии)
+type outlookState struct {
+	Appointments map[string]string `json:"appointments"`
+}
+
+func loadOutlookState() outlookState {
+	state := outlookState{Appointments: map[string]string{}}
+	data, err := os.ReadFile(dataPath(outlookSyncFile))
+	if err != nil {
+		return state
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		log.Printf("Ошибка чтения %s: %v", outlookSyncFile, err)
+	}
+	if state.Appointments == nil {
+		state.Appointments = map[string]string{}
+	}
+	return state
+}
+
+func saveOutlookState(state outlookState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dataPath(outlookSyncFile), data, 0644)
+}
+
+// toOLEDate переводит время в VT_DATE: Outlook принимает его без разбора строк в локали пользователя
+func toOLEDate(t time.Time) float64 {
+	return t.In(time.Local).Sub(oleDateEpoch).Hours() / 24
+}
+
+// fromOLEDate переводит VT_DATE в местное время
+func fromOLEDate(d float64) time.Time {
+	days := math.Floor(d)
+	frac := time.Duration((d - days) * float64(24*time.Hour))
+	return oleDateEpoch.AddDate(0, 0, int(days)).Add(frac).Round(time.Second)
+}
+
+// withOutlook подключается к Outlook (запущенному или новому) и вызывает fn.
+// COM требует, чтобы все вызовы шли из одного потока, поэтому поток закрепляется на время работы.
+func withOutlook(fn func(outlook *ole.IDispatch) error) error {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	if err := ole.CoInitializeEx(0, ole.COINIT_APARTMENTTHREADED); err != nil {
+		var oleErr *ole.OleError
+		if !errors.As(err, &oleErr) || oleErr.Code() != 1 { // S_FALSE - COM уже инициализирован
+			return err
+		}
+	}
+	defer ole.CoUninitialize()
+
+	unknown, err := oleutil.CreateObject("Outlook.Application")
+	if err != nil {
+		log.Printf("Outlook: %v", err)
+		return errOutlookUnavailable
+	}
+	defer unknown.Release()
+	outlook, err := unknown.QueryInterface(ole.IID_IDispatch)
+	if err != nil {
+		log.Printf("Outlook: %v", err)
+		return errOutlookUnavailable
+	}
+	defer outlook.Release()
+	return fn(outlook)
+}
+
+// oleDispatch вызывает метод или читает свойство, возвращающее объект
+func oleDispatch(v *ole.VARIANT, err error) (*ole.IDispatch, error) {
+	if err != nil {
+		return nil, err
+	}
+	disp := v.ToIDispatch()
+	if disp == nil {
+		return nil, errors.New("объект Outlook недоступен")
+	}
+	return disp, nil
+}
+
+// oleString читает строковое свойство; при ошибке - пустая строка
+func oleString(disp *ole.IDispatch, name string) string {
+	v, err := oleutil.GetProperty(disp, name)
+	if err != nil {
+		return ""
+	}
+	defer v.Clear()
+	return strings.TrimSpace(v.ToString())
+}
+
+// oleInt читает целочисленное свойство
+func oleInt(disp *ole.IDispatch, name string) int {
+	v, err := oleutil.GetProperty(disp, name)
+	if err != nil {
+		return 0
+	}
+	defer v.Clear()
+	switch n := v.Value().(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int16:
+		return int(n)
+	}
+	return 0
+}
+
+// oleTime читает свойство-дату. Значение VT_DATE берём сами: go-ole отбрасывает у него время суток
+func oleTime(disp *ole.IDispatch, name string) time.Time {
+	v, err := oleutil.GetProperty(disp, name)
+	if err != nil || v.VT != ole.VT_DATE {
+		return time.Time{}
+	}
+	return fromOLEDate(math.Float64frombits(uint64(v.Val)))
+}
+
+// readOutlookMail читает письмо Outlook. У отправленных писем нет даты получения - берём дату отправки
+func readOutlookMail(item *ole.IDispatch) linkedMail {
+	m := linkedMail{
+		Subject:     oleString(item, "Subject"),
+		From:        oleString(item, "SenderName"),
+		FromAddress: oleString(item, "SenderEmailAddress"),
+		At:          oleTime(item, "ReceivedTime"),
+	}
+	if m.At.IsZero() || m.At.Year() > outlookNoReceiveYear {
+		m.At = oleTime(item, "SentOn")
+	}
+	if m.At.IsZero() || m.At.Year() > outlookNoReceiveYear {
+		m.At = time.Now()
+	}
+	return m
+}
+
+// outlookSelectedMails - письма, выделенные в активном окне Outlook
+func outlookSelectedMails() ([]linkedMail, error) {
+	var mails []linkedMail
+	err := withOutlook(func(outlook *ole.IDispatch) error {
+		explorer, err := oleDispatch(oleutil.CallMethod(outlook, "ActiveExplorer"))
+		if err != nil {
+			return errors.New("окно Outlook не открыто")
+		}
+		defer explorer.Release()
+		selection, err := oleDispatch(oleutil.GetProperty(explorer, "Selection"))
+		if err != nil {
+			return err
+		}
+		defer selection.Release()
+		for i := 1; i <= oleInt(selection, "Count"); i++ {
+			item, err := oleDispatch(oleutil.CallMethod(selection, "Item", i))
+			if err != nil {
+				continue
+			}
+			if oleInt(item, "Class") == olMail {
+				mails = append(mails, readOutlookMail(item))
+			}
+			item.Release()
+		}
+		return nil
+	})
+	return mails, err
+}
+
+// readMSGFiles открывает сохранённые письма Outlook (.msg)
+func readMSGFiles(paths []string) ([]linkedMail, error) {
+	var mails []linkedMail
+	err := withOutlook(func(outlook *ole.IDispatch) error {
+		ns, err := oleDispatch(oleutil.CallMethod(outlook, "GetNamespace", "MAPI"))
+		if err != nil {
+			return err
+		}
+		defer ns.Release()
+		for _, p := range paths {
+			item, err := oleDispatch(oleutil.CallMethod(ns, "OpenSharedItem", p))
+			if err != nil {
+				return fmt.Errorf("%s: %w", filepath.Base(p), err)
+			}
+			mails = append(mails, readOutlookMail(item))
+			oleutil.CallMethod(item, "Close", olDiscard)
+			item.Release()
+		}
+		return nil
+	})
+	return mails, err
+}
+
+// readEMLFile читает заголовки письма .eml; Outlook для этого не нужен
+func readEMLFile(path string) (linkedMail, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return linkedMail{}, err
+	}
+	defer f.Close()
+	msg, err := mail.ReadMessage(f)
+	if err != nil {
+		return linkedMail{}, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	raw := msg.Header.Get("Subject")
+	subject, err := new(mime.WordDecoder).DecodeHeader(raw)
+	if err != nil {
+		subject = raw
+	}
+	m := linkedMail{Subject: strings.TrimSpace(subject)}
+	if from, err := msg.Header.AddressList("From"); err == nil && len(from) > 0 {
+		m.From = firstNonEmpty(from[0].Name, from[0].Address)
+		m.FromAddress = from[0].Address
+	}
+	if m.At, err = msg.Header.Date(); err != nil {
+		if info, err := f.Stat(); err == nil {
+			m.At = info.ModTime()
+		}
+	}
+	return m, nil
+}
+
+// linkMails добавляет письма в журнал вакансии, пропуская уже привязанные; возвращает число новых
+func linkMails(v Vacancy, mails []linkedMail) (int, bool) {
+	added := 0
+	found := changeJournal(v.Title, v.Company, func(v *Vacancy) {
+		for _, m := range mails {
+			e := JournalEntry{At: m.At, Kind: journalMail, Text: m.journalText()}
+			duplicate := false
+			for _, old := range v.Journal {
+				if old.Kind == e.Kind && old.Text == e.Text && old.At.Equal(e.At) {
+					duplicate = true
+					break
+				}
+			}
+			if !duplicate {
+				v.Journal = append(v.Journal, e)
+				added++
+			}
+		}
+	})
+	return added, found
+}
+
+// reportLinkedMails сообщает результат привязки писем
+func (app *AppMainWindow) reportLinkedMails(v Vacancy, mails []linkedMail, err error) {
+	if err != nil {
+		log.Printf("Не удалось прочитать письма: %v", err)
+		showError(app.MainWindow, "Письма", wrapError("Не удалось прочитать письма", err))
+		return
+	}
+	if len(mails) == 0 {
+		walk.MsgBox(app.MainWindow, "Письма", "Выделите письма в Outlook или перетащите их на вакансию.", walk.MsgBoxIconInformation)
+		return
+	}
+	added, found := linkMails(v, mails)
+	if !found {
+		walk.MsgBox(app.MainWindow, "Ошибка", "Не удалось найти вакансию.", walk.MsgBoxIconError)
+		return
+	}
+	app.showToast("Письма привязаны", fmt.Sprintf("«%s»: новых записей в журнале - %d из %d", v.Title, added, len(mails)), nil)
+}
+
+// linkOutlookSelection привязывает к выбранной вакансии письма, выделенные в Outlook
+func (app *AppMainWindow) linkOutlookSelection() {
+	v, ok := app.selectedVacancyForAttachments()
+	if !ok {
+		return
+	}
+	go func() {
+		mails, err := outlookSelectedMails()
+		app.Synchronize(func() { app.reportLinkedMails(v, mails, err) })
+	}()
+}
+
+// linkMailFiles привязывает к выбранной вакансии перетащенные файлы писем .msg и .eml
+func (app *AppMainWindow) linkMailFiles(paths []string) {
+	v, ok := app.selectedVacancyForAttachments()
+	if !ok {
+		return
+	}
+	go func() {
+		var mails []linkedMail
+		var msg []string
+		var err error
+		for _, p := range paths {
+			if !strings.EqualFold(filepath.Ext(p), ".eml") {
+				msg = append(msg, p)
+				continue
+			}
+			var m linkedMail
+			if m, err = readEMLFile(p); err != nil {
+				break
+			}
+			mails = append(mails, m)
+		}
+		if err == nil && len(msg) > 0 {
+			var fromOutlook []linkedMail
+			fromOutlook, err = readMSGFiles(msg)
+			mails = append(mails, fromOutlook...)
+		}
+		app.Synchronize(func() { app.reportLinkedMails(v, mails, err) })
+	}()
+}
+
+// saveOutlookAppointment создаёт или обновляет встречу Outlook для собеседования и возвращает её EntryID.
+// Длительность и напоминание берутся из настроек календаря, как у событий Google Календаря.
+func saveOutlookAppointment(v Vacancy, entryID string) (string, error) {
+	event := gcalEventFor(v, appSettings.GoogleCalendar)
+	s := appSettings.GoogleCalendar
+	err := withOutlook(func(outlook *ole.IDispatch) error {
+		var item *ole.IDispatch
+		if entryID != "" {
+			if ns, err := oleDispatch(oleutil.CallMethod(outlook, "GetNamespace", "MAPI")); err == nil {
+				item, _ = oleDispatch(oleutil.CallMethod(ns, "GetItemFromID", entryID))
+				ns.Release()
+			}
+		}
+		if item == nil { // Встречи ещё нет или её удалили в Outlook
+			var err error
+			if item, err = oleDispatch(oleutil.CallMethod(outlook, "CreateItem", olAppointmentItem)); err != nil {
+				return err
+			}
+		}
+		defer item.Release()
+		body := event.Description
+		if event.Source != nil {
+			body += "\r\n\r\n" + event.Source.URL
+		}
+		for _, p := range []struct {
+			name  string
+			value interface{}
+		}{
+			{"Subject", event.Summary},
+			{"Start", toOLEDate(v.InterviewAt)},
+			{"Duration", firstPositive(s.DurationMinutes, defaultGcalLength)},
+			{"Body", strings.ReplaceAll(body, "\n", "\r\n")},
+			{"BusyStatus", olBusy},
+			{"ReminderSet", true},
+			{"ReminderMinutesBeforeStart", firstPositive(s.ReminderMinutes, defaultGcalRemind)},
+		} {
+			if _, err := oleutil.PutProperty(item, p.name, p.value); err != nil {
+				return fmt.Errorf("%s: %w", p.name, err)
+			}
+		}
+		if _, err := oleutil.CallMethod(item, "Save"); err != nil {
+			return err
+		}
+		entryID = oleString(item, "EntryID")
+		return nil
+	})
+	return entryID, err
+}
+
+// deleteOutlookAppointment удаляет встречу Outlook; уже удалённая встреча не считается ошибкой
+func deleteOutlookAppointment(entryID string) error {
+	return withOutlook(func(outlook *ole.IDispatch) error {
+		ns, err := oleDispatch(oleutil.CallMethod(outlook, "GetNamespace", "MAPI"))
+		if err != nil {
+			return err
+		}
+		defer ns.Release()
+		item, err := oleDispatch(oleutil.CallMethod(ns, "GetItemFromID", entryID))
+		if err != nil {
+			return nil
+		}
+		defer item.Release()
+		_, err = oleutil.CallMethod(item, "Delete")
+		return err
+	})
+}
+
+// outlookAppointmentForSelected создаёт или обновляет встречу Outlook для собеседования выбранной вакансии.
+// Если собеседование отменено, предлагает удалить созданную ранее встречу.
+func (app *AppMainWindow) outlookAppointmentForSelected() {
+	v, ok := app.selectedVacancyForAttachments()
+	if !ok {
+		return
+	}
+	key := statusTrackerKey(v.Title, v.Company)
+	entryID := loadOutlookState().Appointments[key]
+	if v.InterviewAt.IsZero() {
+		if entryID == "" {
+			walk.MsgBox(app.MainWindow, "Встреча в Outlook", "Для вакансии не назначено собеседование.", walk.MsgBoxIconInformation)
+			return
+		}
+		if walk.MsgBox(app.MainWindow, "Встреча в Outlook", "Собеседование отменено. Удалить встречу из календаря Outlook?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+			return
+		}
+	}
+
+	go func() {
+		var err error
+		if v.InterviewAt.IsZero() {
+			err = deleteOutlookAppointment(entryID)
+			entryID = ""
+		} else {
+			entryID, err = saveOutlookAppointment(v, entryID)
+		}
+		app.Synchronize(func() {
+			if err != nil {
+				log.Printf("Outlook: %v", err)
+				showError(app.MainWindow, "Встреча в Outlook", wrapError("Не удалось сохранить встречу", err))
+				return
+			}
+			state := loadOutlookState()
+			text := "Встреча в Outlook удалена"
+			if entryID == "" {
+				delete(state.Appointments, key)
+			} else {
+				state.Appointments[key] = entryID
+				text = "Встреча в Outlook на " + v.InterviewAt.Format("02.01.2006 15:04")
+			}
+			if err := saveOutlookState(state); err != nil {
+				log.Printf("Ошибка сохранения %s: %v", outlookSyncFile, err)
+			}
+			addJournalEntry(v.Title, v.Company, JournalEntry{At: time.Now(), Kind: journalCalendar, Text: text})
+		})
+	}()
+}
@@ -0,0 +1,334 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lxn/walk"
+)
+
+// pageRule - правила извлечения вакансии со страницы конкретного сайта.
+// Каждое поле - список селекторов (фрагментов регулярного выражения для открывающего тега),
+// которые пробуются по порядку: вёрстка сайтов меняется, и старые варианты оставляем.
+type pageRule struct {
+	Site        string
+	Hosts       []string
+	Title       []string
+	Company     []string
+	Location    []string
+	Salary      []string
+	Description []string
+}
+
+// byClass - селектор элемента с CSS-классом name
+func byClass(name string) string {
+	return `class="(?:[^"]*\s)?` + regexp.QuoteMeta(name) + `(?:\s[^"]*)?"`
+}
+
+// byAttr - селектор элемента с атрибутом attr="value"
+func byAttr(attr, value string) string {
+	return regexp.QuoteMeta(attr) + `="` + regexp.QuoteMeta(value) + `"`
+}
+
+// Правила для сайтов без открытого API, страницы которых сохраняют целиком
+var pageRules = []pageRule{
+	{
+		Site:  "LinkedIn",
+		Hosts: []string{"linkedin.com"},
+		Title: []string{byClass("top-card-layout__title"), byClass("job-details-jobs-unified-top-card__job-title"), byClass("topcard__title")},
+		Company: []string{byClass("topcard__org-name-link"), byClass("job-details-jobs-unified-top-card__company-name"),
+			byClass("topcard__flavor")},
+		Location:    []string{byClass("topcard__flavor--bullet"), byClass("job-details-jobs-unified-top-card__bullet")},
+		Salary:      []string{byClass("salary"), byClass("compensation__salary")},
+		Description: []string{byClass("show-more-less-html__markup"), byClass("jobs-description__content"), byClass("jobs-box__html-content")},
+	},
+	{
+		Site:  "Indeed",
+		Hosts: []string{"indeed.com"},
+		Title: []string{byClass("jobsearch-JobInfoHeader-title"), byAttr("data-testid", "jobsearch-JobInfoHeader-title")},
+		Company: []string{byAttr("data-testid", "inlineHeader-companyName"), byAttr("data-company-name", "true"),
+			byClass("jobsearch-InlineCompanyRating")},
+		Location:    []string{byAttr("data-testid", "inlineHeader-companyLocation"), byAttr("data-testid", "job-location"), byClass("jobsearch-JobInfoHeader-subtitle")},
+		Salary:      []string{byAttr("id", "salaryInfoAndJobType"), byAttr("data-testid", "attribute_snippet_testid")},
+		Description: []string{byAttr("id", "jobDescriptionText"), byClass("jobsearch-jobDescriptionText")},
+	},
+}
+
+// Адрес сохранённой страницы: canonical, og:url или комментарий "saved from url", который пишут браузеры
+var (
+	canonicalRe = regexp.MustCompile(`(?i)<link[^>]+rel="canonical"[^>]+href="([^"]+)"`)
+	ogURLRe     = regexp.MustCompile(`(?i)<meta[^>]+property="og:url"[^>]+content="([^"]+)"`)
+	savedFromRe = regexp.MustCompile(`(?i)<!-- saved from url=\(\d+\)(\S+) -->`)
+	ogTitleRe   = regexp.MustCompile(`(?i)<meta[^>]+property="og:title"[^>]+content="([^"]+)"`)
+	titleTagRe  = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	pageURLRe   = regexp.MustCompile(`https?://[^\s"'<>]+`)
+)
+
+// pageSourceURL ищет адрес, с которого была сохранена страница
+func pageSourceURL(page string) string {
+	for _, re := range []*regexp.Regexp{canonicalRe, ogURLRe, savedFromRe} {
+		if m := re.FindStringSubmatch(page); m != nil && validVacancyURL(html.UnescapeString(m[1])) {
+			return html.UnescapeString(m[1])
+		}
+	}
+	return ""
+}
+
+// pageRuleFor выбирает правила по адресу страницы, а если его нет - по упоминанию сайта в тексте
+func pageRuleFor(sourceURL, page string) *pageRule {
+	for i, rule := range pageRules {
+		for _, host := range rule.Hosts {
+			if sourceURL != "" && strings.Contains(strings.ToLower(sourceURL), host) {
+				return &pageRules[i]
+			}
+		}
+	}
+	if sourceURL != "" {
+		return nil
+	}
+	lower := strings.ToLower(page)
+	for i, rule := range pageRules {
+		for _, host := range rule.Hosts {
+			if strings.Contains(lower, host) {
+				return &pageRules[i]
+			}
+		}
+	}
+	return nil
+}
+
+// extractElement возвращает внутренний HTML первого элемента, подходящего под селектор,
+// с учётом вложенных элементов того же тега
+func extractElement(page, selector string) string {
+	open := regexp.MustCompile(`(?is)<([a-z][a-z0-9]*)\b[^>]*` + selector + `[^>]*>`)
+	loc := open.FindStringSubmatchIndex(page)
+	if loc == nil {
+		return ""
+	}
+	tag := page[loc[2]:loc[3]]
+	start := loc[1]
+	tagRe := regexp.MustCompile(`(?i)<(/?)` + tag + `\b[^>]*>`)
+	depth := 1
+	for _, m := range tagRe.FindAllStringSubmatchIndex(page[start:], -1) {
+		if m[3] > m[2] {
+			depth--
+		} else if !strings.HasSuffix(page[start+m[0]:start+m[1]], "/>") {
+			depth++
+		}
+		if depth == 0 {
+			return page[start : start+m[0]]
+		}
+	}
+	return page[start:]
+}
+
+// extractField пробует селекторы по порядку и возвращает первый непустой текст
+func extractField(page string, selectors []string, multiline bool) string {
+	for _, selector := range selectors {
+		text := htmlToText(extractElement(page, selector))
+		if !multiline {
+			text = strings.Join(strings.Fields(text), " ")
+		}
+		if text != "" {
+			return text
+		}
+	}
+	return ""
+}
+
+// jsonLDString достаёт строку из вложенных объектов JSON-LD по пути ключей;
+// массивы по дороге заменяются первым элементом
+func jsonLDString(obj any, path ...string) string {
+	for _, key := range path {
+		if arr, ok := obj.([]any); ok && len(arr) > 0 {
+			obj = arr[0]
+		}
+		m, ok := obj.(map[string]any)
+		if !ok {
+			return ""
+		}
+		obj = m[key]
+	}
+	s, _ := obj.(string)
+	return strings.TrimSpace(html.UnescapeString(s))
+}
+
+// jobPostingFromPage заполняет вакансию по разметке schema.org JobPosting, если она есть на странице
+func jobPostingFromPage(page string, v *Vacancy) bool {
+	for _, m := range jsonLDRe.FindAllStringSubmatch(page, -1) {
+		var data any
+		if json.Unmarshal([]byte(strings.TrimSpace(m[1])), &data) != nil {
+			continue
+		}
+		p, ok := findJobPosting(data)
+		if !ok {
+			continue
+		}
+		v.Title = jsonLDString(p, "title")
+		v.Company = jsonLDString(p, "hiringOrganization", "name")
+		v.Location = jsonLDString(p, "jobLocation", "address", "addressLocality")
+		v.Description = htmlToText(jsonLDString(p, "description"))
+		if salary := jobPostingSalary(p["baseSalary"]); salary != "" {
+			applySalary(v, salary)
+		}
+		if u := jsonLDString(p, "url"); validVacancyURL(u) {
+			v.SourceURL = u
+		}
+		if posted := jsonLDString(p, "datePosted"); posted != "" {
+			v.PostedAt = parseFeedDate(posted)
+		}
+		return v.Title != ""
+	}
+	return false
+}
+
+// parsePostingHTML извлекает вакансию из сохранённой страницы: сначала из разметки JobPosting,
+// недостающие поля - по правилам сайта, а для незнакомых сайтов - из заголовка и текста страницы
+func parsePostingHTML(page string) (Vacancy, string, error) {
+	v := Vacancy{
+		Keywords:        []string{},
+		Status:          possibleStatuses[0],
+		ExperienceLevel: possibleExperienceLevels[0],
+		SourceURL:       pageSourceURL(page),
+	}
+	jobPostingFromPage(page, &v)
+
+	site := "страница"
+	if rule := pageRuleFor(v.SourceURL, page); rule != nil {
+		site = rule.Site
+		fill := func(field *string, selectors []string, multiline bool) {
+			if *field == "" {
+				*field = extractField(page, selectors, multiline)
+			}
+		}
+		fill(&v.Title, rule.Title, false)
+		fill(&v.Company, rule.Company, false)
+		fill(&v.Location, rule.Location, false)
+		fill(&v.Description, rule.Description, true)
+		if v.Salary == "" {
+			if salary := extractField(page, rule.Salary, false); salary != "" {
+				applySalary(&v, salary)
+			}
+		}
+	}
+
+	if v.Title == "" {
+		if m := ogTitleRe.FindStringSubmatch(page); m != nil {
+			v.Title = html.UnescapeString(m[1])
+		} else if m := titleTagRe.FindStringSubmatch(page); m != nil {
+			v.Title = strings.Join(strings.Fields(html.UnescapeString(m[1])), " ")
+		}
+	}
+	if v.Description == "" {
+		v.Description = parsePostingPage(page).Description
+	}
+	v.Title, v.Company, v.Location = sanitizeLine(v.Title), sanitizeLine(v.Company), sanitizeLine(v.Location)
+	v.Description = sanitizeDescription(v.Description)
+	if v.Title == "" {
+		return v, site, errors.New("не удалось найти название вакансии на странице")
+	}
+	autoDetectExperience(&v)
+	return v, site, nil
+}
+
+// parsePostingText разбирает текст вакансии, скопированный со страницы:
+// первая строка - название, дальше компания и город. LinkedIn пишет их в одну строку через " · ".
+func parsePostingText(text string) (Vacancy, error) {
+	v := Vacancy{
+		Keywords:        []string{},
+		Status:          possibleStatuses[0],
+		ExperienceLevel: possibleExperienceLevels[0],
+	}
+	if m := pageURLRe.FindString(text); validVacancyURL(m) {
+		v.SourceURL = m
+	}
+	var lines []string
+	for _, line := range splitLines(text) {
+		if line != v.SourceURL {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return v, errors.New("в буфере обмена нет текста вакансии")
+	}
+	v.Title = lines[0]
+	rest := lines[1:]
+	if len(rest) > 0 && strings.Contains(rest[0], " · ") {
+		parts := strings.Split(rest[0], " · ")
+		v.Company = strings.TrimSpace(parts[0])
+		if len(parts) > 1 {
+			v.Location = strings.TrimSpace(parts[1])
+		}
+		rest = rest[1:]
+	} else {
+		// Короткие строки под названием - компания и город, как на Indeed
+		if len(rest) > 0 && len([]rune(rest[0])) <= 80 {
+			v.Company, rest = rest[0], rest[1:]
+		}
+		if len(rest) > 0 && len([]rune(rest[0])) <= 60 {
+			v.Location, rest = rest[0], rest[1:]
+		}
+	}
+	v.Description = sanitizeDescription(strings.Join(rest, "\n"))
+	autoDetectExperience(&v)
+	return v, nil
+}
+
+// openImportedPosting открывает извлечённую вакансию в диалоге добавления для проверки
+func (app *AppMainWindow) openImportedPosting(v Vacancy) {
+	app.openImportedVacancy(v)
+}
+
+// importPostingPageFile разбирает сохранённую страницу вакансии
+func (app *AppMainWindow) importPostingPageFile(path string) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось прочитать файл", err))
+		return
+	}
+	v, site, err := parsePostingHTML(string(data))
+	if err != nil {
+		log.Printf("Импорт страницы %s: %v", path, err)
+		walk.MsgBox(app.MainWindow, "Импорт со страницы", fmt.Sprintf("%s (%s):\n%v", filepath.Base(path), site, err), walk.MsgBoxIconWarning)
+		return
+	}
+	log.Printf("Вакансия '%s' импортирована со страницы %s (%s)", v.Title, filepath.Base(path), site)
+	app.openImportedPosting(v)
+}
+
+// importPostingPage предлагает выбрать страницу вакансии, сохранённую из браузера
+func (app *AppMainWindow) importPostingPage() {
+	fd := new(walk.FileDialog)
+	fd.Title = "Импорт вакансии со страницы LinkedIn, Indeed и других сайтов"
+	fd.Filter = "Веб-страница (*.html;*.htm)|*.html;*.htm|Все файлы (*.*)|*.*"
+	if ok, err := fd.ShowOpen(app.MainWindow); err == nil && ok {
+		app.importPostingPageFile(fd.FilePath)
+	}
+}
+
+// importPostingFromClipboard создаёт вакансию из скопированного текста или HTML страницы
+func (app *AppMainWindow) importPostingFromClipboard() {
+	text, err := walk.Clipboard().Text()
+	if err != nil || strings.TrimSpace(text) == "" {
+		walk.MsgBox(app.MainWindow, "Вставить вакансию", "Скопируйте текст вакансии (или код страницы) и повторите.", walk.MsgBoxIconInformation)
+		return
+	}
+	var v Vacancy
+	if strings.Contains(strings.ToLower(text), "<html") || strings.Contains(text, "</div>") {
+		v, _, err = parsePostingHTML(text)
+	} else {
+		v, err = parsePostingText(text)
+	}
+	if err != nil {
+		walk.MsgBox(app.MainWindow, "Вставить вакансию", err.Error(), walk.MsgBoxIconWarning)
+		return
+	}
+	app.openImportedPosting(v)
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Сколько строк показывать в палитре команд
+const paletteMaxItems = 50
+
+// Префикс строк-вакансий в палитре
+const paletteVacancyPrefix = "Вакансия: "
+
+// paletteCommand - действие, доступное из палитры
+type paletteCommand struct {
+	Name string
+	Run  func()
+}
+
+// paletteItem - строка палитры: команда или переход к вакансии
+type paletteItem struct {
+	Label   string
+	Match   string // Текст, по которому ищем
+	Vacancy bool
+	score   int
+	run     func()
+}
+
+// fuzzyScore проверяет, что буквы запроса встречаются в тексте по порядку, и оценивает совпадение:
+// выше - буквы подряд и в начале слов, как в палитрах команд редакторов. Пробелы в запросе не учитываются.
+func fuzzyScore(query, text string) (int, bool) {
+	q := []rune(strings.ToLower(strings.Join(strings.Fields(query), "")))
+	if len(q) == 0 {
+		return 0, true
+	}
+	t := []rune(strings.ToLower(text))
+	score, prev, ti := 0, -2, 0
+	for _, r := range q {
+		for ti < len(t) && t[ti] != r {
+			ti++
+		}
+		if ti == len(t) {
+			return 0, false
+		}
+		score++
+		if ti == prev+1 {
+			score += 5
+		}
+		if ti == 0 || !unicode.IsLetter(t[ti-1]) && !unicode.IsDigit(t[ti-1]) {
+			score += 8
+		}
+		prev = ti
+		ti++
+	}
+	return score - len(t)/10, true
+}
+
+// paletteCommands - команды палитры: кнопки главного окна и пункты меню
+func (app *AppMainWindow) paletteCommands() []paletteCommand {
+	return []paletteCommand{
+		{"Добавить вакансию", app.showAddVacancyDialog},
+		{"Изменить вакансию", app.showEditVacancyDialog},
+		{"Удалить вакансию", app.confirmDeleteVacancy},
+		{"Дублировать вакансию", app.duplicateSelectedVacancy},
+		{"Сохранить изменения вакансии", app.saveVacancyDetails},
+		{"Переключить тему (светлая/тёмная)", app.toggleTheme},
+		{"Режим презентации", app.togglePresentationMode},
+		{"Онлайн поиск", app.switchToOnlineSearchMode},
+		{"Локальные вакансии", app.switchToLocalMode},
+		{"Найти", app.performSearch},
+		{"Поиск везде", app.showGlobalSearch},
+		{"Синонимы поиска", app.showSynonymsDialog},
+		{"Назад", app.navigateBack},
+		{"Вперёд", app.navigateForward},
+		{"Архив резюме", app.showResumeArchive},
+		{"Статистика", app.showStatistics},
+		{"Сформировать отчёт", app.showReportDialog},
+		{"Сравнить офферы", app.showOfferComparison},
+		{"Сегодня", app.showAgenda},
+		{"Итоги по неделям", app.showWeeklySummary},
+		{"Напоминания", app.showRemindersDialog},
+		{"Банк вопросов", app.showQuestionBank},
+		{"Шаблоны вакансий", app.showTemplatesDialog},
+		{"Сохранить вакансию как шаблон", app.saveSelectedAsTemplate},
+		{"Открыть вакансию в отдельном окне", app.openSelectedVacancyWindow},
+		{"Ручной порядок вакансий", app.toggleManualOrder},
+		{"Отменить последнее действие", app.undoLast},
+		{"Уведомления", app.showNotificationHistory},
+		{"Настройки", app.showSettingsDialog},
+		{"Переместить вакансию выше", func() { app.moveSelectedVacancy(-1) }},
+		{"Переместить вакансию ниже", func() { app.moveSelectedVacancy(1) }},
+		{"Поделиться вакансией", app.shareSelectedVacancy},
+		{"Показать QR-код вакансии", app.showQRForSelected},
+		{"Импортировать вакансию", app.importSharedVacancy},
+		{"Импорт со страницы LinkedIn/Indeed", app.importPostingPage},
+		{"Закладка для браузера", app.showBookmarkletDialog},
+		{"Вставить вакансию из буфера обмена", app.importPostingFromClipboard},
+		{"Импорт из CSV/Excel", app.importVacanciesFile},
+		{"Проверить ссылки вакансий", app.checkPostingLinks},
+		{"Экспорт через расширение", app.exportWithPlugin},
+		{"Выгрузка в Trello и Jira", app.showBoardExportDialog},
+		{"Синхронизация с Notion", app.showNotionDialog},
+		{"Google Календарь", app.showGoogleCalendarDialog},
+		{"Встреча в Outlook для собеседования", app.outlookAppointmentForSelected},
+		{"Письма из Outlook", app.linkOutlookSelection},
+		{"Написать рекрутеру", app.mailRecruiterForSelected},
+		{"Резюме под вакансию", app.showResumeForSelected},
+		{"Сопроводительное письмо", app.showCoverLetterForSelected},
+		{"Прикрепить файлы", app.addAttachmentFiles},
+		{"Вставить изображение из буфера", app.pasteAttachment},
+		{"Создать резервную копию", app.backupNow},
+		{"Восстановить из резервной копии", app.showRestoreWizard},
+		{"Экспорт настроек", app.exportConfig},
+		{"Импорт настроек", app.importConfig},
+		{"Профиль и навыки", app.showProfileDialog},
+		{"Цель по откликам", app.showGoalDialog},
+		{"Чёрный список", app.showBlocklistDialog},
+		{"История онлайн-поиска", app.showSearchHistory},
+		{"Вакансии из RSS-лент", app.showFeedVacancies},
+		{"Вакансии из Telegram", app.showTelegramQueue},
+		{"Настройки: курсы валют", app.showCurrencySettings},
+		{"Настройки: резервное копирование", app.showBackupSettings},
+		{"Настройки: период ожидания после отказа", app.showCooldownSettings},
+		{"Настройки: follow-up по откликам", app.showFollowUpSettings},
+		{"Настройки: RSS-ленты", app.showFeedSettings},
+		{"Настройки: перенос полей провайдеров", app.showFieldMappingDialog},
+		{"Настройки: онлайн-поиск", app.showOnlineSearchSettings},
+		{"Настройки: сеть", app.showNetworkSettings},
+		{"Настройки: оформление", app.showAppearanceSettings},
+		{"Настройки: шрифты", app.showFontSettings},
+		{"Настройки: SMTP", app.showSMTPSettings},
+		{"Расширения", app.showPluginsDialog},
+		{"Сценарии", app.showScriptsEditor},
+		{"Веб-хуки", app.showWebhooksDialog},
+		{"Проверить обновления", func() { app.checkForUpdates(true) }},
+		{"Обратная связь", app.showFeedbackDialog},
+	}
+}
+
+// paletteItems - все строки палитры: сначала команды, затем вакансии
+func (app *AppMainWindow) paletteItems() []paletteItem {
+	var items []paletteItem
+	for _, c := range app.paletteCommands() {
+		items = append(items, paletteItem{Label: c.Name, Match: c.Name, run: func() {
+			recordFeatureUse("Палитра: " + c.Name)
+			c.Run()
+		}})
+	}
+	for _, v := range snapshotVacancies() {
+		title, company := v.Title, v.Company
+		match := title
+		if company != "" {
+			match += " — " + company
+		}
+		items = append(items, paletteItem{
+			Label:   paletteVacancyPrefix + match,
+			Match:   match,
+			Vacancy: true,
+			run: func() {
+				if !app.selectVacancy(title, company) {
+					walk.MsgBox(app.MainWindow, "Палитра команд", "Вакансия '"+title+"' скрыта фильтрами или архивом.", walk.MsgBoxIconWarning)
+				}
+			},
+		})
+	}
+	return items
+}
+
+// filterPalette отбирает подходящие строки; лучшие совпадения выше, при равенстве команды идут раньше вакансий
+func filterPalette(items []paletteItem, query string) []paletteItem {
+	var found []paletteItem
+	for _, it := range items {
+		if score, ok := fuzzyScore(query, it.Match); ok {
+			it.score = score
+			found = append(found, it)
+		}
+	}
+	if strings.TrimSpace(query) != "" {
+		sort.SliceStable(found, func(i, j int) bool {
+			if found[i].score != found[j].score {
+				return found[i].score > found[j].score
+			}
+			return !found[i].Vacancy && found[j].Vacancy
+		})
+	}
+	if len(found) > paletteMaxItems {
+		found = found[:paletteMaxItems]
+	}
+	return found
+}
+
+// showCommandPalette - палитра команд (Ctrl+P): нечёткий поиск по командам и вакансиям,
+// Enter выполняет команду или переходит к вакансии
+func (app *AppMainWindow) showCommandPalette() {
+	var dlg *walk.Dialog
+	var queryLE *walk.LineEdit
+	var listLB *walk.ListBox
+	var runPB, closePB *walk.PushButton
+	var chosen *paletteItem
+
+	all := app.paletteItems()
+	var shown []paletteItem
+	refresh := func() {
+		if listLB == nil {
+			return
+		}
+		shown = filterPalette(all, queryLE.Text())
+		labels := make([]string, len(shown))
+		for i, it := range shown {
+			labels[i] = it.Label
+		}
+		listLB.SetModel(labels)
+		if len(shown) > 0 {
+			listLB.SetCurrentIndex(0)
+		}
+	}
+	accept := func() {
+		if i := listLB.CurrentIndex(); i >= 0 && i < len(shown) {
+			chosen = &shown[i]
+			dlg.Accept()
+		}
+	}
+	// Стрелки в поле запроса двигают выделение в списке
+	move := func(key walk.Key) {
+		i := listLB.CurrentIndex()
+		switch key {
+		case walk.KeyDown:
+			i++
+		case walk.KeyUp:
+			i--
+		default:
+			return
+		}
+		if i >= 0 && i < len(shown) {
+			listLB.SetCurrentIndex(i)
+		}
+	}
+
+	if err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Палитра команд",
+		DefaultButton: &runPB,
+		CancelButton:  &closePB,
+		MinSize:       Size{Width: 560, Height: 420},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			LineEdit{
+				AssignTo:      &queryLE,
+				CueBanner:     "Команда или вакансия, например «доб вак» или «стат»",
+				Font:          Font{PointSize: 11},
+				OnTextChanged: func() { refresh() },
+				OnKeyDown:     move,
+			},
+			ListBox{
+				AssignTo:        &listLB,
+				Font:            Font{PointSize: 10},
+				OnItemActivated: accept,
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 5},
+				Children: []Widget{
+					Label{Text: "↑↓ - выбор, Enter - выполнить, Esc - закрыть", TextColor: currentTheme.Text},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &runPB,
+						Text:       "Выполнить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  accept,
+					},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	refresh()
+	queryLE.SetFocus()
+	dlg.Run()
+
+	if chosen != nil {
+		chosen.run()
+	}
+}
@@ -0,0 +1,504 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+	"golang.org/x/sys/windows"
+)
+
+// Точки расширения: источники вакансий, форматы экспорта и каналы уведомлений.
+//
+// Расширения - программы *.exe в папке plugins рядом с данными. Пакет plugin в Go
+// под Windows не работает, поэтому приложение запускает программу на каждый вызов,
+// пишет в stdin один JSON-запрос и читает из stdout один JSON-ответ:
+//
+//	{"method": "describe"}                                      -> {"name": "...", "provides": ["provider", "exporter", "notifier"], "extension": ".csv"}
+//	{"method": "search", "query": "go developer"}               -> {"vacancies": [...]}
+//	{"method": "export", "path": "C:\\...", "vacancies": [...]} -> {}
+//	{"method": "notify", "title": "...", "text": "..."}         -> {}
+//
+// Вакансии передаются в том же виде, что и в vacancies.json. Непустое поле "error"
+// в ответе считается ошибкой вызова.
+
+// JobProvider - источник вакансий для онлайн-поиска
+type JobProvider interface {
+	Name() string
+	Search(ctx context.Context, query string) ([]Vacancy, error)
+}
+
+// Exporter - формат, в который можно выгрузить список вакансий
+type Exporter interface {
+	Name() string
+	Extension() string // Расширение файла с точкой, например ".csv"
+	Export(path string, vacancies []Vacancy) error
+}
+
+// Notifier - канал, в который дублируются всплывающие уведомления
+type Notifier interface {
+	Name() string
+	Notify(title, text string) error
+}
+
+// Возможности расширения в ответе на describe
+const (
+	pluginKindProvider = "provider"
+	pluginKindExporter = "exporter"
+	pluginKindNotifier = "notifier"
+)
+
+const (
+	pluginsDirName        = "plugins"
+	pluginDescribeTimeout = 10 * time.Second
+	pluginCallTimeout     = 2 * time.Minute
+	pluginErrorMaxLen     = 300 // Сколько символов stderr показывать в ошибке
+)
+
+// pluginRequest - запрос к программе-расширению
+type pluginRequest struct {
+	Method    string    `json:"method"`
+	Query     string    `json:"query,omitempty"`
+	Path      string    `json:"path,omitempty"`
+	Vacancies []Vacancy `json:"vacancies,omitempty"`
+	Title     string    `json:"title,omitempty"`
+	Text      string    `json:"text,omitempty"`
+}
+
+// pluginResponse - ответ программы-расширения
+type pluginResponse struct {
+	Name      string    `json:"name,omitempty"`
+	Provides  []string  `json:"provides,omitempty"`
+	Extension string    `json:"extension,omitempty"`
+	Vacancies []Vacancy `json:"vacancies,omitempty"`
+	Error     string    `json:"error,omitempty"`
+}
+
+// externalPlugin - программа из папки plugins; реализует все три интерфейса,
+// а регистрируется только там, что объявила в ответе на describe
+type externalPlugin struct {
+	path      string
+	name      string
+	provides  []string
+	extension string
+}
+
+// pluginRegistry - найденные расширения и ошибки их загрузки
+type pluginRegistry struct {
+	mu        sync.Mutex
+	plugins   []*externalPlugin
+	providers []JobProvider
+	exporters []Exporter
+	notifiers []Notifier
+	errors    []string
+}
+
+var plugins pluginRegistry
+
+// pluginsDir возвращает папку расширений
+func pluginsDir() string {
+	return dataPath(pluginsDirName)
+}
+
+// call запускает программу расширения с одним запросом
+func (p *externalPlugin) call(ctx context.Context, req pluginRequest) (pluginResponse, error) {
+	var resp pluginResponse
+	input, err := json.Marshal(req)
+	if err != nil {
+		return resp, err
+	}
+	cmd := exec.CommandContext(ctx, p.path)
+	cmd.Dir = filepath.Dir(p.path)
+	// Консольные расширения не должны показывать окно
+	cmd.SysProcAttr = &syscall.SysProcAttr{HideWindow: true, CreationFlags: windows.CREATE_NO_WINDOW}
+	cmd.Stdin = bytes.NewReader(input)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return resp, fmt.Errorf("расширение %s не ответило вовремя", p.displayName())
+	}
+	if ctx.Err() == context.Canceled {
+		return resp, ctx.Err()
+	}
+	if err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if len([]rune(msg)) > pluginErrorMaxLen {
+			msg = string([]rune(msg)[:pluginErrorMaxLen]) + "..."
+		}
+		if msg != "" {
+			return resp, fmt.Errorf("расширение %s: %v (%s)", p.displayName(), err, msg)
+		}
+		return resp, fmt.Errorf("расширение %s: %w", p.displayName(), err)
+	}
+	if err := json.Unmarshal(bytes.TrimSpace(out), &resp); err != nil {
+		return resp, fmt.Errorf("расширение %s вернуло неверный ответ: %w", p.displayName(), err)
+	}
+	if resp.Error != "" {
+		return resp, fmt.Errorf("расширение %s: %s", p.displayName(), resp.Error)
+	}
+	return resp, nil
+}
+
+// displayName - имя из describe или имя файла, если расширение ещё не ответило
+func (p *externalPlugin) displayName() string {
+	if p.name != "" {
+		return p.name
+	}
+	return filepath.Base(p.path)
+}
+
+func (p *externalPlugin) Name() string { return p.displayName() }
+
+func (p *externalPlugin) Extension() string { return p.extension }
+
+// Search ищет вакансии; отмена ctx прерывает программу
+func (p *externalPlugin) Search(ctx context.Context, query string) ([]Vacancy, error) {
+	ctx, cancel := context.WithTimeout(ctx, pluginCallTimeout)
+	defer cancel()
+	resp, err := p.call(ctx, pluginRequest{Method: "search", Query: query})
+	if err != nil {
+		return nil, err
+	}
+	for i := range resp.Vacancies {
+		if resp.Vacancies[i].Source == "" {
+			resp.Vacancies[i].Source = p.displayName()
+		}
+	}
+	return resp.Vacancies, nil
+}
+
+func (p *externalPlugin) Export(path string, vacancies []Vacancy) error {
+	ctx, cancel := context.WithTimeout(context.Background(), pluginCallTimeout)
+	defer cancel()
+	_, err := p.call(ctx, pluginRequest{Method: "export", Path: path, Vacancies: vacancies})
+	return err
+}
+
+func (p *externalPlugin) Notify(title, text string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), pluginDescribeTimeout)
+	defer cancel()
+	_, err := p.call(ctx, pluginRequest{Method: "notify", Title: title, Text: text})
+	return err
+}
+
+// describePlugin спрашивает у программы, что она умеет
+func describePlugin(path string) (*externalPlugin, error) {
+	p := &externalPlugin{path: path}
+	ctx, cancel := context.WithTimeout(context.Background(), pluginDescribeTimeout)
+	defer cancel()
+	resp, err := p.call(ctx, pluginRequest{Method: "describe"})
+	if err != nil {
+		return nil, err
+	}
+	p.name = strings.TrimSpace(resp.Name)
+	p.provides = resp.Provides
+	p.extension = strings.TrimSpace(resp.Extension)
+	if p.extension != "" && !strings.HasPrefix(p.extension, ".") {
+		p.extension = "." + p.extension
+	}
+	if slices.Contains(p.provides, pluginKindExporter) && p.extension == "" {
+		return nil, fmt.Errorf("расширение %s не указало расширение файла для экспорта", p.displayName())
+	}
+	return p, nil
+}
+
+// loadPlugins заново находит расширения в папке plugins. Вызывается в фоне:
+// каждое расширение запускается, чтобы узнать его возможности.
+func loadPlugins() {
+	var found []*externalPlugin
+	var errs []string
+	entries, err := os.ReadDir(pluginsDir())
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		errs = append(errs, err.Error())
+	}
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".exe") {
+			continue
+		}
+		p, err := describePlugin(filepath.Join(pluginsDir(), e.Name()))
+		if err != nil {
+			log.Printf("Ошибка загрузки расширения: %v", err)
+			errs = append(errs, err.Error())
+			continue
+		}
+		found = append(found, p)
+	}
+
+	plugins.mu.Lock()
+	defer plugins.mu.Unlock()
+	plugins.plugins = found
+	plugins.errors = errs
+	plugins.providers, plugins.exporters, plugins.notifiers = nil, nil, nil
+	for _, p := range found {
+		if slices.Contains(p.provides, pluginKindProvider) {
+			plugins.providers = append(plugins.providers, p)
+		}
+		if slices.Contains(p.provides, pluginKindExporter) {
+			plugins.exporters = append(plugins.exporters, p)
+		}
+		if slices.Contains(p.provides, pluginKindNotifier) {
+			plugins.notifiers = append(plugins.notifiers, p)
+		}
+	}
+}
+
+// jobProviders возвращает источники вакансий из расширений
+func jobProviders() []JobProvider {
+	plugins.mu.Lock()
+	defer plugins.mu.Unlock()
+	return slices.Clone(plugins.providers)
+}
+
+// exporters возвращает форматы экспорта из расширений
+func exporters() []Exporter {
+	plugins.mu.Lock()
+	defer plugins.mu.Unlock()
+	return slices.Clone(plugins.exporters)
+}
+
+// notifiers возвращает каналы уведомлений из расширений
+func notifiers() []Notifier {
+	plugins.mu.Lock()
+	defer plugins.mu.Unlock()
+	return slices.Clone(plugins.notifiers)
+}
+
+// pluginSources - источники вакансий из расширений для онлайн-поиска
+func pluginSources(query string) []onlineSource {
+	var sources []onlineSource
+	for _, p := range jobProviders() {
+		sources = append(sources, onlineSource{
+			Name: p.Name(),
+			Search: func(ctx context.Context) ([]Vacancy, error) {
+				vacancies, err := p.Search(ctx, query)
+				for i := range vacancies {
+					autoDetectExperience(&vacancies[i])
+				}
+				return vacancies, err
+			},
+		})
+	}
+	return sources
+}
+
+// notifyPlugins дублирует уведомление в каналы расширений, не задерживая интерфейс
+func notifyPlugins(title, text string) {
+	list := notifiers()
+	if len(list) == 0 {
+		return
+	}
+	go func() {
+		for _, n := range list {
+			if err := n.Notify(title, text); err != nil {
+				log.Printf("Ошибка отправки уведомления: %v", err)
+			}
+		}
+	}()
+}
+
+// exportWithPlugin выгружает все вакансии в формат, выбранный из расширений
+func (app *AppMainWindow) exportWithPlugin() {
+	list := exporters()
+	if len(list) == 0 {
+		walk.MsgBox(app.MainWindow, "Экспорт", "Нет расширений с форматами экспорта.\nПоложите программу расширения в папку "+pluginsDir()+" и обновите список в окне «Расширения».", walk.MsgBoxIconInformation)
+		return
+	}
+	names := make([]string, len(list))
+	for i, e := range list {
+		names[i] = e.Name() + " (" + e.Extension() + ")"
+	}
+
+	var dlg *walk.Dialog
+	var formatCB *walk.ComboBox
+	var acceptPB, cancelPB *walk.PushButton
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Экспорт через расширение",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 360, Height: 140},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Формат:", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			ComboBox{AssignTo: &formatCB, Model: names, CurrentIndex: 0},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Экспортировать...",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Accept() },
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	if dlg.Result() != walk.DlgCmdOK || formatCB.CurrentIndex() < 0 {
+		return
+	}
+	exporter := list[formatCB.CurrentIndex()]
+
+	fileDlg := new(walk.FileDialog)
+	fileDlg.Title = "Сохранить вакансии"
+	fileDlg.Filter = exporter.Name() + " (*" + exporter.Extension() + ")|*" + exporter.Extension()
+	fileDlg.FilePath = "Вакансии " + time.Now().Format("2006-01-02") + exporter.Extension()
+	ok, err := fileDlg.ShowSave(app.MainWindow)
+	if err != nil {
+		log.Print("File dialog error: ", err)
+		return
+	}
+	if !ok {
+		return
+	}
+	path := fileDlg.FilePath
+	if !strings.EqualFold(filepath.Ext(path), exporter.Extension()) {
+		path += exporter.Extension()
+	}
+
+	vacancies := snapshotVacancies()
+	go func() {
+		err := exporter.Export(path, vacancies)
+		app.Synchronize(func() {
+			if err != nil {
+				showError(app.MainWindow, "Ошибка экспорта", err)
+				return
+			}
+			app.notifyFileSaved(fmt.Sprintf("Выгружено вакансий: %d", len(vacancies)), path)
+		})
+	}()
+}
+
+// pluginSummary - строка списка расширений в окне «Расширения»
+func pluginSummary(p *externalPlugin) string {
+	var kinds []string
+	for _, kind := range p.provides {
+		switch kind {
+		case pluginKindProvider:
+			kinds = append(kinds, "поиск вакансий")
+		case pluginKindExporter:
+			kinds = append(kinds, "экспорт в "+p.extension)
+		case pluginKindNotifier:
+			kinds = append(kinds, "уведомления")
+		}
+	}
+	if len(kinds) == 0 {
+		kinds = append(kinds, "ничего не предоставляет")
+	}
+	return p.displayName() + " - " + strings.Join(kinds, ", ") + " (" + filepath.Base(p.path) + ")"
+}
+
+// showPluginsDialog показывает найденные расширения и ошибки их загрузки
+func (app *AppMainWindow) showPluginsDialog() {
+	var dlg *walk.Dialog
+	var pluginsLB *walk.ListBox
+	var errorsTE *walk.TextEdit
+	var reloadPB, closePB *walk.PushButton
+
+	refresh := func() {
+		plugins.mu.Lock()
+		items := make([]string, len(plugins.plugins))
+		for i, p := range plugins.plugins {
+			items[i] = pluginSummary(p)
+		}
+		errs := strings.Join(plugins.errors, "\r\n")
+		plugins.mu.Unlock()
+		if len(items) == 0 {
+			items = []string{"Расширения не найдены"}
+		}
+		pluginsLB.SetModel(items)
+		errorsTE.SetText(errs)
+	}
+
+	if err := (Dialog{
+		AssignTo:     &dlg,
+		Title:        "Расширения",
+		CancelButton: &closePB,
+		MinSize:      Size{Width: 520, Height: 380},
+		Layout:       VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:   SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Расширения - программы .exe в папке " + pluginsDir() + ".\nОни добавляют источники онлайн-поиска, форматы экспорта и каналы уведомлений.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			ListBox{AssignTo: &pluginsLB},
+			Label{Text: "Ошибки загрузки:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			TextEdit{AssignTo: &errorsTE, ReadOnly: true, VScroll: true, MaxSize: Size{Height: 90}, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Открыть папку",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if err := os.MkdirAll(pluginsDir(), 0755); err != nil {
+								showError(dlg, "Ошибка", err)
+								return
+							}
+							if err := openFileExternally(pluginsDir()); err != nil {
+								showError(dlg, "Ошибка", err)
+							}
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &reloadPB,
+						Text:       "Обновить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							reloadPB.SetEnabled(false)
+							go func() {
+								loadPlugins()
+								dlg.Synchronize(func() {
+									reloadPB.SetEnabled(true)
+									refresh()
+								})
+							}()
+						},
+					},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	refresh()
+	dlg.Run()
+}
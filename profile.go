@@ -0,0 +1,345 @@
+package main
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Сколько ключевых слов для оценки совпадения предлагать после импорта
+const maxMatchKeywords = 30
+
+// importedProfile - данные профиля из выгрузки LinkedIn или JSON Resume
+type importedProfile struct {
+	Name      string
+	Headline  string
+	Location  string
+	Skills    []string
+	Positions []string // Должности, начиная с последней
+}
+
+// jsonResume - нужная часть формата jsonresume.org
+type jsonResume struct {
+	Basics struct {
+		Name     string `json:"name"`
+		Label    string `json:"label"`
+		Location struct {
+			City   string `json:"city"`
+			Region string `json:"region"`
+		} `json:"location"`
+	} `json:"basics"`
+	Work []struct {
+		Position string `json:"position"`
+	} `json:"work"`
+	Skills []struct {
+		Name     string   `json:"name"`
+		Keywords []string `json:"keywords"`
+	} `json:"skills"`
+}
+
+// parseJSONResume разбирает резюме в формате JSON Resume
+func parseJSONResume(data []byte) (importedProfile, error) {
+	var r jsonResume
+	if err := json.Unmarshal(data, &r); err != nil {
+		return importedProfile{}, fmt.Errorf("это не JSON Resume: %w", err)
+	}
+	p := importedProfile{
+		Name:     strings.TrimSpace(r.Basics.Name),
+		Headline: strings.TrimSpace(r.Basics.Label),
+		Location: strings.TrimSpace(r.Basics.Location.City),
+	}
+	if p.Location == "" {
+		p.Location = strings.TrimSpace(r.Basics.Location.Region)
+	}
+	for _, s := range r.Skills {
+		p.Skills = append(p.Skills, s.Name)
+		p.Skills = append(p.Skills, s.Keywords...)
+	}
+	for _, w := range r.Work {
+		p.Positions = append(p.Positions, w.Position)
+	}
+	if p.Name == "" && len(p.Skills) == 0 && len(p.Positions) == 0 {
+		return p, errors.New("в файле нет ни имени, ни навыков, ни опыта работы")
+	}
+	return p, nil
+}
+
+// readCSVRecords читает CSV выгрузки LinkedIn в записи по именам колонок
+func readCSVRecords(r io.Reader) ([]map[string]string, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.LazyQuotes = true
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	header := rows[0]
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+	var records []map[string]string
+	for _, row := range rows[1:] {
+		rec := map[string]string{}
+		for i, name := range header {
+			if i < len(row) {
+				rec[strings.TrimSpace(name)] = strings.TrimSpace(row[i])
+			}
+		}
+		records = append(records, rec)
+	}
+	return records, nil
+}
+
+// parseLinkedInExport разбирает архив «Получить копию своих данных» LinkedIn:
+// Profile.csv, Skills.csv и Positions.csv
+func parseLinkedInExport(path string) (importedProfile, error) {
+	var p importedProfile
+	zr, err := zip.OpenReader(path)
+	if err != nil {
+		return p, fmt.Errorf("не удалось открыть архив: %w", err)
+	}
+	defer zr.Close()
+
+	found := false
+	for _, f := range zr.File {
+		name := strings.ToLower(filepath.Base(f.Name))
+		if name != "profile.csv" && name != "skills.csv" && name != "positions.csv" {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return p, err
+		}
+		records, err := readCSVRecords(rc)
+		rc.Close()
+		if err != nil {
+			return p, fmt.Errorf("%s: %w", f.Name, err)
+		}
+		found = true
+		switch name {
+		case "profile.csv":
+			if len(records) > 0 {
+				r := records[0]
+				p.Name = strings.TrimSpace(r["First Name"] + " " + r["Last Name"])
+				p.Headline = r["Headline"]
+				p.Location = r["Geo Location"]
+			}
+		case "skills.csv":
+			for _, r := range records {
+				p.Skills = append(p.Skills, r["Name"])
+			}
+		case "positions.csv":
+			// Должности в выгрузке уже идут от последней к первой
+			for _, r := range records {
+				p.Positions = append(p.Positions, r["Title"])
+			}
+		}
+	}
+	if !found {
+		return p, errors.New("в архиве нет Profile.csv, Skills.csv или Positions.csv - это не выгрузка данных LinkedIn")
+	}
+	return p, nil
+}
+
+// loadImportedProfile определяет формат файла по расширению
+func loadImportedProfile(path string) (importedProfile, error) {
+	if strings.EqualFold(filepath.Ext(path), ".zip") {
+		return parseLinkedInExport(path)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return importedProfile{}, err
+	}
+	return parseJSONResume(data)
+}
+
+// uniqueFold убирает пустые строки и повторы без учёта регистра, сохраняя порядок
+func uniqueFold(values []string) []string {
+	seen := map[string]bool{}
+	var out []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		key := strings.ToLower(v)
+		if v == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, v)
+	}
+	return out
+}
+
+// matchKeywordsFor предлагает ключевые слова для оценки совпадения: навыки и последняя должность
+func matchKeywordsFor(p importedProfile) []string {
+	keywords := append([]string{}, p.Skills...)
+	if len(p.Positions) > 0 {
+		keywords = append([]string{p.Positions[0]}, keywords...)
+	}
+	keywords = uniqueFold(keywords)
+	if len(keywords) > maxMatchKeywords {
+		keywords = keywords[:maxMatchKeywords]
+	}
+	return keywords
+}
+
+// profileMatchScore - доля ключевых слов профиля, найденных в вакансии, в процентах; -1 - слов нет
+func profileMatchScore(v Vacancy) int {
+	keywords := appSettings.Profile.MatchKeywords
+	if len(keywords) == 0 {
+		return -1
+	}
+	text := newTextMatcher(vacancyMatchText(v))
+	matched := 0
+	for _, kw := range keywords {
+		if text.contains(kw) {
+			matched++
+		}
+	}
+	return matched * 100 / len(keywords)
+}
+
+// vacancyMatchText - текст вакансии в нижнем регистре, в котором ищутся слова профиля
+func vacancyMatchText(v Vacancy) string {
+	return strings.ToLower(strings.Join(append([]string{v.Title, v.Description}, v.Keywords...), "\n"))
+}
+
+// splitCommaList разбирает список через запятую
+func splitCommaList(text string) []string {
+	return uniqueFold(strings.Split(text, ","))
+}
+
+// showProfileDialog редактирует профиль соискателя и импортирует его из LinkedIn или JSON Resume
+func (app *AppMainWindow) showProfileDialog() {
+	var dlg *walk.Dialog
+	var nameLE, positionLE, locationLE *walk.LineEdit
+	var skillsTE, keywordsTE, highlightsTE *walk.TextEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	profile := appSettings.Profile
+
+	importProfile := func() {
+		fd := new(walk.FileDialog)
+		fd.Title = "Импорт профиля"
+		fd.Filter = "Выгрузка LinkedIn или JSON Resume (*.zip;*.json)|*.zip;*.json|Все файлы (*.*)|*.*"
+		ok, err := fd.ShowOpen(dlg)
+		if err != nil {
+			log.Print("File dialog error: ", err)
+			return
+		}
+		if !ok {
+			return
+		}
+		p, err := loadImportedProfile(fd.FilePath)
+		if err != nil {
+			showError(dlg, "Импорт профиля", err)
+			return
+		}
+		// Пустые поля заполняем, навыки дополняем
+		if strings.TrimSpace(nameLE.Text()) == "" {
+			nameLE.SetText(p.Name)
+		}
+		if strings.TrimSpace(positionLE.Text()) == "" {
+			position := p.Headline
+			if len(p.Positions) > 0 {
+				position = p.Positions[0]
+			}
+			positionLE.SetText(position)
+		}
+		if strings.TrimSpace(locationLE.Text()) == "" {
+			locationLE.SetText(p.Location)
+		}
+		skills := uniqueFold(append(splitCommaList(skillsTE.Text()), p.Skills...))
+		skillsTE.SetText(strings.Join(skills, ", "))
+		keywords := matchKeywordsFor(p)
+		if existing := splitCommaList(keywordsTE.Text()); len(existing) > 0 {
+			keywords = uniqueFold(append(existing, keywords...))
+		}
+		keywordsTE.SetText(strings.Join(keywords, ", "))
+		walk.MsgBox(dlg, "Импорт профиля", fmt.Sprintf("Навыков: %d, должностей: %d.\nПроверьте ключевые слова и сохраните профиль.", len(p.Skills), len(p.Positions)), walk.MsgBoxIconInformation)
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Профиль соискателя",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 520, Height: 560},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Имя:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &nameLE, Text: profile.Name},
+					Label{Text: "Желаемая должность:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &positionLE, Text: profile.DesiredPosition},
+					Label{Text: "Город:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &locationLE, Text: profile.Location},
+				},
+			},
+			Label{Text: "Навыки (через запятую):", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			TextEdit{AssignTo: &skillsTE, Text: strings.Join(profile.Skills, ", "), VScroll: true, Font: Font{PointSize: 9}},
+			Label{
+				Text:      "Ключевые слова для оценки совпадения (через запятую).\nКолонка «Совпадение» в онлайн-поиске - доля этих слов, найденных в вакансии.",
+				TextColor: currentTheme.Text,
+				Font:      Font{Bold: true, PointSize: 9},
+			},
+			TextEdit{AssignTo: &keywordsTE, Text: strings.Join(profile.MatchKeywords, ", "), VScroll: true, Font: Font{PointSize: 9}},
+			Label{Text: "Достижения для резюме (по одному на строку):", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			TextEdit{AssignTo: &highlightsTE, Text: strings.Join(profile.Highlights, "\r\n"), VScroll: true, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Импорт из LinkedIn / JSON Resume...",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked:  importProfile,
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							appSettings.Profile = UserProfile{
+								Name:            strings.TrimSpace(nameLE.Text()),
+								DesiredPosition: strings.TrimSpace(positionLE.Text()),
+								Location:        strings.TrimSpace(locationLE.Text()),
+								Skills:          splitCommaList(skillsTE.Text()),
+								MatchKeywords:   splitCommaList(keywordsTE.Text()),
+								Highlights:      splitLines(highlightsTE.Text()),
+							}
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	app.onlineVacancyModel.refresh()
+}
@@ -0,0 +1,369 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Профили - независимые поиски работы ("Моя", "Для жены", "Стажировки-2025"): у каждого своя
+// папка с вакансиями, резервными копиями, вложениями и резюме. Основной профиль - прежняя
+// папка данных из настроек. Профиль может хранить свои настройки: они читаются поверх общих
+// и сохраняются в его папку. Профиль выбирается при запуске (--profile Имя, последний
+// открытый или из списка) и сменяется перезапуском приложения, чтобы данные профилей не смешивались.
+const (
+	profilesFile       = "profiles.json"
+	profilesDir        = "profiles" // Где создаются папки новых профилей
+	defaultProfileName = "Основной"
+)
+
+// SearchProfile - профиль поиска работы
+type SearchProfile struct {
+	Name        string `json:"name"`
+	Dir         string `json:"dir"`                    // Папка данных профиля; пусто у основного
+	OwnSettings bool   `json:"own_settings,omitempty"` // Хранить настройки в папке профиля
+}
+
+// profileRegistry - список профилей из profiles.json
+type profileRegistry struct {
+	Profiles     []SearchProfile `json:"profiles,omitempty"` // Кроме основного
+	Last         string          `json:"last,omitempty"`
+	AskOnStartup bool            `json:"ask_on_startup"`
+}
+
+// activeProfile - профиль, с которым запущено приложение
+var activeProfile = SearchProfile{Name: defaultProfileName}
+
+func loadProfileRegistry() profileRegistry {
+	var r profileRegistry
+	data, err := os.ReadFile(profilesFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return r
+	}
+	if err == nil {
+		err = json.Unmarshal(data, &r)
+	}
+	if err != nil {
+		log.Printf("Ошибка чтения списка профилей %s: %v", profilesFile, err)
+	}
+	return r
+}
+
+func saveProfileRegistry(r profileRegistry) {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err == nil {
+		err = os.WriteFile(profilesFile, data, 0644)
+	}
+	if err != nil {
+		log.Printf("Ошибка записи списка профилей %s: %v", profilesFile, err)
+	}
+}
+
+// all - все профили, основной первым
+func (r profileRegistry) all() []SearchProfile {
+	return append([]SearchProfile{{Name: defaultProfileName}}, r.Profiles...)
+}
+
+// find ищет профиль по имени без учёта регистра
+func (r profileRegistry) find(name string) (SearchProfile, bool) {
+	for _, p := range r.all() {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return SearchProfile{}, false
+}
+
+// settingsPath - файл, в который сохраняются настройки активного профиля
+func settingsPath() string {
+	if activeProfile.OwnSettings && activeProfile.Dir != "" {
+		return filepath.Join(activeProfile.Dir, settingsFile)
+	}
+	return settingsFile
+}
+
+// profileFromArgs - имя профиля из --profile Имя или --profile=Имя
+func profileFromArgs(args []string) string {
+	return flagValueFromArgs(args, "profile")
+}
+
+// selectStartupProfile выбирает профиль до загрузки настроек: из командной строки,
+// из списка, если пользователь просил спрашивать, или последний открытый
+func selectStartupProfile() {
+	if selectViewOnlyProfile() {
+		return
+	}
+	r := loadProfileRegistry()
+	name := profileFromArgs(os.Args[1:])
+	if name == "" && r.AskOnStartup && len(r.Profiles) > 0 {
+		var ok bool
+		if name, ok = pickProfile(r); !ok {
+			os.Exit(0)
+		}
+	}
+	if name == "" {
+		name = r.Last
+	}
+	p, ok := r.find(name)
+	if !ok {
+		if name != "" {
+			log.Printf("Профиль %q не найден, открывается основной", name)
+		}
+		return
+	}
+	activeProfile = p
+	if p.Dir != "" {
+		if err := os.MkdirAll(p.Dir, 0755); err != nil {
+			log.Printf("Ошибка создания папки профиля %s: %v", p.Dir, err)
+		}
+	}
+	if r.Last != p.Name {
+		r.Last = p.Name
+		saveProfileRegistry(r)
+	}
+	log.Printf("Профиль: %s", p.Name)
+}
+
+// pickProfile показывает список профилей при запуске, до главного окна
+func pickProfile(r profileRegistry) (string, bool) {
+	var dlg *walk.Dialog
+	var lb *walk.ListBox
+	var openPB, cancelPB *walk.PushButton
+	names := profileNames(r)
+	current := max(slices.IndexFunc(names, func(n string) bool { return strings.EqualFold(n, r.Last) }), 0)
+	picked, ok := "", false
+	accept := func() {
+		if i := lb.CurrentIndex(); i >= 0 {
+			picked, ok = names[i], true
+			dlg.Accept()
+		}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Выбор профиля",
+		DefaultButton: &openPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 320, Height: 280},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Какой поиск открыть?", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			ListBox{AssignTo: &lb, Model: names, CurrentIndex: current, OnItemActivated: accept},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &openPB,
+						Text:       "Открыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  accept,
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Выход",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(nil); err != nil {
+		log.Print("Dialog error: ", err)
+		return "", true // Без диалога открывается последний профиль
+	}
+	return picked, ok
+}
+
+func profileNames(r profileRegistry) []string {
+	var names []string
+	for _, p := range r.all() {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+// profileDirName - имя папки для профиля: без символов, запрещённых в именах файлов Windows
+func profileDirName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`<>:"/\|?*`, r) || r < ' ' {
+			return '_'
+		}
+		return r
+	}, strings.TrimRight(name, ". "))
+}
+
+// switchProfile перезапускает приложение с другим профилем
+func (app *AppMainWindow) switchProfile(name string) {
+	exe, err := os.Executable()
+	if err == nil {
+		err = exec.Command(exe, "--profile", name).Start()
+	}
+	if err != nil {
+		log.Printf("Ошибка запуска профиля %s: %v", name, err)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось открыть профиль", err))
+		return
+	}
+	r := loadProfileRegistry()
+	r.Last = name
+	saveProfileRegistry(r)
+	app.MainWindow.Close()
+}
+
+// showProfilesDialog - список профилей: открыть, создать, убрать из списка
+func (app *AppMainWindow) showProfilesDialog() {
+	var dlg *walk.Dialog
+	var lb *walk.ListBox
+	var ownSettingsCB, askCB *walk.CheckBox
+	var closePB *walk.PushButton
+
+	r := loadProfileRegistry()
+	names := profileNames(r)
+	selected := func() (SearchProfile, bool) {
+		if i := lb.CurrentIndex(); i >= 0 && i < len(names) {
+			return r.find(names[i])
+		}
+		return SearchProfile{}, false
+	}
+	reload := func(current string) {
+		names = profileNames(r)
+		lb.SetModel(names)
+		lb.SetCurrentIndex(max(slices.Index(names, current), 0))
+	}
+	open := func() {
+		if p, ok := selected(); ok && p.Name != activeProfile.Name {
+			dlg.Accept()
+			app.switchProfile(p.Name)
+		}
+	}
+	button := func(text string, onClicked walk.EventHandler) PushButton {
+		return PushButton{
+			Text:       text,
+			Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+			Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+			OnClicked:  onClicked,
+		}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Профили",
+		DefaultButton: &closePB,
+		CancelButton:  &closePB,
+		MinSize:       Size{Width: 480, Height: 360},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Открыт профиль «" + activeProfile.Name + "». У каждого профиля свои вакансии, резюме и резервные копии.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					ListBox{AssignTo: &lb, Model: names, CurrentIndex: max(slices.Index(names, activeProfile.Name), 0), OnItemActivated: open},
+					Composite{
+						Layout: VBox{MarginsZero: true},
+						Children: []Widget{
+							button("Открыть", open),
+							button("Создать...", func() {
+								name, ok := promptText(dlg, "Новый профиль", "Название профиля:", "")
+								if !ok {
+									return
+								}
+								if _, exists := r.find(name); exists || profileDirName(name) == "" {
+									walk.MsgBox(dlg, "Профили", "Профиль с таким названием уже есть или название не подходит для папки.", walk.MsgBoxIconWarning)
+									return
+								}
+								p := SearchProfile{Name: name, Dir: filepath.Join(profilesDir, profileDirName(name)), OwnSettings: ownSettingsCB.Checked()}
+								if err := os.MkdirAll(p.Dir, 0755); err != nil {
+									log.Printf("Ошибка создания папки профиля %s: %v", p.Dir, err)
+									showError(dlg, "Ошибка", wrapError("Не удалось создать папку профиля", err))
+									return
+								}
+								r.Profiles = append(r.Profiles, p)
+								saveProfileRegistry(r)
+								log.Printf("Создан профиль %s в %s", p.Name, p.Dir)
+								reload(p.Name)
+							}),
+							button("Убрать из списка", func() {
+								p, ok := selected()
+								if !ok || p.Dir == "" || p.Name == activeProfile.Name {
+									walk.MsgBox(dlg, "Профили", "Основной и открытый сейчас профиль убрать нельзя.", walk.MsgBoxIconInformation)
+									return
+								}
+								if walk.MsgBox(dlg, "Профили", "Убрать профиль «"+p.Name+"» из списка?\nПапка "+p.Dir+" с его данными останется на диске.", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+									return
+								}
+								r.Profiles = slices.DeleteFunc(r.Profiles, func(o SearchProfile) bool { return o.Name == p.Name })
+								saveProfileRegistry(r)
+								reload(activeProfile.Name)
+							}),
+							VSpacer{},
+						},
+					},
+				},
+			},
+			CheckBox{AssignTo: &ownSettingsCB, Text: "У новых профилей свои настройки (иначе общие для всех)"},
+			CheckBox{
+				AssignTo: &askCB,
+				Text:     "Спрашивать профиль при запуске",
+				Checked:  r.AskOnStartup,
+				OnCheckedChanged: func() {
+					r.AskOnStartup = askCB.Checked()
+					saveProfileRegistry(r)
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Accept() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
+
+// buildProfilesMenu заполняет подменю "Профиль" в меню "Файл" для быстрого переключения
+func (app *AppMainWindow) buildProfilesMenu() {
+	if app.profilesMenu == nil {
+		return
+	}
+	actions := app.profilesMenu.Actions()
+	actions.Clear()
+	for _, p := range loadProfileRegistry().all() {
+		a := walk.NewAction()
+		a.SetText(p.Name)
+		a.SetCheckable(true)
+		a.SetChecked(p.Name == activeProfile.Name)
+		a.Triggered().Attach(func() {
+			if p.Name != activeProfile.Name {
+				app.switchProfile(p.Name)
+			} else {
+				a.SetChecked(true)
+			}
+		})
+		actions.Add(a)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Окно хода долгих операций: импорта, выгрузок, синхронизаций и проверки ссылок.
+// Операция выполняется в фоне и сообщает о себе через контекст (progressTotal,
+// progressStep, progressFail), а окно показывает обработанное, оставшееся время
+// и список ошибок по отдельным элементам и позволяет прервать работу.
+
+// Быстрые операции завершаются без окна, чтобы оно не мелькало
+const progressShowDelay = 400 * time.Millisecond
+
+// Как часто окно обновляет ход операции
+const progressRefreshInterval = 200 * time.Millisecond
+
+// progress - ход операции, общий для фоновой работы и окна
+type progress struct {
+	mu      sync.Mutex
+	total   int      // Сколько всего элементов; 0 - неизвестно
+	next    int      // Сколько элементов начато
+	item    string   // Текущий элемент
+	errs    []string // Ошибки по отдельным элементам
+	started time.Time
+}
+
+// progressKey - ключ хода операции в контексте
+type progressKey struct{}
+
+// progressFrom достаёт ход операции из контекста; nil, если операция идёт без окна
+func progressFrom(ctx context.Context) *progress {
+	p, _ := ctx.Value(progressKey{}).(*progress)
+	return p
+}
+
+// progressTotal сообщает, сколько всего элементов предстоит обработать
+func progressTotal(ctx context.Context, n int) {
+	if p := progressFrom(ctx); p != nil {
+		p.mu.Lock()
+		p.total = n
+		p.mu.Unlock()
+	}
+}
+
+// progressStep отмечает переход к следующему элементу
+func progressStep(ctx context.Context, item string) {
+	if p := progressFrom(ctx); p != nil {
+		p.mu.Lock()
+		p.next++
+		p.item = item
+		p.mu.Unlock()
+	}
+}
+
+// progressFail добавляет ошибку по элементу в список; операция при этом продолжается
+func progressFail(ctx context.Context, item string, err error) {
+	log.Printf("%s: %v", item, err)
+	if p := progressFrom(ctx); p != nil {
+		p.mu.Lock()
+		p.errs = append(p.errs, item+": "+err.Error())
+		p.mu.Unlock()
+	}
+}
+
+// state возвращает ход операции: обработано, всего, текущий элемент и ошибки
+func (p *progress) state() (done, total int, item string, errs []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	done = max(p.next-1, 0)
+	return done, p.total, p.item, append([]string(nil), p.errs...)
+}
+
+// progressSummary - строка «N из M, осталось ~…» для окна хода операции
+func progressSummary(done, total int, elapsed time.Duration) string {
+	if total <= 0 {
+		return fmt.Sprintf("Обработано: %d", done)
+	}
+	s := fmt.Sprintf("%d из %d", done, total)
+	if done > 0 && done < total {
+		left := elapsed / time.Duration(done) * time.Duration(total-done)
+		s += ", осталось ~" + formatETA(left)
+	}
+	return s
+}
+
+// formatETA записывает оставшееся время коротко: «40 с», «3 мин»
+func formatETA(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%d с", max(int(d.Seconds()), 1))
+	}
+	return fmt.Sprintf("%d мин", int(d.Round(time.Minute).Minutes()))
+}
+
+// runWithProgress выполняет work в фоне и, если она затянулась, показывает окно хода с кнопкой отмены.
+// Возвращает ошибку work; при отмене - context.Canceled. Если по отдельным элементам были ошибки,
+// окно остаётся открытым со списком, пока его не закроют.
+func runWithProgress(owner walk.Form, title string, work func(ctx context.Context) error) error {
+	p := &progress{started: time.Now()}
+	ctx, cancel := context.WithCancel(context.WithValue(context.Background(), progressKey{}, p))
+	defer cancel()
+	result := make(chan error, 1)
+	go func() { result <- work(ctx) }()
+
+	select {
+	case err := <-result:
+		if _, _, _, errs := p.state(); len(errs) == 0 {
+			return err
+		}
+		result <- err // Ошибки по элементам нужно показать
+	case <-time.After(progressShowDelay):
+	}
+
+	var dlg *walk.Dialog
+	var itemLabel, countLabel, errorsLabel *walk.Label
+	var bar *walk.ProgressBar
+	var errorsLB *walk.ListBox
+	var cancelPB *walk.PushButton
+	finished := false
+	shownErrs := 0
+	var workErr error
+
+	// refresh переносит ход операции в окно
+	refresh := func() {
+		done, total, item, errs := p.state()
+		if finished {
+			done = max(done, total)
+		}
+		if total > 0 {
+			if bar.MarqueeMode() {
+				bar.SetMarqueeMode(false)
+			}
+			bar.SetRange(0, total)
+			bar.SetValue(min(done, total))
+		}
+		itemLabel.SetText(item)
+		countLabel.SetText(progressSummary(done, total, time.Since(p.started)))
+		if len(errs) != shownErrs {
+			shownErrs = len(errs)
+			errorsLB.SetModel(errs)
+			errorsLabel.SetText(fmt.Sprintf("Ошибки (%d):", len(errs)))
+		}
+		errorsLabel.SetVisible(len(errs) > 0)
+		errorsLB.SetVisible(len(errs) > 0)
+	}
+
+	// finish завершает операцию: без ошибок по элементам окно закрывается само
+	finish := func(err error) {
+		finished = true
+		workErr = err
+		refresh()
+		if shownErrs == 0 || errors.Is(err, context.Canceled) {
+			dlg.Accept()
+			return
+		}
+		itemLabel.SetText("Готово, но не всё получилось:")
+		cancelPB.SetText("Закрыть")
+		cancelPB.SetEnabled(true)
+	}
+
+	if err := (Dialog{
+		AssignTo:     &dlg,
+		Title:        title,
+		CancelButton: &cancelPB,
+		MinSize:      Size{Width: 460, Height: 150},
+		Layout:       VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:   SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{AssignTo: &itemLabel, Text: "Подготовка...", TextColor: currentTheme.Text, Font: Font{PointSize: 9}, EllipsisMode: EllipsisEnd},
+			ProgressBar{AssignTo: &bar, MarqueeMode: true, MinSize: Size{Height: 16}},
+			Label{AssignTo: &countLabel, TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			Label{AssignTo: &errorsLabel, Visible: false, TextColor: currentTheme.Text, Font: Font{PointSize: 9, Bold: true}},
+			ListBox{AssignTo: &errorsLB, Model: []string{}, Visible: false, MinSize: Size{Height: 120}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if finished {
+								dlg.Accept()
+								return
+							}
+							cancel()
+							cancelPB.SetText("Отмена...")
+							cancelPB.SetEnabled(false)
+						},
+					},
+				},
+			},
+		},
+	}).Create(owner); err != nil {
+		log.Print("Dialog error: ", err)
+		return <-result
+	}
+	dlg.Closing().Attach(func(canceled *bool, reason walk.CloseReason) {
+		if !finished {
+			*canceled = true // Окно закроется, когда работа остановится
+			cancel()
+		}
+	})
+
+	go func() {
+		ticker := time.NewTicker(progressRefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case err := <-result:
+				dlg.Synchronize(func() { finish(err) })
+				return
+			case <-ticker.C:
+				dlg.Synchronize(refresh)
+			}
+		}
+	}()
+	dlg.Run()
+	return workErr
+}
@@ -0,0 +1,218 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+	"golang.org/x/sys/windows/registry"
+)
+
+// Ссылки vacancy:// - добавление вакансии из браузера одним щелчком по закладке:
+//
+//	vacancy://add?url=<ссылка>&title=<название>&company=<компания>&description=<текст>
+//	vacancy://open?url=<ссылка>
+//
+// add открывает диалог добавления с заполненными полями (или сообщает, что вакансия уже есть),
+// open выделяет вакансию с этой ссылкой, а если её нет - предлагает добавить.
+// Windows запускает приложение со ссылкой в аргументах; открытое окно получает её через канал.
+
+const vacancyProtocol = "vacancy"
+
+// Предельная длина текста из ссылки: браузеры и так обрезают длинные адреса
+const vacancyLinkMaxField = 20000
+
+// Закладка для браузера: передаёт приложению адрес и заголовок открытой страницы
+const vacancyBookmarklet = `javascript:location.href='vacancy://add?url='+encodeURIComponent(location.href)+'&title='+encodeURIComponent(document.title)+'&description='+encodeURIComponent(String(getSelection()))`
+
+// vacancyLink - разобранная ссылка vacancy://
+type vacancyLink struct {
+	Action  string // add или open
+	Vacancy Vacancy
+}
+
+// parseVacancyLink разбирает ссылку vacancy://
+func parseVacancyLink(raw string) (vacancyLink, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return vacancyLink{}, err
+	}
+	if !strings.EqualFold(u.Scheme, vacancyProtocol) {
+		return vacancyLink{}, fmt.Errorf("ссылка %q не начинается с %s://", raw, vacancyProtocol)
+	}
+	// vacancy://add?... и vacancy:add?... - браузеры пишут по-разному
+	action := strings.ToLower(u.Host)
+	if action == "" {
+		action = strings.ToLower(u.Opaque)
+	}
+	if action == "" {
+		action = strings.ToLower(strings.Trim(u.Path, "/"))
+	}
+	if action != "add" && action != "open" {
+		return vacancyLink{}, fmt.Errorf("неизвестное действие %q в ссылке %s://", action, vacancyProtocol)
+	}
+	q := u.Query()
+	field := func(name string) string {
+		s := strings.TrimSpace(q.Get(name))
+		if len(s) > vacancyLinkMaxField {
+			s = strings.ToValidUTF8(s[:vacancyLinkMaxField], "")
+		}
+		return s
+	}
+	link := vacancyLink{Action: action, Vacancy: Vacancy{
+		Title:       field("title"),
+		Company:     field("company"),
+		SourceURL:   field("url"),
+		Description: field("description"),
+	}}
+	if link.Vacancy.SourceURL != "" && canonicalURL(link.Vacancy.SourceURL) == "" {
+		return vacancyLink{}, fmt.Errorf("в ссылке неверный адрес вакансии %q", link.Vacancy.SourceURL)
+	}
+	if link.Vacancy.SourceURL == "" && link.Vacancy.Title == "" {
+		return vacancyLink{}, errors.New("в ссылке нет ни адреса, ни названия вакансии")
+	}
+	return link, nil
+}
+
+// vacancyLinksFromArgs возвращает ссылки vacancy://, переданные в командной строке
+func vacancyLinksFromArgs(args []string) []string {
+	var links []string
+	for _, a := range args {
+		if strings.HasPrefix(strings.ToLower(a), vacancyProtocol+":") {
+			links = append(links, a)
+		}
+	}
+	return links
+}
+
+// findVacancyByURL ищет вакансию с той же ссылкой
+func findVacancyByURL(link string) (Vacancy, bool) {
+	want := canonicalURL(link)
+	if want == "" {
+		return Vacancy{}, false
+	}
+	for _, v := range snapshotVacancies() {
+		if canonicalURL(v.SourceURL) == want {
+			return v, true
+		}
+	}
+	return Vacancy{}, false
+}
+
+// openVacancyLink выполняет действие из ссылки vacancy://
+func (app *AppMainWindow) openVacancyLink(raw string) {
+	link, err := parseVacancyLink(raw)
+	if err != nil {
+		log.Printf("Ссылка %s: %v", raw, err)
+		showError(app.MainWindow, "Ссылка на вакансию", wrapError("Не удалось открыть ссылку", err))
+		return
+	}
+	if link.Action == "open" {
+		if v, ok := findVacancyByURL(link.Vacancy.SourceURL); ok {
+			app.selectVacancy(v.Title, v.Company)
+			return
+		}
+	}
+	if viewOnly {
+		app.notify("В режиме просмотра вакансии не добавляются.")
+		return
+	}
+	log.Printf("Вакансия из ссылки %s://%s: %s", vacancyProtocol, link.Action, link.Vacancy.SourceURL)
+	if existing, ok := findDuplicate(link.Vacancy); ok && appSettings.Dedup.OnCollision == dedupMerge {
+		// Ссылку может открыть любая веб-страница: без согласия её данные в карточку не попадают
+		question := fmt.Sprintf("Вакансия «%s» (%s) уже есть в списке.\nДобавить в неё данные из ссылки?\n\n%s",
+			existing.Title, existing.Company, link.Vacancy.SourceURL)
+		if walk.MsgBox(app.MainWindow, "Ссылка на вакансию", question, walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+			app.selectVacancy(existing.Title, existing.Company)
+			return
+		}
+	}
+	app.openImportedVacancy(link.Vacancy)
+}
+
+// registerVacancyProtocol связывает ссылки vacancy:// с приложением для текущего пользователя
+func registerVacancyProtocol() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	set := func(path, name, value string) error {
+		k, _, err := registry.CreateKey(registry.CURRENT_USER, `Software\Classes\`+path, registry.SET_VALUE)
+		if err != nil {
+			return err
+		}
+		defer k.Close()
+		return k.SetStringValue(name, value)
+	}
+	if err := set(vacancyProtocol, "", "URL:Вакансия (Поисковик Вакансий)"); err != nil {
+		return err
+	}
+	if err := set(vacancyProtocol, "URL Protocol", ""); err != nil {
+		return err
+	}
+	if err := set(vacancyProtocol+`\DefaultIcon`, "", `"`+exe+`",0`); err != nil {
+		return err
+	}
+	return set(vacancyProtocol+`\shell\open\command`, "", `"`+exe+`" "%1"`)
+}
+
+// showBookmarkletDialog показывает закладку для браузера, которая добавляет открытую вакансию в приложение
+func (app *AppMainWindow) showBookmarkletDialog() {
+	var dlg *walk.Dialog
+	var closePB *walk.PushButton
+
+	// Без регистрации браузер не знает, кому отдать ссылку vacancy://
+	if err := registerVacancyProtocol(); err != nil {
+		log.Printf("Ошибка регистрации ссылок %s://: %v", vacancyProtocol, err)
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Закладка для браузера",
+		DefaultButton: &closePB,
+		CancelButton:  &closePB,
+		MinSize:       Size{Width: 480, Height: 220},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Создайте в браузере закладку и вставьте этот текст вместо адреса.\nЩелчок по закладке на странице вакансии добавит её в приложение;\nвыделенный на странице текст попадёт в описание.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			TextEdit{Text: vacancyBookmarklet, ReadOnly: true, MinSize: Size{Height: 60}, Font: Font{Family: "Consolas", PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Скопировать",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10},
+						OnClicked: func() {
+							if err := walk.Clipboard().SetText(vacancyBookmarklet); err != nil {
+								log.Printf("Ошибка копирования в буфер обмена: %v", err)
+								return
+							}
+							app.notify("Закладка скопирована в буфер обмена.")
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Accept() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
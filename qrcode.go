@@ -0,0 +1,591 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// QR-код вакансии, чтобы открыть объявление на телефоне: ссылка на объявление или
+// карточка вакансии текстом. Кодировщик собственный: байтовый режим, уровень коррекции
+// ошибок M (до 15% повреждений), версии 1-40 и выбор маски по штрафам из ISO/IEC 18004.
+
+// Блоки коррекции ошибок уровня M по версиям: кодовых слов коррекции на блок и число блоков
+var (
+	qrECCPerBlock = [41]int{0, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26,
+		26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28}
+	qrNumBlocks = [41]int{0, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16,
+		17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49}
+)
+
+// Биты уровня коррекции M в служебной информации о формате
+const qrFormatLevelM = 0
+
+var errQRTooLong = errors.New("слишком много данных для QR-кода")
+
+// qrCode - матрица модулей QR-кода, true - тёмный модуль
+type qrCode struct {
+	size     int
+	modules  [][]bool
+	function [][]bool // Служебные модули, которые не маскируются
+}
+
+// qrRawCodewords - сколько кодовых слов помещается в версию
+func qrRawCodewords(ver int) int {
+	n := (16*ver+128)*ver + 64
+	if ver >= 2 {
+		align := ver/7 + 2
+		n -= (25*align-10)*align - 55
+		if ver >= 7 {
+			n -= 36
+		}
+	}
+	return n / 8
+}
+
+// qrDataCodewords - сколько кодовых слов данных помещается в версию с уровнем M
+func qrDataCodewords(ver int) int {
+	return qrRawCodewords(ver) - qrECCPerBlock[ver]*qrNumBlocks[ver]
+}
+
+// encodeQR кодирует данные в QR-код наименьшей подходящей версии
+func encodeQR(data []byte) (*qrCode, error) {
+	ver := 0
+	for v := 1; v <= 40; v++ {
+		countBits := 8
+		if v >= 10 {
+			countBits = 16
+		}
+		if 4+countBits+8*len(data) <= qrDataCodewords(v)*8 {
+			ver = v
+			break
+		}
+	}
+	if ver == 0 {
+		return nil, errQRTooLong
+	}
+
+	// Поток битов: режим «байты», длина, данные, терминатор и заполнение
+	var bits qrBits
+	bits.append(0b0100, 4)
+	if ver >= 10 {
+		bits.append(len(data), 16)
+	} else {
+		bits.append(len(data), 8)
+	}
+	for _, b := range data {
+		bits.append(int(b), 8)
+	}
+	capacity := qrDataCodewords(ver) * 8
+	bits.append(0, min(4, capacity-len(bits)))
+	bits.append(0, (8-len(bits)%8)%8)
+	codewords := bits.bytes()
+	for pad := byte(0xEC); len(codewords) < qrDataCodewords(ver); pad ^= 0xEC ^ 0x11 {
+		codewords = append(codewords, pad)
+	}
+
+	q := newQRCode(ver)
+	q.drawCodewords(qrInterleave(ver, codewords))
+
+	// Маска с наименьшим штрафом
+	best, bestPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		q.applyMask(mask)
+		q.drawFormatBits(mask)
+		if p := q.penalty(); bestPenalty < 0 || p < bestPenalty {
+			best, bestPenalty = mask, p
+		}
+		q.applyMask(mask) // Маска снимается повторным наложением
+	}
+	q.applyMask(best)
+	q.drawFormatBits(best)
+	return q, nil
+}
+
+// qrBits - поток битов, старшие биты первыми
+type qrBits []bool
+
+func (b *qrBits) append(value, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*b = append(*b, value>>i&1 != 0)
+	}
+}
+
+func (b qrBits) bytes() []byte {
+	out := make([]byte, len(b)/8)
+	for i, bit := range b {
+		if bit {
+			out[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+	return out
+}
+
+// qrInterleave делит данные на блоки, добавляет к каждому коды коррекции и чередует их
+func qrInterleave(ver int, data []byte) []byte {
+	numBlocks, eccLen := qrNumBlocks[ver], qrECCPerBlock[ver]
+	raw := qrRawCodewords(ver)
+	numShort := numBlocks - raw%numBlocks
+	shortLen := raw/numBlocks - eccLen // Данных в коротком блоке; в длинном на одно слово больше
+
+	divisor := qrReedSolomonDivisor(eccLen)
+	var blocks, eccs [][]byte
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortLen
+		if i >= numShort {
+			n++
+		}
+		block := data[k : k+n]
+		k += n
+		blocks = append(blocks, block)
+		eccs = append(eccs, qrReedSolomonRemainder(block, divisor))
+	}
+
+	var out []byte
+	for i := 0; i <= shortLen; i++ {
+		for _, block := range blocks {
+			if i < len(block) {
+				out = append(out, block[i])
+			}
+		}
+	}
+	for i := 0; i < eccLen; i++ {
+		for _, ecc := range eccs {
+			out = append(out, ecc[i])
+		}
+	}
+	return out
+}
+
+// qrMultiply умножает в поле GF(256) с порождающим многочленом x^8+x^4+x^3+x^2+1
+func qrMultiply(x, y byte) byte {
+	var z byte
+	for i := 7; i >= 0; i-- {
+		z = z<<1 ^ (z>>7)*0x1D
+		z ^= (y >> i & 1) * x
+	}
+	return z
+}
+
+// qrReedSolomonDivisor - порождающий многочлен кода Рида-Соломона степени degree без старшего члена
+func qrReedSolomonDivisor(degree int) []byte {
+	result := make([]byte, degree)
+	result[degree-1] = 1
+	root := byte(1)
+	for i := 0; i < degree; i++ {
+		for j := range result {
+			result[j] = qrMultiply(result[j], root)
+			if j+1 < len(result) {
+				result[j] ^= result[j+1]
+			}
+		}
+		root = qrMultiply(root, 2)
+	}
+	return result
+}
+
+// qrReedSolomonRemainder - кодовые слова коррекции ошибок для блока данных
+func qrReedSolomonRemainder(data, divisor []byte) []byte {
+	result := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ result[0]
+		copy(result, result[1:])
+		result[len(result)-1] = 0
+		for i, d := range divisor {
+			result[i] ^= qrMultiply(d, factor)
+		}
+	}
+	return result
+}
+
+// newQRCode создаёт матрицу версии ver со служебными узорами
+func newQRCode(ver int) *qrCode {
+	size := ver*4 + 17
+	q := &qrCode{size: size, modules: make([][]bool, size), function: make([][]bool, size)}
+	for y := range size {
+		q.modules[y] = make([]bool, size)
+		q.function[y] = make([]bool, size)
+	}
+
+	for i := range size {
+		q.setFunction(6, i, i%2 == 0)
+		q.setFunction(i, 6, i%2 == 0)
+	}
+	for _, c := range [][2]int{{3, 3}, {size - 4, 3}, {3, size - 4}} {
+		for dy := -4; dy <= 4; dy++ {
+			for dx := -4; dx <= 4; dx++ {
+				x, y := c[0]+dx, c[1]+dy
+				if x >= 0 && x < size && y >= 0 && y < size {
+					d := max(qrAbs(dx), qrAbs(dy))
+					q.setFunction(x, y, d != 2 && d != 4)
+				}
+			}
+		}
+	}
+	pos := qrAlignmentPositions(ver)
+	last := len(pos) - 1
+	for i := range pos {
+		for j := range pos {
+			if i == 0 && j == 0 || i == 0 && j == last || i == last && j == 0 {
+				continue // Углы с узорами поиска
+			}
+			for dy := -2; dy <= 2; dy++ {
+				for dx := -2; dx <= 2; dx++ {
+					q.setFunction(pos[i]+dx, pos[j]+dy, max(qrAbs(dx), qrAbs(dy)) != 1)
+				}
+			}
+		}
+	}
+	q.drawFormatBits(0) // Резервирует место; настоящие биты пишутся после выбора маски
+	if ver >= 7 {
+		rem := ver
+		for range 12 {
+			rem = rem<<1 ^ (rem>>11)*0x1F25
+		}
+		bits := ver<<12 | rem
+		for i := range 18 {
+			dark := bits>>i&1 != 0
+			a, b := size-11+i%3, i/3
+			q.setFunction(a, b, dark)
+			q.setFunction(b, a, dark)
+		}
+	}
+	return q
+}
+
+// qrAlignmentPositions - координаты центров выравнивающих узоров
+func qrAlignmentPositions(ver int) []int {
+	if ver == 1 {
+		return nil
+	}
+	n := ver/7 + 2
+	step := (ver*4 + n*2 + 1) / (n*2 - 2) * 2
+	if ver == 32 {
+		step = 26
+	}
+	pos := make([]int, n)
+	pos[0] = 6
+	for i, p := n-1, ver*4+10; i >= 1; i, p = i-1, p-step {
+		pos[i] = p
+	}
+	return pos
+}
+
+// qrAbs - модуль числа
+func qrAbs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func (q *qrCode) setFunction(x, y int, dark bool) {
+	q.modules[y][x] = dark
+	q.function[y][x] = true
+}
+
+// drawFormatBits записывает уровень коррекции и маску в обе копии служебной информации
+func (q *qrCode) drawFormatBits(mask int) {
+	data := qrFormatLevelM<<3 | mask
+	rem := data
+	for range 10 {
+		rem = rem<<1 ^ (rem>>9)*0x537
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+	bit := func(i int) bool { return bits>>i&1 != 0 }
+
+	for i := 0; i <= 5; i++ {
+		q.setFunction(8, i, bit(i))
+	}
+	q.setFunction(8, 7, bit(6))
+	q.setFunction(8, 8, bit(7))
+	q.setFunction(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		q.setFunction(14-i, 8, bit(i))
+	}
+	for i := 0; i < 8; i++ {
+		q.setFunction(q.size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		q.setFunction(8, q.size-15+i, bit(i))
+	}
+	q.setFunction(8, q.size-8, true) // Всегда тёмный модуль
+}
+
+// drawCodewords раскладывает кодовые слова змейкой по столбцам пар справа налево
+func (q *qrCode) drawCodewords(data []byte) {
+	i := 0
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5 // Столбец вертикального узора синхронизации пропускается
+		}
+		for vert := range q.size {
+			for j := range 2 {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert // Вверх
+				}
+				if !q.function[y][x] && i < len(data)*8 {
+					q.modules[y][x] = data[i>>3]>>(7-i&7)&1 != 0
+					i++
+				}
+			}
+		}
+	}
+}
+
+// applyMask инвертирует модули данных по условию маски
+func (q *qrCode) applyMask(mask int) {
+	for y := range q.size {
+		for x := range q.size {
+			var invert bool
+			switch mask {
+			case 0:
+				invert = (x+y)%2 == 0
+			case 1:
+				invert = y%2 == 0
+			case 2:
+				invert = x%3 == 0
+			case 3:
+				invert = (x+y)%3 == 0
+			case 4:
+				invert = (x/3+y/2)%2 == 0
+			case 5:
+				invert = x*y%2+x*y%3 == 0
+			case 6:
+				invert = (x*y%2+x*y%3)%2 == 0
+			case 7:
+				invert = ((x+y)%2+x*y%3)%2 == 0
+			}
+			if invert && !q.function[y][x] {
+				q.modules[y][x] = !q.modules[y][x]
+			}
+		}
+	}
+}
+
+// penalty - штраф за узоры, которые мешают сканерам: длинные ряды, квадраты,
+// похожие на узоры поиска участки и перекос тёмных и светлых модулей
+func (q *qrCode) penalty() int {
+	at := func(x, y int, column bool) bool {
+		if column {
+			return q.modules[x][y]
+		}
+		return q.modules[y][x]
+	}
+	finderLike := []bool{true, false, true, true, true, false, true}
+	result, dark := 0, 0
+	for _, column := range []bool{false, true} {
+		for y := range q.size {
+			run := 0
+			for x := range q.size {
+				if x > 0 && at(x, y, column) == at(x-1, y, column) {
+					run++
+				} else {
+					run = 1
+				}
+				if run == 5 {
+					result += 3
+				} else if run > 5 {
+					result++
+				}
+				// 1:1:3:1:1 с четырьмя светлыми модулями с одной из сторон
+				if x+7 <= q.size {
+					match := true
+					for k, want := range finderLike {
+						if at(x+k, y, column) != want {
+							match = false
+							break
+						}
+					}
+					if match && (q.lightRun(x-4, x, y, column) || q.lightRun(x+7, x+11, y, column)) {
+						result += 40
+					}
+				}
+			}
+		}
+	}
+	for y := range q.size {
+		for x := range q.size {
+			if q.modules[y][x] {
+				dark++
+			}
+			if x > 0 && y > 0 {
+				c := q.modules[y][x]
+				if c == q.modules[y][x-1] && c == q.modules[y-1][x] && c == q.modules[y-1][x-1] {
+					result += 3
+				}
+			}
+		}
+	}
+	total := q.size * q.size
+	result += qrAbs(dark*20-total*10) / total * 10
+	return result
+}
+
+// lightRun - все модули строки (или столбца) с from по to-1 светлые; за краем кода - светлые
+func (q *qrCode) lightRun(from, to, line int, column bool) bool {
+	for i := from; i < to; i++ {
+		if i < 0 || i >= q.size {
+			continue
+		}
+		if column && q.modules[i][line] || !column && q.modules[line][i] {
+			return false
+		}
+	}
+	return true
+}
+
+// vacancyQRText - карточка вакансии текстом для QR-кода
+func vacancyQRText(v Vacancy) string {
+	v = shareableVacancy(v, false, false)
+	var lines []string
+	add := func(label, value string) {
+		if value = strings.TrimSpace(value); value != "" {
+			lines = append(lines, label+value)
+		}
+	}
+	add("", v.Title)
+	add("", v.Company)
+	add("Зарплата: ", v.Salary)
+	add("Место: ", v.Location)
+	add("Формат: ", v.WorkFormat)
+	add("Опыт: ", v.ExperienceLevel)
+	add("", v.SourceURL)
+	return strings.Join(lines, "\n")
+}
+
+// showQRForSelected показывает QR-код выбранной вакансии
+func (app *AppMainWindow) showQRForSelected() {
+	v, ok := app.vacancyAt(app.vacancyTable.CurrentIndex())
+	if !ok {
+		walk.MsgBox(app.MainWindow, "QR-код", "Сначала выберите вакансию в таблице.", walk.MsgBoxIconInformation)
+		return
+	}
+	app.showVacancyQR(v)
+}
+
+// showVacancyQR показывает QR-код ссылки на объявление или карточки вакансии
+func (app *AppMainWindow) showVacancyQR(v Vacancy) {
+	var dlg *walk.Dialog
+	var view *walk.CustomWidget
+	var contentCB *walk.ComboBox
+	var issueLabel *walk.Label
+	var closePB *walk.PushButton
+
+	contents := []struct {
+		Name string
+		Text string
+	}{
+		{"Ссылка на объявление", strings.TrimSpace(v.SourceURL)},
+		{"Карточка вакансии", vacancyQRText(v)},
+	}
+	if contents[0].Text == "" {
+		contents = contents[1:]
+	}
+	names := make([]string, len(contents))
+	for i, c := range contents {
+		names[i] = c.Name
+	}
+
+	code, codeErr := encodeQR([]byte(contents[0].Text))
+	update := func() {
+		code, codeErr = encodeQR([]byte(contents[max(contentCB.CurrentIndex(), 0)].Text))
+		if codeErr != nil {
+			log.Printf("QR-код вакансии '%s': %v", v.Title, codeErr)
+		}
+		issueLabel.SetVisible(codeErr != nil)
+		view.Invalidate()
+	}
+
+	// paint рисует код по центру с белой рамкой в четыре модуля, как требует стандарт
+	paint := func(canvas *walk.Canvas, updateBounds walk.Rectangle) error {
+		bounds := view.ClientBoundsPixels()
+		white, err := walk.NewSolidColorBrush(walk.RGB(255, 255, 255))
+		if err != nil {
+			return err
+		}
+		defer white.Dispose()
+		black, err := walk.NewSolidColorBrush(walk.RGB(0, 0, 0))
+		if err != nil {
+			return err
+		}
+		defer black.Dispose()
+		if code == nil {
+			return nil
+		}
+		cells := code.size + 8
+		module := min(bounds.Width, bounds.Height) / cells
+		if module < 1 {
+			return nil
+		}
+		side := module * cells
+		left, top := (bounds.Width-side)/2, (bounds.Height-side)/2
+		if err := canvas.FillRectanglePixels(white, walk.Rectangle{X: left, Y: top, Width: side, Height: side}); err != nil {
+			return err
+		}
+		for y := range code.size {
+			for x := range code.size {
+				if code.modules[y][x] {
+					r := walk.Rectangle{X: left + (x+4)*module, Y: top + (y+4)*module, Width: module, Height: module}
+					if err := canvas.FillRectanglePixels(black, r); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		return nil
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "QR-код: " + v.Title,
+		DefaultButton: &closePB,
+		CancelButton:  &closePB,
+		MinSize:       Size{Width: 380, Height: 460},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Наведите камеру телефона на код:", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			ComboBox{AssignTo: &contentCB, Model: names, CurrentIndex: 0, OnCurrentIndexChanged: func() {
+				if view != nil && issueLabel != nil {
+					update()
+				}
+			}},
+			CustomWidget{
+				AssignTo:            &view,
+				MinSize:             Size{Width: 300, Height: 300},
+				StretchFactor:       1,
+				ClearsBackground:    true,
+				InvalidatesOnResize: true,
+				PaintPixels:         paint,
+			},
+			Label{
+				AssignTo:  &issueLabel,
+				Text:      "Текст слишком длинный для QR-кода - выберите ссылку.",
+				Visible:   codeErr != nil,
+				TextColor: walk.RGB(200, 0, 0),
+				Font:      Font{PointSize: 9},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Accept() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
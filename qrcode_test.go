@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"slices"
+	"testing"
+)
+
+// Известные значения из ISO/IEC 18004 и разборов стандарта
+
+func TestQRReedSolomon(t *testing.T) {
+	// «HELLO WORLD», версия 1-M
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := qrReedSolomonRemainder(data, qrReedSolomonDivisor(10)); !bytes.Equal(got, want) {
+		t.Errorf("коды коррекции = %v, want %v", got, want)
+	}
+}
+
+func TestQRCodewordCounts(t *testing.T) {
+	for _, c := range []struct{ ver, raw, data int }{
+		{1, 26, 16}, {2, 44, 28}, {7, 196, 124}, {10, 346, 216}, {40, 3706, 2334},
+	} {
+		if got := qrRawCodewords(c.ver); got != c.raw {
+			t.Errorf("qrRawCodewords(%d) = %d, want %d", c.ver, got, c.raw)
+		}
+		if got := qrDataCodewords(c.ver); got != c.data {
+			t.Errorf("qrDataCodewords(%d) = %d, want %d", c.ver, got, c.data)
+		}
+	}
+}
+
+func TestQRAlignmentPositions(t *testing.T) {
+	for ver, want := range map[int][]int{
+		1:  nil,
+		2:  {6, 18},
+		7:  {6, 22, 38},
+		14: {6, 26, 46, 66},
+		32: {6, 34, 60, 86, 112, 138},
+		40: {6, 30, 58, 86, 114, 142, 170},
+	} {
+		if got := qrAlignmentPositions(ver); !slices.Equal(got, want) {
+			t.Errorf("qrAlignmentPositions(%d) = %v, want %v", ver, got, want)
+		}
+	}
+}
+
+// qrFormatString читает служебную информацию о формате у левого верхнего узора, старший бит первым
+func qrFormatString(q *qrCode) string {
+	bit := func(i int) bool {
+		switch {
+		case i <= 5:
+			return q.modules[i][8]
+		case i == 6:
+			return q.modules[7][8]
+		case i == 7:
+			return q.modules[8][8]
+		case i == 8:
+			return q.modules[8][7]
+		default:
+			return q.modules[8][14-i]
+		}
+	}
+	s := make([]byte, 15)
+	for i := range 15 {
+		s[14-i] = '0'
+		if bit(i) {
+			s[14-i] = '1'
+		}
+	}
+	return string(s)
+}
+
+func TestQRFormatBits(t *testing.T) {
+	want := []string{
+		"101010000010010", "101000100100101", "101111001111100", "101101101001011",
+		"100010111111001", "100000011001110", "100111110010111", "100101010100000",
+	}
+	q := newQRCode(1)
+	for mask, w := range want {
+		q.drawFormatBits(mask)
+		if got := qrFormatString(q); got != w {
+			t.Errorf("маска %d: формат %s, want %s", mask, got, w)
+		}
+	}
+}
+
+func TestQRVersionBits(t *testing.T) {
+	q := newQRCode(7)
+	var got []byte
+	for i := 17; i >= 0; i-- {
+		b := byte('0')
+		if q.modules[i/3][q.size-11+i%3] {
+			b = '1'
+		}
+		got = append(got, b)
+	}
+	if want := "000111110010010100"; string(got) != want {
+		t.Errorf("версия 7: %s, want %s", got, want)
+	}
+}
+
+func TestQRCapacity(t *testing.T) {
+	if _, err := encodeQR(make([]byte, 2331)); err != nil {
+		t.Errorf("2331 байт должны поместиться в версию 40-M: %v", err)
+	}
+	if _, err := encodeQR(make([]byte, 2332)); err != errQRTooLong {
+		t.Errorf("2332 байта: ошибка %v, want %v", err, errQRTooLong)
+	}
+}
+
+// TestQRRoundTrip снимает маску с готового кода, читает кодовые слова обратно и сверяет данные и коды коррекции
+func TestQRRoundTrip(t *testing.T) {
+	text := []byte("https://example.com/vacancy/42") // 30 байт - версия 3-M, один блок
+	q, err := encodeQR(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if q.size != 29 {
+		t.Fatalf("размер %d, want 29 (версия 3)", q.size)
+	}
+	if !q.modules[q.size-8][8] {
+		t.Error("нет всегда тёмного модуля")
+	}
+	format := qrFormatString(q)
+	mask := -1
+	for m := range 8 {
+		q2 := newQRCode(3)
+		q2.drawFormatBits(m)
+		if qrFormatString(q2) == format {
+			mask = m
+		}
+	}
+	if mask < 0 {
+		t.Fatalf("неизвестная информация о формате %s", format)
+	}
+	q.applyMask(mask)
+
+	// Тот же обход змейкой, что и в drawCodewords
+	var bits qrBits
+	for right := q.size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := range q.size {
+			for j := range 2 {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = q.size - 1 - vert
+				}
+				if !q.function[y][x] {
+					bits = append(bits, q.modules[y][x])
+				}
+			}
+		}
+	}
+	codewords := bits.bytes()[:qrRawCodewords(3)]
+	nData := qrDataCodewords(3)
+	data, ecc := codewords[:nData], codewords[nData:]
+	if got := qrReedSolomonRemainder(data, qrReedSolomonDivisor(qrECCPerBlock[3])); !bytes.Equal(got, ecc) {
+		t.Errorf("коды коррекции не сходятся с данными")
+	}
+
+	// Режим «байты» (0100), длина 8 бит, затем сами байты
+	if data[0]>>4 != 0b0100 {
+		t.Fatalf("режим %04b, want 0100", data[0]>>4)
+	}
+	n := int(data[0]&0x0F)<<4 | int(data[1]>>4)
+	if n != len(text) {
+		t.Fatalf("длина %d, want %d", n, len(text))
+	}
+	got := make([]byte, n)
+	for i := range n {
+		got[i] = data[1+i]<<4 | data[2+i]>>4
+	}
+	if !bytes.Equal(got, text) {
+		t.Errorf("данные %q, want %q", got, text)
+	}
+}
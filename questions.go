@@ -0,0 +1,480 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Теги вопросов, предлагаемые по умолчанию
+var questionTags = []string{"Алгоритмы", "System design", "Go", "Базы данных", "Сети", "Конкурентность", "Поведенческие"}
+
+// Этапы собеседования
+var questionRounds = []string{"Скрининг с HR", "Техническое", "Live coding", "System design", "С руководителем", "Финальное"}
+
+const allQuestionTags = "Все теги"
+
+// InterviewQuestion - вопрос, заданный на собеседовании
+type InterviewQuestion struct {
+	ID      string    `json:"id"`
+	Text    string    `json:"text"`
+	Round   string    `json:"round,omitempty"` // Этап собеседования
+	Tags    []string  `json:"tags,omitempty"`
+	Notes   string    `json:"notes,omitempty"` // Свой ответ или что стоит подтянуть
+	AskedAt time.Time `json:"askedAt,omitzero"`
+}
+
+// bankQuestion - вопрос вместе с вакансией, на собеседовании по которой его задали
+type bankQuestion struct {
+	Title   string
+	Company string
+	InterviewQuestion
+}
+
+// hasTag - есть ли у вопроса тег без учёта регистра
+func (q InterviewQuestion) hasTag(tag string) bool {
+	for _, t := range q.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// collectQuestions собирает вопросы со всех вакансий (или с одной, если title не пуст),
+// отбирая по тегу и словам запроса. Свежие вопросы идут первыми.
+func collectQuestions(vacancies []Vacancy, title, company, query, tag string) []bankQuestion {
+	words := strings.Fields(strings.ToLower(query))
+	var list []bankQuestion
+	for _, v := range vacancies {
+		if title != "" && !sameVacancy(v.Title, v.Company, title, company) {
+			continue
+		}
+		for _, q := range v.Questions {
+			if tag != "" && tag != allQuestionTags && !q.hasTag(tag) {
+				continue
+			}
+			text := strings.ToLower(q.Text + " " + q.Notes + " " + q.Round + " " + strings.Join(q.Tags, " ") + " " + v.Company + " " + v.Title)
+			matched := true
+			for _, w := range words {
+				if !strings.Contains(text, w) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				list = append(list, bankQuestion{Title: v.Title, Company: v.Company, InterviewQuestion: q})
+			}
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].AskedAt.After(list[j].AskedAt) })
+	return list
+}
+
+// questionTagChoices - теги для фильтра: стандартные и встречающиеся в вопросах, с количеством
+func questionTagChoices(vacancies []Vacancy) []string {
+	counts := map[string]int{}
+	tags := append([]string{}, questionTags...)
+	for _, v := range vacancies {
+		for _, q := range v.Questions {
+			for _, t := range q.Tags {
+				if indexOfFold(tags, t) == -1 {
+					tags = append(tags, t)
+				}
+				counts[strings.ToLower(t)]++
+			}
+		}
+	}
+	choices := []string{allQuestionTags}
+	for _, t := range tags {
+		choices = append(choices, fmt.Sprintf("%s (%d)", t, counts[strings.ToLower(t)]))
+	}
+	return choices
+}
+
+// indexOfFold - индекс строки в списке без учёта регистра, -1 если нет
+func indexOfFold(list []string, s string) int {
+	for i, item := range list {
+		if strings.EqualFold(item, s) {
+			return i
+		}
+	}
+	return -1
+}
+
+// tagFromChoice убирает счётчик из пункта фильтра тегов
+func tagFromChoice(choice string) string {
+	if i := strings.LastIndex(choice, " ("); i > 0 {
+		return choice[:i]
+	}
+	return choice
+}
+
+// splitTags разбирает теги, введённые через запятую, без повторов
+func splitTags(text string) []string {
+	var tags []string
+	for _, t := range strings.Split(text, ",") {
+		if t = strings.TrimSpace(t); t != "" && indexOfFold(tags, t) == -1 {
+			tags = append(tags, t)
+		}
+	}
+	return tags
+}
+
+// changeQuestions меняет вопросы вакансии и сохраняет файл
+func changeQuestions(title, company string, change func(qs []InterviewQuestion) []InterviewQuestion) bool {
+	allVacanciesMutex.Lock()
+	found := false
+	for i := range allVacancies {
+		if sameVacancy(allVacancies[i].Title, allVacancies[i].Company, title, company) {
+			allVacancies[i].Questions = change(allVacancies[i].Questions)
+			found = true
+			break
+		}
+	}
+	allVacanciesMutex.Unlock()
+	if found {
+		saveVacancies()
+		vacancyEvents.Publish(VacancyEvent{Kind: VacancyUpdated, Title: title, Company: company})
+	}
+	return found
+}
+
+// promptQuestion добавляет или редактирует вопрос
+func promptQuestion(owner walk.Form, q InterviewQuestion) (InterviewQuestion, bool) {
+	var dlg *walk.Dialog
+	var textTE, notesTE *walk.TextEdit
+	var roundCB *walk.ComboBox
+	var askedDE *walk.DateEdit
+	var otherTagsLE *walk.LineEdit
+	var acceptPB, cancelPB *walk.PushButton
+	accepted := false
+
+	if q.AskedAt.IsZero() {
+		q.AskedAt = time.Now()
+	}
+	tagBoxes := make([]*walk.CheckBox, len(questionTags))
+	var tagWidgets []Widget
+	var otherTags []string
+	for i, t := range questionTags {
+		tagWidgets = append(tagWidgets, CheckBox{AssignTo: &tagBoxes[i], Text: t, Checked: q.hasTag(t)})
+	}
+	for _, t := range q.Tags {
+		if indexOfFold(questionTags, t) == -1 {
+			otherTags = append(otherTags, t)
+		}
+	}
+	label := func(text string) Widget {
+		return Label{Text: text, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Вопрос с собеседования",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 520, Height: 460},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			label("Вопрос:"),
+			TextEdit{AssignTo: &textTE, Text: q.Text, VScroll: true, MinSize: Size{Height: 60}, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 5},
+				Children: []Widget{
+					label("Этап:"),
+					ComboBox{AssignTo: &roundCB, Editable: true, Model: questionRounds, Value: q.Round, Font: Font{PointSize: 9}},
+					label("Когда:"),
+					DateEdit{AssignTo: &askedDE, Format: "dd.MM.yyyy", Date: q.AskedAt},
+				},
+			},
+			label("Теги:"),
+			Composite{Layout: Grid{Columns: 4, MarginsZero: true}, Children: tagWidgets},
+			LineEdit{AssignTo: &otherTagsLE, Text: strings.Join(otherTags, ", "), CueBanner: "Другие теги через запятую", Font: Font{PointSize: 9}},
+			label("Заметки (ответ, что подтянуть):"),
+			TextEdit{AssignTo: &notesTE, Text: q.Notes, VScroll: true, MinSize: Size{Height: 70}, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							text := strings.TrimSpace(textTE.Text())
+							if text == "" {
+								walk.MsgBox(dlg, "Вопрос", "Введите текст вопроса.", walk.MsgBoxIconInformation)
+								return
+							}
+							var tags []string
+							for i, cb := range tagBoxes {
+								if cb.Checked() {
+									tags = append(tags, questionTags[i])
+								}
+							}
+							for _, t := range splitTags(otherTagsLE.Text()) {
+								if indexOfFold(tags, t) == -1 {
+									tags = append(tags, t)
+								}
+							}
+							if q.ID == "" {
+								q.ID = strconv.FormatInt(time.Now().UnixNano(), 36)
+							}
+							q.Text = text
+							q.Round = strings.TrimSpace(roundCB.Text())
+							q.AskedAt = askedDE.Date()
+							q.Tags = tags
+							q.Notes = strings.TrimSpace(notesTE.Text())
+							accepted = true
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(owner); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	return q, accepted
+}
+
+// QuestionModel - таблица банка вопросов
+type QuestionModel struct {
+	walk.TableModelBase
+	items []bankQuestion
+}
+
+func (m *QuestionModel) RowCount() int {
+	return len(m.items)
+}
+
+func (m *QuestionModel) Value(row, col int) interface{} {
+	q := m.items[row]
+	switch col {
+	case 0:
+		return q.Text
+	case 1:
+		return strings.Join(q.Tags, ", ")
+	case 2:
+		return q.Round
+	case 3:
+		return relationLabel(RelatedVacancy{Title: q.Title, Company: q.Company})
+	case 4:
+		if q.AskedAt.IsZero() {
+			return ""
+		}
+		return q.AskedAt.Format("02.01.2006")
+	}
+	return ""
+}
+
+// showQuestionBank открывает банк вопросов по всем вакансиям
+func (app *AppMainWindow) showQuestionBank() {
+	app.showQuestionBankFor("", "")
+}
+
+// showQuestionBankForSelected открывает банк вопросов с отбором по выбранной вакансии
+func (app *AppMainWindow) showQuestionBankForSelected() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		walk.MsgBox(app.MainWindow, "Вопросы с собеседований", "Пожалуйста, выберите вакансию.", walk.MsgBoxIconInformation)
+		return
+	}
+	v := app.vacancyModel.items[idx]
+	app.showQuestionBankFor(v.Title, v.Company)
+}
+
+// showQuestionBankFor - банк вопросов со всех собеседований: поиск, отбор по тегу и по вакансии.
+// Если передана вакансия, сначала показываются только её вопросы и к ней можно добавлять новые.
+func (app *AppMainWindow) showQuestionBankFor(title, company string) {
+	var dlg *walk.Dialog
+	var table *walk.TableView
+	var searchLE *walk.LineEdit
+	var tagCB *walk.ComboBox
+	var onlyVacancyCB *walk.CheckBox
+	var previewTE *walk.TextEdit
+	var summaryLabel *walk.Label
+	var closePB *walk.PushButton
+	var open bankQuestion
+	opening := false
+	ready := false // Обработчики срабатывают ещё при создании окна, до того как все виджеты назначены
+
+	model := &QuestionModel{}
+	tagChoices := questionTagChoices(snapshotVacancies())
+	refresh := func() {
+		if !ready {
+			return
+		}
+		tag := allQuestionTags
+		if i := tagCB.CurrentIndex(); i > 0 && i < len(tagChoices) {
+			tag = tagFromChoice(tagChoices[i])
+		}
+		vacancies := snapshotVacancies()
+		filterTitle, filterCompany := "", ""
+		if title != "" && onlyVacancyCB.Checked() {
+			filterTitle, filterCompany = title, company
+		}
+		model.items = collectQuestions(vacancies, filterTitle, filterCompany, searchLE.Text(), tag)
+		model.PublishRowsReset()
+		total := len(collectQuestions(vacancies, "", "", "", ""))
+		summaryLabel.SetText(fmt.Sprintf("Показано %d из %d", len(model.items), total))
+		previewTE.SetText("")
+	}
+	selected := func() (bankQuestion, bool) {
+		i := table.CurrentIndex()
+		if i < 0 || i >= len(model.items) {
+			walk.MsgBox(dlg, "Вопросы с собеседований", "Выберите вопрос в списке.", walk.MsgBoxIconInformation)
+			return bankQuestion{}, false
+		}
+		return model.items[i], true
+	}
+	add := func() {
+		q, ok := promptQuestion(dlg, InterviewQuestion{})
+		if !ok {
+			return
+		}
+		if !changeQuestions(title, company, func(qs []InterviewQuestion) []InterviewQuestion { return append(qs, q) }) {
+			walk.MsgBox(dlg, "Ошибка", "Не удалось найти вакансию.", walk.MsgBoxIconError)
+			return
+		}
+		ready = false
+		tagChoices = questionTagChoices(snapshotVacancies())
+		tagCB.SetModel(tagChoices)
+		tagCB.SetCurrentIndex(0)
+		ready = true
+		refresh()
+	}
+	edit := func() {
+		bq, ok := selected()
+		if !ok {
+			return
+		}
+		q, ok := promptQuestion(dlg, bq.InterviewQuestion)
+		if !ok {
+			return
+		}
+		changeQuestions(bq.Title, bq.Company, func(qs []InterviewQuestion) []InterviewQuestion {
+			for i := range qs {
+				if qs[i].ID == q.ID {
+					qs[i] = q
+				}
+			}
+			return qs
+		})
+		refresh()
+	}
+	remove := func() {
+		bq, ok := selected()
+		if !ok || walk.MsgBox(dlg, "Удаление", "Удалить вопрос из банка?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+			return
+		}
+		changeQuestions(bq.Title, bq.Company, func(qs []InterviewQuestion) []InterviewQuestion {
+			for i := range qs {
+				if qs[i].ID == bq.ID {
+					return append(qs[:i], qs[i+1:]...)
+				}
+			}
+			return qs
+		})
+		refresh()
+	}
+	jump := func() {
+		if bq, ok := selected(); ok {
+			open, opening = bq, true
+			dlg.Accept()
+		}
+	}
+
+	heading := "Вопросы со всех собеседований"
+	if title != "" {
+		heading = "Вопросы с собеседований: " + relationLabel(RelatedVacancy{Title: title, Company: company})
+	}
+
+	if err := (Dialog{
+		AssignTo:     &dlg,
+		Title:        "Банк вопросов",
+		CancelButton: &closePB,
+		MinSize:      Size{Width: 820, Height: 520},
+		Layout:       VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:   SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: heading, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 10}},
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 5},
+				Children: []Widget{
+					LineEdit{AssignTo: &searchLE, CueBanner: "Поиск по вопросам, заметкам и компаниям", Font: Font{PointSize: 9}, OnTextChanged: func() { refresh() }},
+					ComboBox{AssignTo: &tagCB, Model: tagChoices, CurrentIndex: 0, Font: Font{PointSize: 9}, OnCurrentIndexChanged: func() { refresh() }},
+					CheckBox{AssignTo: &onlyVacancyCB, Text: "Только эта вакансия", Checked: title != "", Visible: title != "", OnCheckedChanged: func() { refresh() }},
+				},
+			},
+			TableView{
+				AssignTo: &table,
+				Model:    model,
+				Columns: []TableViewColumn{
+					{Title: "Вопрос", Width: 330},
+					{Title: "Теги", Width: 140},
+					{Title: "Этап", Width: 100},
+					{Title: "Вакансия", Width: 150},
+					{Title: "Дата", Width: 75},
+				},
+				OnItemActivated: edit,
+				OnCurrentIndexChanged: func() {
+					i := table.CurrentIndex()
+					if i < 0 || i >= len(model.items) {
+						previewTE.SetText("")
+						return
+					}
+					text := model.items[i].Text
+					if model.items[i].Notes != "" {
+						text += "\r\n\r\nЗаметки:\r\n" + strings.ReplaceAll(model.items[i].Notes, "\n", "\r\n")
+					}
+					previewTE.SetText(text)
+				},
+			},
+			TextEdit{AssignTo: &previewTE, ReadOnly: true, VScroll: true, MinSize: Size{Height: 80}, MaxSize: Size{Height: 120}, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 5},
+				Children: []Widget{
+					Label{AssignTo: &summaryLabel, TextColor: currentTheme.Text},
+					HSpacer{},
+					PushButton{Text: "Добавить...", Visible: title != "", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: add},
+					PushButton{Text: "Изменить...", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: edit},
+					PushButton{Text: "Удалить", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: remove},
+					PushButton{Text: "Перейти к вакансии", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: jump},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	ready = true
+	refresh()
+	dlg.Run()
+
+	if opening && !app.selectVacancy(open.Title, open.Company) {
+		walk.MsgBox(app.MainWindow, "Банк вопросов", "Вакансия '"+open.Title+"' не найдена в списке.", walk.MsgBoxIconWarning)
+	}
+}
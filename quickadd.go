@@ -0,0 +1,141 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Строка быстрого добавления над таблицей: название, компания, ссылка и статус.
+// Enter в любом поле добавляет вакансию без полного диалога, поля очищаются, а
+// статус остаётся выбранным, чтобы подряд заносить вакансии в одном статусе.
+// Ошибки и предупреждения проверки показываются под строкой.
+
+// quickAddRow - строка быстрого добавления для разметки главного окна
+func (app *AppMainWindow) quickAddRow() Widget {
+	edit := func(assignTo **walk.LineEdit, cue string, stretch int) LineEdit {
+		return LineEdit{
+			AssignTo:      assignTo,
+			CueBanner:     cue,
+			StretchFactor: stretch,
+			Font:          Font{PointSize: 9},
+			OnKeyDown:     app.onQuickAddKey,
+		}
+	}
+	return Composite{
+		Visible: !viewOnly,
+		Layout:  VBox{Margins: Margins{Top: 2, Bottom: 4}, Spacing: 2},
+		Children: []Widget{
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 5},
+				Children: []Widget{
+					edit(&app.quickTitleLE, "Новая вакансия: название", 3),
+					edit(&app.quickCompanyLE, "Компания", 2),
+					edit(&app.quickURLLE, "Ссылка", 2),
+					ComboBox{
+						AssignTo:     &app.quickStatusCB,
+						Model:        possibleStatuses,
+						CurrentIndex: 0,
+						MinSize:      Size{Width: 150},
+						Font:         Font{PointSize: 9},
+					},
+					PushButton{
+						Text:        "+",
+						ToolTipText: "Добавить вакансию (Enter)",
+						MaxSize:     Size{Width: 32},
+						OnClicked:   app.quickAddVacancy,
+						Background:  SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+						Font:        Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+					},
+				},
+			},
+			Label{AssignTo: &app.quickAddIssue, Visible: false, Font: Font{PointSize: 9}},
+		},
+	}
+}
+
+// onQuickAddKey: Enter добавляет вакансию, Esc очищает строку
+func (app *AppMainWindow) onQuickAddKey(key walk.Key) {
+	switch key {
+	case walk.KeyReturn:
+		app.quickAddVacancy()
+	case walk.KeyEscape:
+		app.clearQuickAdd()
+	}
+}
+
+// clearQuickAdd очищает поля строки быстрого добавления; статус не сбрасывается
+func (app *AppMainWindow) clearQuickAdd() {
+	for _, le := range []*walk.LineEdit{app.quickTitleLE, app.quickCompanyLE, app.quickURLLE} {
+		le.SetText("")
+	}
+	app.quickAddIssue.SetVisible(false)
+	app.quickTitleLE.SetFocus()
+}
+
+// showQuickAddIssue показывает под строкой ошибку или предупреждение
+func (app *AppMainWindow) showQuickAddIssue(issue FieldIssue) {
+	prefix, color := "⚠ ", walk.RGB(190, 110, 0)
+	if issue.Severity == issueError {
+		prefix, color = "✖ ", walk.RGB(200, 0, 0)
+	}
+	app.quickAddIssue.SetText(prefix + issue.Message)
+	app.quickAddIssue.SetTextColor(color)
+	app.quickAddIssue.SetVisible(true)
+}
+
+// quickAddVacancy добавляет вакансию из строки быстрого добавления
+func (app *AppMainWindow) quickAddVacancy() {
+	if viewOnly {
+		return
+	}
+	v := Vacancy{
+		Title:     strings.TrimSpace(app.quickTitleLE.Text()),
+		Company:   strings.TrimSpace(app.quickCompanyLE.Text()),
+		SourceURL: strings.TrimSpace(app.quickURLLE.Text()),
+		Status:    app.quickStatusCB.Text(),
+	}
+	if v.Status == "" {
+		v.Status = possibleStatuses[0]
+	}
+
+	allVacanciesMutex.Lock()
+	duplicate := app.findVacancyIndexInAllExt(v.Title, v.Company) != -1
+	allVacanciesMutex.Unlock()
+	issues := validateVacancy(v, duplicate, true)
+	if blocked, ok := blockedCompany(v.Company); ok {
+		issues = append(issues, FieldIssue{Field: fieldCompany, Severity: issueWarning, Message: "Компания в вашем чёрном списке ('" + blocked + "')."})
+	}
+	if r, ok := checkCompanyCooldown(v.Company, v.Title); ok {
+		issues = append(issues, FieldIssue{Field: fieldCompany, Severity: issueWarning, Message: cooldownMessage(r, appSettings.RejectionCooldownMonths)})
+	}
+	if issue, ok := firstIssueError(issues); ok {
+		app.showQuickAddIssue(issue)
+		if issue.Field == fieldSourceURL {
+			app.quickURLLE.SetFocus()
+		} else {
+			app.quickTitleLE.SetFocus()
+		}
+		return
+	}
+	if !confirmApplyAfterRejection(app.MainWindow, v, v.Status) {
+		return
+	}
+	askRejectionReasonIfNeeded(app.MainWindow, &v, "")
+
+	stampNewVacancy(&v)
+	allVacanciesMutex.Lock()
+	allVacancies = append(allVacancies, v)
+	allVacanciesMutex.Unlock()
+	saveVacancies()
+	log.Printf("Вакансия '%s' добавлена из строки быстрого добавления.", v.Title)
+
+	app.clearQuickAdd()
+	// Ошибок не осталось, а предупреждения не мешают добавлению - показываем первое из них
+	if len(issues) > 0 {
+		app.showQuickAddIssue(FieldIssue{Severity: issueWarning, Message: "Добавлено. " + issues[0].Message})
+	}
+	vacancyEvents.Publish(vacancyEvent(VacancyAdded, v))
+}
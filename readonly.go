@@ -0,0 +1,84 @@
+package main
+
+import (
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/lxn/walk"
+)
+
+// Режим просмотра: чужая база (например, наставника, который смотрит на ход поиска)
+// открывается только для чтения. Вакансии, настройки и журнал изменений не записываются,
+// резервные копии и черновики не создаются, кнопки правки выключены, а над таблицей
+// висит заметная полоса. Запускается отдельным окном с --view <папка> или --readonly.
+var viewOnly bool
+
+// viewDirFromArgs - папка чужой базы из --view <папка> или --view=<папка>
+func viewDirFromArgs(args []string) string {
+	return flagValueFromArgs(args, "view")
+}
+
+// readOnlyFlagFromArgs проверяет, просил ли пользователь открыть профиль только для чтения
+func readOnlyFlagFromArgs(args []string) bool {
+	return boolFlagFromArgs(args, "readonly")
+}
+
+// selectViewOnlyProfile включает режим просмотра по аргументам запуска.
+// Возвращает true, если открыта папка из --view и обычный выбор профиля не нужен.
+func selectViewOnlyProfile() bool {
+	viewOnly = readOnlyFlagFromArgs(os.Args[1:])
+	dir := viewDirFromArgs(os.Args[1:])
+	if dir == "" {
+		return false
+	}
+	viewOnly = true
+	activeProfile = SearchProfile{Name: "Просмотр: " + filepath.Base(filepath.Clean(dir)), Dir: dir}
+	log.Printf("Режим просмотра: %s", dir)
+	return true
+}
+
+// openViewOnlyWindow предлагает выбрать папку с чужой базой и открывает её в отдельном окне для просмотра
+func (app *AppMainWindow) openViewOnlyWindow() {
+	fd := new(walk.FileDialog)
+	fd.Title = "Папка с файлом " + vacanciesFile + " для просмотра"
+	if abs, err := filepath.Abs(profilesDir); err == nil {
+		fd.InitialDirPath = abs
+	}
+	ok, err := fd.ShowBrowseFolder(app.MainWindow)
+	if err != nil || !ok {
+		return
+	}
+	if _, err := os.Stat(filepath.Join(fd.FilePath, vacanciesFile)); err != nil {
+		walk.MsgBox(app.MainWindow, "Просмотр", "В папке нет файла "+vacanciesFile+".", walk.MsgBoxIconWarning)
+		return
+	}
+	exe, err := os.Executable()
+	if err == nil {
+		err = exec.Command(exe, "--view", fd.FilePath).Start()
+	}
+	if err != nil {
+		log.Printf("Ошибка открытия %s для просмотра: %v", fd.FilePath, err)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось открыть базу для просмотра", err))
+	}
+}
+
+// applyViewOnlyMode показывает полосу режима просмотра и выключает правку в главном окне
+func (app *AppMainWindow) applyViewOnlyMode() {
+	if !viewOnly {
+		return
+	}
+	app.MainWindow.SetTitle("Поисковик Вакансий — " + activeProfile.Name + " (только просмотр)")
+	if app.viewOnlyBanner != nil {
+		dir, _ := filepath.Abs(dataPath(""))
+		app.viewOnlyBanner.SetText("Только просмотр: " + dir + ". Изменения не сохраняются.")
+		app.viewOnlyBar.SetVisible(true)
+	}
+	// Поля панели деталей выключает detailsEditable при каждом выборе вакансии
+	for _, pb := range []*walk.PushButton{app.addVacancyButton, app.editVacancyButton, app.deleteVacancyButton, app.duplicateButton} {
+		if pb != nil {
+			pb.SetEnabled(false)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+)
+
+const (
+	recoveryFileName = "recovery.json"
+	recoveryInterval = 15 * time.Second
+
+	draftKindDialog  = "dialog"  // Окно добавления/редактирования вакансии
+	draftKindDetails = "details" // Панель деталей главного окна
+)
+
+// EditDraft - несохранённые правки вакансии, записанные на случай аварийного завершения
+type EditDraft struct {
+	Kind            string    `json:"kind"`
+	IsEdit          bool      `json:"isEdit,omitempty"`
+	OriginalTitle   string    `json:"originalTitle,omitempty"`
+	OriginalCompany string    `json:"originalCompany,omitempty"`
+	OriginalStatus  string    `json:"originalStatus,omitempty"`
+	Vacancy         Vacancy   `json:"vacancy"`
+	SavedAt         time.Time `json:"savedAt"`
+}
+
+// draftSource снимает черновик с открытой формы; ok == false, если несохранённых правок нет
+type draftSource func() (draft EditDraft, ok bool)
+
+// Источники черновиков и последнее записанное содержимое файла.
+// Используются только из потока интерфейса.
+var (
+	draftSources     = map[string]draftSource{}
+	lastRecoveryData []byte
+)
+
+// registerDraftSource подключает форму к периодическому сохранению черновиков
+func registerDraftSource(kind string, source draftSource) {
+	draftSources[kind] = source
+}
+
+// unregisterDraftSource отключает форму и сразу убирает её черновик из файла
+func unregisterDraftSource(kind string) {
+	delete(draftSources, kind)
+	writeRecoveryDrafts()
+}
+
+// writeRecoveryDrafts записывает черновики всех открытых форм. Если правок нет, файл удаляется.
+func writeRecoveryDrafts() {
+	kinds := make([]string, 0, len(draftSources))
+	for kind := range draftSources {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+
+	var drafts []EditDraft
+	for _, kind := range kinds {
+		if draft, ok := draftSources[kind](); ok {
+			draft.Kind = kind
+			drafts = append(drafts, draft)
+		}
+	}
+	if len(drafts) == 0 {
+		if lastRecoveryData != nil {
+			clearRecoveryFile()
+		}
+		return
+	}
+
+	// Время не участвует в сравнении, иначе файл перезаписывался бы на каждом тике
+	data, err := json.Marshal(drafts)
+	if err != nil {
+		log.Printf("Ошибка кодирования черновиков: %v", err)
+		return
+	}
+	if string(data) == string(lastRecoveryData) {
+		return
+	}
+	now := time.Now()
+	for i := range drafts {
+		drafts[i].SavedAt = now
+	}
+	fileData, err := json.MarshalIndent(drafts, "", "  ")
+	if err != nil {
+		log.Printf("Ошибка кодирования черновиков: %v", err)
+		return
+	}
+	path := dataPath(recoveryFileName)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, fileData, 0644); err != nil {
+		log.Printf("Ошибка записи черновиков в %s: %v", tmp, err)
+		return
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		log.Printf("Ошибка записи черновиков в %s: %v", path, err)
+		return
+	}
+	lastRecoveryData = data
+}
+
+// loadRecoveryDrafts читает черновики, оставшиеся после аварийного завершения
+func loadRecoveryDrafts() ([]EditDraft, error) {
+	data, err := os.ReadFile(dataPath(recoveryFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var drafts []EditDraft
+	if err := json.Unmarshal(data, &drafts); err != nil {
+		return nil, err
+	}
+	return drafts, nil
+}
+
+// clearRecoveryFile удаляет файл черновиков
+func clearRecoveryFile() {
+	lastRecoveryData = nil
+	if err := os.Remove(dataPath(recoveryFileName)); err != nil && !os.IsNotExist(err) {
+		log.Printf("Не удалось удалить файл черновиков: %v", err)
+	}
+}
+
+// parseKeywords разбирает строку ключевых слов через запятую
+func parseKeywords(s string) []string {
+	keywords := []string{}
+	for _, kw := range strings.Split(s, ",") {
+		if kw = strings.TrimSpace(kw); kw != "" {
+			keywords = append(keywords, kw)
+		}
+	}
+	return keywords
+}
+
+// formVacancy собирает вакансию из текущих значений полей диалога.
+// ok == false, пока диалог ещё создаётся и не все поля готовы.
+func (dlg *AddVacancyDialog) formVacancy() (v Vacancy, ok bool) {
+	if dlg.binder == nil {
+		return Vacancy{}, false
+	}
+	// Пока binder заполняет виджеты, актуальные значения уже лежат в форме
+	if !dlg.resetting && dlg.binder.Submit() != nil {
+		return Vacancy{}, false
+	}
+	v = *dlg.vacancy
+	dlg.form.applyTo(&v)
+	v.Status = dlg.form.Status
+	return v, true
+}
+
+// draft снимает содержимое открытого диалога вакансии
+func (dlg *AddVacancyDialog) draft() (EditDraft, bool) {
+	v, ok := dlg.formVacancy()
+	if !ok || !vacancyFieldsDiffer(dlg.baseline, v) {
+		return EditDraft{}, false
+	}
+	return EditDraft{
+		IsEdit:          dlg.isEdit,
+		OriginalTitle:   dlg.originalTitle,
+		OriginalCompany: dlg.originalCompany,
+		OriginalStatus:  dlg.originalStatus,
+		Vacancy:         v,
+	}, true
+}
+
+// detailsDraft снимает правки из панели деталей, если они отличаются от сохранённой вакансии
+func (app *AppMainWindow) detailsDraft() (EditDraft, bool) {
+	if app.vacancyTable == nil || app.detailNotesTE == nil || !app.detailNotesTE.Enabled() {
+		return EditDraft{}, false
+	}
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		return EditDraft{}, false
+	}
+	saved, _ := app.vacancyAt(idx)
+	form, err := app.DetailsVM.submit()
+	if err != nil {
+		return EditDraft{}, false
+	}
+	v := saved
+	form.applyTo(&v)
+	v.Status = form.Status
+	if !vacancyFieldsDiffer(saved, v) {
+		return EditDraft{}, false
+	}
+	return EditDraft{
+		IsEdit:          true,
+		OriginalTitle:   saved.Title,
+		OriginalCompany: saved.Company,
+		OriginalStatus:  saved.Status,
+		Vacancy:         v,
+	}, true
+}
+
+// vacancyFieldsDiffer сравнивает редактируемые поля вакансии. Пустые статус и опыт
+// равны значениям по умолчанию, которые подставляют формы; переводы строк
+// из TextEdit (\r\n) не считаются изменением.
+func vacancyFieldsDiffer(a, b Vacancy) bool {
+	orDefault := func(value, def string) string {
+		if value == "" {
+			return def
+		}
+		return value
+	}
+	text := func(s string) string {
+		return strings.TrimSpace(strings.ReplaceAll(s, "\r\n", "\n"))
+	}
+	return a.Title != b.Title || a.Company != b.Company ||
+		orDefault(a.Status, possibleStatuses[0]) != orDefault(b.Status, possibleStatuses[0]) ||
+		orDefault(a.ExperienceLevel, possibleExperienceLevels[0]) != orDefault(b.ExperienceLevel, possibleExperienceLevels[0]) ||
+		!equalStringSlices(a.Keywords, b.Keywords) ||
+		a.SourceURL != b.SourceURL || a.Salary != b.Salary ||
+		text(a.Description) != text(b.Description) || text(a.Notes) != text(b.Notes)
+}
+
+// fillDetailsPanel показывает восстановленные правки в панели деталей (без сохранения)
+func (app *AppMainWindow) fillDetailsPanel(v Vacancy) {
+	if !app.ensureDetails() {
+		return
+	}
+	app.DetailsVM.bindForm(newDetailsFormData(v, true))
+}
+
+// restoreDraft открывает черновик в той форме, где он был начат
+func (app *AppMainWindow) restoreDraft(d EditDraft) {
+	original := Vacancy{Title: d.OriginalTitle, Company: d.OriginalCompany, Status: d.OriginalStatus}
+	exists := d.IsEdit && app.findVacancyIndexInAllExt(d.OriginalTitle, d.OriginalCompany) != -1
+
+	if d.Kind == draftKindDetails && exists {
+		for i, item := range app.vacancyModel.items {
+			if item.Title == d.OriginalTitle && item.Company == d.OriginalCompany {
+				app.vacancyTable.SetCurrentIndex(i)
+				// Панель заполняется через Synchronize - подставляем черновик после неё
+				app.MainWindow.Synchronize(func() { app.fillDetailsPanel(d.Vacancy) })
+				return
+			}
+		}
+	}
+
+	// Вакансия могла быть удалена - тогда черновик превращается в новую вакансию
+	v := d.Vacancy
+	if !exists {
+		original = Vacancy{Status: d.OriginalStatus}
+	}
+	showVacancyDialogFrom(app, &v, exists, false, original)
+}
+
+// startCrashRecovery предлагает восстановить черновики после аварийного завершения
+// и запускает их периодическое сохранение
+func (app *AppMainWindow) startCrashRecovery() {
+	drafts, err := loadRecoveryDrafts()
+	if err != nil {
+		log.Printf("Не удалось прочитать черновики: %v", err)
+	}
+
+	var restore []EditDraft
+	if len(drafts) > 0 {
+		lines := make([]string, 0, len(drafts))
+		for _, d := range drafts {
+			where := "окно вакансии"
+			if d.Kind == draftKindDetails {
+				where = "панель деталей"
+			}
+			lines = append(lines, fmt.Sprintf("• %s (%s, %s)", d.Vacancy.Title, where, d.SavedAt.Local().Format("02.01.2006 15:04")))
+		}
+		if walk.DlgCmdYes == walk.MsgBox(app.MainWindow, "Восстановление правок",
+			"Прошлый сеанс завершился аварийно. Остались несохранённые правки:\n\n"+strings.Join(lines, "\n")+"\n\nВосстановить их?",
+			walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) {
+			restore = drafts
+		}
+		clearRecoveryFile()
+	}
+
+	registerDraftSource(draftKindDetails, app.detailsDraft)
+	go func() {
+		for range time.Tick(recoveryInterval) {
+			app.Synchronize(writeRecoveryDrafts)
+		}
+	}()
+
+	for _, d := range restore {
+		app.restoreDraft(d)
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import "regexp"
+
+// Режим презентации для показа экрана на тренировочных собеседованиях: зарплаты, заметки,
+// контакты рекрутеров, плюсы и минусы офферов и журнал писем заменяются точками в таблице,
+// панели деталей и окне вакансии. Пока режим включён, правка в панели и окне вакансии
+// выключена, чтобы точки не записались вместо настоящих значений. Режим не сохраняется
+// между запусками.
+var presentationMode bool
+
+const redactedText = "••••••"
+
+// Адреса почты и телефоны в тексте описания
+var contactPattern = regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.-]+|\+?\d[\d\s()-]{8,}\d`)
+
+// redactVacancy - копия вакансии без зарплаты, заметок и контактов
+func redactVacancy(v Vacancy) Vacancy {
+	hide := func(s *string) {
+		if *s != "" {
+			*s = redactedText
+		}
+	}
+	hide(&v.Salary)
+	hide(&v.Notes)
+	hide(&v.Recruiter)
+	hide(&v.RecruiterEmail)
+	hide(&v.OfferPros)
+	hide(&v.OfferCons)
+	hide(&v.RejectionComment)
+	v.SalaryMin, v.SalaryMax = 0, 0
+	v.Description = contactPattern.ReplaceAllString(v.Description, redactedText)
+	journal := make([]JournalEntry, len(v.Journal))
+	for i, e := range v.Journal {
+		journal[i] = JournalEntry{At: e.At, Kind: e.Kind, Text: redactedText}
+	}
+	v.Journal = journal
+	return v
+}
+
+// togglePresentationMode включает и выключает режим презентации
+func (app *AppMainWindow) togglePresentationMode() {
+	presentationMode = !presentationMode
+	if app.presentationAction != nil {
+		app.presentationAction.SetChecked(presentationMode)
+	}
+	app.vacancyModel.PublishRowsReset()
+	app.updateVacancyDetails()
+	refreshVacancyWindows()
+}
+
+// detailsEditable проверяет, можно ли сейчас править вакансию в панели деталей и окне вакансии
+func detailsEditable() bool {
+	return !viewOnly && !presentationMode
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+const rejectedStatus = "Отказ"
+
+// Причины отказа, из которых выбирает пользователь
+var rejectionReasons = []string{
+	"Нет ответа",
+	"После скрининга",
+	"После тестового задания",
+	"После собеседования",
+	"Не сошлись по зарплате",
+	"Другое",
+}
+
+// Подпись для отказов без указанной причины
+const unknownRejectionReason = "Не указана"
+
+// promptRejectionReason спрашивает причину отказа. Возвращает выбранную причину
+// и комментарий; ok == false, если пользователь закрыл окно без выбора.
+func promptRejectionReason(owner walk.Form, vacancyTitle string) (reason, comment string, ok bool) {
+	var dlg *walk.Dialog
+	var reasonCB *walk.ComboBox
+	var commentLE *walk.LineEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Причина отказа",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 380, Height: 200},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Почему закончилась вакансия '" + vacancyTitle + "'?", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			ComboBox{AssignTo: &reasonCB, Model: rejectionReasons, CurrentIndex: 0, Font: Font{PointSize: 9}},
+			Label{Text: "Комментарий (необязательно):", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			LineEdit{AssignTo: &commentLE, Font: Font{PointSize: 9}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							reason = reasonCB.Text()
+							comment = strings.TrimSpace(commentLE.Text())
+							ok = true
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Пропустить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(owner); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	return reason, comment, ok
+}
+
+// askRejectionReasonIfNeeded запрашивает причину, если вакансия только что перешла в статус "Отказ"
+func askRejectionReasonIfNeeded(owner walk.Form, v *Vacancy, oldStatus string) {
+	if v.Status != rejectedStatus || oldStatus == rejectedStatus {
+		return
+	}
+	if reason, comment, ok := promptRejectionReason(owner, v.Title); ok {
+		v.RejectionReason = reason
+		v.RejectionComment = comment
+	}
+}
+
+// buildRejectionBreakdown считает отказы по причинам
+func buildRejectionBreakdown(vacancies []Vacancy) []ReportBar {
+	counts := map[string]int{}
+	for _, v := range vacancies {
+		if !everReached(v, rejectedStatus) {
+			continue
+		}
+		reason := v.RejectionReason
+		if reason == "" {
+			reason = unknownRejectionReason
+		}
+		counts[reason]++
+	}
+
+	var bars []ReportBar
+	for _, reason := range append(append([]string{}, rejectionReasons...), unknownRejectionReason) {
+		if counts[reason] > 0 {
+			bars = append(bars, ReportBar{Label: reason, Value: counts[reason]})
+		}
+	}
+	return scaleBars(bars)
+}
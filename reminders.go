@@ -0,0 +1,469 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Как часто проверять, не подошёл ли срок напоминаний
+const reminderCheckInterval = 30 * time.Second
+
+// Срок "без ограничения" для выборки всех предстоящих напоминаний
+var farFuture = time.Date(9999, 1, 1, 0, 0, 0, 0, time.Local)
+
+// Reminder - напоминание по вакансии: "написать рекрутеру в пятницу"
+type Reminder struct {
+	ID     string    `json:"id"`
+	Text   string    `json:"text"`
+	DueAt  time.Time `json:"dueAt"`
+	DoneAt time.Time `json:"doneAt,omitzero"` // Когда отмечено выполненным
+}
+
+// dueReminder - напоминание вместе с вакансией, к которой оно относится
+type dueReminder struct {
+	Title   string
+	Company string
+	Reminder
+}
+
+// newReminderID возвращает идентификатор, уникальный в пределах файла вакансий
+func newReminderID() string {
+	return strconv.FormatInt(time.Now().UnixNano(), 36)
+}
+
+// Варианты "Отложить"
+var snoozeOptions = []struct {
+	Label string
+	Next  func(now time.Time) time.Time
+}{
+	{"на 1 час", func(now time.Time) time.Time { return now.Add(time.Hour) }},
+	{"на 3 часа", func(now time.Time) time.Time { return now.Add(3 * time.Hour) }},
+	{"до завтра, 10:00", func(now time.Time) time.Time { return atTime(now.AddDate(0, 0, 1), 10) }},
+	{"на неделю", func(now time.Time) time.Time { return now.AddDate(0, 0, 7) }},
+}
+
+// atTime - тот же день в hour:00
+func atTime(day time.Time, hour int) time.Time {
+	y, m, d := day.Date()
+	return time.Date(y, m, d, hour, 0, 0, 0, time.Local)
+}
+
+// nextWeekday - ближайший следующий день недели в 10:00
+func nextWeekday(now time.Time, wd time.Weekday) time.Time {
+	days := (int(wd) - int(now.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return atTime(now.AddDate(0, 0, days), 10)
+}
+
+// formatDue - срок напоминания для списков: "сегодня 15:00", "завтра 10:00", "просрочено: 12.10 09:00"
+func formatDue(due, now time.Time) string {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	clock := due.Format("15:04")
+	switch {
+	case due.Before(now) && due.Before(today):
+		return "просрочено: " + due.Format("02.01 15:04")
+	case due.Before(today.AddDate(0, 0, 1)):
+		return "сегодня " + clock
+	case due.Before(today.AddDate(0, 0, 2)):
+		return "завтра " + clock
+	}
+	return due.Format("02.01.2006 15:04")
+}
+
+// pendingReminders - невыполненные напоминания со сроком до until, по порядку сроков
+func pendingReminders(vacancies []Vacancy, until time.Time) []dueReminder {
+	var list []dueReminder
+	for _, v := range vacancies {
+		for _, r := range v.Reminders {
+			if r.DoneAt.IsZero() && !r.DueAt.After(until) {
+				list = append(list, dueReminder{Title: v.Title, Company: v.Company, Reminder: r})
+			}
+		}
+	}
+	sort.SliceStable(list, func(i, j int) bool { return list[i].DueAt.Before(list[j].DueAt) })
+	return list
+}
+
+// snapshotVacancies возвращает копию списка вакансий
+func snapshotVacancies() []Vacancy {
+	allVacanciesMutex.Lock()
+	defer allVacanciesMutex.Unlock()
+	vacancies := make([]Vacancy, len(allVacancies))
+	copy(vacancies, allVacancies)
+	return vacancies
+}
+
+// changeReminder меняет напоминание вакансии и сохраняет файл. false - вакансия или напоминание удалены.
+func changeReminder(title, company, id string, change func(r *Reminder)) bool {
+	allVacanciesMutex.Lock()
+	found := false
+	for i := range allVacancies {
+		if !sameVacancy(allVacancies[i].Title, allVacancies[i].Company, title, company) {
+			continue
+		}
+		for j := range allVacancies[i].Reminders {
+			if allVacancies[i].Reminders[j].ID == id {
+				change(&allVacancies[i].Reminders[j])
+				found = true
+			}
+		}
+	}
+	allVacanciesMutex.Unlock()
+	if found {
+		saveVacancies()
+		vacancyEvents.Publish(VacancyEvent{Kind: VacancyUpdated, Title: title, Company: company})
+	}
+	return found
+}
+
+// completeReminder отмечает напоминание выполненным
+func completeReminder(r dueReminder) bool {
+	return changeReminder(r.Title, r.Company, r.ID, func(rem *Reminder) { rem.DoneAt = time.Now() })
+}
+
+// snoozeReminder переносит срок напоминания
+func snoozeReminder(r dueReminder, due time.Time) bool {
+	return changeReminder(r.Title, r.Company, r.ID, func(rem *Reminder) { rem.DueAt = due })
+}
+
+// promptReminder спрашивает текст и срок нового напоминания
+func promptReminder(owner walk.Form, vacancyTitle string) (Reminder, bool) {
+	var dlg *walk.Dialog
+	var textLE *walk.LineEdit
+	var dueDE *walk.DateEdit
+	var acceptPB, cancelPB *walk.PushButton
+	var result Reminder
+	accepted := false
+
+	now := time.Now()
+	preset := func(text string, due func() time.Time) Widget {
+		return PushButton{
+			Text:       text,
+			Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+			OnClicked:  func() { dueDE.SetDate(due()) },
+		}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Напоминание",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 420, Height: 220},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: fmt.Sprintf("Напомнить по вакансии '%s':", vacancyTitle), TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			LineEdit{AssignTo: &textLE, CueBanner: "Например: написать рекрутеру", Font: Font{PointSize: 9}},
+			Label{Text: "Когда:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			DateEdit{AssignTo: &dueDE, Format: "dd.MM.yyyy HH:mm", Date: atTime(now.AddDate(0, 0, 1), 10), MinDate: atTime(now, 0)},
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 5},
+				Children: []Widget{
+					preset("Через час", func() time.Time { return time.Now().Add(time.Hour) }),
+					preset("Завтра", func() time.Time { return atTime(time.Now().AddDate(0, 0, 1), 10) }),
+					preset("В пятницу", func() time.Time { return nextWeekday(time.Now(), time.Friday) }),
+					preset("Через неделю", func() time.Time { return atTime(time.Now().AddDate(0, 0, 7), 10) }),
+					HSpacer{},
+				},
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Добавить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							text := strings.TrimSpace(textLE.Text())
+							if text == "" {
+								walk.MsgBox(dlg, "Напоминание", "Напишите, о чём напомнить.", walk.MsgBoxIconInformation)
+								return
+							}
+							result = Reminder{ID: newReminderID(), Text: text, DueAt: dueDE.Date()}
+							accepted = true
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(owner); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	return result, accepted
+}
+
+// addReminderToSelected добавляет напоминание к выбранной вакансии
+func (app *AppMainWindow) addReminderToSelected() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		walk.MsgBox(app.MainWindow, "Напоминания", "Пожалуйста, выберите вакансию.", walk.MsgBoxIconInformation)
+		return
+	}
+	v := app.vacancyModel.items[idx]
+	r, ok := promptReminder(app.MainWindow, v.Title)
+	if !ok {
+		return
+	}
+
+	allVacanciesMutex.Lock()
+	i := app.findVacancyIndexInAllExt(v.Title, v.Company)
+	if i != -1 {
+		allVacancies[i].Reminders = append(allVacancies[i].Reminders, r)
+	}
+	allVacanciesMutex.Unlock()
+	if i == -1 {
+		walk.MsgBox(app.MainWindow, "Ошибка", "Не удалось найти вакансию для напоминания.", walk.MsgBoxIconError)
+		return
+	}
+	saveVacancies()
+	vacancyEvents.Publish(vacancyEvent(VacancyUpdated, v))
+}
+
+// updateRemindersList показывает в панели деталей невыполненные напоминания выбранной вакансии
+func (app *AppMainWindow) updateRemindersList(v Vacancy, hasSelection bool) {
+	if app.detailRemindersLB == nil {
+		return
+	}
+	app.reminderItems = nil
+	if hasSelection {
+		app.reminderItems = pendingReminders([]Vacancy{v}, farFuture)
+	}
+	now := time.Now()
+	labels := make([]string, len(app.reminderItems))
+	for i, r := range app.reminderItems {
+		labels[i] = formatDue(r.DueAt, now) + " — " + r.Text
+	}
+	app.detailRemindersLB.SetModel(labels)
+	app.detailRemindersLB.SetEnabled(hasSelection)
+}
+
+// selectedDetailReminder - напоминание, выбранное в панели деталей
+func (app *AppMainWindow) selectedDetailReminder() (dueReminder, bool) {
+	i := app.detailRemindersLB.CurrentIndex()
+	if i < 0 || i >= len(app.reminderItems) {
+		walk.MsgBox(app.MainWindow, "Напоминания", "Выберите напоминание в списке.", walk.MsgBoxIconInformation)
+		return dueReminder{}, false
+	}
+	return app.reminderItems[i], true
+}
+
+// completeDetailReminder отмечает выполненным напоминание из панели деталей
+func (app *AppMainWindow) completeDetailReminder() {
+	if r, ok := app.selectedDetailReminder(); ok {
+		completeReminder(r)
+	}
+}
+
+// snoozeDetailReminder откладывает напоминание из панели деталей до завтра
+func (app *AppMainWindow) snoozeDetailReminder() {
+	if r, ok := app.selectedDetailReminder(); ok {
+		snoozeReminder(r, snoozeOptions[2].Next(time.Now()))
+	}
+}
+
+// ReminderModel - таблица напоминаний в окне "Напоминания"
+type ReminderModel struct {
+	walk.TableModelBase
+	items []dueReminder
+	now   time.Time
+}
+
+func (m *ReminderModel) RowCount() int {
+	return len(m.items)
+}
+
+func (m *ReminderModel) Value(row, col int) interface{} {
+	r := m.items[row]
+	switch col {
+	case 0:
+		return formatDue(r.DueAt, m.now)
+	case 1:
+		return r.Text
+	case 2:
+		return relationLabel(RelatedVacancy{Title: r.Title, Company: r.Company})
+	}
+	return ""
+}
+
+// showRemindersDialog - окно с напоминаниями, срок которых наступил (или всеми предстоящими)
+func (app *AppMainWindow) showRemindersDialog() {
+	if app.remindersDialogOpen {
+		return
+	}
+	app.remindersDialogOpen = true
+	defer func() { app.remindersDialogOpen = false }()
+
+	var dlg *walk.Dialog
+	var table *walk.TableView
+	var allCB *walk.CheckBox
+	var snoozeCB *walk.ComboBox
+	var closePB *walk.PushButton
+	var open dueReminder
+	opening := false
+
+	model := &ReminderModel{}
+	reload := func() {
+		until := time.Now()
+		if allCB != nil && allCB.Checked() {
+			until = farFuture
+		} else {
+			// Показываем всё, что на сегодня, включая ещё не наступившие часы
+			until = atTime(until.AddDate(0, 0, 1), 0)
+		}
+		model.now = time.Now()
+		model.items = pendingReminders(snapshotVacancies(), until)
+		model.PublishRowsReset()
+	}
+	selected := func() (dueReminder, bool) {
+		i := table.CurrentIndex()
+		if i < 0 || i >= len(model.items) {
+			walk.MsgBox(dlg, "Напоминания", "Выберите напоминание в списке.", walk.MsgBoxIconInformation)
+			return dueReminder{}, false
+		}
+		return model.items[i], true
+	}
+	button := func(text string, onClicked walk.EventHandler) Widget {
+		return PushButton{Text: text, Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: onClicked}
+	}
+	snoozeLabels := make([]string, len(snoozeOptions))
+	for i, o := range snoozeOptions {
+		snoozeLabels[i] = o.Label
+	}
+
+	if err := (Dialog{
+		AssignTo:     &dlg,
+		Title:        "Напоминания",
+		CancelButton: &closePB,
+		MinSize:      Size{Width: 640, Height: 360},
+		Layout:       VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:   SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			CheckBox{AssignTo: &allCB, Text: "Показать все предстоящие, а не только на сегодня", OnCheckedChanged: reload},
+			TableView{
+				AssignTo: &table,
+				Model:    model,
+				Columns: []TableViewColumn{
+					{Title: "Срок", Width: 140},
+					{Title: "Напоминание", Width: 260},
+					{Title: "Вакансия", Width: 200},
+				},
+				OnItemActivated: func() {
+					if r, ok := selected(); ok {
+						open, opening = r, true
+						dlg.Accept()
+					}
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 5},
+				Children: []Widget{
+					button("Выполнено", func() {
+						if r, ok := selected(); ok {
+							completeReminder(r)
+							reload()
+						}
+					}),
+					Label{Text: "Отложить:", TextColor: currentTheme.Text},
+					ComboBox{AssignTo: &snoozeCB, Model: snoozeLabels, CurrentIndex: 0},
+					button("Отложить", func() {
+						if r, ok := selected(); ok {
+							snoozeReminder(r, snoozeOptions[snoozeCB.CurrentIndex()].Next(time.Now()))
+							reload()
+						}
+					}),
+					button("Открыть вакансию", func() {
+						if r, ok := selected(); ok {
+							open, opening = r, true
+							dlg.Accept()
+						}
+					}),
+					HSpacer{},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	reload()
+	dlg.Run()
+
+	if opening && !app.selectVacancy(open.Title, open.Company) {
+		walk.MsgBox(app.MainWindow, "Напоминания", "Вакансия '"+open.Title+"' не найдена в списке.", walk.MsgBoxIconWarning)
+	}
+}
+
+// markDueRemindersNotified вызывается при запуске: о просроченных к этому времени напоминаниях
+// отдельных уведомлений не будет - они уже есть в панели "Сегодня"
+func (app *AppMainWindow) markDueRemindersNotified() {
+	app.remindersNotified = map[string]bool{}
+	for _, r := range pendingReminders(snapshotVacancies(), time.Now()) {
+		app.remindersNotified[reminderKey(r)] = true
+	}
+}
+
+// reminderKey отличает один срок напоминания от другого после переноса
+func reminderKey(r dueReminder) string {
+	return r.ID + "@" + strconv.FormatInt(r.DueAt.Unix(), 10)
+}
+
+// startReminderMonitor периодически проверяет сроки напоминаний и показывает уведомления
+func (app *AppMainWindow) startReminderMonitor() {
+	go func() {
+		for range time.Tick(reminderCheckInterval) {
+			app.Synchronize(app.notifyDueReminders)
+		}
+	}()
+}
+
+// notifyDueReminders показывает уведомление о напоминаниях, срок которых только что наступил.
+// Об одном и том же сроке уведомляем один раз; отложенное напоминание уведомит снова.
+func (app *AppMainWindow) notifyDueReminders() {
+	if app.remindersNotified == nil {
+		app.remindersNotified = map[string]bool{}
+	}
+	var fresh []dueReminder
+	for _, r := range pendingReminders(snapshotVacancies(), time.Now()) {
+		if key := reminderKey(r); !app.remindersNotified[key] {
+			app.remindersNotified[key] = true
+			fresh = append(fresh, r)
+		}
+	}
+	switch len(fresh) {
+	case 0:
+		return
+	case 1:
+		app.showToast("Напоминание: "+fresh[0].Title, fresh[0].Text, app.showRemindersDialog)
+	default:
+		app.showToast(fmt.Sprintf("Напоминаний: %d", len(fresh)), fresh[0].Text+" и другие", app.showRemindersDialog)
+	}
+}
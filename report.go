@@ -0,0 +1,420 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Статусы, означающие, что работодатель как-то отреагировал на отклик
+var responseStatuses = []string{"Тестовое задание", "Собеседование", "Оффер", "Отказ"}
+
+// Периоды отчёта, доступные в диалоге
+var reportPeriods = []string{"За неделю", "За месяц"}
+var reportFormats = []string{"HTML", "PDF"}
+
+// ReportBar - одна полоса на диаграмме отчёта
+type ReportBar struct {
+	Label string
+	Value int
+	Width int // Ширина полосы в процентах от максимальной
+}
+
+// CompanyReportRow - строка разбивки по компаниям
+type CompanyReportRow struct {
+	Company      string
+	Vacancies    int
+	Applications int
+	Interviews   int
+	Offers       int
+	Rejections   int
+}
+
+// JobSearchReport содержит агрегированные данные для отчёта о поиске работы
+type JobSearchReport struct {
+	PeriodName   string
+	From         time.Time
+	To           time.Time
+	GeneratedAt  time.Time
+	Total        int
+	Applications int
+	Responses    int
+	Interviews   int
+	Offers       int
+	Rejections   int
+	Funnel       []ReportBar
+	Daily        []ReportBar
+	Statuses     []ReportBar
+	Companies    []CompanyReportRow
+}
+
+// reportRange возвращает границы периода отчёта, заканчивающегося в момент now
+func reportRange(period string, now time.Time) (time.Time, time.Time) {
+	end := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+	if period == "За месяц" {
+		return end.AddDate(0, -1, 0), end
+	}
+	return end.AddDate(0, 0, -7), end
+}
+
+// inRange проверяет попадание момента t в интервал [from, to)
+func inRange(t, from, to time.Time) bool {
+	return !t.IsZero() && !t.Before(from) && t.Before(to)
+}
+
+// buildJobSearchReport собирает статистику по вакансиям за период [from, to)
+func buildJobSearchReport(vacancies []Vacancy, period string, from, to time.Time) JobSearchReport {
+	r := JobSearchReport{PeriodName: period, From: from, To: to, GeneratedAt: time.Now(), Total: len(vacancies)}
+
+	days := int(to.Sub(from).Hours() / 24)
+	daily := make([]int, days)
+	statusCounts := map[string]int{}
+	companies := map[string]*CompanyReportRow{}
+
+	for _, v := range vacancies {
+		statusCounts[v.Status]++
+
+		applied := inRange(v.AppliedAt, from, to)
+		responded := statusReachedBetween(v, from, to, responseStatuses...)
+		interviewed := statusReachedBetween(v, from, to, "Собеседование")
+		offered := statusReachedBetween(v, from, to, "Оффер")
+		rejected := statusReachedBetween(v, from, to, "Отказ")
+
+		if applied {
+			r.Applications++
+			if day := int(v.AppliedAt.Sub(from).Hours() / 24); day >= 0 && day < days {
+				daily[day]++
+			}
+		}
+		if responded {
+			r.Responses++
+		}
+		if interviewed {
+			r.Interviews++
+		}
+		if offered {
+			r.Offers++
+		}
+		if rejected {
+			r.Rejections++
+		}
+
+		active := applied || inRange(v.CreatedAt, from, to) || statusReachedBetween(v, from, to, possibleStatuses...)
+		if !active {
+			continue
+		}
+		name := strings.TrimSpace(v.Company)
+		if name == "" {
+			name = "Не указана"
+		}
+		row, ok := companies[strings.ToLower(name)]
+		if !ok {
+			row = &CompanyReportRow{Company: name}
+			companies[strings.ToLower(name)] = row
+		}
+		row.Vacancies++
+		if applied {
+			row.Applications++
+		}
+		if interviewed {
+			row.Interviews++
+		}
+		if offered {
+			row.Offers++
+		}
+		if rejected {
+			row.Rejections++
+		}
+	}
+
+	r.Funnel = scaleBars([]ReportBar{
+		{Label: "Отклики", Value: r.Applications},
+		{Label: "Ответы", Value: r.Responses},
+		{Label: "Собеседования", Value: r.Interviews},
+		{Label: "Офферы", Value: r.Offers},
+	})
+
+	dailyBars := make([]ReportBar, days)
+	for i := range daily {
+		dailyBars[i] = ReportBar{Label: from.AddDate(0, 0, i).Format("02.01"), Value: daily[i]}
+	}
+	r.Daily = scaleBars(dailyBars)
+
+	var statusBars []ReportBar
+	for _, s := range possibleStatuses {
+		if statusCounts[s] > 0 {
+			statusBars = append(statusBars, ReportBar{Label: s, Value: statusCounts[s]})
+		}
+	}
+	r.Statuses = scaleBars(statusBars)
+
+	for _, row := range companies {
+		r.Companies = append(r.Companies, *row)
+	}
+	sort.Slice(r.Companies, func(i, j int) bool {
+		if r.Companies[i].Applications != r.Companies[j].Applications {
+			return r.Companies[i].Applications > r.Companies[j].Applications
+		}
+		return strings.ToLower(r.Companies[i].Company) < strings.ToLower(r.Companies[j].Company)
+	})
+	return r
+}
+
+// scaleBars проставляет ширину полос относительно максимального значения
+func scaleBars(bars []ReportBar) []ReportBar {
+	maxValue := 0
+	for _, b := range bars {
+		if b.Value > maxValue {
+			maxValue = b.Value
+		}
+	}
+	for i := range bars {
+		if maxValue > 0 {
+			bars[i].Width = bars[i].Value * 100 / maxValue
+		}
+	}
+	return bars
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.Format("02.01.2006") },
+	"datetime": func(t time.Time) string {
+		return t.Format("02.01.2006 15:04")
+	},
+	"lastDay": func(t time.Time) time.Time { return t.AddDate(0, 0, -1) },
+}).Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>Отчёт о поиске работы</title>
+<style>
+body { font-family: "Segoe UI", Arial, sans-serif; color: #222; margin: 32px; -webkit-print-color-adjust: exact; print-color-adjust: exact; }
+h1 { font-size: 22px; margin-bottom: 4px; }
+h2 { font-size: 16px; margin-top: 28px; border-bottom: 1px solid #ccc; padding-bottom: 4px; }
+.muted { color: #777; font-size: 12px; }
+.cards { display: flex; gap: 12px; margin-top: 16px; }
+.card { flex: 1; border: 1px solid #ddd; border-radius: 6px; padding: 10px; text-align: center; }
+.card b { display: block; font-size: 24px; }
+.bar { display: flex; align-items: center; margin: 3px 0; font-size: 12px; }
+.bar span.label { width: 170px; }
+.bar span.fill { background: #4a90d9; height: 14px; margin-right: 6px; }
+table { border-collapse: collapse; width: 100%; font-size: 12px; }
+th, td { border: 1px solid #ddd; padding: 4px 8px; text-align: left; }
+th { background: #f3f3f3; }
+</style>
+</head>
+<body>
+<h1>Отчёт о поиске работы</h1>
+<div class="muted">{{.PeriodName}}: {{date .From}} – {{date (lastDay .To)}} · сформирован {{datetime .GeneratedAt}}</div>
+
+<div class="cards">
+<div class="card"><b>{{.Applications}}</b>отклики</div>
+<div class="card"><b>{{.Responses}}</b>ответы</div>
+<div class="card"><b>{{.Interviews}}</b>собеседования</div>
+<div class="card"><b>{{.Offers}}</b>офферы</div>
+<div class="card"><b>{{.Rejections}}</b>отказы</div>
+</div>
+
+<h2>Воронка</h2>
+{{range .Funnel}}<div class="bar"><span class="label">{{.Label}}</span><span class="fill" style="width: {{.Width}}%"></span>{{.Value}}</div>
+{{end}}
+<h2>Отклики по дням</h2>
+{{range .Daily}}<div class="bar"><span class="label">{{.Label}}</span><span class="fill" style="width: {{.Width}}%"></span>{{.Value}}</div>
+{{end}}
+<h2>Все вакансии по статусам (всего {{.Total}})</h2>
+{{range .Statuses}}<div class="bar"><span class="label">{{.Label}}</span><span class="fill" style="width: {{.Width}}%"></span>{{.Value}}</div>
+{{end}}
+<h2>По компаниям</h2>
+{{if .Companies}}<table>
+<tr><th>Компания</th><th>Вакансий</th><th>Отклики</th><th>Собеседования</th><th>Офферы</th><th>Отказы</th></tr>
+{{range .Companies}}<tr><td>{{.Company}}</td><td>{{.Vacancies}}</td><td>{{.Applications}}</td><td>{{.Interviews}}</td><td>{{.Offers}}</td><td>{{.Rejections}}</td></tr>
+{{end}}</table>{{else}}<p class="muted">За период не было активности.</p>{{end}}
+</body>
+</html>
+`))
+
+// renderReportHTML формирует HTML-представление отчёта
+func renderReportHTML(r JobSearchReport) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := reportTemplate.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("ошибка формирования HTML отчёта: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// findPDFPrinter ищет установленный браузер на базе Chromium, умеющий печатать страницы в PDF
+func findPDFPrinter() (string, error) {
+	var candidates []string
+	for _, env := range []string{"ProgramFiles(x86)", "ProgramFiles", "LocalAppData"} {
+		dir := os.Getenv(env)
+		if dir == "" {
+			continue
+		}
+		candidates = append(candidates,
+			filepath.Join(dir, "Microsoft", "Edge", "Application", "msedge.exe"),
+			filepath.Join(dir, "Google", "Chrome", "Application", "chrome.exe"),
+		)
+	}
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", errors.New("не найден Microsoft Edge или Google Chrome, необходимый для сохранения в PDF")
+}
+
+// writeReportPDF печатает HTML отчёта в PDF с помощью браузера в headless-режиме
+func writeReportPDF(html []byte, pdfPath string) error {
+	browser, err := findPDFPrinter()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp("", "jobsearch-report-*.html")
+	if err != nil {
+		return fmt.Errorf("ошибка создания временного файла: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(html); err != nil {
+		tmp.Close()
+		return fmt.Errorf("ошибка записи временного файла: %w", err)
+	}
+	tmp.Close()
+
+	absPDF, err := filepath.Abs(pdfPath)
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(browser, "--headless", "--disable-gpu", "--no-pdf-header-footer",
+		"--print-to-pdf="+absPDF, "file:///"+filepath.ToSlash(tmp.Name()))
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("ошибка печати в PDF: %w (%s)", err, strings.TrimSpace(string(out)))
+	}
+	if _, err := os.Stat(absPDF); err != nil {
+		return fmt.Errorf("браузер не создал файл PDF: %w", err)
+	}
+	return nil
+}
+
+// openFileExternally открывает файл связанным с ним приложением Windows
+func openFileExternally(path string) error {
+	return exec.Command("cmd", "/c", "start", "", path).Start()
+}
+
+// showReportDialog отображает диалог формирования отчёта о поиске работы
+func (app *AppMainWindow) showReportDialog() {
+	var dlg *walk.Dialog
+	var periodCB, formatCB *walk.ComboBox
+	var acceptPB, cancelPB *walk.PushButton
+
+	generate := func() {
+		period := periodCB.Text()
+		format := formatCB.Text()
+
+		fileDlg := new(walk.FileDialog)
+		fileDlg.Title = "Сохранить отчёт"
+		ext := ".html"
+		if format == "PDF" {
+			ext = ".pdf"
+			fileDlg.Filter = "PDF (*.pdf)|*.pdf"
+		} else {
+			fileDlg.Filter = "HTML (*.html)|*.html"
+		}
+		fileDlg.FilePath = "Отчёт " + time.Now().Format("2006-01-02") + ext
+
+		ok, err := fileDlg.ShowSave(dlg)
+		if err != nil {
+			showError(dlg, "Ошибка", wrapError("Ошибка при открытии диалога", err))
+			return
+		}
+		if !ok {
+			return
+		}
+		path := fileDlg.FilePath
+		if !strings.EqualFold(filepath.Ext(path), ext) {
+			path += ext
+		}
+
+		allVacanciesMutex.Lock()
+		vacancies := make([]Vacancy, len(allVacancies))
+		copy(vacancies, allVacancies)
+		allVacanciesMutex.Unlock()
+
+		from, to := reportRange(period, time.Now())
+		html, err := renderReportHTML(buildJobSearchReport(vacancies, period, from, to))
+		if err == nil {
+			if format == "PDF" {
+				err = writeReportPDF(html, path)
+			} else {
+				err = os.WriteFile(path, html, 0644)
+			}
+		}
+		if err != nil {
+			log.Printf("Ошибка формирования отчёта: %v", err)
+			showError(dlg, "Ошибка", wrapError("Не удалось сформировать отчёт", err))
+			return
+		}
+
+		dlg.Accept()
+		if walk.DlgCmdYes == walk.MsgBox(app.MainWindow, "Отчёт готов", "Отчёт сохранён в файл:\n"+path+"\n\nОткрыть его сейчас?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) {
+			if err := openFileExternally(path); err != nil {
+				showError(app.MainWindow, "Ошибка", wrapError("Не удалось открыть отчёт", err))
+			}
+		}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Сформировать отчёт",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 320, Height: 180},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true, Spacing: 8},
+				Children: []Widget{
+					Label{Text: "Период:", Font: Font{Bold: true, PointSize: 9}, TextColor: currentTheme.Text},
+					ComboBox{AssignTo: &periodCB, Model: reportPeriods, CurrentIndex: 0, Font: Font{PointSize: 9}},
+					Label{Text: "Формат:", Font: Font{Bold: true, PointSize: 9}, TextColor: currentTheme.Text},
+					ComboBox{AssignTo: &formatCB, Model: reportFormats, CurrentIndex: 0, Font: Font{PointSize: 9}},
+				},
+			},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сформировать",
+						OnClicked:  generate,
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						OnClicked:  func() { dlg.Cancel() },
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Форматы резюме в диалоге генерации
+var resumeFormats = []string{"HTML и JSON Resume", "Только HTML", "Только JSON Resume"}
+
+// resumeHighlight - достижение в резюме; Matched - в нём есть слова из вакансии
+type resumeHighlight struct {
+	Text    string
+	Matched bool
+}
+
+// tailoredResume - резюме, подстроенное под вакансию: совпадающие навыки и достижения идут первыми
+type tailoredResume struct {
+	Profile     UserProfile
+	Vacancy     Vacancy
+	Matched     []string // Навыки профиля, найденные в вакансии
+	Other       []string // Остальные навыки
+	Highlights  []resumeHighlight
+	GeneratedAt time.Time
+}
+
+// resumeTerms - слова, по которым достижения считаются подходящими: совпавшие навыки и ключевые слова вакансии
+func resumeTerms(matched []string, v Vacancy) []string {
+	return uniqueFold(append(append([]string{}, matched...), v.Keywords...))
+}
+
+// countTerms считает, сколько слов встречается в тексте
+func countTerms(text string, terms []string) int {
+	m := newTextMatcher(text)
+	n := 0
+	for _, t := range terms {
+		if m.contains(t) {
+			n++
+		}
+	}
+	return n
+}
+
+// splitSkills делит навыки профиля на найденные в вакансии и остальные
+func splitSkills(skills []string, v Vacancy) (matched, other []string) {
+	text := newTextMatcher(vacancyMatchText(v))
+	for _, s := range uniqueFold(skills) {
+		if text.contains(s) {
+			matched = append(matched, s)
+		} else {
+			other = append(other, s)
+		}
+	}
+	return matched, other
+}
+
+// rankHighlights ставит первыми достижения, в которых больше слов из вакансии
+func rankHighlights(highlights, terms []string) []string {
+	ranked := uniqueFold(highlights)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return countTerms(ranked[i], terms) > countTerms(ranked[j], terms)
+	})
+	return ranked
+}
+
+// tailorResume собирает резюме под вакансию из профиля и выбранных достижений
+func tailorResume(p UserProfile, v Vacancy, highlights []string) tailoredResume {
+	r := tailoredResume{Profile: p, Vacancy: v, GeneratedAt: time.Now()}
+	r.Matched, r.Other = splitSkills(p.Skills, v)
+	terms := resumeTerms(r.Matched, v)
+	for _, h := range rankHighlights(highlights, terms) {
+		r.Highlights = append(r.Highlights, resumeHighlight{Text: h, Matched: countTerms(h, terms) > 0})
+	}
+	return r
+}
+
+// jsonResumeSkill - раздел навыков JSON Resume
+type jsonResumeSkill struct {
+	Name     string   `json:"name"`
+	Keywords []string `json:"keywords"`
+}
+
+// jsonResumeOutput - резюме в формате jsonresume.org, которое мы записываем
+type jsonResumeOutput struct {
+	Schema string `json:"$schema"`
+	Basics struct {
+		Name     string `json:"name,omitempty"`
+		Label    string `json:"label,omitempty"`
+		Summary  string `json:"summary,omitempty"`
+		Location struct {
+			City string `json:"city,omitempty"`
+		} `json:"location"`
+	} `json:"basics"`
+	Skills []jsonResumeSkill `json:"skills,omitempty"`
+	Meta   struct {
+		LastModified string `json:"lastModified"`
+	} `json:"meta"`
+}
+
+// renderJSONResume формирует JSON Resume; достижения попадают в summary, так как опыта работы в профиле нет
+func renderJSONResume(r tailoredResume) ([]byte, error) {
+	var out jsonResumeOutput
+	out.Schema = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+	out.Basics.Name = r.Profile.Name
+	out.Basics.Label = r.Profile.DesiredPosition
+	out.Basics.Location.City = r.Profile.Location
+	var summary []string
+	for _, h := range r.Highlights {
+		summary = append(summary, "— "+h.Text)
+	}
+	out.Basics.Summary = strings.Join(summary, "\n")
+	if len(r.Matched) > 0 {
+		out.Skills = append(out.Skills, jsonResumeSkill{Name: "Ключевые для вакансии", Keywords: r.Matched})
+	}
+	if len(r.Other) > 0 {
+		out.Skills = append(out.Skills, jsonResumeSkill{Name: "Другие навыки", Keywords: r.Other})
+	}
+	out.Meta.LastModified = r.GeneratedAt.Format(time.RFC3339)
+	return json.MarshalIndent(out, "", "  ")
+}
+
+var resumeTemplate = template.Must(template.New("resume").Funcs(template.FuncMap{
+	"date": func(t time.Time) string { return t.Format("02.01.2006") },
+}).Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<title>{{.Profile.Name}} — резюме</title>
+<style>
+body { font-family: "Segoe UI", Arial, sans-serif; color: #222; margin: 32px auto; max-width: 760px; }
+h1 { font-size: 26px; margin-bottom: 2px; }
+h2 { font-size: 16px; margin-top: 24px; border-bottom: 1px solid #ccc; padding-bottom: 4px; }
+.muted { color: #777; font-size: 12px; }
+.skill { display: inline-block; border: 1px solid #ddd; border-radius: 4px; padding: 2px 8px; margin: 2px; font-size: 13px; }
+.skill.match { background: #fff3b0; border-color: #e0c200; font-weight: 600; }
+li.match { font-weight: 600; }
+</style>
+</head>
+<body>
+<h1>{{.Profile.Name}}</h1>
+<div>{{.Profile.DesiredPosition}}{{if .Profile.Location}} · {{.Profile.Location}}{{end}}</div>
+<div class="muted">Для вакансии «{{.Vacancy.Title}}»{{if .Vacancy.Company}} в {{.Vacancy.Company}}{{end}} · {{date .GeneratedAt}}</div>
+{{if .Highlights}}
+<h2>Достижения</h2>
+<ul>
+{{range .Highlights}}<li{{if .Matched}} class="match"{{end}}>{{.Text}}</li>
+{{end}}</ul>{{end}}
+{{if or .Matched .Other}}
+<h2>Навыки</h2>
+<div>{{range .Matched}}<span class="skill match">{{.}}</span>{{end}}{{range .Other}}<span class="skill">{{.}}</span>{{end}}</div>{{end}}
+</body>
+</html>
+`))
+
+// renderHTMLResume формирует HTML-резюме
+func renderHTMLResume(r tailoredResume) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := resumeTemplate.Execute(&buf, r); err != nil {
+		return nil, fmt.Errorf("ошибка формирования HTML резюме: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// attachmentTarget - для кого документ: компания, а если её нет - название вакансии
+func attachmentTarget(v Vacancy) string {
+	if v.Company != "" {
+		return v.Company
+	}
+	return v.Title
+}
+
+// storeTailoredResume сохраняет резюме в выбранных форматах как вложения
+func storeTailoredResume(r tailoredResume, format string) ([]Attachment, error) {
+	type output struct {
+		ext    string
+		render func(tailoredResume) ([]byte, error)
+	}
+	var outputs []output
+	if format != resumeFormats[2] {
+		outputs = append(outputs, output{".html", renderHTMLResume})
+	}
+	if format != resumeFormats[1] {
+		outputs = append(outputs, output{".json", renderJSONResume})
+	}
+	var stored []Attachment
+	for _, o := range outputs {
+		data, err := o.render(r)
+		if err == nil {
+			var a Attachment
+			a, err = storeAttachmentData(data, o.ext, "Резюме для "+attachmentTarget(r.Vacancy)+o.ext)
+			stored = append(stored, a)
+		}
+		if err != nil {
+			for _, a := range stored {
+				os.Remove(attachmentPath(a))
+			}
+			return nil, err
+		}
+	}
+	return stored, nil
+}
+
+// showResumeForSelected генерирует резюме под выбранную вакансию и прикрепляет его к ней
+func (app *AppMainWindow) showResumeForSelected() {
+	v, ok := app.selectedVacancyForAttachments()
+	if !ok {
+		return
+	}
+	profile := appSettings.Profile
+	if profile.Name == "" && len(profile.Skills) == 0 && len(profile.Highlights) == 0 {
+		if walk.MsgBox(app.MainWindow, "Резюме под вакансию", "Профиль пуст. Заполнить его сейчас?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) == walk.DlgCmdYes {
+			app.showProfileDialog()
+		}
+		return
+	}
+
+	matched, _ := splitSkills(profile.Skills, v)
+	ranked := rankHighlights(profile.Highlights, resumeTerms(matched, v))
+	matchedText := "Навыки из профиля в вакансии не найдены."
+	if len(matched) > 0 {
+		matchedText = "Совпадающие навыки (будут выделены): " + strings.Join(matched, ", ")
+	}
+
+	var dlg *walk.Dialog
+	var highlightsTE *walk.TextEdit
+	var formatCB *walk.ComboBox
+	var acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Резюме под вакансию",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 520, Height: 420},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: fmt.Sprintf("«%s» - %s", v.Title, v.Company), TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 10}},
+			Label{Text: matchedText, TextColor: currentTheme.Text},
+			Label{
+				Text:      "Достижения для этого резюме, по одному на строку.\nПодходящие к вакансии стоят первыми - лишние строки удалите.",
+				TextColor: currentTheme.Text,
+				Font:      Font{Bold: true, PointSize: 9},
+			},
+			TextEdit{AssignTo: &highlightsTE, Text: strings.Join(ranked, "\r\n"), VScroll: true, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Формат:", TextColor: currentTheme.Text},
+					ComboBox{AssignTo: &formatCB, Model: resumeFormats, CurrentIndex: 0},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Создать",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							r := tailorResume(profile, v, splitLines(highlightsTE.Text()))
+							stored, err := storeTailoredResume(r, formatCB.Text())
+							if err != nil {
+								log.Printf("Не удалось создать резюме: %v", err)
+								showError(dlg, "Ошибка", wrapError("Не удалось создать резюме", err))
+								return
+							}
+							if !changeAttachments(v.Title, v.Company, func(list []Attachment) []Attachment { return append(list, stored...) }) {
+								for _, a := range stored {
+									os.Remove(attachmentPath(a))
+								}
+								walk.MsgBox(dlg, "Ошибка", "Не удалось найти вакансию.", walk.MsgBoxIconError)
+								return
+							}
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
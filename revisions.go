@@ -0,0 +1,636 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Сколько ждать ответа сайта с вакансией
+const postingFetchTimeout = 20 * time.Second
+
+// Больше этого страницу вакансии не читаем
+const maxPostingPageSize = 5 << 20
+
+// PostingRevision - версия описания и зарплаты вакансии на момент проверки
+type PostingRevision struct {
+	At          time.Time `json:"at"`
+	Description string    `json:"description"`
+	Salary      string    `json:"salary,omitempty"`
+}
+
+// fetchedPosting - описание вакансии, заново полученное по SourceURL
+type fetchedPosting struct {
+	Description string
+	Salary      string
+	SalaryKnown bool   // false, если зарплату со страницы определить не удалось
+	Note        string // Пояснение для пользователя о том, откуда взят текст
+}
+
+// Ссылка на вакансию hh.ru: для неё есть открытый API
+var hhVacancyURLRe = regexp.MustCompile(`^https?://([a-z0-9-]+\.)*hh\.(ru|kz|by|uz)/vacancy/(\d+)`)
+
+// Разметка schema.org JobPosting, которую публикует большинство сайтов с вакансиями
+var jsonLDRe = regexp.MustCompile(`(?is)<script[^>]+application/ld\+json[^>]*>(.*?)</script>`)
+
+// Регулярные выражения для грубого перевода HTML в текст
+var (
+	htmlSkipRe  = regexp.MustCompile(`(?is)<(script|style|noscript|head)[^>]*>.*?</(script|style|noscript|head)>`)
+	htmlItemRe  = regexp.MustCompile(`(?i)<li[^>]*>`)
+	htmlBreakRe = regexp.MustCompile(`(?i)<br\s*/?>|</(p|div|li|h[1-6]|tr|ul|ol|section)>`)
+	htmlTagRe   = regexp.MustCompile(`<[^>]+>`)
+	htmlBodyRe  = regexp.MustCompile(`(?is)<body[^>]*>(.*)</body>`)
+)
+
+// htmlToText превращает HTML-описание в простой текст: абзацы - строки, пункты списков - "• "
+func htmlToText(s string) string {
+	s = htmlSkipRe.ReplaceAllString(s, "")
+	s = htmlItemRe.ReplaceAllString(s, "\n• ")
+	s = htmlBreakRe.ReplaceAllString(s, "\n")
+	s = htmlTagRe.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+
+	var lines []string
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" || line == "•" {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// formatSalaryRange собирает строку зарплаты из границ в виде, понятном parseSalary
+func formatSalaryRange(from, to int, currency string) string {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if currency == "RUR" {
+		currency = "RUB"
+	}
+	var s string
+	switch {
+	case from > 0 && to > 0 && from == to:
+		s = formatMoney(from)
+	case from > 0 && to > 0:
+		s = "от " + formatMoney(from) + " до " + formatMoney(to)
+	case from > 0:
+		s = "от " + formatMoney(from)
+	case to > 0:
+		s = "до " + formatMoney(to)
+	default:
+		return ""
+	}
+	if currency != "" {
+		s += " " + currency
+	}
+	return s
+}
+
+// jsonNumber читает число из JSON, где оно бывает и строкой
+func jsonNumber(v any) int {
+	switch n := v.(type) {
+	case float64:
+		return int(n)
+	case string:
+		f, _ := strconv.ParseFloat(strings.ReplaceAll(n, " ", ""), 64)
+		return int(f)
+	}
+	return 0
+}
+
+// isJobPosting проверяет, что объект schema.org - вакансия
+func isJobPosting(obj map[string]any) bool {
+	switch t := obj["@type"].(type) {
+	case string:
+		return t == "JobPosting"
+	case []any:
+		for _, item := range t {
+			if item == "JobPosting" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findJobPosting ищет объект JobPosting в разобранном JSON-LD, в том числе внутри @graph
+func findJobPosting(data any) (map[string]any, bool) {
+	switch d := data.(type) {
+	case map[string]any:
+		if isJobPosting(d) {
+			return d, true
+		}
+		if graph, ok := d["@graph"]; ok {
+			return findJobPosting(graph)
+		}
+	case []any:
+		for _, item := range d {
+			if p, ok := findJobPosting(item); ok {
+				return p, true
+			}
+		}
+	}
+	return nil, false
+}
+
+// jobPostingSalary собирает строку зарплаты из baseSalary разметки JobPosting
+func jobPostingSalary(base any) string {
+	obj, ok := base.(map[string]any)
+	if !ok {
+		return ""
+	}
+	currency, _ := obj["currency"].(string)
+	switch value := obj["value"].(type) {
+	case map[string]any:
+		if c, ok := value["currency"].(string); ok && currency == "" {
+			currency = c
+		}
+		from, to := jsonNumber(value["minValue"]), jsonNumber(value["maxValue"])
+		if from == 0 && to == 0 {
+			from = jsonNumber(value["value"])
+			to = from
+		}
+		return formatSalaryRange(from, to, currency)
+	default:
+		n := jsonNumber(value)
+		return formatSalaryRange(n, n, currency)
+	}
+}
+
+// parsePostingPage извлекает описание вакансии со страницы: из разметки JobPosting,
+// а если её нет - весь текст страницы
+func parsePostingPage(page string) fetchedPosting {
+	for _, m := range jsonLDRe.FindAllStringSubmatch(page, -1) {
+		var data any
+		if json.Unmarshal([]byte(strings.TrimSpace(m[1])), &data) != nil {
+			continue
+		}
+		p, ok := findJobPosting(data)
+		if !ok {
+			continue
+		}
+		description, _ := p["description"].(string)
+		// Если baseSalary в разметке нет, зарплата в вакансии не указана
+		return fetchedPosting{Description: htmlToText(description), Salary: jobPostingSalary(p["baseSalary"]), SalaryKnown: true}
+	}
+
+	body := page
+	if m := htmlBodyRe.FindStringSubmatch(page); m != nil {
+		body = m[1]
+	}
+	return fetchedPosting{
+		Description: htmlToText(body),
+		Note:        "На странице нет структурированного описания вакансии - сравнивается весь текст страницы, зарплата не проверяется.",
+	}
+}
+
+// httpGet скачивает адрес с ограничением размера ответа
+func httpGet(ctx context.Context, url, accept string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", "projectgolang/"+appVersion)
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone {
+		return nil, errors.New("вакансия больше не опубликована (страница не найдена)")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpStatusError{Code: resp.StatusCode, Status: resp.Status}
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxPostingPageSize))
+}
+
+// fetchHHPosting получает вакансию через API hh.ru
+func fetchHHPosting(ctx context.Context, id string) (fetchedPosting, error) {
+	data, err := httpGet(ctx, "https://api.hh.ru/vacancies/"+id, "application/json")
+	if err != nil {
+		return fetchedPosting{}, err
+	}
+	var entry struct {
+		Description string `json:"description"`
+		Salary      *struct {
+			From     int    `json:"from"`
+			To       int    `json:"to"`
+			Currency string `json:"currency"`
+		} `json:"salary"`
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return fetchedPosting{}, fmt.Errorf("ошибка разбора ответа hh.ru: %w", err)
+	}
+	result := fetchedPosting{Description: htmlToText(entry.Description), SalaryKnown: true}
+	if entry.Salary != nil {
+		result.Salary = formatSalaryRange(entry.Salary.From, entry.Salary.To, entry.Salary.Currency)
+	}
+	return result, nil
+}
+
+// fetchPosting заново получает описание и зарплату вакансии по её ссылке
+func fetchPosting(ctx context.Context, sourceURL string) (fetchedPosting, error) {
+	if m := hhVacancyURLRe.FindStringSubmatch(sourceURL); m != nil {
+		result, err := fetchHHPosting(ctx, m[3])
+		result.Description = sanitizeDescription(result.Description)
+		return result, err
+	}
+	data, err := httpGet(ctx, sourceURL, "text/html")
+	if err != nil {
+		return fetchedPosting{}, err
+	}
+	result := parsePostingPage(string(data))
+	result.Description = sanitizeDescription(result.Description)
+	if result.Description == "" {
+		return result, errors.New("не удалось найти текст вакансии на странице")
+	}
+	return result, nil
+}
+
+// salaryChanged сравнивает зарплаты по смыслу, а не по написанию
+func salaryChanged(old, updated string) bool {
+	if strings.EqualFold(strings.TrimSpace(old), strings.TrimSpace(updated)) {
+		return false
+	}
+	oldMin, oldMax, oldCur := parseSalary(old)
+	newMin, newMax, newCur := parseSalary(updated)
+	return oldMin != newMin || oldMax != newMax || oldCur != newCur || (oldMin == 0 && oldMax == 0)
+}
+
+// recordPostingRevision сохраняет новую версию описания в истории и применяет её к вакансии.
+// При первой записи в историю попадает и исходная версия.
+func recordPostingRevision(v *Vacancy, p fetchedPosting, at time.Time) {
+	if len(v.Revisions) == 0 {
+		first := v.CreatedAt
+		if first.IsZero() {
+			first = at
+		}
+		v.Revisions = append(v.Revisions, PostingRevision{At: first, Description: v.Description, Salary: v.Salary})
+	}
+	salary := v.Salary
+	if p.SalaryKnown {
+		salary = p.Salary
+	}
+	v.Revisions = append(v.Revisions, PostingRevision{At: at, Description: p.Description, Salary: salary})
+	v.Description = p.Description
+	if p.SalaryKnown {
+		applySalary(v, salary)
+	}
+}
+
+// diffKind - вид строки в сравнении версий
+type diffKind int
+
+const (
+	diffSame    diffKind = iota // Фрагмент не изменился
+	diffRemoved                 // Фрагмент удалён
+	diffAdded                   // Фрагмент добавлен
+	diffChanged                 // Фрагмент заменён другим
+)
+
+// diffRow - строка сравнения "было / стало"
+type diffRow struct {
+	Kind diffKind
+	Old  string
+	New  string
+}
+
+// splitSegments делит текст на фрагменты для сравнения: строки, а длинные строки - на предложения
+func splitSegments(text string) []string {
+	var segments []string
+	for _, line := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		start := 0
+		runes := []rune(line)
+		for i := 0; i < len(runes)-1; i++ {
+			if strings.ContainsRune(".!?", runes[i]) && runes[i+1] == ' ' {
+				segments = append(segments, strings.TrimSpace(string(runes[start:i+1])))
+				start = i + 1
+			}
+		}
+		if rest := strings.TrimSpace(string(runes[start:])); rest != "" {
+			segments = append(segments, rest)
+		}
+	}
+	return segments
+}
+
+// diffSegments сравнивает два списка фрагментов (наибольшая общая подпоследовательность)
+// и соединяет соседние удаления и добавления в замены, чтобы их было видно рядом
+func diffSegments(a, b []string) []diffRow {
+	n, m := len(a), len(b)
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var rows []diffRow
+	var removed, added []string
+	flush := func() {
+		for k := 0; k < len(removed) || k < len(added); k++ {
+			switch {
+			case k < len(removed) && k < len(added):
+				rows = append(rows, diffRow{Kind: diffChanged, Old: removed[k], New: added[k]})
+			case k < len(removed):
+				rows = append(rows, diffRow{Kind: diffRemoved, Old: removed[k]})
+			default:
+				rows = append(rows, diffRow{Kind: diffAdded, New: added[k]})
+			}
+		}
+		removed, added = nil, nil
+	}
+	i, j := 0, 0
+	for i < n || j < m {
+		switch {
+		case i < n && j < m && a[i] == b[j]:
+			flush()
+			rows = append(rows, diffRow{Kind: diffSame, Old: a[i], New: b[j]})
+			i++
+			j++
+		case j < m && (i == n || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, b[j])
+			j++
+		default:
+			removed = append(removed, a[i])
+			i++
+		}
+	}
+	flush()
+	return rows
+}
+
+// hasChanges проверяет, есть ли в сравнении отличия
+func hasChanges(rows []diffRow) bool {
+	for _, r := range rows {
+		if r.Kind != diffSame {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffModel для TableView в окне сравнения версий
+type DiffModel struct {
+	walk.TableModelBase
+	items []diffRow
+}
+
+func (m *DiffModel) RowCount() int {
+	return len(m.items)
+}
+
+func (m *DiffModel) Value(row, col int) interface{} {
+	if col == 0 {
+		return m.items[row].Old
+	}
+	return m.items[row].New
+}
+
+// Цвета строк сравнения
+var (
+	diffRemovedColor = walk.RGB(255, 220, 220)
+	diffAddedColor   = walk.RGB(220, 255, 220)
+	diffChangedColor = walk.RGB(255, 245, 200)
+)
+
+// checkPostingUpdates заново загружает выбранную вакансию по ссылке и показывает, что изменилось
+func (app *AppMainWindow) checkPostingUpdates() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		walk.MsgBox(app.MainWindow, "Проверить обновления", "Пожалуйста, выберите вакансию.", walk.MsgBoxIconInformation)
+		return
+	}
+	v, _ := app.vacancyAt(idx)
+	if !validVacancyURL(strings.TrimSpace(v.SourceURL)) {
+		walk.MsgBox(app.MainWindow, "Проверить обновления", "У вакансии нет ссылки на источник.", walk.MsgBoxIconInformation)
+		return
+	}
+
+	if app.checkPostingPB != nil {
+		app.checkPostingPB.SetEnabled(false)
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), postingFetchTimeout)
+		defer cancel()
+		posting, err := fetchPosting(ctx, strings.TrimSpace(v.SourceURL))
+
+		app.Synchronize(func() {
+			if app.checkPostingPB != nil {
+				app.checkPostingPB.SetEnabled(true)
+			}
+			if err != nil {
+				log.Printf("Ошибка проверки вакансии %s: %v", v.SourceURL, err)
+				if isOfflineError(err) {
+					app.setOnline(false)
+					walk.MsgBox(app.MainWindow, "Проверить обновления", "Нет подключения к интернету. Проверьте вакансию, когда сеть появится.", walk.MsgBoxIconWarning)
+					return
+				}
+				showError(app.MainWindow, "Проверить обновления", wrapError("Не удалось загрузить вакансию", err))
+				return
+			}
+			rows := diffSegments(splitSegments(v.Description), splitSegments(posting.Description))
+			newSalary := posting.SalaryKnown && salaryChanged(v.Salary, posting.Salary)
+			if !hasChanges(rows) && !newSalary {
+				app.notify("Описание и зарплата вакансии «" + v.Title + "» не изменились.")
+				return
+			}
+			if !app.showPostingDiff(v, posting, rows, newSalary) {
+				return
+			}
+
+			allVacanciesMutex.Lock()
+			i := app.findVacancyIndexInAllExt(v.Title, v.Company)
+			if i != -1 {
+				recordPostingRevision(&allVacancies[i], posting, time.Now())
+			}
+			allVacanciesMutex.Unlock()
+			if i == -1 {
+				walk.MsgBox(app.MainWindow, "Ошибка", "Не удалось найти вакансию для обновления.", walk.MsgBoxIconError)
+				return
+			}
+			saveVacancies()
+			vacancyEvents.Publish(vacancyEvent(VacancyUpdated, v))
+		})
+	}()
+}
+
+// checkPostingLinks проверяет, открываются ли ссылки вакансий, и показывает те, что больше недоступны
+func (app *AppMainWindow) checkPostingLinks() {
+	var targets []Vacancy
+	for _, v := range snapshotVacancies() {
+		if validVacancyURL(strings.TrimSpace(v.SourceURL)) {
+			targets = append(targets, v)
+		}
+	}
+	if len(targets) == 0 {
+		app.notify("Ни у одной вакансии нет ссылки на источник.")
+		return
+	}
+	failed := 0
+	err := runWithProgress(app.MainWindow, "Проверка ссылок вакансий", func(ctx context.Context) error {
+		progressTotal(ctx, len(targets))
+		for _, v := range targets {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			progressStep(ctx, v.Title)
+			reqCtx, cancel := context.WithTimeout(ctx, postingFetchTimeout)
+			_, err := httpGet(reqCtx, strings.TrimSpace(v.SourceURL), "text/html")
+			cancel()
+			switch {
+			case err == nil:
+			case ctx.Err() != nil:
+				return ctx.Err()
+			case isOfflineError(err):
+				return err
+			default:
+				failed++
+				progressFail(ctx, v.Title, err)
+			}
+		}
+		return nil
+	})
+	switch {
+	case errors.Is(err, context.Canceled):
+	case err != nil:
+		app.setOnline(false)
+		walk.MsgBox(app.MainWindow, "Проверка ссылок", "Нет подключения к интернету. Проверьте ссылки, когда сеть появится.", walk.MsgBoxIconWarning)
+	default:
+		app.notify(fmt.Sprintf("Проверено ссылок: %d, недоступны: %d.", len(targets), failed))
+	}
+}
+
+// showPostingDiff показывает версии описания рядом. Возвращает true, если новую версию нужно сохранить.
+func (app *AppMainWindow) showPostingDiff(v Vacancy, posting fetchedPosting, rows []diffRow, newSalary bool) bool {
+	var dlg *walk.Dialog
+	var table *walk.TableView
+	var oldTE, newTE *walk.TextEdit
+	var acceptPB, cancelPB *walk.PushButton
+	accepted := false
+
+	salaryText := "Зарплата не изменилась."
+	if newSalary {
+		old, updated := v.Salary, posting.Salary
+		if old == "" {
+			old = "не указана"
+		}
+		if updated == "" {
+			updated = "не указана"
+		}
+		salaryText = "Зарплата: было «" + old + "», стало «" + updated + "»."
+	}
+	revisions := "Сохранённых версий пока нет."
+	if n := len(v.Revisions); n > 0 {
+		revisions = fmt.Sprintf("Сохранено версий: %d, последняя от %s.", n, v.Revisions[n-1].At.Local().Format("02.01.2006 15:04"))
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Изменения в вакансии",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 820, Height: 560},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: fmt.Sprintf("'%s' — %s", v.Title, v.Company), TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 10}},
+			Label{Text: salaryText, TextColor: currentTheme.Text, Font: Font{Bold: newSalary, PointSize: 9}},
+			Label{Text: posting.Note, Visible: posting.Note != "", TextColor: walk.RGB(190, 110, 0), Font: Font{PointSize: 9}},
+			Label{Text: revisions, TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			TableView{
+				AssignTo: &table,
+				Columns: []TableViewColumn{
+					{Title: "Было", Width: 380},
+					{Title: "Стало", Width: 380},
+				},
+				Model: &DiffModel{items: rows},
+				StyleCell: func(style *walk.CellStyle) {
+					if style.Row() < 0 || style.Row() >= len(rows) {
+						return
+					}
+					switch rows[style.Row()].Kind {
+					case diffRemoved:
+						if style.Col() == 0 {
+							style.BackgroundColor = diffRemovedColor
+						}
+					case diffAdded:
+						if style.Col() == 1 {
+							style.BackgroundColor = diffAddedColor
+						}
+					case diffChanged:
+						style.BackgroundColor = diffChangedColor
+					}
+				},
+				OnCurrentIndexChanged: func() {
+					i := table.CurrentIndex()
+					if i < 0 || i >= len(rows) {
+						return
+					}
+					oldTE.SetText(rows[i].Old)
+					newTE.SetText(rows[i].New)
+				},
+				StretchFactor: 3,
+			},
+			Composite{
+				Layout:        HBox{MarginsZero: true},
+				StretchFactor: 1,
+				Children: []Widget{
+					TextEdit{AssignTo: &oldTE, ReadOnly: true, VScroll: true, Font: Font{PointSize: 9}},
+					TextEdit{AssignTo: &newTE, ReadOnly: true, VScroll: true, Font: Font{PointSize: 9}},
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить новую версию",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							accepted = true
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	return accepted
+}
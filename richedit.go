@@ -0,0 +1,311 @@
+package main
+
+import (
+	"strings"
+	"syscall"
+	"unicode"
+	"unsafe"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+	"github.com/lxn/win"
+	"golang.org/x/sys/windows"
+)
+
+// Сообщения и флаги RichEdit, которых нет в lxn/win
+const (
+	emExGetSel        = win.WM_USER + 52
+	emExSetSel        = win.WM_USER + 55
+	emHideSelection   = win.WM_USER + 63
+	emSetBkgndColor   = win.WM_USER + 67
+	emSetCharFormat   = win.WM_USER + 68
+	emSetEventMask    = win.WM_USER + 69
+	emGetTextEx       = win.WM_USER + 94
+	emGetTextLengthEx = win.WM_USER + 95
+	emSetZoom         = win.WM_USER + 225
+
+	scfDefault   = 0x0000
+	scfSelection = 0x0001
+	scfAll       = 0x0004
+
+	cfmBackColor     = 0x04000000
+	cfmColor         = 0x40000000
+	cfmFace          = 0x20000000
+	cfmSize          = 0x80000000
+	cfeAutoBackColor = 0x04000000
+
+	enmChange = 0x0001
+
+	gtUseCRLF     = 1
+	gtlUseCRLF    = 1
+	gtlNumChars   = 8
+	codepageUTF16 = 1200
+)
+
+// charFormat2 - CHARFORMAT2W
+type charFormat2 struct {
+	cbSize          uint32
+	dwMask          uint32
+	dwEffects       uint32
+	yHeight         int32
+	yOffset         int32
+	crTextColor     uint32
+	bCharSet        byte
+	bPitchAndFamily byte
+	szFaceName      [32]uint16
+	wWeight         uint16
+	sSpacing        int16
+	crBackColor     uint32
+	lcid            uint32
+	dwReserved      uint32
+	sStyle          int16
+	wKerning        uint16
+	bUnderlineType  byte
+	bAnimation      byte
+	bRevAuthor      byte
+	bUnderlineColor byte
+}
+
+type charRange struct {
+	cpMin, cpMax int32
+}
+
+type getTextEx struct {
+	cb            uint32
+	flags         uint32
+	codepage      uint32
+	lpDefaultChar uintptr
+	lpUsedDefChar uintptr
+}
+
+type getTextLengthEx struct {
+	flags    uint32
+	codepage uint32
+}
+
+var msftedit = windows.NewLazySystemDLL("Msftedit.dll")
+
+// RichTextEdit - многострочное поле на основе RichEdit: в отличие от walk.TextEdit
+// умеет подсвечивать отдельные фрагменты текста
+type RichTextEdit struct {
+	walk.WidgetBase
+	textChangedPublisher walk.EventPublisher
+	zoomPublisher        walk.IntEventPublisher
+}
+
+// NewRichTextEdit создаёт поле RichEdit
+func NewRichTextEdit(parent walk.Container) (*RichTextEdit, error) {
+	if err := msftedit.Load(); err != nil {
+		return nil, err
+	}
+	re := new(RichTextEdit)
+	if err := walk.InitWidget(
+		re,
+		parent,
+		"RICHEDIT50W",
+		win.WS_TABSTOP|win.WS_VISIBLE|win.WS_VSCROLL|win.ES_MULTILINE|win.ES_WANTRETURN|win.ES_AUTOVSCROLL,
+		win.WS_EX_CLIENTEDGE); err != nil {
+		return nil, err
+	}
+	re.GraphicsEffects().Add(walk.InteractionEffect)
+	re.GraphicsEffects().Add(walk.FocusEffect)
+	// RichEdit присылает EN_CHANGE, только если об этом попросить
+	re.SendMessage(emSetEventMask, 0, enmChange)
+
+	// Свойства для walk.DataBinder: Text - простой текст, Markdown - заметки с форматированием
+	re.MustRegisterProperty("Text", walk.NewProperty(
+		func() interface{} { return re.Text() },
+		func(v interface{}) error {
+			s, _ := v.(string)
+			return re.SetText(s)
+		},
+		re.textChangedPublisher.Event()))
+	re.MustRegisterProperty("Markdown", walk.NewProperty(
+		func() interface{} { return re.Markdown() },
+		func(v interface{}) error {
+			s, _ := v.(string)
+			re.SetMarkdown(s)
+			return nil
+		},
+		re.textChangedPublisher.Event()))
+	return re, nil
+}
+
+// TextChanged срабатывает при любом изменении текста
+func (re *RichTextEdit) TextChanged() *walk.Event {
+	return re.textChangedPublisher.Event()
+}
+
+// ZoomRequested срабатывает на Ctrl+колесо и Ctrl+плюс/минус/0: +1 - крупнее, -1 - мельче, 0 - сбросить.
+// Собственный масштаб RichEdit при этом не меняется: масштаб задаёт приложение через SetZoom.
+func (re *RichTextEdit) ZoomRequested() *walk.IntEvent {
+	return re.zoomPublisher.Event()
+}
+
+func (re *RichTextEdit) WndProc(hwnd win.HWND, msg uint32, wParam, lParam uintptr) uintptr {
+	switch msg {
+	case win.WM_COMMAND:
+		if win.HIWORD(uint32(wParam)) == win.EN_CHANGE {
+			re.textChangedPublisher.Publish()
+		}
+	case win.WM_MOUSEWHEEL:
+		if win.LOWORD(uint32(wParam))&win.MK_CONTROL != 0 {
+			re.zoomPublisher.Publish(wheelZoomStep(wParam))
+			return 0
+		}
+	case win.WM_KEYDOWN:
+		if step, ok := keyZoomStep(walk.Key(wParam)); ok && walk.ModifiersDown() == walk.ModControl {
+			re.zoomPublisher.Publish(step)
+			return 0
+		}
+	}
+	return re.WidgetBase.WndProc(hwnd, msg, wParam, lParam)
+}
+
+func (re *RichTextEdit) CreateLayoutItem(ctx *walk.LayoutContext) walk.LayoutItem {
+	return walk.NewGreedyLayoutItem()
+}
+
+// Text возвращает текст с переводами строк "\r\n", как у walk.TextEdit
+func (re *RichTextEdit) Text() string {
+	lengthEx := getTextLengthEx{flags: gtlUseCRLF | gtlNumChars, codepage: codepageUTF16}
+	n := int(re.SendMessage(emGetTextLengthEx, uintptr(unsafe.Pointer(&lengthEx)), 0))
+	if n <= 0 {
+		return ""
+	}
+	buf := make([]uint16, n+1)
+	textEx := getTextEx{cb: uint32(len(buf) * 2), flags: gtUseCRLF, codepage: codepageUTF16}
+	re.SendMessage(emGetTextEx, uintptr(unsafe.Pointer(&textEx)), uintptr(unsafe.Pointer(&buf[0])))
+	return syscall.UTF16ToString(buf)
+}
+
+// SetText заменяет текст; подсветка при этом сбрасывается
+func (re *RichTextEdit) SetText(text string) error {
+	p, err := syscall.UTF16PtrFromString(text)
+	if err != nil {
+		return err
+	}
+	re.SendMessage(win.WM_SETTEXT, 0, uintptr(unsafe.Pointer(p)))
+	return nil
+}
+
+// SetReadOnly запрещает или разрешает правку
+func (re *RichTextEdit) SetReadOnly(readOnly bool) {
+	re.SendMessage(win.EM_SETREADONLY, uintptr(win.BoolToBOOL(readOnly)), 0)
+}
+
+// SetColors задаёт цвет текста и фона (для тем оформления)
+func (re *RichTextEdit) SetColors(text, background walk.Color) {
+	re.SendMessage(emSetBkgndColor, 0, uintptr(background))
+	cf := charFormat2{dwMask: cfmColor, crTextColor: uint32(text)}
+	cf.cbSize = uint32(unsafe.Sizeof(cf))
+	re.SendMessage(emSetCharFormat, scfAll, uintptr(unsafe.Pointer(&cf)))
+	// Формат по умолчанию - для текста, который пользователь введёт после
+	re.SendMessage(emSetCharFormat, scfDefault, uintptr(unsafe.Pointer(&cf)))
+}
+
+// SetFace меняет шрифт и размер всего текста, не трогая жирный, курсив и цвета.
+// WM_SETFONT для этого не годится: он сбрасывает оформление заметок.
+func (re *RichTextEdit) SetFace(family string, pointSize int) {
+	cf := charFormat2{dwMask: cfmFace | cfmSize, yHeight: int32(pointSize * 20)} // yHeight в twips
+	cf.cbSize = uint32(unsafe.Sizeof(cf))
+	copy(cf.szFaceName[:len(cf.szFaceName)-1], syscall.StringToUTF16(family))
+	re.SendMessage(emSetCharFormat, scfAll, uintptr(unsafe.Pointer(&cf)))
+	re.SendMessage(emSetCharFormat, scfDefault, uintptr(unsafe.Pointer(&cf)))
+}
+
+// SetZoom задаёт масштаб текста в процентах
+func (re *RichTextEdit) SetZoom(percent int) {
+	re.SendMessage(emSetZoom, uintptr(percent), 100)
+}
+
+// findOccurrences ищет term в text без учёта регистра и возвращает позиции в символах RichEdit:
+// в единицах UTF-16, где перевод строки "\r\n" занимает одну позицию
+func findOccurrences(text, term string) []charRange {
+	needle := []rune(strings.ToLower(strings.TrimSpace(term)))
+	if len(needle) == 0 {
+		return nil
+	}
+	haystack := []rune(strings.ReplaceAll(text, "\r\n", "\r"))
+	// Позиция каждой руны в UTF-16: символы вне BMP (эмодзи) занимают две
+	pos := make([]int32, len(haystack)+1)
+	for i, r := range haystack {
+		pos[i+1] = pos[i] + 1
+		if r > 0xFFFF {
+			pos[i+1]++
+		}
+		haystack[i] = unicode.ToLower(r)
+	}
+
+	var ranges []charRange
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		match := true
+		for j, r := range needle {
+			if haystack[i+j] != r {
+				match = false
+				break
+			}
+		}
+		if match {
+			ranges = append(ranges, charRange{pos[i], pos[i+len(needle)]})
+			i += len(needle) - 1
+		}
+	}
+	return ranges
+}
+
+// Highlight подсвечивает все вхождения term и возвращает их число. Пустой term снимает подсветку.
+// Выделение и прокрутка пользователя сохраняются.
+func (re *RichTextEdit) Highlight(term string, color walk.Color) int {
+	var selection charRange
+	re.SendMessage(emExGetSel, 0, uintptr(unsafe.Pointer(&selection)))
+	re.SendMessage(emHideSelection, 1, 0)
+	re.SetSuspended(true)
+	defer func() {
+		re.SendMessage(emExSetSel, 0, uintptr(unsafe.Pointer(&selection)))
+		re.SendMessage(emHideSelection, 0, 0)
+		re.SetSuspended(false)
+		re.Invalidate()
+	}()
+
+	clear := charFormat2{dwMask: cfmBackColor, dwEffects: cfeAutoBackColor}
+	clear.cbSize = uint32(unsafe.Sizeof(clear))
+	re.SendMessage(emSetCharFormat, scfAll, uintptr(unsafe.Pointer(&clear)))
+
+	ranges := findOccurrences(re.Text(), term)
+	mark := charFormat2{dwMask: cfmBackColor, crBackColor: uint32(color)}
+	mark.cbSize = uint32(unsafe.Sizeof(mark))
+	for i := range ranges {
+		re.SendMessage(emExSetSel, 0, uintptr(unsafe.Pointer(&ranges[i])))
+		re.SendMessage(emSetCharFormat, scfSelection, uintptr(unsafe.Pointer(&mark)))
+	}
+	return len(ranges)
+}
+
+// RichText - декларативное описание RichTextEdit для панели деталей
+type RichText struct {
+	AssignTo      **RichTextEdit
+	Name          string
+	Font          Font
+	MinSize       Size
+	MaxSize       Size
+	StretchFactor int
+	ReadOnly      bool
+	Enabled       Property
+	Text          Property
+	Markdown      Property
+}
+
+func (rt RichText) Create(builder *Builder) error {
+	w, err := NewRichTextEdit(builder.Parent())
+	if err != nil {
+		return err
+	}
+	if rt.AssignTo != nil {
+		*rt.AssignTo = w
+	}
+	return builder.InitWidget(rt, w, func() error {
+		w.SetReadOnly(rt.ReadOnly)
+		return nil
+	})
+}
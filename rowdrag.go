@@ -0,0 +1,125 @@
+package main
+
+import (
+	"slices"
+	"unsafe"
+
+	"github.com/lxn/walk"
+	"github.com/lxn/win"
+)
+
+// Перетаскивание строк таблицы мышью: на кнопку статуса над таблицей - сменить статус
+// перетаскиваемых вакансий, на другую строку в режиме ручного порядка - переставить вакансию.
+// Нажатие на строку список обрабатывает сам и возвращает управление, когда кнопку
+// отпустили или мышь сдвинулась дальше порога перетаскивания; во втором случае
+// кнопка ещё нажата, и мы захватываем мышь до её отпускания.
+
+// attachRowDrag подключает перетаскивание строк таблицы вакансий
+func (app *AppMainWindow) attachRowDrag() {
+	app.vacancyTable.MouseDown().Attach(func(x, y int, button walk.MouseButton) {
+		app.dragRows = nil
+		row := app.vacancyTable.IndexAt(x, y)
+		if button != walk.LeftButton || row < 0 || viewOnly {
+			return
+		}
+		// Нажатие на выделенную строку тянет всё выделение, на другую - только её
+		rows := []int{row}
+		if selected := app.vacancyTable.SelectedIndexes(); slices.Contains(selected, row) {
+			rows = append(rows, slices.DeleteFunc(selected, func(i int) bool { return i == row })...)
+		}
+		app.Synchronize(func() {
+			if win.GetKeyState(win.VK_LBUTTON) >= 0 {
+				return // Обычный щелчок
+			}
+			app.dragRows = rows
+			win.SetCapture(app.vacancyTable.Handle())
+			app.updateDragCursor()
+		})
+	})
+	app.vacancyTable.MouseMove().Attach(func(x, y int, button walk.MouseButton) {
+		if app.dragRows != nil {
+			app.updateDragCursor()
+		}
+	})
+	app.vacancyTable.MouseUp().Attach(func(x, y int, button walk.MouseButton) {
+		if button != walk.LeftButton || app.dragRows == nil {
+			return
+		}
+		rows := app.dragRows
+		app.dragRows = nil
+		win.ReleaseCapture()
+		app.dropRows(rows)
+	})
+}
+
+// cursorOver - положение курсора в клиентских координатах w, если курсор над w или его дочерним окном
+func cursorOver(w walk.Window) (x, y int, ok bool) {
+	var pt win.POINT
+	if !win.GetCursorPos(&pt) {
+		return 0, 0, false
+	}
+	hwnd := win.WindowFromPoint(pt)
+	if hwnd != w.Handle() && !win.IsChild(w.Handle(), hwnd) {
+		return 0, 0, false
+	}
+	win.ScreenToClient(w.Handle(), &pt)
+	return int(pt.X), int(pt.Y), true
+}
+
+// chipStatusUnderCursor - статус кнопки над таблицей, на которую указывает курсор
+func (app *AppMainWindow) chipStatusUnderCursor() (string, bool) {
+	if app.statusChipsBar == nil {
+		return "", false
+	}
+	x, y, ok := cursorOver(app.statusChipsBar)
+	if !ok {
+		return "", false
+	}
+	pt := win.POINT{X: int32(x), Y: int32(y)}
+	// Кнопки статусов идут первыми, в порядке possibleStatuses; у разделителей и пустого места номер отрицательный
+	i := int(int32(win.SendMessage(app.statusChipsBar.Handle(), win.TB_HITTEST, 0, uintptr(unsafe.Pointer(&pt)))))
+	if i < 0 || i >= len(possibleStatuses) {
+		return "", false
+	}
+	return possibleStatuses[i], true
+}
+
+// updateDragCursor показывает, что случится, если отпустить кнопку мыши здесь
+func (app *AppMainWindow) updateDragCursor() {
+	cursor := win.IDC_NO
+	if _, ok := app.chipStatusUnderCursor(); ok {
+		cursor = win.IDC_HAND
+	} else if _, _, ok := cursorOver(app.vacancyTable); ok && app.manualOrderActive() {
+		cursor = win.IDC_SIZENS
+	}
+	win.SetCursor(win.LoadCursor(0, win.MAKEINTRESOURCE(uintptr(cursor))))
+}
+
+// dropRows завершает перетаскивание строк rows туда, где отпущена кнопка мыши
+func (app *AppMainWindow) dropRows(rows []int) {
+	if status, ok := app.chipStatusUnderCursor(); ok {
+		var vacancies []Vacancy
+		for _, row := range rows {
+			if v, ok := app.vacancyAt(row); ok {
+				vacancies = append(vacancies, v)
+			}
+		}
+		app.setStatusForVacancies(vacancies, status)
+		return
+	}
+
+	x, y, ok := cursorOver(app.vacancyTable)
+	if !ok || !app.manualOrderActive() {
+		return
+	}
+	target := app.vacancyTable.IndexAt(x, y)
+	if target < 0 {
+		target = len(app.vacancyModel.items) - 1 // Ниже последней строки - в конец
+	}
+	// Переставляем строку, за которую взяли
+	moved, ok := app.vacancyAt(rows[0])
+	dest, ok2 := app.vacancyAt(target)
+	if ok && ok2 && target != rows[0] {
+		app.moveVacancyTo(moved, dest)
+	}
+}
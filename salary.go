@@ -0,0 +1,232 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lxn/walk"
+)
+
+// Число с разделителями разрядов и необязательным множителем "тыс"/"k"
+var salaryNumberRe = regexp.MustCompile(`(\d[\d \x{00a0}\x{202f}\x{2009}]*(?:[.,]\d+)?)\s*(тыс\.?|k|к)?`)
+
+// Предлоги "от" и "до" как отдельные слова
+var (
+	salaryFromRe = regexp.MustCompile(`(^|[^\pL])от([^\pL]|$)`)
+	salaryToRe   = regexp.MustCompile(`(^|[^\pL])до([^\pL]|$)`)
+)
+
+// parseSalary разбирает строку вида "от 150 000 до 200 000 руб." или "$3k-4k"
+// в минимальное и максимальное значение и код валюты. Нулевые значения
+// означают, что граница не указана.
+func parseSalary(raw string) (minValue, maxValue int, currency string) {
+	text := strings.ToLower(strings.TrimSpace(raw))
+	if text == "" {
+		return 0, 0, ""
+	}
+
+	var values []int
+	for _, m := range salaryNumberRe.FindAllStringSubmatch(text, -1) {
+		digits := strings.NewReplacer(" ", "", "\u00a0", "", "\u202f", "", "\u2009", "", ",", ".").Replace(m[1])
+		f, err := strconv.ParseFloat(digits, 64)
+		if err != nil || f <= 0 {
+			continue
+		}
+		if m[2] != "" {
+			f *= 1000
+		}
+		values = append(values, int(f))
+	}
+	if len(values) == 0 {
+		return 0, 0, ""
+	}
+
+	currency = detectCurrency(text)
+	switch {
+	case len(values) >= 2:
+		minValue, maxValue = values[0], values[1]
+		if minValue > maxValue {
+			minValue, maxValue = maxValue, minValue
+		}
+	case salaryToRe.MatchString(text) && !salaryFromRe.MatchString(text):
+		maxValue = values[0]
+	case salaryFromRe.MatchString(text):
+		minValue = values[0]
+	default:
+		minValue, maxValue = values[0], values[0]
+	}
+	return minValue, maxValue, currency
+}
+
+// detectCurrency определяет валюту по тексту; по умолчанию рубли
+func detectCurrency(text string) string {
+	switch {
+	case strings.Contains(text, "$") || strings.Contains(text, "usd") || strings.Contains(text, "долл"):
+		return "USD"
+	case strings.Contains(text, "€") || strings.Contains(text, "eur") || strings.Contains(text, "евро"):
+		return "EUR"
+	case strings.Contains(text, "₸") || strings.Contains(text, "kzt") || strings.Contains(text, "тенге"):
+		return "KZT"
+	default:
+		return "RUB"
+	}
+}
+
+// applySalary сохраняет исходную строку зарплаты и её разобранные значения
+func applySalary(v *Vacancy, raw string) {
+	v.Salary = strings.TrimSpace(raw)
+	v.SalaryMin, v.SalaryMax, v.SalaryCurrency = parseSalary(v.Salary)
+}
+
+// salaryMidpoint возвращает характерное значение зарплаты вакансии
+func salaryMidpoint(v Vacancy) int {
+	switch {
+	case v.SalaryMin > 0 && v.SalaryMax > 0:
+		return (v.SalaryMin + v.SalaryMax) / 2
+	case v.SalaryMin > 0:
+		return v.SalaryMin
+	default:
+		return v.SalaryMax
+	}
+}
+
+// Варианты группировки в аналитике зарплат
+var salaryGroupings = []string{"По ключевым словам", "По уровню опыта", "По компаниям"}
+
+// SalaryStatsRow - агрегированные зарплаты для одной группы и валюты
+type SalaryStatsRow struct {
+	Group    string
+	Currency string
+	Count    int
+	Min      int
+	Median   int
+	Max      int
+}
+
+// buildSalaryStats группирует вакансии с указанной зарплатой и считает мин/медиану/макс
+func buildSalaryStats(vacancies []Vacancy, grouping string) []SalaryStatsRow {
+	type bucket struct {
+		row    SalaryStatsRow
+		values []int
+	}
+	buckets := map[string]*bucket{}
+
+	add := func(group string, v Vacancy) {
+		group = strings.TrimSpace(group)
+		if group == "" {
+			return
+		}
+		key := strings.ToLower(group) + "|" + v.SalaryCurrency
+		b, ok := buckets[key]
+		if !ok {
+			b = &bucket{row: SalaryStatsRow{Group: group, Currency: v.SalaryCurrency}}
+			buckets[key] = b
+		}
+		low, high := v.SalaryMin, v.SalaryMax
+		if low == 0 {
+			low = high
+		}
+		if high == 0 {
+			high = low
+		}
+		if b.row.Count == 0 || low < b.row.Min {
+			b.row.Min = low
+		}
+		if high > b.row.Max {
+			b.row.Max = high
+		}
+		b.row.Count++
+		b.values = append(b.values, salaryMidpoint(v))
+	}
+
+	for _, v := range vacancies {
+		if v.SalaryMin == 0 && v.SalaryMax == 0 {
+			continue
+		}
+		switch grouping {
+		case "По уровню опыта":
+			add(v.ExperienceLevel, v)
+		case "По компаниям":
+			add(v.Company, v)
+		default:
+			for _, kw := range v.Keywords {
+				add(strings.ToLower(kw), v)
+			}
+		}
+	}
+
+	rows := make([]SalaryStatsRow, 0, len(buckets))
+	for _, b := range buckets {
+		sort.Ints(b.values)
+		n := len(b.values)
+		if n%2 == 1 {
+			b.row.Median = b.values[n/2]
+		} else {
+			b.row.Median = (b.values[n/2-1] + b.values[n/2]) / 2
+		}
+		rows = append(rows, b.row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return strings.ToLower(rows[i].Group) < strings.ToLower(rows[j].Group)
+	})
+	return rows
+}
+
+// formatMoney форматирует сумму с разделением разрядов пробелами
+func formatMoney(value int) string {
+	s := strconv.Itoa(value)
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && (len(s)-i)%3 == 0 {
+			b.WriteRune(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// SalaryStatsModel для TableView на вкладке "Зарплаты"
+type SalaryStatsModel struct {
+	walk.TableModelBase
+	items []SalaryStatsRow
+}
+
+func (m *SalaryStatsModel) RowCount() int {
+	return len(m.items)
+}
+
+func (m *SalaryStatsModel) Value(row, col int) interface{} {
+	item := m.items[row]
+	switch col {
+	case 0:
+		return item.Group
+	case 1:
+		return item.Currency
+	case 2:
+		return item.Count
+	case 3:
+		return formatMoney(item.Min)
+	case 4:
+		return formatMoney(item.Median)
+	case 5:
+		return formatMoney(item.Max)
+	}
+	return ""
+}
+
+// salarySummary возвращает краткую подпись для вкладки зарплат
+func salarySummary(vacancies []Vacancy) string {
+	withSalary := 0
+	for _, v := range vacancies {
+		if v.SalaryMin > 0 || v.SalaryMax > 0 {
+			withSalary++
+		}
+	}
+	return fmt.Sprintf("Зарплата указана у %d из %d вакансий.", withSalary, len(vacancies))
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Теги, по которым описание считается HTML. Одиночное "<" в тексте ("опыт <3 лет") не в счёт.
+var htmlMarkupRe = regexp.MustCompile(`(?i)</?(p|br|b|i|u|strong|em|small|span|div|font|a|ul|ol|li|h[1-6]|table|tr|td|th|section|article|blockquote|pre|code)\b[^>]*>`)
+
+// Мнемоники, оставшиеся после одного раскодирования: провайдеры нередко кодируют "&nbsp;" дважды
+var htmlEntityRe = regexp.MustCompile(`&(#\d+|#x[0-9a-fA-F]+|[a-zA-Z]{2,8});`)
+
+// Невидимые и неразрывные символы, которые мешают переносу строк и поиску
+var invisibleReplacer = strings.NewReplacer(
+	"\u00a0", " ", "\u2007", " ", "\u2009", " ", "\u202f", " ",
+	"\u200b", "", "\u200c", "", "\u200d", "", "\u2060", "", "\ufeff", "", "\u00ad", "",
+)
+
+// sanitizeDescription приводит описание от провайдера или со страницы к простому тексту:
+// убирает разметку и мнемоники, лишние пробелы и пустые строки. Строки разделяются "\r\n",
+// как их понимает многострочное поле Windows.
+func sanitizeDescription(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	// Повторные проходы - для дважды закодированного текста, где "&amp;lt;b&amp;gt;" через раз становится тегом
+	for range 3 {
+		switch {
+		case htmlMarkupRe.MatchString(s):
+			s = htmlToText(s)
+		case htmlEntityRe.MatchString(s):
+			s = html.UnescapeString(s)
+		}
+	}
+	s = invisibleReplacer.Replace(s)
+
+	var lines []string
+	blank := false
+	for _, line := range strings.Split(s, "\n") {
+		line = strings.Join(strings.Fields(line), " ")
+		if line == "" {
+			blank = len(lines) > 0
+			continue
+		}
+		// Абзацы разделяем одной пустой строкой, сколько бы их ни было в исходнике
+		if blank {
+			lines = append(lines, "")
+			blank = false
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\r\n")
+}
+
+// sanitizeLine - то же для однострочных полей: название, компания, город
+func sanitizeLine(s string) string {
+	return strings.Join(strings.Fields(sanitizeDescription(s)), " ")
+}
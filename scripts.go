@@ -0,0 +1,491 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+	lua "github.com/yuin/gopher-lua"
+	"github.com/yuin/gopher-lua/parse"
+)
+
+// Пользовательские сценарии на Lua: функции on_add(v) и on_status_change(v, old, new)
+// из файла hooks.lua вызываются при добавлении вакансии и смене её статуса.
+const scriptsFileName = "hooks.lua"
+
+// Сколько может выполняться один вызов сценария
+const scriptTimeout = 2 * time.Second
+
+// Пример, который показывается в редакторе, пока сценариев нет
+const scriptsExample = `-- Вызывается при добавлении вакансии
+function on_add(v)
+  if has(v.description, "golang", " go ") then
+    add_keyword(v, "Go")
+  end
+end
+
+-- Вызывается при смене статуса
+function on_status_change(v, old, new)
+  append_csv("status_log.csv", os_date(), v.title, v.company, old, new)
+end
+`
+
+// Справка по доступным полям и функциям
+const scriptsHelp = "Поля v: title, company, status, description, salary, source_url, source (только чтение);\n" +
+	"keywords, notes, experience, location, work_format (можно менять).\n" +
+	"Функции: has(text, слово...), add_keyword(v, слово), append_csv(файл, значение...), os_date(), log(текст)."
+
+// Сколько пачек вызовов сценариев может ждать выполнения
+const scriptQueueSize = 64
+
+// Последняя ошибка сценария - показывается в редакторе
+var lastScriptError string
+
+// Разобранный файл сценариев: читается один раз и сбрасывается при сохранении в редакторе
+var scriptsCache struct {
+	sync.Mutex
+	path  string
+	proto *lua.FunctionProto // nil - сценариев нет
+	err   error
+}
+
+// scriptsPath возвращает путь к файлу сценариев
+func scriptsPath() string {
+	return dataPath(scriptsFileName)
+}
+
+// loadScripts читает сценарии из path; пустая строка - сценариев нет
+func loadScripts(path string) string {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			log.Printf("Ошибка чтения сценариев %s: %v", path, err)
+		}
+		return ""
+	}
+	return string(data)
+}
+
+// compiledScripts возвращает разобранный файл сценариев path; nil без ошибки - сценариев нет
+func compiledScripts(path string) (*lua.FunctionProto, error) {
+	scriptsCache.Lock()
+	defer scriptsCache.Unlock()
+	if scriptsCache.path != path {
+		scriptsCache.path = path
+		scriptsCache.proto, scriptsCache.err = compileScripts(loadScripts(path))
+	}
+	return scriptsCache.proto, scriptsCache.err
+}
+
+// compileScripts разбирает текст сценариев
+func compileScripts(source string) (*lua.FunctionProto, error) {
+	if source == "" {
+		return nil, nil
+	}
+	chunk, err := parse.Parse(strings.NewReader(source), scriptsFileName)
+	if err != nil {
+		return nil, err
+	}
+	return lua.Compile(chunk, scriptsFileName)
+}
+
+// invalidateScripts сбрасывает разобранные сценарии, следующий вызов перечитает файл
+func invalidateScripts() {
+	scriptsCache.Lock()
+	defer scriptsCache.Unlock()
+	scriptsCache.path, scriptsCache.proto, scriptsCache.err = "", nil, nil
+}
+
+// newScriptState создаёт интерпретатор без доступа к файлам и процессам:
+// только базовые функции, строки, таблицы, математика и функции приложения.
+// append_csv пишет только в папку dir.
+func newScriptState(ctx context.Context, dir string) *lua.LState {
+	L := lua.NewState(lua.Options{SkipOpenLibs: true})
+	for _, lib := range []struct {
+		name string
+		open lua.LGFunction
+	}{
+		{lua.BaseLibName, lua.OpenBase},
+		{lua.StringLibName, lua.OpenString},
+		{lua.TabLibName, lua.OpenTable},
+		{lua.MathLibName, lua.OpenMath},
+	} {
+		L.Push(L.NewFunction(lib.open))
+		L.Push(lua.LString(lib.name))
+		L.Call(1, 0)
+	}
+	for _, name := range []string{"dofile", "loadfile"} {
+		L.SetGlobal(name, lua.LNil)
+	}
+	L.SetGlobal("has", L.NewFunction(luaHas))
+	L.SetGlobal("add_keyword", L.NewFunction(luaAddKeyword))
+	L.SetGlobal("append_csv", L.NewFunction(luaAppendCSV(dir)))
+	L.SetGlobal("os_date", L.NewFunction(func(L *lua.LState) int {
+		L.Push(lua.LString(time.Now().Format("2006-01-02 15:04")))
+		return 1
+	}))
+	L.SetGlobal("log", L.NewFunction(func(L *lua.LState) int {
+		log.Printf("Сценарий: %s", L.CheckString(1))
+		return 0
+	}))
+	L.SetContext(ctx)
+	return L
+}
+
+// luaHas - has(text, слово...): есть ли в тексте хотя бы одно из слов без учёта регистра
+func luaHas(L *lua.LState) int {
+	text := strings.ToLower(L.CheckString(1))
+	for i := 2; i <= L.GetTop(); i++ {
+		if strings.Contains(text, strings.ToLower(L.CheckString(i))) {
+			L.Push(lua.LTrue)
+			return 1
+		}
+	}
+	L.Push(lua.LFalse)
+	return 1
+}
+
+// luaAddKeyword - add_keyword(v, слово): добавляет ключевое слово, если его ещё нет
+func luaAddKeyword(L *lua.LState) int {
+	v := L.CheckTable(1)
+	word := strings.TrimSpace(L.CheckString(2))
+	if word == "" {
+		return 0
+	}
+	keywords, ok := v.RawGetString("keywords").(*lua.LTable)
+	if !ok {
+		keywords = L.NewTable()
+		v.RawSetString("keywords", keywords)
+	}
+	found := false
+	keywords.ForEach(func(_, value lua.LValue) {
+		if strings.EqualFold(value.String(), word) {
+			found = true
+		}
+	})
+	if !found {
+		keywords.Append(lua.LString(word))
+	}
+	return 0
+}
+
+// luaAppendCSV - append_csv(файл, значение...): дописывает строку в CSV в папке dir.
+// Абсолютные пути и выход из папки через ".." запрещены.
+func luaAppendCSV(dir string) lua.LGFunction {
+	return func(L *lua.LState) int {
+		name := L.CheckString(1)
+		if !filepath.IsLocal(name) {
+			L.RaiseError("append_csv: файл %q вне папки данных", name)
+			return 0
+		}
+		row := make([]string, 0, L.GetTop()-1)
+		for i := 2; i <= L.GetTop(); i++ {
+			row = append(row, L.ToStringMeta(L.Get(i)).String())
+		}
+		f, err := os.OpenFile(filepath.Join(dir, name), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			L.RaiseError("append_csv: %v", err)
+			return 0
+		}
+		defer f.Close()
+		w := csv.NewWriter(f)
+		w.Write(row)
+		w.Flush()
+		if err := w.Error(); err != nil {
+			L.RaiseError("append_csv: %v", err)
+		}
+		return 0
+	}
+}
+
+// vacancyToLua переводит вакансию в таблицу для сценария
+func vacancyToLua(L *lua.LState, v Vacancy) *lua.LTable {
+	t := L.NewTable()
+	for name, value := range map[string]string{
+		"title": v.Title, "company": v.Company, "status": v.Status, "description": v.Description,
+		"salary": v.Salary, "source_url": v.SourceURL, "source": v.Source, "notes": v.Notes,
+		"experience": v.ExperienceLevel, "location": v.Location, "work_format": v.WorkFormat,
+	} {
+		t.RawSetString(name, lua.LString(value))
+	}
+	keywords := L.NewTable()
+	for _, kw := range v.Keywords {
+		keywords.Append(lua.LString(kw))
+	}
+	t.RawSetString("keywords", keywords)
+	return t
+}
+
+// applyLuaVacancy переносит в вакансию поля, которые сценарию разрешено менять
+func applyLuaVacancy(t *lua.LTable, v *Vacancy) {
+	str := func(name, current string) string {
+		if s, ok := t.RawGetString(name).(lua.LString); ok {
+			return strings.TrimSpace(string(s))
+		}
+		return current
+	}
+	v.Notes = str("notes", v.Notes)
+	v.ExperienceLevel = str("experience", v.ExperienceLevel)
+	v.Location = str("location", v.Location)
+	v.WorkFormat = str("work_format", v.WorkFormat)
+	if keywords, ok := t.RawGetString("keywords").(*lua.LTable); ok {
+		var list []string
+		keywords.ForEach(func(_, value lua.LValue) {
+			if kw := strings.TrimSpace(value.String()); kw != "" {
+				list = append(list, kw)
+			}
+		})
+		v.Keywords = list
+	}
+}
+
+// runScriptHook вызывает функцию hook из сценариев для вакансии и возвращает
+// вакансию с правками сценария. Если такой функции нет, вакансия возвращается без изменений.
+func runScriptHook(proto *lua.FunctionProto, dir, hook string, v Vacancy, args ...string) (Vacancy, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), scriptTimeout)
+	defer cancel()
+	L := newScriptState(ctx, dir)
+	defer L.Close()
+	L.Push(L.NewFunctionFromProto(proto))
+	if err := L.PCall(0, lua.MultRet, nil); err != nil {
+		return v, err
+	}
+	fn, ok := L.GetGlobal(hook).(*lua.LFunction)
+	if !ok {
+		return v, nil
+	}
+	t := vacancyToLua(L, v)
+	params := []lua.LValue{t}
+	for _, a := range args {
+		params = append(params, lua.LString(a))
+	}
+	if err := L.CallByParam(lua.P{Fn: fn, NRet: 0, Protect: true}, params...); err != nil {
+		return v, fmt.Errorf("%s: %w", hook, err)
+	}
+	applyLuaVacancy(t, &v)
+	return v, nil
+}
+
+// scriptCall - один вызов функции сценария для вакансии
+type scriptCall struct {
+	hook string
+	v    Vacancy
+	args []string
+}
+
+// scriptBatch - вызовы сценариев по одному событию
+type scriptBatch struct {
+	path  string // Файл сценариев
+	dir   string // Папка данных для append_csv
+	calls []scriptCall
+}
+
+// scriptHooks следит за событиями вакансий и вызывает сценарии
+type scriptHooks struct {
+	app      *AppMainWindow
+	statuses statusTracker
+	queue    chan scriptBatch
+}
+
+// subscribeScriptHooks подключает сценарии к шине событий вакансий. Сценарии выполняются
+// по очереди в отдельной горутине, чтобы не задерживать интерфейс.
+func (app *AppMainWindow) subscribeScriptHooks() {
+	h := &scriptHooks{
+		app:      app,
+		statuses: newStatusTracker(snapshotVacancies()),
+		queue:    make(chan scriptBatch, scriptQueueSize),
+	}
+	go h.worker()
+	vacancyEvents.Subscribe(h.handle)
+}
+
+// handle ставит в очередь on_add для добавленной вакансии и on_status_change для каждой
+// вакансии, статус которой изменился с прошлого события
+func (h *scriptHooks) handle(e VacancyEvent) {
+	vacancies := snapshotVacancies()
+	changes := h.statuses.update(vacancies)
+
+	batch := scriptBatch{path: scriptsPath(), dir: dataPath("")}
+	if e.Kind == VacancyAdded {
+		for _, v := range vacancies {
+			if sameVacancy(v.Title, v.Company, e.Title, e.Company) {
+				batch.calls = append(batch.calls, scriptCall{hook: "on_add", v: v})
+				break
+			}
+		}
+	}
+	for _, c := range changes {
+		batch.calls = append(batch.calls, scriptCall{hook: "on_status_change", v: c.Vacancy, args: []string{c.OldStatus, c.Vacancy.Status}})
+	}
+	if len(batch.calls) == 0 {
+		return
+	}
+	select {
+	case h.queue <- batch:
+	default:
+		log.Printf("Очередь сценариев переполнена, пропущено вызовов: %d", len(batch.calls))
+	}
+}
+
+// worker выполняет сценарии из очереди и переносит их правки в вакансии в потоке интерфейса
+func (h *scriptHooks) worker() {
+	for batch := range h.queue {
+		proto, err := compiledScripts(batch.path)
+		if err != nil {
+			h.app.Synchronize(func() { h.reportError(err) })
+			continue
+		}
+		if proto == nil {
+			continue
+		}
+		var changed []Vacancy
+		for _, c := range batch.calls {
+			updated, err := runScriptHook(proto, batch.dir, c.hook, c.v, c.args...)
+			if err != nil {
+				h.app.Synchronize(func() { h.reportError(err) })
+				continue
+			}
+			if vacancyFieldsDiffer(c.v, updated) || updated.Location != c.v.Location || updated.WorkFormat != c.v.WorkFormat {
+				changed = append(changed, updated)
+			}
+		}
+		if len(changed) > 0 {
+			h.app.Synchronize(func() { h.apply(changed) })
+		}
+	}
+}
+
+// apply переносит в вакансии поля, изменённые сценариями
+func (h *scriptHooks) apply(changed []Vacancy) {
+	allVacanciesMutex.Lock()
+	var saved []Vacancy
+	for _, u := range changed {
+		for i := range allVacancies {
+			v := &allVacancies[i]
+			if sameVacancy(v.Title, v.Company, u.Title, u.Company) {
+				v.Keywords, v.Notes, v.ExperienceLevel = u.Keywords, u.Notes, u.ExperienceLevel
+				v.Location, v.WorkFormat = u.Location, u.WorkFormat
+				saved = append(saved, *v)
+				break
+			}
+		}
+	}
+	allVacanciesMutex.Unlock()
+	if len(saved) == 0 {
+		return
+	}
+	saveVacancies()
+	// Сценарии не меняют статус, поэтому повторное событие не вызовет их снова
+	for _, v := range saved {
+		vacancyEvents.Publish(vacancyEvent(VacancyUpdated, v))
+	}
+}
+
+// reportError запоминает ошибку сценария и показывает уведомление
+func (h *scriptHooks) reportError(err error) {
+	log.Printf("Ошибка сценария: %v", err)
+	lastScriptError = time.Now().Format("02.01 15:04") + " " + err.Error()
+	h.app.showToast("Ошибка сценария", err.Error(), h.app.showScriptsEditor)
+}
+
+// showScriptsEditor открывает редактор сценариев
+func (app *AppMainWindow) showScriptsEditor() {
+	var dlg *walk.Dialog
+	var codeTE *walk.TextEdit
+	var statusLabel *walk.Label
+	var acceptPB, cancelPB *walk.PushButton
+
+	source := loadScripts(scriptsPath())
+	if source == "" {
+		source = scriptsExample
+	}
+	status := "Ошибок нет"
+	if lastScriptError != "" {
+		status = "Последняя ошибка: " + lastScriptError
+	}
+
+	// check проверяет синтаксис без вызова функций
+	check := func() error {
+		ctx, cancel := context.WithTimeout(context.Background(), scriptTimeout)
+		defer cancel()
+		L := newScriptState(ctx, dataPath(""))
+		defer L.Close()
+		return L.DoString(strings.ReplaceAll(codeTE.Text(), "\r\n", "\n"))
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Сценарии",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 640, Height: 520},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: scriptsHelp, TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			TextEdit{
+				AssignTo: &codeTE,
+				Text:     strings.ReplaceAll(source, "\n", "\r\n"),
+				VScroll:  true,
+				HScroll:  true,
+				Font:     Font{Family: "Consolas", PointSize: 10},
+			},
+			Label{AssignTo: &statusLabel, Text: status, TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Проверить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if err := check(); err != nil {
+								statusLabel.SetText("Ошибка: " + err.Error())
+								return
+							}
+							statusLabel.SetText("Ошибок нет")
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if err := check(); err != nil {
+								statusLabel.SetText("Ошибка: " + err.Error())
+								return
+							}
+							code := strings.ReplaceAll(codeTE.Text(), "\r\n", "\n")
+							if err := os.WriteFile(scriptsPath(), []byte(code), 0644); err != nil {
+								showError(dlg, "Ошибка", wrapError("Не удалось сохранить сценарии", err))
+								return
+							}
+							invalidateScripts()
+							lastScriptError = ""
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
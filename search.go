@@ -0,0 +1,146 @@
+package main
+
+import (
+	"strings"
+	"unicode"
+)
+
+// searchQuery - запрос локального поиска. В точном режиме ищется подстрока целиком,
+// в нечётком - каждое слово запроса, с опечатками. Запрос и текст сравниваются и в латинице,
+// поэтому алфавит описания не важен; вне точного режима (stem) - по основам слов.
+type searchQuery struct {
+	term    string
+	pattern string   // Нормализованный запрос для поиска в нормализованном тексте
+	words   []string // Слова нормализованного запроса
+	fuzzy   bool
+	stem    bool
+}
+
+func newSearchQuery(term string, fuzzy, stem bool) searchQuery {
+	term = strings.ToLower(term)
+	norm := normalizeSearchText(term, stem)
+	return searchQuery{term: term, pattern: normalizedPattern(norm), words: strings.Fields(norm), fuzzy: fuzzy, stem: stem}
+}
+
+// searchWords делит текст на слова из букв и цифр
+func searchWords(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })
+}
+
+// match проверяет, подходит ли текст под запрос, и оценивает качество совпадения: 1 - найдено без опечаток
+func (q searchQuery) match(text string) (float64, bool) {
+	lower := strings.ToLower(text)
+	if strings.Contains(lower, q.term) {
+		return 1, true
+	}
+	if q.pattern == "" {
+		return 0, false
+	}
+	norm := normalizeSearchText(lower, q.stem)
+	padded := " " + norm + " "
+	if strings.Contains(padded, q.pattern) {
+		return 1, true
+	}
+	if !q.fuzzy {
+		return 0, false
+	}
+	textWords := strings.Fields(norm)
+	total := 0.0
+	for _, w := range q.words {
+		best := 0.0
+		if strings.Contains(padded, normalizedPattern(w)) {
+			best = 1
+		} else {
+			for _, tw := range textWords {
+				best = max(best, fuzzyWordQuality(w, tw))
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		total += best
+	}
+	return total / float64(len(q.words)), true
+}
+
+// matchAny - лучшее совпадение среди нескольких текстов
+func (q searchQuery) matchAny(texts ...string) (float64, bool) {
+	best, found := 0.0, false
+	for _, t := range texts {
+		if quality, ok := q.match(t); ok {
+			best, found = max(best, quality), true
+		}
+	}
+	return best, found
+}
+
+// maxTypos - сколько опечаток допускаем в слове: в коротких словах опечатка меняет смысл
+func maxTypos(length int) int {
+	switch {
+	case length <= 3:
+		return 0
+	case length <= 5:
+		return 1
+	}
+	return 2
+}
+
+// fuzzyWordQuality сравнивает слово запроса со словом текста по расстоянию Левенштейна; 0 - не похожи.
+// Слово в тексте может быть длиннее: «разработчиком» для «разрабтчик» сравнивается и по началу.
+func fuzzyWordQuality(query, word string) float64 {
+	q, w := []rune(query), []rune(word)
+	limit := maxTypos(len(q))
+	if limit == 0 || len(w) < len(q)-limit {
+		return 0
+	}
+	best := limit + 1
+	for n := len(q) - limit; n <= len(q)+limit && n <= len(w); n++ {
+		best = min(best, levenshtein(q, w[:n]))
+	}
+	if best > limit {
+		return 0
+	}
+	return 1 - float64(best)/float64(len(q)+1)
+}
+
+// levenshtein - число вставок, удалений и замен букв, превращающих a в b
+func levenshtein(a, b []rune) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
+
+// textMatcher ищет в тексте слова профиля и навыки с учётом алфавита, синонимов и словоформ
+type textMatcher struct {
+	lower  string
+	padded string // Нормализованный текст между пробелами
+}
+
+func newTextMatcher(text string) textMatcher {
+	lower := strings.ToLower(text)
+	return textMatcher{lower: lower, padded: " " + normalizeSearchText(lower, true) + " "}
+}
+
+// contains проверяет, встречается ли слово или фраза в тексте
+func (m textMatcher) contains(term string) bool {
+	term = strings.ToLower(term)
+	if strings.Contains(m.lower, term) {
+		return true
+	}
+	pattern := normalizedPattern(normalizeSearchText(term, true))
+	return pattern != "" && strings.Contains(m.padded, pattern)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lxn/walk"
+)
+
+// Цвет подсветки найденного текста в светлой и тёмной теме
+var (
+	searchHighlightLight = walk.RGB(255, 230, 110)
+	searchHighlightDark  = walk.RGB(110, 90, 0)
+)
+
+// pluralRu выбирает форму слова для числа: 1 совпадение, 2 совпадения, 5 совпадений
+func pluralRu(n int, one, few, many string) string {
+	n %= 100
+	switch {
+	case n >= 11 && n <= 14:
+		return many
+	case n%10 == 1:
+		return one
+	case n%10 >= 2 && n%10 <= 4:
+		return few
+	}
+	return many
+}
+
+// currentTextSearchTerm - текст из строки поиска, если поиск идёт по тексту
+// (при поиске по статусу и опыту подсвечивать в описании нечего)
+func (app *AppMainWindow) currentTextSearchTerm() string {
+	if app.searchEdit == nil || app.searchFieldCB == nil {
+		return ""
+	}
+	if i := app.searchFieldCB.CurrentIndex(); i >= 0 && i < len(searchFields) {
+		switch searchFields[i] {
+		case "По статусу", "По опыту":
+			return ""
+		}
+	}
+	return strings.TrimSpace(app.searchEdit.Text())
+}
+
+// highlightSearchMatches подсвечивает искомый текст в описании и заметках выбранной вакансии
+// и показывает над деталями, сколько совпадений нашлось
+func (app *AppMainWindow) highlightSearchMatches() {
+	if app.detailDescriptionTE == nil || app.detailNotesTE == nil || app.detailMatchesLabel == nil {
+		return
+	}
+	term := ""
+	if app.detailDescriptionTE.Enabled() {
+		term = app.currentTextSearchTerm()
+	}
+	color := searchHighlightLight
+	if currentTheme.Dark {
+		color = searchHighlightDark
+	}
+	inDescription := app.detailDescriptionTE.Highlight(term, color)
+	inNotes := app.detailNotesTE.Highlight(term, color)
+
+	if term == "" {
+		app.detailMatchesLabel.SetVisible(false)
+		return
+	}
+	total := inDescription + inNotes
+	text := fmt.Sprintf("🔍 %d %s с «%s»", total, pluralRu(total, "совпадение", "совпадения", "совпадений"), term)
+	if total > 0 {
+		text += fmt.Sprintf(" (описание: %d, заметки: %d)", inDescription, inNotes)
+	} else {
+		text += " в описании и заметках — строка найдена по другим полям"
+	}
+	app.detailMatchesLabel.SetText(text)
+	app.detailMatchesLabel.SetVisible(true)
+}
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+const searchHistoryFile = "search_history.json"
+
+// Сколько обычных (не отслеживаемых) запросов хранить
+const maxSearchHistory = 50
+
+// Как часто заново выполнять отслеживаемые запросы
+const watchRefreshInterval = 6 * time.Hour
+
+// Первый пункт выпадающего списка истории
+const searchHistoryPlaceholder = "🕘 История поиска"
+
+// SearchHistoryEntry - выполненный онлайн-поиск
+type SearchHistoryEntry struct {
+	Term     string    `json:"term"`
+	Provider string    `json:"provider"`
+	Results  int       `json:"results"`           // Сколько вакансий нашлось
+	New      int       `json:"new"`               // Из них нет в локальном списке
+	At       time.Time `json:"at"`                // Когда запрос выполнялся последний раз
+	Watched  bool      `json:"watched,omitempty"` // Запрос отслеживается: выполняется заново в фоне
+}
+
+// label - строка записи для выпадающего списка и окна истории
+func (e SearchHistoryEntry) label() string {
+	mark := ""
+	if e.Watched {
+		mark = "★ "
+	}
+	return fmt.Sprintf("%s%s (%d, новых %d)", mark, e.Term, e.Results, e.New)
+}
+
+// loadSearchHistory читает историю онлайн-поиска, свежие запросы первыми
+func loadSearchHistory() []SearchHistoryEntry {
+	data, err := os.ReadFile(dataPath(searchHistoryFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Ошибка чтения файла %s: %v", searchHistoryFile, err)
+		}
+		return nil
+	}
+	var history []SearchHistoryEntry
+	if err := json.Unmarshal(data, &history); err != nil {
+		log.Printf("Ошибка декодирования JSON из файла %s: %v", searchHistoryFile, err)
+		return nil
+	}
+	return history
+}
+
+// saveSearchHistory записывает историю онлайн-поиска
+func saveSearchHistory(history []SearchHistoryEntry) {
+	data, err := json.MarshalIndent(history, "", "  ")
+	if err != nil {
+		log.Printf("Ошибка кодирования истории поиска: %v", err)
+		return
+	}
+	if err := os.WriteFile(dataPath(searchHistoryFile), data, 0644); err != nil {
+		log.Printf("Ошибка записи файла %s: %v", searchHistoryFile, err)
+	}
+}
+
+// addSearchHistory поднимает запрос в начало истории с новыми результатами.
+// Отметка "отслеживать" у повторного запроса сохраняется.
+func addSearchHistory(history []SearchHistoryEntry, entry SearchHistoryEntry) []SearchHistoryEntry {
+	result := []SearchHistoryEntry{entry}
+	plain := 1 // Новая запись
+	for _, e := range history {
+		if strings.EqualFold(e.Term, entry.Term) && e.Provider == entry.Provider {
+			result[0].Watched = e.Watched
+			continue
+		}
+		if !e.Watched {
+			plain++
+			if plain >= maxSearchHistory {
+				continue
+			}
+		}
+		result = append(result, e)
+	}
+	return result
+}
+
+// countNewVacancies считает вакансии, которых ещё нет в локальном списке
+func countNewVacancies(found []Vacancy) int {
+	allVacanciesMutex.Lock()
+	defer allVacanciesMutex.Unlock()
+	n := 0
+	for _, v := range found {
+		local := false
+		for _, l := range allVacancies {
+			if sameVacancy(v.Title, v.Company, l.Title, l.Company) {
+				local = true
+				break
+			}
+		}
+		if !local {
+			n++
+		}
+	}
+	return n
+}
+
+// recordOnlineSearch сохраняет выполненный запрос в истории и обновляет выпадающий список
+func (app *AppMainWindow) recordOnlineSearch(term string, results, newCount int) {
+	term = strings.TrimSpace(term)
+	if term == "" {
+		return
+	}
+	history := addSearchHistory(loadSearchHistory(), SearchHistoryEntry{
+		Term:     term,
+		Provider: "Jooble",
+		Results:  results,
+		New:      newCount,
+		At:       time.Now(),
+	})
+	saveSearchHistory(history)
+	app.refreshSearchHistoryCB(history)
+}
+
+// refreshSearchHistoryCB заполняет выпадающий список истории
+func (app *AppMainWindow) refreshSearchHistoryCB(history []SearchHistoryEntry) {
+	if app.searchHistoryCB == nil {
+		return
+	}
+	labels := []string{searchHistoryPlaceholder}
+	for _, e := range history {
+		labels = append(labels, e.label())
+	}
+	app.searchHistoryItems = history
+	app.searchHistoryCB.SetModel(labels)
+	app.searchHistoryCB.SetCurrentIndex(0)
+}
+
+// rerunOnlineSearch повторяет онлайн-поиск по запросу из истории
+func (app *AppMainWindow) rerunOnlineSearch(term string) {
+	app.searchFieldCB.SetCurrentIndex(0)
+	app.searchEdit.SetText(term)
+	app.switchToOnlineSearchMode()
+}
+
+// onSearchHistoryPicked выполняет запрос, выбранный в выпадающем списке истории
+func (app *AppMainWindow) onSearchHistoryPicked() {
+	i := app.searchHistoryCB.CurrentIndex() - 1 // Первый пункт - заголовок
+	if i < 0 || i >= len(app.searchHistoryItems) {
+		return
+	}
+	term := app.searchHistoryItems[i].Term
+	// Возвращаем заголовок после обработки выбора
+	app.searchHistoryCB.Synchronize(func() { app.searchHistoryCB.SetCurrentIndex(0) })
+	if app.cancelOnlineSearchButton != nil && app.cancelOnlineSearchButton.Visible() {
+		return // Уже идёт поиск
+	}
+	app.rerunOnlineSearch(term)
+}
+
+// refreshWatchedSearches в фоне заново выполняет отслеживаемые запросы, которые давно не обновлялись.
+// Результаты попадают в кэш поиска, число новых вакансий - в историю.
+func (app *AppMainWindow) refreshWatchedSearches() {
+	if app.watchRunning {
+		return
+	}
+	var due []string
+	for _, e := range loadSearchHistory() {
+		if e.Watched && time.Since(e.At) >= watchRefreshInterval {
+			due = append(due, e.Term)
+		}
+	}
+	if len(due) == 0 {
+		return
+	}
+	app.watchRunning = true
+
+	go func() {
+		type result struct {
+			term            string
+			found, newCount int
+		}
+		var results []result
+		for _, term := range due {
+			found, err := searchOnline(context.Background(), term)
+			if err != nil {
+				log.Printf("Отслеживаемый поиск '%s' не удался: %v", term, err)
+				continue
+			}
+			results = append(results, result{term: term, found: len(found), newCount: countNewVacancies(found)})
+		}
+
+		app.Synchronize(func() {
+			app.watchRunning = false
+			history := loadSearchHistory()
+			for _, r := range results {
+				for i := range history {
+					if strings.EqualFold(history[i].Term, r.term) {
+						history[i].Results, history[i].New, history[i].At = r.found, r.newCount, time.Now()
+					}
+				}
+			}
+			saveSearchHistory(history)
+			app.refreshSearchHistoryCB(history)
+		})
+	}()
+}
+
+// SearchHistoryModel для TableView в окне истории поиска
+type SearchHistoryModel struct {
+	walk.TableModelBase
+	items []SearchHistoryEntry
+}
+
+func (m *SearchHistoryModel) RowCount() int {
+	return len(m.items)
+}
+
+func (m *SearchHistoryModel) Value(row, col int) interface{} {
+	item := m.items[row]
+	switch col {
+	case 0:
+		return item.Term
+	case 1:
+		return item.Provider
+	case 2:
+		return item.Results
+	case 3:
+		return item.New
+	case 4:
+		return item.At.Local().Format("02.01.2006 15:04")
+	case 5:
+		if item.Watched {
+			return "★ да"
+		}
+		return ""
+	}
+	return ""
+}
+
+// showSearchHistory показывает историю онлайн-поиска: повтор запроса, отслеживание, удаление
+func (app *AppMainWindow) showSearchHistory() {
+	var dlg *walk.Dialog
+	var table *walk.TableView
+	model := &SearchHistoryModel{items: loadSearchHistory()}
+	var rerun string
+
+	save := func() {
+		saveSearchHistory(model.items)
+		model.PublishRowsReset()
+		app.refreshSearchHistoryCB(model.items)
+	}
+	selected := func() int {
+		i := table.CurrentIndex()
+		if i < 0 || i >= len(model.items) {
+			return -1
+		}
+		return i
+	}
+
+	if _, err := (Dialog{
+		AssignTo:   &dlg,
+		Title:      "История онлайн-поиска",
+		MinSize:    Size{Width: 640, Height: 380},
+		Layout:     VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      fmt.Sprintf("Отслеживаемые запросы выполняются заново в фоне раз в %d ч., пока есть сеть.", int(watchRefreshInterval.Hours())),
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			TableView{
+				AssignTo: &table,
+				Model:    model,
+				Columns: []TableViewColumn{
+					{Title: "Запрос", Width: 180},
+					{Title: "Источник", Width: 80},
+					{Title: "Найдено", Width: 70},
+					{Title: "Новых", Width: 60},
+					{Title: "Когда", Width: 110},
+					{Title: "Отслеживается", Width: 100},
+				},
+				OnItemActivated: func() {
+					if i := selected(); i >= 0 {
+						rerun = model.items[i].Term
+						dlg.Accept()
+					}
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Повторить поиск",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if i := selected(); i >= 0 {
+								rerun = model.items[i].Term
+								dlg.Accept()
+							}
+						},
+					},
+					PushButton{
+						Text:       "Отслеживать / не отслеживать",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if i := selected(); i >= 0 {
+								model.items[i].Watched = !model.items[i].Watched
+								save()
+							}
+						},
+					},
+					PushButton{
+						Text:       "Удалить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if i := selected(); i >= 0 {
+								model.items = append(model.items[:i], model.items[i+1:]...)
+								save()
+							}
+						},
+					},
+					PushButton{
+						Text:       "Очистить историю",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if walk.DlgCmdYes != walk.MsgBox(dlg, "История поиска", "Удалить все запросы, кроме отслеживаемых?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) {
+								return
+							}
+							var kept []SearchHistoryEntry
+							for _, e := range model.items {
+								if e.Watched {
+									kept = append(kept, e)
+								}
+							}
+							model.items = kept
+							save()
+						},
+					},
+					HSpacer{},
+					PushButton{
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Accept() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	if rerun != "" {
+		app.rerunOnlineSearch(rerun)
+	}
+}
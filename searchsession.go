@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+
+	"github.com/lxn/walk"
+)
+
+// SearchSession - один онлайн-поиск. Отмена - это отмена его контекста, поэтому её можно
+// вызывать сколько угодно раз и из любых обработчиков; обработчики кнопок, подключённые
+// на время поиска, отключаются, когда сеанс заканчивается.
+type SearchSession struct {
+	ctx      context.Context
+	cancel   context.CancelFunc
+	handlers []sessionHandler
+}
+
+// sessionHandler - обработчик события, подключённый на время сеанса
+type sessionHandler struct {
+	event  *walk.Event
+	handle int
+}
+
+func newSearchSession() *SearchSession {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &SearchSession{ctx: ctx, cancel: cancel}
+}
+
+// Context - контекст, который передаётся источникам вакансий
+func (s *SearchSession) Context() context.Context {
+	return s.ctx
+}
+
+// Cancel отменяет поиск
+func (s *SearchSession) Cancel() {
+	s.cancel()
+}
+
+// Canceled проверяет, отменён ли поиск
+func (s *SearchSession) Canceled() bool {
+	return s.ctx.Err() != nil
+}
+
+// attach подключает обработчик к событию до конца сеанса
+func (s *SearchSession) attach(event *walk.Event, handler walk.EventHandler) {
+	s.handlers = append(s.handlers, sessionHandler{event: event, handle: event.Attach(handler)})
+}
+
+// End завершает сеанс: отменяет контекст и отключает обработчики. Вызывается в потоке интерфейса.
+func (s *SearchSession) End() {
+	s.cancel()
+	for _, h := range s.handlers {
+		h.event.Detach(h.handle)
+	}
+	s.handlers = nil
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// onlineSource - источник онлайн-поиска: Jooble, RSS-лента или расширение
+type onlineSource struct {
+	Name   string
+	Search func(ctx context.Context) ([]Vacancy, error)
+}
+
+// searchBatch - результаты одного источника, пришедшие по ходу поиска
+type searchBatch struct {
+	Source    string
+	Vacancies []Vacancy
+	Err       error
+	index     int // Номер источника в списке: у двух лент бывает одинаковое имя
+}
+
+// onlineSources - все источники для поиска по keywords
+func onlineSources(keywords string) []onlineSource {
+	sources := []onlineSource{{
+		Name:   "Jooble",
+		Search: func(ctx context.Context) ([]Vacancy, error) { return searchVacanciesJooble(ctx, keywords, "") },
+	}}
+	sources = append(sources, feedSources(keywords)...)
+	return append(sources, pluginSources(keywords)...)
+}
+
+// streamSearch опрашивает источники одновременно и отдаёт результат каждого, как только он готов.
+// Выключенные после череды неудач источники пропускаются. Канал закрывается, когда ответили все источники.
+func streamSearch(ctx context.Context, sources []onlineSource) <-chan searchBatch {
+	out := make(chan searchBatch, len(sources))
+	var wg sync.WaitGroup
+	for i, s := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if until, ok := breakerAllow(s.Name); !ok {
+				out <- searchBatch{Source: s.Name, Err: &circuitOpenError{Source: s.Name, Until: until}, index: i}
+				return
+			}
+			vacancies, err := s.Search(ctx)
+			breakerRecord(s.Name, err)
+			out <- searchBatch{Source: s.Name, Vacancies: vacancies, Err: err, index: i}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+	return out
+}
+
+// searchOnline ищет вакансии во всех источниках и возвращает их вместе.
+// Ошибка возвращается, только если не удалось получить ничего.
+func searchOnline(ctx context.Context, keywords string) ([]Vacancy, error) {
+	var found []Vacancy
+	var firstErr error
+	for b := range streamSearch(ctx, onlineSources(keywords)) {
+		if b.Err != nil {
+			var open *circuitOpenError
+			if !errors.As(b.Err, &open) {
+				log.Printf("Ошибка поиска в источнике %s: %v", b.Source, b.Err)
+			}
+			if firstErr == nil {
+				firstErr = b.Err
+			}
+			continue
+		}
+		found = append(found, b.Vacancies...)
+	}
+	if len(found) == 0 && firstErr != nil {
+		return nil, firstErr
+	}
+	return found, nil
+}
+
+// searchProgress - ход онлайн-поиска в окне: что ответил каждый источник
+type searchProgress struct {
+	sources  []string
+	status   []string
+	done     int
+	found    int             // Найдено всего, включая вакансии из локального списка
+	seen     map[string]bool // Ссылки уже показанных вакансий: одна вакансия бывает в нескольких источниках
+	firstErr error
+}
+
+func newSearchProgress(sources []onlineSource) *searchProgress {
+	p := &searchProgress{seen: map[string]bool{}}
+	for _, s := range sources {
+		p.sources = append(p.sources, s.Name)
+		p.status = append(p.status, "ищем...")
+	}
+	return p
+}
+
+// add учитывает ответ источника и возвращает вакансии, которых ещё нет ни в результатах, ни в локальном списке
+func (p *searchProgress) add(b searchBatch) []Vacancy {
+	p.done++
+	if errors.Is(b.Err, context.Canceled) {
+		p.status[b.index] = "отменён"
+		return nil
+	}
+	if b.Err != nil {
+		var open *circuitOpenError
+		if errors.As(b.Err, &open) {
+			p.status[b.index] = "временно недоступен до " + open.Until.Format("15:04")
+		} else if until, ok := breakerAllow(b.Source); !ok {
+			// Эта неудача выключила источник
+			p.status[b.index] = "временно недоступен до " + until.Format("15:04")
+		} else {
+			log.Printf("Ошибка поиска в источнике %s: %v", b.Source, b.Err)
+			p.status[b.index] = "ошибка"
+		}
+		if p.firstErr == nil {
+			p.firstErr = b.Err
+		}
+		return nil
+	}
+	p.status[b.index] = fmt.Sprint(len(b.Vacancies))
+	p.found += len(b.Vacancies)
+	var fresh []Vacancy
+	for _, v := range notInLocalList(b.Vacancies) {
+		if u := canonicalURL(v.SourceURL); u != "" {
+			if p.seen[u] {
+				continue
+			}
+			p.seen[u] = true
+		}
+		fresh = append(fresh, v)
+	}
+	return fresh
+}
+
+// unavailable проверяет, отключён ли сейчас хотя бы один источник поиска
+func (p *searchProgress) unavailable() bool {
+	for _, name := range p.sources {
+		if _, ok := breakerAllow(name); !ok {
+			return true
+		}
+	}
+	return false
+}
+
+// text - строка с состоянием каждого источника
+func (p *searchProgress) text() string {
+	parts := make([]string, len(p.sources))
+	for i, name := range p.sources {
+		parts[i] = name + ": " + p.status[i]
+	}
+	return strings.Join(parts, "   ")
+}
+
+// notInLocalList отбрасывает вакансии, которые уже есть в локальном списке
+func notInLocalList(vacancies []Vacancy) []Vacancy {
+	allVacanciesMutex.Lock()
+	defer allVacanciesMutex.Unlock()
+	var fresh []Vacancy
+	for _, onlineV := range vacancies {
+		if findDuplicateLocked(onlineV) == -1 {
+			fresh = append(fresh, onlineV)
+		}
+	}
+	return fresh
+}
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// Пароли и токены интеграций (SMTP, Google Календарь, Notion, Trello, Jira и другие из
+// settingsSecrets) хранятся в settings.json зашифрованными DPAPI: расшифровать их может
+// только та же учётная запись Windows. В памяти настройки лежат открытыми, шифруются
+// только при записи. В резервные копии секреты не попадают вовсе.
+
+// Префикс зашифрованного значения в файле настроек; значения без него - открытые из старых версий
+const protectedSecretPrefix = "dpapi:"
+
+// protectSecret шифрует секрет для текущего пользователя Windows
+func protectSecret(secret string) (string, error) {
+	if secret == "" {
+		return "", nil
+	}
+	in := []byte(secret)
+	var out windows.DataBlob
+	if err := windows.CryptProtectData(&windows.DataBlob{Size: uint32(len(in)), Data: &in[0]}, nil, nil, 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &out); err != nil {
+		return "", err
+	}
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(out.Data)))
+	return protectedSecretPrefix + base64.StdEncoding.EncodeToString(unsafe.Slice(out.Data, out.Size)), nil
+}
+
+// unprotectSecret расшифровывает значение из файла настроек; открытое значение возвращается как есть
+func unprotectSecret(value string) (string, error) {
+	encoded, ok := strings.CutPrefix(value, protectedSecretPrefix)
+	if !ok {
+		return value, nil
+	}
+	in, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", err
+	}
+	if len(in) == 0 {
+		return "", errors.New("пустое зашифрованное значение")
+	}
+	var out windows.DataBlob
+	if err := windows.CryptUnprotectData(&windows.DataBlob{Size: uint32(len(in)), Data: &in[0]}, nil, nil, 0, nil, windows.CRYPTPROTECT_UI_FORBIDDEN, &out); err != nil {
+		return "", err
+	}
+	defer windows.LocalFree(windows.Handle(unsafe.Pointer(out.Data)))
+	if out.Size == 0 {
+		return "", nil
+	}
+	return string(unsafe.Slice(out.Data, out.Size)), nil
+}
+
+// protectSettingsSecrets шифрует секреты в копии настроек перед записью в файл
+func protectSettingsSecrets(s *AppSettings) {
+	for _, p := range settingsSecrets(s) {
+		if *p == "" {
+			continue
+		}
+		enc, err := protectSecret(*p)
+		if err != nil {
+			log.Printf("Ошибка шифрования секрета настроек, значение не сохранено: %v", err)
+			*p = ""
+			continue
+		}
+		*p = enc
+	}
+}
+
+// unprotectSettingsSecrets расшифровывает секреты после чтения файла настроек. Значения,
+// зашифрованные другим пользователем или на другом компьютере, сбрасываются - их нужно ввести заново.
+func unprotectSettingsSecrets(s *AppSettings) {
+	for _, p := range settingsSecrets(s) {
+		plain, err := unprotectSecret(*p)
+		if err != nil {
+			log.Printf("Не удалось расшифровать секрет из настроек, его нужно ввести заново: %v", err)
+			plain = ""
+		}
+		*p = plain
+	}
+}
+
+// withoutSettingsSecrets убирает секреты из файла настроек data, например для резервной копии
+func withoutSettingsSecrets(data []byte) ([]byte, error) {
+	var s AppSettings
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	for _, p := range settingsSecrets(&s) {
+		*p = ""
+	}
+	return json.MarshalIndent(s, "", "  ")
+}
+
+// keepSettingsSecrets возвращает в s секреты из prev, которых нет в s: после восстановления
+// настроек из резервной копии без секретов интеграции продолжают работать
+func keepSettingsSecrets(s *AppSettings, prev AppSettings) {
+	prevSecrets := settingsSecrets(&prev)
+	for i, p := range settingsSecrets(s) {
+		if *p == "" {
+			*p = *prevSecrets[i]
+		}
+	}
+}
@@ -0,0 +1,420 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Окно «Настройки» собирает основные параметры приложения на вкладках. Изменения
+// применяются сразу, как только значение проходит проверку, а «Отменить изменения»
+// возвращает всё, как было до открытия окна. Редкие и большие разделы (сеть, SMTP,
+// шрифты, синонимы) открываются из вкладок своими окнами.
+
+// Вкладки окна настроек, на которые указывает ошибка проверки
+const (
+	settingsPageGeneral = iota
+	settingsPageAppearance
+	settingsPageSearch
+	settingsPageAPI
+	settingsPageNotifications
+	settingsPageData
+)
+
+// Сколько секунд показывать сообщение в окне по умолчанию
+const defaultToastSeconds = 8
+
+// Языки распознавания tesseract: rus, eng, rus+eng, chi_sim
+var ocrLanguagesRe = regexp.MustCompile(`^[a-z_]+(\+[a-z_]+)*$`)
+
+// settingsError - ошибка проверки настроек и вкладка, где её исправлять
+type settingsError struct {
+	Page int
+	Err  error
+}
+
+func (e *settingsError) Error() string { return e.Err.Error() }
+
+func (e *settingsError) Unwrap() error { return e.Err }
+
+// validateSettings проверяет значения, которые нельзя ограничить самим полем ввода
+func validateSettings(s AppSettings) error {
+	fail := func(page int, text string) error {
+		return &settingsError{Page: page, Err: errors.New(text)}
+	}
+	if s.UpdateURL != "" && !validVacancyURL(s.UpdateURL) {
+		return fail(settingsPageAPI, "Адрес проверки обновлений должен начинаться с http:// или https://.")
+	}
+	if strings.ContainsAny(s.JoobleAPIKey, " \t") {
+		return fail(settingsPageAPI, "Ключ Jooble не должен содержать пробелов.")
+	}
+	if s.TelegramBotToken != "" && !strings.Contains(s.TelegramBotToken, ":") {
+		return fail(settingsPageAPI, "Токен бота Telegram выглядит как 123456:ABC... - скопируйте его из @BotFather целиком.")
+	}
+	if s.OCRLanguages != "" && !ocrLanguagesRe.MatchString(s.OCRLanguages) {
+		return fail(settingsPageData, "Языки распознавания записываются через «+», например rus+eng.")
+	}
+	if s.TesseractPath != "" {
+		if _, err := os.Stat(s.TesseractPath); err != nil {
+			return fail(settingsPageData, "Файл tesseract.exe не найден: "+s.TesseractPath)
+		}
+	}
+	if s.BackupDir != "" {
+		if info, err := os.Stat(s.BackupDir); err == nil && !info.IsDir() {
+			return fail(settingsPageData, "Папка для резервных копий указывает на файл.")
+		}
+	}
+	return nil
+}
+
+// toastTimeoutSeconds - сколько секунд показывать сообщение в окне
+func toastTimeoutSeconds() int {
+	if appSettings.ToastSeconds > 0 {
+		return appSettings.ToastSeconds
+	}
+	return defaultToastSeconds
+}
+
+// applySettings применяет appSettings к окну, сравнивая с прежними old
+func (app *AppMainWindow) applySettings(old AppSettings) {
+	s := appSettings
+	if s.ThemeName != old.ThemeName || s.StatusPalette != old.StatusPalette {
+		app.applySavedTheme()
+	}
+	for _, check := range []struct {
+		action *walk.Action
+		on     bool
+	}{
+		{app.fuzzySearchAction, s.FuzzySearch},
+		{app.exactSearchAction, s.ExactSearch},
+		{app.suggestCompaniesAction, s.SuggestBundledCompanies},
+		{app.showArchiveAction, s.ShowArchived},
+	} {
+		if check.action != nil {
+			check.action.SetChecked(check.on)
+		}
+	}
+	if s.FuzzySearch != old.FuzzySearch || s.ExactSearch != old.ExactSearch || s.ShowArchived != old.ShowArchived {
+		app.performSearch()
+	}
+	if s.SalaryTargetCurrency != old.SalaryTargetCurrency {
+		app.refreshSalaryColumn()
+	}
+	if s.WeeklyApplicationGoal != old.WeeklyApplicationGoal {
+		app.updateGoalProgress()
+	}
+	if s.Fonts != old.Fonts || s.Zoom != old.Zoom {
+		app.applyFonts()
+	}
+	if s.StartWithWindows != old.StartWithWindows && !viewOnly {
+		app.applyAutostart()
+	}
+	if s.ProxyMode != old.ProxyMode || s.ProxyURL != old.ProxyURL || s.TLSSkipVerify != old.TLSSkipVerify || s.TLSCAFile != old.TLSCAFile ||
+		s.ConnectTimeoutSeconds != old.ConnectTimeoutSeconds || s.ReadTimeoutSeconds != old.ReadTimeoutSeconds {
+		resetHTTPClient()
+	}
+}
+
+// showSettingsDialog показывает окно настроек
+func (app *AppMainWindow) showSettingsDialog() {
+	var dlg *walk.Dialog
+	var tabs *walk.TabWidget
+	var issueLabel *walk.Label
+	var donePB, revertPB *walk.PushButton
+
+	var languageCB, themeCB, paletteCB, currencyCB *walk.ComboBox
+	var agendaCB, updatesCB, archivedCB, fuzzyCB, exactCB, suggestCB, muteTrayCB, autostartCB, autoBackupCB *walk.CheckBox
+	var goalNE, cooldownNE, cacheNE, feedNE, toastNE, followUpNE, retentionNE *walk.NumberEdit
+	var joobleLE, telegramLE, updateURLLE, backupDirLE, tesseractLE, ocrLE *walk.LineEdit
+
+	original := appSettings
+	ready := false
+
+	languageNames := make([]string, len(interfaceLanguages))
+	languageIndex := 0
+	for i, l := range interfaceLanguages {
+		languageNames[i] = l.Name
+		if l.Code == appSettings.Language {
+			languageIndex = i
+		}
+	}
+	paletteNames := make([]string, len(statusPalettes))
+	paletteIndex := 0
+	for i, p := range statusPalettes {
+		paletteNames[i] = p.Name
+		if p.ID == appSettings.StatusPalette {
+			paletteIndex = i
+		}
+	}
+	retention := appSettings.BackupRetention
+	if retention <= 0 {
+		retention = defaultBackupRetention
+	}
+
+	// collect собирает настройки из полей окна поверх текущих
+	collect := func() AppSettings {
+		s := appSettings
+		if i := languageCB.CurrentIndex(); i >= 0 {
+			s.Language = interfaceLanguages[i].Code
+		}
+		s.WeeklyApplicationGoal = int(goalNE.Value())
+		s.RejectionCooldownMonths = int(cooldownNE.Value())
+		s.HideAgendaOnStartup = !agendaCB.Checked()
+		s.SkipUpdateCheck = !updatesCB.Checked()
+		s.StartWithWindows = autostartCB.Checked()
+
+		s.ThemeName = themeByName(themeCB.Text()).Name
+		if i := paletteCB.CurrentIndex(); i >= 0 {
+			s.StatusPalette = statusPalettes[i].ID
+		}
+		if i := currencyCB.CurrentIndex(); i >= 0 {
+			s.SalaryTargetCurrency = salaryCurrencies[i]
+		}
+		s.ShowArchived = archivedCB.Checked()
+
+		s.FuzzySearch = fuzzyCB.Checked()
+		s.ExactSearch = exactCB.Checked()
+		s.SuggestBundledCompanies = suggestCB.Checked()
+		s.SearchCacheTTLMinutes = int(cacheNE.Value())
+		s.FeedPollMinutes = int(feedNE.Value())
+
+		s.JoobleAPIKey = strings.TrimSpace(joobleLE.Text())
+		s.TelegramBotToken = strings.TrimSpace(telegramLE.Text())
+		s.UpdateURL = strings.TrimSpace(updateURLLE.Text())
+
+		s.ToastSeconds = int(toastNE.Value())
+		s.MuteTrayNotifications = muteTrayCB.Checked()
+		s.FollowUpDays = int(followUpNE.Value())
+
+		s.AutoBackup = autoBackupCB.Checked()
+		s.BackupRetention = int(retentionNE.Value())
+		s.BackupDir = strings.TrimSpace(backupDirLE.Text())
+		s.TesseractPath = strings.TrimSpace(tesseractLE.Text())
+		s.OCRLanguages = strings.TrimSpace(ocrLE.Text())
+		return s
+	}
+
+	// update проверяет поля и, если всё в порядке, сразу применяет настройки
+	update := func() bool {
+		if !ready {
+			return true
+		}
+		s := collect()
+		if err := validateSettings(s); err != nil {
+			issueLabel.SetText("⚠ " + err.Error())
+			issueLabel.SetVisible(true)
+			var se *settingsError
+			if errors.As(err, &se) {
+				tabs.SetCurrentIndex(se.Page)
+			}
+			return false
+		}
+		issueLabel.SetVisible(false)
+		old := appSettings
+		appSettings = s
+		app.applySettings(old)
+		saveSettings()
+		return true
+	}
+	changed := func() { update() }
+
+	label := func(text string) Label {
+		return Label{Text: text, TextColor: currentTheme.Text, Font: Font{PointSize: 9}}
+	}
+	note := func(text string) Label {
+		return Label{Text: text, ColumnSpan: 2, TextColor: currentTheme.Text, Font: Font{PointSize: 8}}
+	}
+	check := func(assign **walk.CheckBox, text string, checked bool) CheckBox {
+		return CheckBox{AssignTo: assign, Text: text, ColumnSpan: 2, Checked: checked, OnCheckedChanged: changed}
+	}
+	number := func(assign **walk.NumberEdit, value, min, max int) NumberEdit {
+		return NumberEdit{AssignTo: assign, Value: float64(value), MinValue: float64(min), MaxValue: float64(max), SpinButtonsVisible: true, OnValueChanged: changed}
+	}
+	text := func(assign **walk.LineEdit, value string, password bool) LineEdit {
+		return LineEdit{AssignTo: assign, Text: value, PasswordMode: password, OnEditingFinished: changed}
+	}
+	button := func(text string, onClicked walk.EventHandler) PushButton {
+		return PushButton{Text: text, Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: onClicked}
+	}
+	// page - вкладка с полями в две колонки и кнопками дополнительных окон внизу
+	page := func(title string, rows []Widget, buttons ...Widget) TabPage {
+		children := []Widget{Composite{Layout: Grid{Columns: 2, MarginsZero: true}, Children: rows}, VSpacer{}}
+		if len(buttons) > 0 {
+			children = append(children, Composite{Layout: HBox{MarginsZero: true}, Children: append(buttons, HSpacer{})})
+		}
+		return TabPage{
+			Title:      title,
+			Layout:     VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+			Background: SolidColorBrush{Color: currentTheme.Background},
+			Children:   children,
+		}
+	}
+	browse := func(le **walk.LineEdit, title, filter string, folder bool) walk.EventHandler {
+		return func() {
+			fd := &walk.FileDialog{Title: title, Filter: filter, FilePath: (*le).Text()}
+			var ok bool
+			var err error
+			if folder {
+				ok, err = fd.ShowBrowseFolder(dlg)
+			} else {
+				ok, err = fd.ShowOpen(dlg)
+			}
+			if err != nil || !ok {
+				return
+			}
+			(*le).SetText(fd.FilePath)
+			update()
+		}
+	}
+
+	if err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Настройки",
+		DefaultButton: &donePB,
+		CancelButton:  &revertPB,
+		MinSize:       Size{Width: 560, Height: 440},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			TabWidget{
+				AssignTo: &tabs,
+				Pages: []TabPage{
+					page("Общие", []Widget{
+						label("Язык интерфейса:"),
+						ComboBox{AssignTo: &languageCB, Model: languageNames, CurrentIndex: languageIndex, OnCurrentIndexChanged: changed},
+						note("Пока интерфейс есть только на русском."),
+						label("Цель по откликам в неделю:"),
+						number(&goalNE, appSettings.WeeklyApplicationGoal, 0, 500),
+						label("Период ожидания после отказа, мес.:"),
+						number(&cooldownNE, appSettings.RejectionCooldownMonths, 0, 36),
+						check(&agendaCB, "Показывать панель «Сегодня» при запуске", !appSettings.HideAgendaOnStartup),
+						check(&updatesCB, "Проверять обновления при запуске", !appSettings.SkipUpdateCheck),
+						check(&autostartCB, "Запускать вместе с Windows (в области уведомлений)", appSettings.StartWithWindows),
+						note("До открытия окна работают только напоминания и отслеживаемые поиски."),
+					}, button("Профиль и навыки...", app.showProfileDialog)),
+					page("Внешний вид", []Widget{
+						label("Тема:"),
+						ComboBox{AssignTo: &themeCB, Model: themeNames(), CurrentIndex: slices.Index(themeNames(), currentTheme.Name), OnCurrentIndexChanged: changed},
+						label("Цвета статусов:"),
+						ComboBox{AssignTo: &paletteCB, Model: paletteNames, CurrentIndex: paletteIndex, OnCurrentIndexChanged: changed},
+						label("Валюта колонки «Зарплата»:"),
+						ComboBox{AssignTo: &currencyCB, Model: salaryCurrencies, CurrentIndex: slices.Index(salaryCurrencies, salaryTargetCurrency()), OnCurrentIndexChanged: changed},
+						check(&archivedCB, "Показывать вакансии в архиве и с отказом", appSettings.ShowArchived),
+					}, button("Шрифты...", app.showFontSettings), button("Курсы валют...", func() {
+						app.showCurrencySettings()
+						ready = false // Валюту могли сменить и там
+						currencyCB.SetCurrentIndex(slices.Index(salaryCurrencies, salaryTargetCurrency()))
+						ready = true
+					})),
+					page("Поиск", []Widget{
+						check(&fuzzyCB, "Нечёткий поиск (с опечатками)", appSettings.FuzzySearch),
+						check(&exactCB, "Точный поиск (без словоформ)", appSettings.ExactSearch),
+						check(&suggestCB, "Подсказывать известные компании", appSettings.SuggestBundledCompanies),
+						label("Хранить результаты онлайн-поиска, мин.:"),
+						number(&cacheNE, appSettings.SearchCacheTTLMinutes, 0, searchCacheTTLMaxMinutes),
+						label("Проверять RSS-ленты раз в, мин.:"),
+						number(&feedNE, appSettings.FeedPollMinutes, 5, feedPollMaxMinutes),
+					}, button("Синонимы...", app.showSynonymsDialog), button("Чёрный список...", app.showBlocklistDialog), button("Дубликаты...", app.showDedupSettings)),
+					page("API", []Widget{
+						label("Ключ Jooble API:"),
+						text(&joobleLE, appSettings.JoobleAPIKey, false),
+						note("Пусто - встроенный ключ приложения."),
+						label("Токен бота Telegram:"),
+						text(&telegramLE, appSettings.TelegramBotToken, true),
+						label("Адрес проверки обновлений:"),
+						text(&updateURLLE, appSettings.UpdateURL, false),
+						note("Пусто - релизы на GitHub."),
+					}, button("Сеть и прокси...", app.showNetworkSettings), button("SMTP...", app.showSMTPSettings)),
+					page("Уведомления", []Widget{
+						label("Показывать сообщения в окне, сек.:"),
+						number(&toastNE, toastTimeoutSeconds(), 3, 60),
+						check(&muteTrayCB, "Не показывать всплывающие уведомления Windows", appSettings.MuteTrayNotifications),
+						note("Уведомления всё равно попадают в историю."),
+						label("Напоминать о follow-up через, дн.:"),
+						number(&followUpNE, appSettings.FollowUpDays, 0, 90),
+					}, button("История уведомлений...", app.showNotificationHistory), button("Напоминания...", app.showRemindersDialog)),
+					page("Данные", []Widget{
+						label("Папка данных:"),
+						LineEdit{Text: dataPath("."), ReadOnly: true},
+						check(&autoBackupCB, "Создавать резервную копию раз в неделю", appSettings.AutoBackup),
+						label("Хранить последних автоматических копий:"),
+						number(&retentionNE, retention, 1, 100),
+						label("Папка для копий:"),
+						Composite{Layout: HBox{MarginsZero: true}, Children: []Widget{
+							text(&backupDirLE, appSettings.BackupDir, false),
+							button("...", browse(&backupDirLE, "Папка для резервных копий", "", true)),
+						}},
+						label("Путь к tesseract.exe:"),
+						Composite{Layout: HBox{MarginsZero: true}, Children: []Widget{
+							text(&tesseractLE, appSettings.TesseractPath, false),
+							button("...", browse(&tesseractLE, "Где находится tesseract.exe", "tesseract.exe|tesseract.exe", false)),
+						}},
+						label("Языки распознавания:"),
+						text(&ocrLE, appSettings.OCRLanguages, false),
+					}, button("Открыть папку данных", func() {
+						if err := openFileExternally(dataPath(".")); err != nil {
+							log.Printf("Ошибка открытия папки данных: %v", err)
+						}
+					}), button("Создать копию сейчас", app.backupNow)),
+				},
+			},
+			Label{AssignTo: &issueLabel, Visible: false, TextColor: walk.RGB(200, 0, 0), Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					label("Изменения применяются сразу."),
+					HSpacer{},
+					PushButton{
+						AssignTo:   &donePB,
+						Text:       "Готово",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if update() {
+								dlg.Accept()
+							}
+						},
+					},
+					PushButton{
+						AssignTo:   &revertPB,
+						Text:       "Отменить изменения",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	ready = true
+	if dlg.Run() == walk.DlgCmdOK {
+		return
+	}
+	// Закрытие окна без «Готово» возвращает поля окна к значениям при открытии
+	current := appSettings
+	copyDialogSettings(&appSettings, original)
+	app.applySettings(current)
+	saveSettings()
+}
+
+// copyDialogSettings переносит из src в dst поля, которые меняются в окне настроек
+func copyDialogSettings(dst *AppSettings, src AppSettings) {
+	dst.Language, dst.WeeklyApplicationGoal, dst.RejectionCooldownMonths = src.Language, src.WeeklyApplicationGoal, src.RejectionCooldownMonths
+	dst.HideAgendaOnStartup, dst.SkipUpdateCheck, dst.StartWithWindows = src.HideAgendaOnStartup, src.SkipUpdateCheck, src.StartWithWindows
+	dst.ThemeName, dst.StatusPalette, dst.SalaryTargetCurrency, dst.ShowArchived = src.ThemeName, src.StatusPalette, src.SalaryTargetCurrency, src.ShowArchived
+	dst.FuzzySearch, dst.ExactSearch, dst.SuggestBundledCompanies = src.FuzzySearch, src.ExactSearch, src.SuggestBundledCompanies
+	dst.SearchCacheTTLMinutes, dst.FeedPollMinutes = src.SearchCacheTTLMinutes, src.FeedPollMinutes
+	dst.JoobleAPIKey, dst.TelegramBotToken, dst.UpdateURL = src.JoobleAPIKey, src.TelegramBotToken, src.UpdateURL
+	dst.ToastSeconds, dst.MuteTrayNotifications, dst.FollowUpDays = src.ToastSeconds, src.MuteTrayNotifications, src.FollowUpDays
+	dst.AutoBackup, dst.BackupRetention, dst.BackupDir = src.AutoBackup, src.BackupRetention, src.BackupDir
+	dst.TesseractPath, dst.OCRLanguages = src.TesseractPath, src.OCRLanguages
+}
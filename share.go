@@ -0,0 +1,276 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+	"golang.org/x/sys/windows/registry"
+)
+
+const (
+	sharedVacancyExt     = ".vacancy"
+	sharedVacancyFormat  = "jobsearch-vacancy"
+	sharedVacancyVersion = 1
+	sharedVacancyProgID  = "JobSearch.Vacancy"
+)
+
+// SharedVacancy - содержимое файла .vacancy для обмена вакансиями
+type SharedVacancy struct {
+	Format  string  `json:"format"`
+	Version int     `json:"version"`
+	Vacancy Vacancy `json:"vacancy"`
+}
+
+// shareableVacancy оставляет в вакансии только то, чем имеет смысл делиться:
+// без резюме, истории статусов и личных оценок
+func shareableVacancy(v Vacancy, withDescription, withNotes bool) Vacancy {
+	shared := Vacancy{
+		Title:           v.Title,
+		Company:         v.Company,
+		Keywords:        append([]string{}, v.Keywords...),
+		SourceURL:       v.SourceURL,
+		ExperienceLevel: v.ExperienceLevel,
+		Salary:          v.Salary,
+		SalaryMin:       v.SalaryMin,
+		SalaryMax:       v.SalaryMax,
+		SalaryCurrency:  v.SalaryCurrency,
+		WorkFormat:      v.WorkFormat,
+		Location:        v.Location,
+	}
+	if withDescription {
+		shared.Description = v.Description
+	}
+	if withNotes {
+		shared.Notes = v.Notes
+	}
+	return shared
+}
+
+var unsafeFileNameChars = regexp.MustCompile(`[<>:"/\\|?*\x00-\x1f]+`)
+
+// sharedVacancyFileName предлагает имя файла по названию и компании
+func sharedVacancyFileName(v Vacancy) string {
+	name := strings.TrimSpace(v.Title)
+	if v.Company != "" {
+		name += " - " + strings.TrimSpace(v.Company)
+	}
+	name = strings.TrimSpace(unsafeFileNameChars.ReplaceAllString(name, "_"))
+	if name == "" {
+		name = "vacancy"
+	}
+	return name + sharedVacancyExt
+}
+
+// writeSharedVacancy сохраняет вакансию в файл .vacancy
+func writeSharedVacancy(path string, v Vacancy) error {
+	data, err := json.MarshalIndent(SharedVacancy{Format: sharedVacancyFormat, Version: sharedVacancyVersion, Vacancy: v}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// readSharedVacancy читает вакансию из файла .vacancy
+func readSharedVacancy(path string) (Vacancy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Vacancy{}, err
+	}
+	var shared SharedVacancy
+	if err := json.Unmarshal(data, &shared); err != nil {
+		return Vacancy{}, fmt.Errorf("файл повреждён: %w", err)
+	}
+	if shared.Format != sharedVacancyFormat {
+		return Vacancy{}, errors.New("это не файл вакансии")
+	}
+	if shared.Version > sharedVacancyVersion {
+		return Vacancy{}, fmt.Errorf("файл создан более новой версией приложения (версия %d)", shared.Version)
+	}
+	if strings.TrimSpace(shared.Vacancy.Title) == "" {
+		return Vacancy{}, errors.New("в файле нет названия вакансии")
+	}
+	v := shared.Vacancy
+	v.Status = possibleStatuses[0]
+	v.StatusHistory = nil
+	v.ResumePath, v.ResumeFileName = "", ""
+	v.Attachments = nil
+	v.Journal = nil
+	return v, nil
+}
+
+// shareSelectedVacancy экспортирует выбранную вакансию в файл .vacancy
+func (app *AppMainWindow) shareSelectedVacancy() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		walk.MsgBox(app.MainWindow, "Поделиться вакансией", "Пожалуйста, выберите вакансию.", walk.MsgBoxIconInformation)
+		return
+	}
+	v, _ := app.vacancyAt(idx)
+
+	var dlg *walk.Dialog
+	var descCB, notesCB *walk.CheckBox
+	var withDescription, withNotes, accepted bool
+	if _, err := (Dialog{
+		AssignTo:   &dlg,
+		Title:      "Поделиться вакансией",
+		MinSize:    Size{Width: 360, Height: 170},
+		Layout:     VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Файл будет содержать название, компанию, ссылку, зарплату и ключевые слова.", TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			CheckBox{AssignTo: &descCB, Text: "Включить описание", Checked: true},
+			CheckBox{AssignTo: &notesCB, Text: "Включить мои заметки", Checked: false},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						Text:       "Сохранить...",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							withDescription, withNotes, accepted = descCB.Checked(), notesCB.Checked(), true
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	if !accepted {
+		return
+	}
+
+	fd := new(walk.FileDialog)
+	fd.Title = "Сохранить вакансию"
+	fd.Filter = "Вакансия (*.vacancy)|*.vacancy"
+	fd.FilePath = sharedVacancyFileName(v)
+	ok, err := fd.ShowSave(app.MainWindow)
+	if err != nil || !ok {
+		return
+	}
+	path := fd.FilePath
+	if !strings.EqualFold(filepath.Ext(path), sharedVacancyExt) {
+		path += sharedVacancyExt
+	}
+	if err := writeSharedVacancy(path, shareableVacancy(v, withDescription, withNotes)); err != nil {
+		log.Printf("Ошибка экспорта вакансии: %v", err)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось сохранить файл", err))
+		return
+	}
+	app.notifyFileSaved("Вакансия сохранена в файл", path)
+}
+
+// importSharedVacancyFile открывает файл .vacancy в диалоге добавления для проверки перед сохранением
+func (app *AppMainWindow) importSharedVacancyFile(path string) {
+	v, err := readSharedVacancy(path)
+	if err != nil {
+		log.Printf("Ошибка импорта вакансии из %s: %v", path, err)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось открыть файл вакансии", err))
+		return
+	}
+	app.openImportedVacancy(v)
+}
+
+// importSharedVacancy предлагает выбрать файл .vacancy для импорта
+func (app *AppMainWindow) importSharedVacancy() {
+	fd := new(walk.FileDialog)
+	fd.Title = "Импорт вакансии"
+	fd.Filter = "Вакансия (*.vacancy)|*.vacancy"
+	if ok, err := fd.ShowOpen(app.MainWindow); err == nil && ok {
+		app.importSharedVacancyFile(fd.FilePath)
+	}
+}
+
+// handleDroppedFiles разбирает перетащенные в окно файлы: вакансии и сохранённые страницы вакансий
+// импортируются, письма .msg и .eml попадают в журнал, остальные файлы прикрепляются к выбранной вакансии как резюме
+func (app *AppMainWindow) handleDroppedFiles(files []string) {
+	var others, mails []string
+	for _, f := range files {
+		switch strings.ToLower(filepath.Ext(f)) {
+		case sharedVacancyExt:
+			app.importSharedVacancyFile(f)
+		case ".html", ".htm":
+			app.importPostingPageFile(f)
+		case ".msg", ".eml":
+			mails = append(mails, f)
+		default:
+			others = append(others, f)
+		}
+	}
+	if len(mails) > 0 {
+		app.linkMailFiles(mails)
+	}
+	if len(others) > 0 {
+		app.handleFileDrop(others)
+	}
+}
+
+// registerVacancyFileAssociation связывает расширение .vacancy с приложением для текущего пользователя
+func registerVacancyFileAssociation() error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	set := func(path, name, value string) error {
+		k, _, err := registry.CreateKey(registry.CURRENT_USER, `Software\Classes\`+path, registry.SET_VALUE)
+		if err != nil {
+			return err
+		}
+		defer k.Close()
+		return k.SetStringValue(name, value)
+	}
+	if err := set(sharedVacancyExt, "", sharedVacancyProgID); err != nil {
+		return err
+	}
+	if err := set(sharedVacancyProgID, "", "Вакансия (Поисковик Вакансий)"); err != nil {
+		return err
+	}
+	if err := set(sharedVacancyProgID+`\DefaultIcon`, "", `"`+exe+`",0`); err != nil {
+		return err
+	}
+	return set(sharedVacancyProgID+`\shell\open\command`, "", `"`+exe+`" "%1"`)
+}
+
+// registerFileAssociation регистрирует .vacancy и ссылки vacancy:// по команде из меню
+func (app *AppMainWindow) registerFileAssociation() {
+	if err := registerVacancyFileAssociation(); err != nil {
+		log.Printf("Ошибка регистрации расширения %s: %v", sharedVacancyExt, err)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось связать файлы .vacancy с приложением", err))
+		return
+	}
+	if err := registerVacancyProtocol(); err != nil {
+		log.Printf("Ошибка регистрации ссылок %s://: %v", vacancyProtocol, err)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось связать ссылки vacancy:// с приложением", err))
+		return
+	}
+	app.notify("Файлы .vacancy и ссылки vacancy:// теперь открываются в этом приложении.")
+}
+
+// vacancyFilesFromArgs возвращает файлы .vacancy, переданные в командной строке
+func vacancyFilesFromArgs(args []string) []string {
+	var files []string
+	for _, a := range args {
+		if strings.EqualFold(filepath.Ext(a), sharedVacancyExt) {
+			files = append(files, a)
+		}
+	}
+	return files
+}
@@ -0,0 +1,368 @@
+package main
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Способы защиты соединения с SMTP-сервером
+const (
+	smtpStartTLS = "STARTTLS"
+	smtpTLS      = "SSL/TLS"
+	smtpPlain    = "Без шифрования"
+)
+
+var smtpSecurityModes = []string{smtpStartTLS, smtpTLS, smtpPlain}
+
+// Сколько ждать SMTP-сервер, включая передачу вложений
+const smtpTimeout = 60 * time.Second
+
+// SMTPSettings - почтовый сервер для отправки откликов из приложения
+type SMTPSettings struct {
+	Host     string `json:"host,omitempty"`
+	Port     int    `json:"port,omitempty"`
+	Security string `json:"security,omitempty"` // smtpStartTLS, smtpTLS или smtpPlain
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	From     string `json:"from,omitempty"`      // Адрес отправителя
+	FromName string `json:"from_name,omitempty"` // Имя отправителя; пустое - имя из профиля
+}
+
+// configured - заполнены ли сервер и адрес отправителя
+func (s SMTPSettings) configured() bool {
+	return s.Host != "" && s.From != ""
+}
+
+// dialSMTP подключается к серверу и включает шифрование согласно настройкам
+func dialSMTP(s SMTPSettings) (*smtp.Client, error) {
+	addr := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	var conn net.Conn
+	var err error
+	if s.Security == smtpTLS {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, &tls.Config{ServerName: s.Host})
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, err
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+	c, err := smtp.NewClient(conn, s.Host)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	if s.Security == smtpStartTLS {
+		if ok, _ := c.Extension("STARTTLS"); !ok {
+			c.Close()
+			return nil, errors.New("сервер не поддерживает STARTTLS - выберите SSL/TLS или другой порт")
+		}
+		if err := c.StartTLS(&tls.Config{ServerName: s.Host}); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+	if s.Username != "" {
+		// PlainAuth сам откажется передавать пароль без шифрования
+		if err := c.Auth(smtp.PlainAuth("", s.Username, s.Password, s.Host)); err != nil {
+			c.Close()
+			return nil, fmt.Errorf("ошибка входа: %w", err)
+		}
+	}
+	return c, nil
+}
+
+// testSMTP проверяет подключение и вход, ничего не отправляя
+func testSMTP(s SMTPSettings) error {
+	c, err := dialSMTP(s)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	return c.Quit()
+}
+
+// writeBase64Lines пишет данные в base64 строками по 76 символов
+func writeBase64Lines(w *bytes.Buffer, data []byte) {
+	encoded := base64.StdEncoding.EncodeToString(data)
+	for len(encoded) > 76 {
+		w.WriteString(encoded[:76] + "\r\n")
+		encoded = encoded[76:]
+	}
+	w.WriteString(encoded + "\r\n")
+}
+
+// buildMIMEMessage собирает письмо: текст в quoted-printable и вложения в base64
+func buildMIMEMessage(s SMTPSettings, m recruiterMail) ([]byte, error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+
+	from := mail.Address{Name: firstNonEmpty(s.FromName, appSettings.Profile.Name), Address: s.From}
+	to := mail.Address{Name: m.Name, Address: m.To}
+	host := "localhost"
+	if at := strings.LastIndex(s.From, "@"); at >= 0 {
+		host = s.From[at+1:]
+	}
+	headers := []string{
+		"From: " + from.String(),
+		"To: " + to.String(),
+		"Subject: " + mime.BEncoding.Encode("utf-8", m.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		fmt.Sprintf("Message-ID: <%d.%d@%s>", time.Now().UnixNano(), os.Getpid(), host),
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/mixed; boundary=\"" + mw.Boundary() + "\"",
+	}
+	var msg bytes.Buffer
+	msg.WriteString(strings.Join(headers, "\r\n") + "\r\n\r\n")
+
+	part, err := mw.CreatePart(textproto.MIMEHeader{
+		"Content-Type":              {"text/plain; charset=utf-8"},
+		"Content-Transfer-Encoding": {"quoted-printable"},
+	})
+	if err != nil {
+		return nil, err
+	}
+	qp := quotedprintable.NewWriter(part)
+	if _, err := qp.Write([]byte(m.Body)); err != nil {
+		return nil, err
+	}
+	if err := qp.Close(); err != nil {
+		return nil, err
+	}
+
+	for _, f := range m.Files {
+		data, err := os.ReadFile(f.Path)
+		if err != nil {
+			return nil, fmt.Errorf("не удалось прочитать вложение %s: %w", f.Name, err)
+		}
+		contentType := mime.TypeByExtension(strings.ToLower(filepath.Ext(f.Name)))
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+		part, err := mw.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {contentType},
+			"Content-Disposition":       {mime.FormatMediaType("attachment", map[string]string{"filename": f.Name})},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		var encoded bytes.Buffer
+		writeBase64Lines(&encoded, data)
+		if _, err := part.Write(encoded.Bytes()); err != nil {
+			return nil, err
+		}
+	}
+	if err := mw.Close(); err != nil {
+		return nil, err
+	}
+	msg.Write(buf.Bytes())
+	return msg.Bytes(), nil
+}
+
+// sendSMTP отправляет письмо через настроенный сервер
+func sendSMTP(s SMTPSettings, m recruiterMail) error {
+	msg, err := buildMIMEMessage(s, m)
+	if err != nil {
+		return err
+	}
+	c, err := dialSMTP(s)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+	if err := c.Mail(s.From); err != nil {
+		return err
+	}
+	if err := c.Rcpt(m.To); err != nil {
+		return fmt.Errorf("сервер не принял адрес получателя: %w", err)
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
+
+// sendRecruiterMailSMTP отправляет письмо в фоне и записывает его в журнал вакансии
+func (app *AppMainWindow) sendRecruiterMailSMTP(v Vacancy, m recruiterMail) {
+	s := appSettings.SMTP
+	go func() {
+		err := sendSMTP(s, m)
+		app.MainWindow.Synchronize(func() {
+			if err != nil {
+				log.Printf("SMTP: %v", err)
+				showError(app.MainWindow, "Письмо не отправлено", wrapError("Не удалось отправить письмо на "+m.To, err))
+				return
+			}
+			addJournalEntry(v.Title, v.Company, JournalEntry{At: time.Now(), Kind: journalMail, Text: mailJournalText(m) + " (SMTP)"})
+			app.showToast("Письмо отправлено", "«"+m.Subject+"» → "+m.To, nil)
+		})
+	}()
+}
+
+// showSMTPSettings настраивает почтовый сервер для отправки откликов
+func (app *AppMainWindow) showSMTPSettings() {
+	var dlg *walk.Dialog
+	var hostLE, userLE, passwordLE, fromLE, fromNameLE *walk.LineEdit
+	var portNE *walk.NumberEdit
+	var securityCB *walk.ComboBox
+	var acceptPB, cancelPB *walk.PushButton
+
+	s := appSettings.SMTP
+	if s.Port == 0 {
+		s.Port = 587
+	}
+	security := max(indexOfString(smtpSecurityModes, s.Security), 0)
+	collect := func() SMTPSettings {
+		return SMTPSettings{
+			Host:     strings.TrimSpace(hostLE.Text()),
+			Port:     int(portNE.Value()),
+			Security: smtpSecurityModes[max(securityCB.CurrentIndex(), 0)],
+			Username: strings.TrimSpace(userLE.Text()),
+			Password: passwordLE.Text(),
+			From:     strings.TrimSpace(fromLE.Text()),
+			FromName: strings.TrimSpace(fromNameLE.Text()),
+		}
+	}
+	validate := func(s SMTPSettings) bool {
+		if !s.configured() {
+			walk.MsgBox(dlg, "Настройки SMTP", "Укажите сервер и адрес отправителя.", walk.MsgBoxIconWarning)
+			return false
+		}
+		if _, err := mail.ParseAddress(s.From); err != nil {
+			walk.MsgBox(dlg, "Настройки SMTP", "Некорректный адрес отправителя: "+err.Error(), walk.MsgBoxIconWarning)
+			return false
+		}
+		return true
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Настройки SMTP",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 460, Height: 320},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Почтовый сервер для отправки откликов из приложения:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Сервер:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &hostLE, Text: s.Host, CueBanner: "smtp.example.com"},
+					Label{Text: "Порт:", TextColor: currentTheme.Text},
+					NumberEdit{AssignTo: &portNE, Value: float64(s.Port), MinValue: 1, MaxValue: 65535},
+					Label{Text: "Защита:", TextColor: currentTheme.Text},
+					ComboBox{
+						AssignTo:     &securityCB,
+						Model:        smtpSecurityModes,
+						CurrentIndex: security,
+						OnCurrentIndexChanged: func() {
+							// Подставляем стандартный порт, если пользователь его не менял
+							ports := map[string]float64{smtpStartTLS: 587, smtpTLS: 465, smtpPlain: 25}
+							mode := smtpSecurityModes[max(securityCB.CurrentIndex(), 0)]
+							for _, p := range ports {
+								if portNE.Value() == p {
+									portNE.SetValue(ports[mode])
+									break
+								}
+							}
+						},
+					},
+					Label{Text: "Логин:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &userLE, Text: s.Username},
+					Label{Text: "Пароль:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &passwordLE, Text: s.Password, PasswordMode: true},
+					Label{Text: "Адрес отправителя:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &fromLE, Text: s.From, CueBanner: "me@example.com"},
+					Label{Text: "Имя отправителя:", TextColor: currentTheme.Text},
+					LineEdit{AssignTo: &fromNameLE, Text: s.FromName, CueBanner: appSettings.Profile.Name},
+				},
+			},
+			Label{Text: "Для Gmail и Яндекса нужен пароль приложения, а не пароль от почты.", TextColor: currentTheme.Text, Font: Font{PointSize: 8}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Проверить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							s := collect()
+							if !validate(s) {
+								return
+							}
+							dlg.SetEnabled(false)
+							go func() {
+								err := testSMTP(s)
+								dlg.Synchronize(func() {
+									dlg.SetEnabled(true)
+									if err != nil {
+										showError(dlg, "Проверка SMTP", wrapError("Не удалось подключиться", err))
+										return
+									}
+									app.notify("Проверка SMTP: подключение и вход выполнены.")
+								})
+							}()
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							s := collect()
+							if s.Host != "" || s.From != "" {
+								if !validate(s) {
+									return
+								}
+							}
+							appSettings.SMTP = s
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
@@ -0,0 +1,407 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Ускорение запуска: большой vacancies.json загружается под заставкой, панель
+// онлайн-результатов строится только при первом онлайн-поиске, а панель деталей -
+// при первом выборе вакансии.
+
+// С файла такого размера загрузка заметна, и вместо пустого экрана показывается заставка
+const splashMinFileSize = 1 << 20
+
+// loadVacanciesWithSplash загружает вакансии, показывая заставку, если файл большой
+func loadVacanciesWithSplash() {
+	info, err := os.Stat(dataPath(vacanciesFile))
+	if err != nil || info.Size() < splashMinFileSize {
+		loadVacancies()
+		return
+	}
+
+	var dlg *walk.Dialog
+	if err := (Dialog{
+		AssignTo: &dlg,
+		Title:    "Поиск работы",
+		MinSize:  Size{Width: 320, Height: 110},
+		Layout:   VBox{Margins: Margins{Top: 14, Left: 14, Right: 14, Bottom: 14}, Spacing: 10},
+		Children: []Widget{
+			Label{Text: fmt.Sprintf("Загрузка вакансий (%.1f МБ)...", float64(info.Size())/(1<<20)), Font: Font{PointSize: 10}},
+			ProgressBar{MarqueeMode: true, MinSize: Size{Height: 16}},
+		},
+	}).Create(nil); err != nil {
+		log.Print("Dialog error: ", err)
+		loadVacancies()
+		return
+	}
+	loaded := false
+	dlg.Closing().Attach(func(canceled *bool, reason walk.CloseReason) {
+		*canceled = !loaded // Закрыть заставку можно только после загрузки
+	})
+	go func() {
+		loadVacancies()
+		dlg.Synchronize(func() {
+			loaded = true
+			dlg.Accept()
+		})
+	}()
+	dlg.Run()
+}
+
+// ensureDetails строит панель деталей при первом выборе вакансии: до этого группа
+// «Детали вакансии» пустая, и окно со списком появляется быстрее
+func (app *AppMainWindow) ensureDetails() bool {
+	if app.detailsScrollView != nil {
+		return true
+	}
+	if app.detailsGroup == nil {
+		return false
+	}
+	app.detailsGroup.SetSuspended(true)
+	defer app.detailsGroup.SetSuspended(false)
+	builder := NewBuilder(app.detailsGroup)
+	for _, w := range app.detailsWidgets() {
+		if err := w.Create(builder); err != nil {
+			log.Printf("Ошибка создания панели деталей: %v", err)
+			return false
+		}
+	}
+	app.detailKeywordsAC.Attach(app.detailKeywordsLE)
+	app.saveVacancyChangesPB.Clicked().Attach(func() { recordFeatureUse(app.saveVacancyChangesPB.Text()) })
+	app.attachEditorZoomHandlers()
+	app.DetailsVM.applyTheme(currentTheme)
+	app.applyFonts()
+	return true
+}
+
+// detailsWidgets - содержимое панели деталей
+func (app *AppMainWindow) detailsWidgets() []Widget {
+	return []Widget{
+		ScrollView{
+			AssignTo:      &app.detailsScrollView,
+			Layout:        VBox{Margins: Margins{Left: 9, Top: 9, Right: 9, Bottom: 9}, Spacing: 6},
+			StretchFactor: 1,
+			DataBinder: DataBinder{
+				AssignTo:   &app.detailsBinder,
+				DataSource: app.detailsForm,
+			},
+			Children: []Widget{
+				Label{AssignTo: &app.detailMatchesLabel, Visible: false, Font: Font{PointSize: 9}, TextColor: walk.RGB(150, 100, 0)},
+				Label{AssignTo: &app.detailTitleLabel, Text: "Название:", Font: Font{Bold: true, PointSize: 9}},
+				Label{AssignTo: &app.detailTitleDisplay, Text: Bind("Title"), Font: Font{PointSize: 10, Bold: true}, TextColor: walk.RGB(0, 0, 100)},
+				Label{AssignTo: &app.detailCompanyLabel, Text: "Компания:", Font: Font{Bold: true, PointSize: 9}},
+				Label{AssignTo: &app.detailCompanyDisplay, Text: Bind("Company"), Font: Font{PointSize: 9}},
+				Label{AssignTo: &app.detailStatusLabel, Text: "Статус:", Font: Font{Bold: true, PointSize: 9}},
+				ComboBox{AssignTo: &app.detailStatusCB, Model: possibleStatuses, Value: Bind("Status"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+				Composite{
+					AssignTo: &app.followUpBar,
+					Visible:  false,
+					Layout:   HBox{MarginsZero: true, Spacing: 5},
+					Children: []Widget{
+						Label{AssignTo: &app.followUpLabel, Font: Font{PointSize: 9}, TextColor: walk.RGB(170, 90, 0)},
+						HSpacer{},
+						PushButton{Text: "Отметить follow-up отправленным", OnClicked: app.markSelectedFollowUpSent, Font: Font{Family: "Segoe UI", PointSize: 9}},
+					},
+				},
+				Label{AssignTo: &app.detailExperienceLabel, Text: "Уровень опыта:", Font: Font{Bold: true, PointSize: 9}},
+				ComboBox{AssignTo: &app.detailExperienceCB, Model: possibleExperienceLevels, Value: Bind("ExperienceLevel"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+				Label{AssignTo: &app.detailKeywordsLabel, Text: "Ключевые слова (через запятую):", Font: Font{Bold: true, PointSize: 9}},
+				LineEdit{AssignTo: &app.detailKeywordsLE, Text: Bind("Keywords"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+				app.detailKeywordsAC.Widget(),
+				Label{AssignTo: &app.detailSourceURLLabel, Text: "URL Источника:", Font: Font{Bold: true, PointSize: 9}},
+				Composite{
+					Layout: HBox{MarginsZero: true, Spacing: 5},
+					Children: []Widget{
+						LineEdit{AssignTo: &app.detailSourceURLLE, Text: Bind("SourceURL"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+						PushButton{
+							AssignTo:  &app.checkPostingPB,
+							Text:      "Проверить обновления",
+							Enabled:   false,
+							OnClicked: app.checkPostingUpdates,
+							Font:      Font{Family: "Segoe UI", PointSize: 9},
+						},
+						PushButton{
+							AssignTo:    &app.showQRPB,
+							Text:        "Показать QR",
+							ToolTipText: "Открыть вакансию на телефоне",
+							Enabled:     false,
+							OnClicked:   app.showQRForSelected,
+							Font:        Font{Family: "Segoe UI", PointSize: 9},
+						},
+					},
+				},
+				Label{AssignTo: &app.detailSalaryLabel, Text: "Зарплата:", Font: Font{Bold: true, PointSize: 9}},
+				LineEdit{AssignTo: &app.detailSalaryLE, Text: Bind("Salary"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+				Label{AssignTo: &app.detailDescriptionLabel, Text: "Описание:", Font: Font{Bold: true, PointSize: 9}},
+				RichText{
+					AssignTo:      &app.detailDescriptionTE,
+					Text:          Bind("Description"),
+					Enabled:       Bind("Editable"),
+					MinSize:       Size{Height: 100},
+					MaxSize:       Size{Height: 300},
+					StretchFactor: 2,
+					Font:          Font{PointSize: 9},
+				},
+				Composite{
+					Layout: HBox{MarginsZero: true, Spacing: 3},
+					Children: []Widget{
+						Label{AssignTo: &app.detailNotesLabel, Text: "Заметки:", Font: Font{Bold: true, PointSize: 9}},
+						HSpacer{},
+						PushButton{Text: "Ж", ToolTipText: "Жирный (Ctrl+B)", MaxSize: Size{Width: 28}, Font: Font{Family: "Segoe UI", PointSize: 9, Bold: true}, OnClicked: func() { app.detailNotesTE.ToggleBold() }},
+						PushButton{Text: "К", ToolTipText: "Курсив (Ctrl+I)", MaxSize: Size{Width: 28}, Font: Font{Family: "Segoe UI", PointSize: 9, Italic: true}, OnClicked: func() { app.detailNotesTE.ToggleItalic() }},
+						PushButton{Text: "• Список", ToolTipText: "Маркированный список", Font: Font{Family: "Segoe UI", PointSize: 9}, OnClicked: func() { app.detailNotesTE.ToggleBullets() }},
+					},
+				},
+				RichText{AssignTo: &app.detailNotesTE, Markdown: Bind("Notes"), Enabled: Bind("Editable"), MinSize: Size{0, 80}, Font: Font{PointSize: 9}},
+				Label{AssignTo: &app.detailResumeLabel, Text: "Резюме:", Font: Font{Bold: true, PointSize: 9}},
+				Composite{
+					AssignTo:   &app.detailResumeDropArea,
+					Layout:     HBox{Margins: Margins{Top: 2, Bottom: 2}, Spacing: 5},
+					MinSize:    Size{Height: 40},
+					Background: SolidColorBrush{Color: walk.RGB(240, 240, 240)},
+					Children: []Widget{
+						Label{
+							AssignTo:      &app.detailResumeDisplay,
+							Text:          "Нажмите 'Выбрать' для добавления резюме",
+							TextAlignment: AlignCenter,
+							MinSize:       Size{Width: 200},
+						},
+						HSpacer{},
+						PushButton{
+							AssignTo:  &app.detailResumeOpenBtn,
+							Text:      "Открыть",
+							Enabled:   false,
+							MaxSize:   Size{Width: 70},
+							OnClicked: app.openResume,
+							Font:      Font{Family: "Segoe UI", PointSize: 9},
+						},
+						PushButton{
+							Text:      "Выбрать",
+							MaxSize:   Size{Width: 70},
+							OnClicked: app.selectResume,
+							Font:      Font{Family: "Segoe UI", PointSize: 9},
+						},
+						PushButton{
+							AssignTo:  &app.detailResumeClearBtn,
+							Text:      "×",
+							Enabled:   false,
+							MaxSize:   Size{Width: 25},
+							OnClicked: app.clearResume,
+							Font:      Font{Family: "Segoe UI", PointSize: 9, Bold: true},
+						},
+					},
+				},
+				Label{AssignTo: &app.detailRelatedLabel, Text: "Связанные вакансии:", Font: Font{Bold: true, PointSize: 9}},
+				ListBox{
+					AssignTo:        &app.detailRelatedLB,
+					MinSize:         Size{Height: 50},
+					MaxSize:         Size{Height: 90},
+					Font:            Font{PointSize: 9},
+					OnItemActivated: app.openRelatedVacancy,
+				},
+				Composite{
+					Layout: HBox{MarginsZero: true, Spacing: 5},
+					Children: []Widget{
+						PushButton{Text: "Связать...", OnClicked: app.linkSelectedVacancy, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						PushButton{Text: "Перейти", OnClicked: app.openRelatedVacancy, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						PushButton{Text: "Убрать связь", OnClicked: app.unlinkRelatedVacancy, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						HSpacer{},
+					},
+				},
+				Label{Text: "Тестовое задание:", Font: Font{Bold: true, PointSize: 9}},
+				Label{AssignTo: &app.detailTestTaskLabel, Font: Font{PointSize: 9}},
+				Composite{
+					Layout: HBox{MarginsZero: true, Spacing: 5},
+					Children: []Widget{
+						PushButton{AssignTo: &app.editTestTaskPB, Text: "Добавить...", OnClicked: app.editSelectedTestTask, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						PushButton{AssignTo: &app.submitTestTaskPB, Text: "Сдано", OnClicked: app.submitSelectedTestTask, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						PushButton{AssignTo: &app.openTestTaskPB, Text: "Открыть ссылку", OnClicked: app.openSelectedTestTaskLink, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						HSpacer{},
+						PushButton{Text: "Вопросы с собеседований...", OnClicked: app.showQuestionBankForSelected, Font: Font{Family: "Segoe UI", PointSize: 9}},
+					},
+				},
+				Composite{
+					Layout: HBox{MarginsZero: true, Spacing: 5},
+					Children: []Widget{
+						Label{AssignTo: &app.attachmentsLabel, Text: "Вложения:", Font: Font{Bold: true, PointSize: 9}},
+						HSpacer{},
+						PushButton{Text: "Прикрепить...", OnClicked: app.addAttachmentFiles, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						PushButton{Text: "Вставить из буфера", ToolTipText: "Ctrl+Shift+V", OnClicked: app.pasteAttachment, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						PushButton{Text: "Резюме...", ToolTipText: "Создать резюме под эту вакансию", OnClicked: app.showResumeForSelected, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						PushButton{Text: "Письмо...", ToolTipText: "Создать сопроводительное письмо .docx", OnClicked: app.showCoverLetterForSelected, Font: Font{Family: "Segoe UI", PointSize: 9}},
+					},
+				},
+				ScrollView{
+					AssignTo:      &app.attachmentsStrip,
+					Layout:        HBox{MarginsZero: true, Spacing: 6},
+					VerticalFixed: true,
+					MinSize:       Size{Height: thumbHeight + 20},
+					MaxSize:       Size{Height: thumbHeight + 20},
+				},
+				Label{Text: "Напоминания:", Font: Font{Bold: true, PointSize: 9}},
+				ListBox{
+					AssignTo: &app.detailRemindersLB,
+					MinSize:  Size{Height: 40},
+					MaxSize:  Size{Height: 80},
+					Font:     Font{PointSize: 9},
+				},
+				Composite{
+					Layout: HBox{MarginsZero: true, Spacing: 5},
+					Children: []Widget{
+						PushButton{Text: "Напомнить...", OnClicked: app.addReminderToSelected, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						PushButton{Text: "Выполнено", OnClicked: app.completeDetailReminder, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						PushButton{Text: "Отложить до завтра", OnClicked: app.snoozeDetailReminder, Font: Font{Family: "Segoe UI", PointSize: 9}},
+						HSpacer{},
+					},
+				},
+				Composite{
+					Layout: HBox{MarginsZero: true, Spacing: 5},
+					Children: []Widget{
+						Label{Text: "Журнал:", Font: Font{Bold: true, PointSize: 9}},
+						HSpacer{},
+						PushButton{Text: "Письма из Outlook", OnClicked: app.linkOutlookSelection, Font: Font{Family: "Segoe UI", PointSize: 9}, ToolTipText: "Добавить в журнал письма, выделенные в Outlook. Письма можно и перетащить на вакансию"},
+						PushButton{Text: "Встреча в Outlook", OnClicked: app.outlookAppointmentForSelected, Font: Font{Family: "Segoe UI", PointSize: 9}, ToolTipText: "Создать или обновить встречу Outlook для собеседования"},
+						PushButton{Text: "Написать рекрутеру...", OnClicked: app.mailRecruiterForSelected, Font: Font{Family: "Segoe UI", PointSize: 9}},
+					},
+				},
+				ListBox{
+					AssignTo: &app.detailJournalLB,
+					MinSize:  Size{Height: 40},
+					MaxSize:  Size{Height: 80},
+					Font:     Font{PointSize: 9},
+				},
+				PushButton{
+					AssignTo:   &app.saveVacancyChangesPB,
+					Text:       "Сохранить изменения вакансии",
+					Enabled:    Bind("Editable"),
+					OnClicked:  app.saveVacancyDetails,
+					Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+					Background: SolidColorBrush{Color: walk.RGB(220, 255, 220)},
+				},
+			},
+		},
+	}
+}
+
+// ensureOnlineResults строит панель онлайн-результатов при первом обращении
+func (app *AppMainWindow) ensureOnlineResults() bool {
+	if app.onlineResultsTable != nil {
+		return true
+	}
+	if app.onlineResultsContainer == nil {
+		return false
+	}
+	app.onlineResultsContainer.SetSuspended(true)
+	defer app.onlineResultsContainer.SetSuspended(false)
+	builder := NewBuilder(app.onlineResultsContainer)
+	for _, w := range app.onlineResultsWidgets() {
+		if err := w.Create(builder); err != nil {
+			log.Printf("Ошибка создания панели онлайн-результатов: %v", err)
+			return false
+		}
+	}
+	app.OnlineSearchVM.applyTheme(currentTheme)
+	return true
+}
+
+// onlineResultsWidgets - содержимое панели онлайн-результатов
+func (app *AppMainWindow) onlineResultsWidgets() []Widget {
+	return []Widget{
+		Composite{
+			Layout: HBox{MarginsZero: true, Spacing: 8},
+			Children: []Widget{
+				Label{
+					AssignTo: &app.onlineResultsLabel,
+					Text:     "Результаты онлайн-поиска:",
+					Font:     Font{Bold: true, PointSize: 10},
+				},
+				HSpacer{},
+				LineEdit{
+					AssignTo:      &app.onlineFilterLE,
+					CueBanner:     "Фильтр по результатам...",
+					MinSize:       Size{Width: 200},
+					Font:          Font{PointSize: 9},
+					OnTextChanged: app.applyOnlineFilter,
+				},
+				PushButton{
+					AssignTo:   &app.cancelOnlineSearchButton,
+					Text:       "Отменить поиск",
+					Visible:    false,
+					Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+					Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+				},
+				PushButton{
+					AssignTo:   &app.backToLocalButton,
+					Text:       "<< Назад к локальному списку",
+					Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+					Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+					OnClicked:  app.switchToLocalMode,
+				},
+			},
+		},
+		Composite{
+			Layout: HBox{MarginsZero: true, Spacing: 8},
+			Children: []Widget{
+				ProgressBar{AssignTo: &app.onlineProgressPB, MaxSize: Size{Width: 160, Height: 14}, Visible: false},
+				Label{AssignTo: &app.onlineSourcesLabel, Font: Font{PointSize: 8}},
+				HSpacer{},
+			},
+		},
+		HSplitter{
+			StretchFactor: 1,
+			Children: []Widget{
+				TableView{
+					AssignTo: &app.onlineResultsTable,
+					Model:    app.onlineVacancyModel,
+					Columns: []TableViewColumn{
+						{Title: "Название", Width: 220},
+						{Title: "Компания", Width: 160},
+						{Title: "Зарплата", Width: 130},
+						{Title: "Город", Width: 110},
+						{Title: "Опубликовано", Width: 90},
+						{Title: "Источник", Width: 180},
+						{Title: "Совпадение", Width: 90},
+					},
+					StretchFactor:         2,
+					OnCurrentIndexChanged: app.updateOnlinePreview,
+					OnItemActivated: func() {
+						idx := app.onlineResultsTable.CurrentIndex()
+						if idx >= 0 && idx < len(app.onlineVacancyModel.items) {
+							selectedOnlineVacancy := app.onlineVacancyModel.items[idx]
+							vacancyCopy := selectedOnlineVacancy
+							if showVacancyDialogExt(app, &vacancyCopy, false, true) {
+								app.onlineVacancyModel.Remove(selectedOnlineVacancy)
+								app.applyOnlineFilter()
+							}
+						}
+					},
+				},
+				app.onlinePreviewPane(),
+			},
+		},
+		PushButton{
+			AssignTo:   &app.addOnlineVacancyButton,
+			Text:       "Добавить выбранное в локальный список",
+			Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+			Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+			OnClicked: func() {
+				idx := app.onlineResultsTable.CurrentIndex()
+				if idx < 0 || idx >= len(app.onlineVacancyModel.items) {
+					walk.MsgBox(app.MainWindow, "Подсказка", "Пожалуйста, сначала выберите вакансию из списка выше.", walk.MsgBoxIconInformation)
+					return
+				}
+				selectedOnlineVacancy := app.onlineVacancyModel.items[idx]
+				vacancyCopy := selectedOnlineVacancy
+				if showVacancyDialogExt(app, &vacancyCopy, false, true) {
+					app.onlineVacancyModel.Remove(selectedOnlineVacancy)
+					app.applyOnlineFilter()
+				}
+			},
+		},
+	}
+}
@@ -0,0 +1,364 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Этапы воронки: вакансия засчитывается на этапе, если дошла до него или дальше
+var funnelStageDefs = []struct {
+	Label    string
+	Statuses []string
+}{
+	{Label: "Отклики"},
+	{Label: "Ответы", Statuses: responseStatuses},
+	{Label: "Тестовые", Statuses: []string{"Тестовое задание", "Собеседование", "Оффер"}},
+	{Label: "Собеседования", Statuses: []string{"Собеседование", "Оффер"}},
+	{Label: "Офферы", Statuses: []string{"Оффер"}},
+}
+
+// FunnelStage - один этап воронки с количеством вакансий
+type FunnelStage struct {
+	Label string
+	Count int
+}
+
+// buildFunnel строит воронку по откликам, отправленным в интервале [from, to)
+func buildFunnel(vacancies []Vacancy, from, to time.Time) []FunnelStage {
+	stages := make([]FunnelStage, len(funnelStageDefs))
+	for i, def := range funnelStageDefs {
+		stages[i].Label = def.Label
+	}
+	for _, v := range vacancies {
+		if !inRange(v.AppliedAt, from, to) {
+			continue
+		}
+		stages[0].Count++
+		for i, def := range funnelStageDefs[1:] {
+			if everReached(v, def.Statuses...) {
+				stages[i+1].Count++
+			}
+		}
+	}
+	return stages
+}
+
+// percent возвращает долю part от whole в процентах
+func percent(part, whole int) float64 {
+	if whole == 0 {
+		return 0
+	}
+	return float64(part) * 100 / float64(whole)
+}
+
+// StatisticsDialog - окно статистики по поиску работы
+type StatisticsDialog struct {
+	*walk.Dialog
+	owner        *AppMainWindow
+	vacancies    []Vacancy
+	fromDE       *walk.DateEdit
+	toDE         *walk.DateEdit
+	funnelView   *walk.CustomWidget
+	funnelLabel  *walk.Label
+	funnelStages []FunnelStage
+
+	salaryGroupCB *walk.ComboBox
+	salaryModel   *SalaryStatsModel
+	salaryLabel   *walk.Label
+
+	rejectionView  *walk.CustomWidget
+	rejectionLabel *walk.Label
+	rejectionBars  []ReportBar
+}
+
+// showStatistics открывает окно статистики
+func (app *AppMainWindow) showStatistics() {
+	allVacanciesMutex.Lock()
+	vacancies := make([]Vacancy, len(allVacancies))
+	copy(vacancies, allVacancies)
+	allVacanciesMutex.Unlock()
+
+	dlg := &StatisticsDialog{
+		owner:         app,
+		vacancies:     vacancies,
+		salaryModel:   &SalaryStatsModel{},
+		rejectionBars: buildRejectionBreakdown(vacancies),
+	}
+	from, _ := reportRange("За месяц", time.Now())
+
+	if err := (Dialog{
+		AssignTo:   &dlg.Dialog,
+		Title:      "Статистика",
+		MinSize:    Size{Width: 640, Height: 480},
+		Layout:     VBox{},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			TabWidget{
+				Pages: []TabPage{
+					{
+						Title:      "Воронка",
+						Layout:     VBox{},
+						Background: SolidColorBrush{Color: currentTheme.Background},
+						Children: []Widget{
+							Composite{
+								Layout: HBox{MarginsZero: true, Spacing: 8},
+								Children: []Widget{
+									Label{Text: "Отклики с:", TextColor: currentTheme.Text},
+									DateEdit{AssignTo: &dlg.fromDE, Date: from, OnDateChanged: dlg.updateFunnel},
+									Label{Text: "по:", TextColor: currentTheme.Text},
+									DateEdit{AssignTo: &dlg.toDE, Date: time.Now(), OnDateChanged: dlg.updateFunnel},
+									HSpacer{},
+								},
+							},
+							Label{AssignTo: &dlg.funnelLabel, Font: Font{PointSize: 9}, TextColor: currentTheme.Text},
+							CustomWidget{
+								AssignTo:            &dlg.funnelView,
+								MinSize:             Size{Height: 260},
+								StretchFactor:       1,
+								ClearsBackground:    true,
+								InvalidatesOnResize: true,
+								Paint:               dlg.paintFunnel,
+							},
+						},
+					},
+					{
+						Title:      "Зарплаты",
+						Layout:     VBox{},
+						Background: SolidColorBrush{Color: currentTheme.Background},
+						Children: []Widget{
+							Composite{
+								Layout: HBox{MarginsZero: true, Spacing: 8},
+								Children: []Widget{
+									Label{Text: "Группировать:", TextColor: currentTheme.Text},
+									ComboBox{
+										AssignTo:              &dlg.salaryGroupCB,
+										Model:                 salaryGroupings,
+										CurrentIndex:          0,
+										OnCurrentIndexChanged: dlg.updateSalaryStats,
+									},
+									HSpacer{},
+								},
+							},
+							Label{AssignTo: &dlg.salaryLabel, Text: salarySummary(vacancies), Font: Font{PointSize: 9}, TextColor: currentTheme.Text},
+							TableView{
+								Model:      dlg.salaryModel,
+								Background: SolidColorBrush{Color: currentTheme.TableBG},
+								Columns: []TableViewColumn{
+									{Title: "Группа", Width: 180},
+									{Title: "Валюта", Width: 60},
+									{Title: "Вакансий", Width: 70},
+									{Title: "Мин.", Width: 90, Alignment: AlignFar},
+									{Title: "Медиана", Width: 90, Alignment: AlignFar},
+									{Title: "Макс.", Width: 90, Alignment: AlignFar},
+								},
+								StretchFactor: 1,
+							},
+						},
+					},
+					{
+						Title:      "Отказы",
+						Layout:     VBox{},
+						Background: SolidColorBrush{Color: currentTheme.Background},
+						Children: []Widget{
+							Label{AssignTo: &dlg.rejectionLabel, Text: rejectionSummary(dlg.rejectionBars), Font: Font{PointSize: 9}, TextColor: currentTheme.Text},
+							CustomWidget{
+								AssignTo:            &dlg.rejectionView,
+								MinSize:             Size{Height: 200},
+								StretchFactor:       1,
+								ClearsBackground:    true,
+								InvalidatesOnResize: true,
+								Paint: func(canvas *walk.Canvas, updateBounds walk.Rectangle) error {
+									return paintHorizontalBars(canvas, dlg.rejectionView.ClientBounds(), dlg.rejectionBars)
+								},
+							},
+						},
+					},
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked:  func() { dlg.Accept() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	dlg.updateFunnel()
+	dlg.updateSalaryStats()
+	// Пока окно открыто, вакансии могут измениться - статистика пересчитывается по событиям
+	subscription := vacancyEvents.Subscribe(func(VacancyEvent) { dlg.reload() })
+	defer vacancyEvents.Unsubscribe(subscription)
+	dlg.Run()
+}
+
+// reload пересчитывает все вкладки по текущему списку вакансий
+func (d *StatisticsDialog) reload() {
+	d.vacancies = snapshotVacancies()
+	d.rejectionBars = buildRejectionBreakdown(d.vacancies)
+	d.salaryLabel.SetText(salarySummary(d.vacancies))
+	d.rejectionLabel.SetText(rejectionSummary(d.rejectionBars))
+	d.rejectionView.Invalidate()
+	d.updateFunnel()
+	d.updateSalaryStats()
+}
+
+// selectedRange возвращает выбранный в окне интервал дат, включая последний день
+func (d *StatisticsDialog) selectedRange() (time.Time, time.Time) {
+	from, to := d.fromDE.Date(), d.toDE.Date()
+	from = time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.Local)
+	to = time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+	return from, to
+}
+
+// updateFunnel пересчитывает воронку при смене интервала
+func (d *StatisticsDialog) updateFunnel() {
+	if d.fromDE == nil || d.toDE == nil || d.funnelLabel == nil {
+		return
+	}
+	from, to := d.selectedRange()
+	d.funnelStages = buildFunnel(d.vacancies, from, to)
+
+	applied := d.funnelStages[0].Count
+	offers := d.funnelStages[len(d.funnelStages)-1].Count
+	if applied == 0 {
+		d.funnelLabel.SetText("За выбранный период откликов не было.")
+	} else {
+		d.funnelLabel.SetText(fmt.Sprintf("Откликов: %d, дошли до оффера: %d (%.1f%%)", applied, offers, percent(offers, applied)))
+	}
+	if d.funnelView != nil {
+		d.funnelView.Invalidate()
+	}
+}
+
+// paintFunnel рисует воронку: ширина полосы пропорциональна числу вакансий на этапе
+func (d *StatisticsDialog) paintFunnel(canvas *walk.Canvas, updateBounds walk.Rectangle) error {
+	if len(d.funnelStages) == 0 {
+		return nil
+	}
+	bounds := d.funnelView.ClientBounds()
+
+	font, err := walk.NewFont("Segoe UI", 9, 0)
+	if err != nil {
+		return err
+	}
+	defer font.Dispose()
+
+	rowHeight := bounds.Height / len(d.funnelStages)
+	textHeight := 18
+	maxCount := d.funnelStages[0].Count
+	for i, stage := range d.funnelStages {
+		top := bounds.Y + i*rowHeight
+
+		caption := fmt.Sprintf("%s: %d", stage.Label, stage.Count)
+		if i > 0 {
+			caption += fmt.Sprintf("  (%.0f%% от предыдущего этапа, %.0f%% от откликов)",
+				percent(stage.Count, d.funnelStages[i-1].Count), percent(stage.Count, maxCount))
+		}
+		textBounds := walk.Rectangle{X: bounds.X, Y: top, Width: bounds.Width, Height: textHeight}
+		if err := canvas.DrawText(caption, font, currentTheme.Text, textBounds, walk.TextCenter|walk.TextVCenter|walk.TextSingleLine); err != nil {
+			return err
+		}
+
+		width := 2
+		if maxCount > 0 {
+			width = bounds.Width * stage.Count / maxCount
+		}
+		if width < 2 {
+			width = 2
+		}
+		barHeight := rowHeight - textHeight - 6
+		if barHeight < 4 {
+			barHeight = 4
+		}
+		// Чем дальше этап, тем насыщеннее цвет
+		shade := byte(200 - i*35)
+		brush, err := walk.NewSolidColorBrush(walk.RGB(shade/2, shade, 255))
+		if err != nil {
+			return err
+		}
+		barBounds := walk.Rectangle{X: bounds.X + (bounds.Width-width)/2, Y: top + textHeight, Width: width, Height: barHeight}
+		err = canvas.FillRectangle(brush, barBounds)
+		brush.Dispose()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateSalaryStats пересчитывает аналитику зарплат при смене группировки
+func (d *StatisticsDialog) updateSalaryStats() {
+	if d.salaryGroupCB == nil {
+		return
+	}
+	d.salaryModel.items = buildSalaryStats(d.vacancies, d.salaryGroupCB.Text())
+	d.salaryModel.PublishRowsReset()
+}
+
+// rejectionSummary возвращает подпись над диаграммой причин отказов
+func rejectionSummary(bars []ReportBar) string {
+	total := 0
+	for _, b := range bars {
+		total += b.Value
+	}
+	if total == 0 {
+		return "Отказов пока нет."
+	}
+	return fmt.Sprintf("Всего отказов: %d", total)
+}
+
+// paintHorizontalBars рисует горизонтальную диаграмму: подпись слева, полоса и значение справа
+func paintHorizontalBars(canvas *walk.Canvas, bounds walk.Rectangle, bars []ReportBar) error {
+	if len(bars) == 0 {
+		return nil
+	}
+	font, err := walk.NewFont("Segoe UI", 9, 0)
+	if err != nil {
+		return err
+	}
+	defer font.Dispose()
+
+	brush, err := walk.NewSolidColorBrush(walk.RGB(74, 144, 217))
+	if err != nil {
+		return err
+	}
+	defer brush.Dispose()
+
+	const labelWidth, valueWidth, rowHeight = 190, 40, 26
+	barArea := bounds.Width - labelWidth - valueWidth
+	if barArea < 10 {
+		barArea = 10
+	}
+	for i, bar := range bars {
+		top := bounds.Y + i*rowHeight
+		labelBounds := walk.Rectangle{X: bounds.X, Y: top, Width: labelWidth, Height: rowHeight}
+		if err := canvas.DrawText(bar.Label, font, currentTheme.Text, labelBounds, walk.TextLeft|walk.TextVCenter|walk.TextSingleLine); err != nil {
+			return err
+		}
+		width := barArea * bar.Width / 100
+		if width < 2 {
+			width = 2
+		}
+		barBounds := walk.Rectangle{X: bounds.X + labelWidth, Y: top + 5, Width: width, Height: rowHeight - 10}
+		if err := canvas.FillRectangle(brush, barBounds); err != nil {
+			return err
+		}
+		valueBounds := walk.Rectangle{X: barBounds.X + width + 6, Y: top, Width: valueWidth, Height: rowHeight}
+		if err := canvas.DrawText(fmt.Sprint(bar.Value), font, currentTheme.Text, valueBounds, walk.TextLeft|walk.TextVCenter|walk.TextSingleLine); err != nil {
+			return err
+		}
+	}
+	return nil
+}
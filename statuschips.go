@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// statusChipItems строит кнопки-фильтры по статусам для панели над таблицей.
+// Нажатые кнопки складываются: показываются вакансии с любым из выбранных статусов.
+func (app *AppMainWindow) statusChipItems() []MenuItem {
+	app.statusChips = make([]*walk.Action, len(possibleStatuses))
+	items := make([]MenuItem, 0, len(possibleStatuses)+6)
+	for i, status := range possibleStatuses {
+		items = append(items, Action{
+			AssignTo:    &app.statusChips[i],
+			Text:        status,
+			Checkable:   true,
+			OnTriggered: app.performSearch,
+		})
+	}
+	items = append(items,
+		Separator{},
+		Action{AssignTo: &app.clearChipsAction, Text: "✕ Все статусы", Enabled: false, OnTriggered: app.clearStatusChips},
+		Separator{},
+		Action{
+			AssignTo:    &app.showArchiveAction,
+			Text:        "Показывать архив",
+			Checkable:   true,
+			Checked:     appSettings.ShowArchived,
+			OnTriggered: app.toggleShowArchived,
+		},
+		Separator{},
+		Action{
+			AssignTo:    &app.manualOrderAction,
+			Text:        "⇅ Ручной порядок",
+			Checkable:   true,
+			OnTriggered: func() { app.setManualOrder(app.manualOrderAction.Checked()) },
+		},
+	)
+	return items
+}
+
+// selectedChipStatuses - статусы нажатых кнопок; пустое множество - фильтр выключен
+func (app *AppMainWindow) selectedChipStatuses() map[string]bool {
+	selected := map[string]bool{}
+	for i, chip := range app.statusChips {
+		if chip != nil && chip.Checked() {
+			selected[possibleStatuses[i]] = true
+		}
+	}
+	return selected
+}
+
+// filterByStatusChips обновляет счётчики на кнопках по результатам поиска
+// и оставляет только вакансии с выбранными статусами
+func (app *AppMainWindow) filterByStatusChips(items []Vacancy) []Vacancy {
+	counts := map[string]int{}
+	for _, v := range items {
+		counts[v.Status]++
+	}
+	for i, chip := range app.statusChips {
+		if chip != nil {
+			chip.SetText(fmt.Sprintf("%s (%d)", possibleStatuses[i], counts[possibleStatuses[i]]))
+		}
+	}
+
+	selected := app.selectedChipStatuses()
+	if app.clearChipsAction != nil {
+		app.clearChipsAction.SetEnabled(len(selected) > 0)
+	}
+	if len(selected) == 0 {
+		return items
+	}
+	filtered := make([]Vacancy, 0, len(items))
+	for _, v := range items {
+		if selected[v.Status] {
+			filtered = append(filtered, v)
+		}
+	}
+	return filtered
+}
+
+// clearStatusChips отжимает все кнопки статусов
+func (app *AppMainWindow) clearStatusChips() {
+	for _, chip := range app.statusChips {
+		if chip != nil {
+			chip.SetChecked(false)
+		}
+	}
+	app.performSearch()
+}
+
+// setStatusForVacancies меняет статус нескольких вакансий, например перетащенных на кнопку статуса
+func (app *AppMainWindow) setStatusForVacancies(vacancies []Vacancy, status string) {
+	var targets []Vacancy
+	for _, v := range vacancies {
+		if v.Status != status && confirmApplyAfterRejection(app.MainWindow, v, status) {
+			targets = append(targets, v)
+		}
+	}
+	if len(targets) == 0 {
+		return
+	}
+	if len(targets) > 1 && walk.MsgBox(app.MainWindow, "Смена статуса",
+		fmt.Sprintf("Сменить статус %d вакансий на «%s»?", len(targets), status),
+		walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+		return
+	}
+	// Причину отказа спрашиваем один раз для всех вакансий, до блокировки списка
+	var rejectionReason, rejectionComment string
+	rejectionAnswered := false
+	if status == rejectedStatus {
+		title := targets[0].Title
+		if len(targets) > 1 {
+			title += fmt.Sprintf(" (и ещё %d)", len(targets)-1)
+		}
+		rejectionReason, rejectionComment, rejectionAnswered = promptRejectionReason(app.MainWindow, title)
+	}
+
+	before := snapshotVacancies()
+	var changed []Vacancy
+	allVacanciesMutex.Lock()
+	for _, t := range targets {
+		i := app.findVacancyIndexInAllExt(t.Title, t.Company)
+		if i == -1 || allVacancies[i].Status == status {
+			continue
+		}
+		setVacancyStatus(&allVacancies[i], status)
+		if rejectionAnswered {
+			allVacancies[i].RejectionReason = rejectionReason
+			allVacancies[i].RejectionComment = rejectionComment
+		}
+		changed = append(changed, allVacancies[i])
+	}
+	allVacanciesMutex.Unlock()
+	if len(changed) == 0 {
+		return
+	}
+
+	text := fmt.Sprintf("Статус %d вакансий изменён на «%s»", len(changed), status)
+	e := VacancyEvent{Kind: VacancyUpdated}
+	if len(changed) == 1 {
+		text = fmt.Sprintf("Статус вакансии «%s» изменён на «%s»", changed[0].Title, status)
+		e = vacancyEvent(VacancyUpdated, changed[0])
+	}
+	undo := recordUndo(text, before)
+	saveVacancies()
+	log.Print(text)
+	vacancyEvents.Publish(e)
+	app.showUndoToast(text, undo)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/blevesearch/snowballstem"
+	"github.com/blevesearch/snowballstem/english"
+	"github.com/blevesearch/snowballstem/russian"
+)
+
+// Стемминг для поиска: слова сводятся к основе по Snowball, поэтому «тестирование» находит
+// «тестировании» и «тестировщик». Отдельного индекса нет: основы подставляются при
+// нормализации запроса и текста вакансии (см. normalizeSearchText), а нормализованные
+// тексты кэшируются. Режим «точный поиск» отключает стемминг.
+
+// Короче этого слова не стеммятся: у них нечего отрезать
+const minStemLength = 4
+
+// Словообразовательные суффиксы, которые Snowball оставляет: «тестировщик» и «тестирование»
+// сводятся к «тестиров», «разработчик» - к «разработ», «заказчик» - к «заказ». Суффиксы
+// взяты с соседней буквой, чтобы не резать корни: «диван», «мальчик» остаются как есть.
+var russianDerivationalSuffixes = []struct{ suffix, replace string }{
+	{"щик", ""},
+	{"тчик", "т"},
+	{"дчик", "д"},
+	{"зчик", "з"},
+	{"ован", "ов"},
+	{"еван", "ев"},
+}
+
+// stemWord - основа слова: кириллица - русским стеммером, латиница - английским
+func stemWord(word string) string {
+	if len([]rune(word)) < minStemLength {
+		return word
+	}
+	switch {
+	case isScript(word, unicode.Cyrillic):
+		env := snowballstem.NewEnv(strings.ReplaceAll(word, "ё", "е"))
+		russian.Stem(env)
+		stem := env.Current()
+		for _, s := range russianDerivationalSuffixes {
+			if base, ok := strings.CutSuffix(stem, s.suffix); ok && len([]rune(base)) >= minStemLength-1 {
+				return base + s.replace
+			}
+		}
+		return stem
+	case isScript(word, unicode.Latin):
+		env := snowballstem.NewEnv(word)
+		english.Stem(env)
+		return env.Current()
+	}
+	return word
+}
+
+// isScript проверяет, что слово целиком из букв одного алфавита
+func isScript(word string, script *unicode.RangeTable) bool {
+	for _, r := range word {
+		if !unicode.Is(script, r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Кэш нормализованных текстов: описания вакансий не меняются между поисками, а стемминг
+// каждого слова на каждое нажатие Enter заметен на сотнях вакансий
+const maxNormalizedCache = 5000
+
+type normalizedKey struct {
+	text string
+	stem bool
+}
+
+var (
+	normalizedMutex sync.Mutex
+	normalizedCache = map[normalizedKey]string{}
+)
+
+// cachedNormalized возвращает нормализованный текст из кэша или вычисляет его
+func cachedNormalized(text string, stem bool, normalize func() string) string {
+	key := normalizedKey{text, stem}
+	normalizedMutex.Lock()
+	norm, ok := normalizedCache[key]
+	normalizedMutex.Unlock()
+	if ok {
+		return norm
+	}
+	norm = normalize()
+	normalizedMutex.Lock()
+	if len(normalizedCache) >= maxNormalizedCache {
+		clear(normalizedCache)
+	}
+	normalizedCache[key] = norm
+	normalizedMutex.Unlock()
+	return norm
+}
+
+// resetNormalizedCache сбрасывает кэш, когда меняются правила нормализации (синонимы)
+func resetNormalizedCache() {
+	normalizedMutex.Lock()
+	clear(normalizedCache)
+	normalizedMutex.Unlock()
+}
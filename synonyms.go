@@ -0,0 +1,196 @@
+package main
+
+import (
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Файл синонимов, который пользователь может править и в приложении, и в любом редакторе
+const synonymsFile = "synonyms.txt"
+
+// Содержимое файла синонимов при первом запуске
+const defaultSynonyms = `# Синонимы для поиска и совпадения навыков: слова одной строки считаются одинаковыми.
+# Формат: слово=слово=фраза из слов. Регистр и алфавит (рус/лат) не важны.
+golang=go
+удаленка=удаленно=remote=удаленная работа
+js=javascript
+ts=typescript
+k8s=kubernetes
+postgresql=postgres
+qa=тестировщик
+аналитик=analyst
+`
+
+// synonymTable - синонимы в нормализованном виде: каждое слово и фраза заменяются первым словом своей строки.
+// Таблиц две - для поиска со стеммингом и без, потому что синонимы нормализуются так же, как текст.
+type synonymTable struct {
+	words   map[string]string
+	phrases [][2]string // Фразы из нескольких слов, длинные первыми
+}
+
+var (
+	synonymsMutex  sync.RWMutex
+	synonymsLoaded bool
+	searchSynonyms [2]synonymTable // [0] - точный поиск, [1] - со стеммингом
+)
+
+// parseSynonyms разбирает файл синонимов
+func parseSynonyms(text string, stem bool) synonymTable {
+	t := synonymTable{words: map[string]string{}}
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		var members []string
+		for _, m := range strings.Split(line, "=") {
+			if norm := transliterateWords(m, stem); norm != "" {
+				members = append(members, norm)
+			}
+		}
+		if len(members) < 2 {
+			continue
+		}
+		canonical := members[0]
+		for _, m := range members[1:] {
+			if m == canonical {
+				continue
+			}
+			if strings.Contains(m, " ") {
+				t.phrases = append(t.phrases, [2]string{m, canonical})
+			} else {
+				t.words[m] = canonical
+			}
+		}
+	}
+	sort.SliceStable(t.phrases, func(i, j int) bool { return len(t.phrases[i][0]) > len(t.phrases[j][0]) })
+	return t
+}
+
+// apply заменяет в нормализованном тексте синонимы каноническими словами
+func (t synonymTable) apply(norm string) string {
+	if len(t.words) == 0 && len(t.phrases) == 0 {
+		return norm
+	}
+	padded := " " + norm + " "
+	for _, p := range t.phrases {
+		padded = strings.ReplaceAll(padded, " "+p[0]+" ", " "+p[1]+" ")
+	}
+	words := strings.Fields(padded)
+	for i, w := range words {
+		if canonical, ok := t.words[w]; ok {
+			words[i] = canonical
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// readSynonymsFile читает файл синонимов; при первом запуске создаёт его с примерами
+func readSynonymsFile() string {
+	data, err := os.ReadFile(dataPath(synonymsFile))
+	if os.IsNotExist(err) {
+		if err := os.WriteFile(dataPath(synonymsFile), []byte(defaultSynonyms), 0644); err != nil {
+			log.Printf("Ошибка записи %s: %v", synonymsFile, err)
+		}
+		return defaultSynonyms
+	}
+	if err != nil {
+		log.Printf("Ошибка чтения %s: %v", synonymsFile, err)
+	}
+	return string(data)
+}
+
+// currentSynonyms - синонимы для поиска; файл читается при первом обращении
+func currentSynonyms(stem bool) synonymTable {
+	synonymsMutex.RLock()
+	tables, loaded := searchSynonyms, synonymsLoaded
+	synonymsMutex.RUnlock()
+	if !loaded {
+		tables = reloadSynonyms()
+	}
+	if stem {
+		return tables[1]
+	}
+	return tables[0]
+}
+
+// reloadSynonyms перечитывает файл синонимов
+func reloadSynonyms() [2]synonymTable {
+	text := readSynonymsFile()
+	tables := [2]synonymTable{parseSynonyms(text, false), parseSynonyms(text, true)}
+	synonymsMutex.Lock()
+	searchSynonyms, synonymsLoaded = tables, true
+	synonymsMutex.Unlock()
+	resetNormalizedCache()
+	return tables
+}
+
+// showSynonymsDialog редактирует файл синонимов
+func (app *AppMainWindow) showSynonymsDialog() {
+	var dlg *walk.Dialog
+	var synonymsTE *walk.TextEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Синонимы поиска",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 480, Height: 420},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Слова одной строки считаются одинаковыми в поиске и при подсчёте совпадения с профилем.\nНапример: удаленка=remote=удаленная работа",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			TextEdit{
+				AssignTo: &synonymsTE,
+				Text:     strings.ReplaceAll(strings.ReplaceAll(readSynonymsFile(), "\r\n", "\n"), "\n", "\r\n"),
+				VScroll:  true,
+				Font:     Font{Family: "Consolas", PointSize: 10},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					Label{Text: "Файл: " + dataPath(synonymsFile), TextColor: currentTheme.Text, Font: Font{PointSize: 8}},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							text := strings.ReplaceAll(synonymsTE.Text(), "\r\n", "\n")
+							if err := os.WriteFile(dataPath(synonymsFile), []byte(text), 0644); err != nil {
+								showError(dlg, "Ошибка", wrapError("Не удалось сохранить синонимы", err))
+								return
+							}
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	// Файл могли изменить и в другом редакторе, поэтому перечитываем его и после отмены
+	reloadSynonyms()
+	app.performSearch()
+	app.onlineVacancyModel.PublishRowsReset()
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"log"
+	"slices"
+
+	"github.com/lxn/walk"
+)
+
+// Вкладки над таблицей: у каждой свой поиск, нажатые кнопки статусов, показ архива
+// и сортировка. Переключение вкладки восстанавливает её фильтры, а изменения фильтров
+// запоминаются в текущей вкладке. Вкладки сохраняются в настройках.
+
+// FilterTab - вкладка с состоянием фильтров таблицы
+type FilterTab struct {
+	Name         string    `json:"name"`
+	Field        int       `json:"field,omitempty"`      // Индекс в searchFields
+	Term         string    `json:"term,omitempty"`       // Текст поиска
+	Status       string    `json:"status,omitempty"`     // Поиск "По статусу"
+	Experience   string    `json:"experience,omitempty"` // Поиск "По опыту"
+	Chips        []string  `json:"chips,omitempty"`      // Нажатые кнопки статусов
+	ShowArchived bool      `json:"show_archived,omitempty"`
+	SortKeys     []SortKey `json:"sort_keys,omitempty"`
+}
+
+// defaultFilterTabs - вкладки при первом запуске
+func defaultFilterTabs() []FilterTab {
+	return []FilterTab{
+		{Name: "Активные"},
+		{Name: "Собеседования", Chips: []string{"Тестовое задание", "Собеседование", "Оффер"}},
+		{Name: "Архив", Chips: []string{archivedStatus, rejectedStatus}, ShowArchived: true},
+	}
+}
+
+// captureFilterTab - текущее состояние фильтров таблицы
+func (app *AppMainWindow) captureFilterTab(name string) FilterTab {
+	t := FilterTab{
+		Name:         name,
+		Field:        max(app.searchFieldCB.CurrentIndex(), 0),
+		ShowArchived: appSettings.ShowArchived,
+		SortKeys:     append([]SortKey{}, app.vacancyModel.sortKeys...),
+	}
+	switch searchFields[t.Field] {
+	case "По статусу":
+		t.Status = app.statusFilterCB.Text()
+	case "По опыту":
+		t.Experience = app.experienceFilterCB.Text()
+	default:
+		t.Term = app.searchEdit.Text()
+	}
+	selected := app.selectedChipStatuses()
+	for _, s := range possibleStatuses {
+		if selected[s] {
+			t.Chips = append(t.Chips, s)
+		}
+	}
+	return t
+}
+
+// applyFilterTab восстанавливает фильтры вкладки и обновляет таблицу
+func (app *AppMainWindow) applyFilterTab(t FilterTab) {
+	app.applyingFilterTab = true
+	defer func() { app.applyingFilterTab = false }()
+
+	if t.Field < 0 || t.Field >= len(searchFields) {
+		t.Field = 0
+	}
+	if app.searchFieldCB.CurrentIndex() != t.Field {
+		app.searchFieldCB.SetCurrentIndex(t.Field) // Сбрасывает поле поиска
+	}
+	switch searchFields[t.Field] {
+	case "По статусу":
+		app.statusFilterCB.SetCurrentIndex(max(slices.Index(possibleStatuses, t.Status), 0))
+	case "По опыту":
+		app.experienceFilterCB.SetCurrentIndex(max(slices.Index(possibleExperienceLevels, t.Experience), 0))
+	default:
+		app.searchEdit.SetText(t.Term)
+	}
+	for i, chip := range app.statusChips {
+		if chip != nil {
+			chip.SetChecked(slices.Contains(t.Chips, possibleStatuses[i]))
+		}
+	}
+	appSettings.ShowArchived = t.ShowArchived
+	if app.showArchiveAction != nil {
+		app.showArchiveAction.SetChecked(t.ShowArchived)
+	}
+	app.vacancyModel.setSortKeys(t.SortKeys)
+	appSettings.SortKeys = append([]SortKey{}, app.vacancyModel.sortKeys...)
+	app.updateSortHeaders()
+	app.performSearch()
+}
+
+// rememberFilterTab записывает текущие фильтры в открытую вкладку
+func (app *AppMainWindow) rememberFilterTab() {
+	i := appSettings.ActiveFilterTab
+	if app.applyingFilterTab || app.searchFieldCB == nil || i < 0 || i >= len(appSettings.FilterTabs) {
+		return
+	}
+	appSettings.FilterTabs[i] = app.captureFilterTab(appSettings.FilterTabs[i].Name)
+}
+
+// switchFilterTab открывает вкладку i, сохранив фильтры текущей
+func (app *AppMainWindow) switchFilterTab(i int) {
+	if i < 0 || i >= len(appSettings.FilterTabs) {
+		return
+	}
+	app.rememberFilterTab()
+	appSettings.ActiveFilterTab = i
+	app.applyFilterTab(appSettings.FilterTabs[i])
+	app.rebuildFilterTabs()
+	saveSettings()
+}
+
+// addFilterTab создаёт вкладку с текущими фильтрами
+func (app *AppMainWindow) addFilterTab() {
+	name, ok := promptText(app.MainWindow, "Новая вкладка", "Название вкладки (в неё попадут текущие фильтры):", "")
+	if !ok || name == "" {
+		return
+	}
+	app.rememberFilterTab()
+	appSettings.FilterTabs = append(appSettings.FilterTabs, app.captureFilterTab(name))
+	appSettings.ActiveFilterTab = len(appSettings.FilterTabs) - 1
+	app.rebuildFilterTabs()
+	saveSettings()
+}
+
+// renameFilterTab переименовывает открытую вкладку
+func (app *AppMainWindow) renameFilterTab() {
+	i := appSettings.ActiveFilterTab
+	name, ok := promptText(app.MainWindow, "Переименовать вкладку", "Название вкладки:", appSettings.FilterTabs[i].Name)
+	if !ok || name == "" {
+		return
+	}
+	appSettings.FilterTabs[i].Name = name
+	app.rebuildFilterTabs()
+	saveSettings()
+}
+
+// closeFilterTab удаляет открытую вкладку; последнюю удалить нельзя
+func (app *AppMainWindow) closeFilterTab() {
+	if len(appSettings.FilterTabs) < 2 {
+		return
+	}
+	i := appSettings.ActiveFilterTab
+	if walk.MsgBox(app.MainWindow, "Вкладки", "Закрыть вкладку «"+appSettings.FilterTabs[i].Name+"»?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+		return
+	}
+	appSettings.FilterTabs = slices.Delete(appSettings.FilterTabs, i, i+1)
+	appSettings.ActiveFilterTab = min(i, len(appSettings.FilterTabs)-1)
+	app.applyFilterTab(appSettings.FilterTabs[appSettings.ActiveFilterTab])
+	app.rebuildFilterTabs()
+	saveSettings()
+}
+
+// rebuildFilterTabs заново заполняет полосу вкладок
+func (app *AppMainWindow) rebuildFilterTabs() {
+	if app.filterTabsBar == nil {
+		return
+	}
+	actions := app.filterTabsBar.Actions()
+	actions.Clear()
+	add := func(text, toolTip string, enabled bool, run func()) {
+		a := walk.NewAction()
+		a.SetText(text)
+		a.SetToolTip(toolTip)
+		a.SetEnabled(enabled)
+		a.Triggered().Attach(run)
+		if err := actions.Add(a); err != nil {
+			log.Printf("Ошибка добавления вкладки: %v", err)
+		}
+	}
+	for i, t := range appSettings.FilterTabs {
+		a := walk.NewAction()
+		a.SetText(t.Name)
+		a.SetCheckable(true)
+		a.SetChecked(i == appSettings.ActiveFilterTab)
+		a.Triggered().Attach(func() { app.switchFilterTab(i) })
+		actions.Add(a)
+	}
+	actions.Add(walk.NewSeparatorAction())
+	add("+", "Новая вкладка с текущими фильтрами", true, app.addFilterTab)
+	add("✎", "Переименовать вкладку", true, app.renameFilterTab)
+	add("✕", "Закрыть вкладку", len(appSettings.FilterTabs) > 1, app.closeFilterTab)
+}
+
+// restoreFilterTabs открывает вкладку прошлого сеанса и запоминает фильтры при выходе
+func (app *AppMainWindow) restoreFilterTabs() {
+	if len(appSettings.FilterTabs) == 0 {
+		appSettings.FilterTabs = defaultFilterTabs()
+		// Первая вкладка получает фильтры, с которыми приложение работало до вкладок
+		appSettings.FilterTabs[0].ShowArchived = appSettings.ShowArchived
+		appSettings.FilterTabs[0].SortKeys = appSettings.SortKeys
+	}
+	if appSettings.ActiveFilterTab < 0 || appSettings.ActiveFilterTab >= len(appSettings.FilterTabs) {
+		appSettings.ActiveFilterTab = 0
+	}
+	app.applyFilterTab(appSettings.FilterTabs[appSettings.ActiveFilterTab])
+	app.rebuildFilterTabs()
+	app.vacancyTable.ColumnClicked().Attach(func(int) { app.rememberFilterTab() })
+	app.MainWindow.Closing().Attach(func(*bool, walk.CloseReason) {
+		app.rememberFilterTab()
+		saveSettings()
+	})
+}
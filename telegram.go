@@ -0,0 +1,680 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+const telegramQueueFile = "telegram_queue.json"
+
+// Сколько ключей уже разобранных постов помнить, чтобы не предлагать их снова
+const maxTelegramSeen = 5000
+
+// Ожидание ответа Bot API
+const telegramRequestTimeout = 30 * time.Second
+
+// TelegramCandidate - пост из канала с вакансиями, ожидающий решения пользователя
+type TelegramCandidate struct {
+	Key     string    `json:"key"` // Канал и номер сообщения
+	Channel string    `json:"channel"`
+	PostURL string    `json:"post_url,omitempty"` // Ссылка на пост, если у канала есть публичное имя
+	At      time.Time `json:"at"`
+	Text    string    `json:"text"`
+	Vacancy Vacancy   `json:"vacancy"`
+}
+
+// telegramQueue - очередь на разбор и ключи постов, которые уже были в очереди
+type telegramQueue struct {
+	Candidates []TelegramCandidate `json:"candidates"`
+	Seen       []string            `json:"seen"`
+}
+
+// loadTelegramQueue читает очередь постов из Telegram
+func loadTelegramQueue() telegramQueue {
+	var q telegramQueue
+	data, err := os.ReadFile(dataPath(telegramQueueFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Ошибка чтения файла %s: %v", telegramQueueFile, err)
+		}
+		return q
+	}
+	if err := json.Unmarshal(data, &q); err != nil {
+		log.Printf("Ошибка декодирования JSON из файла %s: %v", telegramQueueFile, err)
+	}
+	return q
+}
+
+// saveTelegramQueue записывает очередь постов из Telegram
+func saveTelegramQueue(q telegramQueue) {
+	if len(q.Seen) > maxTelegramSeen {
+		q.Seen = q.Seen[len(q.Seen)-maxTelegramSeen:]
+	}
+	data, err := json.MarshalIndent(q, "", "  ")
+	if err != nil {
+		log.Printf("Ошибка кодирования очереди Telegram: %v", err)
+		return
+	}
+	if err := os.WriteFile(dataPath(telegramQueueFile), data, 0644); err != nil {
+		log.Printf("Ошибка записи файла %s: %v", telegramQueueFile, err)
+	}
+}
+
+// enqueue добавляет новые посты в очередь. Посты, которые уже разбирались, вакансии из
+// локального списка и компании из чёрного списка пропускаются.
+func (q *telegramQueue) enqueue(candidates []TelegramCandidate) (added int) {
+	seen := make(map[string]bool, len(q.Seen))
+	for _, key := range q.Seen {
+		seen[key] = true
+	}
+	allVacanciesMutex.Lock()
+	defer allVacanciesMutex.Unlock()
+	for _, c := range candidates {
+		if seen[c.Key] {
+			continue
+		}
+		seen[c.Key] = true
+		q.Seen = append(q.Seen, c.Key)
+		if isBlocked(c.Vacancy) {
+			continue
+		}
+		// При правиле "добавлять обе" повтор остаётся в очереди - решит пользователь
+		if appSettings.Dedup.OnCollision == dedupKeepBoth || findDuplicateLocked(c.Vacancy) == -1 {
+			q.Candidates = append(q.Candidates, c)
+			added++
+		}
+	}
+	return added
+}
+
+// Подписи полей в постах с вакансиями
+var telegramFieldLabels = map[string][]string{
+	"title":    {"вакансия", "должность", "позиция", "position", "role", "title"},
+	"company":  {"компания", "работодатель", "company", "employer"},
+	"salary":   {"зарплата", "з/п", "зп", "вилка", "оклад", "salary", "compensation"},
+	"location": {"город", "локация", "место", "location", "city"},
+}
+
+// Хэштеги, которые отмечают пост как вакансию, но не несут навыков
+var telegramPostTags = map[string]bool{
+	"вакансия": true, "vacancy": true, "job": true, "jobs": true, "работа": true, "hiring": true, "вакансии": true,
+}
+
+var (
+	telegramTagRe   = regexp.MustCompile(`#([\p{L}\p{N}_]+)`)
+	telegramURLRe   = regexp.MustCompile(`https?://[^\s)\]]+`)
+	telegramLabelRe = regexp.MustCompile(`^[^\p{L}]*([\p{L}/ ]{2,20}?)\s*[:：—–-]\s*(.+)$`)
+	telegramDecorRe = regexp.MustCompile(`[*_~` + "`" + `]+`)
+	telegramMoneyRe = regexp.MustCompile(`(?i)(₽|руб|rub|\$|usd|€|eur|тыс|\d\s?k\b)`)
+)
+
+// cleanTelegramLine убирает разметку и эмодзи в начале строки
+func cleanTelegramLine(line string) string {
+	line = telegramDecorRe.ReplaceAllString(line, "")
+	line = strings.TrimLeftFunc(line, func(r rune) bool {
+		return !strings.ContainsRune("«\"(", r) && !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.TrimSpace(line)
+}
+
+// telegramLabeledField распознаёт строку вида "Компания: Рога и копыта"
+func telegramLabeledField(line string) (field, value string, ok bool) {
+	m := telegramLabelRe.FindStringSubmatch(line)
+	if m == nil {
+		return "", "", false
+	}
+	label := strings.ToLower(strings.TrimSpace(m[1]))
+	for f, labels := range telegramFieldLabels {
+		for _, l := range labels {
+			if label == l {
+				return f, strings.TrimSpace(m[2]), true
+			}
+		}
+	}
+	return "", "", false
+}
+
+// parseTelegramPost разбирает пост канала. false - пост не похож на вакансию
+// (нет хэштега вакансии и не нашлось ни компании, ни зарплаты).
+func parseTelegramPost(text string, links []string) (Vacancy, bool) {
+	v := Vacancy{
+		Keywords:        []string{},
+		Status:          possibleStatuses[0],
+		ExperienceLevel: possibleExperienceLevels[0],
+		Description:     sanitizeDescription(text),
+	}
+
+	isPosting := false
+	for _, m := range telegramTagRe.FindAllStringSubmatch(text, -1) {
+		tag := strings.ToLower(m[1])
+		if telegramPostTags[tag] {
+			isPosting = true
+			continue
+		}
+		v.Keywords = append(v.Keywords, strings.ReplaceAll(m[1], "_", " "))
+	}
+
+	for _, raw := range splitLines(text) {
+		// Строки из одних хэштегов уже разобраны выше
+		if strings.HasPrefix(raw, "#") {
+			continue
+		}
+		line := cleanTelegramLine(raw)
+		if line == "" {
+			continue
+		}
+		if field, value, ok := telegramLabeledField(line); ok {
+			switch field {
+			case "title":
+				if v.Title == "" {
+					v.Title = value
+				}
+			case "company":
+				if v.Company == "" {
+					v.Company = value
+				}
+			case "salary":
+				if v.Salary == "" {
+					applySalary(&v, value)
+				}
+			case "location":
+				if v.Location == "" {
+					v.Location = value
+				}
+			}
+			continue
+		}
+		// Первая строка без подписи и ссылок обычно и есть название
+		if v.Title == "" && !telegramURLRe.MatchString(line) {
+			v.Title = line
+		} else if v.Salary == "" && telegramMoneyRe.MatchString(line) {
+			if lo, hi, _ := parseSalary(line); lo > 0 || hi > 0 {
+				applySalary(&v, line)
+			}
+		}
+	}
+
+	// Ссылка на саму вакансию ценнее ссылок на другие посты Telegram
+	links = append(links, telegramURLRe.FindAllString(text, -1)...)
+	for _, link := range links {
+		if validVacancyURL(link) && !strings.Contains(link, "t.me/") {
+			v.SourceURL = link
+			break
+		}
+	}
+
+	if v.Title == "" {
+		return v, false
+	}
+	autoDetectExperience(&v)
+	return v, isPosting || v.Company != "" || v.Salary != ""
+}
+
+// telegramExport - файл result.json из экспорта Telegram Desktop
+type telegramExport struct {
+	Name     string `json:"name"`
+	ID       int64  `json:"id"`
+	Messages []struct {
+		ID   int64  `json:"id"`
+		Type string `json:"type"`
+		Date string `json:"date"`
+		Text any    `json:"text"`
+	} `json:"messages"`
+}
+
+// flattenTelegramText собирает текст сообщения из экспорта: это строка
+// или массив из строк и объектов {type, text, href}
+func flattenTelegramText(t any) (string, []string) {
+	switch v := t.(type) {
+	case string:
+		return v, nil
+	case []any:
+		var b strings.Builder
+		var links []string
+		for _, part := range v {
+			switch p := part.(type) {
+			case string:
+				b.WriteString(p)
+			case map[string]any:
+				text, _ := p["text"].(string)
+				b.WriteString(text)
+				if href, ok := p["href"].(string); ok {
+					links = append(links, href)
+				}
+			}
+		}
+		return b.String(), links
+	}
+	return "", nil
+}
+
+// parseTelegramExport превращает экспорт канала в кандидатов на вакансии
+func parseTelegramExport(data []byte) ([]TelegramCandidate, int, error) {
+	var export telegramExport
+	if err := json.Unmarshal(data, &export); err != nil {
+		return nil, 0, fmt.Errorf("это не экспорт чата Telegram (result.json): %w", err)
+	}
+	if len(export.Messages) == 0 {
+		return nil, 0, errors.New("в экспорте нет сообщений")
+	}
+	var candidates []TelegramCandidate
+	skipped := 0
+	for _, m := range export.Messages {
+		if m.Type != "message" {
+			continue
+		}
+		text, links := flattenTelegramText(m.Text)
+		v, ok := parseTelegramPost(text, links)
+		if !ok {
+			skipped++
+			continue
+		}
+		at, _ := time.ParseInLocation("2006-01-02T15:04:05", m.Date, time.Local)
+		v.Source = export.Name
+		applyFieldMapping(providerTelegram, &v)
+		candidates = append(candidates, TelegramCandidate{
+			Key:     fmt.Sprintf("%d:%d", export.ID, m.ID),
+			Channel: export.Name,
+			At:      at,
+			Text:    text,
+			Vacancy: v,
+		})
+	}
+	return candidates, skipped, nil
+}
+
+// telegramUpdates - ответ метода getUpdates Bot API (нужны только посты каналов)
+type telegramUpdates struct {
+	OK          bool   `json:"ok"`
+	Description string `json:"description"`
+	Result      []struct {
+		UpdateID    int64 `json:"update_id"`
+		ChannelPost *struct {
+			MessageID int64  `json:"message_id"`
+			Date      int64  `json:"date"`
+			Text      string `json:"text"`
+			Caption   string `json:"caption"`
+			Chat      struct {
+				ID       int64  `json:"id"`
+				Title    string `json:"title"`
+				Username string `json:"username"`
+			} `json:"chat"`
+			Entities []struct {
+				Type string `json:"type"`
+				URL  string `json:"url"`
+			} `json:"entities"`
+		} `json:"channel_post"`
+	} `json:"result"`
+}
+
+// fetchTelegramPosts забирает новые посты каналов, в которые добавлен бот.
+// Возвращает кандидатов и смещение для следующего запроса.
+func fetchTelegramPosts(ctx context.Context, token string, offset int64) ([]TelegramCandidate, int64, error) {
+	params := url.Values{}
+	params.Set("offset", strconv.FormatInt(offset, 10))
+	params.Set("allowed_updates", `["channel_post"]`)
+	req, err := http.NewRequestWithContext(ctx, "GET", "https://api.telegram.org/bot"+token+"/getUpdates?"+params.Encode(), nil)
+	if err != nil {
+		return nil, offset, err
+	}
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		// В тексте ошибки есть адрес запроса, а в нём токен
+		return nil, offset, errors.New(strings.ReplaceAll(err.Error(), token, "***"))
+	}
+	defer resp.Body.Close()
+
+	var updates telegramUpdates
+	if err := json.NewDecoder(resp.Body).Decode(&updates); err != nil {
+		return nil, offset, fmt.Errorf("неожиданный ответ Telegram (HTTP %d): %w", resp.StatusCode, err)
+	}
+	if !updates.OK {
+		return nil, offset, fmt.Errorf("Telegram: %s", updates.Description)
+	}
+
+	var candidates []TelegramCandidate
+	for _, u := range updates.Result {
+		offset = u.UpdateID + 1
+		post := u.ChannelPost
+		if post == nil {
+			continue
+		}
+		text := post.Text
+		if text == "" {
+			text = post.Caption
+		}
+		var links []string
+		for _, e := range post.Entities {
+			if e.URL != "" {
+				links = append(links, e.URL)
+			}
+		}
+		v, ok := parseTelegramPost(text, links)
+		if !ok {
+			continue
+		}
+		v.Source = post.Chat.Title
+		applyFieldMapping(providerTelegram, &v)
+		c := TelegramCandidate{
+			Key:     fmt.Sprintf("%d:%d", post.Chat.ID, post.MessageID),
+			Channel: post.Chat.Title,
+			At:      time.Unix(post.Date, 0),
+			Text:    text,
+			Vacancy: v,
+		}
+		if post.Chat.Username != "" {
+			c.PostURL = fmt.Sprintf("https://t.me/%s/%d", post.Chat.Username, post.MessageID)
+		}
+		candidates = append(candidates, c)
+	}
+	return candidates, offset, nil
+}
+
+// TelegramQueueModel для TableView в окне разбора постов
+type TelegramQueueModel struct {
+	walk.TableModelBase
+	items []TelegramCandidate
+}
+
+func (m *TelegramQueueModel) RowCount() int {
+	return len(m.items)
+}
+
+func (m *TelegramQueueModel) Value(row, col int) interface{} {
+	item := m.items[row]
+	switch col {
+	case 0:
+		return item.Vacancy.Title
+	case 1:
+		return item.Vacancy.Company
+	case 2:
+		return item.Vacancy.Salary
+	case 3:
+		return item.Channel
+	case 4:
+		if item.At.IsZero() {
+			return ""
+		}
+		return item.At.Local().Format("02.01.2006")
+	}
+	return ""
+}
+
+// showTelegramBotSettings задаёт токен бота, через которого читаются каналы
+func showTelegramBotSettings(owner walk.Form) {
+	var dlg *walk.Dialog
+	var tokenLE *walk.LineEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Бот Telegram",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 460, Height: 200},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "Создайте бота у @BotFather и добавьте его администратором в каналы\nс вакансиями (или в свой канал, куда пересылаете посты).",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			Label{Text: "Токен бота:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			LineEdit{AssignTo: &tokenLE, Text: appSettings.TelegramBotToken, PasswordMode: true, Font: Font{PointSize: 9}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							token := strings.TrimSpace(tokenLE.Text())
+							if token != appSettings.TelegramBotToken {
+								appSettings.TelegramBotToken = token
+								appSettings.TelegramUpdateOffset = 0
+								saveSettings()
+							}
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(owner); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
+
+// showTelegramQueue показывает очередь постов из Telegram. Выбранный пост открывается
+// в диалоге добавления после закрытия очереди, затем очередь открывается снова.
+func (app *AppMainWindow) showTelegramQueue() {
+	for {
+		picked, ok := app.runTelegramQueueDialog()
+		if !ok {
+			return
+		}
+		v := picked.Vacancy
+		if app.openImportedVacancy(v) {
+			q := loadTelegramQueue()
+			for i, c := range q.Candidates {
+				if c.Key == picked.Key {
+					q.Candidates = append(q.Candidates[:i], q.Candidates[i+1:]...)
+					break
+				}
+			}
+			saveTelegramQueue(q)
+		}
+	}
+}
+
+// runTelegramQueueDialog - окно очереди; возвращает пост, выбранный для добавления
+func (app *AppMainWindow) runTelegramQueueDialog() (TelegramCandidate, bool) {
+	var dlg *walk.Dialog
+	var table *walk.TableView
+	var textTE *walk.TextEdit
+	var statusLabel *walk.Label
+	var fetchPB *walk.PushButton
+	queue := loadTelegramQueue()
+	model := &TelegramQueueModel{items: queue.Candidates}
+	var picked TelegramCandidate
+	var chosen bool
+
+	save := func() {
+		queue.Candidates = model.items
+		saveTelegramQueue(queue)
+		model.PublishRowsReset()
+		statusLabel.SetText(fmt.Sprintf("В очереди: %d", len(model.items)))
+	}
+	selected := func() int {
+		i := table.CurrentIndex()
+		if i < 0 || i >= len(model.items) {
+			return -1
+		}
+		return i
+	}
+	addToQueue := func(candidates []TelegramCandidate, skipped int) {
+		added := queue.enqueue(candidates)
+		model.items = queue.Candidates
+		save()
+		statusLabel.SetText(fmt.Sprintf("В очереди: %d (новых: %d, не похожих на вакансию постов: %d)", len(model.items), added, skipped))
+	}
+
+	importExport := func() {
+		fd := new(walk.FileDialog)
+		fd.Title = "Экспорт канала из Telegram Desktop"
+		fd.Filter = "Экспорт Telegram (result.json)|*.json"
+		if ok, err := fd.ShowOpen(dlg); err != nil || !ok {
+			return
+		}
+		data, err := os.ReadFile(fd.FilePath)
+		if err == nil {
+			var candidates []TelegramCandidate
+			var skipped int
+			if candidates, skipped, err = parseTelegramExport(data); err == nil {
+				addToQueue(candidates, skipped)
+				return
+			}
+		}
+		showError(dlg, "Ошибка", wrapError("Не удалось импортировать экспорт", err))
+	}
+
+	fetchFromBot := func() {
+		if appSettings.TelegramBotToken == "" {
+			showTelegramBotSettings(dlg)
+			if appSettings.TelegramBotToken == "" {
+				return
+			}
+		}
+		if app.offline {
+			walk.MsgBox(dlg, "Telegram", "Нет подключения к интернету.", walk.MsgBoxIconInformation)
+			return
+		}
+		fetchPB.SetEnabled(false)
+		statusLabel.SetText("Получение постов от бота...")
+		token, offset := appSettings.TelegramBotToken, appSettings.TelegramUpdateOffset
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), telegramRequestTimeout)
+			defer cancel()
+			candidates, next, err := fetchTelegramPosts(ctx, token, offset)
+			dlg.Synchronize(func() {
+				fetchPB.SetEnabled(true)
+				if err != nil {
+					log.Printf("Ошибка получения постов Telegram: %v", err)
+					statusLabel.SetText("Ошибка: " + err.Error())
+					return
+				}
+				appSettings.TelegramUpdateOffset = next
+				saveSettings()
+				addToQueue(candidates, 0)
+			})
+		}()
+	}
+
+	if _, err := (Dialog{
+		AssignTo:   &dlg,
+		Title:      "Вакансии из Telegram",
+		MinSize:    Size{Width: 760, Height: 520},
+		Layout:     VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{AssignTo: &fetchPB, Text: "Получить от бота", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: fetchFromBot},
+					PushButton{Text: "Импорт экспорта канала...", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: importExport},
+					PushButton{Text: "Бот...", Background: SolidColorBrush{Color: currentTheme.ButtonBG}, OnClicked: func() { showTelegramBotSettings(dlg) }},
+					HSpacer{},
+					Label{AssignTo: &statusLabel, Text: fmt.Sprintf("В очереди: %d", len(model.items)), TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+				},
+			},
+			VSplitter{
+				Children: []Widget{
+					TableView{
+						AssignTo: &table,
+						Model:    model,
+						Columns: []TableViewColumn{
+							{Title: "Название", Width: 220},
+							{Title: "Компания", Width: 140},
+							{Title: "Зарплата", Width: 120},
+							{Title: "Канал", Width: 140},
+							{Title: "Дата", Width: 80},
+						},
+						OnCurrentIndexChanged: func() {
+							text := ""
+							if i := selected(); i >= 0 {
+								text = strings.ReplaceAll(model.items[i].Text, "\n", "\r\n")
+							}
+							textTE.SetText(text)
+						},
+						OnItemActivated: func() {
+							if i := selected(); i >= 0 {
+								picked, chosen = model.items[i], true
+								dlg.Accept()
+							}
+						},
+					},
+					TextEdit{AssignTo: &textTE, ReadOnly: true, VScroll: true, Font: Font{PointSize: 9}},
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Добавить...",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if i := selected(); i >= 0 {
+								picked, chosen = model.items[i], true
+								dlg.Accept()
+							}
+						},
+					},
+					PushButton{
+						Text:       "Пропустить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if i := selected(); i >= 0 {
+								model.items = append(model.items[:i], model.items[i+1:]...)
+								save()
+							}
+						},
+					},
+					PushButton{
+						Text:       "Открыть пост",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if i := selected(); i >= 0 {
+								link := model.items[i].PostURL
+								if link == "" {
+									link = model.items[i].Vacancy.SourceURL
+								}
+								if link == "" {
+									walk.MsgBox(dlg, "Telegram", "У этого поста нет ссылки: канал закрытый.", walk.MsgBoxIconInformation)
+								} else if err := openURL(link); err != nil {
+									log.Printf("Не удалось открыть ссылку %s: %v", link, err)
+								}
+							}
+						},
+					},
+					HSpacer{},
+					PushButton{
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	return picked, chosen
+}
@@ -0,0 +1,410 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Обратная связь и анонимная статистика использования. Статистика выключена, пока
+// пользователь сам её не включит, и содержит только счётчики запусков функций (названия
+// пунктов меню, команд палитры и кнопок), версию приложения и ОС и случайный номер
+// установки - никаких вакансий, поисковых запросов и путей. Отзывы и статистика уходят
+// на адрес из настроек; без адреса отзыв открывается как новая задача на GitHub.
+type TelemetrySettings struct {
+	Enabled    bool      `json:"enabled"`               // Отправлять анонимную статистику
+	Endpoint   string    `json:"endpoint,omitempty"`    // Куда отправлять отзывы и статистику
+	InstallID  string    `json:"install_id,omitempty"`  // Случайный номер установки
+	LastSentAt time.Time `json:"last_sent_at,omitzero"` // Когда статистика отправлялась последний раз
+}
+
+const (
+	usageFile         = "usage.json" // Накопленные счётчики, рядом с settings.json: общие для всех профилей
+	usageSendInterval = 24 * time.Hour
+	telemetryTimeout  = 15 * time.Second
+	newIssueURL       = "https://github.com/Project-Golang-2025/projectgolang/issues/new"
+)
+
+// usage - счётчики функций с последней отправки
+var usage = struct {
+	sync.Mutex
+	counts map[string]int
+	loaded bool
+}{counts: map[string]int{}}
+
+// loadUsageLocked подгружает счётчики, накопленные в прошлых запусках. Вызывается при заблокированном usage.
+func loadUsageLocked() {
+	if usage.loaded {
+		return
+	}
+	usage.loaded = true
+	data, err := os.ReadFile(usageFile)
+	if err == nil {
+		err = json.Unmarshal(data, &usage.counts)
+	}
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		log.Printf("Ошибка чтения %s: %v", usageFile, err)
+	}
+}
+
+// recordFeatureUse учитывает запуск функции, если пользователь разрешил статистику
+func recordFeatureUse(feature string) {
+	feature = strings.TrimSpace(strings.TrimSuffix(strings.ReplaceAll(feature, "&", ""), "..."))
+	if !appSettings.Telemetry.Enabled || feature == "" {
+		return
+	}
+	usage.Lock()
+	defer usage.Unlock()
+	loadUsageLocked()
+	usage.counts[feature]++
+}
+
+// saveUsage сохраняет счётчики, чтобы не потерять их при выходе до отправки
+func saveUsage() {
+	usage.Lock()
+	defer usage.Unlock()
+	if !usage.loaded {
+		return
+	}
+	data, err := json.MarshalIndent(usage.counts, "", "  ")
+	if err == nil {
+		err = os.WriteFile(usageFile, data, 0644)
+	}
+	if err != nil {
+		log.Printf("Ошибка записи %s: %v", usageFile, err)
+	}
+}
+
+// usageReport - то, что уходит на сервер статистики
+type usageReport struct {
+	Kind      string         `json:"kind"` // "usage"
+	InstallID string         `json:"install_id"`
+	Version   string         `json:"version"`
+	OS        string         `json:"os"`
+	Since     time.Time      `json:"since,omitzero"`
+	Counts    map[string]int `json:"counts"`
+}
+
+func currentUsageReport() usageReport {
+	usage.Lock()
+	defer usage.Unlock()
+	loadUsageLocked()
+	counts := make(map[string]int, len(usage.counts))
+	for k, n := range usage.counts {
+		counts[k] = n
+	}
+	return usageReport{
+		Kind:      "usage",
+		InstallID: appSettings.Telemetry.InstallID,
+		Version:   appVersion,
+		OS:        runtime.GOOS + "/" + runtime.GOARCH,
+		Since:     appSettings.Telemetry.LastSentAt,
+		Counts:    counts,
+	}
+}
+
+// newInstallID - случайный номер установки, не связанный ни с пользователем, ни с компьютером
+func newInstallID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// postTelemetry отправляет JSON на адрес из настроек
+func postTelemetry(ctx context.Context, endpoint string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "projectgolang/"+appVersion)
+	resp, err := httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return &httpStatusError{Code: resp.StatusCode, Status: resp.Status}
+	}
+	return nil
+}
+
+// sendUsageIfDue раз в сутки отправляет накопленные счётчики и обнуляет их
+func (app *AppMainWindow) sendUsageIfDue() {
+	s := appSettings.Telemetry
+	if !s.Enabled || s.Endpoint == "" || time.Since(s.LastSentAt) < usageSendInterval {
+		return
+	}
+	report := currentUsageReport()
+	if len(report.Counts) == 0 {
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), telemetryTimeout)
+		defer cancel()
+		if err := postTelemetry(ctx, s.Endpoint, report); err != nil {
+			log.Printf("Статистика не отправлена: %v", err)
+			return
+		}
+		usage.Lock()
+		for k, n := range report.Counts {
+			if usage.counts[k] -= n; usage.counts[k] <= 0 {
+				delete(usage.counts, k)
+			}
+		}
+		usage.Unlock()
+		saveUsage()
+		app.Synchronize(func() {
+			appSettings.Telemetry.LastSentAt = time.Now()
+			saveSettings()
+		})
+	}()
+}
+
+// startUsageTracking считает пункты меню и кнопки главного окна и периодически отправляет статистику
+func (app *AppMainWindow) startUsageTracking() {
+	var track func(actions *walk.ActionList)
+	track = func(actions *walk.ActionList) {
+		for i := 0; i < actions.Len(); i++ {
+			a := actions.At(i)
+			// Пункты «Недавние» и «Профиль» пересобираются на ходу и содержат имена вакансий и профилей
+			if a.IsSeparator() || a.Menu() != nil && (a.Menu() == app.recentMenu || a.Menu() == app.profilesMenu) {
+				continue
+			}
+			if a.Menu() != nil {
+				track(a.Menu().Actions())
+				continue
+			}
+			a.Triggered().Attach(func() { recordFeatureUse(a.Text()) })
+		}
+	}
+	track(app.MainWindow.Menu().Actions())
+	for _, pb := range []*walk.PushButton{app.addVacancyButton, app.editVacancyButton, app.deleteVacancyButton, app.duplicateButton, app.onlineSearchButton} {
+		if pb != nil {
+			pb.Clicked().Attach(func() { recordFeatureUse(pb.Text()) })
+		}
+	}
+
+	app.sendUsageIfDue()
+	go func() {
+		for range time.Tick(time.Hour) {
+			app.Synchronize(app.sendUsageIfDue)
+		}
+	}()
+}
+
+// feedbackKinds - о чём отзыв
+var feedbackKinds = []string{"Ошибка", "Предложение", "Вопрос", "Другое"}
+
+// feedbackMessage - отзыв, отправляемый на сервер
+type feedbackMessage struct {
+	Kind    string `json:"kind"` // "feedback"
+	Topic   string `json:"topic"`
+	Text    string `json:"text"`
+	Contact string `json:"contact,omitempty"`
+	Version string `json:"version"`
+	OS      string `json:"os"`
+}
+
+// sendFeedback отправляет отзыв на адрес из настроек или открывает его как задачу на GitHub.
+// Запрос идёт в фоне, done вызывается в потоке интерфейса с ошибкой или nil.
+func (app *AppMainWindow) sendFeedback(msg feedbackMessage, done func(err error)) {
+	endpoint := appSettings.Telemetry.Endpoint
+	if endpoint == "" {
+		body := msg.Text + "\n\n---\nВерсия: " + msg.Version + ", " + msg.OS
+		link := newIssueURL + "?" + url.Values{"title": {msg.Topic + ": " + firstLine(msg.Text)}, "body": {body}}.Encode()
+		if err := openURL(link); err != nil {
+			log.Printf("Ошибка открытия %s: %v", newIssueURL, err)
+			done(wrapError("Не удалось открыть браузер", err))
+			return
+		}
+		done(nil)
+		return
+	}
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), telemetryTimeout)
+		defer cancel()
+		err := postTelemetry(ctx, endpoint, msg)
+		if err != nil {
+			log.Printf("Ошибка отправки отзыва: %v", err)
+			err = wrapError("Не удалось отправить отзыв", err)
+		}
+		app.Synchronize(func() { done(err) })
+	}()
+}
+
+// firstLine - первая строка текста, не длиннее 80 символов, для заголовка задачи
+func firstLine(s string) string {
+	s, _, _ = strings.Cut(strings.TrimSpace(s), "\n")
+	if r := []rune(strings.TrimSpace(s)); len(r) > 80 {
+		return string(r[:80]) + "…"
+	}
+	return strings.TrimSpace(s)
+}
+
+// usagePreview - текст счётчиков для показа пользователю перед отправкой
+func usagePreview() string {
+	report := currentUsageReport()
+	names := make([]string, 0, len(report.Counts))
+	for name := range report.Counts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	fmt.Fprintf(&b, "Номер установки: %s\r\nВерсия: %s, %s\r\n\r\n", report.InstallID, report.Version, report.OS)
+	if len(names) == 0 {
+		b.WriteString("Счётчиков пока нет.\r\n")
+	}
+	for _, name := range names {
+		fmt.Fprintf(&b, "%s: %d\r\n", name, report.Counts[name])
+	}
+	return b.String()
+}
+
+// showFeedbackDialog - форма отзыва и согласие на анонимную статистику
+func (app *AppMainWindow) showFeedbackDialog() {
+	var dlg *walk.Dialog
+	var kindCB *walk.ComboBox
+	var textTE *walk.TextEdit
+	var contactLE, endpointLE *walk.LineEdit
+	var telemetryCB *walk.CheckBox
+	var sendPB, closePB *walk.PushButton
+
+	label := func(text string) Label {
+		return Label{Text: text, TextColor: currentTheme.Text, Font: Font{PointSize: 9}}
+	}
+	// Согласие и адрес сохраняются и при закрытии без отправки отзыва
+	saveTelemetry := func() {
+		s := &appSettings.Telemetry
+		s.Enabled = telemetryCB.Checked()
+		s.Endpoint = strings.TrimSpace(endpointLE.Text())
+		if s.Enabled && s.InstallID == "" {
+			s.InstallID = newInstallID()
+		}
+		saveSettings()
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Обратная связь",
+		DefaultButton: &sendPB,
+		CancelButton:  &closePB,
+		MinSize:       Size{Width: 520, Height: 480},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					label("Тема:"),
+					ComboBox{AssignTo: &kindCB, Model: feedbackKinds, CurrentIndex: 0},
+					HSpacer{},
+				},
+			},
+			label("Что случилось или чего не хватает:"),
+			TextEdit{AssignTo: &textTE, VScroll: true, MinSize: Size{Height: 140}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					label("Как с вами связаться (необязательно):"),
+					LineEdit{AssignTo: &contactLE},
+				},
+			},
+			label("Вместе с отзывом отправляются только версия приложения и ОС."),
+			CheckBox{AssignTo: &telemetryCB, Text: "Отправлять анонимную статистику: какие функции и сколько раз запускались", Checked: appSettings.Telemetry.Enabled},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					label("Адрес для отзывов и статистики:"),
+					LineEdit{AssignTo: &endpointLE, Text: appSettings.Telemetry.Endpoint, CueBanner: "не задан - отзыв откроется как задача на GitHub"},
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Что отправляется?",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							walk.MsgBox(dlg, "Анонимная статистика", usagePreview(), walk.MsgBoxIconInformation)
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &sendPB,
+						Text:       "Отправить отзыв",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							text := strings.TrimSpace(textTE.Text())
+							if text == "" {
+								textTE.SetFocus()
+								return
+							}
+							saveTelemetry()
+							msg := feedbackMessage{
+								Kind:    "feedback",
+								Topic:   kindCB.Text(),
+								Text:    text,
+								Contact: strings.TrimSpace(contactLE.Text()),
+								Version: appVersion,
+								OS:      runtime.GOOS + "/" + runtime.GOARCH,
+							}
+							sendPB.SetEnabled(false)
+							app.sendFeedback(msg, func(err error) {
+								// Диалог могли закрыть, пока отзыв отправлялся
+								closed := dlg.IsDisposed()
+								if err != nil {
+									if closed {
+										showError(app.MainWindow, "Ошибка", err)
+										return
+									}
+									sendPB.SetEnabled(true)
+									showError(dlg, "Ошибка", err)
+									return
+								}
+								app.notify("Спасибо! Отзыв отправлен.")
+								if !closed {
+									dlg.Accept()
+								}
+							})
+						},
+					},
+					PushButton{
+						AssignTo:   &closePB,
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							saveTelemetry()
+							dlg.Cancel()
+						},
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
@@ -0,0 +1,264 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+const templatesFile = "templates.json"
+
+// Подпись пункта "без шаблона" в диалоге добавления
+const noTemplateItem = "(без шаблона)"
+
+// VacancyTemplate - заготовка для быстрого добавления похожих вакансий.
+// Название, компания и ссылка в шаблоне не хранятся.
+type VacancyTemplate struct {
+	Name    string  `json:"name"`
+	Vacancy Vacancy `json:"vacancy"`
+}
+
+// templateFromVacancy оставляет в вакансии только то, что повторяется от вакансии к вакансии
+func templateFromVacancy(name string, v Vacancy) VacancyTemplate {
+	return VacancyTemplate{
+		Name: name,
+		Vacancy: Vacancy{
+			Description:     v.Description,
+			Keywords:        append([]string{}, v.Keywords...),
+			Status:          v.Status,
+			ExperienceLevel: v.ExperienceLevel,
+			Notes:           v.Notes,
+			Salary:          v.Salary,
+			SalaryMin:       v.SalaryMin,
+			SalaryMax:       v.SalaryMax,
+			SalaryCurrency:  v.SalaryCurrency,
+			WorkFormat:      v.WorkFormat,
+			Location:        v.Location,
+		},
+	}
+}
+
+// loadTemplates читает сохранённые шаблоны
+func loadTemplates() []VacancyTemplate {
+	data, err := os.ReadFile(dataPath(templatesFile))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("Ошибка чтения файла %s: %v", templatesFile, err)
+		}
+		return nil
+	}
+	var templates []VacancyTemplate
+	if err := json.Unmarshal(data, &templates); err != nil {
+		log.Printf("Ошибка декодирования JSON из файла %s: %v", templatesFile, err)
+		return nil
+	}
+	return templates
+}
+
+// saveTemplates записывает шаблоны в файл
+func saveTemplates(templates []VacancyTemplate) error {
+	data, err := json.MarshalIndent(templates, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(dataPath(templatesFile), data, 0644)
+}
+
+// templateNames возвращает пункты выбора шаблона для диалога добавления
+func templateNames(templates []VacancyTemplate) []string {
+	names := []string{noTemplateItem}
+	for _, t := range templates {
+		names = append(names, t.Name)
+	}
+	return names
+}
+
+// applyTemplate заполняет поля диалога добавления из шаблона, не трогая название, компанию и ссылку
+func (dlg *AddVacancyDialog) applyTemplate(t VacancyTemplate) {
+	if dlg.binder == nil || dlg.binder.Submit() != nil {
+		return
+	}
+	tv := newVacancyFormData(t.Vacancy)
+	dlg.form.Status = tv.Status
+	dlg.form.ExperienceLevel = tv.ExperienceLevel
+	dlg.form.Keywords = tv.Keywords
+	dlg.form.Salary = tv.Salary
+	dlg.form.Description = tv.Description
+	dlg.form.Notes = tv.Notes
+	dlg.resetting = true
+	dlg.binder.Reset()
+	// Полей для формата работы и города в диалоге нет - переносим их напрямую
+	dlg.vacancy.WorkFormat = t.Vacancy.WorkFormat
+	dlg.vacancy.Location = t.Vacancy.Location
+}
+
+// promptText спрашивает у пользователя одну строку текста
+func promptText(owner walk.Form, title, label, initial string) (string, bool) {
+	var dlg *walk.Dialog
+	var textLE *walk.LineEdit
+	var acceptPB, cancelPB *walk.PushButton
+	var text string
+	ok := false
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         title,
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 360, Height: 140},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: label, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			LineEdit{AssignTo: &textLE, Text: initial, Font: Font{PointSize: 9}},
+			VSpacer{},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "OK",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							text = strings.TrimSpace(textLE.Text())
+							if text == "" {
+								return
+							}
+							ok = true
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(owner); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	return text, ok
+}
+
+// saveSelectedAsTemplate сохраняет выбранную вакансию как шаблон
+func (app *AppMainWindow) saveSelectedAsTemplate() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		walk.MsgBox(app.MainWindow, "Шаблон", "Пожалуйста, выберите вакансию.", walk.MsgBoxIconInformation)
+		return
+	}
+	v, _ := app.vacancyAt(idx)
+
+	name, ok := promptText(app.MainWindow, "Сохранить как шаблон", "Название шаблона:", v.Title)
+	if !ok {
+		return
+	}
+	templates := loadTemplates()
+	t := templateFromVacancy(name, v)
+	replaced := false
+	for i := range templates {
+		if strings.EqualFold(templates[i].Name, name) {
+			if walk.DlgCmdYes != walk.MsgBox(app.MainWindow, "Шаблон", "Шаблон '"+name+"' уже есть. Заменить его?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) {
+				return
+			}
+			templates[i] = t
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		templates = append(templates, t)
+	}
+	if err := saveTemplates(templates); err != nil {
+		log.Printf("Ошибка сохранения шаблонов: %v", err)
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось сохранить шаблон", err))
+		return
+	}
+	app.notify("Шаблон «" + name + "» сохранён. Его можно выбрать при добавлении вакансии.")
+}
+
+// showTemplatesDialog позволяет переименовать и удалить шаблоны
+func (app *AppMainWindow) showTemplatesDialog() {
+	templates := loadTemplates()
+	var dlg *walk.Dialog
+	var listLB *walk.ListBox
+
+	names := func() []string {
+		result := make([]string, len(templates))
+		for i, t := range templates {
+			result[i] = t.Name
+		}
+		return result
+	}
+	save := func() {
+		if err := saveTemplates(templates); err != nil {
+			log.Printf("Ошибка сохранения шаблонов: %v", err)
+			showError(dlg, "Ошибка", wrapError("Не удалось сохранить шаблоны", err))
+		}
+		listLB.SetModel(names())
+	}
+
+	if _, err := (Dialog{
+		AssignTo:   &dlg,
+		Title:      "Шаблоны вакансий",
+		MinSize:    Size{Width: 380, Height: 320},
+		Layout:     VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: "Шаблоны для быстрого добавления вакансий:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			ListBox{AssignTo: &listLB, Model: names(), Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Переименовать...",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							i := listLB.CurrentIndex()
+							if i < 0 {
+								return
+							}
+							if name, ok := promptText(dlg, "Переименовать шаблон", "Новое название:", templates[i].Name); ok {
+								templates[i].Name = name
+								save()
+							}
+						},
+					},
+					PushButton{
+						Text:       "Удалить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							i := listLB.CurrentIndex()
+							if i < 0 {
+								return
+							}
+							if walk.DlgCmdYes == walk.MsgBox(dlg, "Удаление шаблона", "Удалить шаблон '"+templates[i].Name+"'?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) {
+								templates = append(templates[:i], templates[i+1:]...)
+								save()
+							}
+						},
+					},
+					HSpacer{},
+					PushButton{
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Accept() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
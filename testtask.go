@@ -0,0 +1,301 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Статус, который ставится вакансии при получении тестового задания
+const testTaskStatus = "Тестовое задание"
+
+// Идентификатор напоминания о сроке тестового задания: у вакансии оно одно
+const testTaskReminderID = "test-task"
+
+// За сколько до срока напоминать о сдаче тестового задания
+const testTaskRemindBefore = 24 * time.Hour
+
+// TestTask - тестовое задание по вакансии
+type TestTask struct {
+	ReceivedAt  time.Time `json:"receivedAt,omitzero"`  // Когда получено
+	DueAt       time.Time `json:"dueAt,omitzero"`       // Срок сдачи
+	Link        string    `json:"link,omitempty"`       // Репозиторий или ссылка на задание
+	HoursSpent  float64   `json:"hoursSpent,omitempty"` // Сколько часов потрачено
+	SubmittedAt time.Time `json:"submittedAt,omitzero"` // Когда отправлено
+	Feedback    string    `json:"feedback,omitempty"`   // Отзыв работодателя
+}
+
+// testTaskSummary - описание тестового задания для панели деталей
+func testTaskSummary(t *TestTask, now time.Time) string {
+	if t == nil {
+		return "Нет тестового задания."
+	}
+	var parts []string
+	if !t.ReceivedAt.IsZero() {
+		parts = append(parts, "получено "+t.ReceivedAt.Format("02.01.2006"))
+	}
+	switch {
+	case !t.SubmittedAt.IsZero():
+		parts = append(parts, "сдано "+t.SubmittedAt.Format("02.01.2006"))
+	case !t.DueAt.IsZero():
+		parts = append(parts, "сдать: "+formatDue(t.DueAt, now))
+	}
+	if t.HoursSpent > 0 {
+		parts = append(parts, fmt.Sprintf("потрачено %g ч", t.HoursSpent))
+	}
+	text := strings.Join(parts, ", ")
+	if text == "" {
+		text = "Тестовое задание без дат"
+	}
+	if t.Link != "" {
+		text += "\n" + t.Link
+	}
+	if t.Feedback != "" {
+		text += "\nОтзыв: " + t.Feedback
+	}
+	return text
+}
+
+// testTaskReminderDue - когда напомнить о сдаче: за сутки до срока, а если сутки уже прошли - к самому сроку
+func testTaskReminderDue(due, now time.Time) time.Time {
+	if at := due.Add(-testTaskRemindBefore); at.After(now) {
+		return at
+	}
+	return due
+}
+
+// syncTestTaskReminder приводит напоминание о сроке тестового задания в соответствие с самим заданием:
+// создаёт или переносит его при новом сроке, закрывает после сдачи и удаляет вместе с заданием
+func syncTestTaskReminder(v *Vacancy) {
+	idx := -1
+	for i, r := range v.Reminders {
+		if r.ID == testTaskReminderID {
+			idx = i
+			break
+		}
+	}
+	t := v.TestTask
+	if t == nil || t.DueAt.IsZero() {
+		if idx != -1 {
+			v.Reminders = append(v.Reminders[:idx], v.Reminders[idx+1:]...)
+		}
+		return
+	}
+	if !t.SubmittedAt.IsZero() {
+		if idx != -1 && v.Reminders[idx].DoneAt.IsZero() {
+			v.Reminders[idx].DoneAt = t.SubmittedAt
+		}
+		return
+	}
+
+	text := "Сдать тестовое задание до " + t.DueAt.Format("02.01.2006 15:04")
+	if idx == -1 {
+		v.Reminders = append(v.Reminders, Reminder{ID: testTaskReminderID, Text: text, DueAt: testTaskReminderDue(t.DueAt, time.Now())})
+		return
+	}
+	// Срок не менялся - оставляем напоминание как есть, в том числе отложенное или выполненное
+	if v.Reminders[idx].Text == text {
+		return
+	}
+	v.Reminders[idx] = Reminder{ID: testTaskReminderID, Text: text, DueAt: testTaskReminderDue(t.DueAt, time.Now())}
+}
+
+// changeTestTask меняет тестовое задание вакансии, обновляет напоминание о сроке и сохраняет файл
+func changeTestTask(title, company string, change func(v *Vacancy)) bool {
+	allVacanciesMutex.Lock()
+	found := false
+	for i := range allVacancies {
+		if sameVacancy(allVacancies[i].Title, allVacancies[i].Company, title, company) {
+			change(&allVacancies[i])
+			syncTestTaskReminder(&allVacancies[i])
+			found = true
+			break
+		}
+	}
+	allVacanciesMutex.Unlock()
+	if found {
+		saveVacancies()
+		vacancyEvents.Publish(VacancyEvent{Kind: VacancyUpdated, Title: title, Company: company})
+	}
+	return found
+}
+
+// promptTestTask редактирует тестовое задание. removed - пользователь удалил задание.
+func promptTestTask(owner walk.Form, vacancyTitle string, current *TestTask) (task TestTask, removed, accepted bool) {
+	var dlg *walk.Dialog
+	var receivedDE, dueDE, submittedDE *walk.DateEdit
+	var linkLE *walk.LineEdit
+	var hoursNE *walk.NumberEdit
+	var feedbackTE *walk.TextEdit
+	var acceptPB, cancelPB *walk.PushButton
+
+	task = TestTask{ReceivedAt: time.Now()}
+	if current != nil {
+		task = *current
+	}
+	label := func(text string) Widget {
+		return Label{Text: text, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}}
+	}
+
+	if _, err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Тестовое задание",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 460, Height: 420},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{Text: fmt.Sprintf("Тестовое задание по вакансии '%s'", vacancyTitle), TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 10}},
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true, Spacing: 6},
+				Children: []Widget{
+					label("Получено:"),
+					DateEdit{AssignTo: &receivedDE, Optional: true, Format: "dd.MM.yyyy", Date: task.ReceivedAt},
+					label("Сдать до:"),
+					DateEdit{AssignTo: &dueDE, Optional: true, Format: "dd.MM.yyyy HH:mm", Date: task.DueAt},
+					label("Ссылка / репозиторий:"),
+					LineEdit{AssignTo: &linkLE, Text: task.Link, CueBanner: "https://github.com/...", Font: Font{PointSize: 9}},
+					label("Потрачено, ч:"),
+					NumberEdit{AssignTo: &hoursNE, Value: task.HoursSpent, Decimals: 1, MinValue: 0, MaxValue: 500, SpinButtonsVisible: true, Font: Font{PointSize: 9}},
+					label("Сдано:"),
+					DateEdit{AssignTo: &submittedDE, Optional: true, Format: "dd.MM.yyyy", Date: task.SubmittedAt},
+				},
+			},
+			label("Отзыв работодателя:"),
+			TextEdit{AssignTo: &feedbackTE, Text: task.Feedback, VScroll: true, MinSize: Size{Height: 70}, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Удалить задание",
+						Visible:    current != nil,
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						OnClicked: func() {
+							if walk.MsgBox(dlg, "Тестовое задание", "Удалить тестовое задание вместе с напоминанием о сроке?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) == walk.DlgCmdYes {
+								removed, accepted = true, true
+								dlg.Accept()
+							}
+						},
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							task = TestTask{
+								ReceivedAt:  receivedDE.Date(),
+								DueAt:       dueDE.Date(),
+								Link:        strings.TrimSpace(linkLE.Text()),
+								HoursSpent:  hoursNE.Value(),
+								SubmittedAt: submittedDE.Date(),
+								Feedback:    strings.TrimSpace(feedbackTE.Text()),
+							}
+							if !task.ReceivedAt.IsZero() && !task.DueAt.IsZero() && task.DueAt.Before(task.ReceivedAt) {
+								walk.MsgBox(dlg, "Тестовое задание", "Срок сдачи раньше даты получения.", walk.MsgBoxIconWarning)
+								return
+							}
+							accepted = true
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(owner); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+	return task, removed, accepted
+}
+
+// editSelectedTestTask добавляет или редактирует тестовое задание выбранной вакансии.
+// Вакансия, ещё не дошедшая до тестового, переводится в статус "Тестовое задание".
+func (app *AppMainWindow) editSelectedTestTask() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		walk.MsgBox(app.MainWindow, "Тестовое задание", "Пожалуйста, выберите вакансию.", walk.MsgBoxIconInformation)
+		return
+	}
+	v := app.vacancyModel.items[idx]
+	task, removed, ok := promptTestTask(app.MainWindow, v.Title, v.TestTask)
+	if !ok {
+		return
+	}
+	found := changeTestTask(v.Title, v.Company, func(vac *Vacancy) {
+		if removed {
+			vac.TestTask = nil
+			return
+		}
+		vac.TestTask = &task
+		if indexOfString(possibleStatuses, vac.Status) < indexOfString(possibleStatuses, testTaskStatus) {
+			setVacancyStatus(vac, testTaskStatus)
+		}
+	})
+	if !found {
+		walk.MsgBox(app.MainWindow, "Ошибка", "Не удалось найти вакансию.", walk.MsgBoxIconError)
+	}
+}
+
+// submitSelectedTestTask отмечает тестовое задание выбранной вакансии сданным сегодня
+func (app *AppMainWindow) submitSelectedTestTask() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) || app.vacancyModel.items[idx].TestTask == nil {
+		walk.MsgBox(app.MainWindow, "Тестовое задание", "У выбранной вакансии нет тестового задания.", walk.MsgBoxIconInformation)
+		return
+	}
+	v := app.vacancyModel.items[idx]
+	changeTestTask(v.Title, v.Company, func(vac *Vacancy) {
+		if vac.TestTask != nil && vac.TestTask.SubmittedAt.IsZero() {
+			vac.TestTask.SubmittedAt = time.Now()
+		}
+	})
+}
+
+// openSelectedTestTaskLink открывает ссылку на тестовое задание в браузере
+func (app *AppMainWindow) openSelectedTestTaskLink() {
+	idx := app.vacancyTable.CurrentIndex()
+	if idx < 0 || idx >= len(app.vacancyModel.items) {
+		return
+	}
+	t := app.vacancyModel.items[idx].TestTask
+	if t == nil || t.Link == "" {
+		walk.MsgBox(app.MainWindow, "Тестовое задание", "Ссылка на тестовое задание не указана.", walk.MsgBoxIconInformation)
+		return
+	}
+	if err := openURL(t.Link); err != nil {
+		showError(app.MainWindow, "Ошибка", wrapError("Не удалось открыть ссылку", err))
+	}
+}
+
+// updateTestTaskSection показывает в панели деталей тестовое задание выбранной вакансии
+func (app *AppMainWindow) updateTestTaskSection(v Vacancy, hasSelection bool) {
+	if app.detailTestTaskLabel == nil {
+		return
+	}
+	text := ""
+	if hasSelection {
+		text = testTaskSummary(v.TestTask, time.Now())
+	}
+	app.detailTestTaskLabel.SetText(text)
+	app.editTestTaskPB.SetEnabled(hasSelection && detailsEditable())
+	if v.TestTask != nil {
+		app.editTestTaskPB.SetText("Изменить...")
+	} else {
+		app.editTestTaskPB.SetText("Добавить...")
+	}
+	app.submitTestTaskPB.SetEnabled(hasSelection && detailsEditable() && v.TestTask != nil && v.TestTask.SubmittedAt.IsZero())
+	app.openTestTaskPB.SetEnabled(hasSelection && v.TestTask != nil && v.TestTask.Link != "")
+}
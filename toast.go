@@ -0,0 +1,76 @@
+package main
+
+import (
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Всплывающая полоса внизу главного окна: короткое сообщение о сделанном и, если
+// нужно, кнопка действия (например, «Отменить»). Не требует нажатия OK и сама
+// скрывается через несколько секунд; новое сообщение заменяет предыдущее, а все
+// сообщения остаются в истории уведомлений.
+
+// toastBarWidget - полоса сообщений для разметки главного окна
+func (app *AppMainWindow) toastBarWidget() Widget {
+	button := func(assignTo **walk.PushButton, text string, onClicked walk.EventHandler) PushButton {
+		return PushButton{
+			AssignTo:   assignTo,
+			Text:       text,
+			OnClicked:  onClicked,
+			Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+			Font:       Font{Family: "Segoe UI", PointSize: 9, Bold: true},
+		}
+	}
+	var closePB *walk.PushButton
+	return Composite{
+		AssignTo:   &app.toastBar,
+		Visible:    false,
+		Layout:     HBox{Margins: Margins{Left: 10, Top: 4, Right: 10, Bottom: 4}, Spacing: 8},
+		Background: SolidColorBrush{Color: walk.RGB(50, 50, 50)},
+		Children: []Widget{
+			Label{AssignTo: &app.toastLabel, TextColor: walk.RGB(255, 255, 255), Font: Font{PointSize: 9}},
+			HSpacer{},
+			button(&app.toastActionPB, "", app.onToastAction),
+			button(&closePB, "✕", app.hideToast),
+		},
+	}
+}
+
+// showInAppToast показывает сообщение text; actionText и action - кнопка действия, пустой текст - без кнопки
+func (app *AppMainWindow) showInAppToast(text, actionText string, action func()) {
+	recordNotification(text) // Даже если окно ещё не создано: в истории сообщение останется
+	if app.toastBar == nil {
+		return
+	}
+	app.toastSeq++
+	seq := app.toastSeq
+	app.toastAction = action
+	app.toastLabel.SetText(text)
+	app.toastActionPB.SetText(actionText)
+	app.toastActionPB.SetVisible(actionText != "" && action != nil)
+	app.toastBar.SetVisible(true)
+	time.AfterFunc(time.Duration(toastTimeoutSeconds())*time.Second, func() {
+		app.Synchronize(func() {
+			if app.toastSeq == seq {
+				app.hideToast()
+			}
+		})
+	})
+}
+
+// hideToast скрывает полосу сообщений
+func (app *AppMainWindow) hideToast() {
+	app.toastAction = nil
+	app.toastBar.SetVisible(false)
+}
+
+// onToastAction выполняет действие кнопки и скрывает сообщение
+func (app *AppMainWindow) onToastAction() {
+	action := app.toastAction
+	app.hideToast()
+	if action != nil {
+		action()
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import "strings"
+
+// Транслитерация для поиска: запрос и текст приводятся к латинице, чтобы «гоу», «go»
+// и «razrabotchik» находили одно и то же независимо от алфавита описания.
+
+// cyrillicToLatin - упрощённая транслитерация, одинаковая для запроса и текста
+var cyrillicToLatin = map[rune]string{
+	'а': "a", 'б': "b", 'в': "v", 'г': "g", 'д': "d", 'е': "e", 'ё': "e", 'ж': "zh",
+	'з': "z", 'и': "i", 'й': "y", 'к': "k", 'л': "l", 'м': "m", 'н': "n", 'о': "o",
+	'п': "p", 'р': "r", 'с': "s", 'т': "t", 'у': "u", 'ф': "f", 'х': "h", 'ц': "ts",
+	'ч': "ch", 'ш': "sh", 'щ': "sch", 'ъ': "", 'ы': "y", 'ь': "", 'э': "e", 'ю': "yu",
+	'я': "ya",
+}
+
+// bilingualTerms - русские написания технологий и ролей, которые транслитерация не сводит к английским
+var bilingualTerms = map[string]string{
+	"гоу": "go", "го": "go", "голанг": "golang",
+	"джава": "java", "джаваскрипт": "javascript", "тайпскрипт": "typescript",
+	"питон": "python", "пайтон": "python",
+	"сишарп": "csharp", "дотнет": "dotnet", "плюсы": "cpp",
+	"раст": "rust", "котлин": "kotlin", "свифт": "swift", "скала": "scala", "пхп": "php",
+	"реакт": "react", "ангуляр": "angular", "вью": "vue", "нода": "node",
+	"докер": "docker", "кубер": "kubernetes", "кубернетес": "kubernetes", "линукс": "linux",
+	"постгрес": "postgres", "постгре": "postgres", "монга": "mongo", "редис": "redis", "кафка": "kafka",
+	"фронтенд": "frontend", "фронт": "frontend", "бэкенд": "backend", "бекенд": "backend", "бэк": "backend",
+	"фулстек": "fullstack", "девопс": "devops", "тимлид": "teamlead", "техлид": "techlead",
+	"джун": "junior", "джуниор": "junior", "мидл": "middle", "сеньор": "senior", "синьор": "senior",
+	"куа": "qa",
+}
+
+// Обозначения, которые разбиение на слова испортило бы
+var searchSymbolTerms = strings.NewReplacer("c#", " csharp ", "c++", " cpp ", ".net", " dotnet ")
+
+// transliterate переводит кириллицу слова в латиницу
+func transliterate(word string) string {
+	var b strings.Builder
+	for _, r := range word {
+		if lat, ok := cyrillicToLatin[r]; ok {
+			b.WriteString(lat)
+		} else {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+// transliterateWords приводит текст к словам в латинице, разделённым пробелом; stem - сводить слова к основе
+func transliterateWords(text string, stem bool) string {
+	words := searchWords(searchSymbolTerms.Replace(strings.ToLower(text)))
+	for i, w := range words {
+		if term, ok := bilingualTerms[w]; ok {
+			w = term
+		}
+		if stem {
+			w = stemWord(w)
+			if term, ok := bilingualTerms[w]; ok { // «питоне» -> «питон» -> python
+				w = stemWord(term)
+			}
+		}
+		words[i] = transliterate(w)
+	}
+	return strings.Join(words, " ")
+}
+
+// normalizeSearchText - нормализованный текст для поиска: латиница, синонимы пользователя и, если stem, основы слов
+func normalizeSearchText(text string, stem bool) string {
+	return cachedNormalized(text, stem, func() string {
+		return currentSynonyms(stem).apply(transliterateWords(text, stem))
+	})
+}
+
+// normalizedPattern - как искать нормализованный запрос в тексте " слово слово ":
+// с начала слова, а короткие запросы - только целым словом, иначе «go» нашлось бы в «gorod»
+func normalizedPattern(norm string) string {
+	if norm == "" {
+		return ""
+	}
+	if len([]rune(norm)) <= 3 {
+		return " " + norm + " "
+	}
+	return " " + norm
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"log"
+	"os"
+
+	"github.com/lxn/walk"
+	"golang.org/x/sys/windows/registry"
+)
+
+// Запуск вместе с Windows: приложение прописывается в автозагрузку текущего пользователя
+// с параметром --tray и стартует без окна, значком в области уведомлений. До открытия
+// окна работают только напоминания и отслеживаемые поиски с лентами вакансий, а проверка
+// обновлений, курсы валют и прочее запускаются, когда пользователь откроет окно.
+
+// Ключ автозагрузки текущего пользователя
+const autostartRunKey = `Software\Microsoft\Windows\CurrentVersion\Run`
+
+// Имя значения в ключе автозагрузки
+const autostartValueName = "JobSearchApp"
+
+// autostartCommand - команда запуска из автозагрузки: свёрнуто в трей, с текущим профилем
+func autostartCommand() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	cmd := `"` + exe + `" --tray`
+	if activeProfile.Name != defaultProfileName && !viewOnly {
+		cmd += ` --profile "` + activeProfile.Name + `"`
+	}
+	if startup.DataDir != "" {
+		cmd += ` --data-dir "` + startup.DataDir + `"`
+	}
+	return cmd, nil
+}
+
+// setAutostart добавляет приложение в автозагрузку Windows или убирает из неё
+func setAutostart(on bool) error {
+	k, _, err := registry.CreateKey(registry.CURRENT_USER, autostartRunKey, registry.QUERY_VALUE|registry.SET_VALUE)
+	if err != nil {
+		return err
+	}
+	defer k.Close()
+	if !on {
+		if err := k.DeleteValue(autostartValueName); err != nil && !errors.Is(err, registry.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	cmd, err := autostartCommand()
+	if err != nil {
+		return err
+	}
+	if current, _, err := k.GetStringValue(autostartValueName); err == nil && current == cmd {
+		return nil
+	}
+	return k.SetStringValue(autostartValueName, cmd)
+}
+
+// syncAutostart приводит автозагрузку в соответствие с настройкой, например после переноса программы в другую папку
+func syncAutostart() {
+	if viewOnly || !appSettings.StartWithWindows {
+		return
+	}
+	if err := setAutostart(true); err != nil {
+		log.Printf("Ошибка обновления автозагрузки: %v", err)
+	}
+}
+
+// applyAutostart записывает изменённую настройку автозагрузки в реестр
+func (app *AppMainWindow) applyAutostart() {
+	if err := setAutostart(appSettings.StartWithWindows); err != nil {
+		log.Printf("Ошибка настройки автозагрузки: %v", err)
+		app.notify("Не удалось изменить автозагрузку Windows: " + err.Error())
+		return
+	}
+	log.Printf("Запуск вместе с Windows: %v", appSettings.StartWithWindows)
+}
+
+// enterTray оставляет приложение значком в области уведомлений до открытия окна
+func (app *AppMainWindow) enterTray() {
+	ni := app.ensureNotifyIcon()
+	if ni == nil {
+		app.showMainWindow() // Без значка окно потом не открыть
+		return
+	}
+	app.inTray = true
+	ni.SetToolTip("Поисковик Вакансий - напоминания и отслеживаемые поиски")
+	if ni.ContextMenu().Actions().Len() == 0 {
+		open := walk.NewAction()
+		open.SetText("Открыть")
+		open.Triggered().Attach(app.showMainWindow)
+		exit := walk.NewAction()
+		exit.SetText("Выход")
+		exit.Triggered().Attach(func() { app.MainWindow.Close() })
+		ni.ContextMenu().Actions().Add(open)
+		ni.ContextMenu().Actions().Add(exit)
+	}
+	log.Print("Приложение запущено в области уведомлений")
+}
+
+// showMainWindow показывает главное окно; при первом открытии из трея спрашивает PIN
+// и запускает то, что было отложено. Заблокированное окно откроется только после ввода PIN.
+func (app *AppMainWindow) showMainWindow() {
+	if app.locked {
+		activateUnlockDialog() // Окно покажет lockApp после разблокировки
+		return
+	}
+	firstShow := false
+	if app.inTray {
+		app.locked = true
+		ok := unlockAtStartup()
+		app.locked = false
+		if !ok {
+			return // Окно остаётся закрытым, приложение - в трее
+		}
+		app.inTray = false
+		app.notifyIcon.SetToolTip("Поисковик Вакансий")
+		firstShow = true
+	}
+	app.MainWindow.Show()
+	app.MainWindow.Activate()
+	if firstShow {
+		app.startInteractive()
+	}
+	app.replayLaunchArgs()
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"log"
+	"reflect"
+	"slices"
+)
+
+// Отмена последних операций со списком вакансий. Операция запоминается как разница
+// между списком до неё и после: изменённые вакансии возвращаются к прежнему виду,
+// удалённые - на прежнее место, добавленные убираются. Так отменяются и побочные
+// изменения, например ссылки на удалённую вакансию из связанных.
+
+// undoLimit - сколько последних операций можно отменить
+const undoLimit = 20
+
+// undoChange - вакансия до и после операции
+type undoChange struct {
+	Index  int      // Место в списке до операции - туда вернётся удалённая вакансия
+	Before *Vacancy // nil - вакансию добавила операция
+	After  *Vacancy // nil - вакансию удалила операция
+}
+
+// undoEntry - операция, которую можно отменить
+type undoEntry struct {
+	Seq     int    // Номер операции: по нему кнопка «Отменить» узнаёт свою операцию
+	Text    string // Что сделано, например "Вакансия «...» удалена"
+	Changes []undoChange
+}
+
+var (
+	undoStack []undoEntry
+	undoSeq   int // Номер последней запомненной операции
+)
+
+// diffVacancyLists - изменения списка вакансий между before и after
+func diffVacancyLists(before, after []Vacancy) []undoChange {
+	current := make(map[string]int, len(after))
+	for i, v := range after {
+		current[statusTrackerKey(v.Title, v.Company)] = i
+	}
+	var changes []undoChange
+	for i := range before {
+		key := statusTrackerKey(before[i].Title, before[i].Company)
+		j, ok := current[key]
+		delete(current, key)
+		switch {
+		case !ok:
+			changes = append(changes, undoChange{Index: i, Before: &before[i]})
+		case !reflect.DeepEqual(before[i], after[j]):
+			changes = append(changes, undoChange{Index: i, Before: &before[i], After: &after[j]})
+		}
+	}
+	for _, j := range current {
+		changes = append(changes, undoChange{Index: -1, After: &after[j]})
+	}
+	return changes
+}
+
+// recordUndo запоминает операцию text: before - список вакансий до неё. Возвращает номер
+// операции или 0, если список не изменился.
+func recordUndo(text string, before []Vacancy) int {
+	changes := diffVacancyLists(before, snapshotVacancies())
+	if len(changes) == 0 {
+		return 0
+	}
+	undoSeq++
+	undoStack = append(undoStack, undoEntry{Seq: undoSeq, Text: text, Changes: changes})
+	if len(undoStack) > undoLimit {
+		undoStack = slices.Delete(undoStack, 0, len(undoStack)-undoLimit)
+	}
+	return undoSeq
+}
+
+// undoLast отменяет последнюю операцию. Вакансии, которые с тех пор переименовали
+// или удалили, остаются как есть.
+func (app *AppMainWindow) undoLast() {
+	if len(undoStack) == 0 {
+		app.showInAppToast("Нечего отменять.", "", nil)
+		return
+	}
+	entry := undoStack[len(undoStack)-1]
+	undoStack = undoStack[:len(undoStack)-1]
+
+	var restored []Vacancy
+	allVacanciesMutex.Lock()
+	// Сначала убираем добавленное и возвращаем изменённое, затем по порядку вставляем удалённое
+	for _, c := range entry.Changes {
+		if c.After == nil {
+			continue
+		}
+		i := app.findVacancyIndexInAllExt(c.After.Title, c.After.Company)
+		switch {
+		case i == -1:
+		case c.Before == nil:
+			allVacancies = slices.Delete(allVacancies, i, i+1)
+		default:
+			allVacancies[i] = *c.Before
+			restored = append(restored, *c.Before)
+		}
+	}
+	deleted := slices.DeleteFunc(slices.Clone(entry.Changes), func(c undoChange) bool { return c.After != nil })
+	slices.SortFunc(deleted, func(a, b undoChange) int { return a.Index - b.Index })
+	for _, c := range deleted {
+		if app.findVacancyIndexInAllExt(c.Before.Title, c.Before.Company) != -1 {
+			continue // Такую вакансию уже добавили заново
+		}
+		allVacancies = slices.Insert(allVacancies, min(c.Index, len(allVacancies)), *c.Before)
+		restored = append(restored, *c.Before)
+	}
+	allVacanciesMutex.Unlock()
+
+	saveVacancies()
+	log.Printf("Отменено: %s", entry.Text)
+	e := VacancyEvent{Kind: VacanciesImported}
+	if len(restored) == 1 {
+		e = vacancyEvent(VacancyUpdated, restored[0])
+	}
+	vacancyEvents.Publish(e)
+	app.showInAppToast("Отменено: "+entry.Text, "", nil)
+}
+
+// showUndoToast сообщает о выполненной операции seq и предлагает её отменить
+func (app *AppMainWindow) showUndoToast(text string, seq int) {
+	if seq == 0 {
+		app.notify(text)
+		return
+	}
+	app.showInAppToast(text, "Отменить", func() { app.undoSeq(seq) })
+}
+
+// undoSeq отменяет операцию seq, только если после неё не было других: кнопка
+// на старом сообщении не должна отменить чужую операцию
+func (app *AppMainWindow) undoSeq(seq int) {
+	if len(undoStack) == 0 || undoStack[len(undoStack)-1].Seq != seq {
+		app.notify("Это действие уже отменено или после него были другие изменения.")
+		return
+	}
+	app.undoLast()
+}
@@ -0,0 +1,389 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Версия приложения; при сборке релиза задаётся через -ldflags "-X main.appVersion=..."
+var appVersion = "1.0.0"
+
+// Адрес последнего релиза по умолчанию (GitHub Releases API)
+const defaultUpdateURL = "https://api.github.com/repos/Project-Golang-2025/projectgolang/releases/latest"
+
+// Сколько ждать ответа сервера обновлений
+const updateCheckTimeout = 15 * time.Second
+
+// ReleaseAsset - файл, приложенный к релизу
+type ReleaseAsset struct {
+	Name        string `json:"name"`
+	DownloadURL string `json:"browser_download_url"`
+	Size        int64  `json:"size"`
+	Digest      string `json:"digest"` // "sha256:...", если сервер его сообщает
+}
+
+// sha256Digest возвращает контрольную сумму SHA-256 файла в hex, если сервер её сообщил
+func (a ReleaseAsset) sha256Digest() (string, bool) {
+	want, ok := strings.CutPrefix(a.Digest, "sha256:")
+	if !ok || len(want) != sha256.Size*2 {
+		return "", false
+	}
+	if _, err := hex.DecodeString(want); err != nil {
+		return "", false
+	}
+	return want, true
+}
+
+// ReleaseInfo - описание релиза в формате GitHub Releases API
+type ReleaseInfo struct {
+	TagName    string         `json:"tag_name"`
+	Name       string         `json:"name"`
+	Body       string         `json:"body"`
+	HTMLURL    string         `json:"html_url"`
+	Draft      bool           `json:"draft"`
+	Prerelease bool           `json:"prerelease"`
+	Assets     []ReleaseAsset `json:"assets"`
+}
+
+// Version возвращает номер версии релиза без префикса "v"
+func (r ReleaseInfo) Version() string {
+	return strings.TrimPrefix(strings.TrimSpace(r.TagName), "v")
+}
+
+// installer возвращает установщик из файлов релиза, если он есть
+func (r ReleaseInfo) installer() (ReleaseAsset, bool) {
+	for _, a := range r.Assets {
+		switch strings.ToLower(filepath.Ext(a.Name)) {
+		case ".exe", ".msi":
+			return a, true
+		}
+	}
+	return ReleaseAsset{}, false
+}
+
+// updateURL возвращает адрес проверки обновлений из настроек
+func updateURL() string {
+	if appSettings.UpdateURL != "" {
+		return appSettings.UpdateURL
+	}
+	return defaultUpdateURL
+}
+
+// compareVersions сравнивает версии вида 1.2.3 по числовым частям: -1, 0 или 1.
+// Суффиксы вроде "-beta" после числа игнорируются.
+func compareVersions(a, b string) int {
+	pa := strings.Split(strings.TrimPrefix(a, "v"), ".")
+	pb := strings.Split(strings.TrimPrefix(b, "v"), ".")
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na = leadingNumber(pa[i])
+		}
+		if i < len(pb) {
+			nb = leadingNumber(pb[i])
+		}
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// leadingNumber разбирает число в начале строки ("3-beta" -> 3)
+func leadingNumber(s string) int {
+	end := 0
+	for end < len(s) && s[end] >= '0' && s[end] <= '9' {
+		end++
+	}
+	n, _ := strconv.Atoi(s[:end])
+	return n
+}
+
+// fetchLatestRelease запрашивает описание последнего релиза
+func fetchLatestRelease(ctx context.Context, url string) (ReleaseInfo, error) {
+	var release ReleaseInfo
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return release, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("User-Agent", "projectgolang/"+appVersion)
+
+	resp, err := updateHTTPClient().Do(req)
+	if err != nil {
+		return release, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return release, &httpStatusError{Code: resp.StatusCode, Status: resp.Status}
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
+		return release, fmt.Errorf("ошибка разбора ответа: %w", err)
+	}
+	if release.Version() == "" {
+		return release, errors.New("в ответе нет номера версии")
+	}
+	return release, nil
+}
+
+// downloadInstaller скачивает установщик во временную папку, сообщая прогресс в байтах,
+// и сверяет контрольную сумму. Установщик без контрольной суммы не скачивается: запускать
+// непроверенный файл с правами установки нельзя.
+func downloadInstaller(ctx context.Context, asset ReleaseAsset, progress func(done, total int64)) (string, error) {
+	want, ok := asset.sha256Digest()
+	if !ok {
+		return "", errors.New("сервер обновлений не сообщил контрольную сумму установщика")
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", asset.DownloadURL, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("User-Agent", "projectgolang/"+appVersion)
+	resp, err := updateHTTPClient().Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", &httpStatusError{Code: resp.StatusCode, Status: resp.Status}
+	}
+
+	path := filepath.Join(os.TempDir(), filepath.Base(asset.Name))
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	total := resp.ContentLength
+	if total <= 0 {
+		total = asset.Size
+	}
+	var done int64
+	buf := make([]byte, 64*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, err := f.Write(buf[:n]); err != nil {
+				f.Close()
+				return "", err
+			}
+			hash.Write(buf[:n])
+			done += int64(n)
+			progress(done, total)
+		}
+		if readErr == io.EOF {
+			break
+		}
+		if readErr != nil {
+			f.Close()
+			return "", readErr
+		}
+	}
+	if err := f.Close(); err != nil {
+		return "", err
+	}
+
+	if got := hex.EncodeToString(hash.Sum(nil)); !strings.EqualFold(got, want) {
+		os.Remove(path)
+		return "", errors.New("контрольная сумма скачанного файла не совпадает")
+	}
+	return path, nil
+}
+
+// launchInstaller запускает скачанный установщик
+func launchInstaller(path string) error {
+	if strings.EqualFold(filepath.Ext(path), ".msi") {
+		return exec.Command("msiexec", "/i", path).Start()
+	}
+	return exec.Command(path).Start()
+}
+
+// checkForUpdates проверяет наличие новой версии. При ручной проверке (manual)
+// сообщает и об отсутствии обновлений, и об ошибках; при автоматической молчит
+// и не показывает версию, которую пользователь решил пропустить.
+func (app *AppMainWindow) checkForUpdates(manual bool) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), updateCheckTimeout)
+		defer cancel()
+		release, err := fetchLatestRelease(ctx, updateURL())
+
+		app.Synchronize(func() {
+			if err != nil {
+				log.Printf("Ошибка проверки обновлений: %v", err)
+				if manual {
+					showError(app.MainWindow, "Обновления", wrapError("Не удалось проверить обновления", err))
+				}
+				return
+			}
+			newer := !release.Draft && !release.Prerelease && compareVersions(release.Version(), appVersion) > 0
+			if !newer {
+				if manual {
+					app.notify("У вас установлена последняя версия (" + appVersion + ").")
+				}
+				return
+			}
+			if !manual && release.Version() == appSettings.SkippedVersion {
+				return
+			}
+			app.showUpdateDialog(release)
+		})
+	}()
+}
+
+// showUpdateDialog показывает список изменений новой версии и предлагает её установить
+func (app *AppMainWindow) showUpdateDialog(release ReleaseInfo) {
+	var dlg *walk.Dialog
+	var installPB, skipPB *walk.PushButton
+	var progressBar *walk.ProgressBar
+	var autoCheckCB *walk.CheckBox
+	asset, hasInstaller := release.installer()
+	_, verifiable := asset.sha256Digest()
+	unverified := hasInstaller && !verifiable
+	hasInstaller = hasInstaller && verifiable
+
+	changelog := strings.TrimSpace(release.Body)
+	if changelog == "" {
+		changelog = "Описание изменений не указано."
+	}
+	title := release.Name
+	if title == "" {
+		title = release.TagName
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	if _, err := (Dialog{
+		AssignTo:   &dlg,
+		Title:      "Доступна новая версия",
+		MinSize:    Size{Width: 520, Height: 420},
+		Layout:     VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      fmt.Sprintf("Вышла версия %s (у вас %s): %s", release.Version(), appVersion, title),
+				TextColor: currentTheme.Text,
+				Font:      Font{Bold: true, PointSize: 10},
+			},
+			Label{Text: "Что нового:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+			TextEdit{Text: strings.ReplaceAll(changelog, "\n", "\r\n"), ReadOnly: true, VScroll: true, Font: Font{PointSize: 9}},
+			Label{
+				Text:      "Сервер не сообщил контрольную сумму установщика, поэтому установить обновление из приложения нельзя.\nСкачайте его со страницы релиза.",
+				Visible:   unverified,
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			ProgressBar{AssignTo: &progressBar, Visible: false},
+			CheckBox{
+				AssignTo: &autoCheckCB,
+				Text:     "Проверять обновления при запуске",
+				Checked:  !appSettings.SkipUpdateCheck,
+				OnCheckedChanged: func() {
+					appSettings.SkipUpdateCheck = !autoCheckCB.Checked()
+					saveSettings()
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						AssignTo:   &skipPB,
+						Text:       "Пропустить эту версию",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10},
+						OnClicked: func() {
+							appSettings.SkippedVersion = release.Version()
+							saveSettings()
+							dlg.Cancel()
+						},
+					},
+					HSpacer{},
+					PushButton{
+						Text:       "Страница релиза",
+						Visible:    release.HTMLURL != "",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10},
+						OnClicked: func() {
+							if err := openFileExternally(release.HTMLURL); err != nil {
+								log.Printf("Ошибка открытия страницы релиза: %v", err)
+							}
+						},
+					},
+					PushButton{
+						AssignTo:   &installPB,
+						Text:       "Скачать и установить",
+						Visible:    hasInstaller,
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							installPB.SetEnabled(false)
+							skipPB.SetEnabled(false)
+							progressBar.SetVisible(true)
+							progressBar.SetRange(0, 100)
+							go func() {
+								lastPercent := -1
+								path, err := downloadInstaller(ctx, asset, func(done, total int64) {
+									if total <= 0 {
+										return
+									}
+									// Окно обновляется только при смене целого процента, а не на каждые 64 КБ
+									if percent := int(done * 100 / total); percent != lastPercent {
+										lastPercent = percent
+										dlg.Synchronize(func() { progressBar.SetValue(percent) })
+									}
+								})
+								dlg.Synchronize(func() {
+									if err != nil {
+										if ctx.Err() != nil {
+											return // Окно закрыто - загрузка отменена
+										}
+										log.Printf("Ошибка загрузки обновления: %v", err)
+										showError(dlg, "Обновления", wrapError("Не удалось скачать обновление", err))
+										installPB.SetEnabled(true)
+										skipPB.SetEnabled(true)
+										progressBar.SetVisible(false)
+										return
+									}
+									if err := launchInstaller(path); err != nil {
+										log.Printf("Ошибка запуска установщика: %v", err)
+										showError(dlg, "Обновления", wrapError("Не удалось запустить установщик", err))
+										return
+									}
+									dlg.Accept()
+									app.MainWindow.Close() // Установщику нужно заменить файлы работающего приложения
+								})
+							}()
+						},
+					},
+					PushButton{
+						Text:       "Позже",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Run(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+	}
+}
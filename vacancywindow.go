@@ -0,0 +1,258 @@
+package main
+
+import (
+	"log"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Отдельные окна вакансий: двойной щелчок по строке таблицы открывает вакансию в своём
+// окне, и несколько таких окон можно поставить рядом, чтобы сравнить вакансии, не
+// переключая выделение. Окно правит те же поля, что панель деталей, и обновляется,
+// когда вакансию меняют в другом месте, если в нём нет несохранённых правок.
+type VacancyWindow struct {
+	*walk.MainWindow
+	app          *AppMainWindow
+	title        string // Название и компания открытой вакансии
+	company      string
+	form         *vacancyWindowFormData
+	binder       *walk.DataBinder
+	stateLabel   *walk.Label
+	subscription int
+}
+
+// vacancyWindowFormData - форма отдельного окна. ReadOnly - обратное Editable для многострочных
+// полей: их не выключаем, а делаем только для чтения, чтобы текст можно было прокручивать и копировать.
+type vacancyWindowFormData struct {
+	vacancyFormData
+	Editable bool
+	ReadOnly bool
+}
+
+// vacancyWindows - открытые окна по ключу statusTrackerKey
+var vacancyWindows = map[string]*VacancyWindow{}
+
+// openSelectedVacancyWindow открывает выбранную в таблице вакансию в отдельном окне
+func (app *AppMainWindow) openSelectedVacancyWindow() {
+	v, ok := app.vacancyAt(app.vacancyTable.CurrentIndex())
+	if !ok {
+		walk.MsgBox(app.MainWindow, "Отдельное окно", "Пожалуйста, выберите вакансию.", walk.MsgBoxIconInformation)
+		return
+	}
+	app.openVacancyWindow(v)
+}
+
+// openVacancyWindow показывает вакансию в отдельном окне; уже открытое окно выводится на передний план
+func (app *AppMainWindow) openVacancyWindow(v Vacancy) {
+	key := statusTrackerKey(v.Title, v.Company)
+	if w, ok := vacancyWindows[key]; ok {
+		w.Show()
+		w.Activate()
+		return
+	}
+
+	w := &VacancyWindow{app: app, title: v.Title, company: v.Company, form: &vacancyWindowFormData{}}
+	w.fill(v)
+	label := func(text string) Label {
+		return Label{Text: text, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}}
+	}
+	if err := (MainWindow{
+		AssignTo:   &w.MainWindow,
+		Title:      v.Title + " — " + v.Company,
+		Size:       Size{Width: 520, Height: 680},
+		Layout:     VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 6},
+		Background: SolidColorBrush{Color: currentTheme.Background},
+		DataBinder: DataBinder{
+			AssignTo:   &w.binder,
+			DataSource: w.form,
+		},
+		Children: []Widget{
+			Label{Text: Bind("Title"), TextColor: currentTheme.Text, Font: Font{PointSize: 11, Bold: true}},
+			Label{Text: Bind("Company"), TextColor: currentTheme.Text, Font: Font{PointSize: 10}},
+			Label{AssignTo: &w.stateLabel, Visible: false, TextColor: walk.RGB(170, 90, 0), Font: Font{PointSize: 9}},
+			Composite{
+				Layout: Grid{Columns: 2, MarginsZero: true, Spacing: 6},
+				Children: []Widget{
+					label("Статус:"),
+					ComboBox{Model: possibleStatuses, Value: Bind("Status"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+					label("Уровень опыта:"),
+					ComboBox{Model: possibleExperienceLevels, Value: Bind("ExperienceLevel"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+					label("Зарплата:"),
+					LineEdit{Text: Bind("Salary"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+					label("Ключевые слова:"),
+					LineEdit{Text: Bind("Keywords"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+					label("URL Источника:"),
+					LineEdit{Text: Bind("SourceURL"), Enabled: Bind("Editable"), Font: Font{PointSize: 9}},
+				},
+			},
+			label("Описание:"),
+			TextEdit{Text: Bind("Description"), ReadOnly: Bind("ReadOnly"), VScroll: true, StretchFactor: 3, Font: Font{PointSize: 9}},
+			label("Заметки:"),
+			TextEdit{Text: Bind("Notes"), ReadOnly: Bind("ReadOnly"), VScroll: true, StretchFactor: 1, Font: Font{PointSize: 9}},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						Text:       "Сохранить",
+						Enabled:    Bind("Editable"),
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  w.save,
+					},
+					PushButton{
+						Text:       "Закрыть",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { w.Close() },
+					},
+				},
+			},
+		},
+	}).Create(); err != nil {
+		log.Print("Ошибка создания окна вакансии: ", err)
+		return
+	}
+
+	vacancyWindows[key] = w
+	w.subscription = vacancyEvents.Subscribe(w.onVacancyEvent)
+	w.Closing().Attach(func(canceled *bool, reason walk.CloseReason) {
+		if w.binder.Dirty() && w.form.Editable &&
+			walk.MsgBox(w, "Отдельное окно", "Закрыть окно без сохранения изменений?", walk.MsgBoxYesNo|walk.MsgBoxIconQuestion) != walk.DlgCmdYes {
+			*canceled = true
+			return
+		}
+		vacancyEvents.Unsubscribe(w.subscription)
+		delete(vacancyWindows, statusTrackerKey(w.title, w.company))
+	})
+	w.Show()
+}
+
+// fill заполняет форму окна; в режиме презентации показывает вакансию без зарплаты и контактов
+func (w *VacancyWindow) fill(v Vacancy) {
+	if presentationMode {
+		v = redactVacancy(v)
+	}
+	w.form.vacancyFormData = *newVacancyFormData(v)
+	w.setEditable(detailsEditable())
+}
+
+func (w *VacancyWindow) setEditable(editable bool) {
+	w.form.Editable = editable
+	w.form.ReadOnly = !editable
+}
+
+// reload перечитывает вакансию из списка. false - вакансии больше нет.
+func (w *VacancyWindow) reload() bool {
+	allVacanciesMutex.Lock()
+	i := w.app.findVacancyIndexInAllExt(w.title, w.company)
+	var v Vacancy
+	if i != -1 {
+		v = allVacancies[i]
+	}
+	allVacanciesMutex.Unlock()
+	if i == -1 {
+		w.setEditable(false)
+		w.stateLabel.SetText("Вакансия удалена или переименована - изменения сохранить нельзя.")
+		w.stateLabel.SetVisible(true)
+		w.binder.Reset()
+		return false
+	}
+	w.fill(v)
+	w.stateLabel.SetVisible(false)
+	w.binder.Reset()
+	return true
+}
+
+// onVacancyEvent обновляет окно после изменений в другом месте, не затирая несохранённые правки
+func (w *VacancyWindow) onVacancyEvent(e VacancyEvent) {
+	if e.Kind != VacanciesImported && !sameVacancy(e.Title, e.Company, w.title, w.company) {
+		// Чужое событие важно, только если им переименовали или удалили эту вакансию
+		allVacanciesMutex.Lock()
+		exists := w.app.findVacancyIndexInAllExt(w.title, w.company) != -1
+		allVacanciesMutex.Unlock()
+		if exists || !w.form.Editable {
+			return
+		}
+	}
+	if w.binder.Dirty() && w.form.Editable {
+		w.stateLabel.SetText("Вакансию изменили в другом окне. При сохранении изменения будут перезаписаны.")
+		w.stateLabel.SetVisible(true)
+		return
+	}
+	w.reload()
+}
+
+// save записывает правки окна в список вакансий
+func (w *VacancyWindow) save() {
+	if !detailsEditable() {
+		return // В окне могут быть точки вместо настоящих значений
+	}
+	if err := w.binder.Submit(); err != nil {
+		log.Print("Form submit error: ", err)
+		return
+	}
+	form := w.form.vacancyFormData
+
+	allVacanciesMutex.Lock()
+	i := w.app.findVacancyIndexInAllExt(w.title, w.company)
+	var current Vacancy
+	if i != -1 {
+		current = allVacancies[i]
+	}
+	allVacanciesMutex.Unlock()
+	if i == -1 {
+		walk.MsgBox(w, "Ошибка", "Не удалось найти оригинальную вакансию для обновления.", walk.MsgBoxIconError)
+		return
+	}
+	// Модальные вопросы задаём до блокировки списка
+	if !confirmApplyAfterRejection(w, current, form.Status) {
+		return
+	}
+	var rejectionReason, rejectionComment string
+	rejectionAnswered := false
+	if form.Status == rejectedStatus && current.Status != rejectedStatus {
+		rejectionReason, rejectionComment, rejectionAnswered = promptRejectionReason(w, current.Title)
+	}
+
+	allVacanciesMutex.Lock()
+	i = w.app.findVacancyIndexInAllExt(w.title, w.company)
+	if i == -1 {
+		allVacanciesMutex.Unlock()
+		walk.MsgBox(w, "Ошибка", "Не удалось найти оригинальную вакансию для обновления.", walk.MsgBoxIconError)
+		return
+	}
+	updated := allVacancies[i]
+	if updated.Status != form.Status {
+		setVacancyStatus(&updated, form.Status)
+		if rejectionAnswered {
+			updated.RejectionReason = rejectionReason
+			updated.RejectionComment = rejectionComment
+		}
+	}
+	// Название и компания в окне не редактируются
+	form.Title, form.Company = updated.Title, updated.Company
+	form.applyTo(&updated)
+	changed := vacancyFieldsDiffer(allVacancies[i], updated)
+	if changed {
+		allVacancies[i] = updated
+	}
+	allVacanciesMutex.Unlock()
+
+	if !changed {
+		w.reload()
+		return
+	}
+	saveVacancies()
+	log.Printf("Вакансия '%s' обновлена в отдельном окне.", updated.Title)
+	w.binder.Reset() // Правки сохранены - событие ниже перечитает окно
+	vacancyEvents.Publish(vacancyEvent(VacancyUpdated, updated))
+}
+
+// refreshVacancyWindows перечитывает все отдельные окна, например после смены режима презентации
+func refreshVacancyWindows() {
+	for _, w := range vacancyWindows {
+		w.reload()
+	}
+}
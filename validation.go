@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/lxn/walk"
+)
+
+// issueSeverity - насколько серьёзна проблема в поле
+type issueSeverity int
+
+const (
+	issueWarning issueSeverity = iota // Предупреждение, сохранить можно
+	issueError                        // Ошибка, сохранение невозможно
+)
+
+// Поля вакансии, к которым привязываются проблемы
+const (
+	fieldTitle       = "title"
+	fieldCompany     = "company"
+	fieldSourceURL   = "sourceURL"
+	fieldSalary      = "salary"
+	fieldDescription = "description"
+	fieldNotes       = "notes"
+)
+
+// FieldIssue - проблема в одном поле формы
+type FieldIssue struct {
+	Field    string
+	Severity issueSeverity
+	Message  string
+}
+
+// Мягкие ограничения длины полей (в символах): превышение - предупреждение, а не ошибка
+var fieldSoftLimits = map[string]int{
+	fieldTitle:       150,
+	fieldCompany:     150,
+	fieldSourceURL:   2000,
+	fieldSalary:      100,
+	fieldDescription: 20000,
+	fieldNotes:       5000,
+}
+
+// validVacancyURL проверяет, что ссылка - абсолютный адрес http(s)
+func validVacancyURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// validateVacancy проверяет поля вакансии. duplicate - есть ли в списке другая вакансия
+// с тем же названием и компанией; для новой вакансии это ошибка, при редактировании - предупреждение.
+func validateVacancy(v Vacancy, duplicate, isNew bool) []FieldIssue {
+	var issues []FieldIssue
+	add := func(field string, severity issueSeverity, message string) {
+		issues = append(issues, FieldIssue{Field: field, Severity: severity, Message: message})
+	}
+
+	if strings.TrimSpace(v.Title) == "" {
+		add(fieldTitle, issueError, "Название вакансии не может быть пустым.")
+	}
+	if strings.TrimSpace(v.Company) == "" {
+		add(fieldCompany, issueWarning, "Компания не указана - такую вакансию трудно будет отличить от похожих.")
+	}
+	if duplicate {
+		if isNew {
+			add(fieldTitle, issueError, "Эта вакансия уже есть в вашем локальном списке.")
+		} else {
+			add(fieldTitle, issueWarning, "В списке уже есть другая вакансия с таким названием и компанией.")
+		}
+	}
+	if u := strings.TrimSpace(v.SourceURL); u != "" && !validVacancyURL(u) {
+		add(fieldSourceURL, issueWarning, "Ссылка обычно начинается с http:// или https:// - проверьте адрес.")
+	}
+
+	values := map[string]string{
+		fieldTitle:       v.Title,
+		fieldCompany:     v.Company,
+		fieldSourceURL:   v.SourceURL,
+		fieldSalary:      v.Salary,
+		fieldDescription: v.Description,
+		fieldNotes:       v.Notes,
+	}
+	for _, field := range []string{fieldTitle, fieldCompany, fieldSourceURL, fieldSalary, fieldDescription, fieldNotes} {
+		if n, limit := utf8.RuneCountInString(values[field]), fieldSoftLimits[field]; n > limit {
+			add(field, issueWarning, fmt.Sprintf("Слишком длинный текст: %d символов при рекомендуемых %d.", n, limit))
+		}
+	}
+	return issues
+}
+
+// firstIssueError возвращает первую ошибку, блокирующую сохранение
+func firstIssueError(issues []FieldIssue) (FieldIssue, bool) {
+	for _, issue := range issues {
+		if issue.Severity == issueError {
+			return issue, true
+		}
+	}
+	return FieldIssue{}, false
+}
+
+// issueLabels возвращает подписи для сообщений под полями диалога
+func (dlg *AddVacancyDialog) issueLabels() map[string]*walk.Label {
+	return map[string]*walk.Label{
+		fieldTitle:       dlg.titleIssue,
+		fieldCompany:     dlg.companyIssue,
+		fieldSourceURL:   dlg.sourceURLIssue,
+		fieldSalary:      dlg.salaryIssue,
+		fieldDescription: dlg.descriptionIssue,
+		fieldNotes:       dlg.notesIssue,
+	}
+}
+
+// fieldWidget возвращает поле ввода, к которому относится проблема
+func (dlg *AddVacancyDialog) fieldWidget(field string) walk.Widget {
+	switch field {
+	case fieldCompany:
+		return dlg.companyLE
+	case fieldSourceURL:
+		return dlg.sourceURLLE
+	case fieldSalary:
+		return dlg.salaryLE
+	case fieldDescription:
+		return dlg.descriptionTE
+	case fieldNotes:
+		return dlg.notesTE
+	}
+	return dlg.titleLE
+}
+
+// validate проверяет текущие значения полей диалога
+func (dlg *AddVacancyDialog) validate(app *AppMainWindow) []FieldIssue {
+	v, ok := dlg.formVacancy()
+	if !ok {
+		return nil
+	}
+	idx := app.findVacancyIndexInAllExt(v.Title, v.Company)
+	duplicate := idx != -1
+	if dlg.isEdit && idx != -1 && idx == app.findVacancyIndexInAllExt(dlg.originalTitle, dlg.originalCompany) {
+		duplicate = false // Это та же самая вакансия
+	}
+	issues := validateVacancy(v, duplicate, !dlg.isEdit)
+
+	if !dlg.isEdit {
+		if blocked, ok := blockedCompany(v.Company); ok {
+			issues = append(issues, FieldIssue{Field: fieldCompany, Severity: issueWarning, Message: "Компания в вашем чёрном списке ('" + blocked + "')."})
+		}
+	}
+
+	// Недавний отказ в этой компании важен, пока отклик ещё не отправлен или только отправляется
+	if !dlg.isEdit || beforeApplying(v.Status) {
+		exclude := v.Title
+		if dlg.isEdit {
+			exclude = dlg.originalTitle
+		}
+		if r, ok := checkCompanyCooldown(v.Company, exclude); ok {
+			issues = append(issues, FieldIssue{Field: fieldCompany, Severity: issueWarning, Message: cooldownMessage(r, appSettings.RejectionCooldownMonths)})
+		}
+	}
+	return issues
+}
+
+// refreshIssues перепроверяет поля и показывает сообщения под ними.
+// Возвращает false, если есть ошибки, блокирующие сохранение.
+func (dlg *AddVacancyDialog) refreshIssues(app *AppMainWindow) bool {
+	issues := dlg.validate(app)
+	byField := map[string]FieldIssue{}
+	for _, issue := range issues {
+		// На поле показываем самую серьёзную проблему
+		if prev, ok := byField[issue.Field]; !ok || issue.Severity > prev.Severity {
+			byField[issue.Field] = issue
+		}
+	}
+	for field, label := range dlg.issueLabels() {
+		if label == nil {
+			continue
+		}
+		issue, ok := byField[field]
+		label.SetVisible(ok)
+		if !ok {
+			continue
+		}
+		prefix, color := "⚠ ", walk.RGB(190, 110, 0)
+		if issue.Severity == issueError {
+			prefix, color = "✖ ", walk.RGB(200, 0, 0)
+		}
+		label.SetText(prefix + issue.Message)
+		label.SetTextColor(color)
+	}
+	_, hasError := firstIssueError(issues)
+	return !hasError
+}
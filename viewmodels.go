@@ -0,0 +1,339 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/lxn/walk"
+)
+
+// Панели главного окна разделены на модели представления: каждая хранит свои виджеты
+// и сама заполняет их и применяет тему. AppMainWindow встраивает модели, поэтому
+// поля и методы панелей доступны через app напрямую.
+
+// SearchBarVM - строка поиска и фильтры над таблицей вакансий
+type SearchBarVM struct {
+	searchEdit         *walk.LineEdit
+	searchFieldCB      *walk.ComboBox
+	searchLabel        *walk.Label
+	statusFilterCB     *walk.ComboBox
+	experienceFilterCB *walk.ComboBox
+	searchButton       *walk.PushButton
+	searchHistoryCB    *walk.ComboBox
+	searchHistoryItems []SearchHistoryEntry // Записи истории в searchHistoryCB (после заголовка)
+}
+
+// DetailsVM - панель деталей выбранной вакансии
+type DetailsVM struct {
+	detailsGroup           *walk.GroupBox
+	detailsScrollView      *walk.ScrollView
+	detailTitleLabel       *walk.Label // For "Название:"
+	detailMatchesLabel     *walk.Label // "N совпадений" при поиске по тексту
+	detailTitleDisplay     *walk.Label // To display the title (non-editable in panel)
+	detailCompanyLabel     *walk.Label // For "Компания:"
+	detailCompanyDisplay   *walk.Label // To display the company (non-editable in panel)
+	detailStatusLabel      *walk.Label
+	detailStatusCB         *walk.ComboBox // Editable
+	detailExperienceLabel  *walk.Label
+	detailExperienceCB     *walk.ComboBox // Editable
+	detailKeywordsLabel    *walk.Label
+	detailKeywordsLE       *walk.LineEdit // Editable
+	detailKeywordsAC       *Autocomplete
+	detailSourceURLLabel   *walk.Label
+	detailSourceURLLE      *walk.LineEdit // Editable
+	checkPostingPB         *walk.PushButton
+	showQRPB               *walk.PushButton
+	detailSalaryLabel      *walk.Label
+	detailSalaryLE         *walk.LineEdit // Editable
+	detailDescriptionLabel *walk.Label
+	detailDescriptionTE    *RichTextEdit // Editable, с подсветкой найденного
+	detailNotesLabel       *walk.Label
+	detailNotesTE          *RichTextEdit    // Editable, с подсветкой найденного
+	saveVacancyChangesPB   *walk.PushButton // Button to save changes from details panel
+	detailsForm            *detailsFormData // Значения полей панели, связанные через detailsBinder
+	detailsBinder          *walk.DataBinder
+
+	detailResumeLabel    *walk.Label
+	detailResumeDisplay  *walk.Label
+	detailResumeDropArea *walk.Composite
+	detailResumeOpenBtn  *walk.PushButton
+	detailResumeClearBtn *walk.PushButton
+
+	detailRelatedLabel *walk.Label
+	detailRelatedLB    *walk.ListBox
+	relatedItems       []RelatedVacancy // Связанные вакансии, показанные в detailRelatedLB
+
+	followUpBar   *walk.Composite // "Нет ответа N дн." с кнопкой отметки follow-up
+	followUpLabel *walk.Label
+
+	detailTestTaskLabel *walk.Label
+	editTestTaskPB      *walk.PushButton
+	submitTestTaskPB    *walk.PushButton
+	openTestTaskPB      *walk.PushButton
+
+	attachmentsLabel *walk.Label
+	attachmentsStrip *walk.ScrollView        // Галерея миниатюр вложений
+	thumbnails       map[string]*walk.Bitmap // Миниатюры по имени файла вложения
+	attachmentMenus  []*walk.Menu            // Контекстные меню миниатюр, пересоздаются вместе с галереей
+	ocrRunning       bool
+
+	detailRemindersLB *walk.ListBox
+	reminderItems     []dueReminder // Напоминания, показанные в detailRemindersLB
+
+	detailJournalLB *walk.ListBox
+}
+
+// OnlineSearchVM - результаты онлайн-поиска с панелью предпросмотра
+type OnlineSearchVM struct {
+	onlineResultsContainer   *walk.Composite
+	onlineResultsLabel       *walk.Label
+	onlineSourcesLabel       *walk.Label       // Что ответил каждый источник
+	onlineProgressPB         *walk.ProgressBar // Сколько источников уже ответило
+	onlineFilterLE           *walk.LineEdit
+	onlinePreviewPanel       *walk.Composite
+	onlinePreviewTitle       *walk.Label
+	onlinePreviewCompany     *walk.Label
+	onlinePreviewSalary      *walk.Label
+	onlinePreviewLocation    *walk.Label
+	onlinePreviewSource      *walk.Label
+	onlinePreviewPosted      *walk.Label
+	onlinePreviewLink        *walk.LinkLabel
+	onlinePreviewTE          *walk.TextEdit
+	onlineResultsTable       *walk.TableView
+	onlineVacancyModel       *OnlineVacancyModel
+	backToLocalButton        *walk.PushButton
+	cancelOnlineSearchButton *walk.PushButton
+	addOnlineVacancyButton   *walk.PushButton
+
+	// Идущий онлайн-поиск, nil - поиск не идёт
+	onlineSearch *SearchSession
+}
+
+// query возвращает поле поиска и искомое значение в нижнем регистре.
+// Для поиска по статусу и опыту значение берётся из соответствующего списка.
+func (vm *SearchBarVM) query() (field, term string) {
+	field = searchFields[0]
+	if i := vm.searchFieldCB.CurrentIndex(); i >= 0 && i < len(searchFields) {
+		field = searchFields[i]
+	}
+	switch field {
+	case "По статусу":
+		term = vm.statusFilterCB.Text()
+	case "По опыту":
+		term = vm.experienceFilterCB.Text()
+	default:
+		term = vm.searchEdit.Text()
+	}
+	return field, strings.ToLower(term)
+}
+
+// setEnabled включает поиск по локальному списку или выключает его на время онлайн-поиска
+func (vm *SearchBarVM) setEnabled(enabled bool) {
+	if vm.searchEdit != nil {
+		vm.searchEdit.SetEnabled(enabled)
+	}
+	if vm.searchFieldCB != nil {
+		vm.searchFieldCB.SetEnabled(enabled)
+	}
+	if vm.searchButton != nil {
+		vm.searchButton.SetEnabled(enabled)
+	}
+}
+
+func (vm *SearchBarVM) applyTheme(theme Theme) {
+	themeLabels(theme, vm.searchLabel)
+	themeComboBoxes(theme, vm.searchFieldCB, vm.statusFilterCB, vm.experienceFilterCB)
+	themeLineEdits(theme, vm.searchEdit)
+	themeButtons(theme, vm.searchButton)
+}
+
+// bind показывает в панели выбранную вакансию; без выбора поля очищаются и блокируются.
+// online - есть ли сеть для проверки объявления на сайте.
+func (vm *DetailsVM) bind(v Vacancy, hasSelection, online bool) {
+	vm.bindForm(newDetailsFormData(v, hasSelection))
+	if vm.checkPostingPB != nil {
+		vm.checkPostingPB.SetEnabled(hasSelection && v.SourceURL != "" && online)
+	}
+	if vm.showQRPB != nil {
+		vm.showQRPB.SetEnabled(hasSelection)
+	}
+	if vm.detailResumeDisplay == nil {
+		return
+	}
+	hasResume := hasSelection && v.ResumeFileName != ""
+	switch {
+	case !hasSelection:
+		vm.detailResumeDisplay.SetText("Нет прикрепленного резюме")
+	case hasResume:
+		vm.detailResumeDisplay.SetText(v.ResumeFileName)
+	default:
+		vm.detailResumeDisplay.SetText("Перетащите файл резюме сюда")
+	}
+	if vm.detailResumeOpenBtn != nil {
+		vm.detailResumeOpenBtn.SetEnabled(hasResume)
+	}
+	if vm.detailResumeClearBtn != nil {
+		vm.detailResumeClearBtn.SetEnabled(hasResume && detailsEditable())
+	}
+}
+
+// bindForm заполняет поля панели из формы
+func (vm *DetailsVM) bindForm(form *detailsFormData) {
+	vm.detailsForm = form
+	if vm.detailsBinder == nil {
+		return
+	}
+	vm.detailsBinder.SetDataSource(form)
+	vm.detailsBinder.Reset()
+}
+
+// submit переносит правки из полей в форму
+func (vm *DetailsVM) submit() (*detailsFormData, error) {
+	if err := vm.detailsBinder.Submit(); err != nil {
+		return nil, err
+	}
+	return vm.detailsForm, nil
+}
+
+func (vm *DetailsVM) applyTheme(theme Theme) {
+	if vm.detailsScrollView != nil {
+		scrollBrush, _ := walk.NewSolidColorBrush(theme.Background)
+		defer scrollBrush.Dispose()
+		vm.detailsScrollView.SetBackground(scrollBrush)
+	}
+	if vm.detailsGroup != nil {
+		groupBrush, _ := walk.NewSolidColorBrush(theme.PanelBG)
+		defer groupBrush.Dispose()
+		vm.detailsGroup.SetBackground(groupBrush)
+	}
+	themeContainers(theme, vm.detailResumeDropArea)
+	themeButtons(theme, vm.saveVacancyChangesPB, vm.detailResumeOpenBtn, vm.detailResumeClearBtn)
+	themeLabels(theme,
+		vm.detailTitleLabel, vm.detailTitleDisplay, vm.detailCompanyLabel, vm.detailCompanyDisplay,
+		vm.detailStatusLabel, vm.detailExperienceLabel, vm.detailKeywordsLabel, vm.detailSourceURLLabel,
+		vm.detailSalaryLabel, vm.detailDescriptionLabel, vm.detailNotesLabel,
+		vm.detailResumeLabel, vm.detailResumeDisplay, vm.detailRelatedLabel)
+	themeComboBoxes(theme, vm.detailStatusCB, vm.detailExperienceCB)
+	themeLineEdits(theme, vm.detailKeywordsLE, vm.detailSourceURLLE, vm.detailSalaryLE)
+	for _, re := range []*RichTextEdit{vm.detailDescriptionTE, vm.detailNotesTE} {
+		if re != nil {
+			re.SetColors(theme.Text, theme.Background)
+		}
+	}
+}
+
+// updateOnlinePreview показывает выбранный онлайн-результат в панели предпросмотра
+func (vm *OnlineSearchVM) updateOnlinePreview() {
+	if vm.onlinePreviewTE == nil {
+		return
+	}
+	idx := vm.onlineResultsTable.CurrentIndex()
+	if idx < 0 || idx >= len(vm.onlineVacancyModel.items) {
+		vm.onlinePreviewTitle.SetText("Выберите вакансию в списке")
+		for _, l := range []*walk.Label{vm.onlinePreviewCompany, vm.onlinePreviewSalary, vm.onlinePreviewLocation, vm.onlinePreviewSource, vm.onlinePreviewPosted} {
+			l.SetText("")
+		}
+		vm.onlinePreviewLink.SetText("")
+		vm.onlinePreviewTE.SetText("")
+		return
+	}
+	v := vm.onlineVacancyModel.items[idx]
+
+	orDash := func(s string) string {
+		if strings.TrimSpace(s) == "" {
+			return "-"
+		}
+		return s
+	}
+	vm.onlinePreviewTitle.SetText(v.Title)
+	vm.onlinePreviewCompany.SetText(orDash(v.Company))
+	vm.onlinePreviewSalary.SetText(orDash(v.Salary))
+	vm.onlinePreviewLocation.SetText(orDash(v.Location))
+	vm.onlinePreviewSource.SetText(orDash(v.Source))
+	posted := "-"
+	if !v.PostedAt.IsZero() {
+		posted = v.PostedAt.Local().Format("02.01.2006")
+	}
+	vm.onlinePreviewPosted.SetText(posted)
+	link := ""
+	if validVacancyURL(v.SourceURL) {
+		link = `<a href="` + strings.ReplaceAll(v.SourceURL, `"`, "%22") + `">Открыть вакансию на сайте</a>`
+	}
+	vm.onlinePreviewLink.SetText(link)
+	vm.onlinePreviewTE.SetText(v.Description)
+}
+
+func (vm *OnlineSearchVM) applyTheme(theme Theme) {
+	themeContainers(theme, vm.onlineResultsContainer, vm.onlinePreviewPanel)
+	themeButtons(theme, vm.backToLocalButton, vm.cancelOnlineSearchButton)
+	themeTables(theme, vm.onlineResultsTable)
+	themeLabels(theme,
+		vm.onlineResultsLabel, vm.onlineSourcesLabel, vm.onlinePreviewTitle, vm.onlinePreviewCompany, vm.onlinePreviewSalary,
+		vm.onlinePreviewLocation, vm.onlinePreviewSource, vm.onlinePreviewPosted)
+	if vm.onlinePreviewTE != nil {
+		brush, _ := walk.NewSolidColorBrush(theme.Background)
+		defer brush.Dispose()
+		vm.onlinePreviewTE.SetBackground(brush)
+		vm.onlinePreviewTE.SetTextColor(theme.Text)
+	}
+}
+
+// Общие шаги применения темы к виджетам одного вида; nil пропускаются
+
+func themeContainers(theme Theme, containers ...*walk.Composite) {
+	brush, _ := walk.NewSolidColorBrush(theme.Background)
+	defer brush.Dispose()
+	for _, c := range containers {
+		if c != nil {
+			c.SetBackground(brush)
+		}
+	}
+}
+
+func themeButtons(theme Theme, buttons ...*walk.PushButton) {
+	brush, _ := walk.NewSolidColorBrush(theme.ButtonBG)
+	defer brush.Dispose()
+	for _, b := range buttons {
+		if b != nil {
+			b.SetBackground(brush)
+		}
+	}
+}
+
+func themeTables(theme Theme, tables ...*walk.TableView) {
+	brush, _ := walk.NewSolidColorBrush(theme.TableBG)
+	defer brush.Dispose()
+	for _, t := range tables {
+		if t != nil {
+			t.SetBackground(brush)
+		}
+	}
+}
+
+func themeLabels(theme Theme, labels ...*walk.Label) {
+	for _, l := range labels {
+		if l != nil {
+			l.SetTextColor(theme.Text)
+		}
+	}
+}
+
+func themeComboBoxes(theme Theme, comboBoxes ...*walk.ComboBox) {
+	brush, _ := walk.NewSolidColorBrush(theme.ButtonBG)
+	defer brush.Dispose()
+	for _, cb := range comboBoxes {
+		if cb != nil {
+			cb.SetBackground(brush)
+		}
+	}
+}
+
+func themeLineEdits(theme Theme, lineEdits ...*walk.LineEdit) {
+	brush, _ := walk.NewSolidColorBrush(theme.Background)
+	defer brush.Dispose()
+	for _, le := range lineEdits {
+		if le != nil {
+			le.SetBackground(brush)
+			le.SetTextColor(theme.Text)
+		}
+	}
+}
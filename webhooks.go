@@ -0,0 +1,399 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// Webhook - адрес, на который отправляется запрос при смене статуса вакансии
+type Webhook struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	Template string `json:"template"`         // Тело запроса, шаблон text/template
+	Status   string `json:"status,omitempty"` // На какой статус срабатывать; пусто - на любой
+	Enabled  bool   `json:"enabled"`
+}
+
+// webhookPayload - данные, доступные в шаблоне тела запроса
+type webhookPayload struct {
+	Title     string
+	Company   string
+	OldStatus string
+	Status    string
+	SourceURL string
+	Salary    string
+	ChangedAt string // Время смены в формате RFC 3339
+}
+
+// Готовые шаблоны тела запроса
+var webhookPresets = []struct {
+	Name     string
+	Template string
+}{
+	{"JSON", `{"title": {{json .Title}}, "company": {{json .Company}}, "old_status": {{json .OldStatus}}, "status": {{json .Status}}, "url": {{json .SourceURL}}, "salary": {{json .Salary}}, "changed_at": {{json .ChangedAt}}}`},
+	{"Slack / Mattermost", `{"text": {{json (printf "%s — %s: %s → %s" .Title .Company .OldStatus .Status)}}}`},
+	{"Discord", `{"content": {{json (printf "%s — %s: %s → %s" .Title .Company .OldStatus .Status)}}}`},
+}
+
+// Статусы для выбора в настройке: первый пункт - любой статус
+var webhookStatusOptions = append([]string{"Любой статус"}, possibleStatuses...)
+
+// Ограничение частоты общее для всех адресов, чтобы массовая смена статусов не заваливала сервис
+var webhookProvider = &searchProvider{Name: "webhook", limiter: &rateLimiter{interval: 200 * time.Millisecond}}
+
+const webhookTimeout = time.Minute
+
+var webhookTemplateFuncs = template.FuncMap{
+	// json превращает значение в литерал JSON с экранированием
+	"json": func(v any) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+}
+
+// renderWebhook подставляет данные в шаблон тела запроса
+func renderWebhook(tmpl string, p webhookPayload) ([]byte, error) {
+	t, err := template.New("webhook").Funcs(webhookTemplateFuncs).Parse(tmpl)
+	if err != nil {
+		return nil, fmt.Errorf("ошибка в шаблоне: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, p); err != nil {
+		return nil, fmt.Errorf("ошибка в шаблоне: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// sendWebhook отправляет POST-запрос с телом по шаблону
+func sendWebhook(ctx context.Context, w Webhook, p webhookPayload) error {
+	body, err := renderWebhook(w.Template, p)
+	if err != nil {
+		return err
+	}
+	contentType := "text/plain; charset=utf-8"
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		contentType = "application/json"
+	}
+	respBody, status, err := fetchWithRetry(ctx, webhookProvider, func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Content-Type", contentType)
+		return req, nil
+	})
+	if err != nil {
+		return err
+	}
+	if status < 200 || status >= 300 {
+		return fmt.Errorf("сервер ответил %d: %s", status, strings.TrimSpace(truncateRunes(string(respBody), 200)))
+	}
+	return nil
+}
+
+// truncateRunes обрезает строку до n символов
+func truncateRunes(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n]) + "..."
+}
+
+// webhookPayloadFor - данные шаблона для смены статуса
+func webhookPayloadFor(c statusChange) webhookPayload {
+	v := c.Vacancy
+	return webhookPayload{
+		Title:     v.Title,
+		Company:   v.Company,
+		OldStatus: c.OldStatus,
+		Status:    v.Status,
+		SourceURL: v.SourceURL,
+		Salary:    v.Salary,
+		ChangedAt: time.Now().Format(time.RFC3339),
+	}
+}
+
+// subscribeWebhooks отправляет веб-хуки при смене статусов вакансий
+func (app *AppMainWindow) subscribeWebhooks() {
+	statuses := newStatusTracker(snapshotVacancies())
+	vacancyEvents.Subscribe(func(VacancyEvent) {
+		changes := statuses.update(snapshotVacancies())
+		if len(changes) == 0 {
+			return
+		}
+		hooks := slices.Clone(appSettings.Webhooks)
+		go func() {
+			for _, c := range changes {
+				for _, w := range hooks {
+					if !w.Enabled || (w.Status != "" && w.Status != c.Vacancy.Status) {
+						continue
+					}
+					ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+					err := sendWebhook(ctx, w, webhookPayloadFor(c))
+					cancel()
+					if err != nil {
+						log.Printf("Ошибка веб-хука %s: %v", w.Name, err)
+						app.Synchronize(func() {
+							app.showToast("Веб-хук не отправлен", w.Name+": "+err.Error(), app.showWebhooksDialog)
+						})
+					}
+				}
+			}
+		}()
+	})
+}
+
+// showWebhooksDialog настраивает веб-хуки
+func (app *AppMainWindow) showWebhooksDialog() {
+	var dlg *walk.Dialog
+	var hooksLB *walk.ListBox
+	var nameLE, urlLE *walk.LineEdit
+	var statusCB, presetCB *walk.ComboBox
+	var templateTE *walk.TextEdit
+	var enabledCB *walk.CheckBox
+	var editor *walk.Composite
+	var acceptPB, cancelPB *walk.PushButton
+
+	hooks := slices.Clone(appSettings.Webhooks)
+	current := -1
+
+	names := func() []string {
+		list := make([]string, len(hooks))
+		for i, w := range hooks {
+			list[i] = w.Name
+			if !w.Enabled {
+				list[i] += " (выключен)"
+			}
+		}
+		return list
+	}
+	// store переносит поля редактора в выбранный веб-хук
+	store := func() {
+		if current < 0 || current >= len(hooks) {
+			return
+		}
+		w := &hooks[current]
+		w.Name = strings.TrimSpace(nameLE.Text())
+		if w.Name == "" {
+			w.Name = "Веб-хук"
+		}
+		w.URL = strings.TrimSpace(urlLE.Text())
+		w.Template = templateTE.Text()
+		w.Status = ""
+		if i := statusCB.CurrentIndex(); i > 0 {
+			w.Status = webhookStatusOptions[i]
+		}
+		w.Enabled = enabledCB.Checked()
+	}
+	// load показывает веб-хук в редакторе
+	load := func(i int) {
+		current = i
+		editor.SetEnabled(i >= 0)
+		if i < 0 {
+			nameLE.SetText("")
+			urlLE.SetText("")
+			templateTE.SetText("")
+			statusCB.SetCurrentIndex(0)
+			enabledCB.SetChecked(false)
+			return
+		}
+		w := hooks[i]
+		nameLE.SetText(w.Name)
+		urlLE.SetText(w.URL)
+		templateTE.SetText(w.Template)
+		statusCB.SetCurrentIndex(indexOfString(webhookStatusOptions, w.Status))
+		enabledCB.SetChecked(w.Enabled)
+	}
+	refreshList := func(selected int) {
+		hooksLB.SetModel(names())
+		hooksLB.SetCurrentIndex(selected)
+		load(selected)
+	}
+
+	test := func() {
+		store()
+		if current < 0 {
+			return
+		}
+		w := hooks[current]
+		if !validVacancyURL(w.URL) {
+			walk.MsgBox(dlg, "Веб-хуки", "Укажите адрес http(s).", walk.MsgBoxIconWarning)
+			return
+		}
+		sample := statusChange{
+			Vacancy:   Vacancy{Title: "Go-разработчик", Company: "Пример", Status: possibleStatuses[2], SourceURL: "https://example.com/vacancy"},
+			OldStatus: possibleStatuses[0],
+		}
+		dlg.SetEnabled(false)
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), webhookTimeout)
+			err := sendWebhook(ctx, w, webhookPayloadFor(sample))
+			cancel()
+			dlg.Synchronize(func() {
+				dlg.SetEnabled(true)
+				if err != nil {
+					showError(dlg, "Проверка веб-хука", err)
+					return
+				}
+				app.notify("Тестовый запрос веб-хука отправлен.")
+			})
+		}()
+	}
+
+	presetNames := make([]string, len(webhookPresets))
+	for i, p := range webhookPresets {
+		presetNames[i] = p.Name
+	}
+
+	if err := (Dialog{
+		AssignTo:      &dlg,
+		Title:         "Веб-хуки",
+		DefaultButton: &acceptPB,
+		CancelButton:  &cancelPB,
+		MinSize:       Size{Width: 720, Height: 460},
+		Layout:        VBox{Margins: Margins{Top: 10, Left: 10, Right: 10, Bottom: 10}, Spacing: 8},
+		Background:    SolidColorBrush{Color: currentTheme.Background},
+		Children: []Widget{
+			Label{
+				Text:      "При смене статуса вакансии на адрес отправляется POST-запрос с телом по шаблону.\nВ шаблоне доступны {{.Title}}, {{.Company}}, {{.OldStatus}}, {{.Status}}, {{.SourceURL}}, {{.Salary}}, {{.ChangedAt}}; {{json .Title}} экранирует значение для JSON.",
+				TextColor: currentTheme.Text,
+				Font:      Font{PointSize: 9},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true, Spacing: 8},
+				Children: []Widget{
+					Composite{
+						Layout:  VBox{MarginsZero: true},
+						MaxSize: Size{Width: 200},
+						Children: []Widget{
+							ListBox{
+								AssignTo: &hooksLB,
+								Model:    names(),
+								OnCurrentIndexChanged: func() {
+									store()
+									load(hooksLB.CurrentIndex())
+								},
+							},
+							Composite{
+								Layout: HBox{MarginsZero: true},
+								Children: []Widget{
+									PushButton{
+										Text:       "Добавить",
+										Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+										OnClicked: func() {
+											store()
+											hooks = append(hooks, Webhook{Name: "Веб-хук", Template: webhookPresets[0].Template, Enabled: true})
+											refreshList(len(hooks) - 1)
+											nameLE.SetFocus()
+										},
+									},
+									PushButton{
+										Text:       "Удалить",
+										Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+										OnClicked: func() {
+											if current < 0 {
+												return
+											}
+											hooks = slices.Delete(hooks, current, current+1)
+											current = -1
+											refreshList(min(hooksLB.CurrentIndex(), len(hooks)-1))
+										},
+									},
+								},
+							},
+						},
+					},
+					Composite{
+						AssignTo: &editor,
+						Enabled:  false,
+						Layout:   Grid{Columns: 2, MarginsZero: true},
+						Children: []Widget{
+							Label{Text: "Название:", TextColor: currentTheme.Text},
+							LineEdit{AssignTo: &nameLE},
+							Label{Text: "Адрес:", TextColor: currentTheme.Text},
+							LineEdit{AssignTo: &urlLE, CueBanner: "https://hooks.slack.com/services/..."},
+							Label{Text: "Срабатывать на:", TextColor: currentTheme.Text},
+							ComboBox{AssignTo: &statusCB, Model: webhookStatusOptions, CurrentIndex: 0},
+							Label{Text: "Шаблон:", TextColor: currentTheme.Text},
+							ComboBox{
+								AssignTo:     &presetCB,
+								Model:        presetNames,
+								CurrentIndex: -1,
+								ToolTipText:  "Подставить готовый шаблон",
+								OnCurrentIndexChanged: func() {
+									if i := presetCB.CurrentIndex(); i >= 0 {
+										templateTE.SetText(webhookPresets[i].Template)
+									}
+								},
+							},
+							TextEdit{AssignTo: &templateTE, ColumnSpan: 2, VScroll: true, Font: Font{Family: "Consolas", PointSize: 9}},
+							CheckBox{AssignTo: &enabledCB, ColumnSpan: 2, Text: "Включён"},
+							PushButton{
+								ColumnSpan: 2,
+								Text:       "Отправить тестовый запрос",
+								Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+								OnClicked:  test,
+							},
+						},
+					},
+				},
+			},
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					HSpacer{},
+					PushButton{
+						AssignTo:   &acceptPB,
+						Text:       "Сохранить",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							store()
+							for i, w := range hooks {
+								if !validVacancyURL(w.URL) {
+									refreshList(i)
+									walk.MsgBox(dlg, "Веб-хуки", "У веб-хука «"+w.Name+"» не указан адрес http(s).", walk.MsgBoxIconWarning)
+									return
+								}
+								if _, err := renderWebhook(w.Template, webhookPayload{}); err != nil {
+									refreshList(i)
+									walk.MsgBox(dlg, "Веб-хуки", w.Name+": "+err.Error(), walk.MsgBoxIconWarning)
+									return
+								}
+							}
+							appSettings.Webhooks = hooks
+							saveSettings()
+							dlg.Accept()
+						},
+					},
+					PushButton{
+						AssignTo:   &cancelPB,
+						Text:       "Отмена",
+						Background: SolidColorBrush{Color: currentTheme.ButtonBG},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { dlg.Cancel() },
+					},
+				},
+			},
+		},
+	}).Create(app.MainWindow); err != nil {
+		log.Print("Dialog error: ", err)
+		return
+	}
+	if len(hooks) > 0 {
+		refreshList(0)
+	}
+	dlg.Run()
+}
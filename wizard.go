@@ -0,0 +1,311 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/lxn/walk"
+	. "github.com/lxn/walk/declarative"
+)
+
+// UserProfile - профиль соискателя, заполняется в мастере первого запуска
+type UserProfile struct {
+	Name            string   `json:"name,omitempty"`
+	DesiredPosition string   `json:"desired_position,omitempty"`
+	Location        string   `json:"location,omitempty"`
+	Skills          []string `json:"skills,omitempty"`
+	MatchKeywords   []string `json:"match_keywords,omitempty"` // Слова, по которым оценивается совпадение вакансии с профилем
+	Highlights      []string `json:"highlights,omitempty"`     // Достижения для резюме, по одному на строку
+}
+
+// InterfaceLanguage - язык интерфейса, доступный для выбора
+type InterfaceLanguage struct {
+	Code string
+	Name string
+}
+
+// Пока интерфейс есть только на русском
+var interfaceLanguages = []InterfaceLanguage{
+	{Code: "ru", Name: "Русский"},
+}
+
+// Заголовки шагов мастера первого запуска
+var wizardStepTitles = []string{
+	"Папка для данных",
+	"Язык и тема",
+	"Ключи API",
+	"Импорт данных",
+	"Ваш профиль",
+}
+
+// FirstRunWizard - пошаговый мастер первого запуска
+type FirstRunWizard struct {
+	*walk.Dialog
+	pages     [5]*walk.Composite
+	step      int
+	stepLabel *walk.Label
+	backPB    *walk.PushButton
+	nextPB    *walk.PushButton
+
+	dataDirLE  *walk.LineEdit
+	languageCB *walk.ComboBox
+	themeCB    *walk.ComboBox
+	apiKeyLE   *walk.LineEdit
+	importLE   *walk.LineEdit
+	nameLE     *walk.LineEdit
+	positionLE *walk.LineEdit
+	locationLE *walk.LineEdit
+	skillsLE   *walk.LineEdit
+
+	imported []Vacancy
+}
+
+// showPage показывает шаг мастера с номером step
+func (w *FirstRunWizard) showPage(step int) {
+	w.step = step
+	for i, page := range w.pages {
+		page.SetVisible(i == step)
+	}
+	w.stepLabel.SetText(fmt.Sprintf("Шаг %d из %d: %s", step+1, len(w.pages), wizardStepTitles[step]))
+	w.backPB.SetEnabled(step > 0)
+	if step == len(w.pages)-1 {
+		w.nextPB.SetText("Готово")
+	} else {
+		w.nextPB.SetText("Далее")
+	}
+}
+
+// validateStep проверяет текущий шаг перед переходом к следующему
+func (w *FirstRunWizard) validateStep() bool {
+	switch w.step {
+	case 0:
+		dir := strings.TrimSpace(w.dataDirLE.Text())
+		if dir == "" {
+			walk.MsgBox(w, "Папка для данных", "Укажите папку, в которой будут храниться вакансии.", walk.MsgBoxIconWarning)
+			return false
+		}
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			showError(w, "Папка для данных", wrapError("Не удалось создать папку", err))
+			return false
+		}
+	case 3:
+		w.imported = nil
+		path := strings.TrimSpace(w.importLE.Text())
+		if path == "" {
+			return true
+		}
+		vacancies, err := readVacanciesForImport(path)
+		if err != nil {
+			showError(w, "Импорт данных", wrapError("Не удалось прочитать файл", err))
+			return false
+		}
+		w.imported = vacancies
+		walk.MsgBox(w, "Импорт данных", fmt.Sprintf("В файле найдено вакансий: %d. Они будут добавлены по завершении настройки.", len(vacancies)), walk.MsgBoxIconInformation)
+	}
+	return true
+}
+
+// apply сохраняет выбранные в мастере настройки
+func (w *FirstRunWizard) apply() {
+	dir := strings.TrimSpace(w.dataDirLE.Text())
+	if cwd, err := os.Getwd(); err == nil && filepath.Clean(dir) == filepath.Clean(cwd) {
+		dir = "" // Папка приложения - путь не сохраняем, чтобы данные можно было переносить вместе с ним
+	}
+	appSettings.DataDir = dir
+
+	if i := w.languageCB.CurrentIndex(); i >= 0 {
+		appSettings.Language = interfaceLanguages[i].Code
+	}
+	appSettings.ThemeName = w.themeCB.Text()
+	appSettings.JoobleAPIKey = strings.TrimSpace(w.apiKeyLE.Text())
+
+	var skills []string
+	for _, s := range strings.Split(w.skillsLE.Text(), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			skills = append(skills, s)
+		}
+	}
+	appSettings.Profile = UserProfile{
+		Name:            strings.TrimSpace(w.nameLE.Text()),
+		DesiredPosition: strings.TrimSpace(w.positionLE.Text()),
+		Location:        strings.TrimSpace(w.locationLE.Text()),
+		Skills:          skills,
+	}
+	saveSettings()
+}
+
+// wizardPage оформляет содержимое одного шага мастера
+func wizardPage(page **walk.Composite, hint string, children ...Widget) Widget {
+	return Composite{
+		AssignTo: page,
+		Visible:  false,
+		Layout:   VBox{MarginsZero: true, Spacing: 6},
+		Children: append([]Widget{
+			Label{Text: hint, TextColor: currentTheme.Text, Font: Font{PointSize: 9}},
+			VSpacer{Size: 6},
+		}, append(children, VSpacer{})...),
+	}
+}
+
+// wizardField - подпись и поле ввода на странице мастера
+func wizardField(label string, le **walk.LineEdit, text string) []Widget {
+	return []Widget{
+		Label{Text: label, TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+		LineEdit{AssignTo: le, Text: text, Font: Font{PointSize: 9}},
+	}
+}
+
+// showFirstRunWizard проводит пользователя через начальную настройку и загружает вакансии
+// из выбранной папки. Если мастер закрыт, остаются настройки по умолчанию.
+func showFirstRunWizard() {
+	w := &FirstRunWizard{}
+
+	defaultDir, err := os.Getwd()
+	if err != nil {
+		defaultDir = "."
+	}
+	languageNames := make([]string, len(interfaceLanguages))
+	for i, l := range interfaceLanguages {
+		languageNames[i] = l.Name
+	}
+
+	profilePage := []Widget{}
+	profilePage = append(profilePage, wizardField("Имя:", &w.nameLE, "")...)
+	profilePage = append(profilePage, wizardField("Желаемая должность:", &w.positionLE, "")...)
+	profilePage = append(profilePage, wizardField("Город:", &w.locationLE, "")...)
+	profilePage = append(profilePage, wizardField("Навыки (через запятую):", &w.skillsLE, "")...)
+
+	accepted := false
+	if err := (Dialog{
+		AssignTo: &w.Dialog,
+		Title:    "Добро пожаловать в Поисковик Вакансий!",
+		MinSize:  Size{Width: 520, Height: 380},
+		Layout:   VBox{Margins: Margins{Top: 15, Left: 20, Right: 20, Bottom: 15}, Spacing: 10},
+		Children: []Widget{
+			Label{AssignTo: &w.stepLabel, Font: Font{PointSize: 12, Bold: true}},
+			wizardPage(&w.pages[0],
+				"Это приложение поможет вам управлять личным списком вакансий и искать новые возможности онлайн.\nВыберите, где хранить вакансии, резервные копии и резюме.",
+				Composite{
+					Layout: HBox{MarginsZero: true},
+					Children: []Widget{
+						LineEdit{AssignTo: &w.dataDirLE, Text: defaultDir, Font: Font{PointSize: 9}},
+						PushButton{
+							Text: "Обзор...",
+							OnClicked: func() {
+								fd := new(walk.FileDialog)
+								fd.Title = "Папка для данных"
+								fd.InitialDirPath = w.dataDirLE.Text()
+								if ok, err := fd.ShowBrowseFolder(w); err == nil && ok {
+									w.dataDirLE.SetText(fd.FilePath)
+								}
+							},
+						},
+					},
+				},
+			),
+			wizardPage(&w.pages[1],
+				"Настройте внешний вид. Тему можно будет переключить кнопкой в главном окне.",
+				Label{Text: "Язык интерфейса:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+				ComboBox{AssignTo: &w.languageCB, Model: languageNames, CurrentIndex: 0, Font: Font{PointSize: 9}},
+				Label{Text: "Тема:", TextColor: currentTheme.Text, Font: Font{Bold: true, PointSize: 9}},
+				ComboBox{AssignTo: &w.themeCB, Model: themeNames(), CurrentIndex: 0, Font: Font{PointSize: 9}},
+			),
+			wizardPage(&w.pages[2],
+				"Онлайн-поиск работает через Jooble API. Можно оставить поле пустым и пользоваться общим ключом\nили указать свой, полученный на jooble.org/api/about.",
+				wizardField("Ключ Jooble API:", &w.apiKeyLE, "")...,
+			),
+			wizardPage(&w.pages[3],
+				"Если у вас уже есть список вакансий, его можно загрузить сейчас: файл vacancies.json\nиз другой копии приложения или таблицу CSV (первая строка - заголовки столбцов).",
+				Composite{
+					Layout: HBox{MarginsZero: true},
+					Children: []Widget{
+						LineEdit{AssignTo: &w.importLE, Font: Font{PointSize: 9}},
+						PushButton{
+							Text: "Обзор...",
+							OnClicked: func() {
+								fd := new(walk.FileDialog)
+								fd.Title = "Импорт вакансий"
+								fd.Filter = "Вакансии (*.json;*.csv)|*.json;*.csv|Все файлы (*.*)|*.*"
+								if ok, err := fd.ShowOpen(w); err == nil && ok {
+									w.importLE.SetText(fd.FilePath)
+								}
+							},
+						},
+					},
+				},
+			),
+			wizardPage(&w.pages[4],
+				"Расскажите немного о себе. Всё это необязательно и хранится только на вашем компьютере.",
+				profilePage...,
+			),
+			Composite{
+				Layout: HBox{MarginsZero: true},
+				Children: []Widget{
+					PushButton{
+						Text:       "Пропустить настройку",
+						Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+						Font:       Font{Family: "Segoe UI", PointSize: 10},
+						OnClicked:  func() { w.Cancel() },
+					},
+					HSpacer{},
+					PushButton{
+						AssignTo:   &w.backPB,
+						Text:       "Назад",
+						Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked:  func() { w.showPage(w.step - 1) },
+					},
+					PushButton{
+						AssignTo:   &w.nextPB,
+						Text:       "Далее",
+						Background: SolidColorBrush{Color: walk.RGB(235, 235, 235)},
+						Font:       Font{Family: "Segoe UI", PointSize: 10, Bold: true},
+						OnClicked: func() {
+							if !w.validateStep() {
+								return
+							}
+							if w.step < len(w.pages)-1 {
+								w.showPage(w.step + 1)
+								return
+							}
+							accepted = true
+							w.Accept()
+						},
+					},
+				},
+			},
+		},
+	}).Create(nil); err != nil {
+		log.Printf("Ошибка отображения мастера первого запуска: %v", err)
+		loadVacancies()
+		return
+	}
+	w.showPage(0)
+	w.Run()
+
+	if accepted {
+		w.apply()
+		currentTheme = themeByName(appSettings.ThemeName)
+		if len(w.imported) > 0 {
+			if _, err := os.Stat(dataPath(vacanciesFile)); os.IsNotExist(err) {
+				// Пользователь принёс свои вакансии - примеры не нужны
+				allVacancies = []Vacancy{}
+				saveVacancies()
+			}
+		}
+	}
+
+	loadVacancies()
+	var added, merged, skipped int
+	runWithProgress(nil, "Импорт вакансий", func(ctx context.Context) error {
+		added, merged, skipped = mergeImportedVacancies(ctx, w.imported)
+		return nil
+	})
+	if added+merged+skipped > 0 {
+		log.Printf("Импортировано вакансий при первом запуске: %d, объединено с имеющимися: %d, пропущено дубликатов: %d", added, merged, skipped)
+	}
+}
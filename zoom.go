@@ -0,0 +1,107 @@
+package main
+
+import (
+	"github.com/lxn/walk"
+	"github.com/lxn/win"
+)
+
+// Масштаб для чтения длинных описаний: Ctrl+колесо и Ctrl+плюс/минус увеличивают
+// и уменьшают текст описания и заметок или таблицу вакансий (вместе со шрифтом растёт
+// высота строк), Ctrl+0 возвращает 100%. Масштаб сохраняется в настройках.
+
+// ZoomSettings - масштаб в процентах, 0 - 100%
+type ZoomSettings struct {
+	Editors int `json:"editors,omitempty"` // Описание и заметки в панели деталей
+	Table   int `json:"table,omitempty"`   // Таблица вакансий
+}
+
+const (
+	zoomStep = 10
+	zoomMin  = 50
+	zoomMax  = 300
+)
+
+// zoomPercent - масштаб из настроек в процентах
+func zoomPercent(z int) int {
+	if z == 0 {
+		return 100
+	}
+	return min(max(z, zoomMin), zoomMax)
+}
+
+// nextZoom - масштаб после шага: +1 - крупнее, -1 - мельче, 0 - сбросить
+func nextZoom(z, step int) int {
+	if step == 0 {
+		return 0
+	}
+	z = zoomPercent(zoomPercent(z) + step*zoomStep)
+	if z == 100 {
+		return 0
+	}
+	return z
+}
+
+// wheelZoomStep - шаг масштаба по направлению прокрутки колеса из wParam WM_MOUSEWHEEL
+func wheelZoomStep(wParam uintptr) int {
+	if int16(win.HIWORD(uint32(wParam))) > 0 {
+		return 1
+	}
+	return -1
+}
+
+// keyZoomStep - шаг масштаба для клавиши, нажатой вместе с Ctrl
+func keyZoomStep(key walk.Key) (int, bool) {
+	switch key {
+	case walk.KeyOEMPlus, walk.KeyAdd:
+		return 1, true
+	case walk.KeyOEMMinus, walk.KeySubtract:
+		return -1, true
+	case walk.Key0, walk.KeyNumpad0:
+		return 0, true
+	}
+	return 0, false
+}
+
+// zoomEditors меняет масштаб описания и заметок
+func (app *AppMainWindow) zoomEditors(step int) {
+	appSettings.Zoom.Editors = nextZoom(appSettings.Zoom.Editors, step)
+	for _, re := range []*RichTextEdit{app.detailDescriptionTE, app.detailNotesTE} {
+		re.SetZoom(zoomPercent(appSettings.Zoom.Editors))
+	}
+	saveSettings()
+}
+
+// zoomTable меняет масштаб таблицы вакансий
+func (app *AppMainWindow) zoomTable(step int) {
+	appSettings.Zoom.Table = nextZoom(appSettings.Zoom.Table, step)
+	applyRegionFont(app.vacancyTable, appSettings.Fonts.Table, zoomPercent(appSettings.Zoom.Table))
+	saveSettings()
+}
+
+// attachEditorZoomHandlers подключает масштабирование к описанию и заметкам панели деталей
+func (app *AppMainWindow) attachEditorZoomHandlers() {
+	for _, re := range []*RichTextEdit{app.detailDescriptionTE, app.detailNotesTE} {
+		if re != nil {
+			re.ZoomRequested().Attach(app.zoomEditors)
+		}
+	}
+}
+
+// attachZoomHandlers подключает масштабирование к таблице вакансий; редакторы
+// подключает ensureDetails вместе с панелью деталей
+func (app *AppMainWindow) attachZoomHandlers() {
+	if app.vacancyTable == nil {
+		return
+	}
+	// Колесо таблица всё равно прокрутит: WM_MOUSEWHEEL до нас не перехватить
+	app.vacancyTable.MouseWheel().Attach(func(x, y int, button walk.MouseButton) {
+		if win.LOWORD(uint32(button))&win.MK_CONTROL != 0 {
+			app.zoomTable(wheelZoomStep(uintptr(button)))
+		}
+	})
+	app.vacancyTable.KeyDown().Attach(func(key walk.Key) {
+		if step, ok := keyZoomStep(key); ok && walk.ModifiersDown() == walk.ModControl {
+			app.zoomTable(step)
+		}
+	})
+}